@@ -82,6 +82,9 @@ func ErrorDisplay(err error) string {
 	if errors.Is(err, ErrTokenError) {
 		return "token error"
 	}
+	if errors.Is(err, ErrTokenKeyUnavailable) {
+		return "token encryption key unavailable"
+	}
 	switch x := err.(type) {
 	case sqlite3.Error:
 		return "database error"
@@ -2,6 +2,7 @@ package app_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
@@ -28,6 +29,11 @@ func TestError(t *testing.T) {
 		got := app.ErrorDisplay(err)
 		xassert.Equal(t, "general error", got)
 	})
+	t.Run("should return token key error", func(t *testing.T) {
+		err := fmt.Errorf("create token source: %w", app.ErrTokenKeyUnavailable)
+		got := app.ErrorDisplay(err)
+		xassert.Equal(t, "token encryption key unavailable", got)
+	})
 	// t.Run("should resolve goesi errors", func(t *testing.T) {
 	// 	// given
 	// 	httpmock.Activate()
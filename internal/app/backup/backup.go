@@ -0,0 +1,557 @@
+// Package backup provides backups of the user data and restoring user data from backups.
+//
+// A backup is a ZIP archive with a consistent snapshot of the database
+// and a copy of the app's settings.
+//
+// A database can not be replaced while the app is using it.
+// Restoring a backup is therefore done in two steps:
+// First a backup is validated and staged for restore with [Backup.PrepareRestore].
+// Then it is applied with [ApplyPendingRestore] during the next start of the app,
+// before the database is opened.
+package backup
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+)
+
+const (
+	archiveDBName          = "evebuddy.sqlite"
+	archivePreferencesName = "preferences.json"
+	fileExtension          = ".zip"
+	filePrefix             = "evebuddy-backup-"
+	fileTimeFormat         = "20060102-150405.000"
+	folderName             = "backups"
+	restoreFolderName      = "restore-pending"
+)
+
+// Settings provides the configuration for automatic backups.
+type Settings interface {
+	BackupAutoEnabled() bool
+	BackupExcludeCache() bool
+	BackupIntervalHours() int
+	BackupRetention() int
+}
+
+type Params struct {
+	DB              *sql.DB // database to backup
+	DataDir         string  // folder with the user data
+	PreferencesPath string  // path to the settings file
+	Settings        Settings
+}
+
+// Backup is a service for creating backups and restoring from them.
+type Backup struct {
+	dataDir         string
+	db              *sql.DB
+	mu              sync.Mutex // ensures only one backup is created at a time
+	preferencesPath string
+	settings        Settings
+}
+
+// New returns a new backup service.
+func New(arg Params) *Backup {
+	if arg.DB == nil {
+		panic("backup: missing DB")
+	}
+	if arg.DataDir == "" {
+		panic("backup: missing data dir")
+	}
+	if arg.Settings == nil {
+		panic("backup: missing settings")
+	}
+	b := &Backup{
+		dataDir:         arg.DataDir,
+		db:              arg.DB,
+		preferencesPath: arg.PreferencesPath,
+		settings:        arg.Settings,
+	}
+	return b
+}
+
+// Dir returns the path to the backup folder for a data folder.
+func Dir(dataDir string) string {
+	return filepath.Join(dataDir, folderName)
+}
+
+// Dir returns the path to the folder where backups are stored.
+func (b *Backup) Dir() string {
+	return Dir(b.dataDir)
+}
+
+// Info describes a backup file.
+type Info struct {
+	CreatedAt time.Time
+	Name      string
+	Path      string
+	Size      int64
+}
+
+// List returns all backups, newest first.
+func (b *Backup) List() ([]Info, error) {
+	entries, err := os.ReadDir(b.Dir())
+	if errors.Is(err, os.ErrNotExist) {
+		return []Info{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	items := make([]Info, 0)
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, filePrefix) || filepath.Ext(name) != fileExtension {
+			continue
+		}
+		createdAt, err := time.ParseInLocation(
+			fileTimeFormat,
+			strings.TrimSuffix(strings.TrimPrefix(name, filePrefix), fileExtension),
+			time.UTC,
+		)
+		if err != nil {
+			continue // not a backup created by the app
+		}
+		fi, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, Info{
+			CreatedAt: createdAt,
+			Name:      name,
+			Path:      filepath.Join(b.Dir(), name),
+			Size:      fi.Size(),
+		})
+	}
+	slices.SortFunc(items, func(a, b Info) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return items, nil
+}
+
+// Create creates a new backup and returns its path.
+// When excludeCache is true, the cache incl. the HTTP cache for ESI
+// is not included in the backup, which makes it much smaller.
+func (b *Backup) Create(ctx context.Context, excludeCache bool) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	p, err := b.create(ctx, excludeCache)
+	if err != nil {
+		return "", fmt.Errorf("create backup: %w", err)
+	}
+	slog.Info("Backup created", "path", p, "excludeCache", excludeCache)
+	return p, nil
+}
+
+func (b *Backup) create(ctx context.Context, excludeCache bool) (string, error) {
+	if err := os.MkdirAll(b.Dir(), os.ModePerm); err != nil {
+		return "", err
+	}
+	tmpDir, err := os.MkdirTemp(b.Dir(), "tmp-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	dbPath := filepath.Join(tmpDir, archiveDBName)
+	if err := createSnapshot(ctx, b.db, dbPath, excludeCache); err != nil {
+		return "", err
+	}
+	files := map[string]string{archiveDBName: dbPath}
+	if b.preferencesPath != "" {
+		_, err := os.Stat(b.preferencesPath)
+		if err == nil {
+			files[archivePreferencesName] = b.preferencesPath
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+	}
+
+	// Write to a temporary file first to ensure the backup folder only contains complete backups
+	name := filePrefix + time.Now().UTC().Format(fileTimeFormat) + fileExtension
+	tmpPath := filepath.Join(tmpDir, name)
+	if err := writeArchive(tmpPath, files); err != nil {
+		return "", err
+	}
+	p := filepath.Join(b.Dir(), name)
+	if err := os.Rename(tmpPath, p); err != nil {
+		return "", err
+	}
+	return p, nil
+}
+
+// createSnapshot creates a consistent snapshot of a database at path
+// with SQLite's online backup API.
+func createSnapshot(ctx context.Context, src *sql.DB, path string, excludeCache bool) error {
+	dst, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	dstConn, err := dst.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer dstConn.Close()
+	srcConn, err := src.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+	err = dstConn.Raw(func(dstDriverConn any) error {
+		return srcConn.Raw(func(srcDriverConn any) error {
+			d, ok := dstDriverConn.(*sqlite3.SQLiteConn)
+			if !ok {
+				return fmt.Errorf("unexpected driver connection: %T", dstDriverConn)
+			}
+			s, ok := srcDriverConn.(*sqlite3.SQLiteConn)
+			if !ok {
+				return fmt.Errorf("unexpected driver connection: %T", srcDriverConn)
+			}
+			bk, err := d.Backup("main", s, "main")
+			if err != nil {
+				return err
+			}
+			if _, err := bk.Step(-1); err != nil {
+				bk.Finish()
+				return err
+			}
+			return bk.Finish()
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("snapshot: %w", err)
+	}
+	// The snapshot should be a single file
+	if _, err := dstConn.ExecContext(ctx, "PRAGMA journal_mode=DELETE;"); err != nil {
+		return err
+	}
+	if excludeCache {
+		// The cache also contains the HTTP cache for ESI
+		if _, err := dstConn.ExecContext(ctx, "DELETE FROM cache;"); err != nil {
+			return err
+		}
+		if _, err := dstConn.ExecContext(ctx, "VACUUM;"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeArchive writes a ZIP archive at path with files.
+// files maps the name of a file in the archive to its path.
+func writeArchive(path string, files map[string]string) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err2 := f.Close(); err == nil {
+			err = err2
+		}
+	}()
+	zw := zip.NewWriter(f)
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if err := copyFileTo(w, files[name]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func copyFileTo(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// Prune deletes the oldest backups so that no more then keep backups remain
+// and returns the number of deleted backups.
+func (b *Backup) Prune(keep int) (int, error) {
+	items, err := b.List()
+	if err != nil {
+		return 0, err
+	}
+	if len(items) <= keep {
+		return 0, nil
+	}
+	var n int
+	for _, x := range items[max(0, keep):] {
+		if err := os.Remove(x.Path); err != nil {
+			return n, err
+		}
+		slog.Info("Backup deleted", "path", x.Path)
+		n++
+	}
+	return n, nil
+}
+
+// CreateIfDue creates a new backup when automatic backups are enabled
+// and the newest backup is older than the configured interval.
+// It also deletes old backups according to the configured retention.
+// Reports whether a backup was created.
+func (b *Backup) CreateIfDue(ctx context.Context) (bool, error) {
+	if !b.settings.BackupAutoEnabled() {
+		return false, nil
+	}
+	items, err := b.List()
+	if err != nil {
+		return false, err
+	}
+	interval := time.Duration(b.settings.BackupIntervalHours()) * time.Hour
+	if len(items) > 0 && time.Since(items[0].CreatedAt) < interval {
+		return false, nil
+	}
+	if _, err := b.Create(ctx, b.settings.BackupExcludeCache()); err != nil {
+		return false, err
+	}
+	if _, err := b.Prune(b.settings.BackupRetention()); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// StartTicker starts a ticker which creates automatic backups when they are due.
+// The ticker stops when ctx is canceled.
+func (b *Backup) StartTicker(ctx context.Context, d time.Duration) {
+	go func() {
+		t := time.NewTicker(d)
+		defer t.Stop()
+		for {
+			if _, err := b.CreateIfDue(ctx); err != nil {
+				slog.Error("Failed to create automatic backup", "error", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+		}
+	}()
+}
+
+// PrepareRestore validates a backup and stages it for restore.
+// The backup is applied during the next start of the app.
+//
+// The database of the backup is migrated to the current version of the app.
+// Backups from a newer version of the app are rejected.
+func (b *Backup) PrepareRestore(ctx context.Context, r io.Reader) error {
+	err := b.prepareRestore(ctx, r)
+	if err != nil {
+		if err2 := b.CancelRestore(); err2 != nil {
+			slog.Warn("Failed to remove staged backup", "error", err2)
+		}
+		return fmt.Errorf("prepare restore: %w", err)
+	}
+	slog.Info("Backup prepared for restore")
+	return nil
+}
+
+func (b *Backup) prepareRestore(ctx context.Context, r io.Reader) error {
+	dir := restoreDir(b.dataDir)
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	archivePath := filepath.Join(dir, "backup"+fileExtension)
+	if err := writeFile(archivePath, r); err != nil {
+		return err
+	}
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("not a valid backup: %w: %w", err, app.ErrInvalid)
+	}
+	defer zr.Close()
+	var hasDB bool
+	for _, f := range zr.File {
+		if f.Name != archiveDBName && f.Name != archivePreferencesName {
+			continue
+		}
+		if f.Name == archiveDBName {
+			hasDB = true
+		}
+		if err := extractFile(f, filepath.Join(dir, f.Name)); err != nil {
+			return err
+		}
+	}
+	if !hasDB {
+		return fmt.Errorf("not a valid backup: database missing: %w", app.ErrInvalid)
+	}
+	if err := zr.Close(); err != nil {
+		return err
+	}
+	if err := os.Remove(archivePath); err != nil {
+		return err
+	}
+	return validateDB(ctx, filepath.Join(dir, archiveDBName))
+}
+
+// validateDB validates the database at path and migrates it to the current version.
+func validateDB(ctx context.Context, path string) error {
+	db, err := sql.Open("sqlite3", path+"?_fk=on")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	var result string
+	if err := db.QueryRowContext(ctx, "PRAGMA quick_check;").Scan(&result); err != nil {
+		return fmt.Errorf("not a valid database: %w: %w", err, app.ErrInvalid)
+	}
+	if result != "ok" {
+		return fmt.Errorf("database is corrupted: %s: %w", result, app.ErrInvalid)
+	}
+	if err := storage.CheckMigrations(db); err != nil {
+		return err
+	}
+	if err := storage.ApplyMigrations(db); err != nil {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+	return db.Close()
+}
+
+// CancelRestore removes a staged backup.
+func (b *Backup) CancelRestore() error {
+	return os.RemoveAll(restoreDir(b.dataDir))
+}
+
+// HasPendingRestore reports whether a backup is staged for restore.
+func (b *Backup) HasPendingRestore() bool {
+	_, err := os.Stat(filepath.Join(restoreDir(b.dataDir), archiveDBName))
+	return err == nil
+}
+
+// Preferences defines the methods for restoring settings.
+// It is implemented by [fyne.Preferences].
+type Preferences interface {
+	RemoveValue(key string)
+	SetBool(key string, value bool)
+	SetBoolList(key string, value []bool)
+	SetFloat(key string, value float64)
+	SetFloatList(key string, value []float64)
+	SetString(key string, value string)
+	SetStringList(key string, value []string)
+}
+
+// ApplyPendingRestore applies a backup which is staged for restore and
+// reports whether a backup was restored.
+// It must be called before the database is opened.
+//
+// The current database is kept with the suffix ".before-restore".
+// Settings with the given keys which are not included in the backup are removed.
+//
+// When the restore fails the current database is put back
+// and the staged backup is discarded, so that the app starts normally next time.
+func ApplyPendingRestore(dataDir, dbPath string, prefs Preferences, keys []string) (bool, error) {
+	dir := restoreDir(dataDir)
+	src := filepath.Join(dir, archiveDBName)
+	if _, err := os.Stat(src); errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if err := replaceDB(src, dbPath); err != nil {
+		if err2 := os.RemoveAll(dir); err2 != nil {
+			slog.Warn("Failed to remove staged backup", "error", err2)
+		}
+		return false, fmt.Errorf("apply restore: %w", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, archivePreferencesName))
+	if err == nil {
+		if err := restorePreferences(prefs, keys, data); err != nil {
+			slog.Warn("Failed to restore settings", "error", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		slog.Warn("Failed to read settings from backup", "error", err)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		slog.Warn("Failed to remove staged backup", "error", err)
+	}
+	slog.Info("Backup restored", "path", dbPath)
+	return true, nil
+}
+
+// replaceDB replaces the database at dbPath with the database at src.
+// The current database incl. its WAL files is kept with the suffix ".before-restore".
+// When replacing fails, the current database is put back.
+func replaceDB(src, dbPath string) error {
+	var moved []string // suffixes of files moved away
+	rollback := func(err error) error {
+		for _, suffix := range moved {
+			if err2 := os.Rename(dbPath+".before-restore"+suffix, dbPath+suffix); err2 != nil {
+				err = errors.Join(err, fmt.Errorf("rollback: %w", err2))
+			}
+		}
+		return err
+	}
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		p := dbPath + suffix
+		old := dbPath + ".before-restore" + suffix
+		if err := os.Remove(old); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return rollback(err)
+		}
+		err := os.Rename(p, old)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return rollback(err)
+		}
+		moved = append(moved, suffix)
+	}
+	if err := os.Rename(src, dbPath); err != nil {
+		return rollback(err)
+	}
+	return nil
+}
+
+func restoreDir(dataDir string) string {
+	return filepath.Join(dataDir, restoreFolderName)
+}
+
+func writeFile(path string, r io.Reader) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err2 := f.Close(); err == nil {
+			err = err2
+		}
+	}()
+	_, err = io.Copy(f, r)
+	return err
+}
+
+func extractFile(f *zip.File, path string) error {
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return writeFile(path, r)
+}
@@ -0,0 +1,335 @@
+package backup_test
+
+import (
+	"archive/zip"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/backup"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+)
+
+type settingsFake struct {
+	autoEnabled   bool
+	excludeCache  bool
+	intervalHours int
+	retention     int
+}
+
+func (s settingsFake) BackupAutoEnabled() bool  { return s.autoEnabled }
+func (s settingsFake) BackupExcludeCache() bool { return s.excludeCache }
+func (s settingsFake) BackupIntervalHours() int { return s.intervalHours }
+func (s settingsFake) BackupRetention() int     { return s.retention }
+
+func TestBackup(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+	c := factory.CreateCharacter()
+	err := st.CacheSet(t.Context(), storage.CacheSetParams{Key: "esicache-alpha", Value: []byte("dummy")})
+	require.NoError(t, err)
+	dataDir := t.TempDir()
+	prefsPath := filepath.Join(dataDir, "preferences.json")
+	err = os.WriteFile(prefsPath, []byte(`{"alpha":"bravo"}`), 0o644)
+	require.NoError(t, err)
+	b := backup.New(backup.Params{
+		DB:              db,
+		DataDir:         dataDir,
+		PreferencesPath: prefsPath,
+		Settings:        settingsFake{},
+	})
+
+	t.Run("can create backup with cache", func(t *testing.T) {
+		// when
+		p, err := b.Create(t.Context(), false)
+		// then
+		require.NoError(t, err)
+		defer os.Remove(p)
+		assert.Equal(t, b.Dir(), filepath.Dir(p))
+		db2 := openArchiveDB(t, p)
+		assert.Equal(t, 1, countRows(t, db2, "characters", c.ID))
+		assert.Equal(t, 1, countRows(t, db2, "cache", 0))
+	})
+	t.Run("can create backup without cache", func(t *testing.T) {
+		// when
+		p, err := b.Create(t.Context(), true)
+		// then
+		require.NoError(t, err)
+		defer os.Remove(p)
+		db2 := openArchiveDB(t, p)
+		assert.Equal(t, 1, countRows(t, db2, "characters", c.ID))
+		assert.Equal(t, 0, countRows(t, db2, "cache", 0))
+	})
+	t.Run("can list backups", func(t *testing.T) {
+		// given
+		p1, err := b.Create(t.Context(), true)
+		require.NoError(t, err)
+		defer os.Remove(p1)
+		p2, err := b.Create(t.Context(), true)
+		require.NoError(t, err)
+		defer os.Remove(p2)
+		// when
+		got, err := b.List()
+		// then
+		require.NoError(t, err)
+		if assert.Len(t, got, 2) {
+			assert.Equal(t, p2, got[0].Path)
+			assert.Equal(t, p1, got[1].Path)
+			assert.Positive(t, got[0].Size)
+		}
+	})
+	t.Run("can prune old backups", func(t *testing.T) {
+		// given
+		for range 3 {
+			_, err := b.Create(t.Context(), true)
+			require.NoError(t, err)
+		}
+		before, err := b.List()
+		require.NoError(t, err)
+		// when
+		n, err := b.Prune(2)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		after, err := b.List()
+		require.NoError(t, err)
+		assert.Equal(t, before[:2], after)
+		_, err = b.Prune(0)
+		require.NoError(t, err)
+	})
+}
+
+func TestBackup_CreateIfDue(t *testing.T) {
+	db, _, _ := testutil.NewDBOnDisk(t)
+	defer db.Close()
+	t.Run("should create backup when none exists", func(t *testing.T) {
+		b := backup.New(backup.Params{
+			DB:       db,
+			DataDir:  t.TempDir(),
+			Settings: settingsFake{autoEnabled: true, intervalHours: 1, retention: 3},
+		})
+		ok, err := b.CreateIfDue(t.Context())
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+	t.Run("should not create backup when newest backup is recent", func(t *testing.T) {
+		b := backup.New(backup.Params{
+			DB:       db,
+			DataDir:  t.TempDir(),
+			Settings: settingsFake{autoEnabled: true, intervalHours: 1, retention: 3},
+		})
+		_, err := b.Create(t.Context(), true)
+		require.NoError(t, err)
+		ok, err := b.CreateIfDue(t.Context())
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+	t.Run("should not create backup when disabled", func(t *testing.T) {
+		b := backup.New(backup.Params{
+			DB:       db,
+			DataDir:  t.TempDir(),
+			Settings: settingsFake{intervalHours: 1, retention: 3},
+		})
+		ok, err := b.CreateIfDue(t.Context())
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+	t.Run("should delete backups exceeding retention", func(t *testing.T) {
+		b := backup.New(backup.Params{
+			DB:       db,
+			DataDir:  t.TempDir(),
+			Settings: settingsFake{autoEnabled: true, retention: 1},
+		})
+		_, err := b.Create(t.Context(), true)
+		require.NoError(t, err)
+		ok, err := b.CreateIfDue(t.Context())
+		require.NoError(t, err)
+		assert.True(t, ok)
+		items, err := b.List()
+		require.NoError(t, err)
+		assert.Len(t, items, 1)
+	})
+}
+
+func TestRestore(t *testing.T) {
+	t.Run("can restore a backup", func(t *testing.T) {
+		// given
+		db, _, factory := testutil.NewDBOnDisk(t)
+		defer db.Close()
+		c := factory.CreateCharacter()
+		dataDir := t.TempDir()
+		prefsPath := filepath.Join(dataDir, "preferences.json")
+		err := os.WriteFile(prefsPath, []byte(`{"alpha":"bravo","charlie":[1,2],"delta":true}`), 0o644)
+		require.NoError(t, err)
+		b := backup.New(backup.Params{
+			DB:              db,
+			DataDir:         dataDir,
+			PreferencesPath: prefsPath,
+			Settings:        settingsFake{},
+		})
+		p, err := b.Create(t.Context(), true)
+		require.NoError(t, err)
+		f, err := os.Open(p)
+		require.NoError(t, err)
+		defer f.Close()
+		// when
+		err = b.PrepareRestore(t.Context(), f)
+		// then
+		require.NoError(t, err)
+		assert.True(t, b.HasPendingRestore())
+
+		// given
+		dbPath := filepath.Join(dataDir, "evebuddy.sqlite")
+		err = os.WriteFile(dbPath, []byte("current"), 0o644)
+		require.NoError(t, err)
+		prefs := test.NewTempApp(t).Preferences()
+		prefs.SetString("echo", "foxtrot")
+		// when
+		ok, err := backup.ApplyPendingRestore(dataDir, dbPath, prefs, []string{"echo"})
+		// then
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.False(t, b.HasPendingRestore())
+		db2, err := sql.Open("sqlite3", dbPath)
+		require.NoError(t, err)
+		defer db2.Close()
+		assert.Equal(t, 1, countRows(t, db2, "characters", c.ID))
+		data, err := os.ReadFile(dbPath + ".before-restore")
+		require.NoError(t, err)
+		assert.Equal(t, "current", string(data))
+		assert.Equal(t, "bravo", prefs.String("alpha"))
+		assert.Equal(t, []int{1, 2}, prefs.IntList("charlie"))
+		assert.True(t, prefs.Bool("delta"))
+		assert.Equal(t, "", prefs.String("echo"))
+	})
+	t.Run("should keep current database when restore fails", func(t *testing.T) {
+		// given
+		db, _, _ := testutil.NewDBOnDisk(t)
+		defer db.Close()
+		dataDir := t.TempDir()
+		b := backup.New(backup.Params{DB: db, DataDir: dataDir, Settings: settingsFake{}})
+		p, err := b.Create(t.Context(), true)
+		require.NoError(t, err)
+		f, err := os.Open(p)
+		require.NoError(t, err)
+		defer f.Close()
+		err = b.PrepareRestore(t.Context(), f)
+		require.NoError(t, err)
+		dbPath := filepath.Join(dataDir, "evebuddy.sqlite")
+		err = os.WriteFile(dbPath, []byte("current"), 0o644)
+		require.NoError(t, err)
+		err = os.WriteFile(dbPath+"-wal", []byte("current-wal"), 0o644)
+		require.NoError(t, err)
+		// a non-empty folder can not be removed, which makes the restore fail
+		blocker := dbPath + ".before-restore-wal"
+		err = os.MkdirAll(filepath.Join(blocker, "dummy"), os.ModePerm)
+		require.NoError(t, err)
+		prefs := test.NewTempApp(t).Preferences()
+		// when
+		ok, err := backup.ApplyPendingRestore(dataDir, dbPath, prefs, nil)
+		// then
+		assert.Error(t, err)
+		assert.False(t, ok)
+		assert.False(t, b.HasPendingRestore())
+		data, err := os.ReadFile(dbPath)
+		require.NoError(t, err)
+		assert.Equal(t, "current", string(data))
+		data, err = os.ReadFile(dbPath + "-wal")
+		require.NoError(t, err)
+		assert.Equal(t, "current-wal", string(data))
+		assert.NoFileExists(t, dbPath+".before-restore")
+	})
+	t.Run("should do nothing when no restore is pending", func(t *testing.T) {
+		dataDir := t.TempDir()
+		prefs := test.NewTempApp(t).Preferences()
+		ok, err := backup.ApplyPendingRestore(dataDir, filepath.Join(dataDir, "evebuddy.sqlite"), prefs, nil)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+	t.Run("should reject backups from a newer version", func(t *testing.T) {
+		// given
+		db, _, _ := testutil.NewDBOnDisk(t)
+		defer db.Close()
+		_, err := db.Exec("INSERT INTO migrations (name) VALUES ('9999_from_the_future');")
+		require.NoError(t, err)
+		b := backup.New(backup.Params{DB: db, DataDir: t.TempDir(), Settings: settingsFake{}})
+		p, err := b.Create(t.Context(), true)
+		require.NoError(t, err)
+		f, err := os.Open(p)
+		require.NoError(t, err)
+		defer f.Close()
+		// when
+		err = b.PrepareRestore(t.Context(), f)
+		// then
+		assert.ErrorIs(t, err, app.ErrInvalid)
+		assert.False(t, b.HasPendingRestore())
+	})
+	t.Run("should reject invalid files", func(t *testing.T) {
+		// given
+		db, _, _ := testutil.NewDBOnDisk(t)
+		defer db.Close()
+		b := backup.New(backup.Params{DB: db, DataDir: t.TempDir(), Settings: settingsFake{}})
+		f, err := os.CreateTemp(t.TempDir(), "invalid-*.zip")
+		require.NoError(t, err)
+		defer f.Close()
+		_, err = f.WriteString("invalid")
+		require.NoError(t, err)
+		_, err = f.Seek(0, 0)
+		require.NoError(t, err)
+		// when
+		err = b.PrepareRestore(t.Context(), f)
+		// then
+		assert.ErrorIs(t, err, app.ErrInvalid)
+		assert.False(t, b.HasPendingRestore())
+	})
+}
+
+// openArchiveDB extracts the database from a backup archive and returns it.
+func openArchiveDB(t *testing.T, path string) *sql.DB {
+	t.Helper()
+	zr, err := zip.OpenReader(path)
+	require.NoError(t, err)
+	defer zr.Close()
+	p := filepath.Join(t.TempDir(), "evebuddy.sqlite")
+	for _, f := range zr.File {
+		if f.Name != "evebuddy.sqlite" {
+			continue
+		}
+		r, err := f.Open()
+		require.NoError(t, err)
+		w, err := os.Create(p)
+		require.NoError(t, err)
+		_, err = w.ReadFrom(r)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		require.NoError(t, r.Close())
+	}
+	db, err := sql.Open("sqlite3", p)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return db
+}
+
+// countRows returns the number of rows in table. When id is not zero, only rows with that ID are counted.
+func countRows(t *testing.T, db *sql.DB, table string, id int64) int {
+	t.Helper()
+	q := "SELECT COUNT(*) FROM " + table
+	var args []any
+	if id != 0 {
+		q += " WHERE id = ?"
+		args = append(args, id)
+	}
+	var n int
+	err := db.QueryRow(q, args...).Scan(&n)
+	require.NoError(t, err)
+	return n
+}
@@ -0,0 +1,89 @@
+package backup
+
+import (
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"slices"
+)
+
+// restorePreferences restores all settings from the content of a preferences file.
+// Settings with the given keys which are not included in the file are removed.
+func restorePreferences(prefs Preferences, keys []string, data []byte) error {
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	var n int
+	for _, k := range slices.Sorted(maps.Keys(values)) {
+		v := values[k]
+		if !setPreference(prefs, k, v) {
+			slog.Warn("Restore settings: Ignoring value with unsupported type", "key", k, "value", v)
+			continue
+		}
+		n++
+	}
+	for _, k := range keys {
+		if _, ok := values[k]; !ok {
+			prefs.RemoveValue(k)
+		}
+	}
+	slog.Info("Settings restored", "count", n)
+	return nil
+}
+
+// setPreference sets the value for a key and reports whether it was successful.
+// Supports all types which can be produced by decoding a Fyne preferences file.
+// Note that integers are stored as floats in Fyne preferences files.
+func setPreference(prefs Preferences, key string, value any) bool {
+	switch x := value.(type) {
+	case bool:
+		prefs.SetBool(key, x)
+	case float64:
+		prefs.SetFloat(key, x)
+	case string:
+		prefs.SetString(key, x)
+	case []any:
+		if len(x) == 0 {
+			prefs.RemoveValue(key)
+			return true
+		}
+		switch x[0].(type) {
+		case bool:
+			s, ok := convertList[bool](x)
+			if !ok {
+				return false
+			}
+			prefs.SetBoolList(key, s)
+		case float64:
+			s, ok := convertList[float64](x)
+			if !ok {
+				return false
+			}
+			prefs.SetFloatList(key, s)
+		case string:
+			s, ok := convertList[string](x)
+			if !ok {
+				return false
+			}
+			prefs.SetStringList(key, s)
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+func convertList[T any](items []any) ([]T, bool) {
+	s := make([]T, 0, len(items))
+	for _, v := range items {
+		x, ok := v.(T)
+		if !ok {
+			return nil, false
+		}
+		s = append(s, x)
+	}
+	return s, true
+}
@@ -0,0 +1,68 @@
+package app
+
+import "fmt"
+
+// Blueprint represents a blueprint owned by a character or corporation.
+type Blueprint struct {
+	ItemID             int64
+	LocationFlag       LocationFlag
+	LocationID         int64 // can be a station, structure or the item ID of a container
+	MaterialEfficiency int
+	Quantity           int // -1 for an original, -2 for a copy or the size of a stack of originals
+	Runs               int // -1 for an original
+	TimeEfficiency     int
+	Type               *EveType
+}
+
+// IsCopy reports whether the blueprint is a copy (BPC).
+func (b Blueprint) IsCopy() bool {
+	return b.Quantity == -2
+}
+
+// IsOriginal reports whether the blueprint is an original (BPO).
+func (b Blueprint) IsOriginal() bool {
+	return !b.IsCopy()
+}
+
+// Count returns the number of blueprints represented by this item.
+// Blueprints are singletons, except for stacks of unused originals.
+func (b Blueprint) Count() int {
+	if b.Quantity > 0 {
+		return b.Quantity
+	}
+	return 1
+}
+
+// KindDisplay returns a short description of the kind of blueprint, i.e. BPO or BPC.
+func (b Blueprint) KindDisplay() string {
+	if b.IsCopy() {
+		return "BPC"
+	}
+	return "BPO"
+}
+
+// RunsDisplay returns the remaining runs for copies and "∞" for originals.
+func (b Blueprint) RunsDisplay() string {
+	if b.IsOriginal() || b.Runs < 0 {
+		return "∞"
+	}
+	return fmt.Sprint(b.Runs)
+}
+
+// TypeName returns the name of the blueprint type.
+func (b Blueprint) TypeName() string {
+	if b.Type == nil {
+		return ""
+	}
+	return b.Type.Name
+}
+
+type CharacterBlueprint struct {
+	Blueprint
+	CharacterID int64
+}
+
+type CorporationBlueprint struct {
+	Blueprint
+	CorporationID int64
+}
@@ -0,0 +1,34 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+)
+
+func TestBlueprint(t *testing.T) {
+	cases := []struct {
+		name       string
+		bp         app.Blueprint
+		isCopy     bool
+		count      int
+		kind       string
+		runs       string
+		isOriginal bool
+	}{
+		{"original", app.Blueprint{Quantity: -1, Runs: -1}, false, 1, "BPO", "∞", true},
+		{"copy", app.Blueprint{Quantity: -2, Runs: 10}, true, 1, "BPC", "10", false},
+		{"stack of originals", app.Blueprint{Quantity: 3, Runs: -1}, false, 3, "BPO", "∞", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.isCopy, tc.bp.IsCopy())
+			assert.Equal(t, tc.isOriginal, tc.bp.IsOriginal())
+			assert.Equal(t, tc.count, tc.bp.Count())
+			assert.Equal(t, tc.kind, tc.bp.KindDisplay())
+			assert.Equal(t, tc.runs, tc.bp.RunsDisplay())
+		})
+	}
+}
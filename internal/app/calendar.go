@@ -0,0 +1,78 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ErikKalkoken/evebuddy/internal/evehtml"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xstrings"
+)
+
+// CalendarEventResponse represents the response of a character to a calendar event.
+type CalendarEventResponse string
+
+const (
+	CalendarEventResponseAccepted     CalendarEventResponse = "accepted"
+	CalendarEventResponseDeclined     CalendarEventResponse = "declined"
+	CalendarEventResponseNotResponded CalendarEventResponse = "not_responded"
+	CalendarEventResponseTentative    CalendarEventResponse = "tentative"
+)
+
+func (r CalendarEventResponse) Display() string {
+	return xstrings.Title(strings.ReplaceAll(string(r), "_", " "))
+}
+
+// CharacterCalendarEvent represents an event in the calendar of a character.
+type CharacterCalendarEvent struct {
+	CharacterID  int64
+	Date         time.Time
+	Duration     time.Duration
+	EventID      int64
+	ID           int64
+	Importance   int64
+	LastNotified optional.Optional[time.Time] // start date of the event when it was last notified
+	OwnerID      int64
+	OwnerName    string
+	OwnerType    string
+	Response     CalendarEventResponse
+	Text         string
+	Title        string
+}
+
+// EndDate returns when an event ends.
+func (e CharacterCalendarEvent) EndDate() time.Time {
+	return e.Date.Add(e.Duration)
+}
+
+// IsImportant reports whether an event has been marked as important.
+func (e CharacterCalendarEvent) IsImportant() bool {
+	return e.Importance > 0
+}
+
+// TextPlain returns the description of an event as plain text.
+func (e CharacterCalendarEvent) TextPlain() string {
+	return evehtml.ToPlain(e.Text)
+}
+
+// NeedsNotification reports whether the upcoming event should be notified at time now,
+// when notifications are to be sent lead time before an event starts.
+// Declined events are not notified and events are notified once only for each start date.
+func (e CharacterCalendarEvent) NeedsNotification(now time.Time, lead time.Duration) bool {
+	if e.Response == CalendarEventResponseDeclined {
+		return false
+	}
+	if !now.Before(e.Date) || e.Date.Sub(now) > lead {
+		return false
+	}
+	if e.LastNotified.ValueOrZero().Equal(e.Date) {
+		return false
+	}
+	return true
+}
+
+// CharacterCalendarEventAttendee represents an attendee of a calendar event.
+type CharacterCalendarEventAttendee struct {
+	Character *EveEntity
+	Response  CalendarEventResponse
+}
@@ -0,0 +1,53 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+func TestCalendarEventResponse_Display(t *testing.T) {
+	assert.Equal(t, "Not Responded", app.CalendarEventResponseNotResponded.Display())
+	assert.Equal(t, "Accepted", app.CalendarEventResponseAccepted.Display())
+}
+
+func TestCharacterCalendarEvent_NeedsNotification(t *testing.T) {
+	now := time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC)
+	lead := 15 * time.Minute
+	cases := []struct {
+		name         string
+		date         time.Time
+		lastNotified optional.Optional[time.Time]
+		response     app.CalendarEventResponse
+		want         bool
+	}{
+		{"starts within lead time", now.Add(10 * time.Minute), optional.Optional[time.Time]{}, app.CalendarEventResponseAccepted, true},
+		{"starts after lead time", now.Add(20 * time.Minute), optional.Optional[time.Time]{}, app.CalendarEventResponseAccepted, false},
+		{"has already started", now.Add(-time.Minute), optional.Optional[time.Time]{}, app.CalendarEventResponseAccepted, false},
+		{"was already notified", now.Add(10 * time.Minute), optional.New(now.Add(10 * time.Minute)), app.CalendarEventResponseAccepted, false},
+		{"was rescheduled", now.Add(10 * time.Minute), optional.New(now.Add(-time.Hour)), app.CalendarEventResponseTentative, true},
+		{"was declined", now.Add(10 * time.Minute), optional.Optional[time.Time]{}, app.CalendarEventResponseDeclined, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			x := app.CharacterCalendarEvent{
+				Date:         tc.date,
+				LastNotified: tc.lastNotified,
+				Response:     tc.response,
+			}
+			assert.Equal(t, tc.want, x.NeedsNotification(now, lead))
+		})
+	}
+}
+
+func TestCharacterCalendarEvent_EndDate(t *testing.T) {
+	x := app.CharacterCalendarEvent{
+		Date:     time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC),
+		Duration: 90 * time.Minute,
+	}
+	assert.Equal(t, time.Date(2025, 10, 1, 19, 30, 0, 0, time.UTC), x.EndDate())
+}
@@ -17,23 +17,26 @@ import (
 
 // Character is an EVE Online character owned by the user.
 type Character struct {
-	AssetValue         optional.Optional[float64] // value of character assets
-	ContractItemsValue optional.Optional[float64]
-	ContractsEscrow    optional.Optional[float64]
-	EveCharacter       *EveCharacter
-	Home               optional.Optional[*EveLocation]
-	ID                 int64
-	IsTrainingWatched  bool
-	LastCloneJumpAt    optional.Optional[time.Time]
-	LastLoginAt        optional.Optional[time.Time]
-	Location           optional.Optional[*EveLocation]
-	OrderItemsValue    optional.Optional[float64]
-	OrdersEscrow       optional.Optional[float64]
-	Ship               optional.Optional[*EveType]
-	SkillPointsValue   optional.Optional[float64]
-	TrainedSP          optional.Optional[int64]
-	UnallocatedSP      optional.Optional[int64]
-	WalletBalance      optional.Optional[float64]
+	AssetValue           optional.Optional[float64] // value of character assets
+	ContractItemsValue   optional.Optional[float64]
+	ContractsEscrow      optional.Optional[float64]
+	EveCharacter         *EveCharacter
+	Home                 optional.Optional[*EveLocation]
+	ID                   int64
+	IsJumpFatigueWatched bool
+	IsTrainingWatched    bool
+	JumpFatigueExpiresAt optional.Optional[time.Time]
+	LastCloneJumpAt      optional.Optional[time.Time]
+	LastJumpAt           optional.Optional[time.Time]
+	LastLoginAt          optional.Optional[time.Time]
+	Location             optional.Optional[*EveLocation]
+	OrderItemsValue      optional.Optional[float64]
+	OrdersEscrow         optional.Optional[float64]
+	Ship                 optional.Optional[*EveType]
+	SkillPointsValue     optional.Optional[float64]
+	TrainedSP            optional.Optional[int64]
+	UnallocatedSP        optional.Optional[int64]
+	WalletBalance        optional.Optional[float64]
 	// Calculated fields
 	NextCloneJump optional.Optional[time.Time] // zero time == now
 }
@@ -52,6 +55,36 @@ func (c *Character) NameOrZero() string {
 	return c.EveCharacter.Name
 }
 
+// JumpFatigueRemaining returns the remaining jump fatigue of a character at time t.
+// It returns 0 when a character has no jump fatigue.
+func (c *Character) JumpFatigueRemaining(t time.Time) time.Duration {
+	expires, ok := c.JumpFatigueExpiresAt.Value()
+	if !ok || !expires.After(t) {
+		return 0
+	}
+	return expires.Sub(t)
+}
+
+// JumpActivationExpiresAt returns when the jump activation timer ("blue timer")
+// of a character expires.
+//
+// This is an estimate, because ESI does not report the timer.
+// The timer is assumed to be one tenth of the jump fatigue after the last jump,
+// but at least 1 minute and at most 30 minutes.
+func (c *Character) JumpActivationExpiresAt() optional.Optional[time.Time] {
+	var z optional.Optional[time.Time]
+	lastJump, ok := c.LastJumpAt.Value()
+	if !ok {
+		return z
+	}
+	expires, ok := c.JumpFatigueExpiresAt.Value()
+	if !ok || expires.Before(lastJump) {
+		return z
+	}
+	d := min(max(expires.Sub(lastJump)/10, time.Minute), 30*time.Minute)
+	return optional.New(lastJump.Add(d))
+}
+
 // CombinedAssetsValue returns the combined assets estimated value.
 //
 // This is the total sum of the estimated market price of a character's assets.
@@ -157,6 +190,70 @@ func RolesAll() iter.Seq[Role] {
 	return maps.Keys(role2String)
 }
 
+// RoleFromESIName returns the role for a name used by ESI, e.g. "Station_Manager",
+// and reports whether the name is known.
+func RoleFromESIName(s string) (Role, bool) {
+	r, ok := esiName2Role[s]
+	return r, ok
+}
+
+var esiName2Role = map[string]Role{
+	"Account_Take_1":            RoleAccountTake1,
+	"Account_Take_2":            RoleAccountTake2,
+	"Account_Take_3":            RoleAccountTake3,
+	"Account_Take_4":            RoleAccountTake4,
+	"Account_Take_5":            RoleAccountTake5,
+	"Account_Take_6":            RoleAccountTake6,
+	"Account_Take_7":            RoleAccountTake7,
+	"Accountant":                RoleAccountant,
+	"Auditor":                   RoleAuditor,
+	"Brand_Manager":             RoleBrandManager,
+	"Communications_Officer":    RoleCommunicationsOfficer,
+	"Config_Equipment":          RoleConfigEquipment,
+	"Config_Starbase_Equipment": RoleConfigStarbaseEquipment,
+	"Container_Take_1":          RoleContainerTake1,
+	"Container_Take_2":          RoleContainerTake2,
+	"Container_Take_3":          RoleContainerTake3,
+	"Container_Take_4":          RoleContainerTake4,
+	"Container_Take_5":          RoleContainerTake5,
+	"Container_Take_6":          RoleContainerTake6,
+	"Container_Take_7":          RoleContainerTake7,
+	"Contract_Manager":          RoleContractManager,
+	"Deliveries_Container_Take": RoleDeliveriesContainerTake,
+	"Deliveries_Query":          RoleDeliveriesQuery,
+	"Deliveries_Take":           RoleDeliveriesTake,
+	"Diplomat":                  RoleDiplomat,
+	"Director":                  RoleDirector,
+	"Factory_Manager":           RoleFactoryManager,
+	"Fitting_Manager":           RoleFittingManager,
+	"Hangar_Query_1":            RoleHangarQuery1,
+	"Hangar_Query_2":            RoleHangarQuery2,
+	"Hangar_Query_3":            RoleHangarQuery3,
+	"Hangar_Query_4":            RoleHangarQuery4,
+	"Hangar_Query_5":            RoleHangarQuery5,
+	"Hangar_Query_6":            RoleHangarQuery6,
+	"Hangar_Query_7":            RoleHangarQuery7,
+	"Hangar_Take_1":             RoleHangarTake1,
+	"Hangar_Take_2":             RoleHangarTake2,
+	"Hangar_Take_3":             RoleHangarTake3,
+	"Hangar_Take_4":             RoleHangarTake4,
+	"Hangar_Take_5":             RoleHangarTake5,
+	"Hangar_Take_6":             RoleHangarTake6,
+	"Hangar_Take_7":             RoleHangarTake7,
+	"Junior_Accountant":         RoleJuniorAccountant,
+	"Personnel_Manager":         RolePersonnelManager,
+	"Project_Manager":           RoleProjectManager,
+	"Rent_Factory_Facility":     RoleRentFactoryFacility,
+	"Rent_Office":               RoleRentOffice,
+	"Rent_Research_Facility":    RoleRentResearchFacility,
+	"Security_Officer":          RoleSecurityOfficer,
+	"Skill_Plan_Manager":        RoleSkillPlanManager,
+	"Starbase_Defense_Operator": RoleStarbaseDefenseOperator,
+	"Starbase_Fuel_Technician":  RoleStarbaseFuelTechnician,
+	"Station_Manager":           RoleStationManager,
+	"Trader":                    RoleTrader,
+}
+
 var role2String = map[Role]string{
 	RoleAuditor:                 "auditor",
 	RoleConfigEquipment:         "config equipment",
@@ -329,14 +426,16 @@ func (cp CharacterPlanet) ProducedSchematics() []*EveSchematic {
 }
 
 type PlanetPin struct {
-	ID                   int64
-	ExpiryTime           optional.Optional[time.Time]
-	ExtractorProductType optional.Optional[*EveType]
-	FactorySchematic     optional.Optional[*EveSchematic]
-	InstallTime          optional.Optional[time.Time]
-	LastCycleStart       optional.Optional[time.Time]
-	Schematic            optional.Optional[*EveSchematic]
-	Type                 *EveType
+	ID                        int64
+	ExpiryTime                optional.Optional[time.Time]
+	ExtractorCycleTime        optional.Optional[time.Duration]
+	ExtractorProductType      optional.Optional[*EveType]
+	ExtractorQuantityPerCycle optional.Optional[int64] // base value for calculating the output of each cycle
+	FactorySchematic          optional.Optional[*EveSchematic]
+	InstallTime               optional.Optional[time.Time]
+	LastCycleStart            optional.Optional[time.Time]
+	Schematic                 optional.Optional[*EveSchematic]
+	Type                      *EveType
 }
 
 func (pp PlanetPin) IsExtracting() bool {
@@ -37,6 +37,72 @@ func TestCharacater_NameorZero(t *testing.T) {
 	})
 }
 
+func TestCharacter_JumpFatigueRemaining(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name      string
+		expiresAt optional.Optional[time.Time]
+		want      time.Duration
+	}{
+		{"has fatigue", optional.New(now.Add(2 * time.Hour)), 2 * time.Hour},
+		{"fatigue expired", optional.New(now.Add(-2 * time.Hour)), 0},
+		{"no fatigue", optional.Optional[time.Time]{}, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &app.Character{JumpFatigueExpiresAt: tc.expiresAt}
+			xassert.Equal(t, tc.want, c.JumpFatigueRemaining(now))
+		})
+	}
+}
+
+func TestCharacter_JumpActivationExpiresAt(t *testing.T) {
+	lastJump := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name      string
+		lastJump  optional.Optional[time.Time]
+		expiresAt optional.Optional[time.Time]
+		want      optional.Optional[time.Time]
+	}{
+		{
+			"one tenth of fatigue",
+			optional.New(lastJump),
+			optional.New(lastJump.Add(100 * time.Minute)),
+			optional.New(lastJump.Add(10 * time.Minute)),
+		},
+		{
+			"at least one minute",
+			optional.New(lastJump),
+			optional.New(lastJump.Add(5 * time.Minute)),
+			optional.New(lastJump.Add(time.Minute)),
+		},
+		{
+			"at most 30 minutes",
+			optional.New(lastJump),
+			optional.New(lastJump.Add(10 * time.Hour)),
+			optional.New(lastJump.Add(30 * time.Minute)),
+		},
+		{
+			"no jump",
+			optional.Optional[time.Time]{},
+			optional.New(lastJump),
+			optional.Optional[time.Time]{},
+		},
+		{
+			"no fatigue",
+			optional.New(lastJump),
+			optional.Optional[time.Time]{},
+			optional.Optional[time.Time]{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &app.Character{LastJumpAt: tc.lastJump, JumpFatigueExpiresAt: tc.expiresAt}
+			xassert.Equal(t, tc.want, c.JumpActivationExpiresAt())
+		})
+	}
+}
+
 func TestCharacterPlanet_ExtractedTypes(t *testing.T) {
 	extractorType := &app.EveType{Group: &app.EveGroup{ID: app.EveGroupExtractorControlUnits}}
 	productType1a := &app.EveType{ID: 1}
@@ -222,3 +288,15 @@ func TestCharacterWalletTransaction_Total(t *testing.T) {
 		})
 	}
 }
+
+func TestRoleFromESIName(t *testing.T) {
+	t.Run("known name", func(t *testing.T) {
+		got, ok := app.RoleFromESIName("Station_Manager")
+		assert.True(t, ok)
+		xassert.Equal(t, app.RoleStationManager, got)
+	})
+	t.Run("unknown name", func(t *testing.T) {
+		_, ok := app.RoleFromESIName("Invalid")
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,85 @@
+package characterservice
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/fnt-eve/goesi-openapi/esi"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
+)
+
+// ListAllBlueprints returns the blueprints of all characters.
+func (s *CharacterService) ListAllBlueprints(ctx context.Context) ([]*app.CharacterBlueprint, error) {
+	return s.st.ListAllCharacterBlueprints(ctx)
+}
+
+// ListBlueprints returns the blueprints of a character.
+func (s *CharacterService) ListBlueprints(ctx context.Context, characterID int64) ([]*app.CharacterBlueprint, error) {
+	return s.st.ListCharacterBlueprints(ctx, characterID)
+}
+
+func (s *CharacterService) updateBlueprintsESI(ctx context.Context, arg characterSectionUpdateParams) (bool, error) {
+	if arg.section != app.SectionCharacterBlueprints {
+		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
+	}
+	return s.updateSectionIfChanged(
+		ctx, arg, false,
+		func(ctx context.Context, characterID int64) (any, error) {
+			ctx = xgoesi.NewContextWithOperationID(ctx, "GetCharactersCharacterIdBlueprints")
+			rows, err := xgoesi.FetchPages(
+				func(page int32) ([]esi.CharactersCharacterIdBlueprintsGetInner, *http.Response, error) {
+					return s.esiClient.CharacterAPI.GetCharactersCharacterIdBlueprints(ctx, characterID).Page(page).Execute()
+				},
+			)
+			if err != nil {
+				return false, err
+			}
+			slices.SortFunc(rows, func(a, b esi.CharactersCharacterIdBlueprintsGetInner) int {
+				return cmp.Compare(a.ItemId, b.ItemId)
+			})
+			slog.Debug("Received blueprints from ESI", "count", len(rows), "characterID", characterID)
+			return rows, nil
+		},
+		func(ctx context.Context, characterID int64, data any) (bool, error) {
+			rows := data.([]esi.CharactersCharacterIdBlueprintsGetInner)
+			var typeIDs set.Set[int64]
+			for _, r := range rows {
+				typeIDs.Add(r.TypeId)
+			}
+			if err := s.eus.AddMissingTypes(ctx, typeIDs); err != nil {
+				return false, err
+			}
+			var blueprints []storage.CreateCharacterBlueprintParams
+			for _, r := range rows {
+				locationFlag, found := locationFlagFromESIValue[r.LocationFlag]
+				if !found {
+					locationFlag = app.FlagUnknown
+					slog.Warn("Unknown location flag encountered", "characterID", characterID, "blueprint", r)
+				}
+				blueprints = append(blueprints, storage.CreateCharacterBlueprintParams{
+					CharacterID:        characterID,
+					ItemID:             r.ItemId,
+					LocationFlag:       locationFlag,
+					LocationID:         r.LocationId,
+					MaterialEfficiency: int(r.MaterialEfficiency),
+					Quantity:           int(r.Quantity),
+					Runs:               int(r.Runs),
+					TimeEfficiency:     int(r.TimeEfficiency),
+					TypeID:             r.TypeId,
+				})
+			}
+			if err := s.st.ReplaceCharacterBlueprints(ctx, characterID, blueprints); err != nil {
+				return false, err
+			}
+			slog.Info("Stored updated blueprints", "characterID", characterID, "count", len(blueprints))
+			return true, nil
+		})
+}
@@ -0,0 +1,69 @@
+package characterservice
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestUpdateCharacterBlueprintsESI(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	s := NewFake(Params{Storage: st})
+	ctx := context.Background()
+	t.Run("should replace blueprints with new data from ESI", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		c := factory.CreateCharacter()
+		factory.CreateCharacterToken(storage.UpdateOrCreateCharacterTokenParams{CharacterID: c.ID})
+		factory.CreateCharacterBlueprint(storage.CreateCharacterBlueprintParams{CharacterID: c.ID})
+		et := factory.CreateEveType()
+		location := factory.CreateEveLocationStructure()
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/characters/%d/blueprints?page=1", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"item_id":             1000000010495,
+				"location_flag":       "Hangar",
+				"location_id":         location.ID,
+				"material_efficiency": 10,
+				"quantity":            -2,
+				"runs":                7,
+				"time_efficiency":     20,
+				"type_id":             et.ID,
+			}}),
+		)
+		// when
+		changed, err := s.updateBlueprintsESI(ctx, characterSectionUpdateParams{
+			characterID: c.ID,
+			section:     app.SectionCharacterBlueprints,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		oo, err := s.ListBlueprints(ctx, c.ID)
+		require.NoError(t, err)
+		require.Len(t, oo, 1)
+		o := oo[0]
+		xassert.Equal(t, 1000000010495, o.ItemID)
+		xassert.Equal(t, app.FlagHangar, o.LocationFlag)
+		xassert.Equal(t, location.ID, o.LocationID)
+		xassert.Equal(t, 10, o.MaterialEfficiency)
+		xassert.Equal(t, 7, o.Runs)
+		xassert.Equal(t, 20, o.TimeEfficiency)
+		xassert.Equal(t, et.ID, o.Type.ID)
+		assert.True(t, o.IsCopy())
+	})
+}
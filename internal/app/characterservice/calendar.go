@@ -0,0 +1,193 @@
+package characterservice
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/fnt-eve/goesi-openapi/esi"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
+)
+
+// ListAllUpcomingCalendarEvents returns the upcoming calendar events of all characters.
+func (s *CharacterService) ListAllUpcomingCalendarEvents(ctx context.Context) ([]*app.CharacterCalendarEvent, error) {
+	return s.st.ListAllCharacterCalendarEventsFrom(ctx, time.Now())
+}
+
+// ListCalendarEvents returns the calendar events of a character.
+func (s *CharacterService) ListCalendarEvents(ctx context.Context, characterID int64) ([]*app.CharacterCalendarEvent, error) {
+	return s.st.ListCharacterCalendarEvents(ctx, characterID)
+}
+
+// ListCalendarEventAttendees returns the attendees of a calendar event.
+func (s *CharacterService) ListCalendarEventAttendees(ctx context.Context, characterID, eventID int64) ([]*app.CharacterCalendarEventAttendee, error) {
+	return s.st.ListCharacterCalendarEventAttendees(ctx, characterID, eventID)
+}
+
+// NotifyUpcomingCalendarEvents sends notifications for calendar events of a character,
+// which start within lead time.
+// Each event is notified once only, unless it was moved to a different start date.
+// It sends one notification covering all upcoming events.
+func (s *CharacterService) NotifyUpcomingCalendarEvents(ctx context.Context, characterID int64, lead time.Duration, notify func(title, content string)) error {
+	_, err, _ := s.sfg.Do(fmt.Sprintf("NotifyUpcomingCalendarEvents-%d", characterID), func() (any, error) {
+		events, err := s.ListCalendarEvents(ctx, characterID)
+		if err != nil {
+			return nil, err
+		}
+		characterName, err := s.getCharacterName(ctx, characterID)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		var upcoming []*app.CharacterCalendarEvent
+		for _, e := range events {
+			if !e.NeedsNotification(now, lead) {
+				continue
+			}
+			upcoming = append(upcoming, e)
+			err := s.st.UpdateCharacterCalendarEventLastNotified(ctx, storage.UpdateCharacterCalendarEventLastNotifiedParams{
+				CharacterID:  characterID,
+				EventID:      e.EventID,
+				LastNotified: e.Date,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(upcoming) == 0 {
+			return nil, nil
+		}
+		var title string
+		if len(upcoming) == 1 {
+			title = fmt.Sprintf("%s: %s", characterName, upcoming[0].Title)
+		} else {
+			title = fmt.Sprintf("%s: %d calendar events starting soon", characterName, len(upcoming))
+		}
+		var lines []string
+		for _, e := range upcoming {
+			minutes := int(e.Date.Sub(now).Round(time.Minute).Minutes())
+			lines = append(lines, fmt.Sprintf("%s starts in %d minutes at %s", e.Title, minutes, e.Date.UTC().Format(app.DateTimeFormat)))
+		}
+		notify(title, strings.Join(lines, "\n"))
+		slog.Info("Notified upcoming calendar events", "characterID", characterID, "count", len(upcoming))
+		return nil, nil
+	})
+	return err
+}
+
+// updateCalendarESI updates the calendar of a character from ESI.
+// ESI reports the next 50 upcoming events only, so all other events are removed.
+func (s *CharacterService) updateCalendarESI(ctx context.Context, arg characterSectionUpdateParams) (bool, error) {
+	if arg.section != app.SectionCharacterCalendar {
+		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
+	}
+	return s.updateSectionIfChanged(
+		ctx, arg, false,
+		func(ctx context.Context, characterID int64) (any, error) {
+			ctx = xgoesi.NewContextWithOperationID(ctx, "GetCharactersCharacterIdCalendar")
+			events, _, err := s.esiClient.CalendarAPI.GetCharactersCharacterIdCalendar(ctx, characterID).Execute()
+			if err != nil {
+				return false, err
+			}
+			events = slices.DeleteFunc(events, func(x esi.CharactersCharacterIdCalendarGetInner) bool {
+				return x.EventId == nil
+			})
+			slices.SortFunc(events, func(a, b esi.CharactersCharacterIdCalendarGetInner) int {
+				return cmp.Compare(*a.EventId, *b.EventId)
+			})
+			slog.Debug("Received calendar events from ESI", "characterID", characterID, "count", len(events))
+			return events, nil
+		},
+		func(ctx context.Context, characterID int64, data any) (bool, error) {
+			summaries := data.([]esi.CharactersCharacterIdCalendarGetInner)
+			type calendarEvent struct {
+				event     *esi.CharactersCharacterIdCalendarEventIdGet
+				attendees []esi.CharactersCharacterIdCalendarEventIdAttendeesGetInner
+			}
+			events := make([]calendarEvent, len(summaries))
+			g := new(errgroup.Group)
+			g.SetLimit(5)
+			for i, x := range summaries {
+				eventID := *x.EventId
+				g.Go(func() error {
+					ctx := xgoesi.NewContextWithOperationID(ctx, "GetCharactersCharacterIdCalendarEventId")
+					e, _, err := s.esiClient.CalendarAPI.GetCharactersCharacterIdCalendarEventId(ctx, characterID, eventID).Execute()
+					if err != nil {
+						return err
+					}
+					ctx = xgoesi.NewContextWithOperationID(ctx, "GetCharactersCharacterIdCalendarEventIdAttendees")
+					attendees, _, err := s.esiClient.CalendarAPI.GetCharactersCharacterIdCalendarEventIdAttendees(ctx, characterID, eventID).Execute()
+					if err != nil {
+						// attendees are not available for all events, e.g. for events from the EVE server
+						slog.Warn("Failed to fetch attendees for calendar event", "characterID", characterID, "eventID", eventID, "error", err)
+					}
+					events[i] = calendarEvent{event: e, attendees: attendees}
+					return nil
+				})
+			}
+			if err := g.Wait(); err != nil {
+				return false, err
+			}
+			var entityIDs set.Set[int64]
+			for _, x := range events {
+				for _, a := range x.attendees {
+					if a.CharacterId != nil {
+						entityIDs.Add(*a.CharacterId)
+					}
+				}
+			}
+			if _, err := s.eus.AddMissingEntities(ctx, entityIDs); err != nil {
+				return false, err
+			}
+			var incomingIDs set.Set[int64]
+			for _, x := range events {
+				e := x.event
+				attendees := make(map[int64]app.CalendarEventResponse)
+				for _, a := range x.attendees {
+					if a.CharacterId == nil || a.EventResponse == nil {
+						continue
+					}
+					attendees[*a.CharacterId] = app.CalendarEventResponse(*a.EventResponse)
+				}
+				err := s.st.UpdateOrCreateCharacterCalendarEvent(ctx, storage.UpdateOrCreateCharacterCalendarEventParams{
+					Attendees:   attendees,
+					CharacterID: characterID,
+					Date:        e.Date,
+					Duration:    time.Duration(e.Duration) * time.Minute,
+					EventID:     e.EventId,
+					Importance:  e.Importance,
+					OwnerID:     e.OwnerId,
+					OwnerName:   e.OwnerName,
+					OwnerType:   e.OwnerType,
+					Response:    app.CalendarEventResponse(e.Response),
+					Text:        e.Text,
+					Title:       e.Title,
+				})
+				if err != nil {
+					return false, err
+				}
+				incomingIDs.Add(e.EventId)
+			}
+			currentIDs, err := s.st.ListCharacterCalendarEventIDs(ctx, characterID)
+			if err != nil {
+				return false, err
+			}
+			if obsolete := set.Difference(currentIDs, incomingIDs); obsolete.Size() > 0 {
+				if err := s.st.DeleteCharacterCalendarEvents(ctx, characterID, obsolete); err != nil {
+					return false, err
+				}
+				slog.Info("Removed obsolete calendar events", "characterID", characterID, "count", obsolete.Size())
+			}
+			slog.Info("Stored updated calendar events", "characterID", characterID, "count", len(events))
+			return true, nil
+		})
+}
@@ -0,0 +1,154 @@
+package characterservice
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestUpdateCharacterCalendarESI(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	s := NewFake(Params{Storage: st})
+	ctx := context.Background()
+	t.Run("should create new events and remove obsolete ones", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		c := factory.CreateCharacter()
+		factory.CreateCharacterToken(storage.UpdateOrCreateCharacterTokenParams{CharacterID: c.ID})
+		owner := factory.CreateEveEntityCorporation()
+		attendee := factory.CreateEveEntityCharacter()
+		old := factory.CreateCharacterCalendarEvent(storage.UpdateOrCreateCharacterCalendarEventParams{
+			CharacterID: c.ID,
+		})
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/characters/%d/calendar", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+				{
+					"event_date":     "2025-10-01T18:00:00Z",
+					"event_id":       1386435,
+					"event_response": "accepted",
+					"importance":     1,
+					"title":          "Moon pop",
+				},
+			}),
+		)
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/characters/%d/calendar/1386435", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, map[string]any{
+				"date":       "2025-10-01T18:00:00Z",
+				"duration":   60,
+				"event_id":   1386435,
+				"importance": 1,
+				"owner_id":   owner.ID,
+				"owner_name": owner.Name,
+				"owner_type": "corporation",
+				"response":   "accepted",
+				"text":       "Bring a Hulk",
+				"title":      "Moon pop",
+			}),
+		)
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/characters/%d/calendar/1386435/attendees", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+				{
+					"character_id":   attendee.ID,
+					"event_response": "tentative",
+				},
+			}),
+		)
+		// when
+		changed, err := s.updateCalendarESI(ctx, characterSectionUpdateParams{
+			characterID: c.ID,
+			section:     app.SectionCharacterCalendar,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		ids, err := st.ListCharacterCalendarEventIDs(ctx, c.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, set.Of[int64](1386435), ids)
+		assert.False(t, ids.Contains(old.EventID))
+		e, err := st.GetCharacterCalendarEvent(ctx, c.ID, 1386435)
+		require.NoError(t, err)
+		assert.True(t, time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC).Equal(e.Date))
+		xassert.Equal(t, time.Hour, e.Duration)
+		xassert.Equal(t, "Moon pop", e.Title)
+		xassert.Equal(t, "Bring a Hulk", e.Text)
+		xassert.Equal(t, owner.ID, e.OwnerID)
+		xassert.Equal(t, app.CalendarEventResponseAccepted, e.Response)
+		attendees, err := s.ListCalendarEventAttendees(ctx, c.ID, 1386435)
+		require.NoError(t, err)
+		if assert.Len(t, attendees, 1) {
+			xassert.Equal(t, attendee.ID, attendees[0].Character.ID)
+			xassert.Equal(t, app.CalendarEventResponseTentative, attendees[0].Response)
+		}
+	})
+}
+
+func TestNotifyUpcomingCalendarEvents(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	s := NewFake(Params{Storage: st})
+	ctx := context.Background()
+	lead := 15 * time.Minute
+	t.Run("should notify upcoming events once", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		factory.CreateCharacterCalendarEvent(storage.UpdateOrCreateCharacterCalendarEventParams{
+			CharacterID: c.ID,
+			Date:        time.Now().Add(10 * time.Minute),
+		})
+		factory.CreateCharacterCalendarEvent(storage.UpdateOrCreateCharacterCalendarEventParams{
+			CharacterID: c.ID,
+			Date:        time.Now().Add(2 * time.Hour),
+		})
+		var sendCount int
+		notify := func(title, content string) {
+			sendCount++
+		}
+		// when
+		err1 := s.NotifyUpcomingCalendarEvents(ctx, c.ID, lead, notify)
+		err2 := s.NotifyUpcomingCalendarEvents(ctx, c.ID, lead, notify)
+		// then
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		xassert.Equal(t, 1, sendCount)
+	})
+	t.Run("should not notify declined events", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		factory.CreateCharacterCalendarEvent(storage.UpdateOrCreateCharacterCalendarEventParams{
+			CharacterID: c.ID,
+			Date:        time.Now().Add(10 * time.Minute),
+			Response:    app.CalendarEventResponseDeclined,
+		})
+		var sendCount int
+		// when
+		err := s.NotifyUpcomingCalendarEvents(ctx, c.ID, lead, func(title, content string) {
+			sendCount++
+		})
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, 0, sendCount)
+	})
+}
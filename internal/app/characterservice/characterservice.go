@@ -14,6 +14,7 @@ import (
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
 	"github.com/ErikKalkoken/evebuddy/internal/app/eveuniverseservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationsink"
 	"github.com/ErikKalkoken/evebuddy/internal/app/statuscache"
 	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
 	"github.com/ErikKalkoken/evebuddy/internal/optional"
@@ -39,18 +40,32 @@ type EVENotificationService interface {
 	RenderESI(ctx context.Context, nt app.EveNotificationType, text optional.Optional[string], timestamp time.Time) (title string, body string, err error)
 }
 
+// NotificationRules decides how notifications are delivered, e.g. during quiet hours.
+type NotificationRules interface {
+	Apply(ctx context.Context, n notificationsink.Notification) (notificationsink.Notification, bool)
+	TakeDigest() (notificationsink.Notification, bool)
+}
+
 type Settings interface {
 	ApprovedContactCost() int
 	MarketOrderRetentionDays() int
 	MaxMails() int
 	MaxWalletTransactions() int
 	NotificationTypesEnabled() set.Set[string]
+	NotifyCalendarEnabled() bool
+	NotifyCalendarLeadMinutes() int
 	NotifyCommunicationsEarliest() time.Time
 	NotifyCommunicationsEnabled() bool
 	NotifyContractsEarliest() time.Time
 	NotifyContractsEnabled() bool
+	NotifyIndustryJobsEarliest() time.Time
+	NotifyIndustryJobsEnabled() bool
+	NotifyJumpFatigueEnabled() bool
 	NotifyMailsEarliest() time.Time
 	NotifyMailsEnabled() bool
+	NotifyMarketOrdersEarliest() time.Time
+	NotifyMarketOrdersEnabled() bool
+	NotifyMarketUndercutEnabled() bool
 	NotifyPIEarliest() time.Time
 	NotifyPIEnabled() bool
 	NotifyTrainingEnabled() bool
@@ -71,6 +86,9 @@ type CharacterService struct {
 	esiClient               *esi.APIClient
 	eus                     *eveuniverseservice.EVEUniverseService
 	httpClient              *http.Client
+	isNotifyDisabled        bool                  // Skips all notification hooks during updates, e.g. for headless updates
+	notificationRules       NotificationRules     // Optional rules for delivering notifications
+	notificationSink        notificationsink.Sink // Optional destination for notifications in addition to the desktop
 	scs                     StatusCache
 	sendDesktopNotification func(title, content string) // Callback for sending a desktop notification via Fyne API
 	settings                Settings
@@ -92,7 +110,10 @@ type Params struct {
 	StatusCacheService     StatusCache
 	Storage                *storage.Storage
 	// optional
+	DisableNotifications    bool // Skips all notification hooks during updates
 	HTTPClient              *http.Client
+	NotificationRules       NotificationRules
+	NotificationSink        notificationsink.Sink
 	SendDesktopNotification func(title, content string)
 }
 
@@ -127,13 +148,16 @@ func New(arg Params) *CharacterService {
 		panic("Storage missing")
 	}
 	s := &CharacterService{
-		authClient:       arg.AuthClient,
-		cache:            arg.Cache,
-		concurrencyLimit: -1, // Default is no limit
-		ens:              arg.EveNotificationService,
-		esiClient:        arg.ESIClient,
-		eus:              arg.EveUniverseService,
-		scs:              arg.StatusCacheService,
+		authClient:        arg.AuthClient,
+		cache:             arg.Cache,
+		concurrencyLimit:  -1, // Default is no limit
+		ens:               arg.EveNotificationService,
+		esiClient:         arg.ESIClient,
+		eus:               arg.EveUniverseService,
+		isNotifyDisabled:  arg.DisableNotifications,
+		notificationRules: arg.NotificationRules,
+		notificationSink:  arg.NotificationSink,
+		scs:               arg.StatusCacheService,
 		sendDesktopNotification: func(_, _ string) {
 			slog.Warn("Desktop notifications not configured")
 		},
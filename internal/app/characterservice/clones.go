@@ -30,6 +30,23 @@ func (s *CharacterService) ListJumpClones(ctx context.Context, characterID int64
 	return s.st.ListCharacterJumpClones(ctx, characterID)
 }
 
+// ListCharactersWithJumpTimers returns all characters
+// with the calculated field for the next clone jump.
+func (s *CharacterService) ListCharactersWithJumpTimers(ctx context.Context) ([]*app.Character, error) {
+	characters, err := s.st.ListCharacters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range characters {
+		x, err := s.calcNextCloneJump(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		c.NextCloneJump = x
+	}
+	return characters, nil
+}
+
 // calcNextCloneJump returns when the next clone jump is available.
 // It returns a zero time when a jump is available now.
 // It returns empty when a jump could not be calculated.
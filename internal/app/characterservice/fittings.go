@@ -0,0 +1,214 @@
+package characterservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strings"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/fnt-eve/goesi-openapi/esi"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
+)
+
+// CheckFittingSkills reports for every character which skills are missing to fly a fitting.
+// Required skills are resolved from the dogma attributes of the ship and its fitted items.
+// Types which are not yet known to the app are fetched from ESI first.
+func (s *CharacterService) CheckFittingSkills(ctx context.Context, cf *app.CharacterFitting) ([]*app.CharacterFittingSkillCheck, error) {
+	typeIDs := cf.SkillCheckTypeIDs()
+	if err := s.eus.AddMissingTypes(ctx, typeIDs); err != nil {
+		return nil, err
+	}
+	required := make(map[int64]int)
+	for typeID := range typeIDs.All() {
+		oo, err := s.eus.ListTypeDogmaAttributesForType(ctx, typeID)
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[int64]float64)
+		for _, o := range oo {
+			values[o.DogmaAttribute.ID] = o.Value
+		}
+		for skillID, level := range app.RequiredSkillsFromDogma(values) {
+			required[skillID] = max(required[skillID], level)
+		}
+	}
+	skills, err := s.eveSkillsMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Prerequisites can only be resolved for known skills,
+	// so missing skills are fetched until all required skills are known.
+	for {
+		required = app.ExpandSkillRequirements(required, skills)
+		missing, err := s.st.MissingEveTypes(ctx, set.Collect(maps.Keys(required)))
+		if err != nil {
+			return nil, err
+		}
+		missing.Delete(0) // ignore invalid ID
+		if missing.Size() == 0 {
+			break
+		}
+		if err := s.eus.AddMissingTypes(ctx, missing); err != nil {
+			return nil, err
+		}
+		skills, err = s.eveSkillsMap(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	characters, err := s.st.ListCharactersShort(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []*app.CharacterFittingSkillCheck
+	for _, c := range characters {
+		characterSkills, err := s.characterSkillsMap(ctx, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		ca, err := s.st.GetCharacterAttributes(ctx, c.ID)
+		if err != nil && !errors.Is(err, app.ErrNotFound) {
+			return nil, err
+		}
+		var attributes optional.Optional[*app.CharacterAttributes]
+		if err == nil {
+			attributes.Set(ca)
+		}
+		results = append(results, &app.CharacterFittingSkillCheck{
+			Character:     c,
+			MissingSkills: app.CalcMissingSkills(required, skills, characterSkills, attributes),
+		})
+	}
+	return results, nil
+}
+
+// GetFitting returns a saved fitting of a character.
+func (s *CharacterService) GetFitting(ctx context.Context, characterID, fittingID int64) (*app.CharacterFitting, error) {
+	return s.st.GetCharacterFitting(ctx, characterID, fittingID)
+}
+
+// ListFittings returns the saved fittings of a character.
+func (s *CharacterService) ListFittings(ctx context.Context, characterID int64) ([]*app.CharacterFitting, error) {
+	return s.st.ListCharacterFittings(ctx, characterID)
+}
+
+// MakeFittingEFT returns a saved fitting of a character in EFT format.
+func (s *CharacterService) MakeFittingEFT(ctx context.Context, characterID, fittingID int64) (string, error) {
+	cf, err := s.st.GetCharacterFitting(ctx, characterID, fittingID)
+	if err != nil {
+		return "", err
+	}
+	return cf.EFT(), nil
+}
+
+// ImportFittingEFT returns a fitting from text in EFT format.
+// Types which are not yet known to the app are resolved by name from ESI.
+// The fitting is not stored.
+func (s *CharacterService) ImportFittingEFT(ctx context.Context, text string) (*app.CharacterFitting, error) {
+	f, err := app.ParseEFT(text)
+	if err != nil {
+		return nil, err
+	}
+	names := f.TypeNames()
+	ee, err := s.st.ListEveTypesForNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	types := make(map[string]*app.EveType)
+	for _, et := range ee {
+		types[et.Name] = et
+	}
+	var missing []string
+	for _, n := range names {
+		if _, ok := types[n]; !ok {
+			missing = append(missing, n)
+		}
+	}
+	if len(missing) > 0 {
+		ids, err := s.eus.ResolveTypeNamesESI(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.eus.AddMissingTypes(ctx, set.Collect(maps.Values(ids))); err != nil {
+			return nil, err
+		}
+		for name, id := range ids {
+			et, err := s.eus.GetType(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			// names from ESI can differ in case from the names in the fitting
+			for _, n := range missing {
+				if strings.EqualFold(n, name) {
+					types[n] = et
+				}
+			}
+		}
+	}
+	return f.ToCharacterFitting(types)
+}
+
+func (s *CharacterService) updateFittingsESI(ctx context.Context, arg characterSectionUpdateParams) (bool, error) {
+	if arg.section != app.SectionCharacterFittings {
+		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
+	}
+	return s.updateSectionIfChanged(
+		ctx, arg, false,
+		func(ctx context.Context, characterID int64) (any, error) {
+			ctx = xgoesi.NewContextWithOperationID(ctx, "GetCharactersCharacterIdFittings")
+			fittings, _, err := s.esiClient.FittingsAPI.GetCharactersCharacterIdFittings(ctx, characterID).Execute()
+			if err != nil {
+				return false, err
+			}
+			slog.Debug("Received fittings from ESI", "characterID", characterID, "count", len(fittings))
+			return fittings, nil
+		},
+		func(ctx context.Context, characterID int64, data any) (bool, error) {
+			fittings := data.([]esi.CharactersCharacterIdFittingsGetInner)
+			var typeIDs set.Set[int64]
+			for _, f := range fittings {
+				typeIDs.Add(f.ShipTypeId)
+				for _, it := range f.Items {
+					typeIDs.Add(it.TypeId)
+				}
+			}
+			if err := s.eus.AddMissingTypes(ctx, typeIDs); err != nil {
+				return false, err
+			}
+			args := make([]storage.CreateCharacterFittingParams, len(fittings))
+			for i, f := range fittings {
+				items := make([]storage.CreateCharacterFittingItemParams, len(f.Items))
+				for j, it := range f.Items {
+					flag, found := locationFlagFromESIValue[it.Flag]
+					if !found {
+						flag = app.FlagUnknown
+					}
+					items[j] = storage.CreateCharacterFittingItemParams{
+						Flag:     flag,
+						Quantity: it.Quantity,
+						TypeID:   it.TypeId,
+					}
+				}
+				args[i] = storage.CreateCharacterFittingParams{
+					CharacterID: characterID,
+					Description: f.Description,
+					FittingID:   f.FittingId,
+					Items:       items,
+					Name:        f.Name,
+					ShipTypeID:  f.ShipTypeId,
+				}
+			}
+			if err := s.st.ReplaceCharacterFittings(ctx, characterID, args); err != nil {
+				return false, err
+			}
+			slog.Info("Stored updated fittings", "characterID", characterID, "count", len(fittings))
+			return true, nil
+		})
+}
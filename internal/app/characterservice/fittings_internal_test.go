@@ -0,0 +1,273 @@
+package characterservice
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestUpdateCharacterFittingsESI(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	s := NewFake(Params{Storage: st})
+	ctx := context.Background()
+	t.Run("should replace fittings with fittings from ESI", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		c := factory.CreateCharacter()
+		factory.CreateCharacterToken(storage.UpdateOrCreateCharacterTokenParams{CharacterID: c.ID})
+		old := factory.CreateCharacterFitting(storage.CreateCharacterFittingParams{CharacterID: c.ID})
+		ship := factory.CreateEveType()
+		module := factory.CreateEveType()
+		drone := factory.CreateEveType()
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/characters/%d/fittings", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"description": "Awesome Vindi fitting",
+				"fitting_id":  1,
+				"items": []map[string]any{
+					{
+						"flag":     "HiSlot0",
+						"quantity": 1,
+						"type_id":  module.ID,
+					},
+					{
+						"flag":     "DroneBay",
+						"quantity": 5,
+						"type_id":  drone.ID,
+					},
+				},
+				"name":         "Best Vindicator",
+				"ship_type_id": ship.ID,
+			}}),
+		)
+		// when
+		changed, err := s.updateFittingsESI(ctx, characterSectionUpdateParams{
+			characterID: c.ID,
+			section:     app.SectionCharacterFittings,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		_, err = st.GetCharacterFitting(ctx, c.ID, old.FittingID)
+		assert.ErrorIs(t, err, app.ErrNotFound)
+		cf, err := st.GetCharacterFitting(ctx, c.ID, 1)
+		require.NoError(t, err)
+		xassert.Equal(t, "Best Vindicator", cf.Name)
+		xassert.Equal(t, "Awesome Vindi fitting", cf.Description)
+		xassert.Equal(t, ship.ID, cf.ShipType.ID)
+		want := []*app.CharacterFittingItem{
+			{Flag: app.FlagHiSlot0, Quantity: 1, Type: module},
+			{Flag: app.FlagDroneBay, Quantity: 5, Type: drone},
+		}
+		xassert.Equal(t, want, cf.Items)
+	})
+}
+
+func TestImportFittingEFT(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	s := NewFake(Params{Storage: st})
+	ctx := context.Background()
+	t.Run("should resolve fitting from known types", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		ship := factory.CreateEveType(storage.CreateEveTypeParams{Name: "Rifter"})
+		module := factory.CreateEveType(storage.CreateEveTypeParams{Name: "200mm AutoCannon I"})
+		text := "[Rifter, Alpha]\n\n[Empty Low slot]\n\n[Empty Med slot]\n\n200mm AutoCannon I\n"
+		// when
+		cf, err := s.ImportFittingEFT(ctx, text)
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, "Alpha", cf.Name)
+		xassert.Equal(t, ship, cf.ShipType)
+		want := []*app.CharacterFittingItem{
+			{Flag: app.FlagHiSlot0, Quantity: 1, Type: module},
+		}
+		xassert.Equal(t, want, cf.Items)
+	})
+	t.Run("should fetch types which are not yet known", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		ship := factory.CreateEveType(storage.CreateEveTypeParams{Name: "Rifter"})
+		group := factory.CreateEveGroup()
+		httpmock.RegisterResponder(
+			"POST",
+			"https://esi.evetech.net/universe/ids",
+			httpmock.NewJsonResponderOrPanic(200, map[string]any{
+				"inventory_types": []map[string]any{{
+					"id":   2873,
+					"name": "125mm Gatling AutoCannon I",
+				}},
+			}),
+		)
+		httpmock.RegisterResponder(
+			"GET",
+			"https://esi.evetech.net/universe/types/2873",
+			httpmock.NewJsonResponderOrPanic(200, map[string]any{
+				"description": "A weapon",
+				"group_id":    group.ID,
+				"name":        "125mm Gatling AutoCannon I",
+				"published":   true,
+				"type_id":     2873,
+			}),
+		)
+		text := "[Rifter, Alpha]\n\n[Empty Low slot]\n\n[Empty Med slot]\n\n125mm Gatling AutoCannon I\n"
+		// when
+		cf, err := s.ImportFittingEFT(ctx, text)
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, ship, cf.ShipType)
+		if assert.Len(t, cf.Items, 1) {
+			xassert.Equal(t, app.FlagHiSlot0, cf.Items[0].Flag)
+			xassert.Equal(t, int64(2873), cf.Items[0].Type.ID)
+		}
+	})
+	t.Run("should return error when types are unknown", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		factory.CreateEveType(storage.CreateEveTypeParams{Name: "Rifter"})
+		httpmock.RegisterResponder(
+			"POST",
+			"https://esi.evetech.net/universe/ids",
+			httpmock.NewJsonResponderOrPanic(200, map[string]any{}),
+		)
+		text := "[Rifter, Alpha]\n\nUnknown Module\n"
+		// when
+		_, err := s.ImportFittingEFT(ctx, text)
+		// then
+		assert.ErrorIs(t, err, app.ErrNotFound)
+	})
+}
+
+func TestCheckFittingSkills(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	s := NewFake(Params{Storage: st})
+	ctx := context.Background()
+	t.Run("should report missing skills for each character", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		category := factory.CreateEveCategory(storage.CreateEveCategoryParams{ID: app.EveCategorySkill})
+		group := factory.CreateEveGroup(storage.CreateEveGroupParams{CategoryID: category.ID, IsPublished: true})
+		skill := factory.CreateEveType(storage.CreateEveTypeParams{GroupID: group.ID, IsPublished: true})
+		ship := factory.CreateEveType()
+		primarySkillType := factory.CreateEveDogmaAttribute(storage.CreateEveDogmaAttributeParams{
+			ID: app.EveDogmaAttributePrimarySkillID,
+		})
+		primarySkillLevel := factory.CreateEveDogmaAttribute(storage.CreateEveDogmaAttributeParams{
+			ID: app.EveDogmaAttributePrimarySkillLevel,
+		})
+		factory.CreateEveTypeDogmaAttribute(storage.CreateEveTypeDogmaAttributeParams{
+			EveTypeID:        ship.ID,
+			DogmaAttributeID: primarySkillType.ID,
+			Value:            float64(skill.ID),
+		})
+		factory.CreateEveTypeDogmaAttribute(storage.CreateEveTypeDogmaAttributeParams{
+			EveTypeID:        ship.ID,
+			DogmaAttributeID: primarySkillLevel.ID,
+			Value:            float64(2),
+		})
+		c1 := factory.CreateCharacter()
+		factory.CreateCharacterSkill(storage.UpdateOrCreateCharacterSkillParams{
+			ActiveSkillLevel:  3,
+			CharacterID:       c1.ID,
+			TrainedSkillLevel: 3,
+			TypeID:            skill.ID,
+		})
+		c2 := factory.CreateCharacter()
+		cf := &app.CharacterFitting{ShipType: ship}
+		// when
+		oo, err := s.CheckFittingSkills(ctx, cf)
+		// then
+		require.NoError(t, err)
+		got := make(map[int64]*app.CharacterFittingSkillCheck)
+		for _, o := range oo {
+			got[o.Character.ID] = o
+		}
+		require.Len(t, got, 2)
+		assert.True(t, got[c1.ID].CanFly())
+		assert.False(t, got[c2.ID].CanFly())
+		if assert.Len(t, got[c2.ID].MissingSkills, 1) {
+			xassert.Equal(t, skill.ID, got[c2.ID].MissingSkills[0].Type.ID)
+			xassert.Equal(t, 2, got[c2.ID].MissingSkills[0].RequiredLevel)
+		}
+	})
+	t.Run("should fetch missing types and skills from ESI", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		category := factory.CreateEveCategory(storage.CreateEveCategoryParams{ID: app.EveCategorySkill})
+		skillGroup := factory.CreateEveGroup(storage.CreateEveGroupParams{CategoryID: category.ID, IsPublished: true})
+		shipGroup := factory.CreateEveGroup()
+		factory.CreateEveDogmaAttribute(storage.CreateEveDogmaAttributeParams{
+			ID: app.EveDogmaAttributePrimarySkillID,
+		})
+		factory.CreateEveDogmaAttribute(storage.CreateEveDogmaAttributeParams{
+			ID: app.EveDogmaAttributePrimarySkillLevel,
+		})
+		httpmock.RegisterResponder(
+			"GET",
+			"https://esi.evetech.net/universe/types/587",
+			httpmock.NewJsonResponderOrPanic(200, map[string]any{
+				"description": "The Rifter is a...",
+				"dogma_attributes": []map[string]any{{
+					"attribute_id": app.EveDogmaAttributePrimarySkillID,
+					"value":        3329,
+				}, {
+					"attribute_id": app.EveDogmaAttributePrimarySkillLevel,
+					"value":        1,
+				}},
+				"group_id":  shipGroup.ID,
+				"name":      "Rifter",
+				"published": true,
+				"type_id":   587,
+			}),
+		)
+		httpmock.RegisterResponder(
+			"GET",
+			"https://esi.evetech.net/universe/types/3329",
+			httpmock.NewJsonResponderOrPanic(200, map[string]any{
+				"description": "Skill at operating Minmatar frigates.",
+				"group_id":    skillGroup.ID,
+				"name":        "Minmatar Frigate",
+				"published":   true,
+				"type_id":     3329,
+			}),
+		)
+		c := factory.CreateCharacter()
+		cf := &app.CharacterFitting{ShipType: &app.EveType{ID: 587}}
+		// when
+		oo, err := s.CheckFittingSkills(ctx, cf)
+		// then
+		require.NoError(t, err)
+		require.Len(t, oo, 1)
+		xassert.Equal(t, c.ID, oo[0].Character.ID)
+		if assert.Len(t, oo[0].MissingSkills, 1) {
+			xassert.Equal(t, int64(3329), oo[0].MissingSkills[0].Type.ID)
+			xassert.Equal(t, "Minmatar Frigate", oo[0].MissingSkills[0].Type.Name)
+			xassert.Equal(t, 1, oo[0].MissingSkills[0].RequiredLevel)
+		}
+	})
+}
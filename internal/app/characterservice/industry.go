@@ -30,6 +30,61 @@ func (s *CharacterService) ListAllCharacterIndustryJob(ctx context.Context) ([]*
 	return s.st.ListAllCharacterIndustryJob(ctx)
 }
 
+const cacheKeyIndustryJobReadyNotified = "industry-job-ready-notified"
+
+// industryJobReadyNotifyTimeout is how long a ready job is not notified again.
+// It exceeds the maximum notify timeout, so that jobs are notified once only.
+const industryJobReadyNotifyTimeout = 100 * 24 * time.Hour
+
+// NotifyReadyIndustryJobs sends a notification when industry jobs of a character
+// have become ready for delivery. Each job is notified once only.
+func (s *CharacterService) NotifyReadyIndustryJobs(ctx context.Context, characterID int64, earliest time.Time, notify func(title, content string)) error {
+	_, err, _ := s.sfg.Do(fmt.Sprintf("NotifyReadyIndustryJobs-%d", characterID), func() (any, error) {
+		jobs, err := s.st.ListCharacterIndustryJobs(ctx, characterID)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		var lines []string
+		for _, j := range jobs {
+			if !j.IsReady(now) || j.EndDate.Before(earliest) {
+				continue
+			}
+			key := makeKeyIndustryJobReadyNotified(j.JobID)
+			if _, found := s.cache.GetInt64(key); found {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf(
+				"%s job for %s at %s",
+				j.Activity.Display(),
+				j.BlueprintType.Name,
+				j.Station.DisplayName(),
+			))
+			s.cache.SetInt64(key, j.EndDate.Unix(), industryJobReadyNotifyTimeout)
+		}
+		if len(lines) == 0 {
+			return nil, nil
+		}
+		characterName, err := s.getCharacterName(ctx, characterID)
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(lines)
+		title := fmt.Sprintf("%s: %d industry job(s) ready", characterName, len(lines))
+		notify(title, strings.Join(lines, "\n"))
+		slog.Info("Notified ready industry jobs", "characterID", characterID, "count", len(lines))
+		return nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("NotifyReadyIndustryJobs for character %d: %w", characterID, err)
+	}
+	return nil
+}
+
+func makeKeyIndustryJobReadyNotified(jobID int64) string {
+	return fmt.Sprintf("%s-%d", cacheKeyIndustryJobReadyNotified, jobID)
+}
+
 func (s *CharacterService) ListAllCharactersIndustrySlots(ctx context.Context, typ app.IndustryJobType) ([]app.CharacterIndustrySlots, error) {
 	total := make(map[int64]int)
 	switch typ {
@@ -3,6 +3,7 @@ package characterservice_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
 	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
 	"github.com/ErikKalkoken/evebuddy/internal/app/testutil/testdouble"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
 )
 
 func TestListAllCharactersIndustrySlots(t *testing.T) {
@@ -188,3 +190,47 @@ func TestListAllCharactersIndustrySlots(t *testing.T) {
 		}
 	})
 }
+
+func TestNotifyReadyIndustryJobs(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	earliest := now.Add(-24 * time.Hour)
+	cases := []struct {
+		name         string
+		status       app.IndustryJobStatus
+		endDate      time.Time
+		shouldNotify bool
+	}{
+		{"ready job", app.JobReady, now.Add(-3 * time.Hour), true},
+		{"active job after end date", app.JobActive, now.Add(-3 * time.Hour), true},
+		{"active job before end date", app.JobActive, now.Add(3 * time.Hour), false},
+		{"ready job before earliest", app.JobReady, now.Add(-48 * time.Hour), false},
+		{"delivered job", app.JobDelivered, now.Add(-3 * time.Hour), false},
+		{"cancelled job", app.JobCancelled, now.Add(-3 * time.Hour), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			testutil.MustTruncateTables(db)
+			cs := testdouble.NewCharacterServiceFake(characterservice.Params{Storage: st})
+			j := factory.CreateCharacterIndustryJob(storage.UpdateOrCreateCharacterIndustryJobParams{
+				EndDate: tc.endDate,
+				Status:  tc.status,
+			})
+			var count int
+			notify := func(title, content string) {
+				count++
+			}
+			// when
+			err1 := cs.NotifyReadyIndustryJobs(ctx, j.CharacterID, earliest, notify)
+			err2 := cs.NotifyReadyIndustryJobs(ctx, j.CharacterID, earliest, notify)
+			// then
+			if assert.NoError(t, err1) && assert.NoError(t, err2) {
+				xassert.Equal(t, tc.shouldNotify, count == 1)
+				assert.LessOrEqual(t, count, 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package characterservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fnt-eve/goesi-openapi/esi"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
+)
+
+const cacheKeyJumpFatigueNotified = "expired-jump-fatigue-notified"
+
+// jumpFatigueNotifyTimeout is the maximum age of an expired jump fatigue to still be notified.
+const jumpFatigueNotifyTimeout = 24 * time.Hour
+
+func (s *CharacterService) UpdateIsJumpFatigueWatched(ctx context.Context, characterID int64, v bool) error {
+	s.cache.Delete(makeKeyJumpFatigueNotified(characterID))
+	return s.st.UpdateCharacterIsJumpFatigueWatched(ctx, characterID, v)
+}
+
+// NotifyExpiredJumpFatigueForWatched sends a notification when the jump fatigue
+// of a watched character has expired. Each expiry is notified once only.
+func (s *CharacterService) NotifyExpiredJumpFatigueForWatched(ctx context.Context, characterID int64, notify func(title, content string)) error {
+	_, err, _ := s.sfg.Do(fmt.Sprintf("NotifyExpiredJumpFatigue-%d", characterID), func() (any, error) {
+		c, err := s.GetCharacter(ctx, characterID)
+		if err != nil {
+			return nil, err
+		}
+		if !c.IsJumpFatigueWatched {
+			return nil, nil
+		}
+		expires, ok := c.JumpFatigueExpiresAt.Value()
+		if !ok {
+			return nil, nil
+		}
+		now := time.Now()
+		if expires.After(now) || now.Sub(expires) > jumpFatigueNotifyTimeout {
+			return nil, nil
+		}
+		key := makeKeyJumpFatigueNotified(characterID)
+		v, found := s.cache.GetInt64(key)
+		if found && v == expires.Unix() {
+			return nil, nil
+		}
+		title := fmt.Sprintf("%s: Jump fatigue expired", c.EveCharacter.Name)
+		content := fmt.Sprintf(
+			"The jump fatigue of the watched character %s expired at %s.",
+			c.EveCharacter.Name,
+			expires.UTC().Format(app.DateTimeFormat),
+		)
+		notify(title, content)
+		s.cache.SetInt64(key, expires.Unix(), 2*jumpFatigueNotifyTimeout)
+		return nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("NotifyExpiredJumpFatigue for character %d: %w", characterID, err)
+	}
+	return nil
+}
+
+func makeKeyJumpFatigueNotified(characterID int64) string {
+	return fmt.Sprintf("%s-%d", cacheKeyJumpFatigueNotified, characterID)
+}
+
+func (s *CharacterService) updateJumpFatigueESI(ctx context.Context, arg characterSectionUpdateParams) (bool, error) {
+	if arg.section != app.SectionCharacterJumpFatigue {
+		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
+	}
+	return s.updateSectionIfChanged(
+		ctx, arg, false,
+		func(ctx context.Context, characterID int64) (any, error) {
+			ctx = xgoesi.NewContextWithOperationID(ctx, "GetCharactersCharacterIdFatigue")
+			fatigue, _, err := s.esiClient.CharacterAPI.GetCharactersCharacterIdFatigue(ctx, characterID).Execute()
+			if err != nil {
+				return false, err
+			}
+			return fatigue, nil
+		},
+		func(ctx context.Context, characterID int64, data any) (bool, error) {
+			fatigue := data.(*esi.CharactersCharacterIdFatigueGet)
+			err := s.st.UpdateCharacterJumpFatigue(
+				ctx,
+				characterID,
+				optional.FromPtr(fatigue.JumpFatigueExpireDate),
+				optional.FromPtr(fatigue.LastJumpDate),
+			)
+			if err != nil {
+				return false, err
+			}
+			return true, nil
+		})
+}
@@ -0,0 +1,163 @@
+package characterservice
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestCharacterService_UpdateJumpFatigueESI(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	s := NewFake(Params{Storage: st})
+	ctx := context.Background()
+
+	t.Run("should store jump fatigue", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		c := factory.CreateCharacter()
+		factory.CreateCharacterToken(storage.UpdateOrCreateCharacterTokenParams{CharacterID: c.ID})
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/characters/%d/fatigue", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, map[string]any{
+				"jump_fatigue_expire_date": "2025-10-01T16:00:00Z",
+				"last_jump_date":           "2025-10-01T12:00:00Z",
+				"last_update_date":         "2025-10-01T12:00:00Z",
+			}),
+		)
+		// when
+		changed, err := s.updateJumpFatigueESI(ctx, characterSectionUpdateParams{
+			characterID: c.ID,
+			section:     app.SectionCharacterJumpFatigue,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		c2, err := s.GetCharacter(ctx, c.ID)
+		require.NoError(t, err)
+		xassert.EqualOptional(t, time.Date(2025, 10, 1, 16, 0, 0, 0, time.UTC), c2.JumpFatigueExpiresAt)
+		xassert.EqualOptional(t, time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC), c2.LastJumpAt)
+	})
+
+	t.Run("should reset jump fatigue when character has never jumped", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		c := factory.CreateCharacter(storage.CreateCharacterParams{
+			JumpFatigueExpiresAt: optional.New(time.Now().Add(time.Hour)),
+			LastJumpAt:           optional.New(time.Now().Add(-time.Hour)),
+		})
+		factory.CreateCharacterToken(storage.UpdateOrCreateCharacterTokenParams{CharacterID: c.ID})
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/characters/%d/fatigue", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, map[string]any{}),
+		)
+		// when
+		_, err := s.updateJumpFatigueESI(ctx, characterSectionUpdateParams{
+			characterID: c.ID,
+			section:     app.SectionCharacterJumpFatigue,
+		})
+		// then
+		require.NoError(t, err)
+		c2, err := s.GetCharacter(ctx, c.ID)
+		require.NoError(t, err)
+		assert.True(t, c2.JumpFatigueExpiresAt.IsEmpty())
+		assert.True(t, c2.LastJumpAt.IsEmpty())
+	})
+}
+
+func TestCharacterService_NotifyExpiredJumpFatigueForWatched(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	ctx := context.Background()
+
+	t.Run("should notify once when fatigue of watched character expired", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		s := NewFake(Params{Storage: st})
+		c := factory.CreateCharacterFull(storage.CreateCharacterParams{
+			IsJumpFatigueWatched: true,
+			JumpFatigueExpiresAt: optional.New(time.Now().Add(-5 * time.Minute)),
+		})
+		var sendCount int
+		notify := func(title, content string) {
+			sendCount++
+		}
+		// when
+		err1 := s.NotifyExpiredJumpFatigueForWatched(ctx, c.ID, notify)
+		err2 := s.NotifyExpiredJumpFatigueForWatched(ctx, c.ID, notify)
+		// then
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		xassert.Equal(t, 1, sendCount)
+	})
+
+	t.Run("should notify again when fatigue expired a second time", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		s := NewFake(Params{Storage: st})
+		c := factory.CreateCharacterFull(storage.CreateCharacterParams{
+			IsJumpFatigueWatched: true,
+			JumpFatigueExpiresAt: optional.New(time.Now().Add(-2 * time.Hour)),
+		})
+		var sendCount int
+		notify := func(title, content string) {
+			sendCount++
+		}
+		err := s.NotifyExpiredJumpFatigueForWatched(ctx, c.ID, notify)
+		require.NoError(t, err)
+		err = st.UpdateCharacterJumpFatigue(ctx, c.ID, optional.New(time.Now().Add(-5*time.Minute)), optional.Optional[time.Time]{})
+		require.NoError(t, err)
+		// when
+		err = s.NotifyExpiredJumpFatigueForWatched(ctx, c.ID, notify)
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, 2, sendCount)
+	})
+
+	cases := []struct {
+		name      string
+		isWatched bool
+		expiresAt optional.Optional[time.Time]
+	}{
+		{"not watched", false, optional.New(time.Now().Add(-5 * time.Minute))},
+		{"fatigue not yet expired", true, optional.New(time.Now().Add(5 * time.Minute))},
+		{"fatigue expired long ago", true, optional.New(time.Now().Add(-48 * time.Hour))},
+		{"no fatigue", true, optional.Optional[time.Time]{}},
+	}
+	for _, tc := range cases {
+		t.Run("should not notify when "+tc.name, func(t *testing.T) {
+			// given
+			testutil.MustTruncateTables(db)
+			s := NewFake(Params{Storage: st})
+			c := factory.CreateCharacterFull(storage.CreateCharacterParams{
+				IsJumpFatigueWatched: tc.isWatched,
+				JumpFatigueExpiresAt: tc.expiresAt,
+			})
+			var sendCount int
+			// when
+			err := s.NotifyExpiredJumpFatigueForWatched(ctx, c.ID, func(title, content string) {
+				sendCount++
+			})
+			// then
+			require.NoError(t, err)
+			xassert.Equal(t, 0, sendCount)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package characterservice
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/fnt-eve/goesi-openapi/esi"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
+)
+
+// ListKillmails returns the kills and losses of a character with the most recent first.
+func (s *CharacterService) ListKillmails(ctx context.Context, characterID int64) ([]*app.EveKillmail, error) {
+	return s.st.ListCharacterKillmails(ctx, characterID)
+}
+
+func (s *CharacterService) updateKillmailsESI(ctx context.Context, arg characterSectionUpdateParams) (bool, error) {
+	if arg.section != app.SectionCharacterKillmails {
+		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
+	}
+	return s.updateSectionIfChanged(
+		ctx, arg, true,
+		func(ctx context.Context, characterID int64) (any, error) {
+			ctx = xgoesi.NewContextWithOperationID(ctx, "GetCharactersCharacterIdKillmailsRecent")
+			rows, err := xgoesi.FetchPages(
+				func(page int32) ([]esi.CharactersCharacterIdKillmailsRecentGetInner, *http.Response, error) {
+					return s.esiClient.KillmailsAPI.GetCharactersCharacterIdKillmailsRecent(ctx, characterID).Page(page).Execute()
+				},
+			)
+			if err != nil {
+				return nil, err
+			}
+			slog.Debug("Received killmails from ESI", "count", len(rows), "characterID", characterID)
+			return rows, nil
+		},
+		func(ctx context.Context, characterID int64, data any) (bool, error) {
+			rows := data.([]esi.CharactersCharacterIdKillmailsRecentGetInner)
+			currentIDs, err := s.st.ListCharacterKillmailIDs(ctx, characterID)
+			if err != nil {
+				return false, err
+			}
+			var added set.Set[int64]
+			for _, r := range rows {
+				if currentIDs.Contains(r.KillmailId) {
+					continue
+				}
+				// Killmails are shared between characters and only need to be fetched once
+				km, err := s.eus.GetOrCreateKillmailESI(ctx, r.KillmailId, r.KillmailHash)
+				if err != nil {
+					return false, err
+				}
+				if err := s.st.CreateCharacterKillmail(ctx, characterID, km.ID); err != nil {
+					return false, err
+				}
+				added.Add(km.ID)
+			}
+			if added.Size() == 0 {
+				return false, nil
+			}
+			slog.Info("Added killmails", "characterID", characterID, "count", added.Size())
+			return true, nil
+		})
+}
@@ -0,0 +1,170 @@
+package characterservice
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestUpdateCharacterKillmailsESI(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	s := NewFake(Params{Storage: st})
+	ctx := context.Background()
+	t.Run("should fetch new killmails and link them to the character", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		c := factory.CreateCharacter()
+		factory.CreateCharacterToken(storage.UpdateOrCreateCharacterTokenParams{CharacterID: c.ID})
+		system := factory.CreateEveSolarSystem()
+		ship := factory.CreateEveType()
+		weapon := factory.CreateEveType()
+		module := factory.CreateEveType()
+		charge := factory.CreateEveType()
+		victim := factory.CreateEveEntityCharacter()
+		victimCorporation := factory.CreateEveEntityCorporation()
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/characters/%d/killmails/recent", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"killmail_hash": "8eef5e8fb6b88fe3407c489df33822b2e3b57a5e",
+				"killmail_id":   2,
+			}}),
+		)
+		httpmock.RegisterResponder(
+			"GET",
+			"https://esi.evetech.net/killmails/2/8eef5e8fb6b88fe3407c489df33822b2e3b57a5e",
+			httpmock.NewJsonResponderOrPanic(200, map[string]any{
+				"attackers": []map[string]any{{
+					"character_id":    c.ID,
+					"corporation_id":  c.EveCharacter.Corporation.ID,
+					"damage_done":     5745,
+					"final_blow":      true,
+					"security_status": 2.1,
+					"ship_type_id":    ship.ID,
+					"weapon_type_id":  weapon.ID,
+				}},
+				"killmail_id":     2,
+				"killmail_time":   "2016-10-22T17:13:36Z",
+				"solar_system_id": system.ID,
+				"victim": map[string]any{
+					"character_id":   victim.ID,
+					"corporation_id": victimCorporation.ID,
+					"damage_taken":   5745,
+					"items": []map[string]any{{
+						"flag":               27,
+						"item_type_id":       module.ID,
+						"quantity_destroyed": 1,
+						"singleton":          0,
+						"items": []map[string]any{{
+							"flag":             27,
+							"item_type_id":     charge.ID,
+							"quantity_dropped": 100,
+							"singleton":        0,
+						}},
+					}},
+					"ship_type_id": ship.ID,
+				},
+			}),
+		)
+		// when
+		changed, err := s.updateKillmailsESI(ctx, characterSectionUpdateParams{
+			characterID: c.ID,
+			section:     app.SectionCharacterKillmails,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		oo, err := st.ListCharacterKillmails(ctx, c.ID)
+		require.NoError(t, err)
+		require.Len(t, oo, 1)
+		km, err := st.GetEveKillmail(ctx, 2)
+		require.NoError(t, err)
+		xassert.Equal(t, "8eef5e8fb6b88fe3407c489df33822b2e3b57a5e", km.Hash)
+		xassert.Equal(t, time.Date(2016, 10, 22, 17, 13, 36, 0, time.UTC), km.Time.UTC())
+		xassert.Equal(t, system.ID, km.SolarSystem.ID)
+		xassert.EqualOptional(t, victim, km.Victim.Character)
+		xassert.Equal(t, ship.ID, km.Victim.ShipType.ID)
+		require.Len(t, km.Attackers, 1)
+		xassert.Equal(t, c.ID, km.Attackers[0].Character.MustValue().ID)
+		assert.Len(t, km.Items, 2)
+	})
+	t.Run("should report no change when killmails are already linked", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		c := factory.CreateCharacter()
+		factory.CreateCharacterToken(storage.UpdateOrCreateCharacterTokenParams{CharacterID: c.ID})
+		km := factory.CreateEveKillmail()
+		err := st.CreateCharacterKillmail(ctx, c.ID, km.ID)
+		require.NoError(t, err)
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/characters/%d/killmails/recent", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"killmail_hash": km.Hash,
+				"killmail_id":   km.ID,
+			}}),
+		)
+		// when
+		changed, err := s.updateKillmailsESI(ctx, characterSectionUpdateParams{
+			characterID: c.ID,
+			section:     app.SectionCharacterKillmails,
+		})
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+		xassert.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+	t.Run("should fetch killmails from all pages", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		c := factory.CreateCharacter()
+		factory.CreateCharacterToken(storage.UpdateOrCreateCharacterTokenParams{CharacterID: c.ID})
+		km1 := factory.CreateEveKillmail()
+		km2 := factory.CreateEveKillmail()
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/characters/%d/killmails/recent?page=1", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"killmail_hash": km1.Hash,
+				"killmail_id":   km1.ID,
+			}}).HeaderSet(http.Header{"X-Pages": []string{"2"}}),
+		)
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/characters/%d/killmails/recent?page=2", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"killmail_hash": km2.Hash,
+				"killmail_id":   km2.ID,
+			}}).HeaderSet(http.Header{"X-Pages": []string{"2"}}),
+		)
+		// when
+		changed, err := s.updateKillmailsESI(ctx, characterSectionUpdateParams{
+			characterID: c.ID,
+			section:     app.SectionCharacterKillmails,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		ids, err := st.ListCharacterKillmailIDs(ctx, c.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, set.Of(km1.ID, km2.ID), ids)
+	})
+}
@@ -5,9 +5,14 @@ import (
 	"fmt"
 	"log/slog"
 	"maps"
+	"math"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/ErikKalkoken/go-set"
+	"github.com/dustin/go-humanize"
 	"github.com/fnt-eve/goesi-openapi/esi"
 	"golang.org/x/sync/errgroup"
 
@@ -22,6 +27,243 @@ func (s *CharacterService) ListAllMarketOrder(ctx context.Context, isBuyOrders b
 	return s.st.ListAllCharacterMarketOrders(ctx, isBuyOrders)
 }
 
+const (
+	cacheKeyMarketOrderLastOpen         = "market-order-last-open"
+	cacheKeyMarketOrderUndercutNotified = "market-order-undercut-notified"
+	priceFormat                         = "#,###.##"
+)
+
+// MarketOrderUndercuts returns the best competing price for each open order in orders,
+// which has been undercut or outbid on the regional market. The result is keyed by order ID.
+//
+// Competing orders from all characters are ignored, so own orders never undercut each other.
+func (s *CharacterService) MarketOrderUndercuts(ctx context.Context, orders []*app.CharacterMarketOrder) (map[int64]float64, error) {
+	ownOrderIDs, err := s.listAllMarketOrderIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	type key struct {
+		regionID int64
+		typeID   int64
+	}
+	markets := make(map[key][]*app.EveMarketOrder)
+	for _, o := range orders {
+		if o.State != app.OrderOpen {
+			continue
+		}
+		markets[key{o.Region.ID, o.Type.ID}] = nil
+	}
+	var mu sync.Mutex
+	g := new(errgroup.Group)
+	g.SetLimit(s.concurrencyLimit)
+	for k := range markets {
+		g.Go(func() error {
+			oo, err := s.eus.MarketOrdersESI(ctx, k.regionID, k.typeID)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			markets[k] = oo
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	result := make(map[int64]float64)
+	for _, o := range orders {
+		if o.State != app.OrderOpen {
+			continue
+		}
+		v, ok := o.UndercutPrice(markets[key{o.Region.ID, o.Type.ID}], ownOrderIDs).Value()
+		if !ok {
+			continue
+		}
+		result[o.OrderID] = v
+	}
+	return result, nil
+}
+
+func (s *CharacterService) listAllMarketOrderIDs(ctx context.Context) (set.Set[int64], error) {
+	var ids set.Set[int64]
+	for _, isBuyOrders := range []bool{false, true} {
+		oo, err := s.st.ListAllCharacterMarketOrders(ctx, isBuyOrders)
+		if err != nil {
+			return ids, err
+		}
+		for _, o := range oo {
+			ids.Add(o.OrderID)
+		}
+	}
+	return ids, nil
+}
+
+// NotifyUndercutMarketOrders sends a notification when open market orders of a character
+// have been undercut or outbid. Each order is notified once only for the same own price.
+// It will sent one notification covering all currently undercut orders.
+func (s *CharacterService) NotifyUndercutMarketOrders(ctx context.Context, characterID int64, notify func(title, content string)) error {
+	_, err, _ := s.sfg.Do(fmt.Sprintf("NotifyUndercutMarketOrders-%d", characterID), func() (any, error) {
+		orders, err := s.st.ListCharacterMarketOrders(ctx, characterID)
+		if err != nil {
+			return nil, err
+		}
+		orders = slices.DeleteFunc(orders, func(o *app.CharacterMarketOrder) bool {
+			return o.State != app.OrderOpen || o.IsCorporation
+		})
+		if len(orders) == 0 {
+			return nil, nil
+		}
+		undercuts, err := s.MarketOrderUndercuts(ctx, orders)
+		if err != nil {
+			return nil, err
+		}
+		characterName, err := s.getCharacterName(ctx, characterID)
+		if err != nil {
+			return nil, err
+		}
+		var lines []string
+		var outbidCount, undercutCount int
+		for _, o := range orders {
+			price, ok := undercuts[o.OrderID]
+			if !ok {
+				continue
+			}
+			key := makeKeyMarketOrderUndercutNotified(o.OrderID)
+			v, found := s.cache.GetInt64(key)
+			if found && v == int64(math.Float64bits(o.Price)) {
+				continue
+			}
+			var verb string
+			if o.IsBuyOrder.ValueOrZero() {
+				verb = "outbid"
+				outbidCount++
+			} else {
+				verb = "undercut"
+				undercutCount++
+			}
+			lines = append(lines, fmt.Sprintf(
+				"%s in %s %s at %s ISK (yours %s ISK)",
+				o.Type.Name,
+				o.Location.DisplayName(),
+				verb,
+				humanize.FormatFloat(priceFormat, price),
+				humanize.FormatFloat(priceFormat, o.Price),
+			))
+			s.cache.SetInt64(key, int64(math.Float64bits(o.Price)), marketOrderUndercutNotifyTimeout)
+		}
+		if len(lines) > 0 {
+			slices.Sort(lines)
+			var parts []string
+			if undercutCount > 0 {
+				parts = append(parts, fmt.Sprintf("%d sell order(s) undercut", undercutCount))
+			}
+			if outbidCount > 0 {
+				parts = append(parts, fmt.Sprintf("%d buy order(s) outbid", outbidCount))
+			}
+			title := fmt.Sprintf("%s: %s", characterName, strings.Join(parts, " and "))
+			notify(title, strings.Join(lines, "\n"))
+			slog.Info("Notified undercut market orders", "characterID", characterID, "count", len(lines))
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("NotifyUndercutMarketOrders for character %d: %w", characterID, err)
+	}
+	return nil
+}
+
+// marketOrderUndercutNotifyTimeout is how long an undercut order is not notified again when the price is unchanged.
+const marketOrderUndercutNotifyTimeout = 24 * time.Hour
+
+func makeKeyMarketOrderUndercutNotified(orderID int64) string {
+	return fmt.Sprintf("%s-%d", cacheKeyMarketOrderUndercutNotified, orderID)
+}
+
+// marketOrderLastOpenTimeout is how long an order is remembered as open after it has expired.
+const marketOrderLastOpenTimeout = 7 * 24 * time.Hour
+
+// NotifyClosedMarketOrders sends a notification when market orders of a character
+// have been filled or have expired.
+//
+// Orders are only notified when they were seen open after earliest,
+// so that orders closed before notifications were enabled are not notified.
+// Each order is notified once only.
+func (s *CharacterService) NotifyClosedMarketOrders(ctx context.Context, characterID int64, earliest time.Time, notify func(title, content string)) error {
+	_, err, _ := s.sfg.Do(fmt.Sprintf("NotifyClosedMarketOrders-%d", characterID), func() (any, error) {
+		orders, err := s.st.ListCharacterMarketOrders(ctx, characterID)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		var lines []string
+		for _, o := range orders {
+			if o.IsCorporation {
+				continue
+			}
+			key := makeKeyMarketOrderLastOpen(o.OrderID)
+			switch o.State {
+			case app.OrderOpen:
+				timeout := max(o.Expires().Sub(now), 0) + marketOrderLastOpenTimeout
+				s.cache.SetInt64(key, now.Unix(), timeout)
+				continue
+			case app.OrderUnknown:
+				continue // state will be resolved with a later update
+			}
+			lastOpen, found := s.cache.GetInt64(key)
+			if !found {
+				continue
+			}
+			s.cache.Delete(key)
+			if o.State != app.OrderExpired || time.Unix(lastOpen, 0).Before(earliest) {
+				continue
+			}
+			var kind string
+			if o.IsBuyOrder.ValueOrZero() {
+				kind = "Buy"
+			} else {
+				kind = "Sell"
+			}
+			var outcome string
+			if o.IsFilled() {
+				outcome = "filled"
+			} else {
+				outcome = fmt.Sprintf("expired with %s of %s remaining",
+					humanize.Comma(o.VolumeRemains),
+					humanize.Comma(o.VolumeTotal),
+				)
+			}
+			lines = append(lines, fmt.Sprintf(
+				"%s order for %s in %s %s",
+				kind,
+				o.Type.Name,
+				o.Location.DisplayName(),
+				outcome,
+			))
+		}
+		if len(lines) == 0 {
+			return nil, nil
+		}
+		characterName, err := s.getCharacterName(ctx, characterID)
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(lines)
+		title := fmt.Sprintf("%s: %d market order(s) filled or expired", characterName, len(lines))
+		notify(title, strings.Join(lines, "\n"))
+		slog.Info("Notified closed market orders", "characterID", characterID, "count", len(lines))
+		return nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("NotifyClosedMarketOrders for character %d: %w", characterID, err)
+	}
+	return nil
+}
+
+func makeKeyMarketOrderLastOpen(orderID int64) string {
+	return fmt.Sprintf("%s-%d", cacheKeyMarketOrderLastOpen, orderID)
+}
+
 func (s *CharacterService) updateMarketOrdersESI(ctx context.Context, arg characterSectionUpdateParams) (bool, error) {
 	if arg.section != app.SectionCharacterMarketOrders {
 		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
@@ -408,3 +408,247 @@ func TestUpdateOrdersEscrow(t *testing.T) {
 	got := c2.OrdersEscrow
 	assert.Equal(t, optional.New(15.2), got)
 }
+
+func TestNotifyUndercutMarketOrders(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	s := NewFake(Params{Storage: st})
+	ctx := context.Background()
+	t.Run("should notify once when order is undercut", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		o := factory.CreateCharacterMarketOrder(storage.UpdateOrCreateCharacterMarketOrderParams{
+			IsBuyOrder: optional.New(false),
+			Price:      10,
+			State:      app.OrderOpen,
+		})
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("=~^https://esi.evetech.net/markets/%d/orders", o.Region.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"duration":      90,
+				"is_buy_order":  false,
+				"issued":        "2026-03-01T10:00:00Z",
+				"location_id":   o.Location.ID,
+				"min_volume":    1,
+				"order_id":      o.OrderID + 1,
+				"price":         9.5,
+				"range":         "region",
+				"system_id":     30000142,
+				"type_id":       o.Type.ID,
+				"volume_remain": 10,
+				"volume_total":  10,
+			}}),
+		)
+		var count int
+		notify := func(title, content string) {
+			count++
+		}
+		// when
+		err1 := s.NotifyUndercutMarketOrders(ctx, o.CharacterID, notify)
+		err2 := s.NotifyUndercutMarketOrders(ctx, o.CharacterID, notify)
+		// then
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		xassert.Equal(t, 1, count)
+	})
+	t.Run("should notify when buy order is outbid", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		o := factory.CreateCharacterMarketOrder(storage.UpdateOrCreateCharacterMarketOrderParams{
+			IsBuyOrder: optional.New(true),
+			Price:      10,
+			State:      app.OrderOpen,
+		})
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("=~^https://esi.evetech.net/markets/%d/orders", o.Region.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"duration":      90,
+				"is_buy_order":  true,
+				"issued":        "2026-03-01T10:00:00Z",
+				"location_id":   o.Location.ID,
+				"min_volume":    1,
+				"order_id":      o.OrderID + 1,
+				"price":         10.5,
+				"range":         "region",
+				"system_id":     30000142,
+				"type_id":       o.Type.ID,
+				"volume_remain": 10,
+				"volume_total":  10,
+			}}),
+		)
+		var titles, contents []string
+		// when
+		err := s.NotifyUndercutMarketOrders(ctx, o.CharacterID, func(title, content string) {
+			titles = append(titles, title)
+			contents = append(contents, content)
+		})
+		// then
+		require.NoError(t, err)
+		require.Len(t, titles, 1)
+		assert.Contains(t, titles[0], "1 buy order(s) outbid")
+		assert.NotContains(t, titles[0], "undercut")
+		assert.Contains(t, contents[0], "outbid")
+	})
+	t.Run("should not notify when order has best price", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		o := factory.CreateCharacterMarketOrder(storage.UpdateOrCreateCharacterMarketOrderParams{
+			IsBuyOrder: optional.New(false),
+			Price:      10,
+			State:      app.OrderOpen,
+		})
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("=~^https://esi.evetech.net/markets/%d/orders", o.Region.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"duration":      90,
+				"is_buy_order":  false,
+				"issued":        "2026-03-01T10:00:00Z",
+				"location_id":   o.Location.ID,
+				"min_volume":    1,
+				"order_id":      o.OrderID + 1,
+				"price":         11,
+				"range":         "region",
+				"system_id":     30000142,
+				"type_id":       o.Type.ID,
+				"volume_remain": 10,
+				"volume_total":  10,
+			}}),
+		)
+		var count int
+		// when
+		err := s.NotifyUndercutMarketOrders(ctx, o.CharacterID, func(title, content string) {
+			count++
+		})
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, 0, count)
+	})
+}
+
+func TestNotifyClosedMarketOrders(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+	ctx := context.Background()
+	earliest := time.Now().Add(-time.Hour)
+	closeOrder := func(o *app.CharacterMarketOrder, state app.MarketOrderState) {
+		err := st.UpdateCharacterMarketOrderState(ctx, storage.UpdateCharacterMarketOrderStateParams{
+			CharacterID: o.CharacterID,
+			OrderIDs:    set.Of(o.OrderID),
+			State:       state,
+		})
+		require.NoError(t, err)
+	}
+	t.Run("should notify once when open order is filled", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		s := NewFake(Params{Storage: st})
+		o := factory.CreateCharacterMarketOrder(storage.UpdateOrCreateCharacterMarketOrderParams{
+			IsBuyOrder: optional.New(false),
+			State:      app.OrderOpen,
+		})
+		var count int
+		var content string
+		notify := func(_, c string) {
+			count++
+			content = c
+		}
+		err := s.NotifyClosedMarketOrders(ctx, o.CharacterID, earliest, notify)
+		require.NoError(t, err)
+		closeOrder(o, app.OrderExpired)
+		// when
+		err1 := s.NotifyClosedMarketOrders(ctx, o.CharacterID, earliest, notify)
+		err2 := s.NotifyClosedMarketOrders(ctx, o.CharacterID, earliest, notify)
+		// then
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		xassert.Equal(t, 1, count)
+		assert.Contains(t, content, "filled")
+	})
+	t.Run("should notify when open order expired with remaining volume", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		s := NewFake(Params{Storage: st})
+		o := factory.CreateCharacterMarketOrder(storage.UpdateOrCreateCharacterMarketOrderParams{
+			State:         app.OrderOpen,
+			VolumeRemains: 5,
+			VolumeTotal:   10,
+		})
+		var content string
+		notify := func(_, c string) {
+			content = c
+		}
+		err := s.NotifyClosedMarketOrders(ctx, o.CharacterID, earliest, notify)
+		require.NoError(t, err)
+		closeOrder(o, app.OrderExpired)
+		// when
+		err = s.NotifyClosedMarketOrders(ctx, o.CharacterID, earliest, notify)
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, content, "expired with 5 of 10 remaining")
+	})
+	t.Run("should not notify cancelled orders", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		s := NewFake(Params{Storage: st})
+		o := factory.CreateCharacterMarketOrder(storage.UpdateOrCreateCharacterMarketOrderParams{
+			State: app.OrderOpen,
+		})
+		var count int
+		notify := func(_, _ string) {
+			count++
+		}
+		err := s.NotifyClosedMarketOrders(ctx, o.CharacterID, earliest, notify)
+		require.NoError(t, err)
+		closeOrder(o, app.OrderCancelled)
+		// when
+		err = s.NotifyClosedMarketOrders(ctx, o.CharacterID, earliest, notify)
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, 0, count)
+	})
+	t.Run("should not notify orders which were not seen open", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		s := NewFake(Params{Storage: st})
+		o := factory.CreateCharacterMarketOrder(storage.UpdateOrCreateCharacterMarketOrderParams{
+			State: app.OrderExpired,
+		})
+		var count int
+		notify := func(_, _ string) {
+			count++
+		}
+		// when
+		err := s.NotifyClosedMarketOrders(ctx, o.CharacterID, earliest, notify)
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, 0, count)
+	})
+	t.Run("should not notify orders last seen open before earliest", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		s := NewFake(Params{Storage: st})
+		o := factory.CreateCharacterMarketOrder(storage.UpdateOrCreateCharacterMarketOrderParams{
+			State: app.OrderOpen,
+		})
+		notify := func(_, _ string) {}
+		err := s.NotifyClosedMarketOrders(ctx, o.CharacterID, earliest, notify)
+		require.NoError(t, err)
+		closeOrder(o, app.OrderExpired)
+		var count int
+		// when
+		err = s.NotifyClosedMarketOrders(ctx, o.CharacterID, time.Now().Add(time.Hour), func(_, _ string) {
+			count++
+		})
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, 0, count)
+	})
+}
@@ -0,0 +1,90 @@
+package characterservice
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/fnt-eve/goesi-openapi/esi"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
+)
+
+// ListAllMiningLedgerEntries returns the mining ledger entries of all characters.
+func (s *CharacterService) ListAllMiningLedgerEntries(ctx context.Context) ([]*app.CharacterMiningLedgerEntry, error) {
+	return s.st.ListAllCharacterMiningLedgerEntries(ctx)
+}
+
+// ListMiningLedgerEntries returns the mining ledger entries of a character.
+func (s *CharacterService) ListMiningLedgerEntries(ctx context.Context, characterID int64) ([]*app.CharacterMiningLedgerEntry, error) {
+	return s.st.ListCharacterMiningLedgerEntries(ctx, characterID)
+}
+
+// updateMiningLedgerESI updates the mining ledger of a character from ESI.
+// ESI only reports the last 30 days, so older entries are kept.
+func (s *CharacterService) updateMiningLedgerESI(ctx context.Context, arg characterSectionUpdateParams) (bool, error) {
+	if arg.section != app.SectionCharacterMiningLedger {
+		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
+	}
+	return s.updateSectionIfChanged(
+		ctx, arg, false,
+		func(ctx context.Context, characterID int64) (any, error) {
+			ctx = xgoesi.NewContextWithOperationID(ctx, "GetCharactersCharacterIdMining")
+			rows, err := xgoesi.FetchPages(
+				func(page int32) ([]esi.CharactersCharacterIdMiningGetInner, *http.Response, error) {
+					return s.esiClient.IndustryAPI.GetCharactersCharacterIdMining(ctx, characterID).Page(page).Execute()
+				},
+			)
+			if err != nil {
+				return false, err
+			}
+			slices.SortFunc(rows, func(a, b esi.CharactersCharacterIdMiningGetInner) int {
+				return cmp.Or(
+					cmp.Compare(a.Date, b.Date),
+					cmp.Compare(a.SolarSystemId, b.SolarSystemId),
+					cmp.Compare(a.TypeId, b.TypeId),
+				)
+			})
+			slog.Debug("Received mining ledger from ESI", "characterID", characterID, "count", len(rows))
+			return rows, nil
+		},
+		func(ctx context.Context, characterID int64, data any) (bool, error) {
+			rows := data.([]esi.CharactersCharacterIdMiningGetInner)
+			var systemIDs, typeIDs set.Set[int64]
+			for _, r := range rows {
+				systemIDs.Add(r.SolarSystemId)
+				typeIDs.Add(r.TypeId)
+			}
+			if err := s.eus.AddMissingSolarSystems(ctx, systemIDs); err != nil {
+				return false, err
+			}
+			if err := s.eus.AddMissingTypes(ctx, typeIDs); err != nil {
+				return false, err
+			}
+			for _, r := range rows {
+				date, err := time.Parse(time.DateOnly, r.Date)
+				if err != nil {
+					return false, err
+				}
+				err = s.st.UpdateOrCreateCharacterMiningLedgerEntry(ctx, storage.UpdateOrCreateCharacterMiningLedgerEntryParams{
+					CharacterID:   characterID,
+					Date:          date,
+					Quantity:      r.Quantity,
+					SolarSystemID: r.SolarSystemId,
+					TypeID:        r.TypeId,
+				})
+				if err != nil {
+					return false, err
+				}
+			}
+			slog.Info("Stored updated mining ledger", "characterID", characterID, "count", len(rows))
+			return true, nil
+		})
+}
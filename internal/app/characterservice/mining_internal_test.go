@@ -0,0 +1,77 @@
+package characterservice
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestUpdateCharacterMiningLedgerESI(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	s := NewFake(Params{Storage: st})
+	ctx := context.Background()
+	t.Run("should add new entries and update existing ones", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		c := factory.CreateCharacter()
+		factory.CreateCharacterToken(storage.UpdateOrCreateCharacterTokenParams{CharacterID: c.ID})
+		system := factory.CreateEveSolarSystem()
+		et := factory.CreateEveType()
+		old := factory.CreateCharacterMiningLedgerEntry(storage.UpdateOrCreateCharacterMiningLedgerEntryParams{
+			CharacterID:   c.ID,
+			Date:          time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+			SolarSystemID: system.ID,
+			TypeID:        et.ID,
+		})
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/characters/%d/mining?page=1", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+				{
+					"date":            "2025-10-01",
+					"quantity":        7004,
+					"solar_system_id": system.ID,
+					"type_id":         et.ID,
+				},
+				{
+					"date":            "2025-10-02",
+					"quantity":        5199,
+					"solar_system_id": system.ID,
+					"type_id":         et.ID,
+				},
+			}),
+		)
+		// when
+		changed, err := s.updateMiningLedgerESI(ctx, characterSectionUpdateParams{
+			characterID: c.ID,
+			section:     app.SectionCharacterMiningLedger,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		oo, err := s.ListMiningLedgerEntries(ctx, c.ID)
+		require.NoError(t, err)
+		require.Len(t, oo, 3)
+		xassert.Equal(t, time.Date(2025, 10, 2, 0, 0, 0, 0, time.UTC), oo[0].Date.UTC())
+		xassert.Equal(t, 5199, oo[0].Quantity)
+		xassert.Equal(t, system.ID, oo[0].SolarSystem.ID)
+		xassert.Equal(t, et.ID, oo[0].Type.ID)
+		xassert.Equal(t, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), oo[1].Date.UTC())
+		xassert.Equal(t, 7004, oo[1].Quantity)
+		xassert.Equal(t, old.Quantity, oo[2].Quantity)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationsink"
 	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
 	"github.com/ErikKalkoken/evebuddy/internal/optional"
 	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
@@ -34,9 +35,17 @@ func (s *CharacterService) NotifyNotifications(ctx context.Context, characterID
 			if !typesEnabled.Contains(n.Type) {
 				continue
 			}
-			if err := s.SendDesktopNotification(ctx, n); err != nil {
+			title, content, err := s.makeNotificationTitleAndContent(ctx, n)
+			if err != nil {
 				return nil, fmt.Errorf("notify communications: %w", err)
 			}
+			s.sendNotification(ctx, notificationsink.Notification{
+				Type:        n.Type.String(),
+				Title:       title,
+				Content:     content,
+				Timestamp:   n.Timestamp,
+				CharacterID: n.CharacterID,
+			})
 			if err := s.st.UpdateCharacterNotificationsSetProcessed(ctx, n.CharacterID, n.NotificationID); err != nil {
 				return nil, fmt.Errorf("notify communications: %w", err)
 			}
@@ -54,6 +63,15 @@ func (s *CharacterService) SetNotificationsAsRead(ctx context.Context, ids set.S
 }
 
 func (s *CharacterService) SendDesktopNotification(ctx context.Context, n *app.CharacterNotification) error {
+	title, content, err := s.makeNotificationTitleAndContent(ctx, n)
+	if err != nil {
+		return fmt.Errorf("SendDesktopNotification: %w", err)
+	}
+	s.sendDesktopNotification(title, content)
+	return nil
+}
+
+func (s *CharacterService) makeNotificationTitleAndContent(ctx context.Context, n *app.CharacterNotification) (string, string, error) {
 	var recipient string
 	v, ok := n.Recipient.Value()
 	if ok {
@@ -61,14 +79,65 @@ func (s *CharacterService) SendDesktopNotification(ctx context.Context, n *app.C
 	} else {
 		n, err := s.getCharacterName(ctx, n.CharacterID)
 		if err != nil {
-			return fmt.Errorf("SendDesktopNotification: %w", err)
+			return "", "", err
 		}
 		recipient = n
 	}
 	title := fmt.Sprintf("%s: New Communication from %s", recipient, n.Sender.Name)
 	content := n.Title.ValueOrZero()
-	s.sendDesktopNotification(title, content)
-	return nil
+	return title, content, nil
+}
+
+// notifyFunc returns a function for sending notifications of a type about a character
+// to the desktop and to the notification sink.
+func (s *CharacterService) notifyFunc(ctx context.Context, characterID int64, typ string) func(title, content string) {
+	return func(title, content string) {
+		s.sendNotification(ctx, notificationsink.Notification{
+			Type:        typ,
+			Title:       title,
+			Content:     content,
+			Timestamp:   time.Now(),
+			CharacterID: characterID,
+		})
+	}
+}
+
+// sendNotification sends a notification to the desktop and to the notification sink,
+// unless the notification rules hold it back.
+func (s *CharacterService) sendNotification(ctx context.Context, n notificationsink.Notification) {
+	if s.notificationRules != nil {
+		var ok bool
+		n, ok = s.notificationRules.Apply(ctx, n)
+		if !ok {
+			return
+		}
+	}
+	s.sendDesktopNotification(n.Title, n.Content)
+	s.sendToNotificationSink(ctx, n)
+}
+
+// sendNotificationDigest sends the digest of notifications held back during quiet hours if there is one.
+func (s *CharacterService) sendNotificationDigest(ctx context.Context) {
+	if s.notificationRules == nil {
+		return
+	}
+	n, ok := s.notificationRules.TakeDigest()
+	if !ok {
+		return
+	}
+	s.sendDesktopNotification(n.Title, n.Content)
+	s.sendToNotificationSink(ctx, n)
+}
+
+// sendToNotificationSink sends a notification to the notification sink if one is configured.
+// Errors are logged only, so that failing sinks do not disrupt notifying on the desktop.
+func (s *CharacterService) sendToNotificationSink(ctx context.Context, n notificationsink.Notification) {
+	if s.notificationSink == nil {
+		return
+	}
+	if err := s.notificationSink.Send(ctx, n); err != nil {
+		slog.Warn("Failed to send notification to sink", "type", n.Type, "title", n.Title, "error", err)
+	}
 }
 
 func (s *CharacterService) ListAllNotifications(ctx context.Context) ([]*app.CharacterNotification, error) {
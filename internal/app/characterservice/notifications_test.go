@@ -1,6 +1,8 @@
 package characterservice_test
 
 import (
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -9,6 +11,7 @@ import (
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
 	"github.com/ErikKalkoken/evebuddy/internal/app/characterservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationsink"
 	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
 	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
 	"github.com/ErikKalkoken/evebuddy/internal/optional"
@@ -61,3 +64,102 @@ func TestNotifyCommunications(t *testing.T) {
 		})
 	}
 }
+
+type notificationSinkFake struct {
+	sent []notificationsink.Notification
+}
+
+func (s *notificationSinkFake) Send(_ context.Context, n notificationsink.Notification) error {
+	s.sent = append(s.sent, n)
+	return nil
+}
+
+func TestNotifyCommunicationsWithSink(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+	now := time.Now().UTC()
+	s, _ := storage.EveNotificationTypeToESIString(app.StructureUnderAttack)
+	n := factory.CreateCharacterNotification(storage.CreateCharacterNotificationParams{
+		Title:     optional.New("title"),
+		Body:      optional.New("body"),
+		Type:      s,
+		Timestamp: now,
+	})
+	sink := &notificationSinkFake{}
+	var sendCount int
+	cs := characterservice.NewFake(characterservice.Params{
+		NotificationSink: sink,
+		SendDesktopNotification: func(title string, content string) {
+			sendCount++
+		},
+		Storage: st,
+	})
+	// when
+	err := cs.NotifyNotifications(t.Context(), n.CharacterID, now.Add(-time.Hour), set.Of(app.StructureUnderAttack))
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, sendCount)
+		if assert.Len(t, sink.sent, 1) {
+			assert.Equal(t, "StructureUnderAttack", sink.sent[0].Type)
+			assert.Equal(t, "title", sink.sent[0].Content)
+		}
+	}
+}
+
+type notificationRulesFake struct {
+	applied []notificationsink.Notification
+	deliver bool
+}
+
+func (r *notificationRulesFake) Apply(_ context.Context, n notificationsink.Notification) (notificationsink.Notification, bool) {
+	r.applied = append(r.applied, n)
+	return n, r.deliver
+}
+
+func (r *notificationRulesFake) TakeDigest() (notificationsink.Notification, bool) {
+	return notificationsink.Notification{}, false
+}
+
+func TestNotifyCommunicationsWithRules(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+	now := time.Now().UTC()
+	s, _ := storage.EveNotificationTypeToESIString(app.StructureUnderAttack)
+	for _, deliver := range []bool{true, false} {
+		t.Run(fmt.Sprintf("deliver: %v", deliver), func(t *testing.T) {
+			// given
+			testutil.MustTruncateTables(db)
+			n := factory.CreateCharacterNotification(storage.CreateCharacterNotificationParams{
+				Title:     optional.New("title"),
+				Body:      optional.New("body"),
+				Type:      s,
+				Timestamp: now,
+			})
+			rules := &notificationRulesFake{deliver: deliver}
+			sink := &notificationSinkFake{}
+			var sendCount int
+			cs := characterservice.NewFake(characterservice.Params{
+				NotificationRules: rules,
+				NotificationSink:  sink,
+				SendDesktopNotification: func(title string, content string) {
+					sendCount++
+				},
+				Storage: st,
+			})
+			// when
+			err := cs.NotifyNotifications(t.Context(), n.CharacterID, now.Add(-time.Hour), set.Of(app.StructureUnderAttack))
+			// then
+			if assert.NoError(t, err) {
+				if assert.Len(t, rules.applied, 1) {
+					assert.Equal(t, n.CharacterID, rules.applied[0].CharacterID)
+				}
+				var want int
+				if deliver {
+					want = 1
+				}
+				assert.Equal(t, want, sendCount)
+				assert.Len(t, sink.sent, want)
+			}
+		})
+	}
+}
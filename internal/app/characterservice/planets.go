@@ -159,6 +159,12 @@ func (s *CharacterService) updatePlanetsESI(ctx context.Context, arg characterSe
 							}
 							arg.ExtractorProductTypeID = optional.New(et.ID)
 						}
+						if pin.ExtractorDetails != nil {
+							if x := pin.ExtractorDetails.CycleTime; x != nil {
+								arg.ExtractorCycleTime = optional.New(time.Duration(*x) * time.Second)
+							}
+							arg.ExtractorQuantityPerCycle = optional.FromPtr(pin.ExtractorDetails.QtyPerCycle)
+						}
 						if pin.FactoryDetails != nil && pin.FactoryDetails.SchematicId != 0 {
 							es, err := s.eus.GetOrCreateSchematicESI(ctx, pin.FactoryDetails.SchematicId)
 							if err != nil {
@@ -210,6 +210,8 @@ func TestUpdateCharacterPlanetsESI(t *testing.T) {
 							pin.LastCycleStart.ValueOrZero(),
 						)
 						xassert.EqualOptional(t, productType, pin.ExtractorProductType)
+						xassert.EqualOptional(t, 30*time.Minute, pin.ExtractorCycleTime)
+						xassert.EqualOptional(t, 1081, pin.ExtractorQuantityPerCycle)
 						xassert.Equal(t, pinType, pin.Type)
 					}
 				}
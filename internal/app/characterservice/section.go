@@ -15,6 +15,7 @@ import (
 	"github.com/ErikKalkoken/go-set"
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationsink"
 	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
 	"github.com/ErikKalkoken/evebuddy/internal/optional"
 	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
@@ -35,6 +36,9 @@ func (s *CharacterService) StartUpdateTickerCharacters(d time.Duration) {
 				if err := s.UpdateCharactersIfNeeded(ctx, false); err != nil {
 					slog.Error("Failed to update characters", "error", err)
 				}
+				if err := s.RecordWealthSnapshots(ctx); err != nil {
+					slog.Error("Failed to record wealth snapshots", "error", err)
+				}
 			}()
 			<-time.Tick(d)
 		}
@@ -63,6 +67,10 @@ func (s *CharacterService) UpdateCharactersIfNeeded(ctx context.Context, forceUp
 }
 
 func (s *CharacterService) notifyCharactersIfNeeded(ctx context.Context) error {
+	if s.isNotifyDisabled {
+		return nil
+	}
+	s.sendNotificationDigest(ctx)
 	characters, err := s.ListCharacters(ctx)
 	if err != nil {
 		return err
@@ -74,12 +82,29 @@ func (s *CharacterService) notifyCharactersIfNeeded(ctx context.Context) error {
 	for _, c := range characters {
 		if c.IsTrainingWatched && s.settings.NotifyTrainingEnabled() {
 			wg.Go(func() {
-				err := s.NotifyExpiredTrainingForWatched(ctx, c.ID, s.sendDesktopNotification)
+				err := s.NotifyExpiredTrainingForWatched(ctx, c.ID, s.notifyFunc(ctx, c.ID, notificationsink.TypeExpiredTraining))
 				if err != nil {
 					slog.Error("Notify expired training", "characterID", c.ID, "error", err)
 				}
 			})
 		}
+		if c.IsJumpFatigueWatched && s.settings.NotifyJumpFatigueEnabled() {
+			wg.Go(func() {
+				err := s.NotifyExpiredJumpFatigueForWatched(ctx, c.ID, s.notifyFunc(ctx, c.ID, notificationsink.TypeJumpFatigue))
+				if err != nil {
+					slog.Error("Notify expired jump fatigue", "characterID", c.ID, "error", err)
+				}
+			})
+		}
+		if s.settings.NotifyCalendarEnabled() {
+			wg.Go(func() {
+				lead := time.Duration(s.settings.NotifyCalendarLeadMinutes()) * time.Minute
+				err := s.NotifyUpcomingCalendarEvents(ctx, c.ID, lead, s.notifyFunc(ctx, c.ID, notificationsink.TypeCalendarEvent))
+				if err != nil {
+					slog.Error("Notify upcoming calendar events", "characterID", c.ID, "error", err)
+				}
+			})
+		}
 	}
 	slog.Debug("Started notifying characters", "characters", characters)
 	wg.Wait()
@@ -209,6 +234,7 @@ func (s *CharacterService) UpdateCharacterSectionAndRefreshIfNeeded(ctx context.
 		return
 	}
 
+	notify := !s.isNotifyDisabled
 	switch section {
 	case app.SectionCharacterMailHeaders:
 		go func() {
@@ -227,28 +253,53 @@ func (s *CharacterService) UpdateCharacterSectionAndRefreshIfNeeded(ctx context.
 			if err != nil {
 				slog.Warn("DownloadMissingMailBodies", "characterID", characterID, "error", err)
 			}
+			if err := s.UpdateSearchIndex(ctx, characterID); err != nil {
+				slog.Warn("UpdateSearchIndex", "characterID", characterID, "error", err)
+			}
 		}()
-		if s.settings.NotifyMailsEnabled() {
+		if notify && s.settings.NotifyMailsEnabled() {
 			earliest := s.settings.NotifyMailsEarliest()
-			if err := s.NotifyMails(ctx, characterID, earliest, s.sendDesktopNotification); err != nil {
+			if err := s.NotifyMails(ctx, characterID, earliest, s.notifyFunc(ctx, characterID, notificationsink.TypeMail)); err != nil {
 				logErr(err)
 			}
 		}
 	case app.SectionCharacterContracts:
-		if s.settings.NotifyContractsEnabled() {
+		if notify && s.settings.NotifyContractsEnabled() {
 			earliest := s.settings.NotifyContractsEarliest()
-			if err := s.NotifyUpdatedContracts(ctx, characterID, earliest, s.sendDesktopNotification); err != nil {
+			if err := s.NotifyUpdatedContracts(ctx, characterID, earliest, s.notifyFunc(ctx, characterID, notificationsink.TypeContract)); err != nil {
+				logErr(err)
+			}
+		}
+	case app.SectionCharacterIndustryJobs:
+		if notify && s.settings.NotifyIndustryJobsEnabled() {
+			earliest := s.settings.NotifyIndustryJobsEarliest()
+			if err := s.NotifyReadyIndustryJobs(ctx, characterID, earliest, s.notifyFunc(ctx, characterID, notificationsink.TypeIndustryJobReady)); err != nil {
+				logErr(err)
+			}
+		}
+	case app.SectionCharacterMarketOrders:
+		if notify && s.settings.NotifyMarketUndercutEnabled() {
+			if err := s.NotifyUndercutMarketOrders(ctx, characterID, s.notifyFunc(ctx, characterID, notificationsink.TypeMarketUndercut)); err != nil {
+				logErr(err)
+			}
+		}
+		if notify && s.settings.NotifyMarketOrdersEnabled() {
+			earliest := s.settings.NotifyMarketOrdersEarliest()
+			if err := s.NotifyClosedMarketOrders(ctx, characterID, earliest, s.notifyFunc(ctx, characterID, notificationsink.TypeMarketOrderClosed)); err != nil {
 				logErr(err)
 			}
 		}
 	case app.SectionCharacterNotifications:
-		if s.settings.NotifyCommunicationsEnabled() {
+		if err := s.UpdateSearchIndex(ctx, characterID); err != nil {
+			logErr(err)
+		}
+		if notify && s.settings.NotifyCommunicationsEnabled() {
 			s.notifyNewCommunications(ctx, characterID)
 		}
 	case app.SectionCharacterPlanets:
-		if s.settings.NotifyPIEnabled() {
+		if notify && s.settings.NotifyPIEnabled() {
 			earliest := s.settings.NotifyPIEarliest()
-			if err := s.NotifyExpiredExtractions(ctx, characterID, earliest, s.sendDesktopNotification); err != nil {
+			if err := s.NotifyExpiredExtractions(ctx, characterID, earliest, s.notifyFunc(ctx, characterID, notificationsink.TypeExpiredExtraction)); err != nil {
 				logErr(err)
 			}
 		}
@@ -317,18 +368,28 @@ func (s *CharacterService) UpdateSectionIfNeeded(ctx context.Context, arg charac
 		f = s.updateAssetsESI
 	case app.SectionCharacterAttributes:
 		f = s.updateAttributesESI
+	case app.SectionCharacterBlueprints:
+		f = s.updateBlueprintsESI
+	case app.SectionCharacterCalendar:
+		f = s.updateCalendarESI
 	case app.SectionCharacterContacts:
 		f = s.updateContactsESI
 	case app.SectionCharacterContactLabels:
 		f = s.updateContactLabelsESI
 	case app.SectionCharacterContracts:
 		f = s.updateContractsESI
+	case app.SectionCharacterFittings:
+		f = s.updateFittingsESI
 	case app.SectionCharacterImplants:
 		f = s.updateImplantsESI
 	case app.SectionCharacterIndustryJobs:
 		f = s.updateIndustryJobsESI
 	case app.SectionCharacterJumpClones:
 		f = s.updateJumpClonesESI
+	case app.SectionCharacterJumpFatigue:
+		f = s.updateJumpFatigueESI
+	case app.SectionCharacterKillmails:
+		f = s.updateKillmailsESI
 	case app.SectionCharacterLocation:
 		f = s.updateLocationESI
 	case app.SectionCharacterLoyaltyPoints:
@@ -341,6 +402,8 @@ func (s *CharacterService) UpdateSectionIfNeeded(ctx context.Context, arg charac
 		f = s.updateMailListsESI
 	case app.SectionCharacterMarketOrders:
 		f = s.updateMarketOrdersESI
+	case app.SectionCharacterMiningLedger:
+		f = s.updateMiningLedgerESI
 	case app.SectionCharacterNotifications:
 		f = s.updateNotificationsESI
 	case app.SectionCharacterOnline:
@@ -355,6 +418,8 @@ func (s *CharacterService) UpdateSectionIfNeeded(ctx context.Context, arg charac
 		f = s.updateSkillqueueESI
 	case app.SectionCharacterSkills:
 		f = s.updateSkillsESI
+	case app.SectionCharacterStandings:
+		f = s.updateStandingsESI
 	case app.SectionCharacterWalletBalance:
 		f = s.updateWalletBalanceESI
 	case app.SectionCharacterWalletJournal:
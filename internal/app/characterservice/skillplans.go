@@ -0,0 +1,158 @@
+package characterservice
+
+import (
+	"context"
+	"errors"
+	"maps"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xiter"
+)
+
+// AddSkillToPlan adds a skill up to a level incl. all missing prerequisites to a skill plan.
+// Skill levels the character has already trained are not added.
+func (s *CharacterService) AddSkillToPlan(ctx context.Context, planID, typeID int64, level int) error {
+	p, err := s.st.GetCharacterSkillPlan(ctx, planID)
+	if err != nil {
+		return err
+	}
+	skills, err := s.eveSkillsMap(ctx)
+	if err != nil {
+		return err
+	}
+	characterSkills, err := s.characterSkillsMap(ctx, p.CharacterID)
+	if err != nil {
+		return err
+	}
+	trained := make(map[int64]int)
+	for id, cs := range characterSkills {
+		trained[id] = int(cs.ActiveSkillLevel)
+	}
+	if err := p.AddSkill(typeID, level, skills, trained); err != nil {
+		return err
+	}
+	return s.replaceSkillPlanItems(ctx, p)
+}
+
+// CreateSkillPlan creates a new and empty skill plan for a character.
+func (s *CharacterService) CreateSkillPlan(ctx context.Context, characterID int64, name string) (*app.CharacterSkillPlan, error) {
+	return s.st.CreateCharacterSkillPlan(ctx, storage.CreateCharacterSkillPlanParams{
+		CharacterID: characterID,
+		Name:        name,
+	})
+}
+
+func (s *CharacterService) DeleteSkillPlan(ctx context.Context, id int64) error {
+	return s.st.DeleteCharacterSkillPlan(ctx, id)
+}
+
+// EstimateSkillPlan returns the training estimate for a skill plan
+// based on the current skills, attributes and implants of its character.
+func (s *CharacterService) EstimateSkillPlan(ctx context.Context, p *app.CharacterSkillPlan) (app.SkillPlanEstimate, error) {
+	var e app.SkillPlanEstimate
+	skills, err := s.eveSkillsMap(ctx)
+	if err != nil {
+		return e, err
+	}
+	characterSkills, err := s.characterSkillsMap(ctx, p.CharacterID)
+	if err != nil {
+		return e, err
+	}
+	ca, err := s.st.GetCharacterAttributes(ctx, p.CharacterID)
+	if err != nil && !errors.Is(err, app.ErrNotFound) {
+		return e, err
+	}
+	var attributes optional.Optional[app.TrainingAttributes]
+	if err == nil {
+		attributes.Set(ca.TrainingAttributes())
+	}
+	implants, err := s.implantAttributeBonuses(ctx, p.CharacterID)
+	if err != nil {
+		return e, err
+	}
+	return app.EstimateSkillPlan(*p, skills, characterSkills, attributes, implants), nil
+}
+
+func (s *CharacterService) GetSkillPlan(ctx context.Context, id int64) (*app.CharacterSkillPlan, error) {
+	return s.st.GetCharacterSkillPlan(ctx, id)
+}
+
+// ListSkillPlans returns the skill plans of a character ordered by name.
+func (s *CharacterService) ListSkillPlans(ctx context.Context, characterID int64) ([]*app.CharacterSkillPlan, error) {
+	return s.st.ListCharacterSkillPlans(ctx, characterID)
+}
+
+// RemoveSkillFromPlan removes a skill from a level upwards from a skill plan
+// incl. all planned skills depending on it.
+func (s *CharacterService) RemoveSkillFromPlan(ctx context.Context, planID, typeID int64, level int) error {
+	p, err := s.st.GetCharacterSkillPlan(ctx, planID)
+	if err != nil {
+		return err
+	}
+	skills, err := s.eveSkillsMap(ctx)
+	if err != nil {
+		return err
+	}
+	p.RemoveSkill(typeID, level, skills)
+	return s.replaceSkillPlanItems(ctx, p)
+}
+
+func (s *CharacterService) RenameSkillPlan(ctx context.Context, id int64, name string) error {
+	return s.st.UpdateCharacterSkillPlanName(ctx, id, name)
+}
+
+func (s *CharacterService) replaceSkillPlanItems(ctx context.Context, p *app.CharacterSkillPlan) error {
+	items := make([]storage.CreateCharacterSkillPlanItemParams, len(p.Items))
+	for i, it := range p.Items {
+		items[i] = storage.CreateCharacterSkillPlanItemParams{
+			Level:  it.Level,
+			TypeID: it.Type.ID,
+		}
+	}
+	return s.st.ReplaceCharacterSkillPlanItems(ctx, p.ID, items)
+}
+
+func (s *CharacterService) eveSkillsMap(ctx context.Context) (map[int64]*app.EveSkill, error) {
+	oo, err := s.eus.ListSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := maps.Collect(xiter.MapSlice2(oo, func(x *app.EveSkill) (int64, *app.EveSkill) {
+		return x.Type.ID, x
+	}))
+	return m, nil
+}
+
+func (s *CharacterService) characterSkillsMap(ctx context.Context, characterID int64) (map[int64]*app.CharacterSkill, error) {
+	oo, err := s.st.ListCharacterSkills(ctx, characterID)
+	if err != nil {
+		return nil, err
+	}
+	m := maps.Collect(xiter.MapSlice2(oo, func(x *app.CharacterSkill) (int64, *app.CharacterSkill) {
+		return x.Type.ID, x
+	}))
+	return m, nil
+}
+
+// implantAttributeBonuses returns the sum of the attribute bonuses from the implants of a character.
+func (s *CharacterService) implantAttributeBonuses(ctx context.Context, characterID int64) (app.TrainingAttributes, error) {
+	var total app.TrainingAttributes
+	implants, err := s.st.ListCharacterImplants(ctx, characterID)
+	if err != nil {
+		return total, err
+	}
+	for _, o := range implants {
+		oo, err := s.eus.ListTypeDogmaAttributesForType(ctx, o.EveType.ID)
+		if err != nil {
+			return total, err
+		}
+		values := make(map[int64]float64)
+		for _, x := range oo {
+			values[x.DogmaAttribute.ID] = x.Value
+		}
+		total = total.Add(app.ImplantAttributeBonuses(values))
+	}
+	return total, nil
+}
@@ -0,0 +1,128 @@
+package characterservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func TestSkillPlans(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	s := NewFake(Params{Storage: st})
+	ctx := context.Background()
+	createSkill := func(group *app.EveGroup) *app.EveType {
+		et := factory.CreateEveType(storage.CreateEveTypeParams{GroupID: group.ID, IsPublished: true})
+		for id, v := range map[int64]float64{
+			app.EveDogmaAttributePrimaryAttribute:       app.EveDogmaAttributeIntelligence,
+			app.EveDogmaAttributeSecondaryAttribute:     app.EveDogmaAttributeMemory,
+			app.EveDogmaAttributeTrainingTimeMultiplier: 1,
+		} {
+			factory.CreateEveTypeDogmaAttribute(storage.CreateEveTypeDogmaAttributeParams{
+				DogmaAttributeID: id,
+				EveTypeID:        et.ID,
+				Value:            v,
+			})
+		}
+		return et
+	}
+	createDogmaAttributes := func() {
+		for _, id := range []int64{
+			app.EveDogmaAttributeIntelligenceModifier,
+			app.EveDogmaAttributePrimaryAttribute,
+			app.EveDogmaAttributePrimarySkillID,
+			app.EveDogmaAttributePrimarySkillLevel,
+			app.EveDogmaAttributeSecondaryAttribute,
+			app.EveDogmaAttributeTrainingTimeMultiplier,
+		} {
+			factory.CreateEveDogmaAttribute(storage.CreateEveDogmaAttributeParams{ID: id})
+		}
+	}
+	t.Run("should add skill with missing prerequisites", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		createDogmaAttributes()
+		category := factory.CreateEveCategory(storage.CreateEveCategoryParams{ID: app.EveCategorySkill})
+		group := factory.CreateEveGroup(storage.CreateEveGroupParams{CategoryID: category.ID, IsPublished: true})
+		skill1 := createSkill(group)
+		skill2 := createSkill(group)
+		factory.CreateEveTypeDogmaAttribute(storage.CreateEveTypeDogmaAttributeParams{
+			DogmaAttributeID: app.EveDogmaAttributePrimarySkillID,
+			EveTypeID:        skill1.ID,
+			Value:            float64(skill2.ID),
+		})
+		factory.CreateEveTypeDogmaAttribute(storage.CreateEveTypeDogmaAttributeParams{
+			DogmaAttributeID: app.EveDogmaAttributePrimarySkillLevel,
+			EveTypeID:        skill1.ID,
+			Value:            2,
+		})
+		c := factory.CreateCharacter()
+		factory.CreateCharacterSkill(storage.UpdateOrCreateCharacterSkillParams{
+			ActiveSkillLevel:  1,
+			CharacterID:       c.ID,
+			TrainedSkillLevel: 1,
+			TypeID:            skill2.ID,
+		})
+		p := factory.CreateCharacterSkillPlan(storage.CreateCharacterSkillPlanParams{CharacterID: c.ID})
+		// when
+		err := s.AddSkillToPlan(ctx, p.ID, skill1.ID, 1)
+		// then
+		require.NoError(t, err)
+		p2, err := s.GetSkillPlan(ctx, p.ID)
+		require.NoError(t, err)
+		got := xslices.Map(p2.Items, func(x *app.CharacterSkillPlanItem) [2]int64 {
+			return [2]int64{x.Type.ID, int64(x.Level)}
+		})
+		xassert.Equal(t, [][2]int64{{skill2.ID, 2}, {skill1.ID, 1}}, got)
+	})
+	t.Run("should estimate plan with attributes and implants", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		createDogmaAttributes()
+		category := factory.CreateEveCategory(storage.CreateEveCategoryParams{ID: app.EveCategorySkill})
+		group := factory.CreateEveGroup(storage.CreateEveGroupParams{CategoryID: category.ID, IsPublished: true})
+		skill := createSkill(group)
+		c := factory.CreateCharacter()
+		factory.CreateCharacterAttributes(storage.UpdateOrCreateCharacterAttributesParams{
+			CharacterID:  c.ID,
+			Charisma:     17,
+			Intelligence: 25,
+			Memory:       20,
+			Perception:   17,
+			Willpower:    17,
+		})
+		implant := factory.CreateEveType()
+		factory.CreateEveTypeDogmaAttribute(storage.CreateEveTypeDogmaAttributeParams{
+			DogmaAttributeID: app.EveDogmaAttributeIntelligenceModifier,
+			EveTypeID:        implant.ID,
+			Value:            5,
+		})
+		factory.CreateCharacterImplant(storage.CreateCharacterImplantParams{CharacterID: c.ID, TypeID: implant.ID})
+		p := factory.CreateCharacterSkillPlan(storage.CreateCharacterSkillPlanParams{CharacterID: c.ID})
+		require.NoError(t, s.AddSkillToPlan(ctx, p.ID, skill.ID, 1))
+		p2, err := s.GetSkillPlan(ctx, p.ID)
+		require.NoError(t, err)
+		// when
+		got, err := s.EstimateSkillPlan(ctx, p2)
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, app.TrainingAttributes{Intelligence: 5}, got.Implants)
+		xassert.EqualOptional(t, app.TrainingAttributes{
+			Charisma:     17,
+			Intelligence: 25,
+			Memory:       20,
+			Perception:   17,
+			Willpower:    17,
+		}, got.Attributes)
+		assert.False(t, got.TrainingTime().IsEmpty())
+		assert.False(t, got.Remap.IsEmpty())
+	})
+}
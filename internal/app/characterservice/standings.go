@@ -0,0 +1,121 @@
+package characterservice
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/fnt-eve/goesi-openapi/esi"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
+	"github.com/ErikKalkoken/evebuddy/internal/xiter"
+)
+
+// ListAllStandings returns the NPC standings of all characters.
+func (s *CharacterService) ListAllStandings(ctx context.Context) ([]*app.CharacterStanding, error) {
+	return s.st.ListAllCharacterStandings(ctx)
+}
+
+// ListStandings returns the NPC standings of a character.
+func (s *CharacterService) ListStandings(ctx context.Context, characterID int64) ([]*app.CharacterStanding, error) {
+	return s.st.ListCharacterStandings(ctx, characterID)
+}
+
+// ListAllStandingSkills returns the levels of the skills modifying standings for all characters.
+// Characters without any of these skills trained are not included.
+func (s *CharacterService) ListAllStandingSkills(ctx context.Context) (map[int64]app.StandingSkills, error) {
+	skills, err := s.ListAllSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[int64]app.StandingSkills)
+	for _, sk := range skills {
+		if sk.Type == nil {
+			continue
+		}
+		x := m[sk.CharacterID]
+		level := int(sk.ActiveSkillLevel)
+		switch sk.Type.ID {
+		case app.EveTypeConnections:
+			x.Connections = level
+		case app.EveTypeCriminalConnections:
+			x.CriminalConnections = level
+		case app.EveTypeDiplomacy:
+			x.Diplomacy = level
+		default:
+			continue
+		}
+		m[sk.CharacterID] = x
+	}
+	return m, nil
+}
+
+func (s *CharacterService) updateStandingsESI(ctx context.Context, arg characterSectionUpdateParams) (bool, error) {
+	if arg.section != app.SectionCharacterStandings {
+		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
+	}
+	return s.updateSectionIfChanged(
+		ctx, arg, false,
+		func(ctx context.Context, characterID int64) (any, error) {
+			ctx = xgoesi.NewContextWithOperationID(ctx, "GetCharactersCharacterIdStandings")
+			standings, _, err := s.esiClient.CharacterAPI.GetCharactersCharacterIdStandings(ctx, characterID).Execute()
+			if err != nil {
+				return nil, err
+			}
+			slices.SortFunc(standings, func(a, b esi.CharactersCharacterIdStandingsGetInner) int {
+				return cmp.Compare(a.FromId, b.FromId)
+			})
+			slog.Debug("Received standings from ESI", "count", len(standings), "characterID", characterID)
+			return standings, nil
+		},
+		func(ctx context.Context, characterID int64, data any) (bool, error) {
+			standings := data.([]esi.CharactersCharacterIdStandingsGetInner)
+
+			incomingIDs := set.Collect(xiter.MapSlice(standings, func(x esi.CharactersCharacterIdStandingsGetInner) int64 {
+				return x.FromId
+			}))
+			_, err := s.eus.AddMissingEntities(ctx, incomingIDs)
+			if err != nil {
+				return false, err
+			}
+			for _, r := range standings {
+				fromType := app.StandingFromType(r.FromType)
+				if fromType == app.StandingFromNPCCorp {
+					// needed to resolve the faction of a corporation
+					if _, err := s.eus.GetOrCreateCorporationESI(ctx, r.FromId); err != nil {
+						slog.Error("Failed to get corporation for standing", "corporationID", r.FromId, "error", err)
+					}
+				}
+				err = s.st.UpdateOrCreateCharacterStanding(ctx, storage.UpdateOrCreateCharacterStandingParams{
+					CharacterID: characterID,
+					FromID:      r.FromId,
+					FromType:    fromType,
+					Standing:    r.Standing,
+				})
+				if err != nil {
+					return false, err
+				}
+			}
+			slog.Info("Updated standings", "characterID", characterID, "count", incomingIDs.Size())
+
+			// Delete obsolete entries
+			currentIDs, err := s.st.ListCharacterStandingFromIDs(ctx, characterID)
+			if err != nil {
+				return false, err
+			}
+			obsoleteIDs := set.Difference(currentIDs, incomingIDs)
+			if obsoleteIDs.Size() > 0 {
+				err := s.st.DeleteCharacterStandings(ctx, characterID, obsoleteIDs)
+				if err != nil {
+					return false, err
+				}
+				slog.Info("Deleted obsolete standings", "characterID", characterID, "count", obsoleteIDs.Size())
+			}
+			return true, nil
+		})
+}
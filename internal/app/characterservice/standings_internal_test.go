@@ -0,0 +1,147 @@
+package characterservice
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestCharacterService_UpdateStandingsESI(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	s := NewFake(Params{Storage: st})
+	ctx := context.Background()
+
+	t.Run("should create new standings from scratch", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		c := factory.CreateCharacter()
+		factory.CreateCharacterToken(storage.UpdateOrCreateCharacterTokenParams{CharacterID: c.ID})
+		faction := factory.CreateEveEntityFaction()
+		corporation := factory.CreateEveEntityCorporation()
+		factory.CreateEveCorporation(storage.UpdateOrCreateEveCorporationParams{
+			ID:        corporation.ID,
+			Name:      corporation.Name,
+			FactionID: optional.New(faction.ID),
+		})
+		agent := factory.CreateEveEntityCharacter()
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/characters/%d/standings", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+				{"from_id": faction.ID, "from_type": "faction", "standing": 2.5},
+				{"from_id": corporation.ID, "from_type": "npc_corp", "standing": -1.25},
+				{"from_id": agent.ID, "from_type": "agent", "standing": 7.1},
+			}),
+		)
+		// when
+		changed, err := s.updateStandingsESI(ctx, characterSectionUpdateParams{
+			characterID: c.ID,
+			section:     app.SectionCharacterStandings,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		o1, err := st.GetCharacterStanding(ctx, c.ID, faction.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, app.StandingFromFaction, o1.FromType)
+		xassert.Equal(t, 2.5, o1.Standing)
+		o2, err := st.GetCharacterStanding(ctx, c.ID, corporation.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, app.StandingFromNPCCorp, o2.FromType)
+		xassert.Equal(t, -1.25, o2.Standing)
+		xassert.EqualOptional(t, faction, o2.Faction)
+		o3, err := st.GetCharacterStanding(ctx, c.ID, agent.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, app.StandingFromAgent, o3.FromType)
+		xassert.Equal(t, 7.1, o3.Standing)
+	})
+
+	t.Run("should update existing and remove obsolete standings", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		c := factory.CreateCharacter()
+		factory.CreateCharacterToken(storage.UpdateOrCreateCharacterTokenParams{CharacterID: c.ID})
+		o1 := factory.CreateCharacterStanding(storage.UpdateOrCreateCharacterStandingParams{
+			CharacterID: c.ID,
+			Standing:    1.0,
+		})
+		factory.CreateCharacterStanding(storage.UpdateOrCreateCharacterStandingParams{
+			CharacterID: c.ID,
+		})
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/characters/%d/standings", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+				{"from_id": o1.From.ID, "from_type": "faction", "standing": 3.5},
+			}),
+		)
+		// when
+		changed, err := s.updateStandingsESI(ctx, characterSectionUpdateParams{
+			characterID: c.ID,
+			section:     app.SectionCharacterStandings,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		ids, err := st.ListCharacterStandingFromIDs(ctx, c.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, set.Of(o1.From.ID), ids)
+		o2, err := st.GetCharacterStanding(ctx, c.ID, o1.From.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, 3.5, o2.Standing)
+	})
+}
+
+func TestCharacterService_ListAllStandingSkills(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	s := NewFake(Params{Storage: st})
+	ctx := context.Background()
+	t.Run("should return levels of social skills for each character", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c1 := factory.CreateCharacter()
+		c2 := factory.CreateCharacter()
+		connections := factory.CreateEveType(storage.CreateEveTypeParams{ID: app.EveTypeConnections})
+		diplomacy := factory.CreateEveType(storage.CreateEveTypeParams{ID: app.EveTypeDiplomacy})
+		factory.CreateCharacterSkill(storage.UpdateOrCreateCharacterSkillParams{
+			CharacterID:       c1.ID,
+			TypeID:            connections.ID,
+			ActiveSkillLevel:  4,
+			TrainedSkillLevel: 4,
+		})
+		factory.CreateCharacterSkill(storage.UpdateOrCreateCharacterSkillParams{
+			CharacterID:       c1.ID,
+			TypeID:            diplomacy.ID,
+			ActiveSkillLevel:  2,
+			TrainedSkillLevel: 2,
+		})
+		factory.CreateCharacterSkill(storage.UpdateOrCreateCharacterSkillParams{
+			CharacterID: c2.ID,
+		})
+		// when
+		got, err := s.ListAllStandingSkills(ctx)
+		// then
+		require.NoError(t, err)
+		want := map[int64]app.StandingSkills{
+			c1.ID: {Connections: 4, Diplomacy: 2},
+		}
+		assert.Equal(t, want, got)
+	})
+}
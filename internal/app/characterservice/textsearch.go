@@ -0,0 +1,55 @@
+package characterservice
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/evehtml"
+)
+
+// UpdateSearchIndex adds all mails and notifications of a character
+// which are not yet indexed to the full-text search index.
+func (s *CharacterService) UpdateSearchIndex(ctx context.Context, characterID int64) error {
+	mails, err := s.st.ListCharacterMailsNotIndexed(ctx, characterID)
+	if err != nil {
+		return err
+	}
+	for i, m := range mails {
+		mails[i].Body = evehtml.ToPlain(m.Body)
+	}
+	if err := s.st.UpdateMailSearchIndex(ctx, mails); err != nil {
+		return err
+	}
+	notifications, err := s.st.ListCharacterNotificationsNotIndexed(ctx, characterID)
+	if err != nil {
+		return err
+	}
+	if err := s.st.UpdateNotificationSearchIndex(ctx, notifications); err != nil {
+		return err
+	}
+	if n := len(mails) + len(notifications); n > 0 {
+		slog.Info("Updated search index", "characterID", characterID, "mails", len(mails), "notifications", len(notifications))
+	}
+	return nil
+}
+
+// SearchMailsAndNotifications returns the mails and notifications of all characters
+// matching a query. The newest hits are returned first
+// and up to limit hits are returned for each kind.
+func (s *CharacterService) SearchMailsAndNotifications(ctx context.Context, query string, limit int) ([]*app.TextSearchHit, error) {
+	mails, err := s.st.SearchCharacterMails(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	notifications, err := s.st.SearchCharacterNotifications(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	hits := slices.Concat(mails, notifications)
+	slices.SortFunc(hits, func(a, b *app.TextSearchHit) int {
+		return b.Timestamp.Compare(a.Timestamp)
+	})
+	return hits, nil
+}
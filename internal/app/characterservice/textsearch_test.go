@@ -0,0 +1,82 @@
+package characterservice_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/characterservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil/testdouble"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+func TestTextSearch(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	s := testdouble.NewCharacterServiceFake(characterservice.Params{Storage: st})
+	ctx := context.Background()
+	t.Run("should find mails and notifications of all characters with newest first", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c1 := factory.CreateCharacter()
+		c2 := factory.CreateCharacter()
+		now := time.Now().UTC()
+		m := factory.CreateCharacterMail(storage.CreateCharacterMailParams{
+			CharacterID: c1.ID,
+			Body:        optional.New("<font size=\"12\">Please reinforce the <b>Keepstar</b> now.</font>"),
+			Subject:     optional.New("Alert"),
+			Timestamp:   now.Add(-2 * time.Hour),
+		})
+		n := factory.CreateCharacterNotification(storage.CreateCharacterNotificationParams{
+			CharacterID: c2.ID,
+			Title:       optional.New("Keepstar under attack"),
+			Body:        optional.New("Your structure is under attack."),
+			Timestamp:   now.Add(-1 * time.Hour),
+		})
+		factory.CreateCharacterMail(storage.CreateCharacterMailParams{
+			CharacterID: c2.ID,
+			Body:        optional.New("Nothing to see here"),
+		})
+		// when
+		err1 := s.UpdateSearchIndex(ctx, c1.ID)
+		err2 := s.UpdateSearchIndex(ctx, c2.ID)
+		// then
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		got, err := s.SearchMailsAndNotifications(ctx, "keepstar", 10)
+		require.NoError(t, err)
+		if assert.Len(t, got, 2) {
+			assert.Equal(t, app.TextSearchHitNotification, got[0].Kind)
+			assert.Equal(t, n.NotificationID, got[0].ID)
+			assert.Equal(t, c2.ID, got[0].CharacterID)
+			assert.Equal(t, app.TextSearchHitMail, got[1].Kind)
+			assert.Equal(t, m.MailID, got[1].ID)
+			assert.Equal(t, c1.ID, got[1].CharacterID)
+			assert.NotContains(t, got[1].Snippet, "<b>")
+		}
+	})
+	t.Run("should not index the same objects twice", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		factory.CreateCharacterMail(storage.CreateCharacterMailParams{
+			CharacterID: c.ID,
+			Body:        optional.New("alpha"),
+		})
+		err := s.UpdateSearchIndex(ctx, c.ID)
+		require.NoError(t, err)
+		// when
+		err = s.UpdateSearchIndex(ctx, c.ID)
+		// then
+		require.NoError(t, err)
+		got, err := s.SearchMailsAndNotifications(ctx, "alpha", 10)
+		require.NoError(t, err)
+		assert.Len(t, got, 1)
+	})
+}
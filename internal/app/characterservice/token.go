@@ -78,8 +78,14 @@ func (s *CharacterService) TokenSourceForCorporation(ctx context.Context, corpor
 
 // TokenSource returns a valid token source for a character.
 // The token source will automatically refresh when needed.
+//
+// Returns [app.ErrTokenKeyUnavailable] when the token is encrypted
+// and the encryption key is not available, e.g. because it has not been unlocked yet.
 func (s *CharacterService) TokenSource(ctx context.Context, characterID int64, scopes set.Set[string]) (oauth2.TokenSource, error) {
 	token, err := s.st.GetCharacterToken(ctx, characterID)
+	if errors.Is(err, app.ErrTokenKeyUnavailable) {
+		return nil, fmt.Errorf("create token source for character %d: token is encrypted and needs to be unlocked in settings: %w", characterID, err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("create token source: %w", err)
 	}
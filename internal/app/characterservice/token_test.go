@@ -162,3 +162,41 @@ func TestCharacterTokenForCorporation(t *testing.T) {
 		assert.ErrorIs(t, err, app.ErrNotFound)
 	})
 }
+
+func TestTokenSource(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+	s := testdouble.NewCharacterServiceFake(characterservice.Params{Storage: st})
+	ctx := context.Background()
+	t.Run("should report when encryption key is unavailable", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		key := make([]byte, storage.TokenKeySize)
+		c, err := storage.NewTokenCipher(key)
+		require.NoError(t, err)
+		st.SetTokenCipher(c)
+		o := factory.CreateCharacterToken()
+		st.SetTokenCipher(nil)
+		// when
+		_, err = s.TokenSource(ctx, o.CharacterID, set.Of[string]())
+		// then
+		assert.ErrorIs(t, err, app.ErrTokenKeyUnavailable)
+	})
+	t.Run("should return token source when encryption key is available", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		key := make([]byte, storage.TokenKeySize)
+		c, err := storage.NewTokenCipher(key)
+		require.NoError(t, err)
+		st.SetTokenCipher(c)
+		defer st.SetTokenCipher(nil)
+		o := factory.CreateCharacterToken()
+		// when
+		ts, err := s.TokenSource(ctx, o.CharacterID, set.Of[string]())
+		// then
+		require.NoError(t, err)
+		x, err := ts.Token()
+		require.NoError(t, err)
+		xassert.Equal(t, o.AccessToken, x.AccessToken)
+	})
+}
@@ -0,0 +1,47 @@
+package characterservice
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+)
+
+// ListWealthSnapshots returns the wealth snapshots of all characters since a given day.
+func (s *CharacterService) ListWealthSnapshots(ctx context.Context, since time.Time) ([]*app.CharacterWealthSnapshot, error) {
+	return s.st.ListCharacterWealthSnapshots(ctx, app.SnapshotDay(since))
+}
+
+// RecordWealthSnapshots records the current wealth of all characters as snapshot for today.
+// Existing snapshots for today are updated.
+// Characters with no known wealth are skipped.
+func (s *CharacterService) RecordWealthSnapshots(ctx context.Context) error {
+	characters, err := s.ListCharacters(ctx)
+	if err != nil {
+		return fmt.Errorf("RecordWealthSnapshots: %w", err)
+	}
+	day := app.SnapshotDay(time.Now())
+	var n int
+	for _, c := range characters {
+		if c.WalletBalance.IsEmpty() && c.CombinedAssetsValue().IsEmpty() {
+			continue
+		}
+		err := s.st.UpdateOrCreateCharacterWealthSnapshot(ctx, storage.UpdateOrCreateCharacterWealthSnapshotParams{
+			AssetsValue:     c.CombinedAssetsValue().ValueOrZero(),
+			CharacterID:     c.ID,
+			ContractsEscrow: c.ContractsEscrow.ValueOrZero(),
+			Day:             day,
+			OrdersEscrow:    c.OrdersEscrow.ValueOrZero(),
+			WalletBalance:   c.WalletBalance.ValueOrZero(),
+		})
+		if err != nil {
+			return fmt.Errorf("RecordWealthSnapshots: character %d: %w", c.ID, err)
+		}
+		n++
+	}
+	slog.Debug("Recorded character wealth snapshots", "day", day, "count", n)
+	return nil
+}
@@ -0,0 +1,78 @@
+package characterservice_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/characterservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil/testdouble"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestRecordWealthSnapshots(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	cs := testdouble.NewCharacterServiceFake(characterservice.Params{Storage: st})
+	ctx := context.Background()
+	t.Run("should record snapshot for today", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter(storage.CreateCharacterParams{
+			AssetValue:         optional.New(1.0),
+			ContractItemsValue: optional.New(2.0),
+			ContractsEscrow:    optional.New(3.0),
+			OrderItemsValue:    optional.New(4.0),
+			OrdersEscrow:       optional.New(5.0),
+			WalletBalance:      optional.New(6.0),
+		})
+		// when
+		err := cs.RecordWealthSnapshots(ctx)
+		// then
+		require.NoError(t, err)
+		x, err := st.GetCharacterWealthSnapshot(ctx, c.ID, app.SnapshotDay(time.Now()))
+		require.NoError(t, err)
+		xassert.Equal(t, 7.0, x.AssetsValue)
+		xassert.Equal(t, 3.0, x.ContractsEscrow)
+		xassert.Equal(t, 5.0, x.OrdersEscrow)
+		xassert.Equal(t, 6.0, x.WalletBalance)
+	})
+	t.Run("should update existing snapshot for today", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter(storage.CreateCharacterParams{
+			WalletBalance: optional.New(42.0),
+		})
+		today := app.SnapshotDay(time.Now())
+		factory.CreateCharacterWealthSnapshot(storage.UpdateOrCreateCharacterWealthSnapshotParams{
+			CharacterID: c.ID,
+			Day:         today,
+		})
+		// when
+		err := cs.RecordWealthSnapshots(ctx)
+		// then
+		require.NoError(t, err)
+		oo, err := cs.ListWealthSnapshots(ctx, today)
+		require.NoError(t, err)
+		require.Len(t, oo, 1)
+		xassert.Equal(t, 42.0, oo[0].Total())
+	})
+	t.Run("should skip characters without wealth data", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		factory.CreateCharacter()
+		// when
+		err := cs.RecordWealthSnapshots(ctx)
+		// then
+		require.NoError(t, err)
+		oo, err := cs.ListWealthSnapshots(ctx, time.Now())
+		require.NoError(t, err)
+		xassert.Equal(t, 0, len(oo))
+	})
+}
@@ -1,6 +1,11 @@
 package app
 
 import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/ErikKalkoken/evebuddy/internal/optional"
 )
 
@@ -39,3 +44,223 @@ type CharacterShipSkill struct {
 	SkillLevel        uint
 	TrainedSkillLevel optional.Optional[int]
 }
+
+// skillPointsForLevel defines the total skill points required to train a rank 1 skill to a level.
+var skillPointsForLevel = [6]int64{0, 250, 1415, 8000, 45255, 256000}
+
+// SkillPointsForLevel returns the total skill points required to train a skill of a given rank to a level.
+func SkillPointsForLevel(rank int, level int) int64 {
+	level = max(0, min(level, 5))
+	return skillPointsForLevel[level] * int64(rank)
+}
+
+// SkillPointsPerMinute returns the training speed in skill points per minute
+// for a skill with the given primary and secondary attributes.
+func (ca CharacterAttributes) SkillPointsPerMinute(primaryAttributeID, secondaryAttributeID int64) float64 {
+	return ca.TrainingAttributes().SkillPointsPerMinute(primaryAttributeID, secondaryAttributeID)
+}
+
+// TrainingAttributes returns the attributes of a character which determine the training speed.
+func (ca CharacterAttributes) TrainingAttributes() TrainingAttributes {
+	return TrainingAttributes{
+		Charisma:     ca.Charisma,
+		Intelligence: ca.Intelligence,
+		Memory:       ca.Memory,
+		Perception:   ca.Perception,
+		Willpower:    ca.Willpower,
+	}
+}
+
+// TrainingAttributes are the five character attributes which determine the speed of skill training.
+type TrainingAttributes struct {
+	Charisma     int64
+	Intelligence int64
+	Memory       int64
+	Perception   int64
+	Willpower    int64
+}
+
+// Add returns the sum of two sets of attributes.
+func (ta TrainingAttributes) Add(other TrainingAttributes) TrainingAttributes {
+	return TrainingAttributes{
+		Charisma:     ta.Charisma + other.Charisma,
+		Intelligence: ta.Intelligence + other.Intelligence,
+		Memory:       ta.Memory + other.Memory,
+		Perception:   ta.Perception + other.Perception,
+		Willpower:    ta.Willpower + other.Willpower,
+	}
+}
+
+// Sub returns the difference of two sets of attributes.
+func (ta TrainingAttributes) Sub(other TrainingAttributes) TrainingAttributes {
+	return TrainingAttributes{
+		Charisma:     ta.Charisma - other.Charisma,
+		Intelligence: ta.Intelligence - other.Intelligence,
+		Memory:       ta.Memory - other.Memory,
+		Perception:   ta.Perception - other.Perception,
+		Willpower:    ta.Willpower - other.Willpower,
+	}
+}
+
+// SkillPointsPerMinute returns the training speed in skill points per minute
+// for a skill with the given primary and secondary attributes.
+func (ta TrainingAttributes) SkillPointsPerMinute(primaryAttributeID, secondaryAttributeID int64) float64 {
+	return float64(ta.Value(primaryAttributeID)) + float64(ta.Value(secondaryAttributeID))/2
+}
+
+// Value returns the value of an attribute identified by its dogma attribute ID.
+func (ta TrainingAttributes) Value(attributeID int64) int64 {
+	switch attributeID {
+	case EveDogmaAttributeCharisma:
+		return ta.Charisma
+	case EveDogmaAttributeIntelligence:
+		return ta.Intelligence
+	case EveDogmaAttributeMemory:
+		return ta.Memory
+	case EveDogmaAttributePerception:
+		return ta.Perception
+	case EveDogmaAttributeWillpower:
+		return ta.Willpower
+	}
+	return 0
+}
+
+// ImplantAttributeBonuses returns the attribute bonuses of an implant.
+// The values map dogma attribute IDs to the values of the implant type.
+func ImplantAttributeBonuses(values map[int64]float64) TrainingAttributes {
+	return TrainingAttributes{
+		Charisma:     int64(values[EveDogmaAttributeCharismaModifier]),
+		Intelligence: int64(values[EveDogmaAttributeIntelligenceModifier]),
+		Memory:       int64(values[EveDogmaAttributeMemoryModifier]),
+		Perception:   int64(values[EveDogmaAttributePerceptionModifier]),
+		Willpower:    int64(values[EveDogmaAttributeWillpowerModifier]),
+	}
+}
+
+// CharacterMissingSkill is a skill which a character still needs to train to a required level.
+type CharacterMissingSkill struct {
+	ActiveLevel   int
+	RequiredLevel int
+	SkillPoints   optional.Optional[int64]         // skill points still needed
+	TrainingTime  optional.Optional[time.Duration] // empty when training speed is unknown
+	Type          *EveType
+}
+
+var requiredSkillDogmaAttributes = []struct {
+	typeID int64
+	level  int64
+}{
+	{EveDogmaAttributePrimarySkillID, EveDogmaAttributePrimarySkillLevel},
+	{EveDogmaAttributeSecondarySkillID, EveDogmaAttributeSecondarySkillLevel},
+	{EveDogmaAttributeTertiarySkillID, EveDogmaAttributeTertiarySkillLevel},
+	{EveDogmaAttributeQuaternarySkillID, EveDogmaAttributeQuaternarySkillLevel},
+	{EveDogmaAttributeQuinarySkillID, EveDogmaAttributeQuinarySkillLevel},
+	{EveDogmaAttributeSenarySkillID, EveDogmaAttributeSenarySkillLevel},
+}
+
+// RequiredSkillsFromDogma returns the skills required by a type as map of skill type ID to level.
+// The values map dogma attribute IDs to the values of a type.
+func RequiredSkillsFromDogma(values map[int64]float64) map[int64]int {
+	required := make(map[int64]int)
+	for _, x := range requiredSkillDogmaAttributes {
+		typeID, ok := values[x.typeID]
+		if !ok {
+			continue
+		}
+		level, ok := values[x.level]
+		if !ok {
+			continue
+		}
+		required[int64(typeID)] = max(required[int64(typeID)], int(level))
+	}
+	return required
+}
+
+// ExpandSkillRequirements returns the required skills incl. all their prerequisites.
+// Required skills are given as map of skill type ID to level.
+func ExpandSkillRequirements(required map[int64]int, skills map[int64]*EveSkill) map[int64]int {
+	result := make(map[int64]int)
+	var add func(typeID int64, level int)
+	add = func(typeID int64, level int) {
+		current, ok := result[typeID]
+		if ok && current >= level {
+			return
+		}
+		result[typeID] = level
+		if ok {
+			return // prerequisites have already been added
+		}
+		skill, ok := skills[typeID]
+		if !ok {
+			return
+		}
+		for _, r := range skill.Requirements {
+			if r.Type == nil {
+				continue
+			}
+			add(r.Type.ID, r.Level)
+		}
+	}
+	for typeID, level := range required {
+		add(typeID, level)
+	}
+	return result
+}
+
+// CalcMissingSkills returns the skills a character is missing to fulfill the required skills
+// ordered by skill name.
+// Training times are only calculated when the attributes of the character are known.
+func CalcMissingSkills(
+	required map[int64]int,
+	skills map[int64]*EveSkill,
+	characterSkills map[int64]*CharacterSkill,
+	attributes optional.Optional[*CharacterAttributes],
+) []*CharacterMissingSkill {
+	var missing []*CharacterMissingSkill
+	for typeID, level := range required {
+		var activeLevel int
+		var skillPoints int64
+		if cs, ok := characterSkills[typeID]; ok {
+			activeLevel = int(cs.ActiveSkillLevel)
+			skillPoints = cs.SkillPointsInSkill
+		}
+		if activeLevel >= level {
+			continue
+		}
+		o := &CharacterMissingSkill{
+			ActiveLevel:   activeLevel,
+			RequiredLevel: level,
+		}
+		skill, ok := skills[typeID]
+		if !ok {
+			o.Type = &EveType{ID: typeID, Name: "?"}
+			missing = append(missing, o)
+			continue
+		}
+		o.Type = skill.Type
+		rank, ok := skill.Rank.Value()
+		if !ok {
+			missing = append(missing, o)
+			continue
+		}
+		sp := max(0, SkillPointsForLevel(rank, level)-max(skillPoints, SkillPointsForLevel(rank, activeLevel)))
+		o.SkillPoints.Set(sp)
+		ca, ok := attributes.Value()
+		if !ok || skill.PrimaryAttribute.IsEmpty() || skill.SecondaryAttribute.IsEmpty() {
+			missing = append(missing, o)
+			continue
+		}
+		speed := ca.SkillPointsPerMinute(skill.PrimaryAttribute.ValueOrZero(), skill.SecondaryAttribute.ValueOrZero())
+		if speed > 0 {
+			o.TrainingTime.Set(time.Duration(float64(sp) / speed * float64(time.Minute)))
+		}
+		missing = append(missing, o)
+	}
+	slices.SortFunc(missing, func(a, b *CharacterMissingSkill) int {
+		return cmp.Or(
+			strings.Compare(a.Type.Name, b.Type.Name),
+			cmp.Compare(a.Type.ID, b.Type.ID),
+		)
+	})
+	return missing
+}
@@ -0,0 +1,121 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestSkillPointsForLevel(t *testing.T) {
+	cases := []struct {
+		rank  int
+		level int
+		want  int64
+	}{
+		{1, 0, 0},
+		{1, 1, 250},
+		{1, 5, 256000},
+		{3, 4, 135765},
+		{1, 6, 256000},
+	}
+	for _, tc := range cases {
+		xassert.Equal(t, tc.want, app.SkillPointsForLevel(tc.rank, tc.level))
+	}
+}
+
+func TestCharacterAttributes_SkillPointsPerMinute(t *testing.T) {
+	ca := app.CharacterAttributes{
+		Charisma:     17,
+		Intelligence: 27,
+		Memory:       21,
+		Perception:   20,
+		Willpower:    19,
+	}
+	got := ca.SkillPointsPerMinute(app.EveDogmaAttributeIntelligence, app.EveDogmaAttributeMemory)
+	xassert.Equal(t, 37.5, got)
+}
+
+func TestRequiredSkillsFromDogma(t *testing.T) {
+	values := map[int64]float64{
+		app.EveDogmaAttributePrimarySkillID:      3300,
+		app.EveDogmaAttributePrimarySkillLevel:   3,
+		app.EveDogmaAttributeSecondarySkillID:    3301,
+		app.EveDogmaAttributeSecondarySkillLevel: 1,
+		app.EveDogmaAttributeTertiarySkillID:     3302, // level missing
+	}
+	got := app.RequiredSkillsFromDogma(values)
+	xassert.Equal(t, map[int64]int{3300: 3, 3301: 1}, got)
+}
+
+func TestExpandSkillRequirements(t *testing.T) {
+	a := &app.EveType{ID: 1, Name: "Alpha"}
+	b := &app.EveType{ID: 2, Name: "Bravo"}
+	c := &app.EveType{ID: 3, Name: "Charlie"}
+	skills := map[int64]*app.EveSkill{
+		1: {Type: a, Requirements: map[int]*app.EveRequiredSkill{0: {Type: b, Level: 3}}},
+		2: {Type: b, Requirements: map[int]*app.EveRequiredSkill{0: {Type: c, Level: 1}}},
+		3: {Type: c},
+	}
+	t.Run("should add prerequisites recursively", func(t *testing.T) {
+		got := app.ExpandSkillRequirements(map[int64]int{1: 2}, skills)
+		xassert.Equal(t, map[int64]int{1: 2, 2: 3, 3: 1}, got)
+	})
+	t.Run("should keep highest required level", func(t *testing.T) {
+		got := app.ExpandSkillRequirements(map[int64]int{1: 1, 2: 5}, skills)
+		xassert.Equal(t, map[int64]int{1: 1, 2: 5, 3: 1}, got)
+	})
+}
+
+func TestCalcMissingSkills(t *testing.T) {
+	alpha := &app.EveType{ID: 1, Name: "Alpha"}
+	bravo := &app.EveType{ID: 2, Name: "Bravo"}
+	skills := map[int64]*app.EveSkill{
+		1: {
+			PrimaryAttribute:   optional.New[int64](app.EveDogmaAttributeIntelligence),
+			Rank:               optional.New(1),
+			SecondaryAttribute: optional.New[int64](app.EveDogmaAttributeMemory),
+			Type:               alpha,
+		},
+		2: {
+			PrimaryAttribute:   optional.New[int64](app.EveDogmaAttributeIntelligence),
+			Rank:               optional.New(2),
+			SecondaryAttribute: optional.New[int64](app.EveDogmaAttributeMemory),
+			Type:               bravo,
+		},
+	}
+	attributes := optional.New(&app.CharacterAttributes{Intelligence: 20, Memory: 20})
+	t.Run("should report missing skills with training time", func(t *testing.T) {
+		characterSkills := map[int64]*app.CharacterSkill{
+			1: {ActiveSkillLevel: 1, SkillPointsInSkill: 250, Type: alpha},
+		}
+		got := app.CalcMissingSkills(map[int64]int{1: 2, 2: 1}, skills, characterSkills, attributes)
+		if assert.Len(t, got, 2) {
+			xassert.Equal(t, alpha, got[0].Type)
+			xassert.Equal(t, 1, got[0].ActiveLevel)
+			xassert.Equal(t, 2, got[0].RequiredLevel)
+			xassert.EqualOptional(t, 1165, got[0].SkillPoints)
+			xassert.EqualOptional(t, time.Duration(1165.0/30.0*float64(time.Minute)), got[0].TrainingTime)
+			xassert.Equal(t, bravo, got[1].Type)
+			xassert.EqualOptional(t, 500, got[1].SkillPoints)
+		}
+	})
+	t.Run("should report nothing when all skills are trained", func(t *testing.T) {
+		characterSkills := map[int64]*app.CharacterSkill{
+			1: {ActiveSkillLevel: 5, Type: alpha},
+		}
+		got := app.CalcMissingSkills(map[int64]int{1: 2}, skills, characterSkills, attributes)
+		assert.Empty(t, got)
+	})
+	t.Run("should report no training time when attributes are unknown", func(t *testing.T) {
+		got := app.CalcMissingSkills(map[int64]int{1: 1}, skills, nil, optional.Optional[*app.CharacterAttributes]{})
+		if assert.Len(t, got, 1) {
+			xassert.EqualOptional(t, 250, got[0].SkillPoints)
+			xassert.Empty(t, got[0].TrainingTime)
+		}
+	})
+}
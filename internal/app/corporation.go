@@ -139,7 +139,57 @@ func (wt *CorporationWalletTransaction) Total() float64 {
 }
 
 // CorporationMember represents a member in an EVE Online corporation.
+//
+// The tracking data, e.g. last logon and location, is only available
+// when the member tracking section has been updated.
 type CorporationMember struct {
 	CorporationID int64
 	Character     *EveEntity
+	Base          optional.Optional[*EveLocationShort]
+	Location      optional.Optional[*EveLocationShort]
+	LogoffDate    optional.Optional[time.Time]
+	LogonDate     optional.Optional[time.Time]
+	ShipType      optional.Optional[*EntityShort]
+	StartDate     optional.Optional[time.Time]
+}
+
+// HasTracking reports whether tracking data is available for a member.
+func (cm CorporationMember) HasTracking() bool {
+	return !cm.LogonDate.IsEmpty() || !cm.LogoffDate.IsEmpty() || !cm.StartDate.IsEmpty()
+}
+
+// IsOnline reports whether a member is currently logged in.
+func (cm CorporationMember) IsOnline() bool {
+	logon, ok := cm.LogonDate.Value()
+	if !ok {
+		return false
+	}
+	logoff, ok := cm.LogoffDate.Value()
+	if !ok {
+		return true
+	}
+	return logon.After(logoff)
+}
+
+// InactiveFor returns how long a member has not logged in at time now.
+// Returns an empty value when the last logon is unknown.
+func (cm CorporationMember) InactiveFor(now time.Time) optional.Optional[time.Duration] {
+	if cm.IsOnline() {
+		return optional.New(time.Duration(0))
+	}
+	last, ok := cm.LogoffDate.Value()
+	if !ok {
+		last, ok = cm.LogonDate.Value()
+		if !ok {
+			return optional.Optional[time.Duration]{}
+		}
+	}
+	return optional.New(max(0, now.Sub(last)))
+}
+
+// IsInactive reports whether a member has not logged in for at least d at time now.
+// Members with unknown last logon are not reported as inactive.
+func (cm CorporationMember) IsInactive(now time.Time, d time.Duration) bool {
+	v, ok := cm.InactiveFor(now).Value()
+	return ok && v >= d
 }
@@ -2,8 +2,10 @@ package app_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
 	"github.com/ErikKalkoken/evebuddy/internal/xassert"
 )
 
@@ -32,3 +34,29 @@ func TestCorporation_NameorZero(t *testing.T) {
 		xassert.Equal(t, "", c.NameOrZero())
 	})
 }
+
+func TestCorporationMember_Activity(t *testing.T) {
+	now := time.Now().UTC()
+	cases := []struct {
+		name         string
+		logon        optional.Optional[time.Time]
+		logoff       optional.Optional[time.Time]
+		isOnline     bool
+		inactiveFor  optional.Optional[time.Duration]
+		isInactive30 bool
+	}{
+		{"no tracking", optional.Optional[time.Time]{}, optional.Optional[time.Time]{}, false, optional.Optional[time.Duration]{}, false},
+		{"online", optional.New(now.Add(-time.Hour)), optional.New(now.Add(-2 * time.Hour)), true, optional.New(time.Duration(0)), false},
+		{"logged off recently", optional.New(now.Add(-3 * time.Hour)), optional.New(now.Add(-2 * time.Hour)), false, optional.New(2 * time.Hour), false},
+		{"inactive", optional.New(now.Add(-50 * 24 * time.Hour)), optional.New(now.Add(-40 * 24 * time.Hour)), false, optional.New(40 * 24 * time.Hour), true},
+		{"only logoff known", optional.Optional[time.Time]{}, optional.New(now.Add(-31 * 24 * time.Hour)), false, optional.New(31 * 24 * time.Hour), true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := app.CorporationMember{LogonDate: tc.logon, LogoffDate: tc.logoff}
+			xassert.Equal(t, tc.isOnline, cm.IsOnline())
+			xassert.Equal(t, tc.inactiveFor, cm.InactiveFor(now))
+			xassert.Equal(t, tc.isInactive30, cm.IsInactive(now, 30*24*time.Hour))
+		})
+	}
+}
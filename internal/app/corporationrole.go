@@ -0,0 +1,121 @@
+package app
+
+import (
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+// RoleLocation is where a corporation role applies.
+type RoleLocation uint
+
+const (
+	RoleLocationUndefined RoleLocation = iota
+	RoleLocationBase
+	RoleLocationGeneral
+	RoleLocationGrantable
+	RoleLocationHQ
+	RoleLocationOther
+)
+
+func (x RoleLocation) String() string {
+	switch x {
+	case RoleLocationBase:
+		return "base"
+	case RoleLocationGeneral:
+		return "general"
+	case RoleLocationGrantable:
+		return "grantable"
+	case RoleLocationHQ:
+		return "hq"
+	case RoleLocationOther:
+		return "other"
+	}
+	return "?"
+}
+
+func (x RoleLocation) Display() string {
+	switch x {
+	case RoleLocationBase:
+		return "Base"
+	case RoleLocationGeneral:
+		return "General"
+	case RoleLocationGrantable:
+		return "Grantable"
+	case RoleLocationHQ:
+		return "HQ"
+	case RoleLocationOther:
+		return "Other"
+	}
+	return "?"
+}
+
+// RoleAtLocation is a corporation role, which applies at a location.
+type RoleAtLocation struct {
+	Location RoleLocation
+	Role     Role
+}
+
+// Display returns the name of the role and the location, unless it is a general role.
+func (x RoleAtLocation) Display() string {
+	if x.Location == RoleLocationGeneral {
+		return x.Role.Display()
+	}
+	return x.Role.Display() + " (" + x.Location.Display() + ")"
+}
+
+// CorporationMemberRole represents a role held by a member of a corporation.
+type CorporationMemberRole struct {
+	CorporationID int64
+	Character     *EveEntity
+	Location      RoleLocation
+	Role          Role
+}
+
+// CorporationRoleChange represents a role which was granted to or revoked from a corporation member
+// between two updates.
+type CorporationRoleChange struct {
+	ID            int64
+	ChangedAt     time.Time
+	Character     *EveEntity
+	CorporationID int64
+	IsGranted     bool
+	Location      RoleLocation
+	Role          Role
+}
+
+// Display returns a user friendly description of a change.
+func (x CorporationRoleChange) Display() string {
+	r := RoleAtLocation{Location: x.Location, Role: x.Role}
+	if x.IsGranted {
+		return "Granted " + r.Display()
+	}
+	return "Revoked " + r.Display()
+}
+
+// CorporationTitle represents a title in a corporation.
+type CorporationTitle struct {
+	CorporationID int64
+	Name          string
+	TitleID       int64
+}
+
+// CorporationMemberTitle represents a title held by a member of a corporation.
+type CorporationMemberTitle struct {
+	CorporationID int64
+	Character     *EveEntity
+	Title         *CorporationTitle
+}
+
+// CorporationMemberAudit represents the roles and titles of a corporation member.
+type CorporationMemberAudit struct {
+	Character *EveEntity
+	Roles     set.Set[RoleAtLocation]
+	Titles    []*CorporationTitle
+}
+
+// IsDirector reports whether a member has the director role,
+// which includes all other roles.
+func (x CorporationMemberAudit) IsDirector() bool {
+	return x.Roles.Contains(RoleAtLocation{Location: RoleLocationGeneral, Role: RoleDirector})
+}
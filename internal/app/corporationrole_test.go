@@ -0,0 +1,45 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestRoleAtLocation_Display(t *testing.T) {
+	cases := []struct {
+		location app.RoleLocation
+		want     string
+	}{
+		{app.RoleLocationGeneral, "Station Manager"},
+		{app.RoleLocationHQ, "Station Manager (HQ)"},
+		{app.RoleLocationGrantable, "Station Manager (Grantable)"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.want, func(t *testing.T) {
+			x := app.RoleAtLocation{Location: tc.location, Role: app.RoleStationManager}
+			xassert.Equal(t, tc.want, x.Display())
+		})
+	}
+}
+
+func TestCorporationRoleChange_Display(t *testing.T) {
+	x := app.CorporationRoleChange{IsGranted: true, Location: app.RoleLocationGeneral, Role: app.RoleStationManager}
+	xassert.Equal(t, "Granted Station Manager", x.Display())
+	x.IsGranted = false
+	xassert.Equal(t, "Revoked Station Manager", x.Display())
+	x.Location = app.RoleLocationBase
+	xassert.Equal(t, "Revoked Station Manager (Base)", x.Display())
+}
+
+func TestCorporationMemberAudit_IsDirector(t *testing.T) {
+	director := app.RoleAtLocation{Location: app.RoleLocationGeneral, Role: app.RoleDirector}
+	grantable := app.RoleAtLocation{Location: app.RoleLocationGrantable, Role: app.RoleDirector}
+	accountant := app.RoleAtLocation{Location: app.RoleLocationGeneral, Role: app.RoleAccountant}
+	xassert.Equal(t, true, app.CorporationMemberAudit{Roles: set.Of(director)}.IsDirector())
+	xassert.Equal(t, false, app.CorporationMemberAudit{Roles: set.Of(grantable)}.IsDirector())
+	xassert.Equal(t, false, app.CorporationMemberAudit{Roles: set.Of(accountant)}.IsDirector())
+}
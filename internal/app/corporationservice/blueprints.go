@@ -0,0 +1,85 @@
+package corporationservice
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/fnt-eve/goesi-openapi/esi"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
+)
+
+// ListAllBlueprints returns the blueprints of all corporations.
+func (s *CorporationService) ListAllBlueprints(ctx context.Context) ([]*app.CorporationBlueprint, error) {
+	return s.st.ListAllCorporationBlueprints(ctx)
+}
+
+// ListBlueprints returns the blueprints of a corporation.
+func (s *CorporationService) ListBlueprints(ctx context.Context, corporationID int64) ([]*app.CorporationBlueprint, error) {
+	return s.st.ListCorporationBlueprints(ctx, corporationID)
+}
+
+func (s *CorporationService) updateBlueprintsESI(ctx context.Context, arg corporationSectionUpdateParams) (bool, error) {
+	if arg.section != app.SectionCorporationBlueprints {
+		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
+	}
+	return s.updateSectionIfChanged(
+		ctx, arg, false,
+		func(ctx context.Context, arg corporationSectionUpdateParams) (any, error) {
+			ctx = xgoesi.NewContextWithOperationID(ctx, "GetCorporationsCorporationIdBlueprints")
+			rows, err := xgoesi.FetchPages(
+				func(page int32) ([]esi.CorporationsCorporationIdBlueprintsGetInner, *http.Response, error) {
+					return s.esiClient.CorporationAPI.GetCorporationsCorporationIdBlueprints(ctx, arg.corporationID).Page(page).Execute()
+				},
+			)
+			if err != nil {
+				return false, err
+			}
+			slices.SortFunc(rows, func(a, b esi.CorporationsCorporationIdBlueprintsGetInner) int {
+				return cmp.Compare(a.ItemId, b.ItemId)
+			})
+			slog.Debug("Received blueprints from ESI", "corporationID", arg.corporationID, "count", len(rows))
+			return rows, nil
+		},
+		func(ctx context.Context, arg corporationSectionUpdateParams, data any) (bool, error) {
+			rows := data.([]esi.CorporationsCorporationIdBlueprintsGetInner)
+			var typeIDs set.Set[int64]
+			for _, r := range rows {
+				typeIDs.Add(r.TypeId)
+			}
+			if err := s.eus.AddMissingTypes(ctx, typeIDs); err != nil {
+				return false, err
+			}
+			var blueprints []storage.CreateCorporationBlueprintParams
+			for _, r := range rows {
+				locationFlag, found := locationFlagFromESIValue[r.LocationFlag]
+				if !found {
+					locationFlag = app.FlagUnknown
+					slog.Warn("Unknown location flag encountered", "corporationID", arg.corporationID, "blueprint", r)
+				}
+				blueprints = append(blueprints, storage.CreateCorporationBlueprintParams{
+					CorporationID:      arg.corporationID,
+					ItemID:             r.ItemId,
+					LocationFlag:       locationFlag,
+					LocationID:         r.LocationId,
+					MaterialEfficiency: int(r.MaterialEfficiency),
+					Quantity:           int(r.Quantity),
+					Runs:               int(r.Runs),
+					TimeEfficiency:     int(r.TimeEfficiency),
+					TypeID:             r.TypeId,
+				})
+			}
+			if err := s.st.ReplaceCorporationBlueprints(ctx, arg.corporationID, blueprints); err != nil {
+				return false, err
+			}
+			slog.Info("Stored updated blueprints", "corporationID", arg.corporationID, "count", len(blueprints))
+			return true, nil
+		})
+}
@@ -0,0 +1,67 @@
+package corporationservice
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestUpdateBlueprintsESI(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	ctx := context.Background()
+	t.Run("should replace blueprints with new data from ESI", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		s := NewFake(Params{Storage: st, CharacterService: &CharacterServiceFake{Token: &app.CharacterToken{
+			AccessToken: "accessToken",
+		}}})
+		c := factory.CreateCorporation()
+		factory.CreateCorporationBlueprint(storage.CreateCorporationBlueprintParams{CorporationID: c.ID})
+		et := factory.CreateEveType()
+		location := factory.CreateEveLocationStructure()
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/corporations/%d/blueprints?page=1", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"item_id":             1000000010495,
+				"location_flag":       "CorpSAG1",
+				"location_id":         location.ID,
+				"material_efficiency": 10,
+				"quantity":            -1,
+				"runs":                -1,
+				"time_efficiency":     20,
+				"type_id":             et.ID,
+			}}),
+		)
+		// when
+		changed, err := s.updateBlueprintsESI(ctx, corporationSectionUpdateParams{
+			corporationID: c.ID,
+			section:       app.SectionCorporationBlueprints,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		oo, err := s.ListBlueprints(ctx, c.ID)
+		require.NoError(t, err)
+		require.Len(t, oo, 1)
+		o := oo[0]
+		xassert.Equal(t, 1000000010495, o.ItemID)
+		xassert.Equal(t, app.FlagCorpSAG1, o.LocationFlag)
+		xassert.Equal(t, 10, o.MaterialEfficiency)
+		xassert.Equal(t, et.ID, o.Type.ID)
+		assert.True(t, o.IsOriginal())
+	})
+}
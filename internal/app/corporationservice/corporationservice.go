@@ -2,6 +2,7 @@ package corporationservice
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -12,12 +13,14 @@ import (
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
 	"github.com/ErikKalkoken/evebuddy/internal/app/eveuniverseservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationsink"
 	"github.com/ErikKalkoken/evebuddy/internal/app/statuscache"
 	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
 )
 
 // Cache defines a cache.
 type Cache interface {
+	Delete(string)
 	GetInt64(string) (int64, bool)
 	SetInt64(string, int64, time.Duration)
 }
@@ -26,8 +29,19 @@ type CharacterService interface {
 	TokenSourceForCorporation(ctx context.Context, corporationID int64, roles set.Set[app.Role], scopes set.Set[string]) (oauth2.TokenSource, int64, error)
 }
 
+// NotificationRules decides how notifications are delivered, e.g. during quiet hours.
+type NotificationRules interface {
+	Apply(ctx context.Context, n notificationsink.Notification) (notificationsink.Notification, bool)
+}
+
 type Settings interface {
 	MaxWalletTransactions() int
+	NotifyIndustryJobsEarliest() time.Time
+	NotifyIndustryJobsEnabled() bool
+	NotifyStructureFuelHours() []int
+	NotifyStructuresEarliest() time.Time
+	NotifyStructuresEnabled() bool
+	NotifyStructureTimerHours() int
 }
 
 type StatusCache interface {
@@ -37,17 +51,20 @@ type StatusCache interface {
 
 // CorporationService provides access to all managed EVE Online corporations both online and from local storage.
 type CorporationService struct {
-	cache            Cache
-	concurrencyLimit int
-	cs               CharacterService
-	esiClient        *esi.APIClient
-	eus              *eveuniverseservice.EVEUniverseService
-	httpClient       *http.Client
-	scs              StatusCache
-	settings         Settings
-	sfg              singleflight.Group
-	signals          *app.Signals
-	st               *storage.Storage
+	cache                   Cache
+	concurrencyLimit        int
+	cs                      CharacterService
+	esiClient               *esi.APIClient
+	eus                     *eveuniverseservice.EVEUniverseService
+	httpClient              *http.Client
+	notificationRules       NotificationRules     // Optional rules for delivering notifications
+	notificationSink        notificationsink.Sink // Optional destination for notifications in addition to the desktop
+	scs                     StatusCache
+	sendDesktopNotification func(title, content string) // Callback for sending a desktop notification via Fyne API
+	settings                Settings
+	sfg                     singleflight.Group
+	signals                 *app.Signals
+	st                      *storage.Storage
 }
 
 type Params struct {
@@ -61,7 +78,10 @@ type Params struct {
 	StatusCacheService StatusCache
 	Storage            *storage.Storage
 	// optional
-	HTTPClient *http.Client
+	HTTPClient              *http.Client
+	NotificationRules       NotificationRules
+	NotificationSink        notificationsink.Sink
+	SendDesktopNotification func(title, content string)
 }
 
 // New creates a new corporation service and returns it.
@@ -92,15 +112,20 @@ func New(arg Params) *CorporationService {
 		panic("Storage missing")
 	}
 	s := &CorporationService{
-		cache:            arg.Cache,
-		concurrencyLimit: -1, // Default is no limit
-		cs:               arg.CharacterService,
-		esiClient:        arg.ESIClient,
-		eus:              arg.EveUniverseService,
-		scs:              arg.StatusCacheService,
-		settings:         arg.Settings,
-		signals:          arg.Signals,
-		st:               arg.Storage,
+		cache:             arg.Cache,
+		concurrencyLimit:  -1, // Default is no limit
+		cs:                arg.CharacterService,
+		esiClient:         arg.ESIClient,
+		eus:               arg.EveUniverseService,
+		notificationRules: arg.NotificationRules,
+		notificationSink:  arg.NotificationSink,
+		scs:               arg.StatusCacheService,
+		sendDesktopNotification: func(_, _ string) {
+			slog.Warn("Desktop notifications not configured")
+		},
+		settings: arg.Settings,
+		signals:  arg.Signals,
+		st:       arg.Storage,
 	}
 	if arg.HTTPClient == nil {
 		s.httpClient = http.DefaultClient
@@ -110,5 +135,8 @@ func New(arg Params) *CorporationService {
 	if arg.ConcurrencyLimit > 0 {
 		s.concurrencyLimit = arg.ConcurrencyLimit
 	}
+	if arg.SendDesktopNotification != nil {
+		s.sendDesktopNotification = arg.SendDesktopNotification
+	}
 	return s
 }
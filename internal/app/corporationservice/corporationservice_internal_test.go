@@ -3,6 +3,7 @@ package corporationservice
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/ErikKalkoken/go-set"
 	"github.com/fnt-eve/goesi-openapi"
@@ -31,6 +32,30 @@ func (s *SettingsFake) MaxWalletTransactions() int {
 	return s.MaxTransactions
 }
 
+func (s *SettingsFake) NotifyIndustryJobsEarliest() time.Time {
+	return time.Time{}
+}
+
+func (s *SettingsFake) NotifyIndustryJobsEnabled() bool {
+	return false
+}
+
+func (s *SettingsFake) NotifyStructureFuelHours() []int {
+	return []int{}
+}
+
+func (s *SettingsFake) NotifyStructuresEarliest() time.Time {
+	return time.Time{}
+}
+
+func (s *SettingsFake) NotifyStructuresEnabled() bool {
+	return false
+}
+
+func (s *SettingsFake) NotifyStructureTimerHours() int {
+	return 1
+}
+
 type CharacterServiceFake struct {
 	Token          *app.CharacterToken
 	CorporationIDs set.Set[int64]
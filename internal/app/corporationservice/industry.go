@@ -8,6 +8,7 @@ import (
 	"maps"
 	"net/http"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/ErikKalkoken/go-set"
@@ -36,6 +37,62 @@ func (s *CorporationService) ListCorporationIndustryJobs(ctx context.Context, co
 	return s.st.ListCorporationIndustryJobs(ctx, corporationID)
 }
 
+const cacheKeyIndustryJobReadyNotified = "industry-job-ready-notified"
+
+// industryJobReadyNotifyTimeout is how long a ready job is not notified again.
+// It exceeds the maximum notify timeout, so that jobs are notified once only.
+const industryJobReadyNotifyTimeout = 100 * 24 * time.Hour
+
+// NotifyReadyIndustryJobs sends a notification when industry jobs of a corporation
+// have become ready for delivery. Each job is notified once only.
+func (s *CorporationService) NotifyReadyIndustryJobs(ctx context.Context, corporationID int64, earliest time.Time, notify func(title, content string)) error {
+	_, err, _ := s.sfg.Do(fmt.Sprintf("NotifyReadyIndustryJobs-%d", corporationID), func() (any, error) {
+		jobs, err := s.st.ListCorporationIndustryJobs(ctx, corporationID)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		var lines []string
+		for _, j := range jobs {
+			if !j.IsReady(now) || j.EndDate.Before(earliest) {
+				continue
+			}
+			key := makeKeyIndustryJobReadyNotified(j.JobID)
+			if _, found := s.cache.GetInt64(key); found {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf(
+				"%s job for %s at %s installed by %s",
+				j.Activity.Display(),
+				j.BlueprintType.Name,
+				j.Location.DisplayName(),
+				j.Installer.Name,
+			))
+			s.cache.SetInt64(key, j.EndDate.Unix(), industryJobReadyNotifyTimeout)
+		}
+		if len(lines) == 0 {
+			return nil, nil
+		}
+		c, err := s.GetCorporation(ctx, corporationID)
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(lines)
+		title := fmt.Sprintf("%s: %d industry job(s) ready", c.NameOrZero(), len(lines))
+		notify(title, strings.Join(lines, "\n"))
+		slog.Info("Notified ready industry jobs", "corporationID", corporationID, "count", len(lines))
+		return nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("NotifyReadyIndustryJobs for corporation %d: %w", corporationID, err)
+	}
+	return nil
+}
+
+func makeKeyIndustryJobReadyNotified(jobID int64) string {
+	return fmt.Sprintf("%s-%d", cacheKeyIndustryJobReadyNotified, jobID)
+}
+
 var jobStatusFromESIValue = map[string]app.IndustryJobStatus{
 	"active":    app.JobActive,
 	"cancelled": app.JobCancelled,
@@ -542,3 +542,46 @@ func TestUpdateIndustryJobsESI(t *testing.T) {
 		}
 	})
 }
+
+func TestNotifyReadyIndustryJobs(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	earliest := now.Add(-24 * time.Hour)
+	cases := []struct {
+		name         string
+		status       app.IndustryJobStatus
+		endDate      time.Time
+		shouldNotify bool
+	}{
+		{"ready job", app.JobReady, now.Add(-3 * time.Hour), true},
+		{"active job after end date", app.JobActive, now.Add(-3 * time.Hour), true},
+		{"active job before end date", app.JobActive, now.Add(3 * time.Hour), false},
+		{"ready job before earliest", app.JobReady, now.Add(-48 * time.Hour), false},
+		{"delivered job", app.JobDelivered, now.Add(-3 * time.Hour), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			testutil.MustTruncateTables(db)
+			s := NewFake(Params{Storage: st})
+			j := factory.CreateCorporationIndustryJob(storage.UpdateOrCreateCorporationIndustryJobParams{
+				EndDate: tc.endDate,
+				Status:  tc.status,
+			})
+			var count int
+			notify := func(title, content string) {
+				count++
+			}
+			// when
+			err1 := s.NotifyReadyIndustryJobs(ctx, j.CorporationID, earliest, notify)
+			err2 := s.NotifyReadyIndustryJobs(ctx, j.CorporationID, earliest, notify)
+			// then
+			if assert.NoError(t, err1) && assert.NoError(t, err2) {
+				xassert.Equal(t, tc.shouldNotify, count == 1)
+				assert.LessOrEqual(t, count, 1)
+			}
+		})
+	}
+}
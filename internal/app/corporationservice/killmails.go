@@ -0,0 +1,66 @@
+package corporationservice
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/fnt-eve/goesi-openapi/esi"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
+)
+
+// ListKillmails returns the kills and losses of a corporation with the most recent first.
+func (s *CorporationService) ListKillmails(ctx context.Context, corporationID int64) ([]*app.EveKillmail, error) {
+	return s.st.ListCorporationKillmails(ctx, corporationID)
+}
+
+func (s *CorporationService) updateKillmailsESI(ctx context.Context, arg corporationSectionUpdateParams) (bool, error) {
+	if arg.section != app.SectionCorporationKillmails {
+		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
+	}
+	return s.updateSectionIfChanged(
+		ctx, arg, true,
+		func(ctx context.Context, arg corporationSectionUpdateParams) (any, error) {
+			ctx = xgoesi.NewContextWithOperationID(ctx, "GetCorporationsCorporationIdKillmailsRecent")
+			rows, err := xgoesi.FetchPages(
+				func(page int32) ([]esi.CorporationsCorporationIdKillmailsRecentGetInner, *http.Response, error) {
+					return s.esiClient.KillmailsAPI.GetCorporationsCorporationIdKillmailsRecent(ctx, arg.corporationID).Page(page).Execute()
+				},
+			)
+			if err != nil {
+				return false, err
+			}
+			slog.Debug("Received killmails from ESI", "corporationID", arg.corporationID, "count", len(rows))
+			return rows, nil
+		},
+		func(ctx context.Context, arg corporationSectionUpdateParams, data any) (bool, error) {
+			rows := data.([]esi.CorporationsCorporationIdKillmailsRecentGetInner)
+			currentIDs, err := s.st.ListCorporationKillmailIDs(ctx, arg.corporationID)
+			if err != nil {
+				return false, err
+			}
+			var added set.Set[int64]
+			for _, r := range rows {
+				if currentIDs.Contains(r.KillmailId) {
+					continue
+				}
+				km, err := s.eus.GetOrCreateKillmailESI(ctx, r.KillmailId, r.KillmailHash)
+				if err != nil {
+					return false, err
+				}
+				if err := s.st.CreateCorporationKillmail(ctx, arg.corporationID, km.ID); err != nil {
+					return false, err
+				}
+				added.Add(km.ID)
+			}
+			if added.Size() == 0 {
+				return false, nil
+			}
+			slog.Info("Added killmails", "corporationID", arg.corporationID, "count", added.Size())
+			return true, nil
+		})
+}
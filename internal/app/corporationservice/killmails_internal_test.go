@@ -0,0 +1,66 @@
+package corporationservice
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestUpdateKillmailsESI(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	ctx := context.Background()
+	t.Run("should link new killmails to the corporation", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		s := NewFake(Params{Storage: st, CharacterService: &CharacterServiceFake{Token: &app.CharacterToken{
+			AccessToken: "accessToken",
+		}}})
+		c := factory.CreateCorporation()
+		km1 := factory.CreateEveKillmail()
+		km2 := factory.CreateEveKillmail()
+		err := st.CreateCorporationKillmail(ctx, c.ID, km1.ID)
+		require.NoError(t, err)
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/corporations/%d/killmails/recent?page=1", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+				{
+					"killmail_hash": km1.Hash,
+					"killmail_id":   km1.ID,
+				},
+				{
+					"killmail_hash": km2.Hash,
+					"killmail_id":   km2.ID,
+				},
+			}),
+		)
+		// when
+		changed, err := s.updateKillmailsESI(ctx, corporationSectionUpdateParams{
+			corporationID: c.ID,
+			section:       app.SectionCorporationKillmails,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		oo, err := s.ListKillmails(ctx, c.ID)
+		require.NoError(t, err)
+		got := make([]int64, 0)
+		for _, o := range oo {
+			got = append(got, o.ID)
+		}
+		assert.ElementsMatch(t, []int64{km1.ID, km2.ID}, got)
+		xassert.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+}
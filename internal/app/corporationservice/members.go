@@ -6,9 +6,12 @@ import (
 	"log/slog"
 
 	"github.com/ErikKalkoken/go-set"
+	"github.com/fnt-eve/goesi-openapi/esi"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
 	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
 	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
 )
 
@@ -66,3 +69,66 @@ func (s *CorporationService) updateMembersESI(ctx context.Context, arg corporati
 			return true, nil
 		})
 }
+
+func (s *CorporationService) updateMemberTrackingESI(ctx context.Context, arg corporationSectionUpdateParams) (bool, error) {
+	if arg.section != app.SectionCorporationMemberTracking {
+		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
+	}
+	return s.updateSectionIfChanged(
+		ctx, arg, true,
+		func(ctx context.Context, arg corporationSectionUpdateParams) (any, error) {
+			ctx = xgoesi.NewContextWithOperationID(ctx, "GetCorporationsCorporationIdMembertracking")
+			members, _, err := s.esiClient.CorporationAPI.GetCorporationsCorporationIdMembertracking(ctx, arg.corporationID).Execute()
+			if err != nil {
+				return false, err
+			}
+			return members, nil
+		},
+		func(ctx context.Context, arg corporationSectionUpdateParams, data any) (bool, error) {
+			members := data.([]esi.CorporationsCorporationIdMembertrackingGetInner)
+			var entityIDs, locationIDs, typeIDs set.Set[int64]
+			for _, m := range members {
+				entityIDs.Add(m.CharacterId)
+				if x := m.BaseId; x != nil {
+					locationIDs.Add(*x)
+				}
+				if x := m.LocationId; x != nil {
+					locationIDs.Add(*x)
+				}
+				if x := m.ShipTypeId; x != nil {
+					typeIDs.Add(*x)
+				}
+			}
+			g := new(errgroup.Group)
+			g.Go(func() error {
+				_, err := s.eus.AddMissingEntities(ctx, entityIDs)
+				return err
+			})
+			g.Go(func() error {
+				return s.eus.AddMissingLocations(ctx, locationIDs)
+			})
+			g.Go(func() error {
+				return s.eus.AddMissingTypes(ctx, typeIDs)
+			})
+			if err := g.Wait(); err != nil {
+				return false, err
+			}
+			for _, m := range members {
+				err := s.st.UpdateOrCreateCorporationMemberTracking(ctx, storage.UpdateOrCreateCorporationMemberTrackingParams{
+					BaseID:        optional.FromPtr(m.BaseId),
+					CharacterID:   m.CharacterId,
+					CorporationID: arg.corporationID,
+					LocationID:    optional.FromPtr(m.LocationId),
+					LogoffDate:    optional.FromPtr(m.LogoffDate),
+					LogonDate:     optional.FromPtr(m.LogonDate),
+					ShipTypeID:    optional.FromPtr(m.ShipTypeId),
+					StartDate:     optional.FromPtr(m.StartDate),
+				})
+				if err != nil {
+					return false, err
+				}
+			}
+			slog.Info("Updated corporation member tracking", "corporationID", arg.corporationID, "count", len(members))
+			return true, nil
+		})
+}
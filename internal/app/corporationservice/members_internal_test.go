@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/ErikKalkoken/go-set"
 	"github.com/jarcoal/httpmock"
@@ -79,3 +80,69 @@ func TestUpdateCorporationMembersESI(t *testing.T) {
 		xassert.Equal(t, want, got)
 	})
 }
+
+func TestUpdateCorporationMemberTrackingESI(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	ctx := context.Background()
+	t.Run("should update tracking data of members", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		s := NewFake(Params{Storage: st, CharacterService: &CharacterServiceFake{Token: &app.CharacterToken{AccessToken: "accessToken"}}})
+		c := factory.CreateCorporation()
+		m1 := factory.CreateCorporationMember(storage.CorporationMemberParams{
+			CorporationID: c.ID,
+		})
+		m2 := factory.CreateEveEntityCharacter()
+		base := factory.CreateEveLocationStation()
+		location := factory.CreateEveLocationStation()
+		ship := factory.CreateEveType()
+		logoff := time.Now().UTC().Add(-45 * 24 * time.Hour).Truncate(time.Second)
+		logon := logoff.Add(-2 * time.Hour)
+		start := logoff.Add(-365 * 24 * time.Hour)
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/corporations/%d/membertracking", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"base_id":      base.ID,
+				"character_id": m1.Character.ID,
+				"location_id":  location.ID,
+				"logoff_date":  logoff.Format(app.DateTimeFormatESI),
+				"logon_date":   logon.Format(app.DateTimeFormatESI),
+				"ship_type_id": ship.ID,
+				"start_date":   start.Format(app.DateTimeFormatESI),
+			}, {
+				"character_id": m2.ID,
+			}}),
+		)
+		// when
+		changed, err := s.updateMemberTrackingESI(ctx, corporationSectionUpdateParams{
+			corporationID: c.ID,
+			section:       app.SectionCorporationMemberTracking,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		x1, err := st.GetCorporationMember(ctx, storage.CorporationMemberParams{
+			CorporationID: c.ID,
+			CharacterID:   m1.Character.ID,
+		})
+		require.NoError(t, err)
+		xassert.Equal(t, base.ID, x1.Base.MustValue().ID)
+		xassert.Equal(t, location.ID, x1.Location.MustValue().ID)
+		xassert.Equal(t, ship.ID, x1.ShipType.MustValue().ID)
+		xassert.EqualOptional(t, logoff, x1.LogoffDate)
+		xassert.EqualOptional(t, logon, x1.LogonDate)
+		xassert.EqualOptional(t, start, x1.StartDate)
+		assert.True(t, x1.IsInactive(time.Now(), 30*24*time.Hour))
+		x2, err := st.GetCorporationMember(ctx, storage.CorporationMemberParams{
+			CorporationID: c.ID,
+			CharacterID:   m2.ID,
+		})
+		require.NoError(t, err)
+		assert.False(t, x2.HasTracking())
+	})
+}
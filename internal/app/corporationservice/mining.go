@@ -0,0 +1,122 @@
+package corporationservice
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/fnt-eve/goesi-openapi/esi"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
+)
+
+// ListAllMiningObserverEntries returns the mining observer entries of all corporations.
+func (s *CorporationService) ListAllMiningObserverEntries(ctx context.Context) ([]*app.CorporationMiningObserverEntry, error) {
+	return s.st.ListAllCorporationMiningObserverEntries(ctx)
+}
+
+// ListMiningObserverEntries returns the mining observer entries of a corporation.
+func (s *CorporationService) ListMiningObserverEntries(ctx context.Context, corporationID int64) ([]*app.CorporationMiningObserverEntry, error) {
+	return s.st.ListCorporationMiningObserverEntries(ctx, corporationID)
+}
+
+// ListMiningObservers returns the mining observers of a corporation.
+func (s *CorporationService) ListMiningObservers(ctx context.Context, corporationID int64) ([]*app.CorporationMiningObserver, error) {
+	return s.st.ListCorporationMiningObservers(ctx, corporationID)
+}
+
+// updateMiningObserversESI updates the mining observers of a corporation and their entries from ESI.
+// Entries are only fetched when the list of observers has changed,
+// because an observer's last updated date changes whenever there is new mining.
+func (s *CorporationService) updateMiningObserversESI(ctx context.Context, arg corporationSectionUpdateParams) (bool, error) {
+	if arg.section != app.SectionCorporationMiningObservers {
+		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
+	}
+	return s.updateSectionIfChanged(
+		ctx, arg, false,
+		func(ctx context.Context, arg corporationSectionUpdateParams) (any, error) {
+			ctx = xgoesi.NewContextWithOperationID(ctx, "GetCorporationCorporationIdMiningObservers")
+			observers, err := xgoesi.FetchPages(
+				func(page int32) ([]esi.CorporationCorporationIdMiningObserversGetInner, *http.Response, error) {
+					return s.esiClient.IndustryAPI.GetCorporationCorporationIdMiningObservers(ctx, arg.corporationID).Page(page).Execute()
+				},
+			)
+			if err != nil {
+				return false, err
+			}
+			slices.SortFunc(observers, func(a, b esi.CorporationCorporationIdMiningObserversGetInner) int {
+				return cmp.Compare(a.ObserverId, b.ObserverId)
+			})
+			slog.Debug("Received mining observers from ESI", "corporationID", arg.corporationID, "count", len(observers))
+			return observers, nil
+		},
+		func(ctx context.Context, arg corporationSectionUpdateParams, data any) (bool, error) {
+			observers := data.([]esi.CorporationCorporationIdMiningObserversGetInner)
+			for _, o := range observers {
+				lastUpdated, err := time.Parse(time.DateOnly, o.LastUpdated)
+				if err != nil {
+					return false, err
+				}
+				err = s.st.UpdateOrCreateCorporationMiningObserver(ctx, storage.UpdateOrCreateCorporationMiningObserverParams{
+					CorporationID: arg.corporationID,
+					LastUpdated:   lastUpdated,
+					ObserverID:    o.ObserverId,
+					ObserverType:  o.ObserverType,
+				})
+				if err != nil {
+					return false, err
+				}
+			}
+			ctx = xgoesi.NewContextWithOperationID(ctx, "GetCorporationCorporationIdMiningObserversObserverId")
+			var count int
+			for _, o := range observers {
+				rows, err := xgoesi.FetchPages(
+					func(page int32) ([]esi.CorporationCorporationIdMiningObserversObserverIdGetInner, *http.Response, error) {
+						return s.esiClient.IndustryAPI.GetCorporationCorporationIdMiningObserversObserverId(ctx, arg.corporationID, o.ObserverId).Page(page).Execute()
+					},
+				)
+				if err != nil {
+					return false, err
+				}
+				var entityIDs, typeIDs set.Set[int64]
+				for _, r := range rows {
+					entityIDs.Add(r.CharacterId, r.RecordedCorporationId)
+					typeIDs.Add(r.TypeId)
+				}
+				if _, err := s.eus.AddMissingEntities(ctx, entityIDs); err != nil {
+					return false, err
+				}
+				if err := s.eus.AddMissingTypes(ctx, typeIDs); err != nil {
+					return false, err
+				}
+				for _, r := range rows {
+					lastUpdated, err := time.Parse(time.DateOnly, r.LastUpdated)
+					if err != nil {
+						return false, err
+					}
+					err = s.st.UpdateOrCreateCorporationMiningObserverEntry(ctx, storage.UpdateOrCreateCorporationMiningObserverEntryParams{
+						CharacterID:           r.CharacterId,
+						CorporationID:         arg.corporationID,
+						LastUpdated:           lastUpdated,
+						ObserverID:            o.ObserverId,
+						Quantity:              r.Quantity,
+						RecordedCorporationID: r.RecordedCorporationId,
+						TypeID:                r.TypeId,
+					})
+					if err != nil {
+						return false, err
+					}
+				}
+				count += len(rows)
+			}
+			slog.Info("Stored updated mining observers", "corporationID", arg.corporationID, "observers", len(observers), "entries", count)
+			return true, nil
+		})
+}
@@ -0,0 +1,80 @@
+package corporationservice
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestUpdateMiningObserversESI(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	ctx := context.Background()
+	t.Run("should store observers and their entries from ESI", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		s := NewFake(Params{Storage: st, CharacterService: &CharacterServiceFake{Token: &app.CharacterToken{
+			AccessToken: "accessToken",
+		}}})
+		c := factory.CreateCorporation()
+		character := factory.CreateEveEntityCharacter()
+		corporation := factory.CreateEveEntityCorporation()
+		et := factory.CreateEveType()
+		const observerID = 1_000_000_000_001
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/corporation/%d/mining/observers?page=1", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"last_updated":  "2025-10-02",
+				"observer_id":   observerID,
+				"observer_type": "structure",
+			}}),
+		)
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/corporation/%d/mining/observers/%d?page=1", c.ID, observerID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"character_id":            character.ID,
+				"last_updated":            "2025-10-02",
+				"quantity":                500,
+				"recorded_corporation_id": corporation.ID,
+				"type_id":                 et.ID,
+			}}),
+		)
+		// when
+		changed, err := s.updateMiningObserversESI(ctx, corporationSectionUpdateParams{
+			corporationID: c.ID,
+			section:       app.SectionCorporationMiningObservers,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		observers, err := s.ListMiningObservers(ctx, c.ID)
+		require.NoError(t, err)
+		require.Len(t, observers, 1)
+		xassert.Equal(t, observerID, observers[0].ObserverID)
+		xassert.Equal(t, "structure", observers[0].ObserverType)
+		entries, err := s.ListMiningObserverEntries(ctx, c.ID)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		o := entries[0]
+		xassert.Equal(t, character.ID, o.Character.ID)
+		xassert.Equal(t, time.Date(2025, 10, 2, 0, 0, 0, 0, time.UTC), o.LastUpdated.UTC())
+		xassert.Equal(t, observerID, o.ObserverID)
+		xassert.Equal(t, 500, o.Quantity)
+		xassert.Equal(t, corporation.ID, o.RecordedCorporationID)
+		xassert.Equal(t, et.ID, o.Type.ID)
+	})
+}
@@ -0,0 +1,48 @@
+package corporationservice
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationsink"
+)
+
+// notifyFunc returns a function for sending notifications of a type about a corporation
+// to the desktop and to the notification sink.
+func (s *CorporationService) notifyFunc(ctx context.Context, corporationID int64, typ string) func(title, content string) {
+	return func(title, content string) {
+		s.sendNotification(ctx, notificationsink.Notification{
+			Type:          typ,
+			Title:         title,
+			Content:       content,
+			Timestamp:     time.Now(),
+			CorporationID: corporationID,
+		})
+	}
+}
+
+// sendNotification sends a notification to the desktop and to the notification sink,
+// unless the notification rules hold it back.
+func (s *CorporationService) sendNotification(ctx context.Context, n notificationsink.Notification) {
+	if s.notificationRules != nil {
+		var ok bool
+		n, ok = s.notificationRules.Apply(ctx, n)
+		if !ok {
+			return
+		}
+	}
+	s.sendDesktopNotification(n.Title, n.Content)
+	s.sendToNotificationSink(ctx, n)
+}
+
+// sendToNotificationSink sends a notification to the notification sink if one is configured.
+// Errors are logged only, so that failing sinks do not disrupt notifying on the desktop.
+func (s *CorporationService) sendToNotificationSink(ctx context.Context, n notificationsink.Notification) {
+	if s.notificationSink == nil {
+		return
+	}
+	if err := s.notificationSink.Send(ctx, n); err != nil {
+		slog.Warn("Failed to send notification to sink", "type", n.Type, "title", n.Title, "error", err)
+	}
+}
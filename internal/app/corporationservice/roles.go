@@ -0,0 +1,248 @@
+package corporationservice
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/fnt-eve/goesi-openapi/esi"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
+)
+
+// ListMemberAudits returns the roles and titles of all members, which have roles or titles.
+func (s *CorporationService) ListMemberAudits(ctx context.Context, corporationID int64) ([]*app.CorporationMemberAudit, error) {
+	roles, err := s.st.ListCorporationMemberRoles(ctx, corporationID)
+	if err != nil {
+		return nil, err
+	}
+	titles, err := s.st.ListCorporationMemberTitles(ctx, corporationID)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[int64]*app.CorporationMemberAudit)
+	get := func(c *app.EveEntity) *app.CorporationMemberAudit {
+		x, ok := m[c.ID]
+		if !ok {
+			x = &app.CorporationMemberAudit{Character: c}
+			m[c.ID] = x
+		}
+		return x
+	}
+	for _, r := range roles {
+		get(r.Character).Roles.Add(app.RoleAtLocation{Location: r.Location, Role: r.Role})
+	}
+	for _, t := range titles {
+		x := get(t.Character)
+		x.Titles = append(x.Titles, t.Title)
+	}
+	audits := slices.Collect(maps.Values(m))
+	for _, x := range audits {
+		slices.SortFunc(x.Titles, func(a, b *app.CorporationTitle) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+	slices.SortFunc(audits, func(a, b *app.CorporationMemberAudit) int {
+		return strings.Compare(a.Character.Name, b.Character.Name)
+	})
+	return audits, nil
+}
+
+// ListRoleChanges returns the recorded role changes of a corporation, starting with the latest.
+func (s *CorporationService) ListRoleChanges(ctx context.Context, corporationID int64) ([]*app.CorporationRoleChange, error) {
+	return s.st.ListCorporationRoleChanges(ctx, corporationID)
+}
+
+func (s *CorporationService) updateMemberRolesESI(ctx context.Context, arg corporationSectionUpdateParams) (bool, error) {
+	if arg.section != app.SectionCorporationMemberRoles {
+		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
+	}
+	return s.updateSectionIfChanged(
+		ctx, arg, false,
+		func(ctx context.Context, arg corporationSectionUpdateParams) (any, error) {
+			ctx = xgoesi.NewContextWithOperationID(ctx, "GetCorporationsCorporationIdRoles")
+			roles, _, err := s.esiClient.CorporationAPI.GetCorporationsCorporationIdRoles(ctx, arg.corporationID).Execute()
+			if err != nil {
+				return false, err
+			}
+			return roles, nil
+		},
+		func(ctx context.Context, arg corporationSectionUpdateParams, data any) (bool, error) {
+			members := data.([]esi.CorporationsCorporationIdRolesGetInner)
+			incoming := make(map[int64]set.Set[app.RoleAtLocation])
+			var entityIDs set.Set[int64]
+			for _, m := range members {
+				var roles set.Set[app.RoleAtLocation]
+				for location, names := range map[app.RoleLocation][]string{
+					app.RoleLocationBase:      m.RolesAtBase,
+					app.RoleLocationGeneral:   m.Roles,
+					app.RoleLocationGrantable: m.GrantableRoles,
+					app.RoleLocationHQ:        m.RolesAtHq,
+					app.RoleLocationOther:     m.RolesAtOther,
+				} {
+					for _, n := range names {
+						r, ok := app.RoleFromESIName(n)
+						if !ok {
+							slog.Warn("received unknown role from ESI", "corporationID", arg.corporationID, "role", n)
+							continue
+						}
+						roles.Add(app.RoleAtLocation{Location: location, Role: r})
+					}
+				}
+				if roles.Size() == 0 {
+					continue
+				}
+				incoming[m.CharacterId] = roles
+				entityIDs.Add(m.CharacterId)
+			}
+			if _, err := s.eus.AddMissingEntities(ctx, entityIDs); err != nil {
+				return false, err
+			}
+			oo, err := s.st.ListCorporationMemberRoles(ctx, arg.corporationID)
+			if err != nil {
+				return false, err
+			}
+			current := make(map[int64]set.Set[app.RoleAtLocation])
+			for _, o := range oo {
+				x := current[o.Character.ID]
+				x.Add(app.RoleAtLocation{Location: o.Location, Role: o.Role})
+				current[o.Character.ID] = x
+			}
+			// Changes can only be recorded when a previous update has completed.
+			// Otherwise the first update would report every role as granted.
+			// The section status still reflects the previous update at this point.
+			status, err := s.st.GetCorporationSectionStatus(ctx, arg.corporationID, arg.section)
+			if err != nil {
+				return false, err
+			}
+			var changes []storage.CreateCorporationRoleChangeParams
+			if !status.IsMissing() && status.HasContent() {
+				changes = calcRoleChanges(arg.corporationID, current, incoming, time.Now())
+			}
+			var roles []storage.CorporationMemberRoleParams
+			for characterID, rr := range incoming {
+				for r := range rr.All() {
+					roles = append(roles, storage.CorporationMemberRoleParams{
+						CharacterID:   characterID,
+						CorporationID: arg.corporationID,
+						Location:      r.Location,
+						Role:          r.Role,
+					})
+				}
+			}
+			if err := s.st.ReplaceCorporationMemberRoles(ctx, arg.corporationID, roles, changes); err != nil {
+				return false, err
+			}
+			slog.Info(
+				"Updated corporation member roles",
+				"corporationID", arg.corporationID,
+				"members", len(incoming),
+				"changes", len(changes),
+			)
+			return true, nil
+		})
+}
+
+// calcRoleChanges returns the roles granted and revoked between the current and incoming roles of members.
+func calcRoleChanges(corporationID int64, current, incoming map[int64]set.Set[app.RoleAtLocation], now time.Time) []storage.CreateCorporationRoleChangeParams {
+	var changes []storage.CreateCorporationRoleChangeParams
+	add := func(characterID int64, roles set.Set[app.RoleAtLocation], isGranted bool) {
+		for r := range roles.All() {
+			changes = append(changes, storage.CreateCorporationRoleChangeParams{
+				ChangedAt:     now,
+				CharacterID:   characterID,
+				CorporationID: corporationID,
+				IsGranted:     isGranted,
+				Location:      r.Location,
+				Role:          r.Role,
+			})
+		}
+	}
+	characterIDs := set.Union(set.Collect(maps.Keys(current)), set.Collect(maps.Keys(incoming)))
+	for id := range characterIDs.All() {
+		add(id, set.Difference(incoming[id], current[id]), true)
+		add(id, set.Difference(current[id], incoming[id]), false)
+	}
+	return changes
+}
+
+type corporationTitlesData struct {
+	MemberTitles []esi.CorporationsCorporationIdMembersTitlesGetInner
+	Titles       []esi.CorporationsCorporationIdTitlesGetInner
+}
+
+func (s *CorporationService) updateTitlesESI(ctx context.Context, arg corporationSectionUpdateParams) (bool, error) {
+	if arg.section != app.SectionCorporationTitles {
+		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
+	}
+	return s.updateSectionIfChanged(
+		ctx, arg, false,
+		func(ctx context.Context, arg corporationSectionUpdateParams) (any, error) {
+			var data corporationTitlesData
+			ctx2 := xgoesi.NewContextWithOperationID(ctx, "GetCorporationsCorporationIdTitles")
+			titles, _, err := s.esiClient.CorporationAPI.GetCorporationsCorporationIdTitles(ctx2, arg.corporationID).Execute()
+			if err != nil {
+				return false, err
+			}
+			data.Titles = titles
+			ctx2 = xgoesi.NewContextWithOperationID(ctx, "GetCorporationsCorporationIdMembersTitles")
+			memberTitles, _, err := s.esiClient.CorporationAPI.GetCorporationsCorporationIdMembersTitles(ctx2, arg.corporationID).Execute()
+			if err != nil {
+				return false, err
+			}
+			data.MemberTitles = memberTitles
+			return data, nil
+		},
+		func(ctx context.Context, arg corporationSectionUpdateParams, data any) (bool, error) {
+			d := data.(corporationTitlesData)
+			var titles []storage.CorporationTitleParams
+			var titleIDs set.Set[int64]
+			for _, t := range d.Titles {
+				if t.TitleId == nil {
+					continue
+				}
+				titles = append(titles, storage.CorporationTitleParams{
+					CorporationID: arg.corporationID,
+					Name:          strings.TrimSpace(optional.FromPtr(t.Name).ValueOrZero()),
+					TitleID:       *t.TitleId,
+				})
+				titleIDs.Add(*t.TitleId)
+			}
+			var memberTitles []storage.CorporationMemberTitleParams
+			var entityIDs set.Set[int64]
+			for _, m := range d.MemberTitles {
+				for _, id := range m.Titles {
+					if !titleIDs.Contains(id) {
+						continue
+					}
+					memberTitles = append(memberTitles, storage.CorporationMemberTitleParams{
+						CharacterID:   m.CharacterId,
+						CorporationID: arg.corporationID,
+						TitleID:       id,
+					})
+					entityIDs.Add(m.CharacterId)
+				}
+			}
+			if _, err := s.eus.AddMissingEntities(ctx, entityIDs); err != nil {
+				return false, err
+			}
+			if err := s.st.ReplaceCorporationTitles(ctx, arg.corporationID, titles, memberTitles); err != nil {
+				return false, err
+			}
+			slog.Info(
+				"Updated corporation titles",
+				"corporationID", arg.corporationID,
+				"titles", len(titles),
+				"memberTitles", len(memberTitles),
+			)
+			return true, nil
+		})
+}
@@ -0,0 +1,237 @@
+package corporationservice
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestUpdateCorporationMemberRolesESI(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	ctx := context.Background()
+	t.Run("should store roles without changes on first update", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		s := NewFake(Params{Storage: st, CharacterService: &CharacterServiceFake{Token: &app.CharacterToken{AccessToken: "accessToken"}}})
+		c := factory.CreateCorporation()
+		m1 := factory.CreateEveEntityCharacter()
+		m2 := factory.CreateEveEntityCharacter()
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/corporations/%d/roles", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"character_id": m1.ID,
+				"roles":        []string{"Director"},
+			}, {
+				"character_id":    m2.ID,
+				"roles":           []string{"Accountant", "Station_Manager"},
+				"roles_at_hq":     []string{"Station_Manager"},
+				"grantable_roles": []string{"Accountant"},
+			}}),
+		)
+		// when
+		changed, err := s.updateMemberRolesESI(ctx, corporationSectionUpdateParams{
+			corporationID: c.ID,
+			section:       app.SectionCorporationMemberRoles,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		audits, err := s.ListMemberAudits(ctx, c.ID)
+		require.NoError(t, err)
+		got := make(map[int64]set.Set[app.RoleAtLocation])
+		for _, a := range audits {
+			got[a.Character.ID] = a.Roles
+		}
+		want := map[int64]set.Set[app.RoleAtLocation]{
+			m1.ID: set.Of(
+				app.RoleAtLocation{Location: app.RoleLocationGeneral, Role: app.RoleDirector},
+			),
+			m2.ID: set.Of(
+				app.RoleAtLocation{Location: app.RoleLocationGeneral, Role: app.RoleAccountant},
+				app.RoleAtLocation{Location: app.RoleLocationGeneral, Role: app.RoleStationManager},
+				app.RoleAtLocation{Location: app.RoleLocationHQ, Role: app.RoleStationManager},
+				app.RoleAtLocation{Location: app.RoleLocationGrantable, Role: app.RoleAccountant},
+			),
+		}
+		xassert.Equal(t, want, got)
+		changes, err := s.ListRoleChanges(ctx, c.ID)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+	t.Run("should record role changes between updates", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		s := NewFake(Params{Storage: st, CharacterService: &CharacterServiceFake{Token: &app.CharacterToken{AccessToken: "accessToken"}}})
+		c := factory.CreateCorporation()
+		m1 := factory.CreateEveEntityCharacter()
+		m2 := factory.CreateEveEntityCharacter()
+		factory.CreateCorporationSectionStatus(testutil.CorporationSectionStatusParams{
+			CorporationID: c.ID,
+			Section:       app.SectionCorporationMemberRoles,
+		})
+		err := st.ReplaceCorporationMemberRoles(ctx, c.ID, []storage.CorporationMemberRoleParams{
+			{CharacterID: m1.ID, CorporationID: c.ID, Location: app.RoleLocationGeneral, Role: app.RoleDirector},
+			{CharacterID: m2.ID, CorporationID: c.ID, Location: app.RoleLocationGeneral, Role: app.RoleAccountant},
+			{CharacterID: m2.ID, CorporationID: c.ID, Location: app.RoleLocationHQ, Role: app.RoleStationManager},
+		}, nil)
+		require.NoError(t, err)
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/corporations/%d/roles", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"character_id": m1.ID,
+				"roles":        []string{"Director"},
+			}, {
+				"character_id": m2.ID,
+				"roles":        []string{"Station_Manager"},
+				"roles_at_hq":  []string{"Station_Manager"},
+			}}),
+		)
+		// when
+		changed, err := s.updateMemberRolesESI(ctx, corporationSectionUpdateParams{
+			corporationID: c.ID,
+			section:       app.SectionCorporationMemberRoles,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		changes, err := s.ListRoleChanges(ctx, c.ID)
+		require.NoError(t, err)
+		var got set.Set[string]
+		for _, x := range changes {
+			assert.Equal(t, m2.ID, x.Character.ID)
+			got.Add(x.Display())
+		}
+		want := set.Of("Granted Station Manager", "Revoked Accountant")
+		xassert.Equal(t, want, got)
+	})
+	t.Run("should record roles granted after a previous update without roles", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		s := NewFake(Params{Storage: st, CharacterService: &CharacterServiceFake{Token: &app.CharacterToken{AccessToken: "accessToken"}}})
+		c := factory.CreateCorporation()
+		m1 := factory.CreateEveEntityCharacter()
+		factory.CreateCorporationSectionStatus(testutil.CorporationSectionStatusParams{
+			CorporationID: c.ID,
+			Section:       app.SectionCorporationMemberRoles,
+		})
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/corporations/%d/roles", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"character_id": m1.ID,
+				"roles":        []string{"Director"},
+			}}),
+		)
+		// when
+		changed, err := s.updateMemberRolesESI(ctx, corporationSectionUpdateParams{
+			corporationID: c.ID,
+			section:       app.SectionCorporationMemberRoles,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		changes, err := s.ListRoleChanges(ctx, c.ID)
+		require.NoError(t, err)
+		if assert.Len(t, changes, 1) {
+			assert.Equal(t, m1.ID, changes[0].Character.ID)
+			assert.Equal(t, "Granted Director", changes[0].Display())
+		}
+	})
+}
+
+func TestCalcRoleChanges(t *testing.T) {
+	now := time.Now().UTC()
+	general := func(r app.Role) app.RoleAtLocation {
+		return app.RoleAtLocation{Location: app.RoleLocationGeneral, Role: r}
+	}
+	hq := func(r app.Role) app.RoleAtLocation {
+		return app.RoleAtLocation{Location: app.RoleLocationHQ, Role: r}
+	}
+	current := map[int64]set.Set[app.RoleAtLocation]{
+		1: set.Of(general(app.RoleDirector)),
+		2: set.Of(general(app.RoleAccountant), hq(app.RoleTrader)),
+	}
+	incoming := map[int64]set.Set[app.RoleAtLocation]{
+		2: set.Of(general(app.RoleAccountant), general(app.RoleTrader)),
+		3: set.Of(hq(app.RoleStationManager)),
+	}
+	got := calcRoleChanges(42, current, incoming, now)
+	want := []storage.CreateCorporationRoleChangeParams{
+		{ChangedAt: now, CharacterID: 1, CorporationID: 42, IsGranted: false, Location: app.RoleLocationGeneral, Role: app.RoleDirector},
+		{ChangedAt: now, CharacterID: 2, CorporationID: 42, IsGranted: true, Location: app.RoleLocationGeneral, Role: app.RoleTrader},
+		{ChangedAt: now, CharacterID: 2, CorporationID: 42, IsGranted: false, Location: app.RoleLocationHQ, Role: app.RoleTrader},
+		{ChangedAt: now, CharacterID: 3, CorporationID: 42, IsGranted: true, Location: app.RoleLocationHQ, Role: app.RoleStationManager},
+	}
+	assert.ElementsMatch(t, want, got)
+}
+
+func TestUpdateCorporationTitlesESI(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	ctx := context.Background()
+	t.Run("should store titles and member titles", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		httpmock.Reset()
+		s := NewFake(Params{Storage: st, CharacterService: &CharacterServiceFake{Token: &app.CharacterToken{AccessToken: "accessToken"}}})
+		c := factory.CreateCorporation()
+		m1 := factory.CreateEveEntityCharacter()
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/corporations/%d/titles", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"name":     "Alpha",
+				"title_id": 1,
+			}, {
+				"name":     "Bravo",
+				"title_id": 2,
+			}}),
+		)
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://esi.evetech.net/corporations/%d/members/titles", c.ID),
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{{
+				"character_id": m1.ID,
+				"titles":       []int64{2},
+			}}),
+		)
+		// when
+		changed, err := s.updateTitlesESI(ctx, corporationSectionUpdateParams{
+			corporationID: c.ID,
+			section:       app.SectionCorporationTitles,
+		})
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		titles, err := st.ListCorporationTitles(ctx, c.ID)
+		require.NoError(t, err)
+		assert.Len(t, titles, 2)
+		audits, err := s.ListMemberAudits(ctx, c.ID)
+		require.NoError(t, err)
+		require.Len(t, audits, 1)
+		assert.Equal(t, m1.ID, audits[0].Character.ID)
+		require.Len(t, audits[0].Titles, 1)
+		assert.Equal(t, "Bravo", audits[0].Titles[0].Name)
+	})
+}
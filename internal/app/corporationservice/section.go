@@ -14,6 +14,7 @@ import (
 	"github.com/ErikKalkoken/go-set"
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationsink"
 	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
 	"github.com/ErikKalkoken/evebuddy/internal/optional"
 	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
@@ -25,9 +26,13 @@ func (s *CorporationService) StartUpdateTickerCorporations(d time.Duration) {
 	go func() {
 		for {
 			go func() {
-				if err := s.UpdateCorporationsIfNeeded(context.Background(), false); err != nil {
+				ctx := context.Background()
+				if err := s.UpdateCorporationsIfNeeded(ctx, false); err != nil {
 					slog.Error("Failed to update corporations", "error", err)
 				}
+				if err := s.RecordWalletSnapshots(ctx); err != nil {
+					slog.Error("Failed to record corporation wallet snapshots", "error", err)
+				}
 			}()
 			<-time.Tick(d)
 		}
@@ -99,6 +104,37 @@ func (s *CorporationService) UpdateSectionAndRefreshIfNeeded(ctx context.Context
 		slog.Error("Failed to update corporation section", "corporationID", corporationID, "section", section, "err", err)
 		return
 	}
+
+	logErr := func(err error) {
+		slog.Error("Failed to process update for corporation section", "corporationID", corporationID, "section", section, "err", err)
+	}
+	switch section {
+	case app.SectionCorporationIndustryJobs:
+		if s.settings.NotifyIndustryJobsEnabled() {
+			earliest := s.settings.NotifyIndustryJobsEarliest()
+			if err := s.NotifyReadyIndustryJobs(ctx, corporationID, earliest, s.notifyFunc(ctx, corporationID, notificationsink.TypeIndustryJobReady)); err != nil {
+				logErr(err)
+			}
+		}
+	case app.SectionCorporationStructures:
+		if s.settings.NotifyStructuresEnabled() {
+			thresholds := xslices.Map(s.settings.NotifyStructureFuelHours(), func(x int) time.Duration {
+				return time.Duration(x) * time.Hour
+			})
+			if err := s.NotifyStructureFuel(ctx, corporationID, thresholds, s.notifyFunc(ctx, corporationID, notificationsink.TypeStructureFuel)); err != nil {
+				logErr(err)
+			}
+			lead := time.Duration(s.settings.NotifyStructureTimerHours()) * time.Hour
+			if err := s.NotifyStructureTimers(ctx, corporationID, lead, s.notifyFunc(ctx, corporationID, notificationsink.TypeStructureTimer)); err != nil {
+				logErr(err)
+			}
+			earliest := s.settings.NotifyStructuresEarliest()
+			if err := s.NotifyStructureServicesOffline(ctx, corporationID, earliest, s.notifyFunc(ctx, corporationID, notificationsink.TypeStructureService)); err != nil {
+				logErr(err)
+			}
+		}
+	}
+
 	needsRefresh := hasChanged || forceUpdate
 	arg := app.CorporationSectionUpdated{
 		CorporationID: corporationID,
@@ -160,6 +196,30 @@ func (s *CorporationService) RemoveSectionDataWhenPermissionLost(ctx context.Con
 				if err := s.st.DeleteCorporationIndustryJobs(ctx, corporationID); err != nil {
 					return wrapErr(err)
 				}
+			case app.SectionCorporationKillmails:
+				if err := s.st.DeleteCorporationKillmails(ctx, corporationID); err != nil {
+					return wrapErr(err)
+				}
+			case app.SectionCorporationBlueprints:
+				if err := s.st.DeleteCorporationBlueprints(ctx, corporationID); err != nil {
+					return wrapErr(err)
+				}
+			case app.SectionCorporationMiningObservers:
+				if err := s.st.DeleteCorporationMiningObservers(ctx, corporationID); err != nil {
+					return wrapErr(err)
+				}
+			case app.SectionCorporationMemberRoles:
+				if err := s.st.DeleteCorporationMemberRoles(ctx, corporationID); err != nil {
+					return wrapErr(err)
+				}
+			case app.SectionCorporationMemberTracking:
+				if err := s.st.ResetCorporationMemberTracking(ctx, corporationID); err != nil {
+					return wrapErr(err)
+				}
+			case app.SectionCorporationTitles:
+				if err := s.st.DeleteCorporationTitles(ctx, corporationID); err != nil {
+					return wrapErr(err)
+				}
 			default:
 				continue
 			}
@@ -259,16 +319,28 @@ func (s *CorporationService) updateSectionIfNeeded(ctx context.Context, arg corp
 	switch arg.section {
 	case app.SectionCorporationAssets:
 		f = s.updateAssetsESI
+	case app.SectionCorporationBlueprints:
+		f = s.updateBlueprintsESI
 	case app.SectionCorporationContracts:
 		f = s.updateContractsESI
 	case app.SectionCorporationDivisions:
 		f = s.updateDivisionsESI
 	case app.SectionCorporationIndustryJobs:
 		f = s.updateIndustryJobsESI
+	case app.SectionCorporationKillmails:
+		f = s.updateKillmailsESI
 	case app.SectionCorporationMembers:
 		f = s.updateMembersESI
+	case app.SectionCorporationMemberRoles:
+		f = s.updateMemberRolesESI
+	case app.SectionCorporationMemberTracking:
+		f = s.updateMemberTrackingESI
+	case app.SectionCorporationMiningObservers:
+		f = s.updateMiningObserversESI
 	case app.SectionCorporationStructures:
 		f = s.updateStructuresESI
+	case app.SectionCorporationTitles:
+		f = s.updateTitlesESI
 	case app.SectionCorporationWalletBalances:
 		f = s.updateWalletBalancesESI
 	case
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/ErikKalkoken/go-set"
 	"github.com/fnt-eve/goesi-openapi/esi"
@@ -11,6 +14,7 @@ import (
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
 	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	ihumanize "github.com/ErikKalkoken/evebuddy/internal/humanize"
 	"github.com/ErikKalkoken/evebuddy/internal/optional"
 	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
 	"github.com/ErikKalkoken/evebuddy/internal/xiter"
@@ -25,6 +29,205 @@ func (s *CorporationService) ListStructures(ctx context.Context, corporationID i
 	return s.st.ListCorporationStructures(ctx, corporationID)
 }
 
+const (
+	cacheKeyStructureFuelNotified     = "structure-fuel-notified"
+	cacheKeyStructureServiceOnline    = "structure-service-online"
+	cacheKeyStructureTimerNotified    = "structure-timer-notified"
+	cacheKeyStructureUnanchorNotified = "structure-unanchor-notified"
+)
+
+// structureNotifyTimeout is how long a notified event is remembered after it has passed
+// or after it was last seen.
+const structureNotifyTimeout = 7 * 24 * time.Hour
+
+// NotifyStructureFuel sends a notification when the remaining fuel of structures
+// falls below one of the given thresholds.
+// Each threshold is notified once only for the same fuel expiry
+// and notifying starts over when a structure is refueled.
+func (s *CorporationService) NotifyStructureFuel(ctx context.Context, corporationID int64, thresholds []time.Duration, notify func(title, content string)) error {
+	_, err, _ := s.sfg.Do(fmt.Sprintf("NotifyStructureFuel-%d", corporationID), func() (any, error) {
+		if len(thresholds) == 0 {
+			return nil, nil
+		}
+		structures, err := s.st.ListCorporationStructures(ctx, corporationID)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		var lines []string
+		for _, cs := range structures {
+			fuelExpires, ok := cs.FuelExpires.Value()
+			if !ok {
+				continue
+			}
+			key := fmt.Sprintf("%s-%d-%d", cacheKeyStructureFuelNotified, cs.StructureID, fuelExpires.Unix())
+			remaining := fuelExpires.Sub(now)
+			var threshold time.Duration
+			for _, t := range thresholds {
+				if remaining <= t && (threshold == 0 || t < threshold) {
+					threshold = t
+				}
+			}
+			if threshold == 0 {
+				s.cache.Delete(key) // enough fuel
+				continue
+			}
+			if v, found := s.cache.GetInt64(key); found && time.Duration(v) <= threshold {
+				// keep remembering while the structure remains in this state
+				s.cache.SetInt64(key, v, max(remaining, 0)+structureNotifyTimeout)
+				continue
+			}
+			var line string
+			if remaining > 0 {
+				line = fmt.Sprintf("%s runs out of fuel in %s", cs.DisplayName(), ihumanize.Duration(remaining))
+			} else {
+				line = fmt.Sprintf("%s has run out of fuel", cs.DisplayName())
+			}
+			lines = append(lines, line)
+			s.cache.SetInt64(key, int64(threshold), max(remaining, 0)+structureNotifyTimeout)
+		}
+		if len(lines) == 0 {
+			return nil, nil
+		}
+		title, err := s.makeStructuresTitle(ctx, corporationID, len(lines), "running low on fuel")
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(lines)
+		notify(title, strings.Join(lines, "\n"))
+		slog.Info("Notified structures running low on fuel", "corporationID", corporationID, "count", len(lines))
+		return nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("NotifyStructureFuel for corporation %d: %w", corporationID, err)
+	}
+	return nil
+}
+
+// NotifyStructureTimers sends a notification when reinforcement or unanchoring timers
+// of structures will end within lead. Each timer is notified once only.
+func (s *CorporationService) NotifyStructureTimers(ctx context.Context, corporationID int64, lead time.Duration, notify func(title, content string)) error {
+	_, err, _ := s.sfg.Do(fmt.Sprintf("NotifyStructureTimers-%d", corporationID), func() (any, error) {
+		structures, err := s.st.ListCorporationStructures(ctx, corporationID)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		var lines []string
+		isEndingSoon := func(key string, end time.Time) bool {
+			if !end.After(now) || end.Sub(now) > lead {
+				return false
+			}
+			if v, found := s.cache.GetInt64(key); found && v == end.Unix() {
+				return false
+			}
+			s.cache.SetInt64(key, end.Unix(), end.Sub(now)+structureNotifyTimeout)
+			return true
+		}
+		for _, cs := range structures {
+			if end, ok := cs.StateTimerEnd.Value(); ok && cs.State.IsReinforce() {
+				if isEndingSoon(makeKeyStructure(cacheKeyStructureTimerNotified, cs.StructureID), end) {
+					lines = append(lines, fmt.Sprintf(
+						"%s: %s timer ends in %s",
+						cs.DisplayName(),
+						cs.State.String(),
+						ihumanize.Duration(end.Sub(now)),
+					))
+				}
+			}
+			if end, ok := cs.UnanchorsAt.Value(); ok {
+				if isEndingSoon(makeKeyStructure(cacheKeyStructureUnanchorNotified, cs.StructureID), end) {
+					lines = append(lines, fmt.Sprintf(
+						"%s unanchors in %s",
+						cs.DisplayName(),
+						ihumanize.Duration(end.Sub(now)),
+					))
+				}
+			}
+		}
+		if len(lines) == 0 {
+			return nil, nil
+		}
+		title, err := s.makeStructuresTitle(ctx, corporationID, len(lines), "with timers ending soon")
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(lines)
+		notify(title, strings.Join(lines, "\n"))
+		slog.Info("Notified structure timers", "corporationID", corporationID, "count", len(lines))
+		return nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("NotifyStructureTimers for corporation %d: %w", corporationID, err)
+	}
+	return nil
+}
+
+// NotifyStructureServicesOffline sends a notification when services of structures went offline.
+//
+// Services are only notified when they were seen online after earliest,
+// so that services which went offline before notifications were enabled are not notified.
+func (s *CorporationService) NotifyStructureServicesOffline(ctx context.Context, corporationID int64, earliest time.Time, notify func(title, content string)) error {
+	_, err, _ := s.sfg.Do(fmt.Sprintf("NotifyStructureServicesOffline-%d", corporationID), func() (any, error) {
+		structures, err := s.st.ListCorporationStructures(ctx, corporationID)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		var lines []string
+		for _, cs := range structures {
+			var offline []string
+			for _, ss := range cs.Services {
+				key := fmt.Sprintf("%s-%d-%s", cacheKeyStructureServiceOnline, cs.StructureID, ss.Name)
+				if ss.State == app.StructureServiceStateOnline {
+					s.cache.SetInt64(key, now.Unix(), structureNotifyTimeout)
+					continue
+				}
+				lastOnline, found := s.cache.GetInt64(key)
+				if !found {
+					continue
+				}
+				s.cache.Delete(key)
+				if ss.State != app.StructureServiceStateOffline || time.Unix(lastOnline, 0).Before(earliest) {
+					continue
+				}
+				offline = append(offline, ss.Name)
+			}
+			if len(offline) > 0 {
+				slices.Sort(offline)
+				lines = append(lines, fmt.Sprintf("%s: %s", cs.DisplayName(), strings.Join(offline, ", ")))
+			}
+		}
+		if len(lines) == 0 {
+			return nil, nil
+		}
+		title, err := s.makeStructuresTitle(ctx, corporationID, len(lines), "with services offline")
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(lines)
+		notify(title, strings.Join(lines, "\n"))
+		slog.Info("Notified structure services offline", "corporationID", corporationID, "count", len(lines))
+		return nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("NotifyStructureServicesOffline for corporation %d: %w", corporationID, err)
+	}
+	return nil
+}
+
+func (s *CorporationService) makeStructuresTitle(ctx context.Context, corporationID int64, count int, suffix string) (string, error) {
+	c, err := s.GetCorporation(ctx, corporationID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s: %d structure(s) %s", c.NameOrZero(), count, suffix), nil
+}
+
+func makeKeyStructure(prefix string, structureID int64) string {
+	return fmt.Sprintf("%s-%d", prefix, structureID)
+}
+
 func (s *CorporationService) updateStructuresESI(ctx context.Context, arg corporationSectionUpdateParams) (bool, error) {
 	if arg.section != app.SectionCorporationStructures {
 		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
@@ -9,10 +9,12 @@ import (
 	"github.com/ErikKalkoken/go-set"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
 	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
 	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
 	"github.com/ErikKalkoken/evebuddy/internal/xassert"
 )
 
@@ -101,3 +103,207 @@ func TestUpdateCorporationStructuresESI(t *testing.T) {
 		xassert.Equal(t, app.StructureServiceStateOnline, x.Services[0].State)
 	})
 }
+
+func TestNotifyStructureFuel(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+	ctx := context.Background()
+	thresholds := []time.Duration{72 * time.Hour, 24 * time.Hour}
+	t.Run("should notify once per threshold", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		s := NewFake(Params{Storage: st})
+		arg := storage.UpdateOrCreateCorporationStructureParams{
+			FuelExpires: optional.New(time.Now().Add(48 * time.Hour)),
+		}
+		cs := factory.CreateCorporationStructure(arg)
+		var count int
+		notify := func(title, content string) {
+			count++
+		}
+		// when
+		err1 := s.NotifyStructureFuel(ctx, cs.CorporationID, thresholds, notify)
+		err2 := s.NotifyStructureFuel(ctx, cs.CorporationID, thresholds, notify)
+		// then
+		if assert.NoError(t, err1) && assert.NoError(t, err2) {
+			xassert.Equal(t, 1, count)
+		}
+		// when
+		arg.CorporationID = cs.CorporationID
+		arg.StructureID = cs.StructureID
+		arg.FuelExpires = optional.New(time.Now().Add(12 * time.Hour))
+		factory.CreateCorporationStructure(arg)
+		err := s.NotifyStructureFuel(ctx, cs.CorporationID, thresholds, notify)
+		// then
+		if assert.NoError(t, err) {
+			xassert.Equal(t, 2, count)
+		}
+	})
+	t.Run("should notify once when out of fuel", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		s := NewFake(Params{Storage: st})
+		cs := factory.CreateCorporationStructure(storage.UpdateOrCreateCorporationStructureParams{
+			FuelExpires: optional.New(time.Now().Add(-10 * 24 * time.Hour)),
+		})
+		var count int
+		notify := func(title, content string) {
+			count++
+		}
+		// when
+		for range 3 {
+			err := s.NotifyStructureFuel(ctx, cs.CorporationID, thresholds, notify)
+			require.NoError(t, err)
+		}
+		// then
+		xassert.Equal(t, 1, count)
+	})
+	t.Run("should not notify when enough fuel is left", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		s := NewFake(Params{Storage: st})
+		cs := factory.CreateCorporationStructure(storage.UpdateOrCreateCorporationStructureParams{
+			FuelExpires: optional.New(time.Now().Add(96 * time.Hour)),
+		})
+		var count int
+		// when
+		err := s.NotifyStructureFuel(ctx, cs.CorporationID, thresholds, func(title, content string) {
+			count++
+		})
+		// then
+		if assert.NoError(t, err) {
+			xassert.Equal(t, 0, count)
+		}
+	})
+	t.Run("should notify again after refueling", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		s := NewFake(Params{Storage: st})
+		arg := storage.UpdateOrCreateCorporationStructureParams{
+			FuelExpires: optional.New(time.Now().Add(12 * time.Hour)),
+		}
+		cs := factory.CreateCorporationStructure(arg)
+		arg.CorporationID = cs.CorporationID
+		arg.StructureID = cs.StructureID
+		var count int
+		notify := func(title, content string) {
+			count++
+		}
+		// when
+		err1 := s.NotifyStructureFuel(ctx, cs.CorporationID, thresholds, notify)
+		arg.FuelExpires = optional.New(time.Now().Add(30 * 24 * time.Hour))
+		factory.CreateCorporationStructure(arg)
+		err2 := s.NotifyStructureFuel(ctx, cs.CorporationID, thresholds, notify)
+		arg.FuelExpires = optional.New(time.Now().Add(12 * time.Hour))
+		factory.CreateCorporationStructure(arg)
+		err3 := s.NotifyStructureFuel(ctx, cs.CorporationID, thresholds, notify)
+		// then
+		if assert.NoError(t, err1) && assert.NoError(t, err2) && assert.NoError(t, err3) {
+			xassert.Equal(t, 2, count)
+		}
+	})
+}
+
+func TestNotifyStructureTimers(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	cases := []struct {
+		name         string
+		state        app.StructureState
+		timerEnd     optional.Optional[time.Time]
+		unanchorsAt  optional.Optional[time.Time]
+		shouldNotify bool
+	}{
+		{"reinforce timer ends soon", app.StructureStateArmorReinforce, optional.New(now.Add(30 * time.Minute)), optional.Optional[time.Time]{}, true},
+		{"reinforce timer ends later", app.StructureStateHullReinforce, optional.New(now.Add(3 * time.Hour)), optional.Optional[time.Time]{}, false},
+		{"reinforce timer has ended", app.StructureStateArmorReinforce, optional.New(now.Add(-30 * time.Minute)), optional.Optional[time.Time]{}, false},
+		{"timer of other state", app.StructureStateShieldVulnerable, optional.New(now.Add(30 * time.Minute)), optional.Optional[time.Time]{}, false},
+		{"unanchoring ends soon", app.StructureStateShieldVulnerable, optional.Optional[time.Time]{}, optional.New(now.Add(30 * time.Minute)), true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			testutil.MustTruncateTables(db)
+			s := NewFake(Params{Storage: st})
+			cs := factory.CreateCorporationStructure(storage.UpdateOrCreateCorporationStructureParams{
+				State:         tc.state,
+				StateTimerEnd: tc.timerEnd,
+				UnanchorsAt:   tc.unanchorsAt,
+			})
+			var count int
+			notify := func(title, content string) {
+				count++
+			}
+			// when
+			err1 := s.NotifyStructureTimers(ctx, cs.CorporationID, time.Hour, notify)
+			err2 := s.NotifyStructureTimers(ctx, cs.CorporationID, time.Hour, notify)
+			// then
+			if assert.NoError(t, err1) && assert.NoError(t, err2) {
+				xassert.Equal(t, tc.shouldNotify, count == 1)
+				assert.LessOrEqual(t, count, 1)
+			}
+		})
+	}
+}
+
+func TestNotifyStructureServicesOffline(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+	ctx := context.Background()
+	earliest := time.Now().Add(-time.Hour)
+	makeServices := func(state app.StructureServiceState) []storage.StructureServiceParams {
+		return []storage.StructureServiceParams{
+			{Name: "Clone Bay", State: app.StructureServiceStateOnline},
+			{Name: "Manufacturing", State: state},
+		}
+	}
+	t.Run("should notify once when service went offline", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		s := NewFake(Params{Storage: st})
+		arg := storage.UpdateOrCreateCorporationStructureParams{
+			Services: makeServices(app.StructureServiceStateOnline),
+		}
+		cs := factory.CreateCorporationStructure(arg)
+		arg.CorporationID = cs.CorporationID
+		arg.StructureID = cs.StructureID
+		var count int
+		var content string
+		notify := func(_, c string) {
+			count++
+			content = c
+		}
+		err := s.NotifyStructureServicesOffline(ctx, cs.CorporationID, earliest, notify)
+		assert.NoError(t, err)
+		arg.Services = makeServices(app.StructureServiceStateOffline)
+		factory.CreateCorporationStructure(arg)
+		// when
+		err1 := s.NotifyStructureServicesOffline(ctx, cs.CorporationID, earliest, notify)
+		err2 := s.NotifyStructureServicesOffline(ctx, cs.CorporationID, earliest, notify)
+		// then
+		if assert.NoError(t, err1) && assert.NoError(t, err2) {
+			xassert.Equal(t, 1, count)
+			assert.Contains(t, content, "Manufacturing")
+			assert.NotContains(t, content, "Clone Bay")
+		}
+	})
+	t.Run("should not notify services which were not seen online", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		s := NewFake(Params{Storage: st})
+		cs := factory.CreateCorporationStructure(storage.UpdateOrCreateCorporationStructureParams{
+			Services: makeServices(app.StructureServiceStateOffline),
+		})
+		var count int
+		// when
+		err := s.NotifyStructureServicesOffline(ctx, cs.CorporationID, earliest, func(_, _ string) {
+			count++
+		})
+		// then
+		if assert.NoError(t, err) {
+			xassert.Equal(t, 0, count)
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"maps"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/ErikKalkoken/go-set"
 	"github.com/fnt-eve/goesi-openapi/esi"
@@ -130,6 +131,47 @@ func (s *CorporationService) GetWalletBalancesTotal(ctx context.Context, corpora
 	return b, nil
 }
 
+// ListWalletSnapshots returns the wallet snapshots of a corporation since a given day.
+func (s *CorporationService) ListWalletSnapshots(ctx context.Context, corporationID int64, since time.Time) ([]*app.CorporationWalletSnapshot, error) {
+	enabled, err := s.PermittedSection(ctx, corporationID, app.SectionCorporationWalletBalances)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return []*app.CorporationWalletSnapshot{}, nil
+	}
+	return s.st.ListCorporationWalletSnapshots(ctx, corporationID, app.SnapshotDay(since))
+}
+
+// RecordWalletSnapshots records the current wallet balances of all corporations as snapshot for today.
+// Existing snapshots for today are updated.
+func (s *CorporationService) RecordWalletSnapshots(ctx context.Context) error {
+	corporations, err := s.ListCorporationIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("RecordWalletSnapshots: %w", err)
+	}
+	day := app.SnapshotDay(time.Now())
+	for corporationID := range corporations.All() {
+		balances, err := s.st.ListCorporationWalletBalances(ctx, corporationID)
+		if err != nil {
+			return fmt.Errorf("RecordWalletSnapshots: corporation %d: %w", corporationID, err)
+		}
+		for _, b := range balances {
+			err := s.st.UpdateOrCreateCorporationWalletSnapshot(ctx, storage.UpdateOrCreateCorporationWalletSnapshotParams{
+				Balance:       b.Balance,
+				CorporationID: corporationID,
+				Day:           day,
+				DivisionID:    b.DivisionID,
+			})
+			if err != nil {
+				return fmt.Errorf("RecordWalletSnapshots: corporation %d: %w", corporationID, err)
+			}
+		}
+		slog.Info("Stored corporation wallet snapshots", "corporationID", corporationID, "count", len(balances))
+	}
+	return nil
+}
+
 func (s *CorporationService) updateWalletBalancesESI(ctx context.Context, arg corporationSectionUpdateParams) (bool, error) {
 	if arg.section != app.SectionCorporationWalletBalances {
 		return false, fmt.Errorf("wrong section for update %s: %w", arg.section, app.ErrInvalid)
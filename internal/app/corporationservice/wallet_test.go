@@ -3,8 +3,10 @@ package corporationservice_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
 	"github.com/ErikKalkoken/evebuddy/internal/app/corporationservice"
@@ -88,3 +90,48 @@ func TestWalletBalancesTotal(t *testing.T) {
 		}
 	})
 }
+
+func TestRecordWalletSnapshots(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	ctx := context.Background()
+	s := testdouble.NewCorporationServiceFake(corporationservice.Params{Storage: st})
+	t.Run("should record snapshots for all divisions", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCorporation()
+		factory.CreateCorporationTokenForSection(c.ID, app.SectionCorporationWalletBalances)
+		factory.CreateCorporationWalletBalance(storage.UpdateOrCreateCorporationWalletBalanceParams{
+			CorporationID: c.ID,
+			DivisionID:    app.Division1.ID(),
+			Balance:       12,
+		})
+		factory.CreateCorporationWalletBalance(storage.UpdateOrCreateCorporationWalletBalanceParams{
+			CorporationID: c.ID,
+			DivisionID:    app.Division2.ID(),
+			Balance:       24,
+		})
+		// when
+		err := s.RecordWalletSnapshots(ctx)
+		// then
+		require.NoError(t, err)
+		today := app.SnapshotDay(time.Now())
+		got, err := s.ListWalletSnapshots(ctx, c.ID, today)
+		require.NoError(t, err)
+		want := []*app.CorporationWalletSnapshot{
+			{Balance: 12, CorporationID: c.ID, Day: today, DivisionID: app.Division1.ID()},
+			{Balance: 24, CorporationID: c.ID, Day: today, DivisionID: app.Division2.ID()},
+		}
+		xassert.Equal(t, want, got)
+	})
+	t.Run("should return empty list when section not permitted", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		x := factory.CreateCorporationWalletSnapshot()
+		// when
+		got, err := s.ListWalletSnapshots(ctx, x.CorporationID, x.Day)
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, 0, len(got))
+	})
+}
@@ -81,11 +81,14 @@ const (
 	EveTypeAstroidBelt                 = 9
 	EveTypeCaldariLogisticsStation     = 54
 	EveTypeCharacter                   = 1373
+	EveTypeConnections                 = 3359
 	EveTypeConstellation               = 4
 	EveTypeContracting                 = 25235
 	EveTypeCorporation                 = 2
 	EveTypeCorporationContracting      = 25233
+	EveTypeCriminalConnections         = 3361
 	EveTypeCustomsOffice               = 2233
+	EveTypeDiplomacy                   = 3357
 	EveTypeFaction                     = 19
 	EveTypeIHUB                        = 32458
 	EveTypeIndustry                    = 3380
@@ -199,10 +202,12 @@ type EveRequiredSkill struct {
 }
 
 type EveSkill struct {
-	Rank         optional.Optional[int]    // Rank of this skill
-	Requirements map[int]*EveRequiredSkill // the map index denotes the rank with 0 = primary, 1 = secondary, etc.
-	Skillpoints  optional.Optional[int]    // Total skillpoints needed to train this skill
-	Type         *EveType
+	PrimaryAttribute   optional.Optional[int64]  // Dogma attribute ID of the primary training attribute
+	Rank               optional.Optional[int]    // Rank of this skill
+	Requirements       map[int]*EveRequiredSkill // the map index denotes the rank with 0 = primary, 1 = secondary, etc.
+	SecondaryAttribute optional.Optional[int64]  // Dogma attribute ID of the secondary training attribute
+	Skillpoints        optional.Optional[int]    // Total skillpoints needed to train this skill
+	Type               *EveType
 }
 
 type EveTypeDogmaAttribute struct {
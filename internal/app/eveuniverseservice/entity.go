@@ -16,6 +16,7 @@ import (
 )
 
 const (
+	esiPostUniverseIDsMax   = 500
 	esiPostUniverseNamesMax = 1000
 )
 
@@ -0,0 +1,33 @@
+package eveuniverseservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
+)
+
+// ManufacturingCostIndexESI returns the current manufacturing cost index of a solar system from ESI.
+// Returns 0 for solar systems without a cost index.
+func (s *EVEUniverseService) ManufacturingCostIndexESI(ctx context.Context, solarSystemID int64) (float64, error) {
+	x, err, _ := s.sfg.Do("ManufacturingCostIndicesESI", func() (any, error) {
+		ctx = xgoesi.NewContextWithOperationID(ctx, "GetIndustrySystems")
+		rows, _, err := s.esiClient.IndustryAPI.GetIndustrySystems(ctx).Execute()
+		if err != nil {
+			return nil, err
+		}
+		indices := make(map[int64]float64)
+		for _, r := range rows {
+			for _, ci := range r.CostIndices {
+				if ci.Activity == "manufacturing" {
+					indices[r.SolarSystemId] = ci.CostIndex
+				}
+			}
+		}
+		return indices, nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("manufacturing cost index for solar system %d: %w", solarSystemID, err)
+	}
+	return x.(map[int64]float64)[solarSystemID], nil
+}
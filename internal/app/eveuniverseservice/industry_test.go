@@ -0,0 +1,44 @@
+package eveuniverseservice_test
+
+import (
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app/eveuniverseservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil/testdouble"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestManufacturingCostIndexESI(t *testing.T) {
+	db, st, _ := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	s := testdouble.NewEVEUniverseServiceFake(eveuniverseservice.Params{Storage: st})
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi.evetech.net/industry/systems`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{
+				"solar_system_id": 30000142,
+				"cost_indices": []map[string]any{
+					{"activity": "copying", "cost_index": 0.01},
+					{"activity": "manufacturing", "cost_index": 0.0789},
+				},
+			},
+		}),
+	)
+	t.Run("should return cost index for solar system", func(t *testing.T) {
+		got, err := s.ManufacturingCostIndexESI(t.Context(), 30000142)
+		require.NoError(t, err)
+		xassert.Equal(t, 0.0789, got)
+	})
+	t.Run("should return 0 for unknown solar system", func(t *testing.T) {
+		got, err := s.ManufacturingCostIndexESI(t.Context(), 30000001)
+		require.NoError(t, err)
+		xassert.Equal(t, 0.0, got)
+	})
+}
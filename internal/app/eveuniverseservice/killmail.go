@@ -0,0 +1,136 @@
+package eveuniverseservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xsingleflight"
+)
+
+// GetKillmail returns a killmail with its attackers and items.
+func (s *EVEUniverseService) GetKillmail(ctx context.Context, id int64) (*app.EveKillmail, error) {
+	return s.st.GetEveKillmail(ctx, id)
+}
+
+// GetOrCreateKillmailESI returns a killmail from storage
+// or fetches it from ESI with the given hash when it does not yet exist.
+// Killmails are immutable and therefore never updated once stored.
+func (s *EVEUniverseService) GetOrCreateKillmailESI(ctx context.Context, id int64, hash string) (*app.EveKillmail, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("GetOrCreateKillmailESI %d: %w", id, err)
+	}
+	if id == 0 || hash == "" {
+		return nil, wrapErr(app.ErrInvalid)
+	}
+	o, err, _ := xsingleflight.Do(&s.sfg, fmt.Sprintf("GetOrCreateKillmailESI-%d", id), func() (*app.EveKillmail, error) {
+		o, err := s.st.GetEveKillmail(ctx, id)
+		if err == nil {
+			return o, err
+		} else if !errors.Is(err, app.ErrNotFound) {
+			return nil, err
+		}
+		km, _, err := s.esiClient.KillmailsAPI.GetKillmailsKillmailIdKillmailHash(ctx, hash, id).Execute()
+		if err != nil {
+			return nil, err
+		}
+		entityIDs := set.Of[int64]()
+		typeIDs := set.Of(km.Victim.ShipTypeId)
+		for _, x := range []*int64{
+			km.Victim.AllianceId,
+			km.Victim.CharacterId,
+			km.Victim.CorporationId,
+			km.Victim.FactionId,
+		} {
+			if x != nil {
+				entityIDs.Add(*x)
+			}
+		}
+		var attackers []storage.CreateEveKillmailAttackerParams
+		for _, a := range km.Attackers {
+			for _, x := range []*int64{a.AllianceId, a.CharacterId, a.CorporationId, a.FactionId} {
+				if x != nil {
+					entityIDs.Add(*x)
+				}
+			}
+			for _, x := range []*int64{a.ShipTypeId, a.WeaponTypeId} {
+				if x != nil {
+					typeIDs.Add(*x)
+				}
+			}
+			attackers = append(attackers, storage.CreateEveKillmailAttackerParams{
+				AllianceID:     optional.FromPtr(a.AllianceId),
+				CharacterID:    optional.FromPtr(a.CharacterId),
+				CorporationID:  optional.FromPtr(a.CorporationId),
+				DamageDone:     a.DamageDone,
+				FactionID:      optional.FromPtr(a.FactionId),
+				IsFinalBlow:    a.FinalBlow,
+				SecurityStatus: a.SecurityStatus,
+				ShipTypeID:     optional.FromPtr(a.ShipTypeId),
+				WeaponTypeID:   optional.FromPtr(a.WeaponTypeId),
+			})
+		}
+		// Items inside containers are flattened into a single list
+		var items []storage.CreateEveKillmailItemParams
+		for _, it := range km.Victim.Items {
+			typeIDs.Add(it.ItemTypeId)
+			items = append(items, storage.CreateEveKillmailItemParams{
+				Flag:              it.Flag,
+				IsSingleton:       it.Singleton != 0,
+				QuantityDestroyed: optional.FromPtr(it.QuantityDestroyed).ValueOrZero(),
+				QuantityDropped:   optional.FromPtr(it.QuantityDropped).ValueOrZero(),
+				TypeID:            it.ItemTypeId,
+			})
+			for _, it2 := range it.Items {
+				typeIDs.Add(it2.ItemTypeId)
+				items = append(items, storage.CreateEveKillmailItemParams{
+					Flag:              it2.Flag,
+					IsSingleton:       it2.Singleton != 0,
+					QuantityDestroyed: optional.FromPtr(it2.QuantityDestroyed).ValueOrZero(),
+					QuantityDropped:   optional.FromPtr(it2.QuantityDropped).ValueOrZero(),
+					TypeID:            it2.ItemTypeId,
+				})
+			}
+		}
+		if _, err := s.GetOrCreateSolarSystemESI(ctx, km.SolarSystemId); err != nil {
+			return nil, err
+		}
+		if _, err := s.AddMissingEntities(ctx, entityIDs); err != nil {
+			return nil, err
+		}
+		if err := s.AddMissingTypes(ctx, typeIDs); err != nil {
+			return nil, err
+		}
+		err = s.st.CreateEveKillmail(ctx, storage.CreateEveKillmailParams{
+			Attackers:           attackers,
+			Hash:                hash,
+			ID:                  km.KillmailId,
+			Items:               items,
+			MoonID:              optional.FromPtr(km.MoonId),
+			SolarSystemID:       km.SolarSystemId,
+			Time:                km.KillmailTime,
+			VictimAllianceID:    optional.FromPtr(km.Victim.AllianceId),
+			VictimCharacterID:   optional.FromPtr(km.Victim.CharacterId),
+			VictimCorporationID: optional.FromPtr(km.Victim.CorporationId),
+			VictimDamageTaken:   km.Victim.DamageTaken,
+			VictimFactionID:     optional.FromPtr(km.Victim.FactionId),
+			VictimShipTypeID:    km.Victim.ShipTypeId,
+			WarID:               optional.FromPtr(km.WarId),
+		})
+		if err != nil {
+			return nil, err
+		}
+		slog.Info("Created eve killmail", "ID", id)
+		return s.st.GetEveKillmail(ctx, id)
+	})
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	return o, nil
+}
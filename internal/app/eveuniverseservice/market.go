@@ -0,0 +1,124 @@
+package eveuniverseservice
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/fnt-eve/goesi-openapi/esi"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
+)
+
+// MarketHistory returns the daily market history of a type in a region ordered by date.
+//
+// The history is stored locally and fetched again from ESI when it is outdated,
+// i.e. when it does not yet include the previous day.
+// When fetching fails, outdated history is returned if there is any.
+func (s *EVEUniverseService) MarketHistory(ctx context.Context, regionID, typeID int64) ([]*app.EveMarketHistory, error) {
+	oo, err := s.st.ListEveMarketHistory(ctx, regionID, typeID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMarketHistoryOutdated(oo, s.Now()) {
+		return oo, nil
+	}
+	if err := s.UpdateMarketHistoryESI(ctx, regionID, typeID); err != nil {
+		if len(oo) == 0 {
+			return nil, err
+		}
+		slog.Warn("Failed to update market history. Using outdated history", "regionID", regionID, "typeID", typeID, "error", err)
+		return oo, nil
+	}
+	return s.st.ListEveMarketHistory(ctx, regionID, typeID)
+}
+
+// isMarketHistoryOutdated reports whether the history is missing the previous day.
+func isMarketHistoryOutdated(oo []*app.EveMarketHistory, now time.Time) bool {
+	if len(oo) == 0 {
+		return true
+	}
+	yesterday := now.UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	return oo[len(oo)-1].Date.Before(yesterday)
+}
+
+// UpdateMarketHistoryESI replaces the market history of a type in a region with the current history from ESI.
+func (s *EVEUniverseService) UpdateMarketHistoryESI(ctx context.Context, regionID, typeID int64) error {
+	_, err, _ := s.sfg.Do(fmt.Sprintf("UpdateMarketHistoryESI-%d-%d", regionID, typeID), func() (any, error) {
+		if _, err := s.GetOrCreateRegionESI(ctx, regionID); err != nil {
+			return nil, err
+		}
+		if _, err := s.GetOrCreateTypeESI(ctx, typeID); err != nil {
+			return nil, err
+		}
+		ctx = xgoesi.NewContextWithOperationID(ctx, "GetMarketsRegionIdHistory")
+		rows, _, err := s.esiClient.MarketAPI.GetMarketsRegionIdHistory(ctx, regionID).TypeId(typeID).Execute()
+		if err != nil {
+			return nil, err
+		}
+		items := make([]app.EveMarketHistory, 0, len(rows))
+		for _, r := range rows {
+			date, err := time.Parse(time.DateOnly, r.Date)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, app.EveMarketHistory{
+				Average:    r.Average,
+				Date:       date,
+				Highest:    r.Highest,
+				Lowest:     r.Lowest,
+				OrderCount: r.OrderCount,
+				RegionID:   regionID,
+				TypeID:     typeID,
+				Volume:     r.Volume,
+			})
+		}
+		if err := s.st.ReplaceEveMarketHistory(ctx, regionID, typeID, items); err != nil {
+			return nil, err
+		}
+		slog.Info("Updated market history", "regionID", regionID, "typeID", typeID, "days", len(items))
+		return nil, nil
+	})
+	return err
+}
+
+// MarketOrdersESI returns the current buy and sell orders of a type in a region from ESI.
+// Responses are cached by ESI for a few minutes, so repeated calls are cheap.
+func (s *EVEUniverseService) MarketOrdersESI(ctx context.Context, regionID, typeID int64) ([]*app.EveMarketOrder, error) {
+	x, err, _ := s.sfg.Do(fmt.Sprintf("MarketOrdersESI-%d-%d", regionID, typeID), func() (any, error) {
+		ctx = xgoesi.NewContextWithOperationID(ctx, "GetMarketsRegionIdOrders")
+		rows, err := xgoesi.FetchPages(
+			func(page int32) ([]esi.MarketsRegionIdOrdersGetInner, *http.Response, error) {
+				return s.esiClient.MarketAPI.GetMarketsRegionIdOrders(ctx, regionID).OrderType("all").TypeId(typeID).Page(page).Execute()
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		oo := make([]*app.EveMarketOrder, 0, len(rows))
+		for _, r := range rows {
+			oo = append(oo, &app.EveMarketOrder{
+				Duration:     r.Duration,
+				IsBuyOrder:   r.IsBuyOrder,
+				Issued:       r.Issued,
+				LocationID:   r.LocationId,
+				MinVolume:    r.MinVolume,
+				OrderID:      r.OrderId,
+				Price:        r.Price,
+				Range:        r.Range,
+				SystemID:     r.SystemId,
+				TypeID:       r.TypeId,
+				VolumeRemain: r.VolumeRemain,
+				VolumeTotal:  r.VolumeTotal,
+			})
+		}
+		return oo, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("market orders for region %d and type %d: %w", regionID, typeID, err)
+	}
+	return x.([]*app.EveMarketOrder), nil
+}
@@ -0,0 +1,150 @@
+package eveuniverseservice_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/eveuniverseservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil/testdouble"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestMarketHistory(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	s := testdouble.NewEVEUniverseServiceFake(eveuniverseservice.Params{Storage: st})
+	s.Now = func() time.Time {
+		return time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)
+	}
+	t.Run("should fetch history from ESI when outdated", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		region := factory.CreateEveRegion()
+		et := factory.CreateEveType()
+		httpmock.Reset()
+		httpmock.RegisterResponder(
+			"GET",
+			`=~^https://esi.evetech.net/markets/\d+/history`,
+			httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+				{
+					"average":     5.25,
+					"date":        "2026-03-01",
+					"highest":     5.27,
+					"lowest":      5.11,
+					"order_count": 2267,
+					"volume":      16276782035,
+				},
+				{
+					"average":     5.31,
+					"date":        "2026-03-02",
+					"highest":     5.4,
+					"lowest":      5.2,
+					"order_count": 2001,
+					"volume":      12345,
+				},
+			}),
+		)
+		// when
+		oo, err := s.MarketHistory(t.Context(), region.ID, et.ID)
+		// then
+		require.NoError(t, err)
+		require.Len(t, oo, 2)
+		xassert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), oo[0].Date.UTC())
+		xassert.Equal(t, 5.25, oo[0].Average)
+		xassert.Equal(t, 2267, oo[0].OrderCount)
+		xassert.Equal(t, 16276782035, oo[0].Volume)
+		xassert.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+	t.Run("should return stored history when up-to-date", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		region := factory.CreateEveRegion()
+		et := factory.CreateEveType()
+		err := st.ReplaceEveMarketHistory(t.Context(), region.ID, et.ID, []app.EveMarketHistory{{
+			Average:  1.5,
+			Date:     time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			RegionID: region.ID,
+			TypeID:   et.ID,
+		}})
+		require.NoError(t, err)
+		httpmock.Reset()
+		// when
+		oo, err := s.MarketHistory(t.Context(), region.ID, et.ID)
+		// then
+		require.NoError(t, err)
+		require.Len(t, oo, 1)
+		xassert.Equal(t, 1.5, oo[0].Average)
+		xassert.Equal(t, 0, httpmock.GetTotalCallCount())
+	})
+	t.Run("should return outdated history when fetching fails", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		region := factory.CreateEveRegion()
+		et := factory.CreateEveType()
+		err := st.ReplaceEveMarketHistory(t.Context(), region.ID, et.ID, []app.EveMarketHistory{{
+			Average:  1.5,
+			Date:     time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+			RegionID: region.ID,
+			TypeID:   et.ID,
+		}})
+		require.NoError(t, err)
+		httpmock.Reset()
+		httpmock.RegisterResponder(
+			"GET",
+			`=~^https://esi.evetech.net/markets/\d+/history`,
+			httpmock.NewStringResponder(404, `{"error": "not found"}`),
+		)
+		// when
+		oo, err := s.MarketHistory(t.Context(), region.ID, et.ID)
+		// then
+		require.NoError(t, err)
+		assert.Len(t, oo, 1)
+	})
+}
+
+func TestMarketOrdersESI(t *testing.T) {
+	db, st, _ := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	s := testdouble.NewEVEUniverseServiceFake(eveuniverseservice.Params{Storage: st})
+	httpmock.RegisterResponder(
+		"GET",
+		`=~^https://esi.evetech.net/markets/10000002/orders`,
+		httpmock.NewJsonResponderOrPanic(200, []map[string]any{
+			{
+				"duration":      90,
+				"is_buy_order":  false,
+				"issued":        "2026-03-01T10:00:00Z",
+				"location_id":   60003760,
+				"min_volume":    1,
+				"order_id":      4242,
+				"price":         5.5,
+				"range":         "region",
+				"system_id":     30000142,
+				"type_id":       34,
+				"volume_remain": 1000,
+				"volume_total":  2000,
+			},
+		}),
+	)
+	// when
+	oo, err := s.MarketOrdersESI(t.Context(), 10000002, 34)
+	// then
+	require.NoError(t, err)
+	require.Len(t, oo, 1)
+	o := oo[0]
+	xassert.Equal(t, 4242, o.OrderID)
+	xassert.Equal(t, 5.5, o.Price)
+	xassert.Equal(t, 60003760, o.LocationID)
+	xassert.Equal(t, 1000, o.VolumeRemain)
+	assert.False(t, o.IsBuyOrder)
+}
@@ -57,6 +57,12 @@ func (s *EVEUniverseService) ListSkills(ctx context.Context) ([]*app.EveSkill, e
 			Skillpoints:  sp,
 			Type:         et,
 		}
+		if x, ok := attributes[et.ID][app.EveDogmaAttributePrimaryAttribute]; ok {
+			skill.PrimaryAttribute.Set(int64(x))
+		}
+		if x, ok := attributes[et.ID][app.EveDogmaAttributeSecondaryAttribute]; ok {
+			skill.SecondaryAttribute.Set(int64(x))
+		}
 		for rank, x := range skillDogmaAttributes {
 			typeID, ok := attributes[et.ID][x.typeID]
 			if !ok {
@@ -198,6 +198,31 @@ func (s *EVEUniverseService) GetOrCreateTypeESI(ctx context.Context, id int64) (
 	return o, nil
 }
 
+// ResolveTypeNamesESI returns the IDs of types for the given names as resolved by ESI.
+// Names which can not be resolved are omitted from the result.
+// The result is keyed by the names as returned from ESI.
+func (s *EVEUniverseService) ResolveTypeNamesESI(ctx context.Context, names []string) (map[string]int64, error) {
+	ids := make(map[string]int64)
+	for chunk := range slices.Chunk(names, esiPostUniverseIDsMax) {
+		r, resp, err := s.esiClient.UniverseAPI.PostUniverseIds(ctx).RequestBody(chunk).Execute()
+		if resp != nil && resp.StatusCode == 404 {
+			continue // none of the names could be resolved
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ResolveTypeNamesESI: %w", err)
+		}
+		for _, o := range r.InventoryTypes {
+			ids[o.Name] = o.Id
+		}
+	}
+	return ids, nil
+}
+
+// ListTypesForCategory returns the published types of a category.
+func (s *EVEUniverseService) ListTypesForCategory(ctx context.Context, categoryID int64) ([]*app.EveType, error) {
+	return s.st.ListEveTypesForCategory(ctx, categoryID)
+}
+
 func (s *EVEUniverseService) ListTypeIDs(ctx context.Context) (set.Set[int64], error) {
 	return s.st.ListEveTypeIDs(ctx)
 }
@@ -444,6 +469,19 @@ func (s *EVEUniverseService) MarketPrice(ctx context.Context, typeID int64) (opt
 	return o.AveragePrice, nil
 }
 
+// ListMarketPrices returns all market prices by type ID.
+func (s *EVEUniverseService) ListMarketPrices(ctx context.Context) (map[int64]*app.EveMarketPrice, error) {
+	oo, err := s.st.ListEveMarketPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[int64]*app.EveMarketPrice)
+	for _, o := range oo {
+		m[o.TypeID] = o
+	}
+	return m, nil
+}
+
 // TODO: Change to bulk create
 
 // UpdateMarketPricesESI updates all market prices from ESI and reports which have changed.
@@ -512,6 +550,9 @@ func (s *EVEUniverseService) updateTypes(ctx context.Context) (set.Set[int64], e
 	g.Go(func() error {
 		return s.UpdateCategoryWithChildrenESI(ctx, app.EveCategoryShip)
 	})
+	g.Go(func() error {
+		return s.UpdateCategoryWithChildrenESI(ctx, app.EveCategoryBlueprint)
+	})
 	if err := g.Wait(); err != nil {
 		return set.Set[int64]{}, err
 	}
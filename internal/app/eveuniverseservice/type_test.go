@@ -282,6 +282,52 @@ func TestAddMissingEveTypes(t *testing.T) {
 	})
 }
 
+func TestResolveTypeNamesESI(t *testing.T) {
+	db, st, _ := testutil.NewDBInMemory()
+	defer db.Close()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	s := testdouble.NewEVEUniverseServiceFake(eveuniverseservice.Params{Storage: st})
+	ctx := context.Background()
+	t.Run("should return IDs of resolved types", func(t *testing.T) {
+		// given
+		httpmock.Reset()
+		httpmock.RegisterResponder(
+			"POST",
+			"https://esi.evetech.net/universe/ids",
+			httpmock.NewJsonResponderOrPanic(200, map[string]any{
+				"characters": []map[string]any{{
+					"id":   95465499,
+					"name": "Rifter",
+				}},
+				"inventory_types": []map[string]any{{
+					"id":   587,
+					"name": "Rifter",
+				}},
+			}),
+		)
+		// when
+		got, err := s.ResolveTypeNamesESI(ctx, []string{"Rifter", "Unknown"})
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, map[string]int64{"Rifter": 587}, got)
+	})
+	t.Run("should return empty result when nothing can be resolved", func(t *testing.T) {
+		// given
+		httpmock.Reset()
+		httpmock.RegisterResponder(
+			"POST",
+			"https://esi.evetech.net/universe/ids",
+			httpmock.NewJsonResponderOrPanic(404, map[string]any{"error": "Ensure all names are valid"}),
+		)
+		// when
+		got, err := s.ResolveTypeNamesESI(ctx, []string{"Unknown"})
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
 func TestGetOrCreateEveDogmaAttributeESI(t *testing.T) {
 	db, st, factory := testutil.NewDBInMemory()
 	defer db.Close()
@@ -0,0 +1,140 @@
+package export
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/tokenkey"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+// CommandName is the name of the export command on the command line.
+const CommandName = "export"
+
+// PassphraseEnvVar is the environment variable for the passphrase,
+// which unlocks the tokens of characters for updates.
+const PassphraseEnvVar = "EVEBUDDY_TOKEN_PASSPHRASE"
+
+// stringList is a flag which can be given multiple times
+// and also accepts comma separated values.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(s string) error {
+	for p := range strings.SplitSeq(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			*l = append(*l, p)
+		}
+	}
+	return nil
+}
+
+// RunCommand runs the export command with the given command line arguments,
+// e.g. "assets --character Erik --format json".
+// Exported data is written to stdout unless an output file is specified.
+func (x *Exporter) RunCommand(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	datasets := xslices.Map(Datasets(), func(d Dataset) string {
+		return string(d)
+	})
+	formats := xslices.Map(Formats(), func(f Format) string {
+		return string(f)
+	})
+	fs := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var characters stringList
+	fs.Var(&characters, "character", "Name or ID of a character to export. Can be repeated. Exports all characters when omitted")
+	format := fs.String("format", string(FormatCSV), "Output format: "+strings.Join(formats, ", "))
+	output := fs.String("output", "", "Write to this file instead of stdout")
+	update := fs.Bool("update", false, "Update characters from ESI before exporting")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: %s [flags] <dataset>\n\nDatasets: %s\n\nFlags:\n", CommandName, strings.Join(datasets, ", "))
+		fs.PrintDefaults()
+		fmt.Fprintf(stderr, "\nWhen tokens are encrypted with a passphrase, set %s for updating.\n", PassphraseEnvVar)
+	}
+
+	// the dataset is usually given before the flags
+	var dataset string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		dataset = args[0]
+		args = args[1:]
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if dataset == "" {
+		dataset = fs.Arg(0)
+	}
+	if !slices.Contains(datasets, dataset) {
+		fs.Usage()
+		return fmt.Errorf("unknown dataset %q: %w", dataset, app.ErrInvalid)
+	}
+	if !slices.Contains(formats, *format) {
+		fs.Usage()
+		return fmt.Errorf("unknown format %q: %w", *format, app.ErrInvalid)
+	}
+
+	if *update {
+		// Updates must not run concurrently with the app,
+		// which would update the same characters at the same time.
+		if x.lockInstance != nil {
+			release, err := x.lockInstance()
+			if err != nil {
+				return fmt.Errorf("can not update while EVE Buddy is running: %w", err)
+			}
+			defer release()
+		}
+		if err := x.unlockTokens(ctx); err != nil {
+			return err
+		}
+		slog.Info("Updating characters before export")
+		if err := x.cs.UpdateCharactersIfNeeded(ctx, false); err != nil {
+			return err
+		}
+	}
+	characterIDs, err := x.ResolveCharacters(ctx, characters)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return x.Write(ctx, stdout, Dataset(dataset), Format(*format), characterIDs)
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := x.Write(ctx, f, Dataset(dataset), Format(*format), characterIDs); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// unlockTokens ensures the tokens of characters can be used for updates.
+// Tokens encrypted with a passphrase are unlocked with the passphrase from [PassphraseEnvVar].
+func (x *Exporter) unlockTokens(ctx context.Context) error {
+	if x.tk == nil || x.tk.State() != tokenkey.Locked {
+		return nil
+	}
+	if x.tk.Source() != tokenkey.SourcePassphrase {
+		return fmt.Errorf("tokens are locked, because the key is not available: %w", app.ErrTokenKeyUnavailable)
+	}
+	passphrase := os.Getenv(PassphraseEnvVar)
+	if passphrase == "" {
+		return fmt.Errorf("tokens are locked: set %s to the passphrase for updating: %w", PassphraseEnvVar, app.ErrTokenKeyUnavailable)
+	}
+	if err := x.tk.Unlock(ctx, passphrase); err != nil {
+		return fmt.Errorf("unlock tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,511 @@
+// Package export implements exporting character data as CSV or JSON,
+// e.g. for feeding spreadsheets from scheduled jobs without a UI.
+package export
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/characterservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/eveuniverseservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/tokenkey"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+// Dataset represents a kind of data which can be exported.
+type Dataset string
+
+const (
+	DatasetAssets        Dataset = "assets"
+	DatasetContracts     Dataset = "contracts"
+	DatasetSkills        Dataset = "skills"
+	DatasetWalletJournal Dataset = "wallet-journal"
+)
+
+// Datasets returns all supported datasets.
+func Datasets() []Dataset {
+	return []Dataset{DatasetAssets, DatasetContracts, DatasetSkills, DatasetWalletJournal}
+}
+
+// Format represents an output format.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// Formats returns all supported formats.
+func Formats() []Format {
+	return []Format{FormatCSV, FormatJSON}
+}
+
+// table represents exported data as rows of values with named columns.
+// Values can be nil, bool, int, int64, float64, string or time.Time.
+type table struct {
+	columns []string
+	rows    [][]any
+}
+
+func (t *table) add(values ...any) {
+	if len(values) != len(t.columns) {
+		panic(fmt.Sprintf("export: row has %d values, but table has %d columns", len(values), len(t.columns)))
+	}
+	t.rows = append(t.rows, values)
+}
+
+func (t *table) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.columns); err != nil {
+		return err
+	}
+	for _, r := range t.rows {
+		record := make([]string, len(r))
+		for i, v := range r {
+			record[i] = formatCSVValue(v)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatCSVValue(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case bool:
+		return strconv.FormatBool(x)
+	case int:
+		return strconv.Itoa(x)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case time.Time:
+		return x.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprint(v)
+}
+
+// writeJSON writes the table as JSON array of objects.
+// The properties of each object are in the same order as the columns.
+func (t *table) writeJSON(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("[")
+	for i, r := range t.rows {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n  {")
+		for j, v := range r {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			if x, ok := v.(time.Time); ok {
+				v = x.UTC().Format(time.RFC3339)
+			}
+			key, err := json.Marshal(t.columns[j])
+			if err != nil {
+				return err
+			}
+			value, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			b.Write(key)
+			b.WriteString(": ")
+			b.Write(value)
+		}
+		b.WriteString("}")
+	}
+	if len(t.rows) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("]\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// TokenKey provides the key for the encrypted tokens of characters.
+// It is implemented by [tokenkey.Manager].
+type TokenKey interface {
+	Source() string
+	State() tokenkey.State
+	Unlock(ctx context.Context, passphrase string) error
+}
+
+type Params struct {
+	CharacterService   *characterservice.CharacterService
+	EveUniverseService *eveuniverseservice.EVEUniverseService
+	// optional
+	LockInstance func() (release func(), err error) // Acquires the single instance lock before updating
+	TokenKey     TokenKey
+}
+
+// Exporter exports data of characters from the local database.
+type Exporter struct {
+	cs           *characterservice.CharacterService
+	eus          *eveuniverseservice.EVEUniverseService
+	lockInstance func() (release func(), err error)
+	tk           TokenKey
+}
+
+// New returns a new Exporter.
+func New(arg Params) *Exporter {
+	if arg.CharacterService == nil || arg.EveUniverseService == nil {
+		panic("export: missing services")
+	}
+	x := &Exporter{
+		cs:           arg.CharacterService,
+		eus:          arg.EveUniverseService,
+		lockInstance: arg.LockInstance,
+		tk:           arg.TokenKey,
+	}
+	return x
+}
+
+// ResolveCharacters returns the IDs of characters matching the given names or IDs.
+// Names are matched case-insensitive.
+// When no characters are given it returns the IDs of all characters.
+func (x *Exporter) ResolveCharacters(ctx context.Context, characters []string) (set.Set[int64], error) {
+	names, err := x.cs.CharacterNames(ctx)
+	if err != nil {
+		return set.Set[int64]{}, err
+	}
+	var ids set.Set[int64]
+	if len(characters) == 0 {
+		for id := range names {
+			ids.Add(id)
+		}
+		return ids, nil
+	}
+	for _, c := range characters {
+		c = strings.TrimSpace(c)
+		var found bool
+		for id, name := range names {
+			if strings.EqualFold(name, c) || strconv.FormatInt(id, 10) == c {
+				ids.Add(id)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return set.Set[int64]{}, fmt.Errorf("character %q: %w", c, app.ErrNotFound)
+		}
+	}
+	return ids, nil
+}
+
+// Write exports a dataset for the given characters in the requested format to w.
+func (x *Exporter) Write(ctx context.Context, w io.Writer, dataset Dataset, format Format, characterIDs set.Set[int64]) error {
+	names, err := x.cs.CharacterNames(ctx)
+	if err != nil {
+		return err
+	}
+	var t *table
+	switch dataset {
+	case DatasetAssets:
+		t, err = x.makeAssets(ctx, characterIDs, names)
+	case DatasetContracts:
+		t, err = x.makeContracts(ctx, characterIDs, names)
+	case DatasetSkills:
+		t, err = x.makeSkills(ctx, characterIDs, names)
+	case DatasetWalletJournal:
+		t, err = x.makeWalletJournal(ctx, characterIDs, names)
+	default:
+		return fmt.Errorf("dataset %q: %w", dataset, app.ErrInvalid)
+	}
+	if err != nil {
+		return fmt.Errorf("export %s: %w", dataset, err)
+	}
+	switch format {
+	case FormatCSV:
+		return t.writeCSV(w)
+	case FormatJSON:
+		return t.writeJSON(w)
+	}
+	return fmt.Errorf("format %q: %w", format, app.ErrInvalid)
+}
+
+func (x *Exporter) makeAssets(ctx context.Context, characterIDs set.Set[int64], names map[int64]string) (*table, error) {
+	assets, err := x.cs.ListAllAssets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := x.eus.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locationNames := make(map[int64]string)
+	for _, l := range locations {
+		locationNames[l.ID] = l.DisplayName()
+	}
+	assets = slices.DeleteFunc(assets, func(a *app.CharacterAsset) bool {
+		return !characterIDs.Contains(a.CharacterID)
+	})
+	items := make(map[int64]*app.CharacterAsset)
+	for _, a := range assets {
+		items[a.ItemID] = a
+	}
+	// rootLocationID returns the ID of the location an item is ultimately located at.
+	rootLocationID := func(a *app.CharacterAsset) int64 {
+		id := a.LocationID
+		for range 10 { // limit depth to protect against invalid data
+			parent, ok := items[id]
+			if !ok || parent.CharacterID != a.CharacterID {
+				break
+			}
+			id = parent.LocationID
+		}
+		return id
+	}
+	slices.SortFunc(assets, func(a, b *app.CharacterAsset) int {
+		return cmp.Or(
+			cmp.Compare(names[a.CharacterID], names[b.CharacterID]),
+			cmp.Compare(a.ItemID, b.ItemID),
+		)
+	})
+	t := &table{columns: []string{
+		"character_id",
+		"character",
+		"item_id",
+		"type_id",
+		"type",
+		"group",
+		"category",
+		"name",
+		"quantity",
+		"is_singleton",
+		"location_id",
+		"location_flag",
+		"location",
+		"price",
+	}}
+	for _, a := range assets {
+		var typeID int64
+		var typeName, groupName, categoryName string
+		if a.Type != nil {
+			typeID = a.Type.ID
+			typeName = a.Type.Name
+			if a.Type.Group != nil {
+				groupName = a.Type.Group.Name
+				if a.Type.Group.Category != nil {
+					categoryName = a.Type.Group.Category.Name
+				}
+			}
+		}
+		var location any
+		if n, ok := locationNames[rootLocationID(a)]; ok {
+			location = n
+		}
+		t.add(
+			a.CharacterID,
+			names[a.CharacterID],
+			a.ItemID,
+			typeID,
+			typeName,
+			groupName,
+			categoryName,
+			a.Name,
+			a.Quantity,
+			a.IsSingleton,
+			a.LocationID,
+			a.LocationFlag.String(),
+			location,
+			optionalValue(a.Price),
+		)
+	}
+	return t, nil
+}
+
+func (x *Exporter) makeContracts(ctx context.Context, characterIDs set.Set[int64], names map[int64]string) (*table, error) {
+	contracts, err := x.cs.ListAllContracts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	contracts = slices.DeleteFunc(contracts, func(c *app.CharacterContract) bool {
+		return !characterIDs.Contains(c.CharacterID)
+	})
+	slices.SortFunc(contracts, func(a, b *app.CharacterContract) int {
+		return cmp.Or(
+			cmp.Compare(names[a.CharacterID], names[b.CharacterID]),
+			b.DateIssued.Compare(a.DateIssued),
+			cmp.Compare(a.ContractID, b.ContractID),
+		)
+	})
+	t := &table{columns: []string{
+		"character_id",
+		"character",
+		"contract_id",
+		"type",
+		"status",
+		"title",
+		"issuer",
+		"assignee",
+		"acceptor",
+		"availability",
+		"date_issued",
+		"date_expired",
+		"date_completed",
+		"price",
+		"reward",
+		"collateral",
+		"volume",
+		"start_location",
+		"end_location",
+	}}
+	for _, c := range contracts {
+		t.add(
+			c.CharacterID,
+			names[c.CharacterID],
+			c.ContractID,
+			c.Type.Display(),
+			c.Status.Display(),
+			optionalValue(c.Title),
+			entityName(optional.New(c.IssuerEffective())),
+			entityName(c.Assignee),
+			entityName(c.Acceptor),
+			c.Availability.Display(),
+			c.DateIssued,
+			c.DateExpired,
+			optionalValue(c.DateCompleted),
+			optionalValue(c.Price),
+			optionalValue(c.Reward),
+			optionalValue(c.Collateral),
+			optionalValue(c.Volume),
+			locationName(c.StartLocation),
+			locationName(c.EndLocation),
+		)
+	}
+	return t, nil
+}
+
+func (x *Exporter) makeSkills(ctx context.Context, characterIDs set.Set[int64], names map[int64]string) (*table, error) {
+	skills, err := x.cs.ListAllSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+	skills = slices.DeleteFunc(skills, func(s *app.CharacterSkill) bool {
+		return !characterIDs.Contains(s.CharacterID) || s.Type == nil
+	})
+	slices.SortFunc(skills, func(a, b *app.CharacterSkill) int {
+		return cmp.Or(
+			cmp.Compare(names[a.CharacterID], names[b.CharacterID]),
+			cmp.Compare(a.Type.Name, b.Type.Name),
+		)
+	})
+	t := &table{columns: []string{
+		"character_id",
+		"character",
+		"skill_id",
+		"skill",
+		"group",
+		"active_level",
+		"trained_level",
+		"skill_points",
+	}}
+	for _, s := range skills {
+		var group string
+		if s.Type.Group != nil {
+			group = s.Type.Group.Name
+		}
+		t.add(
+			s.CharacterID,
+			names[s.CharacterID],
+			s.Type.ID,
+			s.Type.Name,
+			group,
+			s.ActiveSkillLevel,
+			s.TrainedSkillLevel,
+			s.SkillPointsInSkill,
+		)
+	}
+	return t, nil
+}
+
+func (x *Exporter) makeWalletJournal(ctx context.Context, characterIDs set.Set[int64], names map[int64]string) (*table, error) {
+	ids := slices.SortedFunc(characterIDs.All(), func(a, b int64) int {
+		return cmp.Compare(names[a], names[b])
+	})
+	t := &table{columns: []string{
+		"character_id",
+		"character",
+		"ref_id",
+		"date",
+		"ref_type",
+		"description",
+		"amount",
+		"balance",
+		"first_party",
+		"second_party",
+		"reason",
+		"tax",
+	}}
+	for _, characterID := range ids {
+		entries, err := x.cs.ListWalletJournalEntries(ctx, characterID)
+		if err != nil {
+			return nil, err
+		}
+		slices.SortFunc(entries, func(a, b *app.CharacterWalletJournalEntry) int {
+			return cmp.Or(b.Date.Compare(a.Date), cmp.Compare(b.RefID, a.RefID))
+		})
+		for _, e := range entries {
+			t.add(
+				e.CharacterID,
+				names[e.CharacterID],
+				e.RefID,
+				e.Date,
+				e.RefTypeDisplay(),
+				e.Description,
+				optionalValue(e.Amount),
+				optionalValue(e.Balance),
+				entityName(e.FirstParty),
+				entityName(e.SecondParty),
+				optionalValue(e.Reason),
+				optionalValue(e.Tax),
+			)
+		}
+	}
+	return t, nil
+}
+
+// optionalValue returns the value of o or nil if o is empty.
+func optionalValue[T any](o optional.Optional[T]) any {
+	v, ok := o.Value()
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+func entityName(o optional.Optional[*app.EveEntity]) any {
+	v, ok := o.Value()
+	if !ok || v == nil {
+		return nil
+	}
+	return v.Name
+}
+
+func locationName(o optional.Optional[*app.EveLocationShort]) any {
+	v, ok := o.Value()
+	if !ok || v == nil {
+		return nil
+	}
+	return v.DisplayName()
+}
@@ -0,0 +1,57 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable(t *testing.T) {
+	makeTable := func() *table {
+		x := &table{columns: []string{"id", "name", "amount", "date", "note"}}
+		x.add(int64(1), "Alpha, Inc.", 1.5, time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC), nil)
+		x.add(int64(2), "Bravo", -2.0, time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC), "x")
+		return x
+	}
+	t.Run("can write CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := makeTable().writeCSV(&buf)
+		require.NoError(t, err)
+		want := "id,name,amount,date,note\n" +
+			"1,\"Alpha, Inc.\",1.5,2025-03-04T05:06:07Z,\n" +
+			"2,Bravo,-2,2025-03-05T00:00:00Z,x\n"
+		assert.Equal(t, want, buf.String())
+	})
+	t.Run("can write JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := makeTable().writeJSON(&buf)
+		require.NoError(t, err)
+		var got []map[string]any
+		err = json.Unmarshal(buf.Bytes(), &got)
+		require.NoError(t, err)
+		want := []map[string]any{
+			{"id": 1.0, "name": "Alpha, Inc.", "amount": 1.5, "date": "2025-03-04T05:06:07Z", "note": nil},
+			{"id": 2.0, "name": "Bravo", "amount": -2.0, "date": "2025-03-05T00:00:00Z", "note": "x"},
+		}
+		assert.Equal(t, want, got)
+	})
+	t.Run("should keep column order in JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		x := &table{columns: []string{"b", "a"}}
+		x.add(1, 2)
+		err := x.writeJSON(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, "[\n  {\"b\": 1, \"a\": 2}\n]\n", buf.String())
+	})
+	t.Run("can write empty JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		x := &table{columns: []string{"a"}}
+		err := x.writeJSON(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, "[]\n", buf.String())
+	})
+}
@@ -0,0 +1,333 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/characterservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/eveuniverseservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/export"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil/testdouble"
+	"github.com/ErikKalkoken/evebuddy/internal/app/tokenkey"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestExporter(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	x := export.New(export.Params{
+		CharacterService:   testdouble.NewCharacterServiceFake(characterservice.Params{Storage: st}),
+		EveUniverseService: testdouble.NewEVEUniverseServiceFake(eveuniverseservice.Params{Storage: st}),
+	})
+	ctx := context.Background()
+
+	t.Run("can export assets as CSV with root location", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		location := factory.CreateEveLocationStructure()
+		ship := factory.CreateCharacterAsset(storage.CreateCharacterAssetParams{
+			CharacterID: c.ID,
+			LocationID:  location.ID,
+			IsSingleton: true,
+		})
+		item := factory.CreateCharacterAsset(storage.CreateCharacterAssetParams{
+			CharacterID:  c.ID,
+			LocationID:   ship.ItemID,
+			LocationType: app.TypeItem,
+			LocationFlag: app.FlagCargo,
+		})
+		factory.CreateCharacterAsset() // other character
+		// when
+		var buf bytes.Buffer
+		err := x.Write(ctx, &buf, export.DatasetAssets, export.FormatCSV, set.Of(c.ID))
+		// then
+		require.NoError(t, err)
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		if assert.Len(t, records, 3) {
+			header := records[0]
+			assert.Equal(t, "character_id", header[0])
+			col := func(name string) int {
+				for i, h := range header {
+					if h == name {
+						return i
+					}
+				}
+				t.Fatalf("column not found: %s", name)
+				return -1
+			}
+			for _, r := range records[1:] {
+				assert.Equal(t, c.EveCharacter.Name, r[col("character")])
+				assert.Equal(t, location.DisplayName(), r[col("location")])
+			}
+			assert.ElementsMatch(t,
+				[]string{strconv.FormatInt(ship.ItemID, 10), strconv.FormatInt(item.ItemID, 10)},
+				[]string{records[1][col("item_id")], records[2][col("item_id")]},
+			)
+		}
+	})
+
+	t.Run("can export skills as JSON", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		sk := factory.CreateCharacterSkill(storage.UpdateOrCreateCharacterSkillParams{
+			CharacterID:       c.ID,
+			ActiveSkillLevel:  3,
+			TrainedSkillLevel: 4,
+		})
+		// when
+		var buf bytes.Buffer
+		err := x.Write(ctx, &buf, export.DatasetSkills, export.FormatJSON, set.Of(c.ID))
+		// then
+		require.NoError(t, err)
+		var got []map[string]any
+		err = json.Unmarshal(buf.Bytes(), &got)
+		require.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, sk.Type.Name, got[0]["skill"])
+			assert.EqualValues(t, 3, got[0]["active_level"])
+			assert.EqualValues(t, 4, got[0]["trained_level"])
+		}
+	})
+
+	t.Run("can export wallet journal", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		factory.CreateCharacterWalletJournalEntry(storage.CreateCharacterWalletJournalEntryParams{
+			CharacterID: c.ID,
+			Amount:      optional.New(123.5),
+		})
+		// when
+		var buf bytes.Buffer
+		err := x.Write(ctx, &buf, export.DatasetWalletJournal, export.FormatJSON, set.Of(c.ID))
+		// then
+		require.NoError(t, err)
+		var got []map[string]any
+		err = json.Unmarshal(buf.Bytes(), &got)
+		require.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, 123.5, got[0]["amount"])
+		}
+	})
+
+	t.Run("can export contracts", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		o := factory.CreateCharacterContract(storage.CreateCharacterContractParams{
+			CharacterID: c.ID,
+		})
+		// when
+		var buf bytes.Buffer
+		err := x.Write(ctx, &buf, export.DatasetContracts, export.FormatJSON, set.Of(c.ID))
+		// then
+		require.NoError(t, err)
+		var got []map[string]any
+		err = json.Unmarshal(buf.Bytes(), &got)
+		require.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.EqualValues(t, o.ContractID, got[0]["contract_id"])
+		}
+	})
+
+	t.Run("should return error for unknown dataset", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := x.Write(ctx, &buf, export.Dataset("invalid"), export.FormatCSV, set.Of[int64]())
+		assert.ErrorIs(t, err, app.ErrInvalid)
+	})
+}
+
+func TestExporter_ResolveCharacters(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	x := export.New(export.Params{
+		CharacterService:   testdouble.NewCharacterServiceFake(characterservice.Params{Storage: st}),
+		EveUniverseService: testdouble.NewEVEUniverseServiceFake(eveuniverseservice.Params{Storage: st}),
+	})
+	ctx := context.Background()
+	testutil.MustTruncateTables(db)
+	c1 := factory.CreateCharacterFull()
+	c2 := factory.CreateCharacterFull()
+
+	t.Run("should return all characters when none specified", func(t *testing.T) {
+		got, err := x.ResolveCharacters(ctx, nil)
+		require.NoError(t, err)
+		xassert.Equal(t, set.Of(c1.ID, c2.ID), got)
+	})
+	t.Run("can resolve by name and ID", func(t *testing.T) {
+		got, err := x.ResolveCharacters(ctx, []string{
+			c1.EveCharacter.Name,
+			strconv.FormatInt(c2.ID, 10),
+		})
+		require.NoError(t, err)
+		xassert.Equal(t, set.Of(c1.ID, c2.ID), got)
+	})
+	t.Run("should return error for unknown character", func(t *testing.T) {
+		_, err := x.ResolveCharacters(ctx, []string{"unknown"})
+		assert.ErrorIs(t, err, app.ErrNotFound)
+	})
+}
+
+func TestExporter_RunCommand(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	x := export.New(export.Params{
+		CharacterService:   testdouble.NewCharacterServiceFake(characterservice.Params{Storage: st}),
+		EveUniverseService: testdouble.NewEVEUniverseServiceFake(eveuniverseservice.Params{Storage: st}),
+	})
+	ctx := context.Background()
+
+	t.Run("can write export to file", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		factory.CreateCharacterSkill(storage.UpdateOrCreateCharacterSkillParams{CharacterID: c.ID})
+		p := filepath.Join(t.TempDir(), "skills.json")
+		var stdout, stderr bytes.Buffer
+		// when
+		err := x.RunCommand(ctx, []string{"skills", "--character", c.EveCharacter.Name, "--format", "json", "--output", p}, &stdout, &stderr)
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, stdout.String())
+		data, err := os.ReadFile(p)
+		require.NoError(t, err)
+		var got []map[string]any
+		err = json.Unmarshal(data, &got)
+		require.NoError(t, err)
+		assert.Len(t, got, 1)
+	})
+	t.Run("can write export to stdout with flags before dataset", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		factory.CreateCharacterSkill(storage.UpdateOrCreateCharacterSkillParams{CharacterID: c.ID})
+		var stdout, stderr bytes.Buffer
+		// when
+		err := x.RunCommand(ctx, []string{"--format", "csv", "skills"}, &stdout, &stderr)
+		// then
+		require.NoError(t, err)
+		records, err := csv.NewReader(&stdout).ReadAll()
+		require.NoError(t, err)
+		assert.Len(t, records, 2)
+	})
+	t.Run("should return error for invalid format", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		err := x.RunCommand(ctx, []string{"skills", "--format", "xml"}, &stdout, &stderr)
+		assert.ErrorIs(t, err, app.ErrInvalid)
+	})
+	t.Run("should return error for missing dataset", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		err := x.RunCommand(ctx, []string{}, &stdout, &stderr)
+		assert.ErrorIs(t, err, app.ErrInvalid)
+	})
+	t.Run("should reject update when tokens are locked and no passphrase given", func(t *testing.T) {
+		// given
+		t.Setenv(export.PassphraseEnvVar, "")
+		tk := &tokenKeyFake{source: tokenkey.SourcePassphrase, state: tokenkey.Locked}
+		x := export.New(export.Params{
+			CharacterService:   testdouble.NewCharacterServiceFake(characterservice.Params{Storage: st}),
+			EveUniverseService: testdouble.NewEVEUniverseServiceFake(eveuniverseservice.Params{Storage: st}),
+			TokenKey:           tk,
+		})
+		var stdout, stderr bytes.Buffer
+		// when
+		err := x.RunCommand(ctx, []string{"skills", "--update"}, &stdout, &stderr)
+		// then
+		assert.ErrorIs(t, err, app.ErrTokenKeyUnavailable)
+		assert.Empty(t, tk.passphrase)
+	})
+	t.Run("should unlock tokens with passphrase from environment before update", func(t *testing.T) {
+		// given
+		t.Setenv(export.PassphraseEnvVar, "my secret")
+		tk := &tokenKeyFake{source: tokenkey.SourcePassphrase, state: tokenkey.Locked, err: tokenkey.ErrWrongPassphrase}
+		x := export.New(export.Params{
+			CharacterService:   testdouble.NewCharacterServiceFake(characterservice.Params{Storage: st}),
+			EveUniverseService: testdouble.NewEVEUniverseServiceFake(eveuniverseservice.Params{Storage: st}),
+			TokenKey:           tk,
+		})
+		var stdout, stderr bytes.Buffer
+		// when
+		err := x.RunCommand(ctx, []string{"skills", "--update"}, &stdout, &stderr)
+		// then
+		assert.ErrorIs(t, err, tokenkey.ErrWrongPassphrase)
+		assert.Equal(t, "my secret", tk.passphrase)
+	})
+	t.Run("should refuse update while another instance is running", func(t *testing.T) {
+		// given
+		errRunning := errors.New("another instance running")
+		tk := &tokenKeyFake{source: tokenkey.SourcePassphrase, state: tokenkey.Locked}
+		x := export.New(export.Params{
+			CharacterService:   testdouble.NewCharacterServiceFake(characterservice.Params{Storage: st}),
+			EveUniverseService: testdouble.NewEVEUniverseServiceFake(eveuniverseservice.Params{Storage: st}),
+			LockInstance: func() (func(), error) {
+				return nil, errRunning
+			},
+			TokenKey: tk,
+		})
+		var stdout, stderr bytes.Buffer
+		// when
+		err := x.RunCommand(ctx, []string{"skills", "--update"}, &stdout, &stderr)
+		// then
+		assert.ErrorIs(t, err, errRunning)
+		assert.Equal(t, tokenkey.Locked, tk.state)
+	})
+	t.Run("should release instance lock after update", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		var isLocked, isReleased bool
+		x := export.New(export.Params{
+			CharacterService:   testdouble.NewCharacterServiceFake(characterservice.Params{Storage: st}),
+			EveUniverseService: testdouble.NewEVEUniverseServiceFake(eveuniverseservice.Params{Storage: st}),
+			LockInstance: func() (func(), error) {
+				isLocked = true
+				return func() {
+					isReleased = true
+				}, nil
+			},
+		})
+		var stdout, stderr bytes.Buffer
+		// when
+		err := x.RunCommand(ctx, []string{"skills", "--update"}, &stdout, &stderr)
+		// then
+		require.NoError(t, err)
+		assert.True(t, isLocked)
+		assert.True(t, isReleased)
+	})
+}
+
+type tokenKeyFake struct {
+	err        error
+	passphrase string
+	source     string
+	state      tokenkey.State
+}
+
+func (f *tokenKeyFake) Source() string        { return f.source }
+func (f *tokenKeyFake) State() tokenkey.State { return f.state }
+
+func (f *tokenKeyFake) Unlock(_ context.Context, passphrase string) error {
+	f.passphrase = passphrase
+	if f.err != nil {
+		return f.err
+	}
+	f.state = tokenkey.Unlocked
+	return nil
+}
@@ -0,0 +1,394 @@
+package app
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+// FittingSlot represents a kind of slot or bay in a ship fitting.
+type FittingSlot uint
+
+const (
+	FittingSlotUndefined FittingSlot = iota
+	FittingSlotLow
+	FittingSlotMedium
+	FittingSlotHigh
+	FittingSlotRig
+	FittingSlotSubsystem
+	FittingSlotService
+	FittingSlotDrone
+	FittingSlotFighter
+	FittingSlotCargo
+)
+
+var fittingSlotFlags = map[FittingSlot][]LocationFlag{
+	FittingSlotLow: {
+		FlagLoSlot0, FlagLoSlot1, FlagLoSlot2, FlagLoSlot3,
+		FlagLoSlot4, FlagLoSlot5, FlagLoSlot6, FlagLoSlot7,
+	},
+	FittingSlotMedium: {
+		FlagMedSlot0, FlagMedSlot1, FlagMedSlot2, FlagMedSlot3,
+		FlagMedSlot4, FlagMedSlot5, FlagMedSlot6, FlagMedSlot7,
+	},
+	FittingSlotHigh: {
+		FlagHiSlot0, FlagHiSlot1, FlagHiSlot2, FlagHiSlot3,
+		FlagHiSlot4, FlagHiSlot5, FlagHiSlot6, FlagHiSlot7,
+	},
+	FittingSlotRig: {
+		FlagRigSlot0, FlagRigSlot1, FlagRigSlot2, FlagRigSlot3,
+		FlagRigSlot4, FlagRigSlot5, FlagRigSlot6, FlagRigSlot7,
+	},
+	FittingSlotSubsystem: {
+		FlagSubSystemSlot0, FlagSubSystemSlot1, FlagSubSystemSlot2, FlagSubSystemSlot3,
+		FlagSubSystemSlot4, FlagSubSystemSlot5, FlagSubSystemSlot6, FlagSubSystemSlot7,
+	},
+	FittingSlotService: {
+		FlagServiceSlot0, FlagServiceSlot1, FlagServiceSlot2, FlagServiceSlot3,
+		FlagServiceSlot4, FlagServiceSlot5, FlagServiceSlot6, FlagServiceSlot7,
+	},
+	FittingSlotDrone:   {FlagDroneBay},
+	FittingSlotFighter: {FlagFighterBay},
+	FittingSlotCargo:   {FlagCargo},
+}
+
+var fittingSlotForFlag = func() map[LocationFlag]FittingSlot {
+	m := make(map[LocationFlag]FittingSlot)
+	for slot, flags := range fittingSlotFlags {
+		for _, f := range flags {
+			m[f] = slot
+		}
+	}
+	return m
+}()
+
+// FittingSlotForFlag returns the slot for a location flag.
+// Flags which do not belong to a slot or bay are reported as cargo.
+func FittingSlotForFlag(flag LocationFlag) FittingSlot {
+	slot, ok := fittingSlotForFlag[flag]
+	if !ok {
+		return FittingSlotCargo
+	}
+	return slot
+}
+
+// eftModuleRacks defines the order of the module racks in EFT format.
+var eftModuleRacks = []FittingSlot{
+	FittingSlotLow,
+	FittingSlotMedium,
+	FittingSlotHigh,
+	FittingSlotRig,
+	FittingSlotSubsystem,
+	FittingSlotService,
+}
+
+// eftOptionalRacks defines the module racks which only some ships have.
+// They are omitted when empty.
+var eftOptionalRacks = set.Of(FittingSlotSubsystem, FittingSlotService)
+
+// eftEmptySlotNames defines the placeholders used in EFT format for empty slots.
+var eftEmptySlotNames = map[FittingSlot]string{
+	FittingSlotLow:       "[Empty Low slot]",
+	FittingSlotMedium:    "[Empty Med slot]",
+	FittingSlotHigh:      "[Empty High slot]",
+	FittingSlotRig:       "[Empty Rig slot]",
+	FittingSlotSubsystem: "[Empty Subsystem slot]",
+	FittingSlotService:   "[Empty Service slot]",
+}
+
+// CharacterFitting is a saved ship fitting of a character.
+type CharacterFitting struct {
+	CharacterID int64
+	Description string
+	FittingID   int64
+	ID          int64
+	Items       []*CharacterFittingItem
+	Name        string
+	ShipType    *EveType
+}
+
+// ItemsForSlot returns the items of a slot ordered by their position.
+func (cf CharacterFitting) ItemsForSlot(slot FittingSlot) []*CharacterFittingItem {
+	var items []*CharacterFittingItem
+	for _, it := range cf.Items {
+		if FittingSlotForFlag(it.Flag) == slot {
+			items = append(items, it)
+		}
+	}
+	slices.SortStableFunc(items, func(a, b *CharacterFittingItem) int {
+		return cmp.Compare(a.Flag, b.Flag)
+	})
+	return items
+}
+
+// EFT returns the fitting in EFT format, which can be imported into PyFA and the game client.
+// Empty slots in front of fitted modules are written as placeholders,
+// so that all modules keep their position.
+func (cf CharacterFitting) EFT() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "[%s, %s]\n", cf.ShipType.Name, cf.Name)
+	for _, rack := range eftModuleRacks {
+		items := cf.ItemsForSlot(rack)
+		placeholder := eftEmptySlotNames[rack]
+		if len(items) == 0 {
+			// Empty racks must be kept so the position of the following racks is preserved
+			if !eftOptionalRacks.Contains(rack) {
+				fmt.Fprintf(&sb, "\n%s\n", placeholder)
+			}
+			continue
+		}
+		sb.WriteString("\n")
+		var position int
+		for _, it := range items {
+			for ; position < slices.Index(fittingSlotFlags[rack], it.Flag); position++ {
+				fmt.Fprintln(&sb, placeholder)
+			}
+			for range max(it.Quantity, 1) {
+				fmt.Fprintln(&sb, it.Type.Name)
+				position++
+			}
+		}
+	}
+	for _, bay := range []FittingSlot{FittingSlotDrone, FittingSlotFighter, FittingSlotCargo} {
+		items := cf.ItemsForSlot(bay)
+		if len(items) == 0 {
+			continue
+		}
+		sb.WriteString("\n")
+		for _, it := range items {
+			fmt.Fprintf(&sb, "%s x%d\n", it.Type.Name, it.Quantity)
+		}
+	}
+	return sb.String()
+}
+
+// SkillCheckTypeIDs returns the IDs of all types in a fitting which a pilot needs skills for,
+// i.e. the ship and all fitted modules, drones and fighters. Items in the cargo are ignored.
+func (cf CharacterFitting) SkillCheckTypeIDs() set.Set[int64] {
+	var ids set.Set[int64]
+	if cf.ShipType != nil {
+		ids.Add(cf.ShipType.ID)
+	}
+	for _, it := range cf.Items {
+		if FittingSlotForFlag(it.Flag) == FittingSlotCargo {
+			continue
+		}
+		ids.Add(it.Type.ID)
+	}
+	return ids
+}
+
+// CharacterFittingItem is an item in a saved ship fitting.
+type CharacterFittingItem struct {
+	Flag     LocationFlag
+	Quantity int64
+	Type     *EveType
+}
+
+// EFTFitting is a ship fitting parsed from text in EFT format.
+// Ship and items are referenced by their type names only.
+type EFTFitting struct {
+	Items        []EFTFittingItem
+	Name         string
+	ShipTypeName string
+}
+
+// EFTFittingItem is an item of a fitting parsed from EFT format.
+type EFTFittingItem struct {
+	Position int         // position within a module rack
+	Quantity int64       // quantity of items with a quantity or 1 for modules
+	Slot     FittingSlot // FittingSlotCargo for all items with a quantity
+	TypeName string
+}
+
+var (
+	eftHeaderRX   = regexp.MustCompile(`^\[([^,\]]+),\s*([^\]]*)\]$`)
+	eftQuantityRX = regexp.MustCompile(`^(.+?)\s+x(\d+)$`)
+)
+
+// ParseEFT parses a ship fitting from text in EFT format.
+//
+// Module racks are identified by their position, which is why EFT exports
+// contain placeholders for empty racks. Charges loaded into modules are ignored.
+// Items with quantities are reported as cargo, because the bay they belong to
+// can only be determined from their type.
+func ParseEFT(text string) (EFTFitting, error) {
+	var f EFTFitting
+	var blocks [][]string
+	var current []string
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if f.ShipTypeName == "" {
+			if line == "" {
+				continue
+			}
+			m := eftHeaderRX.FindStringSubmatch(line)
+			if m == nil {
+				return EFTFitting{}, fmt.Errorf("invalid EFT header %q: %w", line, ErrInvalid)
+			}
+			f.ShipTypeName = strings.TrimSpace(m[1])
+			f.Name = strings.TrimSpace(m[2])
+			continue
+		}
+		if line == "" {
+			if len(current) > 0 {
+				blocks = append(blocks, current)
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return EFTFitting{}, err
+	}
+	if len(current) > 0 {
+		blocks = append(blocks, current)
+	}
+	if f.ShipTypeName == "" {
+		return EFTFitting{}, fmt.Errorf("missing EFT header: %w", ErrInvalid)
+	}
+	var rackIndex int
+	for _, block := range blocks {
+		var position int
+		isRack := slices.ContainsFunc(block, func(line string) bool {
+			return !eftQuantityRX.MatchString(line)
+		})
+		var rack FittingSlot
+		if isRack {
+			if rackIndex < len(eftModuleRacks) {
+				rack = eftModuleRacks[rackIndex]
+			} else {
+				rack = FittingSlotCargo
+			}
+			rackIndex++
+		}
+		for _, line := range block {
+			if strings.HasPrefix(line, "[") {
+				position++ // placeholder for an empty slot
+				continue
+			}
+			line = strings.TrimSpace(strings.TrimSuffix(line, "/OFFLINE"))
+			if m := eftQuantityRX.FindStringSubmatch(line); m != nil {
+				quantity, err := strconv.ParseInt(m[2], 10, 64)
+				if err != nil {
+					return EFTFitting{}, fmt.Errorf("invalid quantity in line %q: %w", line, ErrInvalid)
+				}
+				f.Items = append(f.Items, EFTFittingItem{
+					Quantity: quantity,
+					Slot:     FittingSlotCargo,
+					TypeName: m[1],
+				})
+				continue
+			}
+			name, _, _ := strings.Cut(line, ",")
+			f.Items = append(f.Items, EFTFittingItem{
+				Position: position,
+				Quantity: 1,
+				Slot:     rack,
+				TypeName: strings.TrimSpace(name),
+			})
+			position++
+		}
+	}
+	return f, nil
+}
+
+// TypeNames returns the names of all types in a fitting incl. the ship.
+func (f EFTFitting) TypeNames() []string {
+	names := []string{f.ShipTypeName}
+	for _, it := range f.Items {
+		names = append(names, it.TypeName)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// ToCharacterFitting converts an EFT fitting into a fitting with resolved types.
+// It returns an error when a type can not be resolved.
+//
+// Structures have service slots instead of subsystems,
+// so the rack after the rigs is resolved as service slots for structures.
+func (f EFTFitting) ToCharacterFitting(types map[string]*EveType) (*CharacterFitting, error) {
+	var missing []string
+	for _, n := range f.TypeNames() {
+		if _, ok := types[n]; !ok {
+			missing = append(missing, n)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("unknown types: %s: %w", strings.Join(missing, ", "), ErrNotFound)
+	}
+	cf := &CharacterFitting{
+		Name:     f.Name,
+		ShipType: types[f.ShipTypeName],
+	}
+	isStructure := cf.ShipType.Group.Category.ID == EveCategoryStructure
+	for _, it := range f.Items {
+		et := types[it.TypeName]
+		slot := it.Slot
+		if slot == FittingSlotSubsystem && isStructure {
+			slot = FittingSlotService
+		}
+		var flag LocationFlag
+		switch slot {
+		case FittingSlotCargo:
+			switch et.Group.Category.ID {
+			case EveCategoryDrone:
+				flag = FlagDroneBay
+			case EveCategoryFighter:
+				flag = FlagFighterBay
+			default:
+				flag = FlagCargo
+			}
+		default:
+			flags := fittingSlotFlags[slot]
+			if it.Position < len(flags) {
+				flag = flags[it.Position]
+			} else {
+				flag = FlagCargo
+			}
+		}
+		cf.Items = append(cf.Items, &CharacterFittingItem{
+			Flag:     flag,
+			Quantity: it.Quantity,
+			Type:     et,
+		})
+	}
+	return cf, nil
+}
+
+// CharacterFittingSkillCheck reports which skills a character is missing to fly a fitting.
+type CharacterFittingSkillCheck struct {
+	Character     *EntityShort
+	MissingSkills []*CharacterMissingSkill
+}
+
+// CanFly reports whether the character has all skills required to fly the fitting.
+func (x CharacterFittingSkillCheck) CanFly() bool {
+	return len(x.MissingSkills) == 0
+}
+
+// TrainingTime returns the total time needed to train all missing skills.
+// Returns an empty value when the training time of any missing skill is unknown.
+func (x CharacterFittingSkillCheck) TrainingTime() optional.Optional[time.Duration] {
+	var total time.Duration
+	for _, m := range x.MissingSkills {
+		d, ok := m.TrainingTime.Value()
+		if !ok {
+			return optional.Optional[time.Duration]{}
+		}
+		total += d
+	}
+	return optional.New(total)
+}
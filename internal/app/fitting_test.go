@@ -0,0 +1,313 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func makeFittingType(id int64, name string, categoryID int64) *app.EveType {
+	return &app.EveType{
+		ID:   id,
+		Name: name,
+		Group: &app.EveGroup{
+			Category: &app.EveCategory{ID: categoryID},
+		},
+	}
+}
+
+func TestCharacterFitting_EFT(t *testing.T) {
+	ship := makeFittingType(1, "Rifter", app.EveCategoryShip)
+	plate := makeFittingType(2, "200mm Steel Plates I", 7)
+	scram := makeFittingType(3, "Warp Scrambler I", 7)
+	gun := makeFittingType(4, "200mm AutoCannon I", 7)
+	drone := makeFittingType(5, "Hobgoblin I", app.EveCategoryDrone)
+	ammo := makeFittingType(6, "EMP S", 8)
+	t.Run("can export full fit", func(t *testing.T) {
+		cf := app.CharacterFitting{
+			Name:     "Alpha",
+			ShipType: ship,
+			Items: []*app.CharacterFittingItem{
+				{Flag: app.FlagHiSlot1, Quantity: 1, Type: gun},
+				{Flag: app.FlagHiSlot0, Quantity: 1, Type: gun},
+				{Flag: app.FlagMedSlot0, Quantity: 1, Type: scram},
+				{Flag: app.FlagLoSlot0, Quantity: 1, Type: plate},
+				{Flag: app.FlagDroneBay, Quantity: 2, Type: drone},
+				{Flag: app.FlagCargo, Quantity: 500, Type: ammo},
+			},
+		}
+		want := "[Rifter, Alpha]\n" +
+			"\n200mm Steel Plates I\n" +
+			"\nWarp Scrambler I\n" +
+			"\n200mm AutoCannon I\n200mm AutoCannon I\n" +
+			"\n[Empty Rig slot]\n" +
+			"\nHobgoblin I x2\n" +
+			"\nEMP S x500\n"
+		xassert.Equal(t, want, cf.EFT())
+	})
+	t.Run("keeps position of empty racks", func(t *testing.T) {
+		cf := app.CharacterFitting{
+			Name:     "Alpha",
+			ShipType: ship,
+			Items: []*app.CharacterFittingItem{
+				{Flag: app.FlagHiSlot0, Quantity: 1, Type: gun},
+			},
+		}
+		want := "[Rifter, Alpha]\n" +
+			"\n[Empty Low slot]\n" +
+			"\n[Empty Med slot]\n" +
+			"\n200mm AutoCannon I\n" +
+			"\n[Empty Rig slot]\n"
+		xassert.Equal(t, want, cf.EFT())
+	})
+	t.Run("keeps position of modules after empty slots", func(t *testing.T) {
+		cf := app.CharacterFitting{
+			Name:     "Alpha",
+			ShipType: ship,
+			Items: []*app.CharacterFittingItem{
+				{Flag: app.FlagHiSlot2, Quantity: 1, Type: gun},
+				{Flag: app.FlagHiSlot0, Quantity: 1, Type: gun},
+			},
+		}
+		want := "[Rifter, Alpha]\n" +
+			"\n[Empty Low slot]\n" +
+			"\n[Empty Med slot]\n" +
+			"\n200mm AutoCannon I\n[Empty High slot]\n200mm AutoCannon I\n" +
+			"\n[Empty Rig slot]\n"
+		xassert.Equal(t, want, cf.EFT())
+	})
+	t.Run("can export subsystems and services", func(t *testing.T) {
+		cf := app.CharacterFitting{
+			Name:     "Alpha",
+			ShipType: ship,
+			Items: []*app.CharacterFittingItem{
+				{Flag: app.FlagSubSystemSlot1, Quantity: 1, Type: plate},
+				{Flag: app.FlagServiceSlot0, Quantity: 1, Type: scram},
+			},
+		}
+		want := "[Rifter, Alpha]\n" +
+			"\n[Empty Low slot]\n" +
+			"\n[Empty Med slot]\n" +
+			"\n[Empty High slot]\n" +
+			"\n[Empty Rig slot]\n" +
+			"\n[Empty Subsystem slot]\n200mm Steel Plates I\n" +
+			"\nWarp Scrambler I\n"
+		xassert.Equal(t, want, cf.EFT())
+	})
+}
+
+func TestCharacterFitting_EFTRoundTrip(t *testing.T) {
+	typesForFitting := func(cf app.CharacterFitting) map[string]*app.EveType {
+		types := map[string]*app.EveType{cf.ShipType.Name: cf.ShipType}
+		for _, it := range cf.Items {
+			types[it.Type.Name] = it.Type
+		}
+		return types
+	}
+	cases := []struct {
+		name string
+		cf   app.CharacterFitting
+	}{
+		{
+			name: "frigate",
+			cf: app.CharacterFitting{
+				Name:     "Alpha",
+				ShipType: makeFittingType(587, "Rifter", app.EveCategoryShip),
+				Items: []*app.CharacterFittingItem{
+					{Flag: app.FlagLoSlot1, Quantity: 1, Type: makeFittingType(11, "200mm Steel Plates I", 7)},
+					{Flag: app.FlagMedSlot0, Quantity: 1, Type: makeFittingType(12, "Warp Scrambler I", 7)},
+					{Flag: app.FlagHiSlot0, Quantity: 1, Type: makeFittingType(13, "200mm AutoCannon I", 7)},
+					{Flag: app.FlagHiSlot2, Quantity: 1, Type: makeFittingType(13, "200mm AutoCannon I", 7)},
+					{Flag: app.FlagRigSlot0, Quantity: 1, Type: makeFittingType(14, "Small Projectile Burst Aerator I", 7)},
+					{Flag: app.FlagDroneBay, Quantity: 2, Type: makeFittingType(15, "Hobgoblin I", app.EveCategoryDrone)},
+					{Flag: app.FlagCargo, Quantity: 500, Type: makeFittingType(16, "EMP S", 8)},
+				},
+			},
+		},
+		{
+			name: "T3 cruiser",
+			cf: app.CharacterFitting{
+				Name:     "Bravo",
+				ShipType: makeFittingType(29990, "Loki", app.EveCategoryShip),
+				Items: []*app.CharacterFittingItem{
+					{Flag: app.FlagLoSlot0, Quantity: 1, Type: makeFittingType(21, "Damage Control II", 7)},
+					{Flag: app.FlagMedSlot0, Quantity: 1, Type: makeFittingType(22, "10MN Afterburner II", 7)},
+					{Flag: app.FlagHiSlot0, Quantity: 1, Type: makeFittingType(23, "Heavy Assault Missile Launcher II", 7)},
+					{Flag: app.FlagSubSystemSlot0, Quantity: 1, Type: makeFittingType(24, "Loki Core - Immobility Drivers", 32)},
+					{Flag: app.FlagSubSystemSlot1, Quantity: 1, Type: makeFittingType(25, "Loki Defensive - Covert Reconfiguration", 32)},
+					{Flag: app.FlagSubSystemSlot2, Quantity: 1, Type: makeFittingType(26, "Loki Offensive - Launcher Efficiency Configuration", 32)},
+					{Flag: app.FlagSubSystemSlot3, Quantity: 1, Type: makeFittingType(27, "Loki Propulsion - Intercalated Nanofibers", 32)},
+				},
+			},
+		},
+		{
+			name: "structure",
+			cf: app.CharacterFitting{
+				Name:     "Charlie",
+				ShipType: makeFittingType(35832, "Astrahus", app.EveCategoryStructure),
+				Items: []*app.CharacterFittingItem{
+					{Flag: app.FlagMedSlot0, Quantity: 1, Type: makeFittingType(31, "Standup Focused Warp Disruptor I", 66)},
+					{Flag: app.FlagHiSlot0, Quantity: 1, Type: makeFittingType(32, "Standup Anticapital Missile Launcher I", 66)},
+					{Flag: app.FlagRigSlot0, Quantity: 1, Type: makeFittingType(33, "Standup M-Set Equipment Manufacturing Efficiency I", 66)},
+					{Flag: app.FlagServiceSlot0, Quantity: 1, Type: makeFittingType(34, "Standup Manufacturing Plant I", 66)},
+					{Flag: app.FlagServiceSlot2, Quantity: 1, Type: makeFittingType(35, "Standup Cloning Center I", 66)},
+					{Flag: app.FlagFighterBay, Quantity: 3, Type: makeFittingType(36, "Standup Cyclops I", app.EveCategoryFighter)},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := app.ParseEFT(tc.cf.EFT())
+			require.NoError(t, err)
+			got, err := f.ToCharacterFitting(typesForFitting(tc.cf))
+			require.NoError(t, err)
+			xassert.Equal(t, &tc.cf, got)
+		})
+	}
+}
+
+func TestParseEFT(t *testing.T) {
+	t.Run("can parse fit", func(t *testing.T) {
+		text := `
+[Rifter, Alpha]
+
+200mm Steel Plates I
+
+Warp Scrambler I /OFFLINE
+[Empty Med slot]
+
+200mm AutoCannon I, EMP S
+200mm AutoCannon I, EMP S
+
+[Empty Rig slot]
+
+
+Hobgoblin I x2
+
+EMP S x500
+`
+		got, err := app.ParseEFT(text)
+		require.NoError(t, err)
+		xassert.Equal(t, "Rifter", got.ShipTypeName)
+		xassert.Equal(t, "Alpha", got.Name)
+		want := []app.EFTFittingItem{
+			{Position: 0, Quantity: 1, Slot: app.FittingSlotLow, TypeName: "200mm Steel Plates I"},
+			{Position: 0, Quantity: 1, Slot: app.FittingSlotMedium, TypeName: "Warp Scrambler I"},
+			{Position: 0, Quantity: 1, Slot: app.FittingSlotHigh, TypeName: "200mm AutoCannon I"},
+			{Position: 1, Quantity: 1, Slot: app.FittingSlotHigh, TypeName: "200mm AutoCannon I"},
+			{Position: 0, Quantity: 2, Slot: app.FittingSlotCargo, TypeName: "Hobgoblin I"},
+			{Position: 0, Quantity: 500, Slot: app.FittingSlotCargo, TypeName: "EMP S"},
+		}
+		xassert.Equal(t, want, got.Items)
+	})
+	t.Run("should return error when header is missing", func(t *testing.T) {
+		_, err := app.ParseEFT("200mm Steel Plates I\n")
+		require.ErrorIs(t, err, app.ErrInvalid)
+	})
+	t.Run("should return error when text is empty", func(t *testing.T) {
+		_, err := app.ParseEFT("")
+		require.ErrorIs(t, err, app.ErrInvalid)
+	})
+}
+
+func TestEFTFitting_ToCharacterFitting(t *testing.T) {
+	ship := makeFittingType(1, "Rifter", app.EveCategoryShip)
+	gun := makeFittingType(4, "200mm AutoCannon I", 7)
+	drone := makeFittingType(5, "Hobgoblin I", app.EveCategoryDrone)
+	ammo := makeFittingType(6, "EMP S", 8)
+	types := map[string]*app.EveType{
+		ship.Name:  ship,
+		gun.Name:   gun,
+		drone.Name: drone,
+		ammo.Name:  ammo,
+	}
+	t.Run("can resolve fitting", func(t *testing.T) {
+		f := app.EFTFitting{
+			Name:         "Alpha",
+			ShipTypeName: "Rifter",
+			Items: []app.EFTFittingItem{
+				{Position: 1, Quantity: 1, Slot: app.FittingSlotHigh, TypeName: "200mm AutoCannon I"},
+				{Quantity: 2, Slot: app.FittingSlotCargo, TypeName: "Hobgoblin I"},
+				{Quantity: 500, Slot: app.FittingSlotCargo, TypeName: "EMP S"},
+			},
+		}
+		got, err := f.ToCharacterFitting(types)
+		require.NoError(t, err)
+		xassert.Equal(t, "Alpha", got.Name)
+		xassert.Equal(t, ship, got.ShipType)
+		want := []*app.CharacterFittingItem{
+			{Flag: app.FlagHiSlot1, Quantity: 1, Type: gun},
+			{Flag: app.FlagDroneBay, Quantity: 2, Type: drone},
+			{Flag: app.FlagCargo, Quantity: 500, Type: ammo},
+		}
+		xassert.Equal(t, want, got.Items)
+	})
+	t.Run("should return error when a type is unknown", func(t *testing.T) {
+		f := app.EFTFitting{
+			ShipTypeName: "Rifter",
+			Items: []app.EFTFittingItem{
+				{Quantity: 1, Slot: app.FittingSlotLow, TypeName: "Unknown Module"},
+			},
+		}
+		_, err := f.ToCharacterFitting(types)
+		require.ErrorIs(t, err, app.ErrNotFound)
+	})
+}
+
+func TestFittingSlotForFlag(t *testing.T) {
+	xassert.Equal(t, app.FittingSlotHigh, app.FittingSlotForFlag(app.FlagHiSlot3))
+	xassert.Equal(t, app.FittingSlotRig, app.FittingSlotForFlag(app.FlagRigSlot0))
+	xassert.Equal(t, app.FittingSlotDrone, app.FittingSlotForFlag(app.FlagDroneBay))
+	xassert.Equal(t, app.FittingSlotCargo, app.FittingSlotForFlag(app.FlagHangar))
+}
+
+func TestCharacterFitting_SkillCheckTypeIDs(t *testing.T) {
+	ship := makeFittingType(1, "Rifter", app.EveCategoryShip)
+	gun := makeFittingType(4, "200mm AutoCannon I", 7)
+	drone := makeFittingType(5, "Hobgoblin I", app.EveCategoryDrone)
+	ammo := makeFittingType(6, "EMP S", 8)
+	cf := app.CharacterFitting{
+		ShipType: ship,
+		Items: []*app.CharacterFittingItem{
+			{Flag: app.FlagHiSlot0, Quantity: 1, Type: gun},
+			{Flag: app.FlagDroneBay, Quantity: 2, Type: drone},
+			{Flag: app.FlagCargo, Quantity: 500, Type: ammo},
+		},
+	}
+	xassert.Equal(t, set.Of[int64](1, 4, 5), cf.SkillCheckTypeIDs())
+}
+
+func TestCharacterFittingSkillCheck(t *testing.T) {
+	t.Run("can fly when no skills are missing", func(t *testing.T) {
+		x := app.CharacterFittingSkillCheck{}
+		assert.True(t, x.CanFly())
+		xassert.EqualOptional(t, 0, x.TrainingTime())
+	})
+	t.Run("should sum training times of missing skills", func(t *testing.T) {
+		x := app.CharacterFittingSkillCheck{
+			MissingSkills: []*app.CharacterMissingSkill{
+				{TrainingTime: optional.New(time.Hour)},
+				{TrainingTime: optional.New(2 * time.Hour)},
+			},
+		}
+		assert.False(t, x.CanFly())
+		xassert.EqualOptional(t, 3*time.Hour, x.TrainingTime())
+	})
+	t.Run("should report unknown training time when a time is unknown", func(t *testing.T) {
+		x := app.CharacterFittingSkillCheck{
+			MissingSkills: []*app.CharacterMissingSkill{
+				{TrainingTime: optional.New(time.Hour)},
+				{},
+			},
+		}
+		xassert.Empty(t, x.TrainingTime())
+	})
+}
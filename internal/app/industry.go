@@ -187,6 +187,12 @@ type CharacterIndustryJob struct {
 	SuccessfulRuns     optional.Optional[int64]
 }
 
+// IsReady reports whether a job is ready for delivery at the given time.
+// Active jobs are ready once their end date has passed.
+func (j CharacterIndustryJob) IsReady(now time.Time) bool {
+	return isIndustryJobReady(j.Status, j.EndDate, now)
+}
+
 type IndustryJobActivityCount struct {
 	InstallerID int64
 	Activity    IndustryActivity
@@ -220,3 +226,19 @@ type CorporationIndustryJob struct {
 	Status              IndustryJobStatus
 	SuccessfulRuns      optional.Optional[int64]
 }
+
+// IsReady reports whether a job is ready for delivery at the given time.
+// Active jobs are ready once their end date has passed.
+func (j CorporationIndustryJob) IsReady(now time.Time) bool {
+	return isIndustryJobReady(j.Status, j.EndDate, now)
+}
+
+func isIndustryJobReady(status IndustryJobStatus, endDate, now time.Time) bool {
+	switch status {
+	case JobReady:
+		return true
+	case JobActive:
+		return !endDate.IsZero() && !endDate.After(now)
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+)
+
+func TestIndustryJob_IsReady(t *testing.T) {
+	now := time.Now().UTC()
+	cases := []struct {
+		name    string
+		status  app.IndustryJobStatus
+		endDate time.Time
+		want    bool
+	}{
+		{"ready", app.JobReady, now.Add(time.Hour), true},
+		{"active and ended", app.JobActive, now.Add(-time.Hour), true},
+		{"active and not ended", app.JobActive, now.Add(time.Hour), false},
+		{"active without end date", app.JobActive, time.Time{}, false},
+		{"delivered", app.JobDelivered, now.Add(-time.Hour), false},
+		{"paused", app.JobPaused, now.Add(-time.Hour), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			j1 := app.CharacterIndustryJob{Status: tc.status, EndDate: tc.endDate}
+			assert.Equal(t, tc.want, j1.IsReady(now))
+			j2 := app.CorporationIndustryJob{Status: tc.status, EndDate: tc.endDate}
+			assert.Equal(t, tc.want, j2.IsReady(now))
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"time"
+
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+// EveKillmail is a killmail in EVE Online.
+type EveKillmail struct {
+	Attackers           []*EveKillmailAttacker // only populated when fetching a single killmail
+	Hash                string
+	ID                  int64
+	Items               []*EveKillmailItem // only populated when fetching a single killmail
+	ItemsValue          optional.Optional[float64]
+	MoonID              optional.Optional[int64]
+	ShipValue           optional.Optional[float64]
+	SolarSystem         *EntityShort
+	SolarSystemSecurity float32
+	Time                time.Time
+	Victim              EveKillmailVictim
+	WarID               optional.Optional[int64]
+}
+
+// Value returns the estimated total value of a killmail,
+// which is the value of the destroyed ship and all of its items.
+func (km EveKillmail) Value() optional.Optional[float64] {
+	return optional.SumNonEmpty(km.ShipValue, km.ItemsValue)
+}
+
+// IsLossForCharacter reports whether a killmail is a loss for a character.
+func (km EveKillmail) IsLossForCharacter(characterID int64) bool {
+	x, ok := km.Victim.Character.Value()
+	return ok && x.ID == characterID
+}
+
+// IsLossForCorporation reports whether a killmail is a loss for a corporation.
+func (km EveKillmail) IsLossForCorporation(corporationID int64) bool {
+	x, ok := km.Victim.Corporation.Value()
+	return ok && x.ID == corporationID
+}
+
+// FinalBlow returns the attacker who landed the final blow when known.
+func (km EveKillmail) FinalBlow() optional.Optional[*EveKillmailAttacker] {
+	for _, a := range km.Attackers {
+		if a.IsFinalBlow {
+			return optional.New(a)
+		}
+	}
+	return optional.Optional[*EveKillmailAttacker]{}
+}
+
+// VictimName returns the best available name for the victim.
+func (km EveKillmail) VictimName() string {
+	for _, x := range []optional.Optional[*EveEntity]{
+		km.Victim.Character,
+		km.Victim.Corporation,
+		km.Victim.Faction,
+	} {
+		if v, ok := x.Value(); ok {
+			return v.Name
+		}
+	}
+	return "?"
+}
+
+type EveKillmailVictim struct {
+	Alliance    optional.Optional[*EveEntity]
+	Character   optional.Optional[*EveEntity]
+	Corporation optional.Optional[*EveEntity]
+	DamageTaken int64
+	Faction     optional.Optional[*EveEntity]
+	ShipType    *EntityShort
+}
+
+type EveKillmailAttacker struct {
+	Alliance       optional.Optional[*EveEntity]
+	Character      optional.Optional[*EveEntity]
+	Corporation    optional.Optional[*EveEntity]
+	DamageDone     int64
+	Faction        optional.Optional[*EveEntity]
+	IsFinalBlow    bool
+	SecurityStatus float64
+	ShipType       optional.Optional[*EntityShort]
+	WeaponType     optional.Optional[*EntityShort]
+}
+
+type EveKillmailItem struct {
+	Flag              int64
+	IsSingleton       bool
+	Price             optional.Optional[float64]
+	QuantityDestroyed int64
+	QuantityDropped   int64
+	Type              *EntityShort
+}
+
+// Value returns the estimated value of all destroyed and dropped items.
+func (x EveKillmailItem) Value() optional.Optional[float64] {
+	price, ok := x.Price.Value()
+	if !ok {
+		return optional.Optional[float64]{}
+	}
+	return optional.New(price * float64(x.QuantityDestroyed+x.QuantityDropped))
+}
@@ -0,0 +1,78 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestEveKillmail_Value(t *testing.T) {
+	cases := []struct {
+		name  string
+		ship  optional.Optional[float64]
+		items optional.Optional[float64]
+		want  optional.Optional[float64]
+	}{
+		{"both", optional.New(1.0), optional.New(2.0), optional.New(3.0)},
+		{"ship only", optional.New(1.0), optional.Optional[float64]{}, optional.New(1.0)},
+		{"none", optional.Optional[float64]{}, optional.Optional[float64]{}, optional.Optional[float64]{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			km := app.EveKillmail{ShipValue: tc.ship, ItemsValue: tc.items}
+			xassert.Equal(t, tc.want, km.Value())
+		})
+	}
+}
+
+func TestEveKillmail_IsLoss(t *testing.T) {
+	km := app.EveKillmail{
+		Victim: app.EveKillmailVictim{
+			Character:   optional.New(&app.EveEntity{ID: 1, Category: app.EveEntityCharacter}),
+			Corporation: optional.New(&app.EveEntity{ID: 2, Category: app.EveEntityCorporation}),
+		},
+	}
+	assert.True(t, km.IsLossForCharacter(1))
+	assert.False(t, km.IsLossForCharacter(3))
+	assert.True(t, km.IsLossForCorporation(2))
+	assert.False(t, km.IsLossForCorporation(3))
+}
+
+func TestEveKillmail_FinalBlow(t *testing.T) {
+	t.Run("has final blow", func(t *testing.T) {
+		a := &app.EveKillmailAttacker{IsFinalBlow: true}
+		km := app.EveKillmail{Attackers: []*app.EveKillmailAttacker{{}, a}}
+		xassert.EqualOptional(t, a, km.FinalBlow())
+	})
+	t.Run("no attackers", func(t *testing.T) {
+		km := app.EveKillmail{}
+		assert.True(t, km.FinalBlow().IsEmpty())
+	})
+}
+
+func TestEveKillmail_VictimName(t *testing.T) {
+	t.Run("character", func(t *testing.T) {
+		km := app.EveKillmail{Victim: app.EveKillmailVictim{
+			Character:   optional.New(&app.EveEntity{Name: "Alpha"}),
+			Corporation: optional.New(&app.EveEntity{Name: "Bravo"}),
+		}}
+		xassert.Equal(t, "Alpha", km.VictimName())
+	})
+	t.Run("structure", func(t *testing.T) {
+		km := app.EveKillmail{Victim: app.EveKillmailVictim{
+			Corporation: optional.New(&app.EveEntity{Name: "Bravo"}),
+		}}
+		xassert.Equal(t, "Bravo", km.VictimName())
+	})
+}
+
+func TestEveKillmailItem_Value(t *testing.T) {
+	x := app.EveKillmailItem{Price: optional.New(2.0), QuantityDestroyed: 3, QuantityDropped: 1}
+	xassert.EqualOptional(t, 8.0, x.Value())
+	y := app.EveKillmailItem{QuantityDestroyed: 3}
+	assert.True(t, y.Value().IsEmpty())
+}
@@ -0,0 +1,340 @@
+package localapi
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+type characterResponse struct {
+	ID              int64                        `json:"id"`
+	Name            string                       `json:"name"`
+	CorporationID   int64                        `json:"corporation_id"`
+	CorporationName string                       `json:"corporation_name"`
+	AllianceID      optional.Optional[int64]     `json:"alliance_id"`
+	AllianceName    optional.Optional[string]    `json:"alliance_name"`
+	SecurityStatus  optional.Optional[float64]   `json:"security_status"`
+	LocationID      optional.Optional[int64]     `json:"location_id"`
+	LocationName    optional.Optional[string]    `json:"location_name"`
+	ShipTypeID      optional.Optional[int64]     `json:"ship_type_id"`
+	ShipTypeName    optional.Optional[string]    `json:"ship_type_name"`
+	TotalSP         optional.Optional[int64]     `json:"total_sp"`
+	UnallocatedSP   optional.Optional[int64]     `json:"unallocated_sp"`
+	WalletBalance   optional.Optional[float64]   `json:"wallet_balance"`
+	AssetValue      optional.Optional[float64]   `json:"asset_value"`
+	LastLoginAt     optional.Optional[time.Time] `json:"last_login_at"`
+}
+
+func newCharacterResponse(c *app.Character) characterResponse {
+	x := characterResponse{
+		ID:            c.ID,
+		TotalSP:       c.TrainedSP,
+		UnallocatedSP: c.UnallocatedSP,
+		WalletBalance: c.WalletBalance,
+		AssetValue:    c.AssetValue,
+		LastLoginAt:   c.LastLoginAt,
+	}
+	if ec := c.EveCharacter; ec != nil {
+		x.Name = ec.Name
+		x.SecurityStatus = ec.SecurityStatus
+		if ec.Corporation != nil {
+			x.CorporationID = ec.Corporation.ID
+			x.CorporationName = ec.Corporation.Name
+		}
+		if a, ok := ec.Alliance.Value(); ok {
+			x.AllianceID = optional.New(a.ID)
+			x.AllianceName = optional.New(a.Name)
+		}
+	}
+	if l, ok := c.Location.Value(); ok {
+		x.LocationID = optional.New(l.ID)
+		x.LocationName = optional.New(l.DisplayName())
+	}
+	if s, ok := c.Ship.Value(); ok {
+		x.ShipTypeID = optional.New(s.ID)
+		x.ShipTypeName = optional.New(s.Name)
+	}
+	return x
+}
+
+func (a *LocalAPI) listCharacters(w http.ResponseWriter, r *http.Request) {
+	characters, err := a.cs.ListCharacters(r.Context())
+	if err != nil {
+		writeServerError(w, r, err)
+		return
+	}
+	items := make([]characterResponse, 0, len(characters))
+	for _, c := range characters {
+		items = append(items, newCharacterResponse(c))
+	}
+	writeJSON(w, items)
+}
+
+func (a *LocalAPI) getCharacter(w http.ResponseWriter, r *http.Request) {
+	characterID, ok := a.characterID(w, r)
+	if !ok {
+		return
+	}
+	c, err := a.cs.GetCharacter(r.Context(), characterID)
+	if err != nil {
+		writeServerError(w, r, err)
+		return
+	}
+	writeJSON(w, newCharacterResponse(c))
+}
+
+type walletResponse struct {
+	CharacterID   int64                      `json:"character_id"`
+	CharacterName string                     `json:"character_name"`
+	Balance       optional.Optional[float64] `json:"balance"`
+}
+
+func (a *LocalAPI) listWallets(w http.ResponseWriter, r *http.Request) {
+	characters, err := a.cs.ListCharacters(r.Context())
+	if err != nil {
+		writeServerError(w, r, err)
+		return
+	}
+	items := make([]walletResponse, 0, len(characters))
+	for _, c := range characters {
+		var name string
+		if c.EveCharacter != nil {
+			name = c.EveCharacter.Name
+		}
+		items = append(items, walletResponse{
+			CharacterID:   c.ID,
+			CharacterName: name,
+			Balance:       c.WalletBalance,
+		})
+	}
+	writeJSON(w, items)
+}
+
+type skillqueueItemResponse struct {
+	QueuePosition   int64                        `json:"queue_position"`
+	SkillID         int64                        `json:"skill_id"`
+	SkillName       string                       `json:"skill_name"`
+	GroupName       string                       `json:"group_name"`
+	FinishedLevel   int64                        `json:"finished_level"`
+	StartDate       optional.Optional[time.Time] `json:"start_date"`
+	FinishDate      optional.Optional[time.Time] `json:"finish_date"`
+	LevelStartSP    optional.Optional[int64]     `json:"level_start_sp"`
+	LevelEndSP      optional.Optional[int64]     `json:"level_end_sp"`
+	TrainingStartSP optional.Optional[int64]     `json:"training_start_sp"`
+	IsActive        bool                         `json:"is_active"`
+	CompletionP     float64                      `json:"completion_p"`
+}
+
+func (a *LocalAPI) listSkillqueue(w http.ResponseWriter, r *http.Request) {
+	characterID, ok := a.characterID(w, r)
+	if !ok {
+		return
+	}
+	queue, err := a.cs.ListSkillqueueItems(r.Context(), characterID)
+	if err != nil {
+		writeServerError(w, r, err)
+		return
+	}
+	items := make([]skillqueueItemResponse, 0, len(queue))
+	for _, q := range queue {
+		items = append(items, skillqueueItemResponse{
+			QueuePosition:   q.QueuePosition,
+			SkillID:         q.SkillID,
+			SkillName:       q.SkillName,
+			GroupName:       q.GroupName,
+			FinishedLevel:   q.FinishedLevel,
+			StartDate:       q.StartDate,
+			FinishDate:      q.FinishDate,
+			LevelStartSP:    q.LevelStartSP,
+			LevelEndSP:      q.LevelEndSP,
+			TrainingStartSP: q.TrainingStartSP,
+			IsActive:        q.IsActive(),
+			CompletionP:     q.CompletionP(),
+		})
+	}
+	writeJSON(w, items)
+}
+
+type assetResponse struct {
+	ItemID          int64                      `json:"item_id"`
+	TypeID          int64                      `json:"type_id"`
+	TypeName        string                     `json:"type_name"`
+	Name            string                     `json:"name"`
+	Quantity        int                        `json:"quantity"`
+	IsSingleton     bool                       `json:"is_singleton"`
+	IsBlueprintCopy optional.Optional[bool]    `json:"is_blueprint_copy"`
+	LocationID      int64                      `json:"location_id"`
+	LocationFlag    string                     `json:"location_flag"`
+	Price           optional.Optional[float64] `json:"price"`
+}
+
+func (a *LocalAPI) listAssets(w http.ResponseWriter, r *http.Request) {
+	characterID, ok := a.characterID(w, r)
+	if !ok {
+		return
+	}
+	assets, err := a.cs.ListAssets(r.Context(), characterID)
+	if err != nil {
+		writeServerError(w, r, err)
+		return
+	}
+	items := make([]assetResponse, 0, len(assets))
+	for _, x := range assets {
+		var typeID int64
+		var typeName string
+		if x.Type != nil {
+			typeID = x.Type.ID
+			typeName = x.Type.Name
+		}
+		items = append(items, assetResponse{
+			ItemID:          x.ItemID,
+			TypeID:          typeID,
+			TypeName:        typeName,
+			Name:            x.Name,
+			Quantity:        x.Quantity,
+			IsSingleton:     x.IsSingleton,
+			IsBlueprintCopy: x.IsBlueprintCopy,
+			LocationID:      x.LocationID,
+			LocationFlag:    x.LocationFlag.String(),
+			Price:           x.Price,
+		})
+	}
+	writeJSON(w, items)
+}
+
+type industryJobResponse struct {
+	JobID             int64                        `json:"job_id"`
+	CharacterID       int64                        `json:"character_id"`
+	Activity          string                       `json:"activity"`
+	Status            string                       `json:"status"`
+	BlueprintID       int64                        `json:"blueprint_id"`
+	BlueprintTypeID   int64                        `json:"blueprint_type_id"`
+	BlueprintTypeName string                       `json:"blueprint_type_name"`
+	ProductTypeID     optional.Optional[int64]     `json:"product_type_id"`
+	ProductTypeName   optional.Optional[string]    `json:"product_type_name"`
+	Runs              int                          `json:"runs"`
+	FacilityID        int64                        `json:"facility_id"`
+	FacilityName      string                       `json:"facility_name"`
+	InstallerID       int64                        `json:"installer_id"`
+	InstallerName     string                       `json:"installer_name"`
+	Cost              optional.Optional[float64]   `json:"cost"`
+	StartDate         time.Time                    `json:"start_date"`
+	EndDate           time.Time                    `json:"end_date"`
+	CompletedDate     optional.Optional[time.Time] `json:"completed_date"`
+}
+
+func newIndustryJobResponse(j *app.CharacterIndustryJob) industryJobResponse {
+	x := industryJobResponse{
+		JobID:         j.JobID,
+		CharacterID:   j.CharacterID,
+		Activity:      j.Activity.String(),
+		Status:        j.Status.String(),
+		BlueprintID:   j.BlueprintID,
+		Runs:          j.Runs,
+		Cost:          j.Cost,
+		StartDate:     j.StartDate,
+		EndDate:       j.EndDate,
+		CompletedDate: j.CompletedDate,
+	}
+	if j.BlueprintType != nil {
+		x.BlueprintTypeID = j.BlueprintType.ID
+		x.BlueprintTypeName = j.BlueprintType.Name
+	}
+	if p, ok := j.ProductType.Value(); ok && p != nil {
+		x.ProductTypeID = optional.New(p.ID)
+		x.ProductTypeName = optional.New(p.Name)
+	}
+	if j.Facility != nil {
+		x.FacilityID = j.Facility.ID
+		x.FacilityName = j.Facility.DisplayName()
+	}
+	if j.Installer != nil {
+		x.InstallerID = j.Installer.ID
+		x.InstallerName = j.Installer.Name
+	}
+	return x
+}
+
+func (a *LocalAPI) listAllIndustryJobs(w http.ResponseWriter, r *http.Request) {
+	a.writeIndustryJobs(w, r, 0)
+}
+
+func (a *LocalAPI) listIndustryJobs(w http.ResponseWriter, r *http.Request) {
+	characterID, ok := a.characterID(w, r)
+	if !ok {
+		return
+	}
+	a.writeIndustryJobs(w, r, characterID)
+}
+
+// writeIndustryJobs writes the industry jobs of a character
+// or of all characters when characterID is 0.
+func (a *LocalAPI) writeIndustryJobs(w http.ResponseWriter, r *http.Request, characterID int64) {
+	jobs, err := a.cs.ListAllCharacterIndustryJob(r.Context())
+	if err != nil {
+		writeServerError(w, r, err)
+		return
+	}
+	if characterID != 0 {
+		jobs = slices.DeleteFunc(jobs, func(j *app.CharacterIndustryJob) bool {
+			return j.CharacterID != characterID
+		})
+	}
+	slices.SortFunc(jobs, func(a, b *app.CharacterIndustryJob) int {
+		return cmp.Or(a.EndDate.Compare(b.EndDate), cmp.Compare(a.JobID, b.JobID))
+	})
+	items := make([]industryJobResponse, 0, len(jobs))
+	for _, j := range jobs {
+		items = append(items, newIndustryJobResponse(j))
+	}
+	writeJSON(w, items)
+}
+
+type notificationResponse struct {
+	NotificationID int64                     `json:"notification_id"`
+	Type           string                    `json:"type"`
+	Group          string                    `json:"group"`
+	SenderID       int64                     `json:"sender_id"`
+	SenderName     string                    `json:"sender_name"`
+	Timestamp      time.Time                 `json:"timestamp"`
+	Title          string                    `json:"title"`
+	Body           optional.Optional[string] `json:"body"`
+	IsRead         bool                      `json:"is_read"`
+}
+
+func (a *LocalAPI) listNotifications(w http.ResponseWriter, r *http.Request) {
+	characterID, ok := a.characterID(w, r)
+	if !ok {
+		return
+	}
+	notifications, err := a.cs.ListNotifications(r.Context(), characterID)
+	if err != nil {
+		writeServerError(w, r, err)
+		return
+	}
+	slices.SortFunc(notifications, func(a, b *app.CharacterNotification) int {
+		return cmp.Or(b.Timestamp.Compare(a.Timestamp), cmp.Compare(b.NotificationID, a.NotificationID))
+	})
+	items := make([]notificationResponse, 0, len(notifications))
+	for _, n := range notifications {
+		x := notificationResponse{
+			NotificationID: n.NotificationID,
+			Type:           n.Type.String(),
+			Group:          n.Type.Group().String(),
+			Timestamp:      n.Timestamp,
+			Title:          n.TitleDisplay(),
+			Body:           n.Body,
+			IsRead:         n.IsRead,
+		}
+		if n.Sender != nil {
+			x.SenderID = n.Sender.ID
+			x.SenderName = n.Sender.Name
+		}
+		items = append(items, x)
+	}
+	writeJSON(w, items)
+}
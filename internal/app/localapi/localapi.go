@@ -0,0 +1,151 @@
+// Package localapi provides a read-only HTTP API with character data
+// for third-party tools running on the same machine, e.g. overlays and scripts.
+//
+// The API only listens on localhost and all requests must provide
+// the access token as bearer token in the Authorization header.
+package localapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/characterservice"
+)
+
+const (
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 3 * time.Second
+)
+
+type Params struct {
+	CharacterService *characterservice.CharacterService
+	Token            string // access token required for all requests
+}
+
+// LocalAPI is a read-only HTTP API which serves data of the local characters as JSON.
+type LocalAPI struct {
+	cs    *characterservice.CharacterService
+	token string
+}
+
+// New returns a new LocalAPI.
+func New(arg Params) *LocalAPI {
+	if arg.CharacterService == nil {
+		panic("localapi: missing character service")
+	}
+	if arg.Token == "" {
+		panic("localapi: missing token")
+	}
+	a := &LocalAPI{
+		cs:    arg.CharacterService,
+		token: arg.Token,
+	}
+	return a
+}
+
+// Handler returns the HTTP handler for the API.
+func (a *LocalAPI) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/characters", a.listCharacters)
+	mux.HandleFunc("GET /api/v1/characters/{characterID}", a.getCharacter)
+	mux.HandleFunc("GET /api/v1/characters/{characterID}/assets", a.listAssets)
+	mux.HandleFunc("GET /api/v1/characters/{characterID}/industry-jobs", a.listIndustryJobs)
+	mux.HandleFunc("GET /api/v1/characters/{characterID}/notifications", a.listNotifications)
+	mux.HandleFunc("GET /api/v1/characters/{characterID}/skillqueue", a.listSkillqueue)
+	mux.HandleFunc("GET /api/v1/industry-jobs", a.listAllIndustryJobs)
+	mux.HandleFunc("GET /api/v1/wallets", a.listWallets)
+	return a.authenticate(mux)
+}
+
+// Start starts the API server on localhost at the given port.
+func (a *LocalAPI) Start(port int) (stop func(), err error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("local API: listen error: %w", err)
+	}
+	server := &http.Server{
+		Handler:           a.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	go func() {
+		slog.Info("Local API running", "port", port)
+		err := server.Serve(listener)
+		if errors.Is(err, http.ErrServerClosed) {
+			slog.Info("local API: closed")
+			return
+		}
+		slog.Error("local API: server stopped", "error", err)
+	}()
+	stop = func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = server.Shutdown(ctx)
+	}
+	return stop, nil
+}
+
+// authenticate rejects all requests without a valid token.
+func (a *LocalAPI) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="evebuddy"`)
+			writeError(w, http.StatusUnauthorized, "missing or invalid token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// characterID returns the ID of the character requested in the path
+// or writes an error response and returns false.
+func (a *LocalAPI) characterID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("characterID"), 10, 64)
+	if err != nil || id <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid character ID")
+		return 0, false
+	}
+	ids, err := a.cs.ListCharacterIDs(r.Context())
+	if err != nil {
+		writeServerError(w, r, err)
+		return 0, false
+	}
+	if !ids.Contains(id) {
+		writeError(w, http.StatusNotFound, "character not found")
+		return 0, false
+	}
+	return id, true
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Warn("local API: failed to write response", "error", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+func writeServerError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, app.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	slog.Error("local API: request failed", "path", r.URL.Path, "error", err)
+	writeError(w, http.StatusInternalServerError, "internal error")
+}
@@ -0,0 +1,211 @@
+package localapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app/characterservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/localapi"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil/testdouble"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+const token = "secret"
+
+func TestLocalAPI(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	a := localapi.New(localapi.Params{
+		CharacterService: testdouble.NewCharacterServiceFake(characterservice.Params{Storage: st}),
+		Token:            token,
+	})
+	srv := httptest.NewServer(a.Handler())
+	defer srv.Close()
+
+	get := func(t *testing.T, path, token string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+path, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			resp.Body.Close()
+		})
+		return resp
+	}
+	decode := func(t *testing.T, resp *http.Response) []map[string]any {
+		t.Helper()
+		var got []map[string]any
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		require.NoError(t, err)
+		return got
+	}
+
+	t.Run("should reject requests without token", func(t *testing.T) {
+		resp := get(t, "/api/v1/characters", "")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+	t.Run("should reject requests with invalid token", func(t *testing.T) {
+		resp := get(t, "/api/v1/characters", "invalid")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+	t.Run("should reject write requests", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, srv.URL+"/api/v1/characters", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+	t.Run("can list characters", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		// when
+		resp := get(t, "/api/v1/characters", token)
+		// then
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		got := decode(t, resp)
+		if assert.Len(t, got, 1) {
+			assert.EqualValues(t, c.ID, got[0]["id"])
+			assert.Equal(t, c.EveCharacter.Name, got[0]["name"])
+			assert.Equal(t, c.WalletBalance.ValueOrZero(), got[0]["wallet_balance"])
+		}
+	})
+	t.Run("can list wallet balances", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull(storage.CreateCharacterParams{
+			WalletBalance: optional.New(1234.5),
+		})
+		// when
+		resp := get(t, "/api/v1/wallets", token)
+		// then
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		got := decode(t, resp)
+		if assert.Len(t, got, 1) {
+			assert.EqualValues(t, c.ID, got[0]["character_id"])
+			assert.Equal(t, 1234.5, got[0]["balance"])
+		}
+	})
+	t.Run("can list skillqueue of a character", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		q := factory.CreateCharacterSkillqueueItem(storage.SkillqueueItemParams{CharacterID: c.ID})
+		factory.CreateCharacterSkillqueueItem() // other character
+		// when
+		resp := get(t, fmt.Sprintf("/api/v1/characters/%d/skillqueue", c.ID), token)
+		// then
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		got := decode(t, resp)
+		if assert.Len(t, got, 1) {
+			assert.EqualValues(t, q.SkillID, got[0]["skill_id"])
+		}
+	})
+	t.Run("can list assets of a character", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		x := factory.CreateCharacterAsset(storage.CreateCharacterAssetParams{CharacterID: c.ID})
+		// when
+		resp := get(t, fmt.Sprintf("/api/v1/characters/%d/assets", c.ID), token)
+		// then
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		got := decode(t, resp)
+		if assert.Len(t, got, 1) {
+			assert.EqualValues(t, x.ItemID, got[0]["item_id"])
+		}
+	})
+	t.Run("can list industry jobs", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		j1 := factory.CreateCharacterIndustryJob(storage.UpdateOrCreateCharacterIndustryJobParams{CharacterID: c.ID})
+		j2 := factory.CreateCharacterIndustryJob()
+		// when
+		resp := get(t, "/api/v1/industry-jobs", token)
+		// then
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		got := decode(t, resp)
+		var ids []int64
+		for _, x := range got {
+			ids = append(ids, int64(x["job_id"].(float64)))
+		}
+		assert.ElementsMatch(t, []int64{j1.JobID, j2.JobID}, ids)
+	})
+	t.Run("can list industry jobs of a character", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		j := factory.CreateCharacterIndustryJob(storage.UpdateOrCreateCharacterIndustryJobParams{CharacterID: c.ID})
+		factory.CreateCharacterIndustryJob()
+		// when
+		resp := get(t, fmt.Sprintf("/api/v1/characters/%d/industry-jobs", c.ID), token)
+		// then
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		got := decode(t, resp)
+		if assert.Len(t, got, 1) {
+			assert.EqualValues(t, j.JobID, got[0]["job_id"])
+		}
+	})
+	t.Run("can list notifications of a character", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		n := factory.CreateCharacterNotification(storage.CreateCharacterNotificationParams{CharacterID: c.ID})
+		// when
+		resp := get(t, fmt.Sprintf("/api/v1/characters/%d/notifications", c.ID), token)
+		// then
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		got := decode(t, resp)
+		if assert.Len(t, got, 1) {
+			assert.EqualValues(t, n.NotificationID, got[0]["notification_id"])
+			assert.Equal(t, n.Type.String(), got[0]["type"])
+		}
+	})
+	t.Run("should return 404 for unknown character", func(t *testing.T) {
+		testutil.MustTruncateTables(db)
+		resp := get(t, "/api/v1/characters/42/skillqueue", token)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+	t.Run("should return 400 for invalid character ID", func(t *testing.T) {
+		resp := get(t, "/api/v1/characters/abc/assets", token)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestLocalAPI_Start(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	c := factory.CreateCharacterFull()
+	a := localapi.New(localapi.Params{
+		CharacterService: testdouble.NewCharacterServiceFake(characterservice.Params{Storage: st}),
+		Token:            token,
+	})
+	const port = 30127
+	stop, err := a.Start(port)
+	require.NoError(t, err)
+	defer stop()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, fmt.Sprintf("http://127.0.0.1:%d/api/v1/characters/%d", port, c.ID), nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
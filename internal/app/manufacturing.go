@@ -0,0 +1,224 @@
+package app
+
+import (
+	"math"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+// SCCSurchargeRate is the rate of the SCC surcharge on the estimated item value of industry jobs.
+const SCCSurchargeRate = 0.04
+
+// ManufacturingStructure represents a kind of structure for manufacturing jobs.
+type ManufacturingStructure uint
+
+const (
+	StructureNPCStation ManufacturingStructure = iota
+	StructureRaitaru
+	StructureAzbel
+	StructureSotiyo
+)
+
+// ManufacturingStructures returns all kinds of structures for manufacturing jobs.
+func ManufacturingStructures() []ManufacturingStructure {
+	return []ManufacturingStructure{
+		StructureNPCStation,
+		StructureRaitaru,
+		StructureAzbel,
+		StructureSotiyo,
+	}
+}
+
+func (s ManufacturingStructure) String() string {
+	switch s {
+	case StructureNPCStation:
+		return "NPC Station"
+	case StructureRaitaru:
+		return "Raitaru"
+	case StructureAzbel:
+		return "Azbel"
+	case StructureSotiyo:
+		return "Sotiyo"
+	}
+	return "?"
+}
+
+// MaterialBonus returns the reduction of materials as fraction.
+func (s ManufacturingStructure) MaterialBonus() float64 {
+	if s == StructureNPCStation {
+		return 0
+	}
+	return 0.01
+}
+
+// CostBonus returns the reduction of the job gross cost as fraction.
+func (s ManufacturingStructure) CostBonus() float64 {
+	switch s {
+	case StructureRaitaru:
+		return 0.03
+	case StructureAzbel:
+		return 0.04
+	case StructureSotiyo:
+		return 0.05
+	}
+	return 0
+}
+
+// TimeBonus returns the reduction of the job duration as fraction.
+func (s ManufacturingStructure) TimeBonus() float64 {
+	switch s {
+	case StructureRaitaru:
+		return 0.15
+	case StructureAzbel:
+		return 0.2
+	case StructureSotiyo:
+		return 0.3
+	}
+	return 0
+}
+
+// CanHaveRigs reports whether rigs can be fitted to a structure.
+func (s ManufacturingStructure) CanHaveRigs() bool {
+	return s != StructureNPCStation
+}
+
+// ManufacturingRig represents a material efficiency rig of a structure.
+type ManufacturingRig uint
+
+const (
+	RigNone ManufacturingRig = iota
+	RigT1
+	RigT2
+)
+
+// ManufacturingRigs returns all material efficiency rigs.
+func ManufacturingRigs() []ManufacturingRig {
+	return []ManufacturingRig{RigNone, RigT1, RigT2}
+}
+
+func (r ManufacturingRig) String() string {
+	switch r {
+	case RigNone:
+		return "None"
+	case RigT1:
+		return "T1 ME Rig"
+	case RigT2:
+		return "T2 ME Rig"
+	}
+	return "?"
+}
+
+// MaterialBonus returns the reduction of materials as fraction in a system with the given security.
+func (r ManufacturingRig) MaterialBonus(security SolarSystemSecurityType) float64 {
+	var base float64
+	switch r {
+	case RigT1:
+		base = 0.02
+	case RigT2:
+		base = 0.024
+	default:
+		return 0
+	}
+	switch security {
+	case LowSec:
+		return base * 1.9
+	case NullSec:
+		return base * 2.1
+	}
+	return base
+}
+
+// ManufacturingMaterial is a material required for one run of a manufacturing job.
+type ManufacturingMaterial struct {
+	Quantity int64
+	TypeID   int64
+}
+
+// ManufacturingCostParams are the parameters for calculating the cost of a manufacturing job.
+type ManufacturingCostParams struct {
+	AdjustedPrices  map[int64]float64 // adjusted prices for calculating the estimated item value
+	CostIndex       float64           // manufacturing cost index of the solar system
+	FacilityTax     float64           // tax of the facility as fraction
+	Materials       []ManufacturingMaterial
+	ME              int               // material efficiency of the blueprint in percent
+	Prices          map[int64]float64 // prices for buying materials and selling the product
+	ProductQuantity int64             // quantity produced per run
+	ProductTypeID   int64
+	Rig             ManufacturingRig
+	Runs            int
+	Security        SolarSystemSecurityType
+	Structure       ManufacturingStructure
+	TE              int           // time efficiency of the blueprint in percent
+	Time            time.Duration // duration of one run without any bonuses
+}
+
+// ManufacturingMaterialCost is the cost of a material for a manufacturing job.
+type ManufacturingMaterialCost struct {
+	Price    optional.Optional[float64]
+	Quantity int64
+	Total    float64
+	TypeID   int64
+}
+
+// ManufacturingCost is the calculated cost of a manufacturing job.
+type ManufacturingCost struct {
+	Duration           time.Duration
+	EstimatedItemValue float64
+	InstallCost        float64
+	MaterialCost       float64
+	Materials          []ManufacturingMaterialCost
+	MissingPrices      set.Set[int64] // types without price, which are valued at zero
+	Revenue            float64
+	Profit             float64
+}
+
+// Margin returns the profit as fraction of the revenue
+// or an empty value when there is no revenue.
+func (c ManufacturingCost) Margin() optional.Optional[float64] {
+	if c.Revenue == 0 {
+		return optional.Optional[float64]{}
+	}
+	return optional.New(c.Profit / c.Revenue)
+}
+
+// CalculateManufacturingCost returns the cost and profit of a manufacturing job.
+func CalculateManufacturingCost(arg ManufacturingCostParams) ManufacturingCost {
+	var r ManufacturingCost
+	runs := int64(max(arg.Runs, 1))
+	structureBonus := arg.Structure.MaterialBonus()
+	var rigBonus float64
+	if arg.Structure.CanHaveRigs() {
+		rigBonus = arg.Rig.MaterialBonus(arg.Security)
+	}
+	for _, m := range arg.Materials {
+		r.EstimatedItemValue += float64(m.Quantity*runs) * arg.AdjustedPrices[m.TypeID]
+		q := float64(m.Quantity*runs) * (1 - float64(arg.ME)/100) * (1 - structureBonus) * (1 - rigBonus)
+		quantity := max(runs, int64(math.Ceil(math.Round(q*100)/100)))
+		mc := ManufacturingMaterialCost{
+			Quantity: quantity,
+			TypeID:   m.TypeID,
+		}
+		if price, ok := arg.Prices[m.TypeID]; ok {
+			mc.Price = optional.New(price)
+			mc.Total = price * float64(quantity)
+		} else {
+			r.MissingPrices.Add(m.TypeID)
+		}
+		r.MaterialCost += mc.Total
+		r.Materials = append(r.Materials, mc)
+	}
+	jobCost := r.EstimatedItemValue * arg.CostIndex * (1 - arg.Structure.CostBonus())
+	r.InstallCost = jobCost + r.EstimatedItemValue*(arg.FacilityTax+SCCSurchargeRate)
+	if price, ok := arg.Prices[arg.ProductTypeID]; ok {
+		r.Revenue = price * float64(arg.ProductQuantity*runs)
+	} else {
+		r.MissingPrices.Add(arg.ProductTypeID)
+	}
+	r.Profit = r.Revenue - r.MaterialCost - r.InstallCost
+	d := float64(arg.Time) * float64(runs) * (1 - float64(arg.TE)/100) * (1 - arg.Structure.TimeBonus())
+	r.Duration = time.Duration(d).Round(time.Second)
+	return r
+}
@@ -0,0 +1,102 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+func TestManufacturingRig_MaterialBonus(t *testing.T) {
+	assert.InDelta(t, 0.0, app.RigNone.MaterialBonus(app.HighSec), 0.00001)
+	assert.InDelta(t, 0.02, app.RigT1.MaterialBonus(app.HighSec), 0.00001)
+	assert.InDelta(t, 0.038, app.RigT1.MaterialBonus(app.LowSec), 0.00001)
+	assert.InDelta(t, 0.0504, app.RigT2.MaterialBonus(app.NullSec), 0.00001)
+}
+
+func TestCalculateManufacturingCost(t *testing.T) {
+	const (
+		tritanium = 34
+		pyerite   = 35
+		product   = 587
+	)
+	materials := []app.ManufacturingMaterial{
+		{TypeID: tritanium, Quantity: 1000},
+		{TypeID: pyerite, Quantity: 1},
+	}
+	t.Run("should calculate cost for NPC station", func(t *testing.T) {
+		got := app.CalculateManufacturingCost(app.ManufacturingCostParams{
+			AdjustedPrices:  map[int64]float64{tritanium: 4, pyerite: 10},
+			CostIndex:       0.05,
+			FacilityTax:     0.0025,
+			Materials:       materials,
+			ME:              10,
+			Prices:          map[int64]float64{tritanium: 5, pyerite: 12, product: 20000},
+			ProductQuantity: 1,
+			ProductTypeID:   product,
+			Rig:             app.RigT2, // ignored for NPC stations
+			Runs:            2,
+			Structure:       app.StructureNPCStation,
+		})
+		assert.Equal(t, []app.ManufacturingMaterialCost{
+			{TypeID: tritanium, Quantity: 1800, Price: optional.New(5.0), Total: 9000},
+			{TypeID: pyerite, Quantity: 2, Price: optional.New(12.0), Total: 24},
+		}, got.Materials)
+		assert.InDelta(t, 8020, got.EstimatedItemValue, 0.001)
+		assert.InDelta(t, 9024, got.MaterialCost, 0.001)
+		assert.InDelta(t, 8020*(0.05+0.0025+0.04), got.InstallCost, 0.001)
+		assert.InDelta(t, 40000, got.Revenue, 0.001)
+		assert.InDelta(t, 40000-9024-8020*(0.05+0.0025+0.04), got.Profit, 0.001)
+		assert.Equal(t, 0, got.MissingPrices.Size())
+		margin, ok := got.Margin().Value()
+		assert.True(t, ok)
+		assert.InDelta(t, got.Profit/got.Revenue, margin, 0.00001)
+	})
+	t.Run("should apply structure and rig bonuses", func(t *testing.T) {
+		got := app.CalculateManufacturingCost(app.ManufacturingCostParams{
+			AdjustedPrices:  map[int64]float64{tritanium: 4, pyerite: 10},
+			CostIndex:       0.1,
+			Materials:       materials,
+			ME:              10,
+			Prices:          map[int64]float64{tritanium: 5, pyerite: 12, product: 20000},
+			ProductQuantity: 1,
+			ProductTypeID:   product,
+			Rig:             app.RigT1,
+			Runs:            1,
+			Security:        app.HighSec,
+			Structure:       app.StructureRaitaru,
+		})
+		// 1000 * 0.9 * 0.99 * 0.98 = 873.18 => 874
+		assert.Equal(t, int64(874), got.Materials[0].Quantity)
+		assert.Equal(t, int64(1), got.Materials[1].Quantity)
+		assert.InDelta(t, 4010*(0.1*0.97+0.04), got.InstallCost, 0.001)
+	})
+	t.Run("should report missing prices", func(t *testing.T) {
+		got := app.CalculateManufacturingCost(app.ManufacturingCostParams{
+			Materials:       materials,
+			Prices:          map[int64]float64{tritanium: 5},
+			ProductQuantity: 1,
+			ProductTypeID:   product,
+			Runs:            1,
+		})
+		assert.True(t, got.MissingPrices.Contains(pyerite))
+		assert.True(t, got.MissingPrices.Contains(product))
+		assert.True(t, got.Margin().IsEmpty())
+	})
+	t.Run("should apply time efficiency and structure bonus to duration", func(t *testing.T) {
+		got := app.CalculateManufacturingCost(app.ManufacturingCostParams{
+			Materials:       materials,
+			ProductQuantity: 1,
+			ProductTypeID:   product,
+			Runs:            2,
+			Structure:       app.StructureRaitaru,
+			TE:              20,
+			Time:            time.Hour,
+		})
+		// 2 * 3600s * 0.8 * 0.85 = 4896s
+		assert.Equal(t, 4896*time.Second, got.Duration)
+	})
+}
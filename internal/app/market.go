@@ -3,6 +3,8 @@ package app
 import (
 	"time"
 
+	"github.com/ErikKalkoken/go-set"
+
 	"github.com/ErikKalkoken/evebuddy/internal/optional"
 )
 
@@ -52,3 +54,88 @@ type CharacterMarketOrder struct {
 	VolumeRemains int64
 	VolumeTotal   int64
 }
+
+// Expires returns when an order expires.
+func (o *CharacterMarketOrder) Expires() time.Time {
+	return o.Issued.Add(time.Duration(o.Duration) * time.Hour * 24)
+}
+
+// IsFilled reports whether an order has been completed by trades.
+// Completed orders are reported as expired by ESI.
+func (o *CharacterMarketOrder) IsFilled() bool {
+	return o.State == OrderExpired && o.VolumeRemains == 0
+}
+
+// UndercutPrice returns the best price of competing orders at the same location,
+// which beats the price of order o. Returns an empty value when o is not undercut.
+//
+// Competing sell orders undercut when they are cheaper and competing buy orders outbid when they pay more.
+// Orders with IDs in ignored are not considered, e.g. to ignore orders from own characters.
+// The range of buy orders is not taken into account.
+func (o *CharacterMarketOrder) UndercutPrice(orders []*EveMarketOrder, ignored set.Set[int64]) optional.Optional[float64] {
+	var best optional.Optional[float64]
+	isBuyOrder := o.IsBuyOrder.ValueOrZero()
+	for _, x := range orders {
+		if x.OrderID == o.OrderID || ignored.Contains(x.OrderID) {
+			continue
+		}
+		if x.IsBuyOrder != isBuyOrder || x.TypeID != o.Type.ID || x.LocationID != o.Location.ID {
+			continue
+		}
+		if isBuyOrder {
+			if x.Price > o.Price && x.Price > best.ValueOrZero() {
+				best = optional.New(x.Price)
+			}
+		} else {
+			if v, ok := best.Value(); x.Price < o.Price && (!ok || x.Price < v) {
+				best = optional.New(x.Price)
+			}
+		}
+	}
+	return best
+}
+
+// EveMarketOrder is an order on the regional market.
+type EveMarketOrder struct {
+	Duration     int64
+	IsBuyOrder   bool
+	Issued       time.Time
+	LocationID   int64
+	MinVolume    int64
+	OrderID      int64
+	Price        float64
+	Range        string
+	SystemID     int64
+	TypeID       int64
+	VolumeRemain int64
+	VolumeTotal  int64
+}
+
+// EveMarketHistory is the market history of a type in a region for one day.
+type EveMarketHistory struct {
+	Average    float64
+	Date       time.Time
+	Highest    float64
+	Lowest     float64
+	OrderCount int64
+	RegionID   int64
+	TypeID     int64
+	Volume     int64
+}
+
+// MarketHub is a major trade hub.
+type MarketHub struct {
+	Name     string
+	RegionID int64
+}
+
+// MarketHubs returns the major trade hubs. Jita is always the first.
+func MarketHubs() []MarketHub {
+	return []MarketHub{
+		{Name: "Jita", RegionID: 10000002},
+		{Name: "Amarr", RegionID: 10000043},
+		{Name: "Dodixie", RegionID: 10000032},
+		{Name: "Rens", RegionID: 10000030},
+		{Name: "Hek", RegionID: 10000042},
+	}
+}
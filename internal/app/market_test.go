@@ -0,0 +1,111 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+func TestCharacterMarketOrder_UndercutPrice(t *testing.T) {
+	const (
+		locationID = 60003760
+		typeID     = 34
+	)
+	makeOrder := func(isBuyOrder bool, price float64) *app.CharacterMarketOrder {
+		return &app.CharacterMarketOrder{
+			IsBuyOrder: optional.New(isBuyOrder),
+			Location:   &app.EveLocationShort{ID: locationID},
+			OrderID:    1,
+			Price:      price,
+			Type:       &app.EntityShort{ID: typeID},
+		}
+	}
+	makeCompeting := func(orderID int64, isBuyOrder bool, price float64) *app.EveMarketOrder {
+		return &app.EveMarketOrder{
+			IsBuyOrder: isBuyOrder,
+			LocationID: locationID,
+			OrderID:    orderID,
+			Price:      price,
+			TypeID:     typeID,
+		}
+	}
+	t.Run("should return lowest cheaper sell order", func(t *testing.T) {
+		o := makeOrder(false, 10)
+		orders := []*app.EveMarketOrder{
+			makeCompeting(1, false, 10),
+			makeCompeting(2, false, 9.5),
+			makeCompeting(3, false, 9),
+			makeCompeting(4, false, 11),
+			makeCompeting(5, true, 8),
+		}
+		got := o.UndercutPrice(orders, set.Of[int64]())
+		assert.Equal(t, optional.New(9.0), got)
+	})
+	t.Run("should return highest better buy order", func(t *testing.T) {
+		o := makeOrder(true, 10)
+		orders := []*app.EveMarketOrder{
+			makeCompeting(2, true, 10.5),
+			makeCompeting(3, true, 11),
+			makeCompeting(4, true, 9),
+			makeCompeting(5, false, 12),
+		}
+		got := o.UndercutPrice(orders, set.Of[int64]())
+		assert.Equal(t, optional.New(11.0), got)
+	})
+	t.Run("should return empty when not undercut", func(t *testing.T) {
+		o := makeOrder(false, 10)
+		orders := []*app.EveMarketOrder{
+			makeCompeting(1, false, 10),
+			makeCompeting(2, false, 10),
+			makeCompeting(3, false, 12),
+		}
+		got := o.UndercutPrice(orders, set.Of[int64]())
+		assert.True(t, got.IsEmpty())
+	})
+	t.Run("should ignore orders at other locations", func(t *testing.T) {
+		o := makeOrder(false, 10)
+		x := makeCompeting(2, false, 9)
+		x.LocationID = 42
+		got := o.UndercutPrice([]*app.EveMarketOrder{x}, set.Of[int64]())
+		assert.True(t, got.IsEmpty())
+	})
+	t.Run("should ignore orders from ignore list", func(t *testing.T) {
+		o := makeOrder(false, 10)
+		orders := []*app.EveMarketOrder{
+			makeCompeting(2, false, 9),
+		}
+		got := o.UndercutPrice(orders, set.Of[int64](2))
+		assert.True(t, got.IsEmpty())
+	})
+}
+
+func TestCharacterMarketOrder_Expires(t *testing.T) {
+	issued := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	o := &app.CharacterMarketOrder{Issued: issued, Duration: 90}
+	assert.Equal(t, issued.Add(90*24*time.Hour), o.Expires())
+}
+
+func TestCharacterMarketOrder_IsFilled(t *testing.T) {
+	cases := []struct {
+		name          string
+		state         app.MarketOrderState
+		volumeRemains int64
+		want          bool
+	}{
+		{"expired without remaining volume", app.OrderExpired, 0, true},
+		{"expired with remaining volume", app.OrderExpired, 5, false},
+		{"open", app.OrderOpen, 0, false},
+		{"cancelled", app.OrderCancelled, 0, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := &app.CharacterMarketOrder{State: tc.state, VolumeRemains: tc.volumeRemains}
+			assert.Equal(t, tc.want, o.IsFilled())
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+// CharacterMiningLedgerEntry represents the ore a character has mined
+// on a specific day in a solar system.
+type CharacterMiningLedgerEntry struct {
+	CharacterID int64
+	Date        time.Time
+	Price       optional.Optional[float64] // average market price per unit
+	Quantity    int64
+	SolarSystem *EveSolarSystem
+	Type        *EveType
+}
+
+// Value returns the estimated value of the mined ore.
+func (e CharacterMiningLedgerEntry) Value() optional.Optional[float64] {
+	return miningValue(e.Price, e.Quantity)
+}
+
+// SummaryItem returns the entry as item for a mining summary.
+func (e CharacterMiningLedgerEntry) SummaryItem() MiningSummaryItem {
+	return MiningSummaryItem{
+		Date:     e.Date,
+		Price:    e.Price,
+		Quantity: e.Quantity,
+		Type:     e.Type,
+	}
+}
+
+// CorporationMiningObserver represents a structure which records moon mining for a corporation,
+// e.g. a refinery with a moon drill.
+type CorporationMiningObserver struct {
+	CorporationID int64
+	LastUpdated   time.Time
+	ObserverID    int64
+	ObserverType  string
+	StructureName optional.Optional[string]
+}
+
+// CorporationMiningObserverEntry represents the ore a character has mined
+// at a mining observer of a corporation.
+type CorporationMiningObserverEntry struct {
+	Character             *EveEntity
+	CorporationID         int64
+	LastUpdated           time.Time
+	ObserverID            int64
+	Price                 optional.Optional[float64] // average market price per unit
+	Quantity              int64
+	RecordedCorporationID int64
+	StructureName         optional.Optional[string]
+	Type                  *EveType
+}
+
+// Value returns the estimated value of the mined ore.
+func (e CorporationMiningObserverEntry) Value() optional.Optional[float64] {
+	return miningValue(e.Price, e.Quantity)
+}
+
+// SummaryItem returns the entry as item for a mining summary.
+func (e CorporationMiningObserverEntry) SummaryItem() MiningSummaryItem {
+	return MiningSummaryItem{
+		Date:     e.LastUpdated,
+		Price:    e.Price,
+		Quantity: e.Quantity,
+		Type:     e.Type,
+	}
+}
+
+// MiningSummaryItem represents the total quantity of an ore mined on a day.
+type MiningSummaryItem struct {
+	Date     time.Time
+	Price    optional.Optional[float64] // average market price per unit
+	Quantity int64
+	Type     *EveType
+}
+
+// Value returns the estimated value of the mined ore.
+func (x MiningSummaryItem) Value() optional.Optional[float64] {
+	return miningValue(x.Price, x.Quantity)
+}
+
+// SummarizeMining returns the total mined quantity per day and ore type.
+// The result is ordered by date descending and type name.
+func SummarizeMining(items []MiningSummaryItem) []MiningSummaryItem {
+	type key struct {
+		date   time.Time
+		typeID int64
+	}
+	m := make(map[key]MiningSummaryItem)
+	for _, x := range items {
+		if x.Type == nil {
+			continue
+		}
+		k := key{
+			date:   time.Date(x.Date.Year(), x.Date.Month(), x.Date.Day(), 0, 0, 0, 0, time.UTC),
+			typeID: x.Type.ID,
+		}
+		y, ok := m[k]
+		if !ok {
+			y = MiningSummaryItem{
+				Date:  k.date,
+				Price: x.Price,
+				Type:  x.Type,
+			}
+		}
+		y.Quantity += x.Quantity
+		m[k] = y
+	}
+	var summary []MiningSummaryItem
+	for _, x := range m {
+		summary = append(summary, x)
+	}
+	slices.SortFunc(summary, func(a, b MiningSummaryItem) int {
+		return cmp.Or(
+			b.Date.Compare(a.Date),
+			strings.Compare(a.Type.Name, b.Type.Name),
+		)
+	})
+	return summary
+}
+
+func miningValue(price optional.Optional[float64], quantity int64) optional.Optional[float64] {
+	p, ok := price.Value()
+	if !ok {
+		return optional.Optional[float64]{}
+	}
+	return optional.New(p * float64(quantity))
+}
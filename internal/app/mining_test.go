@@ -0,0 +1,44 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+func TestSummarizeMining(t *testing.T) {
+	veldspar := &app.EveType{ID: 1230, Name: "Veldspar"}
+	scordite := &app.EveType{ID: 1228, Name: "Scordite"}
+	day1 := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2025, 10, 2, 0, 0, 0, 0, time.UTC)
+	t.Run("should sum quantities per day and type", func(t *testing.T) {
+		items := []app.MiningSummaryItem{
+			{Date: day1, Quantity: 100, Type: veldspar, Price: optional.New(2.0)},
+			{Date: day1.Add(3 * time.Hour), Quantity: 50, Type: veldspar, Price: optional.New(2.0)},
+			{Date: day1, Quantity: 10, Type: scordite},
+			{Date: day2, Quantity: 20, Type: veldspar, Price: optional.New(2.0)},
+		}
+		got := app.SummarizeMining(items)
+		want := []app.MiningSummaryItem{
+			{Date: day2, Quantity: 20, Type: veldspar, Price: optional.New(2.0)},
+			{Date: day1, Quantity: 10, Type: scordite},
+			{Date: day1, Quantity: 150, Type: veldspar, Price: optional.New(2.0)},
+		}
+		assert.Equal(t, want, got)
+		assert.Equal(t, optional.New(300.0), got[2].Value())
+		assert.True(t, got[1].Value().IsEmpty())
+	})
+	t.Run("should return empty summary when there are no items", func(t *testing.T) {
+		got := app.SummarizeMining([]app.MiningSummaryItem{})
+		assert.Len(t, got, 0)
+	})
+}
+
+func TestCharacterMiningLedgerEntry_Value(t *testing.T) {
+	x := app.CharacterMiningLedgerEntry{Quantity: 3, Price: optional.New(1.5)}
+	assert.Equal(t, optional.New(4.5), x.Value())
+}
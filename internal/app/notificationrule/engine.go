@@ -0,0 +1,239 @@
+package notificationrule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationsink"
+)
+
+const (
+	cacheKeyDigest  = "digest"
+	digestLinesMax  = 20
+	digestTimeout   = 7 * 24 * time.Hour
+	escalatedPrefix = "URGENT: "
+)
+
+// Settings provides the configuration for rules and quiet hours.
+type Settings interface {
+	NotificationRules() string
+	NotifyQuietHoursEnabled() bool
+	NotifyQuietHoursEnd() int
+	NotifyQuietHoursStart() int
+}
+
+// Storage provides the characters and tags notifications are matched against.
+type Storage interface {
+	ListCharacterIDsForCorporation(ctx context.Context, corporationID int64) (set.Set[int64], error)
+	ListCharacterTagsForCharacter(ctx context.Context, characterID int64) ([]*app.CharacterTag, error)
+}
+
+// Cache stores the notifications held back for the digest.
+type Cache interface {
+	Delete(string)
+	GetString(string) (string, bool)
+	SetString(string, string, time.Duration)
+}
+
+type Params struct {
+	Cache    Cache
+	Settings Settings
+	Storage  Storage
+	// optional
+	Now func() time.Time
+}
+
+// Engine applies rules and quiet hours to notifications.
+// The configuration is read from the settings for every notification,
+// so that changes take effect immediately.
+type Engine struct {
+	cache    Cache
+	mu       sync.Mutex // protects the digest
+	now      func() time.Time
+	settings Settings
+	st       Storage
+}
+
+// New returns a new engine.
+func New(arg Params) *Engine {
+	if arg.Cache == nil || arg.Settings == nil || arg.Storage == nil {
+		panic("notificationrule: missing parameters")
+	}
+	e := &Engine{
+		cache:    arg.Cache,
+		now:      time.Now,
+		settings: arg.Settings,
+		st:       arg.Storage,
+	}
+	if arg.Now != nil {
+		e.now = arg.Now
+	}
+	return e
+}
+
+// Apply applies the rules and quiet hours to a notification.
+// It returns the notification to deliver and reports whether it should be delivered now.
+//
+// Escalated notifications are always delivered.
+// Muted notifications are dropped and notifications during quiet hours are added to the digest.
+func (e *Engine) Apply(ctx context.Context, n notificationsink.Notification) (notificationsink.Notification, bool) {
+	rules, err := ParseRules(e.settings.NotificationRules())
+	if err != nil {
+		slog.Warn("Invalid notification rules. Using defaults", "error", err)
+		rules = DefaultRules()
+	}
+	var characterIDs, tagIDs set.Set[int64]
+	if slices.ContainsFunc(rules, Rule.needsCharacters) {
+		characterIDs, tagIDs = e.resolveCharacters(ctx, n)
+	}
+	var isMuted bool
+	for _, r := range rules {
+		if !r.Matches(n.Type, characterIDs, tagIDs) {
+			continue
+		}
+		switch r.Action {
+		case ActionEscalate:
+			n.Title = escalatedPrefix + n.Title
+			return n, true
+		case ActionMute:
+			isMuted = true
+		}
+	}
+	if isMuted {
+		slog.Info("Notification muted by rule", "type", n.Type, "title", n.Title)
+		return n, false
+	}
+	if e.isQuietHours() {
+		e.addToDigest(n)
+		slog.Info("Notification held back for digest", "type", n.Type, "title", n.Title)
+		return n, false
+	}
+	return n, true
+}
+
+// resolveCharacters returns the IDs of the characters a notification is about and their tags.
+// Errors are logged only, so that notifications are still delivered.
+func (e *Engine) resolveCharacters(ctx context.Context, n notificationsink.Notification) (set.Set[int64], set.Set[int64]) {
+	var characterIDs, tagIDs set.Set[int64]
+	if n.CharacterID != 0 {
+		characterIDs.Add(n.CharacterID)
+	}
+	if n.CorporationID != 0 {
+		ids, err := e.st.ListCharacterIDsForCorporation(ctx, n.CorporationID)
+		if err != nil {
+			slog.Warn("Failed to resolve characters for notification", "corporationID", n.CorporationID, "error", err)
+		} else {
+			characterIDs.AddSeq(ids.All())
+		}
+	}
+	for id := range characterIDs.All() {
+		tags, err := e.st.ListCharacterTagsForCharacter(ctx, id)
+		if err != nil {
+			slog.Warn("Failed to resolve tags for notification", "characterID", id, "error", err)
+			continue
+		}
+		for _, t := range tags {
+			tagIDs.Add(t.ID)
+		}
+	}
+	return characterIDs, tagIDs
+}
+
+func (e *Engine) isQuietHours() bool {
+	if !e.settings.NotifyQuietHoursEnabled() {
+		return false
+	}
+	return IsQuietHour(e.now().Hour(), e.settings.NotifyQuietHoursStart(), e.settings.NotifyQuietHoursEnd())
+}
+
+// IsQuietHour reports whether an hour of the day is within the quiet hours from start to end.
+// Quiet hours can span midnight, e.g. from 22 to 7.
+func IsQuietHour(hour, start, end int) bool {
+	if start == end {
+		return false
+	}
+	if start < end {
+		return hour >= start && hour < end
+	}
+	return hour >= start || hour < end
+}
+
+type digestEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Title     string    `json:"title"`
+	Type      string    `json:"type"`
+}
+
+func (e *Engine) addToDigest(n notificationsink.Notification) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	entries := e.loadDigest()
+	entries = append(entries, digestEntry{
+		Timestamp: n.Timestamp,
+		Title:     n.Title,
+		Type:      n.Type,
+	})
+	b, err := json.Marshal(entries)
+	if err != nil {
+		slog.Error("Failed to store notification for digest", "title", n.Title, "error", err)
+		return
+	}
+	e.cache.SetString(cacheKeyDigest, string(b), digestTimeout)
+}
+
+func (e *Engine) loadDigest() []digestEntry {
+	s, ok := e.cache.GetString(cacheKeyDigest)
+	if !ok {
+		return nil
+	}
+	var entries []digestEntry
+	if err := json.Unmarshal([]byte(s), &entries); err != nil {
+		slog.Warn("Discarding invalid notification digest", "error", err)
+		return nil
+	}
+	return entries
+}
+
+// TakeDigest returns a notification summarizing all notifications held back during quiet hours
+// and clears them.
+// Reports false while quiet hours are ongoing or when no notifications were held back.
+func (e *Engine) TakeDigest() (notificationsink.Notification, bool) {
+	if e.isQuietHours() {
+		return notificationsink.Notification{}, false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	entries := e.loadDigest()
+	if len(entries) == 0 {
+		return notificationsink.Notification{}, false
+	}
+	e.cache.Delete(cacheKeyDigest)
+	slices.SortStableFunc(entries, func(a, b digestEntry) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+	var lines []string
+	for i, x := range entries {
+		if i == digestLinesMax {
+			lines = append(lines, fmt.Sprintf("... and %d more", len(entries)-digestLinesMax))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", x.Timestamp.Local().Format("15:04"), x.Title))
+	}
+	n := notificationsink.Notification{
+		Type:      notificationsink.TypeDigest,
+		Title:     fmt.Sprintf("%d notification(s) during quiet hours", len(entries)),
+		Content:   strings.Join(lines, "\n"),
+		Timestamp: e.now(),
+	}
+	slog.Info("Sending notification digest", "count", len(entries))
+	return n, true
+}
@@ -0,0 +1,178 @@
+package notificationrule_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationrule"
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationsink"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+)
+
+type settingsFake struct {
+	quietEnabled bool
+	quietEnd     int
+	quietStart   int
+	rules        []notificationrule.Rule
+}
+
+func (s *settingsFake) NotificationRules() string {
+	if s.rules == nil {
+		return ""
+	}
+	v, _ := notificationrule.FormatRules(s.rules)
+	return v
+}
+
+func (s *settingsFake) NotifyQuietHoursEnabled() bool {
+	return s.quietEnabled
+}
+
+func (s *settingsFake) NotifyQuietHoursEnd() int {
+	return s.quietEnd
+}
+
+func (s *settingsFake) NotifyQuietHoursStart() int {
+	return s.quietStart
+}
+
+type storageFake struct {
+	corporations map[int64]set.Set[int64]
+	tags         map[int64][]*app.CharacterTag
+}
+
+func (s *storageFake) ListCharacterIDsForCorporation(_ context.Context, corporationID int64) (set.Set[int64], error) {
+	return s.corporations[corporationID], nil
+}
+
+func (s *storageFake) ListCharacterTagsForCharacter(_ context.Context, characterID int64) ([]*app.CharacterTag, error) {
+	return s.tags[characterID], nil
+}
+
+func TestEngine_Apply(t *testing.T) {
+	ctx := context.Background()
+	st := &storageFake{
+		corporations: map[int64]set.Set[int64]{2001: set.Of[int64](1002)},
+		tags:         map[int64][]*app.CharacterTag{1002: {{ID: 7, Name: "Alts"}}},
+	}
+	noon := time.Date(2025, 6, 1, 12, 0, 0, 0, time.Local)
+	midnight := time.Date(2025, 6, 1, 0, 30, 0, 0, time.Local)
+	muteAlts := notificationrule.Rule{Action: notificationrule.ActionMute, Enabled: true, TagIDs: []int64{7}}
+	cases := []struct {
+		name      string
+		settings  *settingsFake
+		now       time.Time
+		n         notificationsink.Notification
+		wantOK    bool
+		wantTitle string
+	}{
+		{
+			"deliver without matching rules",
+			&settingsFake{rules: []notificationrule.Rule{}},
+			noon,
+			notificationsink.Notification{Type: "Mail", Title: "title", CharacterID: 1001},
+			true,
+			"title",
+		},
+		{
+			"mute character by tag",
+			&settingsFake{rules: []notificationrule.Rule{muteAlts}},
+			noon,
+			notificationsink.Notification{Type: "Mail", Title: "title", CharacterID: 1002},
+			false,
+			"title",
+		},
+		{
+			"mute corporation by tag of member",
+			&settingsFake{rules: []notificationrule.Rule{muteAlts}},
+			noon,
+			notificationsink.Notification{Type: "StructureFuelRunningLow", Title: "title", CorporationID: 2001},
+			false,
+			"title",
+		},
+		{
+			"hold back during quiet hours",
+			&settingsFake{quietEnabled: true, quietStart: 22, quietEnd: 7},
+			midnight,
+			notificationsink.Notification{Type: "Mail", Title: "title", CharacterID: 1001},
+			false,
+			"title",
+		},
+		{
+			"escalate default types during quiet hours",
+			&settingsFake{quietEnabled: true, quietStart: 22, quietEnd: 7},
+			midnight,
+			notificationsink.Notification{Type: "StructureUnderAttack", Title: "title", CharacterID: 1002},
+			true,
+			"URGENT: title",
+		},
+		{
+			"escalation overrides mute",
+			&settingsFake{rules: slices.Concat([]notificationrule.Rule{muteAlts}, notificationrule.DefaultRules())},
+			noon,
+			notificationsink.Notification{Type: "StructureUnderAttack", Title: "title", CharacterID: 1002},
+			true,
+			"URGENT: title",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := notificationrule.New(notificationrule.Params{
+				Cache:    testutil.NewCacheFake2(),
+				Settings: tc.settings,
+				Storage:  st,
+				Now: func() time.Time {
+					return tc.now
+				},
+			})
+			got, ok := e.Apply(ctx, tc.n)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantTitle, got.Title)
+		})
+	}
+}
+
+func TestEngine_TakeDigest(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 23, 0, 0, 0, time.Local)
+	settings := &settingsFake{quietEnabled: true, quietStart: 22, quietEnd: 7}
+	e := notificationrule.New(notificationrule.Params{
+		Cache:    testutil.NewCacheFake2(),
+		Settings: settings,
+		Storage:  &storageFake{},
+		Now: func() time.Time {
+			return now
+		},
+	})
+	for _, title := range []string{"second", "first"} {
+		ts := now.Add(-time.Minute)
+		if title == "second" {
+			ts = now
+		}
+		_, ok := e.Apply(ctx, notificationsink.Notification{Type: "Mail", Title: title, Timestamp: ts})
+		assert.False(t, ok)
+	}
+	t.Run("should not send digest during quiet hours", func(t *testing.T) {
+		_, ok := e.TakeDigest()
+		assert.False(t, ok)
+	})
+	t.Run("should send digest after quiet hours", func(t *testing.T) {
+		now = time.Date(2025, 6, 2, 7, 0, 0, 0, time.Local)
+		got, ok := e.TakeDigest()
+		if assert.True(t, ok) {
+			assert.Equal(t, notificationsink.TypeDigest, got.Type)
+			assert.Equal(t, "2 notification(s) during quiet hours", got.Title)
+			assert.Equal(t, "22:59 first\n23:00 second", got.Content)
+		}
+	})
+	t.Run("should send digest only once", func(t *testing.T) {
+		_, ok := e.TakeDigest()
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,123 @@
+// Package notificationrule decides how notifications are delivered
+// based on rules defined by the user and quiet hours.
+//
+// Rules can mute notifications for some characters, tags or types
+// and escalate important notifications, e.g. when a structure is under attack.
+// Notifications during quiet hours are held back
+// and delivered as one digest when the quiet hours are over.
+package notificationrule
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+)
+
+// Action is what happens to notifications matching a rule.
+type Action string
+
+const (
+	ActionMute     Action = "mute"     // notifications are not delivered
+	ActionEscalate Action = "escalate" // notifications are delivered even during quiet hours and marked as urgent
+)
+
+// Actions returns all actions.
+func Actions() []Action {
+	return []Action{ActionMute, ActionEscalate}
+}
+
+// Display returns a user friendly name for an action.
+func (a Action) Display() string {
+	switch a {
+	case ActionMute:
+		return "Mute"
+	case ActionEscalate:
+		return "Escalate"
+	}
+	return string(a)
+}
+
+// Rule defines how matching notifications are delivered.
+//
+// A rule matches notifications of the given types about the given characters
+// or characters with the given tags. Empty filters match all notifications.
+type Rule struct {
+	Action       Action   `json:"action"`
+	CharacterIDs []int64  `json:"character_ids,omitempty"`
+	Enabled      bool     `json:"enabled"`
+	Name         string   `json:"name"`
+	TagIDs       []int64  `json:"tag_ids,omitempty"`
+	Types        []string `json:"types,omitempty"` // e.g. "StructureUnderAttack" or [notificationsink.TypeMail]
+}
+
+// Matches reports whether a rule matches a notification of a type
+// about the given characters, which have the given tags.
+func (r Rule) Matches(typ string, characterIDs, tagIDs set.Set[int64]) bool {
+	if !r.Enabled {
+		return false
+	}
+	if len(r.Types) > 0 && !slices.Contains(r.Types, typ) {
+		return false
+	}
+	if len(r.CharacterIDs) == 0 && len(r.TagIDs) == 0 {
+		return true
+	}
+	for _, id := range r.CharacterIDs {
+		if characterIDs.Contains(id) {
+			return true
+		}
+	}
+	for _, id := range r.TagIDs {
+		if tagIDs.Contains(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) needsCharacters() bool {
+	return r.Enabled && (len(r.CharacterIDs) > 0 || len(r.TagIDs) > 0)
+}
+
+// DefaultRules returns the rules which apply when the user has not configured any rules.
+func DefaultRules() []Rule {
+	return []Rule{{
+		Action:  ActionEscalate,
+		Enabled: true,
+		Name:    "Structures under attack",
+		Types: []string{
+			app.StructureLostArmor.String(),
+			app.StructureLostShields.String(),
+			app.StructureUnderAttack.String(),
+			app.TowerAlertMsg.String(),
+		},
+	}}
+}
+
+// ParseRules returns the rules from their JSON encoding.
+// Returns the default rules for an empty string.
+func ParseRules(s string) ([]Rule, error) {
+	if s == "" {
+		return DefaultRules(), nil
+	}
+	var rules []Rule
+	if err := json.Unmarshal([]byte(s), &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// FormatRules returns the JSON encoding of rules.
+func FormatRules(rules []Rule) (string, error) {
+	if rules == nil {
+		rules = []Rule{}
+	}
+	b, err := json.Marshal(rules)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
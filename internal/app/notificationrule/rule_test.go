@@ -0,0 +1,97 @@
+package notificationrule_test
+
+import (
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationrule"
+)
+
+func TestRule_Matches(t *testing.T) {
+	cases := []struct {
+		name         string
+		rule         notificationrule.Rule
+		typ          string
+		characterIDs set.Set[int64]
+		tagIDs       set.Set[int64]
+		want         bool
+	}{
+		{"empty rule matches all", notificationrule.Rule{Enabled: true}, "Mail", set.Of[int64](1), set.Of[int64](), true},
+		{"disabled rule matches nothing", notificationrule.Rule{}, "Mail", set.Of[int64](1), set.Of[int64](), false},
+		{"type matches", notificationrule.Rule{Enabled: true, Types: []string{"Mail"}}, "Mail", set.Of[int64](), set.Of[int64](), true},
+		{"type does not match", notificationrule.Rule{Enabled: true, Types: []string{"Mail"}}, "Contract", set.Of[int64](), set.Of[int64](), false},
+		{"character matches", notificationrule.Rule{Enabled: true, CharacterIDs: []int64{1, 2}}, "Mail", set.Of[int64](2), set.Of[int64](), true},
+		{"character does not match", notificationrule.Rule{Enabled: true, CharacterIDs: []int64{1}}, "Mail", set.Of[int64](2), set.Of[int64](), false},
+		{"tag matches", notificationrule.Rule{Enabled: true, TagIDs: []int64{7}}, "Mail", set.Of[int64](2), set.Of[int64](7), true},
+		{"either character or tag matches", notificationrule.Rule{Enabled: true, CharacterIDs: []int64{1}, TagIDs: []int64{7}}, "Mail", set.Of[int64](1), set.Of[int64](), true},
+		{"no character for filter", notificationrule.Rule{Enabled: true, TagIDs: []int64{7}}, "Mail", set.Of[int64](), set.Of[int64](), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.rule.Matches(tc.typ, tc.characterIDs, tc.tagIDs))
+		})
+	}
+}
+
+func TestParseAndFormatRules(t *testing.T) {
+	t.Run("should return defaults for empty string", func(t *testing.T) {
+		got, err := notificationrule.ParseRules("")
+		if assert.NoError(t, err) {
+			assert.Equal(t, notificationrule.DefaultRules(), got)
+		}
+	})
+	t.Run("can encode and decode rules", func(t *testing.T) {
+		rules := []notificationrule.Rule{{
+			Action:       notificationrule.ActionMute,
+			CharacterIDs: []int64{1, 2},
+			Enabled:      true,
+			Name:         "Alts",
+			TagIDs:       []int64{3},
+			Types:        []string{"Mail"},
+		}}
+		s, err := notificationrule.FormatRules(rules)
+		if !assert.NoError(t, err) {
+			t.Fatal()
+		}
+		got, err := notificationrule.ParseRules(s)
+		if assert.NoError(t, err) {
+			assert.Equal(t, rules, got)
+		}
+	})
+	t.Run("should keep empty rules", func(t *testing.T) {
+		s, err := notificationrule.FormatRules(nil)
+		if !assert.NoError(t, err) {
+			t.Fatal()
+		}
+		got, err := notificationrule.ParseRules(s)
+		if assert.NoError(t, err) {
+			assert.Len(t, got, 0)
+		}
+	})
+	t.Run("should return error for invalid rules", func(t *testing.T) {
+		_, err := notificationrule.ParseRules("invalid")
+		assert.Error(t, err)
+	})
+}
+
+func TestIsQuietHour(t *testing.T) {
+	cases := []struct {
+		hour, start, end int
+		want             bool
+	}{
+		{23, 22, 7, true},
+		{0, 22, 7, true},
+		{6, 22, 7, true},
+		{7, 22, 7, false},
+		{12, 22, 7, false},
+		{13, 12, 14, true},
+		{14, 12, 14, false},
+		{11, 12, 14, false},
+		{5, 5, 5, false},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, notificationrule.IsQuietHour(tc.hour, tc.start, tc.end), "%+v", tc)
+	}
+}
@@ -0,0 +1,89 @@
+package notificationsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+var (
+	ErrQueueClosed = errors.New("queue closed")
+	ErrQueueFull   = errors.New("queue full")
+)
+
+// Queue is a sink which delivers notifications to another sink in the background.
+// This ensures that slow or failing sinks, e.g. a webhook retrying requests,
+// do not delay the callers, which are usually updating data from ESI.
+//
+// The queue is bounded and notifications are dropped when it is full.
+type Queue struct {
+	cancel context.CancelFunc
+	ctx    context.Context
+	done   chan struct{}
+	queue  chan Notification
+	sink   Sink
+
+	mu       sync.RWMutex
+	isClosed bool
+}
+
+// NewQueue returns a new queue for a sink, which can hold up to size notifications,
+// and starts delivering notifications in the background.
+func NewQueue(sink Sink, size int) *Queue {
+	if sink == nil {
+		panic("notificationsink: missing sink")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &Queue{
+		cancel: cancel,
+		ctx:    ctx,
+		done:   make(chan struct{}),
+		queue:  make(chan Notification, size),
+		sink:   sink,
+	}
+	go q.run()
+	return q
+}
+
+func (q *Queue) run() {
+	defer close(q.done)
+	for n := range q.queue {
+		if q.ctx.Err() != nil {
+			continue // drop remaining notifications after close
+		}
+		if err := q.sink.Send(q.ctx, n); err != nil {
+			slog.Warn("Failed to deliver notification", "type", n.Type, "title", n.Title, "error", err)
+		}
+	}
+}
+
+// Send adds a notification to the queue and returns without waiting for it to be delivered.
+// It returns [ErrQueueFull] when the notification had to be dropped.
+func (q *Queue) Send(_ context.Context, n Notification) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.isClosed {
+		return fmt.Errorf("notificationsink: %w", ErrQueueClosed)
+	}
+	select {
+	case q.queue <- n:
+		return nil
+	default:
+		return fmt.Errorf("notificationsink: %w", ErrQueueFull)
+	}
+}
+
+// Close stops the queue and waits for the background delivery to finish.
+// Notifications which have not been delivered yet are dropped.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	if !q.isClosed {
+		q.isClosed = true
+		q.cancel()
+		close(q.queue)
+	}
+	q.mu.Unlock()
+	<-q.done
+}
@@ -0,0 +1,70 @@
+package notificationsink_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationsink"
+)
+
+// blockingSinkFake is a sink which blocks every send until it is released.
+type blockingSinkFake struct {
+	release chan struct{}
+	sent    chan notificationsink.Notification
+}
+
+func newBlockingSinkFake() *blockingSinkFake {
+	return &blockingSinkFake{
+		release: make(chan struct{}),
+		sent:    make(chan notificationsink.Notification, 10),
+	}
+}
+
+func (s *blockingSinkFake) Send(ctx context.Context, n notificationsink.Notification) error {
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	s.sent <- n
+	return nil
+}
+
+func TestQueue(t *testing.T) {
+	t.Run("should deliver notifications in the background", func(t *testing.T) {
+		s := newBlockingSinkFake()
+		q := notificationsink.NewQueue(s, 10)
+		defer q.Close()
+		n := notificationsink.Notification{Type: notificationsink.TypeMail, Title: "title"}
+		err := q.Send(t.Context(), n)
+		require.NoError(t, err)
+		assert.Empty(t, s.sent) // sink is still blocked
+		close(s.release)
+		select {
+		case got := <-s.sent:
+			assert.Equal(t, n, got)
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	})
+	t.Run("should drop notifications when queue is full", func(t *testing.T) {
+		s := newBlockingSinkFake()
+		q := notificationsink.NewQueue(s, 1)
+		defer q.Close()
+		var err error
+		for range 3 { // first one might already be picked up by the sink
+			err = q.Send(t.Context(), notificationsink.Notification{Type: notificationsink.TypeMail})
+		}
+		assert.ErrorIs(t, err, notificationsink.ErrQueueFull)
+	})
+	t.Run("should reject notifications after close", func(t *testing.T) {
+		q := notificationsink.NewQueue(&sinkFake{}, 10)
+		q.Close()
+		err := q.Send(t.Context(), notificationsink.Notification{Type: notificationsink.TypeMail})
+		assert.ErrorIs(t, err, notificationsink.ErrQueueClosed)
+	})
+}
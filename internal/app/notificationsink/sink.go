@@ -0,0 +1,121 @@
+// Package notificationsink provides destinations for notifications in addition to the desktop,
+// e.g. webhooks for Discord or Slack.
+package notificationsink
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Types of notifications which are generated by the app itself.
+// Notifications from the game server have the name of their [app.EveNotificationType] as type.
+const (
+	TypeCalendarEvent     = "CalendarEvent"
+	TypeContract          = "Contract"
+	TypeDigest            = "Digest"
+	TypeExpiredExtraction = "ExpiredExtraction"
+	TypeExpiredTraining   = "ExpiredTraining"
+	TypeIndustryJobReady  = "IndustryJobReady"
+	TypeJumpFatigue       = "JumpFatigue"
+	TypeMail              = "Mail"
+	TypeMarketOrderClosed = "MarketOrderClosed"
+	TypeMarketUndercut    = "MarketUndercut"
+	TypeStructureFuel     = "StructureFuelRunningLow"
+	TypeStructureService  = "StructureServiceWentOffline"
+	TypeStructureTimer    = "StructureTimerEnding"
+	TypeTest              = "Test"
+)
+
+// AppTypes returns the types of notifications generated by the app itself.
+func AppTypes() []string {
+	return []string{
+		TypeCalendarEvent,
+		TypeContract,
+		TypeDigest,
+		TypeExpiredExtraction,
+		TypeExpiredTraining,
+		TypeIndustryJobReady,
+		TypeJumpFatigue,
+		TypeMail,
+		TypeMarketOrderClosed,
+		TypeMarketUndercut,
+		TypeStructureFuel,
+		TypeStructureService,
+		TypeStructureTimer,
+	}
+}
+
+// AppTypeDisplay returns a user friendly name for a type of notifications generated by the app.
+func AppTypeDisplay(typ string) string {
+	m := map[string]string{
+		TypeCalendarEvent:     "Upcoming calendar events",
+		TypeContract:          "Contracts",
+		TypeDigest:            "Digest of quiet hours",
+		TypeExpiredExtraction: "Expired extractions",
+		TypeExpiredTraining:   "Expired training",
+		TypeIndustryJobReady:  "Industry jobs ready",
+		TypeJumpFatigue:       "Expired jump fatigue",
+		TypeMail:              "Mails",
+		TypeMarketOrderClosed: "Filled or expired market orders",
+		TypeMarketUndercut:    "Undercut market orders",
+		TypeStructureFuel:     "Structures running low on fuel",
+		TypeStructureService:  "Structure services offline",
+		TypeStructureTimer:    "Structure timers ending",
+		TypeTest:              "Test",
+	}
+	s, ok := m[typ]
+	if !ok {
+		return typ
+	}
+	return s
+}
+
+// Notification represents a notification which is delivered to sinks.
+type Notification struct {
+	Type          string // e.g. "StructureUnderAttack" or [TypeMail]
+	Title         string
+	Content       string
+	Timestamp     time.Time
+	CharacterID   int64 // optional, character a notification is about
+	CorporationID int64 // optional, corporation a notification is about
+}
+
+// Sink is a destination for notifications.
+type Sink interface {
+	Send(ctx context.Context, n Notification) error
+}
+
+// Dispatcher is a sink which delivers notifications to all registered sinks.
+type Dispatcher struct {
+	mu    sync.RWMutex
+	sinks []Sink
+}
+
+// NewDispatcher returns a new dispatcher with the given sinks.
+func NewDispatcher(sinks ...Sink) *Dispatcher {
+	d := &Dispatcher{sinks: sinks}
+	return d
+}
+
+// Register adds a sink to the dispatcher.
+func (d *Dispatcher) Register(s Sink) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sinks = append(d.sinks, s)
+}
+
+// Send delivers a notification to all registered sinks.
+// A failing sink does not prevent delivery to the other sinks.
+func (d *Dispatcher) Send(ctx context.Context, n Notification) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	var errs []error
+	for _, s := range d.sinks {
+		if err := s.Send(ctx, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
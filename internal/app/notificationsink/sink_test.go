@@ -0,0 +1,56 @@
+package notificationsink_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationsink"
+)
+
+type sinkFake struct {
+	err  error
+	sent []notificationsink.Notification
+}
+
+func (s *sinkFake) Send(_ context.Context, n notificationsink.Notification) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, n)
+	return nil
+}
+
+func TestDispatcher(t *testing.T) {
+	t.Run("should send to all sinks", func(t *testing.T) {
+		s1 := &sinkFake{}
+		s2 := &sinkFake{}
+		d := notificationsink.NewDispatcher(s1)
+		d.Register(s2)
+		n := notificationsink.Notification{Type: notificationsink.TypeMail, Title: "title"}
+		err := d.Send(t.Context(), n)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []notificationsink.Notification{n}, s1.sent)
+			assert.Equal(t, []notificationsink.Notification{n}, s2.sent)
+		}
+	})
+	t.Run("should send to other sinks when one fails", func(t *testing.T) {
+		myErr := errors.New("failed")
+		s1 := &sinkFake{err: myErr}
+		s2 := &sinkFake{}
+		d := notificationsink.NewDispatcher(s1, s2)
+		n := notificationsink.Notification{Type: notificationsink.TypeMail, Title: "title"}
+		err := d.Send(t.Context(), n)
+		assert.ErrorIs(t, err, myErr)
+		assert.Len(t, s2.sent, 1)
+	})
+}
+
+func TestAppTypeDisplay(t *testing.T) {
+	for _, typ := range notificationsink.AppTypes() {
+		assert.NotEqual(t, typ, notificationsink.AppTypeDisplay(typ))
+	}
+	assert.Equal(t, "unknown", notificationsink.AppTypeDisplay("unknown"))
+}
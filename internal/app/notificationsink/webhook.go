@@ -0,0 +1,237 @@
+package notificationsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/hashicorp/go-retryablehttp"
+	"golang.org/x/time/rate"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/xstrings"
+)
+
+// Payload formats supported by webhooks.
+const (
+	FormatDiscord = "Discord"
+	FormatGeneric = "Generic"
+	FormatSlack   = "Slack"
+)
+
+// Formats returns all supported webhook formats.
+func Formats() []string {
+	return []string{FormatDiscord, FormatSlack, FormatGeneric}
+}
+
+const (
+	discordContentMax      = 4096
+	discordTitleMax        = 256
+	webhookRateBurst       = 5
+	webhookRateInterval    = 2 * time.Second // Discord allows 30 requests per minute per webhook
+	webhookRetryMax        = 3
+	webhookRetryWaitMax    = 30 * time.Second
+	webhookRetryWaitMin    = 1 * time.Second
+	webhookUsername        = "EVE Buddy"
+	webhookResponseBodyMax = 512
+)
+
+// Settings provides the configuration for a webhook.
+type Settings interface {
+	WebhookEnabled() bool
+	WebhookFormat() string
+	WebhookTypes() set.Set[string]
+	WebhookURL() string
+}
+
+type WebhookParams struct {
+	Settings Settings
+	// optional
+	HTTPClient   *http.Client
+	RetryWaitMin time.Duration
+}
+
+// Webhook is a sink which posts notifications to a webhook, e.g. a Discord channel.
+// The configuration is read from the settings on every send,
+// so that changes take effect immediately.
+//
+// Requests are rate limited and retried on temporary errors.
+type Webhook struct {
+	client   *retryablehttp.Client
+	limiter  *rate.Limiter
+	settings Settings
+}
+
+// NewWebhook returns a new webhook sink.
+func NewWebhook(arg WebhookParams) *Webhook {
+	if arg.Settings == nil {
+		panic("notificationsink: missing settings")
+	}
+	client := retryablehttp.NewClient()
+	client.RetryMax = webhookRetryMax
+	client.RetryWaitMin = webhookRetryWaitMin
+	client.RetryWaitMax = webhookRetryWaitMax
+	client.Logger = nil // would log the URL, which contains the secret of the webhook
+	if arg.HTTPClient != nil {
+		client.HTTPClient = arg.HTTPClient
+	}
+	if arg.RetryWaitMin > 0 {
+		client.RetryWaitMin = arg.RetryWaitMin
+	}
+	w := &Webhook{
+		client:   client,
+		limiter:  rate.NewLimiter(rate.Every(webhookRateInterval), webhookRateBurst),
+		settings: arg.Settings,
+	}
+	return w
+}
+
+// Send posts a notification to the webhook.
+// Notifications are ignored when the webhook is disabled
+// or when their type is not enabled for the webhook.
+// When no types are enabled, notifications of all types are posted.
+func (w *Webhook) Send(ctx context.Context, n Notification) error {
+	if !w.settings.WebhookEnabled() {
+		return nil
+	}
+	types := w.settings.WebhookTypes()
+	if types.Size() > 0 && !types.Contains(n.Type) {
+		return nil
+	}
+	return w.post(ctx, n)
+}
+
+// SendTest posts a test notification to the webhook, even when it is disabled.
+// This allows users to verify their configuration.
+func (w *Webhook) SendTest(ctx context.Context) error {
+	return w.post(ctx, Notification{
+		Type:      TypeTest,
+		Title:     "Test",
+		Content:   "This is a test notification from EVE Buddy.",
+		Timestamp: time.Now(),
+	})
+}
+
+func (w *Webhook) post(ctx context.Context, n Notification) error {
+	rawURL := w.settings.WebhookURL()
+	if rawURL == "" {
+		return fmt.Errorf("webhook: URL not configured: %w", app.ErrInvalid)
+	}
+	data, err := makeWebhookPayload(w.settings.WebhookFormat(), n)
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	if err := w.limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPost, rawURL, data)
+	if err != nil {
+		return fmt.Errorf("webhook: %w", redactURL(err, rawURL))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: %w", redactURL(err, rawURL))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseBodyMax))
+		return fmt.Errorf("webhook: %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	slog.Info("webhook notification sent", "type", n.Type, "title", n.Title)
+	return nil
+}
+
+// redactedError is an error with a redacted message.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e redactedError) Error() string {
+	return e.msg
+}
+
+func (e redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactURL returns err with all occurrences of rawURL in its message redacted,
+// because webhook URLs contain secrets.
+func redactURL(err error, rawURL string) error {
+	msg := err.Error()
+	if !strings.Contains(msg, rawURL) {
+		return err
+	}
+	redacted := "REDACTED"
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		redacted = u.Scheme + "://" + u.Host + "/REDACTED"
+	}
+	return redactedError{msg: strings.ReplaceAll(msg, rawURL, redacted), err: err}
+}
+
+// makeWebhookPayload returns the JSON payload for a notification in the given format.
+func makeWebhookPayload(format string, n Notification) ([]byte, error) {
+	var timestamp string
+	if !n.Timestamp.IsZero() {
+		timestamp = n.Timestamp.UTC().Format(time.RFC3339)
+	}
+	var v any
+	switch format {
+	case FormatDiscord:
+		type footer struct {
+			Text string `json:"text"`
+		}
+		type embed struct {
+			Title       string `json:"title"`
+			Description string `json:"description,omitempty"`
+			Timestamp   string `json:"timestamp,omitempty"`
+			Footer      footer `json:"footer"`
+		}
+		v = struct {
+			Username string  `json:"username"`
+			Embeds   []embed `json:"embeds"`
+		}{
+			Username: webhookUsername,
+			Embeds: []embed{{
+				Title:       xstrings.TruncateWithSuffix(n.Title, discordTitleMax, 0),
+				Description: xstrings.TruncateWithSuffix(n.Content, discordContentMax, 0),
+				Timestamp:   timestamp,
+				Footer:      footer{Text: n.Type},
+			}},
+		}
+	case FormatSlack:
+		text := "*" + n.Title + "*"
+		if n.Content != "" {
+			text += "\n" + n.Content
+		}
+		v = struct {
+			Text string `json:"text"`
+		}{
+			Text: text,
+		}
+	case FormatGeneric:
+		v = struct {
+			Type      string `json:"type"`
+			Title     string `json:"title"`
+			Content   string `json:"content"`
+			Timestamp string `json:"timestamp"`
+		}{
+			Type:      n.Type,
+			Title:     n.Title,
+			Content:   n.Content,
+			Timestamp: timestamp,
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %q: %w", format, app.ErrInvalid)
+	}
+	return json.Marshal(v)
+}
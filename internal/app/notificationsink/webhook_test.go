@@ -0,0 +1,193 @@
+package notificationsink_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationsink"
+)
+
+type webhookSettingsFake struct {
+	enabled bool
+	format  string
+	types   set.Set[string]
+	url     string
+}
+
+func (s webhookSettingsFake) WebhookEnabled() bool          { return s.enabled }
+func (s webhookSettingsFake) WebhookFormat() string         { return s.format }
+func (s webhookSettingsFake) WebhookTypes() set.Set[string] { return s.types }
+func (s webhookSettingsFake) WebhookURL() string            { return s.url }
+
+// webhookServer is a local stand-in for a webhook server.
+type webhookServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	bodies   []map[string]any
+	statuses []int // responses for consecutive requests, then 204
+}
+
+func newWebhookServer(t *testing.T, statuses ...int) *webhookServer {
+	s := &webhookServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var body map[string]any
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Error(err)
+		}
+		s.bodies = append(s.bodies, body)
+		status := http.StatusNoContent
+		if len(s.statuses) > 0 {
+			status = s.statuses[0]
+			s.statuses = s.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *webhookServer) requests() []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bodies
+}
+
+func TestWebhook(t *testing.T) {
+	n := notificationsink.Notification{
+		Type:      "StructureUnderAttack",
+		Title:     "Structure under attack",
+		Content:   "Your Astrahus is under attack",
+		Timestamp: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	newWebhook := func(s webhookSettingsFake) *notificationsink.Webhook {
+		return notificationsink.NewWebhook(notificationsink.WebhookParams{
+			Settings:     s,
+			RetryWaitMin: time.Millisecond,
+		})
+	}
+
+	t.Run("can post notification in Discord format", func(t *testing.T) {
+		srv := newWebhookServer(t)
+		w := newWebhook(webhookSettingsFake{enabled: true, format: notificationsink.FormatDiscord, url: srv.URL})
+		err := w.Send(t.Context(), n)
+		require.NoError(t, err)
+		got := srv.requests()
+		if assert.Len(t, got, 1) {
+			embeds := got[0]["embeds"].([]any)
+			embed := embeds[0].(map[string]any)
+			assert.Equal(t, n.Title, embed["title"])
+			assert.Equal(t, n.Content, embed["description"])
+			assert.Equal(t, "2025-06-01T12:00:00Z", embed["timestamp"])
+		}
+	})
+	t.Run("can post notification in Slack format", func(t *testing.T) {
+		srv := newWebhookServer(t)
+		w := newWebhook(webhookSettingsFake{enabled: true, format: notificationsink.FormatSlack, url: srv.URL})
+		err := w.Send(t.Context(), n)
+		require.NoError(t, err)
+		got := srv.requests()
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, "*Structure under attack*\nYour Astrahus is under attack", got[0]["text"])
+		}
+	})
+	t.Run("can post notification in generic format", func(t *testing.T) {
+		srv := newWebhookServer(t)
+		w := newWebhook(webhookSettingsFake{enabled: true, format: notificationsink.FormatGeneric, url: srv.URL})
+		err := w.Send(t.Context(), n)
+		require.NoError(t, err)
+		got := srv.requests()
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, map[string]any{
+				"type":      n.Type,
+				"title":     n.Title,
+				"content":   n.Content,
+				"timestamp": "2025-06-01T12:00:00Z",
+			}, got[0])
+		}
+	})
+	t.Run("should post enabled types only", func(t *testing.T) {
+		srv := newWebhookServer(t)
+		w := newWebhook(webhookSettingsFake{
+			enabled: true,
+			format:  notificationsink.FormatGeneric,
+			types:   set.Of(notificationsink.TypeMail),
+			url:     srv.URL,
+		})
+		err := w.Send(t.Context(), n)
+		require.NoError(t, err)
+		err = w.Send(t.Context(), notificationsink.Notification{Type: notificationsink.TypeMail, Title: "mail"})
+		require.NoError(t, err)
+		got := srv.requests()
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, notificationsink.TypeMail, got[0]["type"])
+		}
+	})
+	t.Run("should not post when disabled", func(t *testing.T) {
+		srv := newWebhookServer(t)
+		w := newWebhook(webhookSettingsFake{format: notificationsink.FormatGeneric, url: srv.URL})
+		err := w.Send(t.Context(), n)
+		require.NoError(t, err)
+		assert.Len(t, srv.requests(), 0)
+	})
+	t.Run("should retry on temporary errors", func(t *testing.T) {
+		srv := newWebhookServer(t, http.StatusTooManyRequests, http.StatusBadGateway)
+		w := newWebhook(webhookSettingsFake{enabled: true, format: notificationsink.FormatGeneric, url: srv.URL})
+		err := w.Send(t.Context(), n)
+		require.NoError(t, err)
+		assert.Len(t, srv.requests(), 3)
+	})
+	t.Run("should return error when request is rejected", func(t *testing.T) {
+		srv := newWebhookServer(t, http.StatusBadRequest)
+		w := newWebhook(webhookSettingsFake{enabled: true, format: notificationsink.FormatGeneric, url: srv.URL})
+		err := w.Send(t.Context(), n)
+		assert.Error(t, err)
+		assert.Len(t, srv.requests(), 1)
+	})
+	t.Run("should not reveal URL in errors", func(t *testing.T) {
+		srv := newWebhookServer(t)
+		url := srv.URL + "/api/webhooks/123/secret-token"
+		srv.Close()
+		w := newWebhook(webhookSettingsFake{enabled: true, format: notificationsink.FormatGeneric, url: url})
+		err := w.Send(t.Context(), n)
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "secret-token")
+	})
+	t.Run("can send test notification when disabled", func(t *testing.T) {
+		srv := newWebhookServer(t)
+		w := newWebhook(webhookSettingsFake{format: notificationsink.FormatGeneric, url: srv.URL})
+		err := w.SendTest(t.Context())
+		require.NoError(t, err)
+		got := srv.requests()
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, notificationsink.TypeTest, got[0]["type"])
+		}
+	})
+	t.Run("should return error when URL is missing", func(t *testing.T) {
+		w := newWebhook(webhookSettingsFake{enabled: true, format: notificationsink.FormatGeneric})
+		err := w.Send(t.Context(), n)
+		assert.ErrorIs(t, err, app.ErrInvalid)
+	})
+	t.Run("should return error for unknown format", func(t *testing.T) {
+		srv := newWebhookServer(t)
+		w := newWebhook(webhookSettingsFake{enabled: true, format: "invalid", url: srv.URL})
+		err := w.Send(t.Context(), n)
+		assert.ErrorIs(t, err, app.ErrInvalid)
+	})
+}
@@ -0,0 +1,178 @@
+package app
+
+import (
+	"math"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+)
+
+// PlanetSchematic describes the inputs and outputs of a schematic for planetary industry.
+type PlanetSchematic struct {
+	CycleTime time.Duration
+	Inputs    map[int64]int64 // quantity per cycle by type ID
+	Outputs   map[int64]int64 // quantity per cycle by type ID
+}
+
+// ExtractorCycle is a projected cycle of an extraction program.
+type ExtractorCycle struct {
+	End      time.Time
+	Quantity int64
+}
+
+// ExtractorCycleOutput returns the projected output of an extractor for a cycle of its program,
+// where 0 is the first cycle.
+// The output decays over time with some noise, which mirrors the calculation in the game client.
+func ExtractorCycleOutput(quantityPerCycle int64, cycleTime time.Duration, cycle int) int64 {
+	const (
+		decayFactor = 0.012
+		noiseFactor = 0.8
+	)
+	base := float64(quantityPerCycle)
+	barWidth := cycleTime.Seconds() / 900
+	t := (float64(cycle) + 0.5) * barWidth
+	decay := base / (1 + t*decayFactor)
+	phaseShift := math.Pow(base, 0.7)
+	sinA := math.Cos(phaseShift + t/12)
+	sinB := math.Cos(phaseShift/2 + t/5)
+	sinC := math.Cos(t / 2)
+	noise := max((sinA+sinB+sinC)/3, 0)
+	return int64(barWidth * decay * (1 + noiseFactor*noise))
+}
+
+// ExtractorCycles returns the projected cycles of the extraction program of a pin,
+// which end after now.
+// Returns an empty slice when the pin is not extracting or the details are incomplete.
+func (pp PlanetPin) ExtractorCycles(now time.Time) []ExtractorCycle {
+	install, ok1 := pp.InstallTime.Value()
+	expiry, ok2 := pp.ExpiryTime.Value()
+	cycleTime, ok3 := pp.ExtractorCycleTime.Value()
+	quantity, ok4 := pp.ExtractorQuantityPerCycle.Value()
+	if !ok1 || !ok2 || !ok3 || !ok4 || cycleTime <= 0 || !expiry.After(install) {
+		return []ExtractorCycle{}
+	}
+	total := int(expiry.Sub(install) / cycleTime)
+	cycles := make([]ExtractorCycle, 0)
+	for i := range total {
+		end := install.Add(time.Duration(i+1) * cycleTime)
+		if !end.After(now) {
+			continue
+		}
+		cycles = append(cycles, ExtractorCycle{
+			End:      end,
+			Quantity: ExtractorCycleOutput(quantity, cycleTime, i),
+		})
+	}
+	return cycles
+}
+
+// ExtractorHourlyOutput returns the average output per hour
+// of the remaining extraction program of a pin.
+func (pp PlanetPin) ExtractorHourlyOutput(now time.Time) float64 {
+	cycles := pp.ExtractorCycles(now)
+	if len(cycles) == 0 {
+		return 0
+	}
+	var total int64
+	for _, c := range cycles {
+		total += c.Quantity
+	}
+	hours := float64(len(cycles)) * pp.ExtractorCycleTime.ValueOrZero().Hours()
+	return float64(total) / hours
+}
+
+// ColonyProduction is the estimated production of one or more colonies.
+type ColonyProduction struct {
+	Consumed         map[int64]float64 // units per hour by type ID
+	Produced         map[int64]float64 // units per hour by type ID
+	ShortTypes       set.Set[int64]    // types which are consumed faster than produced
+	StarvedFactories []*PlanetPin      // factories with inputs from short types
+}
+
+func newColonyProduction() ColonyProduction {
+	return ColonyProduction{
+		Consumed: make(map[int64]float64),
+		Produced: make(map[int64]float64),
+	}
+}
+
+// Net returns the difference between produced and consumed units per hour of a type.
+func (cp ColonyProduction) Net(typeID int64) float64 {
+	return cp.Produced[typeID] - cp.Consumed[typeID]
+}
+
+// Surplus returns the units per hour of all types which are produced faster than consumed.
+func (cp ColonyProduction) Surplus() map[int64]float64 {
+	m := make(map[int64]float64)
+	for id := range cp.Produced {
+		if v := cp.Net(id); v > 0 {
+			m[id] = v
+		}
+	}
+	return m
+}
+
+// ValuePerDay returns the estimated value of the surplus per day.
+// Types without a price are valued at zero.
+func (cp ColonyProduction) ValuePerDay(prices map[int64]float64) float64 {
+	var v float64
+	for id, x := range cp.Surplus() {
+		v += x * 24 * prices[id]
+	}
+	return v
+}
+
+// Production returns the estimated production of a colony at a point in time.
+// The production of factories is calculated from the given schematics.
+// Factories with unknown schematics are ignored.
+func (cp CharacterPlanet) Production(schematics map[int64]PlanetSchematic, now time.Time) ColonyProduction {
+	r := newColonyProduction()
+	for pp := range cp.ActiveExtractors() {
+		r.Produced[pp.ExtractorProductType.MustValue().ID] += pp.ExtractorHourlyOutput(now)
+	}
+	var factories []*PlanetPin
+	for pp := range cp.ActiveProducers() {
+		s, ok := schematics[pp.Schematic.MustValue().ID]
+		if !ok || s.CycleTime <= 0 {
+			continue
+		}
+		cyclesPerHour := float64(time.Hour) / float64(s.CycleTime)
+		for id, q := range s.Inputs {
+			r.Consumed[id] += float64(q) * cyclesPerHour
+		}
+		for id, q := range s.Outputs {
+			r.Produced[id] += float64(q) * cyclesPerHour
+		}
+		factories = append(factories, pp)
+	}
+	for id := range r.Consumed {
+		if r.Net(id) < 0 {
+			r.ShortTypes.Add(id)
+		}
+	}
+	for _, pp := range factories {
+		for id := range schematics[pp.Schematic.MustValue().ID].Inputs {
+			if r.ShortTypes.Contains(id) {
+				r.StarvedFactories = append(r.StarvedFactories, pp)
+				break
+			}
+		}
+	}
+	return r
+}
+
+// MergeColonyProductions returns the combined production of several colonies.
+func MergeColonyProductions(productions ...ColonyProduction) ColonyProduction {
+	r := newColonyProduction()
+	for _, p := range productions {
+		for id, v := range p.Consumed {
+			r.Consumed[id] += v
+		}
+		for id, v := range p.Produced {
+			r.Produced[id] += v
+		}
+		r.ShortTypes.AddSeq(p.ShortTypes.All())
+		r.StarvedFactories = append(r.StarvedFactories, p.StarvedFactories...)
+	}
+	return r
+}
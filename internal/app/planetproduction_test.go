@@ -0,0 +1,124 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+func TestExtractorCycleOutput(t *testing.T) {
+	cases := []struct {
+		cycle int
+		want  int64
+	}{
+		{0, 15897},
+		{1, 13992},
+		{10, 7987},
+		{100, 2930},
+	}
+	for _, tc := range cases {
+		got := app.ExtractorCycleOutput(5000, 30*time.Minute, tc.cycle)
+		assert.Equal(t, tc.want, got)
+	}
+}
+
+func TestPlanetPin_ExtractorCycles(t *testing.T) {
+	now := time.Now().UTC()
+	extractorType := &app.EveType{Group: &app.EveGroup{ID: app.EveGroupExtractorControlUnits}}
+	t.Run("should return remaining cycles", func(t *testing.T) {
+		pp := app.PlanetPin{
+			ExpiryTime:                optional.New(now.Add(2 * time.Hour)),
+			ExtractorCycleTime:        optional.New(30 * time.Minute),
+			ExtractorQuantityPerCycle: optional.New[int64](5000),
+			InstallTime:               optional.New(now.Add(-1 * time.Hour)),
+			Type:                      extractorType,
+		}
+		got := pp.ExtractorCycles(now)
+		want := []app.ExtractorCycle{
+			{End: now.Add(30 * time.Minute), Quantity: 11570},
+			{End: now.Add(60 * time.Minute), Quantity: 10662},
+			{End: now.Add(90 * time.Minute), Quantity: 11551},
+			{End: now.Add(120 * time.Minute), Quantity: 12672},
+		}
+		assert.Equal(t, want, got)
+		assert.InDelta(t, 23227.5, pp.ExtractorHourlyOutput(now), 0.001)
+	})
+	t.Run("should return empty when details are missing", func(t *testing.T) {
+		pp := app.PlanetPin{
+			ExpiryTime:  optional.New(now.Add(2 * time.Hour)),
+			InstallTime: optional.New(now.Add(-1 * time.Hour)),
+			Type:        extractorType,
+		}
+		assert.Len(t, pp.ExtractorCycles(now), 0)
+		assert.Equal(t, 0.0, pp.ExtractorHourlyOutput(now))
+	})
+}
+
+func TestCharacterPlanet_Production(t *testing.T) {
+	now := time.Now().UTC()
+	const (
+		p0Type = 1
+		p1Type = 2
+		p2Type = 3
+	)
+	extractorType := &app.EveType{Group: &app.EveGroup{ID: app.EveGroupExtractorControlUnits}}
+	processorType := &app.EveType{Group: &app.EveGroup{ID: app.EveGroupProcessors}}
+	extractor := &app.PlanetPin{
+		ExpiryTime:                optional.New(now.Add(2 * time.Hour)),
+		ExtractorCycleTime:        optional.New(30 * time.Minute),
+		ExtractorProductType:      optional.New(&app.EveType{ID: p0Type}),
+		ExtractorQuantityPerCycle: optional.New[int64](5000),
+		InstallTime:               optional.New(now.Add(-1 * time.Hour)),
+		Type:                      extractorType,
+	}
+	basicFactory := &app.PlanetPin{
+		Schematic: optional.New(&app.EveSchematic{ID: 10}),
+		Type:      processorType,
+	}
+	advancedFactory := &app.PlanetPin{
+		Schematic: optional.New(&app.EveSchematic{ID: 11}),
+		Type:      processorType,
+	}
+	schematics := map[int64]app.PlanetSchematic{
+		10: {
+			CycleTime: 30 * time.Minute,
+			Inputs:    map[int64]int64{p0Type: 3000},
+			Outputs:   map[int64]int64{p1Type: 20},
+		},
+		11: {
+			CycleTime: time.Hour,
+			Inputs:    map[int64]int64{p1Type: 40},
+			Outputs:   map[int64]int64{p2Type: 5},
+		},
+	}
+	t.Run("should calculate hourly input and output", func(t *testing.T) {
+		cp := app.CharacterPlanet{Pins: []*app.PlanetPin{extractor, basicFactory, advancedFactory}}
+		got := cp.Production(schematics, now)
+		assert.InDelta(t, 23227.5, got.Produced[p0Type], 0.001)
+		assert.InDelta(t, 6000, got.Consumed[p0Type], 0.001)
+		assert.InDelta(t, 0, got.Net(p1Type), 0.001)
+		assert.InDelta(t, 5, got.Net(p2Type), 0.001)
+		assert.Equal(t, 0, got.ShortTypes.Size())
+		assert.Len(t, got.StarvedFactories, 0)
+		assert.InDelta(t, 5*24*1000+17227.5*24*2, got.ValuePerDay(map[int64]float64{p0Type: 2, p2Type: 1000}), 0.001)
+	})
+	t.Run("should report starved factories", func(t *testing.T) {
+		cp := app.CharacterPlanet{Pins: []*app.PlanetPin{basicFactory, advancedFactory}}
+		got := cp.Production(schematics, now)
+		assert.True(t, got.ShortTypes.Contains(p0Type))
+		assert.False(t, got.ShortTypes.Contains(p1Type))
+		assert.Equal(t, []*app.PlanetPin{basicFactory}, got.StarvedFactories)
+	})
+	t.Run("should merge productions", func(t *testing.T) {
+		cp1 := app.CharacterPlanet{Pins: []*app.PlanetPin{extractor}}
+		cp2 := app.CharacterPlanet{Pins: []*app.PlanetPin{basicFactory}}
+		got := app.MergeColonyProductions(cp1.Production(schematics, now), cp2.Production(schematics, now))
+		assert.InDelta(t, 23227.5-6000, got.Net(p0Type), 0.001)
+		assert.True(t, got.ShortTypes.Contains(p0Type))
+		assert.Equal(t, []*app.PlanetPin{basicFactory}, got.StarvedFactories)
+	})
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"time"
+)
+
+// TextSearchHitKind represents the kind of object found by a full-text search.
+type TextSearchHitKind uint
+
+const (
+	TextSearchHitUndefined TextSearchHitKind = iota
+	TextSearchHitMail
+	TextSearchHitNotification
+)
+
+func (k TextSearchHitKind) String() string {
+	switch k {
+	case TextSearchHitMail:
+		return "mail"
+	case TextSearchHitNotification:
+		return "notification"
+	}
+	return "?"
+}
+
+// TextSearchHit represents a mail or notification found by a full-text search.
+type TextSearchHit struct {
+	CharacterID int64
+	ID          int64 // mail ID or notification ID
+	Kind        TextSearchHitKind
+	Sender      *EveEntity
+	Snippet     string // excerpt of the matching text
+	Timestamp   time.Time
+	Title       string
+}
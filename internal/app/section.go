@@ -47,18 +47,24 @@ var _ section = (*CharacterSection)(nil)
 const (
 	SectionCharacterAssets             CharacterSection = "assets"
 	SectionCharacterAttributes         CharacterSection = "attributes"
+	SectionCharacterBlueprints         CharacterSection = "blueprints"
+	SectionCharacterCalendar           CharacterSection = "calendar"
 	SectionCharacterContacts           CharacterSection = "contacts"
 	SectionCharacterContactLabels      CharacterSection = "contact_labels"
 	SectionCharacterContracts          CharacterSection = "contracts"
+	SectionCharacterFittings           CharacterSection = "fittings"
 	SectionCharacterImplants           CharacterSection = "implants"
 	SectionCharacterIndustryJobs       CharacterSection = "industry_jobs"
 	SectionCharacterJumpClones         CharacterSection = "jump_clones"
+	SectionCharacterJumpFatigue        CharacterSection = "jump_fatigue"
+	SectionCharacterKillmails          CharacterSection = "killmails"
 	SectionCharacterLocation           CharacterSection = "location"
 	SectionCharacterLoyaltyPoints      CharacterSection = "loyalty_points"
 	SectionCharacterMailHeaders        CharacterSection = "mail_headers"
 	SectionCharacterMailLabels         CharacterSection = "mail_labels"
 	SectionCharacterMailLists          CharacterSection = "mail_lists"
 	SectionCharacterMarketOrders       CharacterSection = "market_orders"
+	SectionCharacterMiningLedger       CharacterSection = "mining_ledger"
 	SectionCharacterNotifications      CharacterSection = "notifications"
 	SectionCharacterOnline             CharacterSection = "online"
 	SectionCharacterPlanets            CharacterSection = "planets"
@@ -66,6 +72,7 @@ const (
 	SectionCharacterShip               CharacterSection = "ship"
 	SectionCharacterSkillqueue         CharacterSection = "skillqueue"
 	SectionCharacterSkills             CharacterSection = "skills"
+	SectionCharacterStandings          CharacterSection = "standings"
 	SectionCharacterWalletBalance      CharacterSection = "wallet_balance"
 	SectionCharacterWalletJournal      CharacterSection = "wallet_journal"
 	SectionCharacterWalletTransactions CharacterSection = "wallet_transactions"
@@ -74,18 +81,24 @@ const (
 var CharacterSections = []CharacterSection{
 	SectionCharacterAssets,
 	SectionCharacterAttributes,
+	SectionCharacterBlueprints,
+	SectionCharacterCalendar,
 	SectionCharacterContacts,
 	SectionCharacterContactLabels,
 	SectionCharacterContracts,
+	SectionCharacterFittings,
 	SectionCharacterImplants,
 	SectionCharacterIndustryJobs,
 	SectionCharacterJumpClones,
+	SectionCharacterJumpFatigue,
+	SectionCharacterKillmails,
 	SectionCharacterLocation,
 	SectionCharacterLoyaltyPoints,
 	SectionCharacterMailHeaders,
 	SectionCharacterMailLabels,
 	SectionCharacterMailLists,
 	SectionCharacterMarketOrders,
+	SectionCharacterMiningLedger,
 	SectionCharacterNotifications,
 	SectionCharacterOnline,
 	SectionCharacterPlanets,
@@ -93,6 +106,7 @@ var CharacterSections = []CharacterSection{
 	SectionCharacterShip,
 	SectionCharacterSkillqueue,
 	SectionCharacterSkills,
+	SectionCharacterStandings,
 	SectionCharacterWalletBalance,
 	SectionCharacterWalletJournal,
 	SectionCharacterWalletTransactions,
@@ -106,18 +120,24 @@ func (cs CharacterSection) Scopes() set.Set[string] {
 	m := map[CharacterSection][]string{
 		SectionCharacterAssets:             {goesi.ScopeAssetsReadAssetsV1, goesi.ScopeUniverseReadStructuresV1},
 		SectionCharacterAttributes:         {goesi.ScopeSkillsReadSkillsV1},
+		SectionCharacterBlueprints:         {goesi.ScopeCharactersReadBlueprintsV1},
+		SectionCharacterCalendar:           {goesi.ScopeCalendarReadCalendarEventsV1},
 		SectionCharacterContacts:           {goesi.ScopeCharactersReadContactsV1},
 		SectionCharacterContactLabels:      {goesi.ScopeCharactersReadContactsV1},
 		SectionCharacterContracts:          {goesi.ScopeContractsReadCharacterContractsV1, goesi.ScopeUniverseReadStructuresV1},
+		SectionCharacterFittings:           {goesi.ScopeFittingsReadFittingsV1},
 		SectionCharacterImplants:           {goesi.ScopeClonesReadImplantsV1},
 		SectionCharacterIndustryJobs:       {goesi.ScopeIndustryReadCharacterJobsV1, goesi.ScopeUniverseReadStructuresV1},
 		SectionCharacterJumpClones:         {goesi.ScopeClonesReadClonesV1, goesi.ScopeUniverseReadStructuresV1},
+		SectionCharacterJumpFatigue:        {goesi.ScopeCharactersReadFatigueV1},
+		SectionCharacterKillmails:          {goesi.ScopeKillmailsReadKillmailsV1},
 		SectionCharacterLocation:           {goesi.ScopeLocationReadLocationV1, goesi.ScopeUniverseReadStructuresV1},
 		SectionCharacterLoyaltyPoints:      {goesi.ScopeCharactersReadLoyaltyV1},
 		SectionCharacterMailHeaders:        {goesi.ScopeMailOrganizeMailV1, goesi.ScopeMailReadMailV1},
 		SectionCharacterMailLabels:         {goesi.ScopeMailReadMailV1},
 		SectionCharacterMailLists:          {goesi.ScopeMailReadMailV1},
 		SectionCharacterMarketOrders:       {goesi.ScopeMarketsReadCharacterOrdersV1},
+		SectionCharacterMiningLedger:       {goesi.ScopeIndustryReadCharacterMiningV1},
 		SectionCharacterNotifications:      {goesi.ScopeCharactersReadNotificationsV1, goesi.ScopeUniverseReadStructuresV1},
 		SectionCharacterOnline:             {goesi.ScopeLocationReadOnlineV1},
 		SectionCharacterPlanets:            {goesi.ScopePlanetsManagePlanetsV1},
@@ -125,6 +145,7 @@ func (cs CharacterSection) Scopes() set.Set[string] {
 		SectionCharacterShip:               {goesi.ScopeLocationReadShipTypeV1},
 		SectionCharacterSkillqueue:         {goesi.ScopeSkillsReadSkillqueueV1},
 		SectionCharacterSkills:             {goesi.ScopeSkillsReadSkillsV1},
+		SectionCharacterStandings:          {goesi.ScopeCharactersReadStandingsV1},
 		SectionCharacterWalletBalance:      {goesi.ScopeWalletReadCharacterWalletV1},
 		SectionCharacterWalletJournal:      {goesi.ScopeWalletReadCharacterWalletV1},
 		SectionCharacterWalletTransactions: {goesi.ScopeWalletReadCharacterWalletV1, goesi.ScopeUniverseReadStructuresV1},
@@ -145,18 +166,24 @@ func (cs CharacterSection) Timeout() time.Duration {
 	var m = map[CharacterSection]time.Duration{
 		SectionCharacterAssets:             3600 * time.Second,
 		SectionCharacterAttributes:         120 * time.Second,
+		SectionCharacterBlueprints:         3600 * time.Second,
+		SectionCharacterCalendar:           300 * time.Second,
 		SectionCharacterContacts:           300 * time.Second,
 		SectionCharacterContactLabels:      300 * time.Second,
 		SectionCharacterContracts:          300 * time.Second,
+		SectionCharacterFittings:           3600 * time.Second,
 		SectionCharacterImplants:           120 * time.Second,
 		SectionCharacterIndustryJobs:       300 * time.Second,
 		SectionCharacterJumpClones:         120 * time.Second,
+		SectionCharacterJumpFatigue:        300 * time.Second,
+		SectionCharacterKillmails:          3600 * time.Second,
 		SectionCharacterLocation:           300 * time.Second, // minimum 5 seconds
 		SectionCharacterLoyaltyPoints:      3600 * time.Second,
 		SectionCharacterMailHeaders:        60 * time.Second, // minimum 30 seconds
 		SectionCharacterMailLabels:         60 * time.Second, // minimum 30 seconds
 		SectionCharacterMailLists:          120 * time.Second,
 		SectionCharacterMarketOrders:       1200 * time.Second,
+		SectionCharacterMiningLedger:       1800 * time.Second,
 		SectionCharacterNotifications:      600 * time.Second,
 		SectionCharacterOnline:             300 * time.Second, // minimum 30 seconds
 		SectionCharacterPlanets:            600 * time.Second,
@@ -164,6 +191,7 @@ func (cs CharacterSection) Timeout() time.Duration {
 		SectionCharacterShip:               300 * time.Second, // minimum 5 seconds
 		SectionCharacterSkillqueue:         120 * time.Second,
 		SectionCharacterSkills:             120 * time.Second,
+		SectionCharacterStandings:          3600 * time.Second,
 		SectionCharacterWalletBalance:      120 * time.Second,
 		SectionCharacterWalletJournal:      3600 * time.Second,
 		SectionCharacterWalletTransactions: 3600 * time.Second,
@@ -183,11 +211,17 @@ var _ section = (*CorporationSection)(nil)
 
 const (
 	SectionCorporationAssets              CorporationSection = "assets"                // corp-assets
+	SectionCorporationBlueprints          CorporationSection = "blueprints"            // corp-industry
 	SectionCorporationContracts           CorporationSection = "contracts"             // corp-contract
 	SectionCorporationDivisions           CorporationSection = "divisions"             // corp-wallet
 	SectionCorporationIndustryJobs        CorporationSection = "industry_jobs"         // corp-industry
+	SectionCorporationKillmails           CorporationSection = "killmails"             // corp-killmail
 	SectionCorporationMembers             CorporationSection = "members"               // corp-member
+	SectionCorporationMemberRoles         CorporationSection = "member_roles"          // corp-member
+	SectionCorporationMemberTracking      CorporationSection = "member_tracking"       // corp-member
+	SectionCorporationMiningObservers     CorporationSection = "mining_observers"      // corp-industry
 	SectionCorporationStructures          CorporationSection = "structures"            // corp-asset
+	SectionCorporationTitles              CorporationSection = "titles"                // corp-detail, corp-member
 	SectionCorporationWalletBalances      CorporationSection = "wallet_balances"       // corp-wallet
 	SectionCorporationWalletJournal1      CorporationSection = "wallet_journal_1"      // corp-wallet
 	SectionCorporationWalletJournal2      CorporationSection = "wallet_journal_2"      // corp-wallet
@@ -207,11 +241,17 @@ const (
 
 var CorporationSections = []CorporationSection{
 	SectionCorporationAssets,
+	SectionCorporationBlueprints,
 	SectionCorporationContracts,
 	SectionCorporationDivisions,
 	SectionCorporationIndustryJobs,
+	SectionCorporationKillmails,
 	SectionCorporationMembers,
+	SectionCorporationMemberRoles,
+	SectionCorporationMemberTracking,
+	SectionCorporationMiningObservers,
 	SectionCorporationStructures,
+	SectionCorporationTitles,
 	SectionCorporationWalletBalances,
 	SectionCorporationWalletJournal1,
 	SectionCorporationWalletJournal2,
@@ -291,12 +331,18 @@ func (cs CorporationSection) Timeout() time.Duration {
 	)
 	m := map[CorporationSection]time.Duration{
 		SectionCorporationAssets:              3600 * time.Second,
+		SectionCorporationBlueprints:          3600 * time.Second,
 		SectionCorporationContracts:           300 * time.Second,
 		SectionCorporationDivisions:           3600 * time.Second,
 		SectionCorporationIndustryJobs:        300 * time.Second,
+		SectionCorporationKillmails:           3600 * time.Second,
 		SectionCorporationMembers:             3600 * time.Second,
+		SectionCorporationMemberRoles:         3600 * time.Second,
+		SectionCorporationMemberTracking:      3600 * time.Second,
+		SectionCorporationMiningObservers:     3600 * time.Second,
 		SectionCorporationWalletBalances:      300 * time.Second,
 		SectionCorporationStructures:          3600 * time.Second,
+		SectionCorporationTitles:              3600 * time.Second,
 		SectionCorporationWalletJournal1:      walletJournal,
 		SectionCorporationWalletJournal2:      walletJournal,
 		SectionCorporationWalletJournal3:      walletJournal,
@@ -327,11 +373,17 @@ func (cs CorporationSection) Roles() set.Set[Role] {
 	)
 	m := map[CorporationSection][]Role{
 		SectionCorporationAssets:              {RoleDirector},
+		SectionCorporationBlueprints:          {RoleDirector},
 		SectionCorporationContracts:           {},
 		SectionCorporationDivisions:           {RoleDirector},
 		SectionCorporationIndustryJobs:        {RoleFactoryManager},
+		SectionCorporationKillmails:           {RoleDirector},
 		SectionCorporationMembers:             {},
+		SectionCorporationMemberRoles:         {RoleDirector, RolePersonnelManager},
+		SectionCorporationMemberTracking:      {RoleDirector},
+		SectionCorporationMiningObservers:     {RoleAccountant},
 		SectionCorporationStructures:          {RoleStationManager},
+		SectionCorporationTitles:              {RoleDirector},
 		SectionCorporationWalletBalances:      anyAccountant,
 		SectionCorporationWalletJournal1:      anyAccountant,
 		SectionCorporationWalletJournal2:      anyAccountant,
@@ -361,11 +413,17 @@ func (cs CorporationSection) Scopes() set.Set[string] {
 	transactions := []string{goesi.ScopeWalletReadCorporationWalletsV1, goesi.ScopeUniverseReadStructuresV1}
 	m := map[CorporationSection][]string{
 		SectionCorporationAssets:              {goesi.ScopeAssetsReadCorporationAssetsV1},
+		SectionCorporationBlueprints:          {goesi.ScopeCorporationsReadBlueprintsV1},
 		SectionCorporationContracts:           {goesi.ScopeContractsReadCorporationContractsV1},
 		SectionCorporationDivisions:           {goesi.ScopeCorporationsReadDivisionsV1},
 		SectionCorporationIndustryJobs:        {goesi.ScopeIndustryReadCorporationJobsV1},
+		SectionCorporationKillmails:           {goesi.ScopeKillmailsReadCorporationKillmailsV1},
 		SectionCorporationMembers:             {goesi.ScopeCorporationsReadCorporationMembershipV1},
+		SectionCorporationMemberRoles:         {goesi.ScopeCorporationsReadCorporationMembershipV1},
+		SectionCorporationMemberTracking:      {goesi.ScopeCorporationsTrackMembersV1, goesi.ScopeUniverseReadStructuresV1},
+		SectionCorporationMiningObservers:     {goesi.ScopeIndustryReadCorporationMiningV1},
 		SectionCorporationStructures:          {goesi.ScopeCorporationsReadStructuresV1},
+		SectionCorporationTitles:              {goesi.ScopeCorporationsReadTitlesV1},
 		SectionCorporationWalletBalances:      {goesi.ScopeWalletReadCorporationWalletsV1},
 		SectionCorporationWalletJournal1:      journal,
 		SectionCorporationWalletJournal2:      journal,
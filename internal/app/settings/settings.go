@@ -3,6 +3,7 @@ package settings
 
 import (
 	"cmp"
+	"crypto/rand"
 	"log/slog"
 	"slices"
 	"time"
@@ -30,10 +31,29 @@ const (
 	settingDeveloperMode                      = "developer-mode"
 	settingLastCharacterID                    = "settingLastCharacterID"
 	settingLastCorporationID                  = "settingLastCorporationID"
+	settingLocalAPIEnabled                    = "settingLocalAPIEnabled"
+	settingLocalAPIEnabledDefault             = false
+	settingLocalAPIPort                       = "settingLocalAPIPort"
+	settingLocalAPIPortDefault                = 30126
+	settingLocalAPIPortMax                    = 65535
+	settingLocalAPIPortMin                    = 1024
+	settingLocalAPIToken                      = "settingLocalAPIToken"
 	settingLogLevel                           = "logLevel"
 	settingLogLevelDefault                    = "info"
 	settingApprovedContactCost                = "settingApprovedContactCost"
 	settingApprovedContactCostMax             = 1_000_000
+	settingBackupAutoEnabled                  = "settingBackupAutoEnabled"
+	settingBackupAutoEnabledDefault           = false
+	settingBackupExcludeCache                 = "settingBackupExcludeCache"
+	settingBackupExcludeCacheDefault          = true
+	settingBackupIntervalHours                = "settingBackupIntervalHours"
+	settingBackupIntervalHoursDefault         = 24
+	settingBackupIntervalHoursMax             = 7 * 24
+	settingBackupIntervalHoursMin             = 1
+	settingBackupRetention                    = "settingBackupRetention"
+	settingBackupRetentionDefault             = 7
+	settingBackupRetentionMax                 = 100
+	settingBackupRetentionMin                 = 1
 	settingMaxMails                           = "settingMaxMails"
 	settingMaxMailsDefault                    = 250
 	settingMaxMailsMax                        = 10_000
@@ -44,19 +64,56 @@ const (
 	settingMarketOrderRetentionDaysMax        = 360
 	settingMaxWalletTransactionsDefault       = 1_000
 	settingMaxWalletTransactionsMax           = 10_000
+	settingMemberInactiveDays                 = "settingMemberInactiveDays"
+	settingMemberInactiveDaysDefault          = 30
+	settingMemberInactiveDaysMax              = 365
+	settingMemberInactiveDaysMin              = 1
+	settingNotificationRules                  = "settingNotificationRules"
 	settingNotificationTypesEnabled           = "settingNotificationsTypesEnabled"
+	settingNotifyCalendarEnabled              = "settingNotifyCalendarEnabled"
+	settingNotifyCalendarEnabledDefault       = false
+	settingNotifyCalendarLeadMinutes          = "settingNotifyCalendarLeadMinutes"
+	settingNotifyCalendarLeadMinutesDefault   = 15
+	settingNotifyCalendarLeadMinutesMax       = 240
+	settingNotifyCalendarLeadMinutesMin       = 1
 	settingNotifyCommunicationsEarliest       = "settingNotifyCommunicationsEarliest"
 	settingNotifyCommunicationsEnabled        = "settingNotifyCommunicationsEnabled"
 	settingNotifyCommunicationsEnabledDefault = false
 	settingNotifyContractsEarliest            = "settingNotifyContractsEarliest"
 	settingNotifyContractsEnabled             = "settingNotifyContractsEnabled"
 	settingNotifyContractsEnabledDefault      = false
+	settingNotifyIndustryJobsEarliest         = "settingNotifyIndustryJobsEarliest"
+	settingNotifyIndustryJobsEnabled          = "settingNotifyIndustryJobsEnabled"
+	settingNotifyIndustryJobsEnabledDefault   = false
+	settingNotifyJumpFatigueEnabled           = "settingNotifyJumpFatigueEnabled"
+	settingNotifyJumpFatigueEnabledDefault    = false
 	settingNotifyMailsEarliest                = "settingNotifyMailsEarliest"
+	settingNotifyMarketOrdersEarliest         = "settingNotifyMarketOrdersEarliest"
+	settingNotifyMarketOrdersEnabled          = "settingNotifyMarketOrdersEnabled"
+	settingNotifyMarketOrdersEnabledDefault   = false
+	settingNotifyMarketUndercutEnabled        = "settingNotifyMarketUndercutEnabled"
+	settingNotifyMarketUndercutEnabledDefault = false
 	settingNotifyMailsEnabled                 = "settingNotifyMailsEnabled"
 	settingNotifyMailsEnabledDefault          = false
 	settingNotifyPIEarliest                   = "settingNotifyPIEarliest"
 	settingNotifyPIEnabled                    = "settingNotifyPIEnabled"
 	settingNotifyPIEnabledDefault             = false
+	settingNotifyQuietHoursEnabled            = "settingNotifyQuietHoursEnabled"
+	settingNotifyQuietHoursEnabledDefault     = false
+	settingNotifyQuietHoursEnd                = "settingNotifyQuietHoursEnd"
+	settingNotifyQuietHoursEndDefault         = 7
+	settingNotifyQuietHoursMax                = 23
+	settingNotifyQuietHoursMin                = 0
+	settingNotifyQuietHoursStart              = "settingNotifyQuietHoursStart"
+	settingNotifyQuietHoursStartDefault       = 22
+	settingNotifyStructureFuelHours           = "settingNotifyStructureFuelHours"
+	settingNotifyStructureTimerHours          = "settingNotifyStructureTimerHours"
+	settingNotifyStructureTimerHoursDefault   = 1
+	settingNotifyStructureTimerHoursMax       = 48
+	settingNotifyStructureTimerHoursMin       = 1
+	settingNotifyStructuresEarliest           = "settingNotifyStructuresEarliest"
+	settingNotifyStructuresEnabled            = "settingNotifyStructuresEnabled"
+	settingNotifyStructuresEnabledDefault     = false
 	settingNotifyTimeoutHours                 = "settingNotifyTimeoutHours"
 	settingNotifyTimeoutHoursDefault          = 30 * 24
 	settingNotifyTimeoutHoursMax              = 90 * 24
@@ -73,6 +130,12 @@ const (
 	settingDisableDPIDetection                = "settingFyneDisableDPIDetection"
 	settingFyneScale                          = "settingFyneScale"
 	settingFyneScaleDefault                   = 1.0
+	settingWebhookEnabled                     = "settingWebhookEnabled"
+	settingWebhookEnabledDefault              = false
+	settingWebhookFormat                      = "settingWebhookFormat"
+	settingWebhookFormatDefault               = "Discord"
+	settingWebhookTypes                       = "settingWebhookTypes"
+	settingWebhookURL                         = "settingWebhookURL"
 	settingWindowHeightDefault                = 600
 	settingWindowsSize                        = "window-size"
 	settingWindowWidthDefault                 = 1000
@@ -238,6 +301,115 @@ func (s *Settings) SetMarketOrdersRetentionDay(v int) {
 	s.p.SetInt(settingMarketOrdersRetentionDays, v)
 }
 
+// CorporationMemberInactiveDays returns the number of days without login
+// after which a corporation member is considered inactive.
+func (s *Settings) CorporationMemberInactiveDays() int {
+	if s == nil {
+		return 0
+	}
+	return s.p.IntWithFallback(settingMemberInactiveDays, settingMemberInactiveDaysDefault)
+}
+
+func (s *Settings) CorporationMemberInactiveDaysPresets() (minimum int, maximum int, def int) {
+	minimum = settingMemberInactiveDaysMin
+	maximum = settingMemberInactiveDaysMax
+	def = settingMemberInactiveDaysDefault
+	return
+}
+
+func (s *Settings) SetCorporationMemberInactiveDays(v int) {
+	if s == nil {
+		return
+	}
+	s.p.SetInt(settingMemberInactiveDays, v)
+}
+
+func (s *Settings) BackupAutoEnabled() bool {
+	if s == nil {
+		return false
+	}
+	return s.p.BoolWithFallback(settingBackupAutoEnabled, settingBackupAutoEnabledDefault)
+}
+
+func (s *Settings) BackupAutoEnabledDefault() bool {
+	if s == nil {
+		return false
+	}
+	return settingBackupAutoEnabledDefault
+}
+
+func (s *Settings) SetBackupAutoEnabled(v bool) {
+	if s == nil {
+		return
+	}
+	s.p.SetBool(settingBackupAutoEnabled, v)
+}
+
+// BackupExcludeCache reports whether the cache should be excluded from backups.
+func (s *Settings) BackupExcludeCache() bool {
+	if s == nil {
+		return false
+	}
+	return s.p.BoolWithFallback(settingBackupExcludeCache, settingBackupExcludeCacheDefault)
+}
+
+func (s *Settings) BackupExcludeCacheDefault() bool {
+	if s == nil {
+		return false
+	}
+	return settingBackupExcludeCacheDefault
+}
+
+func (s *Settings) SetBackupExcludeCache(v bool) {
+	if s == nil {
+		return
+	}
+	s.p.SetBool(settingBackupExcludeCache, v)
+}
+
+func (s *Settings) BackupIntervalHours() int {
+	if s == nil {
+		return 0
+	}
+	return s.p.IntWithFallback(settingBackupIntervalHours, settingBackupIntervalHoursDefault)
+}
+
+func (s *Settings) BackupIntervalHoursPresets() (minimum int, maximum int, def int) {
+	minimum = settingBackupIntervalHoursMin
+	maximum = settingBackupIntervalHoursMax
+	def = settingBackupIntervalHoursDefault
+	return
+}
+
+func (s *Settings) SetBackupIntervalHours(v int) {
+	if s == nil {
+		return
+	}
+	s.p.SetInt(settingBackupIntervalHours, v)
+}
+
+// BackupRetention returns the number of automatic backups to keep.
+func (s *Settings) BackupRetention() int {
+	if s == nil {
+		return 0
+	}
+	return s.p.IntWithFallback(settingBackupRetention, settingBackupRetentionDefault)
+}
+
+func (s *Settings) BackupRetentionPresets() (minimum int, maximum int, def int) {
+	minimum = settingBackupRetentionMin
+	maximum = settingBackupRetentionMax
+	def = settingBackupRetentionDefault
+	return
+}
+
+func (s *Settings) SetBackupRetention(v int) {
+	if s == nil {
+		return
+	}
+	s.p.SetInt(settingBackupRetention, v)
+}
+
 func (s *Settings) SysTrayEnabled() bool {
 	if s == nil {
 		return false
@@ -393,6 +565,148 @@ func (s *Settings) SetNotifyTimeoutHours(v int) {
 	s.p.SetInt(settingNotifyTimeoutHours, v)
 }
 
+func (s *Settings) NotifyCalendarLeadMinutes() int {
+	if s == nil {
+		return 0
+	}
+	return s.p.IntWithFallback(settingNotifyCalendarLeadMinutes, settingNotifyCalendarLeadMinutesDefault)
+}
+
+func (s *Settings) NotifyCalendarLeadMinutesPresets() (minimum int, maximum int, def int) {
+	minimum = settingNotifyCalendarLeadMinutesMin
+	maximum = settingNotifyCalendarLeadMinutesMax
+	def = settingNotifyCalendarLeadMinutesDefault
+	return
+}
+
+func (s *Settings) SetNotifyCalendarLeadMinutes(v int) {
+	if s == nil {
+		return
+	}
+	s.p.SetInt(settingNotifyCalendarLeadMinutes, v)
+}
+
+// NotifyStructureFuelHours returns the thresholds in hours of remaining fuel
+// for warning about structures running out of fuel.
+func (s *Settings) NotifyStructureFuelHours() []int {
+	if s == nil {
+		return []int{}
+	}
+	return s.p.IntListWithFallback(settingNotifyStructureFuelHours, s.NotifyStructureFuelHoursDefault())
+}
+
+func (s *Settings) NotifyStructureFuelHoursDefault() []int {
+	return []int{7 * 24, 3 * 24, 24}
+}
+
+// NotifyStructureFuelHoursOptions returns the thresholds a user can choose from in descending order.
+func (s *Settings) NotifyStructureFuelHoursOptions() []int {
+	return []int{14 * 24, 7 * 24, 3 * 24, 2 * 24, 24, 12}
+}
+
+func (s *Settings) SetNotifyStructureFuelHours(v []int) {
+	if s == nil {
+		return
+	}
+	s.p.SetIntList(settingNotifyStructureFuelHours, v)
+}
+
+func (s *Settings) ResetNotifyStructureFuelHours() {
+	if s == nil {
+		return
+	}
+	s.SetNotifyStructureFuelHours(s.NotifyStructureFuelHoursDefault())
+}
+
+// NotifyStructureTimerHours returns how many hours before a structure timer ends to warn about it.
+func (s *Settings) NotifyStructureTimerHours() int {
+	if s == nil {
+		return 0
+	}
+	return s.p.IntWithFallback(settingNotifyStructureTimerHours, settingNotifyStructureTimerHoursDefault)
+}
+
+func (s *Settings) NotifyStructureTimerHoursPresets() (minimum int, maximum int, def int) {
+	minimum = settingNotifyStructureTimerHoursMin
+	maximum = settingNotifyStructureTimerHoursMax
+	def = settingNotifyStructureTimerHoursDefault
+	return
+}
+
+func (s *Settings) SetNotifyStructureTimerHours(v int) {
+	if s == nil {
+		return
+	}
+	s.p.SetInt(settingNotifyStructureTimerHours, v)
+}
+
+// NotifyQuietHoursStart returns the hour of the day when quiet hours start.
+func (s *Settings) NotifyQuietHoursStart() int {
+	if s == nil {
+		return 0
+	}
+	return s.p.IntWithFallback(settingNotifyQuietHoursStart, settingNotifyQuietHoursStartDefault)
+}
+
+func (s *Settings) NotifyQuietHoursStartPresets() (minimum int, maximum int, def int) {
+	minimum = settingNotifyQuietHoursMin
+	maximum = settingNotifyQuietHoursMax
+	def = settingNotifyQuietHoursStartDefault
+	return
+}
+
+func (s *Settings) SetNotifyQuietHoursStart(v int) {
+	if s == nil {
+		return
+	}
+	s.p.SetInt(settingNotifyQuietHoursStart, v)
+}
+
+// NotifyQuietHoursEnd returns the hour of the day when quiet hours end.
+func (s *Settings) NotifyQuietHoursEnd() int {
+	if s == nil {
+		return 0
+	}
+	return s.p.IntWithFallback(settingNotifyQuietHoursEnd, settingNotifyQuietHoursEndDefault)
+}
+
+func (s *Settings) NotifyQuietHoursEndPresets() (minimum int, maximum int, def int) {
+	minimum = settingNotifyQuietHoursMin
+	maximum = settingNotifyQuietHoursMax
+	def = settingNotifyQuietHoursEndDefault
+	return
+}
+
+func (s *Settings) SetNotifyQuietHoursEnd(v int) {
+	if s == nil {
+		return
+	}
+	s.p.SetInt(settingNotifyQuietHoursEnd, v)
+}
+
+// NotificationRules returns the rules for notifications encoded as JSON.
+// Returns an empty string when no rules have been configured.
+func (s *Settings) NotificationRules() string {
+	if s == nil {
+		return ""
+	}
+	return s.p.String(settingNotificationRules)
+}
+
+func (s *Settings) ResetNotificationRules() {
+	if s == nil {
+		return
+	}
+	s.SetNotificationRules("")
+}
+
+func (s *Settings) SetNotificationRules(v string) {
+	if s == nil {
+		return
+	}
+	s.p.SetString(settingNotificationRules, v)
+}
+
 func (s *Settings) NotificationTypesEnabled() set.Set[string] {
 	if s == nil {
 		return set.Set[string]{}
@@ -414,6 +728,78 @@ func (s *Settings) SetNotificationTypesEnabled(v set.Set[string]) {
 	s.p.SetStringList(settingNotificationTypesEnabled, slices.Collect(v.All()))
 }
 
+func (s *Settings) WebhookEnabled() bool {
+	if s == nil {
+		return false
+	}
+	return s.p.BoolWithFallback(settingWebhookEnabled, settingWebhookEnabledDefault)
+}
+
+func (s *Settings) WebhookEnabledDefault() bool {
+	if s == nil {
+		return false
+	}
+	return settingWebhookEnabledDefault
+}
+
+func (s *Settings) SetWebhookEnabled(v bool) {
+	if s == nil {
+		return
+	}
+	s.p.SetBool(settingWebhookEnabled, v)
+}
+
+func (s *Settings) WebhookFormat() string {
+	if s == nil {
+		return ""
+	}
+	return s.p.StringWithFallback(settingWebhookFormat, settingWebhookFormatDefault)
+}
+
+func (s *Settings) WebhookFormatDefault() string {
+	if s == nil {
+		return ""
+	}
+	return settingWebhookFormatDefault
+}
+
+func (s *Settings) SetWebhookFormat(v string) {
+	if s == nil {
+		return
+	}
+	s.p.SetString(settingWebhookFormat, v)
+}
+
+// WebhookTypes returns the notification types which are posted to the webhook.
+// An empty set means all types.
+func (s *Settings) WebhookTypes() set.Set[string] {
+	if s == nil {
+		return set.Set[string]{}
+	}
+	return set.Of(s.p.StringList(settingWebhookTypes)...)
+}
+
+func (s *Settings) SetWebhookTypes(v set.Set[string]) {
+	if s == nil {
+		return
+	}
+	s.p.SetStringList(settingWebhookTypes, slices.Collect(v.All()))
+}
+
+func (s *Settings) WebhookURL() string {
+	if s == nil {
+		return ""
+	}
+	return s.p.String(settingWebhookURL)
+}
+
+func (s *Settings) SetWebhookURL(v string) {
+	if s == nil {
+		return
+	}
+	s.p.SetString(settingWebhookURL, v)
+}
+
 func (s *Settings) NotifyCommunicationsEarliest() time.Time {
 	if s == nil {
 		return time.Time{}
@@ -442,6 +828,20 @@ func (s *Settings) SetNotifyContractsEarliest(t time.Time) {
 	s.setEarliest(settingNotifyContractsEarliest, t)
 }
 
+func (s *Settings) NotifyIndustryJobsEarliest() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	return s.calcNotifyEarliest(settingNotifyIndustryJobsEarliest)
+}
+
+func (s *Settings) SetNotifyIndustryJobsEarliest(t time.Time) {
+	if s == nil {
+		return
+	}
+	s.setEarliest(settingNotifyIndustryJobsEarliest, t)
+}
+
 func (s *Settings) NotifyMailsEarliest() time.Time {
 	if s == nil {
 		return time.Time{}
@@ -456,6 +856,20 @@ func (s *Settings) SetNotifyMailsEarliest(t time.Time) {
 	s.setEarliest(settingNotifyMailsEarliest, t)
 }
 
+func (s *Settings) NotifyMarketOrdersEarliest() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	return s.calcNotifyEarliest(settingNotifyMarketOrdersEarliest)
+}
+
+func (s *Settings) SetNotifyMarketOrdersEarliest(t time.Time) {
+	if s == nil {
+		return
+	}
+	s.setEarliest(settingNotifyMarketOrdersEarliest, t)
+}
+
 func (s *Settings) NotifyPIEarliest() time.Time {
 	if s == nil {
 		return time.Time{}
@@ -470,6 +884,20 @@ func (s *Settings) SetNotifyPIEarliest(t time.Time) {
 	s.setEarliest(settingNotifyPIEarliest, t)
 }
 
+func (s *Settings) NotifyStructuresEarliest() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	return s.calcNotifyEarliest(settingNotifyStructuresEarliest)
+}
+
+func (s *Settings) SetNotifyStructuresEarliest(t time.Time) {
+	if s == nil {
+		return
+	}
+	s.setEarliest(settingNotifyStructuresEarliest, t)
+}
+
 func (s *Settings) NotifyTrainingEarliest() time.Time {
 	if s == nil {
 		return time.Time{}
@@ -555,6 +983,48 @@ func (s *Settings) SetNotifyCommunicationsEnabled(v bool) {
 	s.p.SetBool(settingNotifyCommunicationsEnabled, v)
 }
 
+func (s *Settings) NotifyQuietHoursEnabled() bool {
+	if s == nil {
+		return false
+	}
+	return s.p.BoolWithFallback(settingNotifyQuietHoursEnabled, settingNotifyQuietHoursEnabledDefault)
+}
+
+func (s *Settings) NotifyQuietHoursEnabledDefault() bool {
+	if s == nil {
+		return false
+	}
+	return settingNotifyQuietHoursEnabledDefault
+}
+
+func (s *Settings) SetNotifyQuietHoursEnabled(v bool) {
+	if s == nil {
+		return
+	}
+	s.p.SetBool(settingNotifyQuietHoursEnabled, v)
+}
+
+func (s *Settings) NotifyCalendarEnabled() bool {
+	if s == nil {
+		return false
+	}
+	return s.p.BoolWithFallback(settingNotifyCalendarEnabled, settingNotifyCalendarEnabledDefault)
+}
+
+func (s *Settings) NotifyCalendarEnabledDefault() bool {
+	if s == nil {
+		return false
+	}
+	return settingNotifyCalendarEnabledDefault
+}
+
+func (s *Settings) SetNotifyCalendarEnabled(v bool) {
+	if s == nil {
+		return
+	}
+	s.p.SetBool(settingNotifyCalendarEnabled, v)
+}
+
 func (s *Settings) NotifyContractsEnabled() bool {
 	if s == nil {
 		return false
@@ -576,6 +1046,90 @@ func (s *Settings) SetNotifyContractsEnabled(v bool) {
 	s.p.SetBool(settingNotifyContractsEnabled, v)
 }
 
+func (s *Settings) NotifyJumpFatigueEnabled() bool {
+	if s == nil {
+		return false
+	}
+	return s.p.BoolWithFallback(settingNotifyJumpFatigueEnabled, settingNotifyJumpFatigueEnabledDefault)
+}
+
+func (s *Settings) NotifyJumpFatigueEnabledDefault() bool {
+	if s == nil {
+		return false
+	}
+	return settingNotifyJumpFatigueEnabledDefault
+}
+
+func (s *Settings) SetNotifyJumpFatigueEnabled(v bool) {
+	if s == nil {
+		return
+	}
+	s.p.SetBool(settingNotifyJumpFatigueEnabled, v)
+}
+
+func (s *Settings) NotifyIndustryJobsEnabled() bool {
+	if s == nil {
+		return false
+	}
+	return s.p.BoolWithFallback(settingNotifyIndustryJobsEnabled, settingNotifyIndustryJobsEnabledDefault)
+}
+
+func (s *Settings) NotifyIndustryJobsEnabledDefault() bool {
+	if s == nil {
+		return false
+	}
+	return settingNotifyIndustryJobsEnabledDefault
+}
+
+func (s *Settings) SetNotifyIndustryJobsEnabled(v bool) {
+	if s == nil {
+		return
+	}
+	s.p.SetBool(settingNotifyIndustryJobsEnabled, v)
+}
+
+func (s *Settings) NotifyMarketOrdersEnabled() bool {
+	if s == nil {
+		return false
+	}
+	return s.p.BoolWithFallback(settingNotifyMarketOrdersEnabled, settingNotifyMarketOrdersEnabledDefault)
+}
+
+func (s *Settings) NotifyMarketOrdersEnabledDefault() bool {
+	if s == nil {
+		return false
+	}
+	return settingNotifyMarketOrdersEnabledDefault
+}
+
+func (s *Settings) SetNotifyMarketOrdersEnabled(v bool) {
+	if s == nil {
+		return
+	}
+	s.p.SetBool(settingNotifyMarketOrdersEnabled, v)
+}
+
+func (s *Settings) NotifyMarketUndercutEnabled() bool {
+	if s == nil {
+		return false
+	}
+	return s.p.BoolWithFallback(settingNotifyMarketUndercutEnabled, settingNotifyMarketUndercutEnabledDefault)
+}
+
+func (s *Settings) NotifyMarketUndercutEnabledDefault() bool {
+	if s == nil {
+		return false
+	}
+	return settingNotifyMarketUndercutEnabledDefault
+}
+
+func (s *Settings) SetNotifyMarketUndercutEnabled(v bool) {
+	if s == nil {
+		return
+	}
+	s.p.SetBool(settingNotifyMarketUndercutEnabled, v)
+}
+
 func (s *Settings) NotifyMailsEnabled() bool {
 	if s == nil {
 		return false
@@ -618,6 +1172,27 @@ func (s *Settings) SetNotifyPIEnabled(v bool) {
 	s.p.SetBool(settingNotifyPIEnabled, v)
 }
 
+func (s *Settings) NotifyStructuresEnabled() bool {
+	if s == nil {
+		return false
+	}
+	return s.p.BoolWithFallback(settingNotifyStructuresEnabled, settingNotifyStructuresEnabledDefault)
+}
+
+func (s *Settings) NotifyStructuresEnabledDefault() bool {
+	if s == nil {
+		return false
+	}
+	return settingNotifyStructuresEnabledDefault
+}
+
+func (s *Settings) SetNotifyStructuresEnabled(v bool) {
+	if s == nil {
+		return
+	}
+	s.p.SetBool(settingNotifyStructuresEnabled, v)
+}
+
 func (s *Settings) NotifyTrainingEnabled() bool {
 	if s == nil {
 		return false
@@ -664,6 +1239,72 @@ func (s *Settings) SetRecentSearches(v []int64) {
 	}))
 }
 
+func (s *Settings) LocalAPIEnabled() bool {
+	if s == nil {
+		return false
+	}
+	return s.p.BoolWithFallback(settingLocalAPIEnabled, settingLocalAPIEnabledDefault)
+}
+
+func (s *Settings) LocalAPIEnabledDefault() bool {
+	if s == nil {
+		return false
+	}
+	return settingLocalAPIEnabledDefault
+}
+
+func (s *Settings) SetLocalAPIEnabled(v bool) {
+	if s == nil {
+		return
+	}
+	s.p.SetBool(settingLocalAPIEnabled, v)
+}
+
+func (s *Settings) LocalAPIPort() int {
+	if s == nil {
+		return 0
+	}
+	return s.p.IntWithFallback(settingLocalAPIPort, settingLocalAPIPortDefault)
+}
+
+func (s *Settings) LocalAPIPortPresets() (minimum int, maximum int, def int) {
+	minimum = settingLocalAPIPortMin
+	maximum = settingLocalAPIPortMax
+	def = settingLocalAPIPortDefault
+	return
+}
+
+func (s *Settings) SetLocalAPIPort(v int) {
+	if s == nil {
+		return
+	}
+	s.p.SetInt(settingLocalAPIPort, v)
+}
+
+// LocalAPIToken returns the access token for the local API.
+// A new random token is created when none exists yet.
+func (s *Settings) LocalAPIToken() string {
+	if s == nil {
+		return ""
+	}
+	v := s.p.String(settingLocalAPIToken)
+	if v == "" {
+		v = s.ResetLocalAPIToken()
+	}
+	return v
+}
+
+// ResetLocalAPIToken replaces the access token for the local API
+// with a new random token and returns it.
+func (s *Settings) ResetLocalAPIToken() string {
+	if s == nil {
+		return ""
+	}
+	v := rand.Text()
+	s.p.SetString(settingLocalAPIToken, v)
+	return v
+}
+
 func (s *Settings) PreferMarketTab() bool {
 	if s == nil {
 		return false
@@ -799,25 +1440,53 @@ func (s *Settings) ResetUI() {
 // Keys returns all setting keys. Mostly to know what to delete.
 func Keys() []string {
 	return []string{
+		settingBackupAutoEnabled,
+		settingBackupExcludeCache,
+		settingBackupIntervalHours,
+		settingBackupRetention,
 		settingDeveloperMode,
 		settingLastCharacterID,
+		settingLocalAPIEnabled,
+		settingLocalAPIPort,
+		settingLocalAPIToken,
 		settingMaxMails,
 		settingMaxWalletTransactions,
+		settingMemberInactiveDays,
+		settingNotificationRules,
 		settingNotificationTypesEnabled,
+		settingNotifyCalendarEnabled,
+		settingNotifyCalendarLeadMinutes,
 		settingNotifyCommunicationsEarliest,
 		settingNotifyCommunicationsEnabled,
 		settingNotifyContractsEarliest,
 		settingNotifyContractsEnabled,
+		settingNotifyIndustryJobsEarliest,
+		settingNotifyIndustryJobsEnabled,
+		settingNotifyJumpFatigueEnabled,
 		settingNotifyMailsEarliest,
 		settingNotifyMailsEnabled,
+		settingNotifyMarketOrdersEarliest,
+		settingNotifyMarketOrdersEnabled,
+		settingNotifyMarketUndercutEnabled,
 		settingNotifyPIEarliest,
 		settingNotifyPIEnabled,
+		settingNotifyQuietHoursEnabled,
+		settingNotifyQuietHoursEnd,
+		settingNotifyQuietHoursStart,
+		settingNotifyStructureFuelHours,
+		settingNotifyStructureTimerHours,
+		settingNotifyStructuresEarliest,
+		settingNotifyStructuresEnabled,
 		settingNotifyTimeoutHours,
 		settingNotifyTrainingEarliest,
 		settingNotifyTrainingEnabled,
 		settingRecentSearches,
 		settingSysTrayEnabled,
 		settingTabsMainID,
+		settingWebhookEnabled,
+		settingWebhookFormat,
+		settingWebhookTypes,
+		settingWebhookURL,
 		settingWindowsSize,
 	}
 }
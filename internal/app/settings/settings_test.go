@@ -6,6 +6,7 @@ import (
 
 	"fyne.io/fyne/v2"
 	"github.com/ErikKalkoken/go-set"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/ErikKalkoken/evebuddy/internal/app/settings"
 	"github.com/ErikKalkoken/evebuddy/internal/xassert"
@@ -42,6 +43,14 @@ func TestSettings(t *testing.T) {
 		want := s.NotificationTypesEnabled()
 		xassert.Equal(t, want, got)
 	})
+	t.Run("CorporationMemberInactiveDays", func(t *testing.T) {
+		p := settings.NewMyPref()
+		s := settings.New(p)
+		_, _, def := s.CorporationMemberInactiveDaysPresets()
+		xassert.Equal(t, def, s.CorporationMemberInactiveDays())
+		s.SetCorporationMemberInactiveDays(14)
+		xassert.Equal(t, 14, s.CorporationMemberInactiveDays())
+	})
 }
 
 func TestColorTheme(t *testing.T) {
@@ -57,3 +66,72 @@ func TestColorTheme(t *testing.T) {
 	xassert.Equal(t, settings.Dark, x1)
 	})
 }
+
+func TestLocalAPIToken(t *testing.T) {
+	t.Run("should create token when none exists", func(t *testing.T) {
+		s := settings.New(settings.NewMyPref())
+		x1 := s.LocalAPIToken()
+		assert.NotEmpty(t, x1)
+		x2 := s.LocalAPIToken()
+		assert.Equal(t, x1, x2)
+	})
+	t.Run("can reset token", func(t *testing.T) {
+		s := settings.New(settings.NewMyPref())
+		x1 := s.LocalAPIToken()
+		x2 := s.ResetLocalAPIToken()
+		assert.NotEqual(t, x1, x2)
+		assert.Equal(t, x2, s.LocalAPIToken())
+	})
+}
+
+func TestWebhook(t *testing.T) {
+	t.Run("should have defaults", func(t *testing.T) {
+		s := settings.New(settings.NewMyPref())
+		assert.False(t, s.WebhookEnabled())
+		assert.Equal(t, s.WebhookFormatDefault(), s.WebhookFormat())
+		assert.Equal(t, "", s.WebhookURL())
+		assert.Equal(t, 0, s.WebhookTypes().Size())
+	})
+	t.Run("can set and get types", func(t *testing.T) {
+		s := settings.New(settings.NewMyPref())
+		x := set.Of("Mail", "StructureUnderAttack")
+		s.SetWebhookTypes(x)
+		xassert.Equal(t, x, s.WebhookTypes())
+	})
+}
+
+func TestNotifyStructures(t *testing.T) {
+	t.Run("should have defaults", func(t *testing.T) {
+		s := settings.New(settings.NewMyPref())
+		assert.False(t, s.NotifyStructuresEnabled())
+		assert.Equal(t, s.NotifyStructureFuelHoursDefault(), s.NotifyStructureFuelHours())
+		_, _, def := s.NotifyStructureTimerHoursPresets()
+		assert.Equal(t, def, s.NotifyStructureTimerHours())
+	})
+	t.Run("can set and get fuel thresholds", func(t *testing.T) {
+		s := settings.New(settings.NewMyPref())
+		s.SetNotifyStructureFuelHours([]int{48, 12})
+		assert.Equal(t, []int{48, 12}, s.NotifyStructureFuelHours())
+		s.ResetNotifyStructureFuelHours()
+		assert.Equal(t, s.NotifyStructureFuelHoursDefault(), s.NotifyStructureFuelHours())
+	})
+}
+
+func TestNotificationRulesAndQuietHours(t *testing.T) {
+	t.Run("should have defaults", func(t *testing.T) {
+		s := settings.New(settings.NewMyPref())
+		assert.False(t, s.NotifyQuietHoursEnabled())
+		assert.Equal(t, "", s.NotificationRules())
+		_, _, start := s.NotifyQuietHoursStartPresets()
+		assert.Equal(t, start, s.NotifyQuietHoursStart())
+		_, _, end := s.NotifyQuietHoursEndPresets()
+		assert.Equal(t, end, s.NotifyQuietHoursEnd())
+	})
+	t.Run("can set and reset rules", func(t *testing.T) {
+		s := settings.New(settings.NewMyPref())
+		s.SetNotificationRules(`[{"name":"alpha"}]`)
+		assert.Equal(t, `[{"name":"alpha"}]`, s.NotificationRules())
+		s.ResetNotificationRules()
+		assert.Equal(t, "", s.NotificationRules())
+	})
+}
@@ -0,0 +1,281 @@
+package app
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+// Constraints for remapping the base attributes of a character.
+const (
+	remapAttributeMin   = 17
+	remapAttributeMax   = 27
+	remapAttributeTotal = 99
+)
+
+// CharacterSkillPlan is a named plan for training skills of a character.
+// Each item in a plan is a single skill level and items are ordered by training sequence.
+type CharacterSkillPlan struct {
+	CharacterID int64
+	ID          int64
+	Items       []*CharacterSkillPlanItem
+	Name        string
+}
+
+// CharacterSkillPlanItem is a skill level to be trained in a skill plan.
+type CharacterSkillPlanItem struct {
+	Level int
+	Type  *EveType
+}
+
+// Contains reports whether a skill level is part of the plan.
+func (p CharacterSkillPlan) Contains(typeID int64, level int) bool {
+	return slices.ContainsFunc(p.Items, func(x *CharacterSkillPlanItem) bool {
+		return x.Type.ID == typeID && x.Level == level
+	})
+}
+
+// AddSkill adds a skill up to the given level to the plan.
+// All missing prerequisites are added before the skill.
+// Levels which are already planned or trained as reported by trained are skipped.
+func (p *CharacterSkillPlan) AddSkill(typeID int64, level int, skills map[int64]*EveSkill, trained map[int64]int) error {
+	if level < 1 || level > 5 {
+		return fmt.Errorf("invalid skill level %d: %w", level, ErrInvalid)
+	}
+	if _, ok := skills[typeID]; !ok {
+		return fmt.Errorf("unknown skill %d: %w", typeID, ErrNotFound)
+	}
+	var add func(typeID int64, level int)
+	add = func(typeID int64, level int) {
+		skill, ok := skills[typeID]
+		if !ok {
+			return
+		}
+		for _, k := range slices.Sorted(maps.Keys(skill.Requirements)) {
+			r := skill.Requirements[k]
+			if r.Type == nil || r.Type.ID == typeID {
+				continue
+			}
+			add(r.Type.ID, r.Level)
+		}
+		for l := 1; l <= level; l++ {
+			if trained[typeID] >= l || p.Contains(typeID, l) {
+				continue
+			}
+			p.Items = append(p.Items, &CharacterSkillPlanItem{Level: l, Type: skill.Type})
+		}
+	}
+	add(typeID, level)
+	return nil
+}
+
+// RemoveSkill removes a skill from the given level upwards from the plan.
+// All planned skills which depend on the removed levels are removed as well.
+func (p *CharacterSkillPlan) RemoveSkill(typeID int64, level int, skills map[int64]*EveSkill) {
+	removed := map[int64]int{typeID: level}
+	isRemoved := func(typeID int64, level int) bool {
+		l, ok := removed[typeID]
+		return ok && level >= l
+	}
+	for {
+		var changed bool
+		p.Items = slices.DeleteFunc(p.Items, func(x *CharacterSkillPlanItem) bool {
+			if isRemoved(x.Type.ID, x.Level) {
+				return true
+			}
+			skill, ok := skills[x.Type.ID]
+			if !ok {
+				return false
+			}
+			for _, r := range skill.Requirements {
+				if r.Type == nil || !isRemoved(r.Type.ID, r.Level) {
+					continue
+				}
+				if l, ok := removed[x.Type.ID]; !ok || x.Level < l {
+					removed[x.Type.ID] = x.Level
+				}
+				changed = true
+				return true
+			}
+			return false
+		})
+		if !changed {
+			return
+		}
+	}
+}
+
+// Export returns the plan in the clipboard format of the game client and EVEMon.
+func (p CharacterSkillPlan) Export() string {
+	var sb strings.Builder
+	for _, it := range p.Items {
+		fmt.Fprintf(&sb, "%s %d\n", it.Type.Name, it.Level)
+	}
+	return sb.String()
+}
+
+// SkillPlanEntry is an item of a skill plan with its training estimate.
+type SkillPlanEntry struct {
+	IsTrained          bool
+	Item               *CharacterSkillPlanItem
+	PrimaryAttribute   optional.Optional[int64]
+	SecondaryAttribute optional.Optional[int64]
+	SkillPoints        optional.Optional[int64]         // skill points still needed for this level
+	TrainingTime       optional.Optional[time.Duration] // empty when training speed is unknown
+}
+
+// SkillPlanEstimate is the training estimate for a skill plan.
+type SkillPlanEstimate struct {
+	Attributes        optional.Optional[TrainingAttributes] // current attributes incl. implants
+	Entries           []SkillPlanEntry
+	Implants          TrainingAttributes                    // attribute bonuses from implants
+	Remap             optional.Optional[TrainingAttributes] // optimal base attributes for the plan
+	RemapTrainingTime optional.Optional[time.Duration]      // training time after an optimal remap
+}
+
+// TrainingTime returns the total time needed to train the plan.
+// Returns an empty value when the training time of any entry is unknown.
+func (e SkillPlanEstimate) TrainingTime() optional.Optional[time.Duration] {
+	var total time.Duration
+	for _, x := range e.Entries {
+		if x.IsTrained {
+			continue
+		}
+		d, ok := x.TrainingTime.Value()
+		if !ok {
+			return optional.Optional[time.Duration]{}
+		}
+		total += d
+	}
+	return optional.New(total)
+}
+
+// EstimateSkillPlan returns the training estimate for a skill plan.
+//
+// Attributes are the current attributes of a character as reported by ESI,
+// which include the bonuses from implants.
+// The optimal remap is calculated for the base attributes, i.e. without implants.
+func EstimateSkillPlan(
+	p CharacterSkillPlan,
+	skills map[int64]*EveSkill,
+	characterSkills map[int64]*CharacterSkill,
+	attributes optional.Optional[TrainingAttributes],
+	implants TrainingAttributes,
+) SkillPlanEstimate {
+	e := SkillPlanEstimate{
+		Attributes: attributes,
+		Implants:   implants,
+	}
+	for _, it := range p.Items {
+		x := SkillPlanEntry{Item: it}
+		var activeLevel int
+		var skillPoints int64
+		if cs, ok := characterSkills[it.Type.ID]; ok {
+			activeLevel = int(cs.ActiveSkillLevel)
+			skillPoints = cs.SkillPointsInSkill
+		}
+		if activeLevel >= it.Level {
+			x.IsTrained = true
+			e.Entries = append(e.Entries, x)
+			continue
+		}
+		skill, ok := skills[it.Type.ID]
+		if !ok {
+			e.Entries = append(e.Entries, x)
+			continue
+		}
+		x.PrimaryAttribute = skill.PrimaryAttribute
+		x.SecondaryAttribute = skill.SecondaryAttribute
+		rank, ok := skill.Rank.Value()
+		if !ok {
+			e.Entries = append(e.Entries, x)
+			continue
+		}
+		start := SkillPointsForLevel(rank, it.Level-1)
+		if activeLevel == it.Level-1 {
+			start = max(start, skillPoints)
+		}
+		sp := max(0, SkillPointsForLevel(rank, it.Level)-start)
+		x.SkillPoints.Set(sp)
+		if ta, ok := attributes.Value(); ok {
+			x.TrainingTime = calcTrainingTime(ta, x)
+		}
+		e.Entries = append(e.Entries, x)
+	}
+	if remap, d, ok := calcOptimalRemap(e.Entries, implants); ok {
+		e.Remap.Set(remap)
+		e.RemapTrainingTime.Set(d)
+	}
+	return e
+}
+
+func calcTrainingTime(ta TrainingAttributes, x SkillPlanEntry) optional.Optional[time.Duration] {
+	var d optional.Optional[time.Duration]
+	sp, ok := x.SkillPoints.Value()
+	if !ok || x.PrimaryAttribute.IsEmpty() || x.SecondaryAttribute.IsEmpty() {
+		return d
+	}
+	speed := ta.SkillPointsPerMinute(x.PrimaryAttribute.ValueOrZero(), x.SecondaryAttribute.ValueOrZero())
+	if speed <= 0 {
+		return d
+	}
+	d.Set(time.Duration(float64(sp) / speed * float64(time.Minute)))
+	return d
+}
+
+// calcOptimalRemap returns the base attributes with the shortest training time for all entries
+// and that training time. It reports false when there is nothing to train
+// or the training time of an entry can not be calculated.
+func calcOptimalRemap(entries []SkillPlanEntry, implants TrainingAttributes) (TrainingAttributes, time.Duration, bool) {
+	type attributePair struct {
+		primary, secondary int64
+	}
+	// skill points are summed up per attribute pair to speed up the search
+	skillPoints := make(map[attributePair]float64)
+	for _, x := range entries {
+		if x.IsTrained {
+			continue
+		}
+		sp, ok := x.SkillPoints.Value()
+		if !ok || x.PrimaryAttribute.IsEmpty() || x.SecondaryAttribute.IsEmpty() {
+			return TrainingAttributes{}, 0, false
+		}
+		skillPoints[attributePair{x.PrimaryAttribute.ValueOrZero(), x.SecondaryAttribute.ValueOrZero()}] += float64(sp)
+	}
+	if len(skillPoints) == 0 {
+		return TrainingAttributes{}, 0, false
+	}
+	pairs := slices.SortedFunc(maps.Keys(skillPoints), func(a, b attributePair) int {
+		return cmp.Or(cmp.Compare(a.primary, b.primary), cmp.Compare(a.secondary, b.secondary))
+	})
+	var best TrainingAttributes
+	bestMinutes := -1.0
+	for c := int64(remapAttributeMin); c <= remapAttributeMax; c++ {
+		for i := int64(remapAttributeMin); i <= remapAttributeMax; i++ {
+			for m := int64(remapAttributeMin); m <= remapAttributeMax; m++ {
+				for p := int64(remapAttributeMin); p <= remapAttributeMax; p++ {
+					w := remapAttributeTotal - c - i - m - p
+					if w < remapAttributeMin || w > remapAttributeMax {
+						continue
+					}
+					base := TrainingAttributes{Charisma: c, Intelligence: i, Memory: m, Perception: p, Willpower: w}
+					ta := base.Add(implants)
+					var minutes float64
+					for _, k := range pairs {
+						minutes += skillPoints[k] / ta.SkillPointsPerMinute(k.primary, k.secondary)
+					}
+					if bestMinutes < 0 || minutes < bestMinutes {
+						best = base
+						bestMinutes = minutes
+					}
+				}
+			}
+		}
+	}
+	return best, time.Duration(bestMinutes * float64(time.Minute)), true
+}
@@ -0,0 +1,149 @@
+package app_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func makeSkillPlanSkills() (alpha, bravo, charlie *app.EveType, skills map[int64]*app.EveSkill) {
+	alpha = &app.EveType{ID: 1, Name: "Alpha"}
+	bravo = &app.EveType{ID: 2, Name: "Bravo"}
+	charlie = &app.EveType{ID: 3, Name: "Charlie"}
+	makeSkill := func(et *app.EveType, requirements map[int]*app.EveRequiredSkill) *app.EveSkill {
+		return &app.EveSkill{
+			PrimaryAttribute:   optional.New[int64](app.EveDogmaAttributeIntelligence),
+			Rank:               optional.New(1),
+			Requirements:       requirements,
+			SecondaryAttribute: optional.New[int64](app.EveDogmaAttributeMemory),
+			Type:               et,
+		}
+	}
+	skills = map[int64]*app.EveSkill{
+		1: makeSkill(alpha, map[int]*app.EveRequiredSkill{0: {Type: bravo, Level: 2}}),
+		2: makeSkill(bravo, map[int]*app.EveRequiredSkill{0: {Type: charlie, Level: 1}}),
+		3: makeSkill(charlie, nil),
+	}
+	return
+}
+
+func skillPlanItemNames(p app.CharacterSkillPlan) []string {
+	return xslices.Map(p.Items, func(x *app.CharacterSkillPlanItem) string {
+		return fmt.Sprintf("%s %d", x.Type.Name, x.Level)
+	})
+}
+
+func TestCharacterSkillPlan_AddSkill(t *testing.T) {
+	alpha, _, _, skills := makeSkillPlanSkills()
+	t.Run("should add skill with missing prerequisites", func(t *testing.T) {
+		var p app.CharacterSkillPlan
+		err := p.AddSkill(alpha.ID, 2, skills, map[int64]int{3: 1})
+		require.NoError(t, err)
+		want := []string{"Bravo 1", "Bravo 2", "Alpha 1", "Alpha 2"}
+		xassert.Equal(t, want, skillPlanItemNames(p))
+	})
+	t.Run("should not add planned levels again", func(t *testing.T) {
+		var p app.CharacterSkillPlan
+		require.NoError(t, p.AddSkill(alpha.ID, 1, skills, nil))
+		require.NoError(t, p.AddSkill(alpha.ID, 2, skills, nil))
+		want := []string{"Charlie 1", "Bravo 1", "Bravo 2", "Alpha 1", "Alpha 2"}
+		xassert.Equal(t, want, skillPlanItemNames(p))
+	})
+	t.Run("should return error when level is invalid", func(t *testing.T) {
+		var p app.CharacterSkillPlan
+		err := p.AddSkill(alpha.ID, 6, skills, nil)
+		require.ErrorIs(t, err, app.ErrInvalid)
+	})
+	t.Run("should return error when skill is unknown", func(t *testing.T) {
+		var p app.CharacterSkillPlan
+		err := p.AddSkill(42, 1, skills, nil)
+		require.ErrorIs(t, err, app.ErrNotFound)
+	})
+}
+
+func TestCharacterSkillPlan_RemoveSkill(t *testing.T) {
+	alpha, bravo, _, skills := makeSkillPlanSkills()
+	var p app.CharacterSkillPlan
+	require.NoError(t, p.AddSkill(alpha.ID, 3, skills, nil))
+	p.RemoveSkill(bravo.ID, 2, skills)
+	xassert.Equal(t, []string{"Charlie 1", "Bravo 1"}, skillPlanItemNames(p))
+}
+
+func TestCharacterSkillPlan_Export(t *testing.T) {
+	alpha, bravo, _, _ := makeSkillPlanSkills()
+	p := app.CharacterSkillPlan{Items: []*app.CharacterSkillPlanItem{
+		{Level: 1, Type: bravo},
+		{Level: 1, Type: alpha},
+		{Level: 2, Type: alpha},
+	}}
+	xassert.Equal(t, "Bravo 1\nAlpha 1\nAlpha 2\n", p.Export())
+}
+
+func TestEstimateSkillPlan(t *testing.T) {
+	alpha, bravo, _, skills := makeSkillPlanSkills()
+	p := app.CharacterSkillPlan{Items: []*app.CharacterSkillPlanItem{
+		{Level: 1, Type: bravo},
+		{Level: 1, Type: alpha},
+	}}
+	attributes := optional.New(app.TrainingAttributes{Intelligence: 20, Memory: 20})
+	duration := func(sp, speed float64) time.Duration {
+		return time.Duration(sp / speed * float64(time.Minute))
+	}
+	t.Run("should calculate training time for each entry", func(t *testing.T) {
+		characterSkills := map[int64]*app.CharacterSkill{
+			1: {ActiveSkillLevel: 0, SkillPointsInSkill: 100, Type: alpha},
+		}
+		got := app.EstimateSkillPlan(p, skills, characterSkills, attributes, app.TrainingAttributes{})
+		if assert.Len(t, got.Entries, 2) {
+			xassert.EqualOptional(t, 250, got.Entries[0].SkillPoints)
+			xassert.EqualOptional(t, duration(250, 30), got.Entries[0].TrainingTime)
+			xassert.EqualOptional(t, 150, got.Entries[1].SkillPoints)
+		}
+		xassert.EqualOptional(t, duration(250, 30)+duration(150, 30), got.TrainingTime())
+	})
+	t.Run("should skip trained levels", func(t *testing.T) {
+		characterSkills := map[int64]*app.CharacterSkill{
+			2: {ActiveSkillLevel: 1, Type: bravo},
+		}
+		got := app.EstimateSkillPlan(p, skills, characterSkills, attributes, app.TrainingAttributes{})
+		if assert.Len(t, got.Entries, 2) {
+			assert.True(t, got.Entries[0].IsTrained)
+			assert.False(t, got.Entries[1].IsTrained)
+		}
+		xassert.EqualOptional(t, duration(250, 30), got.TrainingTime())
+	})
+	t.Run("should suggest optimal remap", func(t *testing.T) {
+		got := app.EstimateSkillPlan(p, skills, nil, attributes, app.TrainingAttributes{})
+		want := app.TrainingAttributes{
+			Charisma:     17,
+			Intelligence: 27,
+			Memory:       21,
+			Perception:   17,
+			Willpower:    17,
+		}
+		xassert.EqualOptional(t, want, got.Remap)
+		xassert.EqualOptional(t, duration(500, 37.5), got.RemapTrainingTime)
+	})
+	t.Run("should report no training time when attributes are unknown", func(t *testing.T) {
+		got := app.EstimateSkillPlan(p, skills, nil, optional.Optional[app.TrainingAttributes]{}, app.TrainingAttributes{})
+		xassert.Empty(t, got.TrainingTime())
+		assert.False(t, got.Remap.IsEmpty())
+	})
+}
+
+func TestImplantAttributeBonuses(t *testing.T) {
+	values := map[int64]float64{
+		app.EveDogmaAttributeIntelligenceModifier: 5,
+		app.EveDogmaAttributeMemoryModifier:       3,
+	}
+	got := app.ImplantAttributeBonuses(values)
+	xassert.Equal(t, app.TrainingAttributes{Intelligence: 5, Memory: 3}, got)
+}
@@ -6,7 +6,8 @@ import (
 )
 
 var (
-	ErrTokenError = errors.New("token error")
+	ErrTokenError          = errors.New("token error")
+	ErrTokenKeyUnavailable = errors.New("token encryption key unavailable")
 )
 
 // Token represents an OAuth token for a character in EVE Online.
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+// StandingFromType represents the kind of NPC entity a standing is from.
+type StandingFromType string
+
+const (
+	StandingFromAgent   StandingFromType = "agent"
+	StandingFromFaction StandingFromType = "faction"
+	StandingFromNPCCorp StandingFromType = "npc_corp"
+)
+
+func (t StandingFromType) Display() string {
+	switch t {
+	case StandingFromAgent:
+		return "Agent"
+	case StandingFromFaction:
+		return "Faction"
+	case StandingFromNPCCorp:
+		return "Corporation"
+	}
+	return "?"
+}
+
+// pirateFactionIDs are the IDs of the pirate factions.
+// Positive standings towards them are modified by the skill Criminal Connections.
+var pirateFactionIDs = set.Of[int64](
+	500010, // Guristas Pirates
+	500011, // Angel Cartel
+	500012, // Blood Raider Covenant
+	500019, // Sansha's Nation
+	500020, // Serpentis
+)
+
+// agentLevelRequirements are the minimum effective standings
+// required to access agents of level 2 to 5.
+var agentLevelRequirements = []float64{1.0, 3.0, 5.0, 7.0}
+
+// agentStandingBlocked is the standing at or below which agents refuse to work for a character.
+const agentStandingBlocked = -2.0
+
+// CharacterStanding represents the standing of an NPC entity towards a character.
+type CharacterStanding struct {
+	CharacterID int64
+	Faction     optional.Optional[*EveEntity] // faction of an NPC corporation
+	From        *EveEntity
+	FromType    StandingFromType
+	Standing    float64
+}
+
+// IsPirate reports whether a standing is from a pirate faction or one of its corporations.
+func (cs CharacterStanding) IsPirate() bool {
+	if cs.FromType == StandingFromFaction && cs.From != nil {
+		return pirateFactionIDs.Contains(cs.From.ID)
+	}
+	if f, ok := cs.Faction.Value(); ok && f != nil {
+		return pirateFactionIDs.Contains(f.ID)
+	}
+	return false
+}
+
+// EffectiveStanding returns the standing after the social skills of a character are applied.
+func (cs CharacterStanding) EffectiveStanding(skills StandingSkills) float64 {
+	return EffectiveStanding(cs.Standing, cs.IsPirate(), skills)
+}
+
+// StandingSkills represents the levels of the skills of a character which modify NPC standings.
+type StandingSkills struct {
+	Connections         int
+	CriminalConnections int
+	Diplomacy           int
+}
+
+// EffectiveStanding returns the effective standing for a base standing.
+// Negative standings are modified by Diplomacy, positive standings
+// by Connections or by Criminal Connections for pirates.
+// Each skill level moves the standing 4% closer to 10.
+func EffectiveStanding(base float64, isPirate bool, skills StandingSkills) float64 {
+	var level int
+	switch {
+	case base < 0:
+		level = skills.Diplomacy
+	case isPirate:
+		level = skills.CriminalConnections
+	default:
+		level = skills.Connections
+	}
+	return base + (10-base)*0.04*float64(level)
+}
+
+// MaxAgentLevel returns the highest agent level a character can access
+// with the given effective standings, e.g. towards an agent, its corporation and its faction.
+// The highest standing must meet the requirement of a level
+// and none of the standings must be -2 or below.
+// Returns 0 when agents refuse to work for the character.
+func MaxAgentLevel(standings ...float64) int {
+	var best float64
+	for i, s := range standings {
+		if s <= agentStandingBlocked {
+			return 0
+		}
+		if i == 0 || s > best {
+			best = s
+		}
+	}
+	level := 1
+	for i, r := range agentLevelRequirements {
+		if best >= r {
+			level = i + 2
+		}
+	}
+	return level
+}
@@ -0,0 +1,97 @@
+package app_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+func TestEffectiveStanding(t *testing.T) {
+	skills := app.StandingSkills{Connections: 4, CriminalConnections: 2, Diplomacy: 5}
+	cases := []struct {
+		base     float64
+		isPirate bool
+		skills   app.StandingSkills
+		want     float64
+	}{
+		{0, false, app.StandingSkills{}, 0},
+		{0, false, skills, 1.6},
+		{5, false, skills, 5.8},
+		{5, true, skills, 5.4},
+		{-5, false, skills, -2},
+		{-5, true, skills, -2},
+		{10, false, skills, 10},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("base %v pirate %v", tc.base, tc.isPirate), func(t *testing.T) {
+			got := app.EffectiveStanding(tc.base, tc.isPirate, tc.skills)
+			assert.InDelta(t, tc.want, got, 0.0001)
+		})
+	}
+}
+
+func TestCharacterStanding_IsPirate(t *testing.T) {
+	guristas := &app.EveEntity{ID: 500010, Category: app.EveEntityFaction}
+	caldari := &app.EveEntity{ID: 500001, Category: app.EveEntityFaction}
+	cases := []struct {
+		name string
+		cs   app.CharacterStanding
+		want bool
+	}{
+		{"pirate faction", app.CharacterStanding{From: guristas, FromType: app.StandingFromFaction}, true},
+		{"empire faction", app.CharacterStanding{From: caldari, FromType: app.StandingFromFaction}, false},
+		{
+			"pirate corporation",
+			app.CharacterStanding{
+				From:     &app.EveEntity{ID: 1000127},
+				FromType: app.StandingFromNPCCorp,
+				Faction:  optional.New(guristas),
+			},
+			true,
+		},
+		{
+			"empire corporation",
+			app.CharacterStanding{
+				From:     &app.EveEntity{ID: 1000035},
+				FromType: app.StandingFromNPCCorp,
+				Faction:  optional.New(caldari),
+			},
+			false,
+		},
+		{"agent", app.CharacterStanding{From: &app.EveEntity{ID: 3008416}, FromType: app.StandingFromAgent}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.cs.IsPirate())
+		})
+	}
+}
+
+func TestMaxAgentLevel(t *testing.T) {
+	cases := []struct {
+		standings []float64
+		want      int
+	}{
+		{[]float64{0}, 1},
+		{[]float64{0.99}, 1},
+		{[]float64{1}, 2},
+		{[]float64{3}, 3},
+		{[]float64{5.5}, 4},
+		{[]float64{7}, 5},
+		{[]float64{10}, 5},
+		{[]float64{-1.5}, 1},
+		{[]float64{-2}, 0},
+		{[]float64{2, 7.2}, 5},
+		{[]float64{7.2, -3}, 0},
+		{[]float64{-1, -1.5}, 1},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%v", tc.standings), func(t *testing.T) {
+			assert.Equal(t, tc.want, app.MaxAgentLevel(tc.standings...))
+		})
+	}
+}
@@ -17,42 +17,48 @@ import (
 )
 
 type CreateCharacterParams struct {
-	AssetValue         optional.Optional[float64]
-	ContractItemsValue optional.Optional[float64]
-	ContractsEscrow    optional.Optional[float64]
-	HomeID             optional.Optional[int64]
-	ID                 int64
-	IsTrainingWatched  bool
-	LastCloneJumpAt    optional.Optional[time.Time]
-	LastLoginAt        optional.Optional[time.Time]
-	LocationID         optional.Optional[int64]
-	OrderItemsValue    optional.Optional[float64]
-	OrdersEscrow       optional.Optional[float64]
-	ShipID             optional.Optional[int64]
-	SkillPointsValue   optional.Optional[float64]
-	TotalSP            optional.Optional[int]
-	UnallocatedSP      optional.Optional[int]
-	WalletBalance      optional.Optional[float64]
+	AssetValue           optional.Optional[float64]
+	ContractItemsValue   optional.Optional[float64]
+	ContractsEscrow      optional.Optional[float64]
+	HomeID               optional.Optional[int64]
+	ID                   int64
+	IsJumpFatigueWatched bool
+	IsTrainingWatched    bool
+	JumpFatigueExpiresAt optional.Optional[time.Time]
+	LastCloneJumpAt      optional.Optional[time.Time]
+	LastJumpAt           optional.Optional[time.Time]
+	LastLoginAt          optional.Optional[time.Time]
+	LocationID           optional.Optional[int64]
+	OrderItemsValue      optional.Optional[float64]
+	OrdersEscrow         optional.Optional[float64]
+	ShipID               optional.Optional[int64]
+	SkillPointsValue     optional.Optional[float64]
+	TotalSP              optional.Optional[int]
+	UnallocatedSP        optional.Optional[int]
+	WalletBalance        optional.Optional[float64]
 }
 
 func (st *Storage) CreateCharacter(ctx context.Context, arg CreateCharacterParams) error {
 	err := st.qRW.CreateCharacter(ctx, queries.CreateCharacterParams{
-		AssetValue:         optional.ToNullFloat64(arg.AssetValue),
-		ContractItemsValue: optional.ToNullFloat64(arg.ContractItemsValue),
-		ContractsEscrow:    optional.ToNullFloat64(arg.ContractsEscrow),
-		HomeID:             optional.ToNullInt64(arg.HomeID),
-		ID:                 arg.ID,
-		IsTrainingWatched:  arg.IsTrainingWatched,
-		LastCloneJumpAt:    optional.ToNullTime(arg.LastCloneJumpAt),
-		LastLoginAt:        optional.ToNullTime(arg.LastLoginAt),
-		LocationID:         optional.ToNullInt64(arg.LocationID),
-		OrderItemsValue:    optional.ToNullFloat64(arg.OrderItemsValue),
-		OrdersEscrow:       optional.ToNullFloat64(arg.OrdersEscrow),
-		ShipID:             optional.ToNullInt64(arg.ShipID),
-		SkillPointsValue:   optional.ToNullFloat64(arg.SkillPointsValue),
-		TotalSp:            optional.ToNullInt64(arg.TotalSP),
-		UnallocatedSp:      optional.ToNullInt64(arg.UnallocatedSP),
-		WalletBalance:      optional.ToNullFloat64(arg.WalletBalance),
+		AssetValue:           optional.ToNullFloat64(arg.AssetValue),
+		ContractItemsValue:   optional.ToNullFloat64(arg.ContractItemsValue),
+		ContractsEscrow:      optional.ToNullFloat64(arg.ContractsEscrow),
+		HomeID:               optional.ToNullInt64(arg.HomeID),
+		ID:                   arg.ID,
+		IsJumpFatigueWatched: arg.IsJumpFatigueWatched,
+		IsTrainingWatched:    arg.IsTrainingWatched,
+		JumpFatigueExpiresAt: optional.ToNullTime(arg.JumpFatigueExpiresAt),
+		LastCloneJumpAt:      optional.ToNullTime(arg.LastCloneJumpAt),
+		LastJumpAt:           optional.ToNullTime(arg.LastJumpAt),
+		LastLoginAt:          optional.ToNullTime(arg.LastLoginAt),
+		LocationID:           optional.ToNullInt64(arg.LocationID),
+		OrderItemsValue:      optional.ToNullFloat64(arg.OrderItemsValue),
+		OrdersEscrow:         optional.ToNullFloat64(arg.OrdersEscrow),
+		ShipID:               optional.ToNullInt64(arg.ShipID),
+		SkillPointsValue:     optional.ToNullFloat64(arg.SkillPointsValue),
+		TotalSp:              optional.ToNullInt64(arg.TotalSP),
+		UnallocatedSp:        optional.ToNullInt64(arg.UnallocatedSP),
+		WalletBalance:        optional.ToNullFloat64(arg.WalletBalance),
 	})
 	if err != nil {
 		if sqliteErr, ok := err.(sqlite3.Error); ok {
@@ -288,6 +294,14 @@ func (st *Storage) ListCharacterIDs(ctx context.Context) (set.Set[int64], error)
 	return st.listCharacterIDs(ctx, st.qRO)
 }
 
+func (st *Storage) ListCharacterIDsForCorporation(ctx context.Context, corporationID int64) (set.Set[int64], error) {
+	ids, err := st.qRO.ListCharacterIDsForCorporation(ctx, corporationID)
+	if err != nil {
+		return set.Set[int64]{}, fmt.Errorf("ListCharacterIDsForCorporation: %d: %w", corporationID, err)
+	}
+	return set.Collect(slices.Values(ids)), nil
+}
+
 func (st *Storage) listCharacterIDs(ctx context.Context, q *queries.Queries) (set.Set[int64], error) {
 	ids, err := q.ListCharacterIDs(ctx)
 	if err != nil {
@@ -319,6 +333,17 @@ func (st *Storage) UpdateCharacterHome(ctx context.Context, characterID int64, h
 	return nil
 }
 
+func (st *Storage) UpdateCharacterIsJumpFatigueWatched(ctx context.Context, characterID int64, isWatched bool) error {
+	err := st.qRW.UpdateCharacterIsJumpFatigueWatched(ctx, queries.UpdateCharacterIsJumpFatigueWatchedParams{
+		ID:                   characterID,
+		IsJumpFatigueWatched: isWatched,
+	})
+	if err != nil {
+		return fmt.Errorf("update is jump fatigue watched for character %d: %w", characterID, err)
+	}
+	return nil
+}
+
 func (st *Storage) UpdateCharacterIsTrainingWatched(ctx context.Context, characterID int64, isWatched bool) error {
 	err := st.qRW.UpdateCharacterIsTrainingWatched(ctx, queries.UpdateCharacterIsTrainingWatchedParams{
 		ID:                characterID,
@@ -330,6 +355,18 @@ func (st *Storage) UpdateCharacterIsTrainingWatched(ctx context.Context, charact
 	return nil
 }
 
+func (st *Storage) UpdateCharacterJumpFatigue(ctx context.Context, characterID int64, expiresAt, lastJumpAt optional.Optional[time.Time]) error {
+	err := st.qRW.UpdateCharacterJumpFatigue(ctx, queries.UpdateCharacterJumpFatigueParams{
+		ID:                   characterID,
+		JumpFatigueExpiresAt: optional.ToNullTime(expiresAt),
+		LastJumpAt:           optional.ToNullTime(lastJumpAt),
+	})
+	if err != nil {
+		return fmt.Errorf("update jump fatigue for character %d: %w", characterID, err)
+	}
+	return nil
+}
+
 func (st *Storage) UpdateCharacterLastCloneJump(ctx context.Context, characterID int64, v optional.Optional[time.Time]) error {
 	err := st.qRW.UpdateCharacterLastCloneJump(ctx, queries.UpdateCharacterLastCloneJumpParams{
 		ID:              characterID,
@@ -485,16 +522,19 @@ func (st *Storage) characterFromDBModel(
 			eb,
 			eer,
 		),
-		ID:                character.ID,
-		IsTrainingWatched: character.IsTrainingWatched,
-		LastCloneJumpAt:   optional.FromNullTime(character.LastCloneJumpAt),
-		LastLoginAt:       optional.FromNullTime(character.LastLoginAt),
-		OrderItemsValue:   optional.FromNullFloat64(character.OrderItemsValue),
-		OrdersEscrow:      optional.FromNullFloat64(character.OrdersEscrow),
-		SkillPointsValue:  optional.FromNullFloat64(character.SkillPointsValue),
-		TrainedSP:         optional.FromNullInt64(character.TotalSp),
-		UnallocatedSP:     optional.FromNullInt64(character.UnallocatedSp),
-		WalletBalance:     optional.FromNullFloat64(character.WalletBalance),
+		ID:                   character.ID,
+		IsJumpFatigueWatched: character.IsJumpFatigueWatched,
+		IsTrainingWatched:    character.IsTrainingWatched,
+		JumpFatigueExpiresAt: optional.FromNullTime(character.JumpFatigueExpiresAt),
+		LastCloneJumpAt:      optional.FromNullTime(character.LastCloneJumpAt),
+		LastJumpAt:           optional.FromNullTime(character.LastJumpAt),
+		LastLoginAt:          optional.FromNullTime(character.LastLoginAt),
+		OrderItemsValue:      optional.FromNullFloat64(character.OrderItemsValue),
+		OrdersEscrow:         optional.FromNullFloat64(character.OrdersEscrow),
+		SkillPointsValue:     optional.FromNullFloat64(character.SkillPointsValue),
+		TrainedSP:            optional.FromNullInt64(character.TotalSp),
+		UnallocatedSP:        optional.FromNullInt64(character.UnallocatedSp),
+		WalletBalance:        optional.FromNullFloat64(character.WalletBalance),
 	}
 	if homeID.Valid {
 		x, err := st.GetLocation(ctx, homeID.Int64)
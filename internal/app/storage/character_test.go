@@ -147,23 +147,28 @@ func TestCharacter_Create(t *testing.T) {
 		ship := factory.CreateEveType()
 		login := time.Now()
 		cloneJump := time.Now()
+		lastJump := time.Now().Add(-1 * time.Hour)
+		fatigue := time.Now().Add(3 * time.Hour)
 		arg := storage.CreateCharacterParams{
-			AssetValue:         optional.New(3.4),
-			ContractItemsValue: optional.New(2.0),
-			ContractsEscrow:    optional.New(3.0),
-			HomeID:             optional.New(home.ID),
-			ID:                 ec.ID,
-			IsTrainingWatched:  true,
-			LastCloneJumpAt:    optional.New(cloneJump),
-			LastLoginAt:        optional.New(login),
-			LocationID:         optional.New(location.ID),
-			OrderItemsValue:    optional.New(4.0),
-			OrdersEscrow:       optional.New(5.0),
-			ShipID:             optional.New(ship.ID),
-			SkillPointsValue:   optional.New(6.0),
-			TotalSP:            optional.New(123),
-			UnallocatedSP:      optional.New(42),
-			WalletBalance:      optional.New(1.2),
+			AssetValue:           optional.New(3.4),
+			ContractItemsValue:   optional.New(2.0),
+			ContractsEscrow:      optional.New(3.0),
+			HomeID:               optional.New(home.ID),
+			ID:                   ec.ID,
+			IsJumpFatigueWatched: true,
+			IsTrainingWatched:    true,
+			JumpFatigueExpiresAt: optional.New(fatigue),
+			LastCloneJumpAt:      optional.New(cloneJump),
+			LastJumpAt:           optional.New(lastJump),
+			LastLoginAt:          optional.New(login),
+			LocationID:           optional.New(location.ID),
+			OrderItemsValue:      optional.New(4.0),
+			OrdersEscrow:         optional.New(5.0),
+			ShipID:               optional.New(ship.ID),
+			SkillPointsValue:     optional.New(6.0),
+			TotalSP:              optional.New(123),
+			UnallocatedSP:        optional.New(42),
+			WalletBalance:        optional.New(1.2),
 		}
 
 		// when
@@ -187,6 +192,9 @@ func TestCharacter_Create(t *testing.T) {
 		xassert.EqualOptional(t, location, o.Location)
 		xassert.EqualOptional(t, login, o.LastLoginAt)
 		xassert.EqualOptional(t, ship, o.Ship)
+		xassert.EqualOptional(t, fatigue, o.JumpFatigueExpiresAt)
+		xassert.EqualOptional(t, lastJump, o.LastJumpAt)
+		xassert.Equal(t, true, o.IsJumpFatigueWatched)
 		xassert.Equal(t, true, o.IsTrainingWatched)
 		xassert.Equal(t, ec, o.EveCharacter)
 	})
@@ -274,6 +282,23 @@ func TestListCharacters(t *testing.T) {
 		xassert.Equal(t, want, got)
 	})
 
+	t.Run("can list character IDs for corporation", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		ec1 := f.CreateEveCharacter()
+		c1 := f.CreateCharacter(storage.CreateCharacterParams{ID: ec1.ID})
+		ec2 := f.CreateEveCharacter(storage.CreateEveCharacterParams{CorporationID: ec1.Corporation.ID})
+		c2 := f.CreateCharacter(storage.CreateCharacterParams{ID: ec2.ID})
+		f.CreateCharacter()
+		f.CreateEveCharacter(storage.CreateEveCharacterParams{CorporationID: ec1.Corporation.ID})
+		// when
+		got, err := st.ListCharacterIDsForCorporation(t.Context(), ec1.Corporation.ID)
+		// then
+		require.NoError(t, err)
+		want := set.Of(c1.ID, c2.ID)
+		xassert.Equal(t, want, got)
+	})
+
 	t.Run("can list character corporations", func(t *testing.T) {
 		// given
 		testutil.MustTruncateTables(db)
@@ -391,6 +416,43 @@ func TestUpdateCharacterFields(t *testing.T) {
 		assert.True(t, c2.LastCloneJumpAt.IsEmpty())
 	})
 
+	t.Run("can update jump fatigue", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c1 := factory.CreateCharacterFull()
+		expires := time.Now().Add(2 * time.Hour)
+		lastJump := time.Now().Add(-1 * time.Hour)
+
+		// when
+		err := st.UpdateCharacterJumpFatigue(t.Context(), c1.ID, optional.New(expires), optional.New(lastJump))
+
+		// then
+		require.NoError(t, err)
+		c2, err := st.GetCharacter(t.Context(), c1.ID)
+		require.NoError(t, err)
+		xassert.EqualOptional(t, expires, c2.JumpFatigueExpiresAt)
+		xassert.EqualOptional(t, lastJump, c2.LastJumpAt)
+	})
+
+	t.Run("can reset jump fatigue", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c1 := factory.CreateCharacterFull(storage.CreateCharacterParams{
+			JumpFatigueExpiresAt: optional.New(time.Now().Add(2 * time.Hour)),
+			LastJumpAt:           optional.New(time.Now().Add(-1 * time.Hour)),
+		})
+
+		// when
+		err := st.UpdateCharacterJumpFatigue(t.Context(), c1.ID, optional.Optional[time.Time]{}, optional.Optional[time.Time]{})
+
+		// then
+		require.NoError(t, err)
+		c2, err := st.GetCharacter(t.Context(), c1.ID)
+		require.NoError(t, err)
+		assert.True(t, c2.JumpFatigueExpiresAt.IsEmpty())
+		assert.True(t, c2.LastJumpAt.IsEmpty())
+	})
+
 	t.Run("can update last login", func(t *testing.T) {
 		// given
 		testutil.MustTruncateTables(db)
@@ -472,6 +534,21 @@ func TestUpdateCharacterFields(t *testing.T) {
 		assert.False(t, c2.IsTrainingWatched)
 	})
 
+	t.Run("can update is jump fatigue watched", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c1 := factory.CreateCharacterFull()
+
+		// when
+		err := st.UpdateCharacterIsJumpFatigueWatched(t.Context(), c1.ID, true)
+
+		// then
+		require.NoError(t, err)
+		c2, err := st.GetCharacter(t.Context(), c1.ID)
+		require.NoError(t, err)
+		assert.True(t, c2.IsJumpFatigueWatched)
+	})
+
 	t.Run("can update skill points", func(t *testing.T) {
 		// given
 		testutil.MustTruncateTables(db)
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+)
+
+type CreateCharacterBlueprintParams struct {
+	CharacterID        int64
+	ItemID             int64
+	LocationFlag       app.LocationFlag
+	LocationID         int64
+	MaterialEfficiency int
+	Quantity           int
+	Runs               int
+	TimeEfficiency     int
+	TypeID             int64
+}
+
+func (st *Storage) CreateCharacterBlueprint(ctx context.Context, arg CreateCharacterBlueprintParams) error {
+	return createCharacterBlueprint(ctx, st.qRW, arg)
+}
+
+func (st *Storage) DeleteCharacterBlueprints(ctx context.Context, characterID int64) error {
+	if err := st.qRW.DeleteCharacterBlueprints(ctx, characterID); err != nil {
+		return fmt.Errorf("DeleteCharacterBlueprints: %d: %w", characterID, err)
+	}
+	return nil
+}
+
+// ListAllCharacterBlueprints returns the blueprints of all characters ordered by name.
+func (st *Storage) ListAllCharacterBlueprints(ctx context.Context) ([]*app.CharacterBlueprint, error) {
+	rows, err := st.qRO.ListAllCharacterBlueprints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ListAllCharacterBlueprints: %w", err)
+	}
+	oo := make([]*app.CharacterBlueprint, len(rows))
+	for i, r := range rows {
+		oo[i] = characterBlueprintFromDBModel(r.CharacterBlueprint, r.EveType, r.EveGroup, r.EveCategory)
+	}
+	return oo, nil
+}
+
+// ListCharacterBlueprints returns the blueprints of a character ordered by name.
+func (st *Storage) ListCharacterBlueprints(ctx context.Context, characterID int64) ([]*app.CharacterBlueprint, error) {
+	rows, err := st.qRO.ListCharacterBlueprints(ctx, characterID)
+	if err != nil {
+		return nil, fmt.Errorf("ListCharacterBlueprints: %d: %w", characterID, err)
+	}
+	oo := make([]*app.CharacterBlueprint, len(rows))
+	for i, r := range rows {
+		oo[i] = characterBlueprintFromDBModel(r.CharacterBlueprint, r.EveType, r.EveGroup, r.EveCategory)
+	}
+	return oo, nil
+}
+
+// ReplaceCharacterBlueprints replaces all blueprints of a character.
+func (st *Storage) ReplaceCharacterBlueprints(ctx context.Context, characterID int64, blueprints []CreateCharacterBlueprintParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ReplaceCharacterBlueprints for ID %d: %w", characterID, err)
+	}
+	if characterID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	tx, err := st.dbRW.Begin()
+	if err != nil {
+		return wrapErr(err)
+	}
+	defer tx.Rollback()
+	qtx := st.qRW.WithTx(tx)
+	if err := qtx.DeleteCharacterBlueprints(ctx, characterID); err != nil {
+		return wrapErr(err)
+	}
+	for _, arg := range blueprints {
+		if arg.CharacterID != characterID {
+			return wrapErr(app.ErrInvalid)
+		}
+		if err := createCharacterBlueprint(ctx, qtx, arg); err != nil {
+			return wrapErr(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+func createCharacterBlueprint(ctx context.Context, q *queries.Queries, arg CreateCharacterBlueprintParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("createCharacterBlueprint: %+v: %w", arg, err)
+	}
+	if arg.CharacterID == 0 || arg.ItemID == 0 || arg.TypeID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	err := q.CreateCharacterBlueprint(ctx, queries.CreateCharacterBlueprintParams{
+		CharacterID:        arg.CharacterID,
+		EveTypeID:          arg.TypeID,
+		ItemID:             arg.ItemID,
+		LocationFlag:       locationFlagToDBValue[arg.LocationFlag],
+		LocationID:         arg.LocationID,
+		MaterialEfficiency: int64(arg.MaterialEfficiency),
+		Quantity:           int64(arg.Quantity),
+		Runs:               int64(arg.Runs),
+		TimeEfficiency:     int64(arg.TimeEfficiency),
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+func characterBlueprintFromDBModel(r queries.CharacterBlueprint, t queries.EveType, g queries.EveGroup, c queries.EveCategory) *app.CharacterBlueprint {
+	locationFlag, found := locationFlagFromDBValue[r.LocationFlag]
+	if !found {
+		locationFlag = app.FlagUnknown
+	}
+	return &app.CharacterBlueprint{
+		CharacterID: r.CharacterID,
+		Blueprint: app.Blueprint{
+			ItemID:             r.ItemID,
+			LocationFlag:       locationFlag,
+			LocationID:         r.LocationID,
+			MaterialEfficiency: int(r.MaterialEfficiency),
+			Quantity:           int(r.Quantity),
+			Runs:               int(r.Runs),
+			TimeEfficiency:     int(r.TimeEfficiency),
+			Type:               eveTypeFromDBModel(t, g, c),
+		},
+	}
+}
@@ -0,0 +1,107 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func TestCharacterBlueprint(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	t.Run("can create new blueprint", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		owner := factory.CreateCharacterFull()
+		et := factory.CreateEveType()
+		location := factory.CreateEveLocationStructure()
+		// when
+		err := st.CreateCharacterBlueprint(t.Context(), storage.CreateCharacterBlueprintParams{
+			CharacterID:        owner.ID,
+			ItemID:             42,
+			LocationFlag:       app.FlagHangar,
+			LocationID:         location.ID,
+			MaterialEfficiency: 10,
+			Quantity:           -2,
+			Runs:               5,
+			TimeEfficiency:     20,
+			TypeID:             et.ID,
+		})
+		// then
+		require.NoError(t, err)
+		oo, err := st.ListCharacterBlueprints(t.Context(), owner.ID)
+		require.NoError(t, err)
+		if assert.Len(t, oo, 1) {
+			o := oo[0]
+			xassert.Equal(t, owner.ID, o.CharacterID)
+			xassert.Equal(t, 42, o.ItemID)
+			xassert.Equal(t, app.FlagHangar, o.LocationFlag)
+			xassert.Equal(t, location.ID, o.LocationID)
+			xassert.Equal(t, 10, o.MaterialEfficiency)
+			xassert.Equal(t, -2, o.Quantity)
+			xassert.Equal(t, 5, o.Runs)
+			xassert.Equal(t, 20, o.TimeEfficiency)
+			xassert.Equal(t, et.ID, o.Type.ID)
+			assert.True(t, o.IsCopy())
+		}
+	})
+	t.Run("can replace blueprints", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		owner := factory.CreateCharacterFull()
+		factory.CreateCharacterBlueprint(storage.CreateCharacterBlueprintParams{CharacterID: owner.ID})
+		et := factory.CreateEveType()
+		location := factory.CreateEveLocationStructure()
+		// when
+		err := st.ReplaceCharacterBlueprints(t.Context(), owner.ID, []storage.CreateCharacterBlueprintParams{{
+			CharacterID:  owner.ID,
+			ItemID:       7,
+			LocationFlag: app.FlagHangar,
+			LocationID:   location.ID,
+			Quantity:     -1,
+			Runs:         -1,
+			TypeID:       et.ID,
+		}})
+		// then
+		require.NoError(t, err)
+		oo, err := st.ListCharacterBlueprints(t.Context(), owner.ID)
+		require.NoError(t, err)
+		got := xslices.Map(oo, func(x *app.CharacterBlueprint) int64 {
+			return x.ItemID
+		})
+		xassert.Equal(t, []int64{7}, got)
+	})
+	t.Run("can list blueprints of all owners", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		o1 := factory.CreateCharacterBlueprint()
+		o2 := factory.CreateCharacterBlueprint()
+		// when
+		oo, err := st.ListAllCharacterBlueprints(t.Context())
+		// then
+		require.NoError(t, err)
+		got := xslices.Map(oo, func(x *app.CharacterBlueprint) int64 {
+			return x.ItemID
+		})
+		assert.ElementsMatch(t, []int64{o1.ItemID, o2.ItemID}, got)
+	})
+	t.Run("can delete blueprints", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		o := factory.CreateCharacterBlueprint()
+		// when
+		err := st.DeleteCharacterBlueprints(t.Context(), o.CharacterID)
+		// then
+		require.NoError(t, err)
+		oo, err := st.ListCharacterBlueprints(t.Context(), o.CharacterID)
+		require.NoError(t, err)
+		assert.Empty(t, oo)
+	})
+}
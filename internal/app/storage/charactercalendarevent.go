@@ -0,0 +1,210 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+type UpdateOrCreateCharacterCalendarEventParams struct {
+	Attendees   map[int64]app.CalendarEventResponse // maps entity IDs to their response
+	CharacterID int64
+	Date        time.Time
+	Duration    time.Duration
+	EventID     int64
+	Importance  int64
+	OwnerID     int64
+	OwnerName   string
+	OwnerType   string
+	Response    app.CalendarEventResponse
+	Text        string
+	Title       string
+}
+
+// UpdateOrCreateCharacterCalendarEvent updates or creates a calendar event
+// and replaces its attendees.
+func (st *Storage) UpdateOrCreateCharacterCalendarEvent(ctx context.Context, arg UpdateOrCreateCharacterCalendarEventParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("UpdateOrCreateCharacterCalendarEvent: %+v: %w", arg, err)
+	}
+	if arg.CharacterID == 0 || arg.EventID == 0 || arg.Date.IsZero() {
+		return wrapErr(app.ErrInvalid)
+	}
+	tx, err := st.dbRW.Begin()
+	if err != nil {
+		return wrapErr(err)
+	}
+	defer tx.Rollback()
+	qtx := st.qRW.WithTx(tx)
+	id, err := qtx.UpdateOrCreateCharacterCalendarEvent(ctx, queries.UpdateOrCreateCharacterCalendarEventParams{
+		CharacterID: arg.CharacterID,
+		Date:        arg.Date.UTC(),
+		Duration:    int64(arg.Duration.Minutes()),
+		EventID:     arg.EventID,
+		Importance:  arg.Importance,
+		OwnerID:     arg.OwnerID,
+		OwnerName:   arg.OwnerName,
+		OwnerType:   arg.OwnerType,
+		Response:    string(arg.Response),
+		Text:        arg.Text,
+		Title:       arg.Title,
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	if err := qtx.DeleteCharacterCalendarEventAttendees(ctx, id); err != nil {
+		return wrapErr(err)
+	}
+	for entityID, response := range arg.Attendees {
+		err := qtx.CreateCharacterCalendarEventAttendee(ctx, queries.CreateCharacterCalendarEventAttendeeParams{
+			CalendarEventID: id,
+			EveEntityID:     entityID,
+			Response:        string(response),
+		})
+		if err != nil {
+			return wrapErr(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+func (st *Storage) DeleteCharacterCalendarEvents(ctx context.Context, characterID int64, eventIDs set.Set[int64]) error {
+	arg := queries.DeleteCharacterCalendarEventsParams{
+		CharacterID: characterID,
+		EventIds:    slices.Collect(eventIDs.All()),
+	}
+	if err := st.qRW.DeleteCharacterCalendarEvents(ctx, arg); err != nil {
+		return fmt.Errorf("DeleteCharacterCalendarEvents: %+v: %w", arg, err)
+	}
+	return nil
+}
+
+func (st *Storage) GetCharacterCalendarEvent(ctx context.Context, characterID, eventID int64) (*app.CharacterCalendarEvent, error) {
+	arg := queries.GetCharacterCalendarEventParams{
+		CharacterID: characterID,
+		EventID:     eventID,
+	}
+	r, err := st.qRO.GetCharacterCalendarEvent(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("GetCharacterCalendarEvent: %+v: %w", arg, convertGetError(err))
+	}
+	return characterCalendarEventFromDBModel(r), nil
+}
+
+// ListAllCharacterCalendarEventsFrom returns the calendar events of all characters,
+// which start at or after from. Events are ordered by their start date.
+func (st *Storage) ListAllCharacterCalendarEventsFrom(ctx context.Context, from time.Time) ([]*app.CharacterCalendarEvent, error) {
+	rows, err := st.qRO.ListAllCharacterCalendarEventsFrom(ctx, from.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("ListAllCharacterCalendarEventsFrom: %s: %w", from, err)
+	}
+	oo := make([]*app.CharacterCalendarEvent, len(rows))
+	for i, r := range rows {
+		oo[i] = characterCalendarEventFromDBModel(r)
+	}
+	return oo, nil
+}
+
+// ListCharacterCalendarEvents returns the calendar events of a character ordered by their start date.
+func (st *Storage) ListCharacterCalendarEvents(ctx context.Context, characterID int64) ([]*app.CharacterCalendarEvent, error) {
+	rows, err := st.qRO.ListCharacterCalendarEvents(ctx, characterID)
+	if err != nil {
+		return nil, fmt.Errorf("ListCharacterCalendarEvents: %d: %w", characterID, err)
+	}
+	oo := make([]*app.CharacterCalendarEvent, len(rows))
+	for i, r := range rows {
+		oo[i] = characterCalendarEventFromDBModel(r)
+	}
+	return oo, nil
+}
+
+func (st *Storage) ListCharacterCalendarEventIDs(ctx context.Context, characterID int64) (set.Set[int64], error) {
+	ids, err := st.qRO.ListCharacterCalendarEventIDs(ctx, characterID)
+	if err != nil {
+		return set.Set[int64]{}, fmt.Errorf("ListCharacterCalendarEventIDs: %d: %w", characterID, err)
+	}
+	return set.Of(ids...), nil
+}
+
+// ListCharacterCalendarEventAttendees returns the attendees of a calendar event ordered by name.
+func (st *Storage) ListCharacterCalendarEventAttendees(ctx context.Context, characterID, eventID int64) ([]*app.CharacterCalendarEventAttendee, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ListCharacterCalendarEventAttendees: %d %d: %w", characterID, eventID, err)
+	}
+	e, err := st.qRO.GetCharacterCalendarEvent(ctx, queries.GetCharacterCalendarEventParams{
+		CharacterID: characterID,
+		EventID:     eventID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return []*app.CharacterCalendarEventAttendee{}, nil
+	}
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	rows, err := st.qRO.ListCharacterCalendarEventAttendees(ctx, e.ID)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	oo := make([]*app.CharacterCalendarEventAttendee, len(rows))
+	for i, r := range rows {
+		oo[i] = &app.CharacterCalendarEventAttendee{
+			Character: eveEntityFromDBModel(r.EveEntity),
+			Response:  app.CalendarEventResponse(r.Response),
+		}
+	}
+	return oo, nil
+}
+
+type UpdateCharacterCalendarEventLastNotifiedParams struct {
+	CharacterID  int64
+	EventID      int64
+	LastNotified time.Time
+}
+
+func (st *Storage) UpdateCharacterCalendarEventLastNotified(ctx context.Context, arg UpdateCharacterCalendarEventLastNotifiedParams) error {
+	if arg.CharacterID == 0 || arg.EventID == 0 {
+		return fmt.Errorf("UpdateCharacterCalendarEventLastNotified: %+v: %w", arg, app.ErrInvalid)
+	}
+	err := st.qRW.UpdateCharacterCalendarEventLastNotified(ctx, queries.UpdateCharacterCalendarEventLastNotifiedParams{
+		CharacterID:  arg.CharacterID,
+		EventID:      arg.EventID,
+		LastNotified: NewNullTimeFromTime(arg.LastNotified),
+	})
+	if err != nil {
+		return fmt.Errorf("UpdateCharacterCalendarEventLastNotified: %+v: %w", arg, err)
+	}
+	return nil
+}
+
+func characterCalendarEventFromDBModel(r queries.CharacterCalendarEvent) *app.CharacterCalendarEvent {
+	if r.CharacterID == 0 {
+		panic("missing character ID")
+	}
+	return &app.CharacterCalendarEvent{
+		CharacterID:  r.CharacterID,
+		Date:         r.Date,
+		Duration:     time.Duration(r.Duration) * time.Minute,
+		EventID:      r.EventID,
+		ID:           r.ID,
+		Importance:   r.Importance,
+		LastNotified: optional.FromNullTime(r.LastNotified),
+		OwnerID:      r.OwnerID,
+		OwnerName:    r.OwnerName,
+		OwnerType:    r.OwnerType,
+		Response:     app.CalendarEventResponse(r.Response),
+		Text:         r.Text,
+		Title:        r.Title,
+	}
+}
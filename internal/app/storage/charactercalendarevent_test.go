@@ -0,0 +1,165 @@
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func TestCharacterCalendarEvent(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	t.Run("can create new event with attendees", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		character := factory.CreateCharacterFull()
+		owner := factory.CreateEveEntityCorporation()
+		a1 := factory.CreateEveEntityCharacter()
+		a2 := factory.CreateEveEntityCharacter()
+		date := time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC)
+		// when
+		err := st.UpdateOrCreateCharacterCalendarEvent(t.Context(), storage.UpdateOrCreateCharacterCalendarEventParams{
+			Attendees: map[int64]app.CalendarEventResponse{
+				a1.ID: app.CalendarEventResponseAccepted,
+				a2.ID: app.CalendarEventResponseDeclined,
+			},
+			CharacterID: character.ID,
+			Date:        date,
+			Duration:    90 * time.Minute,
+			EventID:     42,
+			Importance:  1,
+			OwnerID:     owner.ID,
+			OwnerName:   owner.Name,
+			OwnerType:   "corporation",
+			Response:    app.CalendarEventResponseTentative,
+			Text:        "text",
+			Title:       "title",
+		})
+		// then
+		require.NoError(t, err)
+		o, err := st.GetCharacterCalendarEvent(t.Context(), character.ID, 42)
+		require.NoError(t, err)
+		xassert.Equal(t, character.ID, o.CharacterID)
+		assert.True(t, date.Equal(o.Date))
+		xassert.Equal(t, 90*time.Minute, o.Duration)
+		xassert.Equal(t, 1, o.Importance)
+		xassert.Equal(t, owner.ID, o.OwnerID)
+		xassert.Equal(t, owner.Name, o.OwnerName)
+		xassert.Equal(t, "corporation", o.OwnerType)
+		xassert.Equal(t, app.CalendarEventResponseTentative, o.Response)
+		xassert.Equal(t, "text", o.Text)
+		xassert.Equal(t, "title", o.Title)
+		assert.True(t, o.LastNotified.IsEmpty())
+		attendees, err := st.ListCharacterCalendarEventAttendees(t.Context(), character.ID, 42)
+		require.NoError(t, err)
+		got := make(map[int64]app.CalendarEventResponse)
+		for _, x := range attendees {
+			got[x.Character.ID] = x.Response
+		}
+		want := map[int64]app.CalendarEventResponse{
+			a1.ID: app.CalendarEventResponseAccepted,
+			a2.ID: app.CalendarEventResponseDeclined,
+		}
+		xassert.Equal(t, want, got)
+	})
+	t.Run("can update existing event and replace attendees", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		a1 := factory.CreateEveEntityCharacter()
+		x := factory.CreateCharacterCalendarEvent(storage.UpdateOrCreateCharacterCalendarEventParams{
+			Attendees: map[int64]app.CalendarEventResponse{a1.ID: app.CalendarEventResponseAccepted},
+		})
+		a2 := factory.CreateEveEntityCharacter()
+		// when
+		err := st.UpdateOrCreateCharacterCalendarEvent(t.Context(), storage.UpdateOrCreateCharacterCalendarEventParams{
+			Attendees:   map[int64]app.CalendarEventResponse{a2.ID: app.CalendarEventResponseTentative},
+			CharacterID: x.CharacterID,
+			Date:        x.Date,
+			Duration:    x.Duration,
+			EventID:     x.EventID,
+			OwnerID:     x.OwnerID,
+			OwnerName:   x.OwnerName,
+			OwnerType:   x.OwnerType,
+			Response:    app.CalendarEventResponseAccepted,
+			Text:        x.Text,
+			Title:       "changed",
+		})
+		// then
+		require.NoError(t, err)
+		o, err := st.GetCharacterCalendarEvent(t.Context(), x.CharacterID, x.EventID)
+		require.NoError(t, err)
+		xassert.Equal(t, "changed", o.Title)
+		xassert.Equal(t, app.CalendarEventResponseAccepted, o.Response)
+		xassert.Equal(t, x.ID, o.ID)
+		attendees, err := st.ListCharacterCalendarEventAttendees(t.Context(), x.CharacterID, x.EventID)
+		require.NoError(t, err)
+		if assert.Len(t, attendees, 1) {
+			xassert.Equal(t, a2.ID, attendees[0].Character.ID)
+		}
+	})
+	t.Run("can list upcoming events of all characters", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		now := time.Now().UTC()
+		e1 := factory.CreateCharacterCalendarEvent(storage.UpdateOrCreateCharacterCalendarEventParams{
+			Date: now.Add(2 * time.Hour),
+		})
+		e2 := factory.CreateCharacterCalendarEvent(storage.UpdateOrCreateCharacterCalendarEventParams{
+			Date: now.Add(1 * time.Hour),
+		})
+		factory.CreateCharacterCalendarEvent(storage.UpdateOrCreateCharacterCalendarEventParams{
+			Date: now.Add(-1 * time.Hour),
+		})
+		// when
+		oo, err := st.ListAllCharacterCalendarEventsFrom(t.Context(), now)
+		// then
+		require.NoError(t, err)
+		got := xslices.Map(oo, func(x *app.CharacterCalendarEvent) int64 {
+			return x.EventID
+		})
+		xassert.Equal(t, []int64{e2.EventID, e1.EventID}, got)
+	})
+	t.Run("can delete events", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		e1 := factory.CreateCharacterCalendarEvent(storage.UpdateOrCreateCharacterCalendarEventParams{
+			CharacterID: c.ID,
+		})
+		e2 := factory.CreateCharacterCalendarEvent(storage.UpdateOrCreateCharacterCalendarEventParams{
+			CharacterID: c.ID,
+		})
+		// when
+		err := st.DeleteCharacterCalendarEvents(t.Context(), c.ID, set.Of(e1.EventID))
+		// then
+		require.NoError(t, err)
+		got, err := st.ListCharacterCalendarEventIDs(t.Context(), c.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, set.Of(e2.EventID), got)
+	})
+	t.Run("can update last notified", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		x := factory.CreateCharacterCalendarEvent()
+		// when
+		err := st.UpdateCharacterCalendarEventLastNotified(t.Context(), storage.UpdateCharacterCalendarEventLastNotifiedParams{
+			CharacterID:  x.CharacterID,
+			EventID:      x.EventID,
+			LastNotified: x.Date,
+		})
+		// then
+		require.NoError(t, err)
+		o, err := st.GetCharacterCalendarEvent(t.Context(), x.CharacterID, x.EventID)
+		require.NoError(t, err)
+		assert.True(t, x.Date.Equal(o.LastNotified.ValueOrZero()))
+	})
+}
@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+)
+
+type CreateCharacterFittingParams struct {
+	CharacterID int64
+	Description string
+	FittingID   int64
+	Items       []CreateCharacterFittingItemParams
+	Name        string
+	ShipTypeID  int64
+}
+
+type CreateCharacterFittingItemParams struct {
+	Flag     app.LocationFlag
+	Quantity int64
+	TypeID   int64
+}
+
+func (st *Storage) CreateCharacterFitting(ctx context.Context, arg CreateCharacterFittingParams) error {
+	return createCharacterFitting(ctx, st.qRW, arg)
+}
+
+func (st *Storage) GetCharacterFitting(ctx context.Context, characterID int64, fittingID int64) (*app.CharacterFitting, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("GetCharacterFitting: %d %d: %w", characterID, fittingID, err)
+	}
+	if characterID == 0 || fittingID == 0 {
+		return nil, wrapErr(app.ErrInvalid)
+	}
+	r, err := st.qRO.GetCharacterFitting(ctx, queries.GetCharacterFittingParams{
+		CharacterID: characterID,
+		FittingID:   fittingID,
+	})
+	if err != nil {
+		return nil, wrapErr(convertGetError(err))
+	}
+	o := characterFittingFromDBModel(r.CharacterFitting, r.EveType, r.EveGroup, r.EveCategory)
+	items, err := listCharacterFittingItems(ctx, st.qRO, o.ID)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	o.Items = items
+	return o, nil
+}
+
+// ListCharacterFittings returns the fittings of a character incl. their items.
+func (st *Storage) ListCharacterFittings(ctx context.Context, characterID int64) ([]*app.CharacterFitting, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ListCharacterFittings: %d: %w", characterID, err)
+	}
+	if characterID == 0 {
+		return nil, wrapErr(app.ErrInvalid)
+	}
+	rows, err := st.qRO.ListCharacterFittings(ctx, characterID)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	var oo []*app.CharacterFitting
+	for _, r := range rows {
+		o := characterFittingFromDBModel(r.CharacterFitting, r.EveType, r.EveGroup, r.EveCategory)
+		items, err := listCharacterFittingItems(ctx, st.qRO, o.ID)
+		if err != nil {
+			return nil, wrapErr(err)
+		}
+		o.Items = items
+		oo = append(oo, o)
+	}
+	return oo, nil
+}
+
+// ReplaceCharacterFittings replaces all fittings of a character.
+func (st *Storage) ReplaceCharacterFittings(ctx context.Context, characterID int64, args []CreateCharacterFittingParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ReplaceCharacterFittings for ID %d: %w", characterID, err)
+	}
+	if characterID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	tx, err := st.dbRW.Begin()
+	if err != nil {
+		return wrapErr(err)
+	}
+	defer tx.Rollback()
+	qtx := st.qRW.WithTx(tx)
+	if err := qtx.DeleteCharacterFittings(ctx, characterID); err != nil {
+		return wrapErr(err)
+	}
+	for _, arg := range args {
+		if arg.CharacterID != characterID {
+			return wrapErr(app.ErrInvalid)
+		}
+		if err := createCharacterFitting(ctx, qtx, arg); err != nil {
+			return wrapErr(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+func createCharacterFitting(ctx context.Context, q *queries.Queries, arg CreateCharacterFittingParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("createCharacterFitting: %+v: %w", arg, err)
+	}
+	if arg.CharacterID == 0 || arg.FittingID == 0 || arg.ShipTypeID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	id, err := q.CreateCharacterFitting(ctx, queries.CreateCharacterFittingParams{
+		CharacterID: arg.CharacterID,
+		Description: arg.Description,
+		FittingID:   arg.FittingID,
+		Name:        arg.Name,
+		ShipTypeID:  arg.ShipTypeID,
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	for _, it := range arg.Items {
+		if it.TypeID == 0 {
+			return wrapErr(app.ErrInvalid)
+		}
+		err := q.CreateCharacterFittingItem(ctx, queries.CreateCharacterFittingItemParams{
+			CharacterFittingID: id,
+			Flag:               locationFlagToDBValue[it.Flag],
+			Quantity:           it.Quantity,
+			TypeID:             it.TypeID,
+		})
+		if err != nil {
+			return wrapErr(err)
+		}
+	}
+	return nil
+}
+
+func listCharacterFittingItems(ctx context.Context, q *queries.Queries, id int64) ([]*app.CharacterFittingItem, error) {
+	rows, err := q.ListCharacterFittingItems(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("list character fitting items for ID %d: %w", id, err)
+	}
+	var oo []*app.CharacterFittingItem
+	for _, r := range rows {
+		flag, found := locationFlagFromDBValue[r.CharacterFittingItem.Flag]
+		if !found {
+			flag = app.FlagUnknown
+		}
+		oo = append(oo, &app.CharacterFittingItem{
+			Flag:     flag,
+			Quantity: r.CharacterFittingItem.Quantity,
+			Type:     eveTypeFromDBModel(r.EveType, r.EveGroup, r.EveCategory),
+		})
+	}
+	return oo, nil
+}
+
+func characterFittingFromDBModel(cf queries.CharacterFitting, et queries.EveType, eg queries.EveGroup, ec queries.EveCategory) *app.CharacterFitting {
+	if cf.CharacterID == 0 {
+		panic("missing character ID")
+	}
+	return &app.CharacterFitting{
+		CharacterID: cf.CharacterID,
+		Description: cf.Description,
+		FittingID:   cf.FittingID,
+		ID:          cf.ID,
+		Name:        cf.Name,
+		ShipType:    eveTypeFromDBModel(et, eg, ec),
+	}
+}
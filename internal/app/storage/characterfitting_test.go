@@ -0,0 +1,120 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func TestCharacterFitting(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+
+	t.Run("can create new fitting with items", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		ship := factory.CreateEveType()
+		module := factory.CreateEveType()
+		drone := factory.CreateEveType()
+		arg := storage.CreateCharacterFittingParams{
+			CharacterID: c.ID,
+			Description: "description",
+			FittingID:   42,
+			Items: []storage.CreateCharacterFittingItemParams{
+				{Flag: app.FlagHiSlot0, Quantity: 1, TypeID: module.ID},
+				{Flag: app.FlagDroneBay, Quantity: 5, TypeID: drone.ID},
+			},
+			Name:       "name",
+			ShipTypeID: ship.ID,
+		}
+		// when
+		err := st.CreateCharacterFitting(t.Context(), arg)
+		// then
+		require.NoError(t, err)
+		x, err := st.GetCharacterFitting(t.Context(), c.ID, 42)
+		require.NoError(t, err)
+		xassert.Equal(t, c.ID, x.CharacterID)
+		xassert.Equal(t, "description", x.Description)
+		xassert.Equal(t, 42, x.FittingID)
+		xassert.Equal(t, "name", x.Name)
+		xassert.Equal(t, ship, x.ShipType)
+		if assert.Len(t, x.Items, 2) {
+			xassert.Equal(t, &app.CharacterFittingItem{Flag: app.FlagHiSlot0, Quantity: 1, Type: module}, x.Items[0])
+			xassert.Equal(t, &app.CharacterFittingItem{Flag: app.FlagDroneBay, Quantity: 5, Type: drone}, x.Items[1])
+		}
+	})
+
+	t.Run("should return not found error when fitting does not exist", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		// when
+		_, err := st.GetCharacterFitting(t.Context(), c.ID, 42)
+		// then
+		assert.ErrorIs(t, err, app.ErrNotFound)
+	})
+
+	t.Run("can replace existing fittings", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		x1 := factory.CreateCharacterFitting(storage.CreateCharacterFittingParams{
+			CharacterID: c.ID,
+		})
+		ship := factory.CreateEveType()
+		module := factory.CreateEveType()
+		arg := storage.CreateCharacterFittingParams{
+			CharacterID: c.ID,
+			FittingID:   42,
+			Items: []storage.CreateCharacterFittingItemParams{
+				{Flag: app.FlagLoSlot0, Quantity: 1, TypeID: module.ID},
+			},
+			Name:       "name",
+			ShipTypeID: ship.ID,
+		}
+		// when
+		err := st.ReplaceCharacterFittings(t.Context(), c.ID, []storage.CreateCharacterFittingParams{arg})
+		// then
+		require.NoError(t, err)
+		_, err = st.GetCharacterFitting(t.Context(), c.ID, x1.FittingID)
+		assert.ErrorIs(t, err, app.ErrNotFound)
+		x, err := st.GetCharacterFitting(t.Context(), c.ID, 42)
+		require.NoError(t, err)
+		xassert.Equal(t, "name", x.Name)
+		if assert.Len(t, x.Items, 1) {
+			xassert.Equal(t, module, x.Items[0].Type)
+		}
+	})
+
+	t.Run("can list fittings for a character", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		x1 := factory.CreateCharacterFitting(storage.CreateCharacterFittingParams{
+			CharacterID: c.ID,
+		})
+		x2 := factory.CreateCharacterFitting(storage.CreateCharacterFittingParams{
+			CharacterID: c.ID,
+		})
+		factory.CreateCharacterFitting()
+		// when
+		oo, err := st.ListCharacterFittings(t.Context(), c.ID)
+		// then
+		require.NoError(t, err)
+		ids := xslices.Map(oo, func(a *app.CharacterFitting) int64 {
+			return a.FittingID
+		})
+		assert.ElementsMatch(t, []int64{x1.FittingID, x2.FittingID}, ids)
+		for _, o := range oo {
+			assert.NotEmpty(t, o.Items)
+		}
+	})
+}
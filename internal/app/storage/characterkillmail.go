@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+)
+
+// CreateCharacterKillmail links an existing killmail to a character.
+// Does nothing when the link already exists.
+func (st *Storage) CreateCharacterKillmail(ctx context.Context, characterID, killmailID int64) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("CreateCharacterKillmail: character %d, killmail %d: %w", characterID, killmailID, err)
+	}
+	if characterID == 0 || killmailID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	err := st.qRW.CreateCharacterKillmail(ctx, queries.CreateCharacterKillmailParams{
+		CharacterID: characterID,
+		KillmailID:  killmailID,
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+func (st *Storage) ListCharacterKillmailIDs(ctx context.Context, characterID int64) (set.Set[int64], error) {
+	ids, err := st.qRO.ListCharacterKillmailIDs(ctx, characterID)
+	if err != nil {
+		return set.Set[int64]{}, fmt.Errorf("ListCharacterKillmailIDs for character %d: %w", characterID, err)
+	}
+	return set.Collect(slices.Values(ids)), nil
+}
+
+// ListCharacterKillmails returns the killmails of a character ordered by time with the most recent first.
+// Attackers and items are not populated.
+func (st *Storage) ListCharacterKillmails(ctx context.Context, characterID int64) ([]*app.EveKillmail, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ListCharacterKillmails for character %d: %w", characterID, err)
+	}
+	ids, err := st.qRO.ListCharacterKillmailIDs(ctx, characterID)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	oo, err := st.listEveKillmails(ctx, ids)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	return oo, nil
+}
@@ -0,0 +1,59 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestCharacterKillmail(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	ctx := context.Background()
+	t.Run("can link killmails and list them", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		k1 := factory.CreateEveKillmail(storage.CreateEveKillmailParams{
+			Time: time.Now().Add(-1 * time.Hour),
+		})
+		k2 := factory.CreateEveKillmail(storage.CreateEveKillmailParams{
+			Time: time.Now().Add(-2 * time.Hour),
+		})
+		factory.CreateEveKillmail()
+		// when
+		err1 := st.CreateCharacterKillmail(ctx, c.ID, k2.ID)
+		err2 := st.CreateCharacterKillmail(ctx, c.ID, k1.ID)
+		err3 := st.CreateCharacterKillmail(ctx, c.ID, k1.ID)
+		// then
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.NoError(t, err3)
+		oo, err := st.ListCharacterKillmails(ctx, c.ID)
+		require.NoError(t, err)
+		require.Len(t, oo, 2)
+		xassert.Equal(t, k1.ID, oo[0].ID)
+		xassert.Equal(t, k2.ID, oo[1].ID)
+		ids, err := st.ListCharacterKillmailIDs(ctx, c.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, set.Of(k1.ID, k2.ID), ids)
+	})
+	t.Run("should return empty list when character has no killmails", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		// when
+		oo, err := st.ListCharacterKillmails(ctx, c.ID)
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, oo)
+	})
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+type UpdateOrCreateCharacterMiningLedgerEntryParams struct {
+	CharacterID   int64
+	Date          time.Time
+	Quantity      int64
+	SolarSystemID int64
+	TypeID        int64
+}
+
+// UpdateOrCreateCharacterMiningLedgerEntry updates or creates a mining ledger entry.
+// Entries are identified by character, day, solar system and type.
+func (st *Storage) UpdateOrCreateCharacterMiningLedgerEntry(ctx context.Context, arg UpdateOrCreateCharacterMiningLedgerEntryParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("UpdateOrCreateCharacterMiningLedgerEntry: %+v: %w", arg, err)
+	}
+	if arg.CharacterID == 0 || arg.SolarSystemID == 0 || arg.TypeID == 0 || arg.Date.IsZero() {
+		return wrapErr(app.ErrInvalid)
+	}
+	err := st.qRW.UpdateOrCreateCharacterMiningLedgerEntry(ctx, queries.UpdateOrCreateCharacterMiningLedgerEntryParams{
+		CharacterID:      arg.CharacterID,
+		Date:             arg.Date.UTC(),
+		EveSolarSystemID: arg.SolarSystemID,
+		EveTypeID:        arg.TypeID,
+		Quantity:         arg.Quantity,
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+// ListAllCharacterMiningLedgerEntries returns the mining ledger entries of all characters.
+// Newest entries come first.
+func (st *Storage) ListAllCharacterMiningLedgerEntries(ctx context.Context) ([]*app.CharacterMiningLedgerEntry, error) {
+	rows, err := st.qRO.ListAllCharacterMiningLedgerEntries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ListAllCharacterMiningLedgerEntries: %w", err)
+	}
+	oo := make([]*app.CharacterMiningLedgerEntry, len(rows))
+	for i, r := range rows {
+		oo[i] = characterMiningLedgerEntryFromDBModel(
+			r.CharacterMiningLedgerEntry,
+			r.EveType,
+			r.EveGroup,
+			r.EveCategory,
+			r.EveSolarSystem,
+			r.EveConstellation,
+			r.EveRegion,
+			r.Price,
+		)
+	}
+	return oo, nil
+}
+
+// ListCharacterMiningLedgerEntries returns the mining ledger entries of a character.
+// Newest entries come first.
+func (st *Storage) ListCharacterMiningLedgerEntries(ctx context.Context, characterID int64) ([]*app.CharacterMiningLedgerEntry, error) {
+	rows, err := st.qRO.ListCharacterMiningLedgerEntries(ctx, characterID)
+	if err != nil {
+		return nil, fmt.Errorf("ListCharacterMiningLedgerEntries: %d: %w", characterID, err)
+	}
+	oo := make([]*app.CharacterMiningLedgerEntry, len(rows))
+	for i, r := range rows {
+		oo[i] = characterMiningLedgerEntryFromDBModel(
+			r.CharacterMiningLedgerEntry,
+			r.EveType,
+			r.EveGroup,
+			r.EveCategory,
+			r.EveSolarSystem,
+			r.EveConstellation,
+			r.EveRegion,
+			r.Price,
+		)
+	}
+	return oo, nil
+}
+
+func characterMiningLedgerEntryFromDBModel(
+	e queries.CharacterMiningLedgerEntry,
+	t queries.EveType,
+	g queries.EveGroup,
+	c queries.EveCategory,
+	s queries.EveSolarSystem,
+	con queries.EveConstellation,
+	r queries.EveRegion,
+	price sql.NullFloat64,
+) *app.CharacterMiningLedgerEntry {
+	if e.CharacterID == 0 {
+		panic("missing character ID")
+	}
+	return &app.CharacterMiningLedgerEntry{
+		CharacterID: e.CharacterID,
+		Date:        e.Date,
+		Price:       optional.FromNullFloat64(price),
+		Quantity:    e.Quantity,
+		SolarSystem: eveSolarSystemFromDBModel(s, con, r),
+		Type:        eveTypeFromDBModel(t, g, c),
+	}
+}
@@ -0,0 +1,101 @@
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func TestCharacterMiningLedgerEntry(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	t.Run("can create new entry", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		character := factory.CreateCharacterFull()
+		system := factory.CreateEveSolarSystem()
+		et := factory.CreateEveType()
+		factory.CreateEveMarketPrice(storage.UpdateOrCreateEveMarketPriceParams{TypeID: et.ID, AveragePrice: optional.New(12.5)})
+		date := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+		// when
+		err := st.UpdateOrCreateCharacterMiningLedgerEntry(t.Context(), storage.UpdateOrCreateCharacterMiningLedgerEntryParams{
+			CharacterID:   character.ID,
+			Date:          date,
+			Quantity:      1000,
+			SolarSystemID: system.ID,
+			TypeID:        et.ID,
+		})
+		// then
+		require.NoError(t, err)
+		oo, err := st.ListCharacterMiningLedgerEntries(t.Context(), character.ID)
+		require.NoError(t, err)
+		if assert.Len(t, oo, 1) {
+			o := oo[0]
+			xassert.Equal(t, character.ID, o.CharacterID)
+			assert.True(t, date.Equal(o.Date))
+			xassert.Equal(t, 1000, o.Quantity)
+			xassert.Equal(t, system.ID, o.SolarSystem.ID)
+			xassert.Equal(t, et.ID, o.Type.ID)
+			xassert.Equal(t, optional.New(12.5), o.Price)
+			xassert.Equal(t, optional.New(12_500.0), o.Value())
+		}
+	})
+	t.Run("can update existing entry", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		x := factory.CreateCharacterMiningLedgerEntry()
+		// when
+		err := st.UpdateOrCreateCharacterMiningLedgerEntry(t.Context(), storage.UpdateOrCreateCharacterMiningLedgerEntryParams{
+			CharacterID:   x.CharacterID,
+			Date:          x.Date,
+			Quantity:      x.Quantity + 5,
+			SolarSystemID: x.SolarSystem.ID,
+			TypeID:        x.Type.ID,
+		})
+		// then
+		require.NoError(t, err)
+		oo, err := st.ListCharacterMiningLedgerEntries(t.Context(), x.CharacterID)
+		require.NoError(t, err)
+		if assert.Len(t, oo, 1) {
+			xassert.Equal(t, x.Quantity+5, oo[0].Quantity)
+		}
+	})
+	t.Run("can list entries of all characters", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		e1 := factory.CreateCharacterMiningLedgerEntry()
+		e2 := factory.CreateCharacterMiningLedgerEntry()
+		// when
+		oo, err := st.ListAllCharacterMiningLedgerEntries(t.Context())
+		// then
+		require.NoError(t, err)
+		got := xslices.Map(oo, func(x *app.CharacterMiningLedgerEntry) int64 {
+			return x.CharacterID
+		})
+		assert.ElementsMatch(t, []int64{e1.CharacterID, e2.CharacterID}, got)
+	})
+	t.Run("should return error when solar system is missing", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		character := factory.CreateCharacterFull()
+		et := factory.CreateEveType()
+		// when
+		err := st.UpdateOrCreateCharacterMiningLedgerEntry(t.Context(), storage.UpdateOrCreateCharacterMiningLedgerEntryParams{
+			CharacterID: character.ID,
+			Date:        time.Now(),
+			Quantity:    1,
+			TypeID:      et.ID,
+		})
+		// then
+		assert.ErrorIs(t, err, app.ErrInvalid)
+	})
+}
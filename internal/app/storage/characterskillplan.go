@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+)
+
+type CreateCharacterSkillPlanParams struct {
+	CharacterID int64
+	Name        string
+}
+
+type CreateCharacterSkillPlanItemParams struct {
+	Level  int
+	TypeID int64
+}
+
+// CreateCharacterSkillPlan creates a new and empty skill plan for a character.
+// It returns [app.ErrAlreadyExists] when the character already has a plan with the same name.
+func (st *Storage) CreateCharacterSkillPlan(ctx context.Context, arg CreateCharacterSkillPlanParams) (*app.CharacterSkillPlan, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("CreateCharacterSkillPlan: %+v: %w", arg, err)
+	}
+	if arg.CharacterID == 0 || arg.Name == "" {
+		return nil, wrapErr(app.ErrInvalid)
+	}
+	r, err := st.qRW.CreateCharacterSkillPlan(ctx, queries.CreateCharacterSkillPlanParams{
+		CharacterID: arg.CharacterID,
+		Name:        arg.Name,
+	})
+	if err != nil {
+		return nil, wrapErr(convertUniqueError(err))
+	}
+	return characterSkillPlanFromDBModel(r), nil
+}
+
+func (st *Storage) DeleteCharacterSkillPlan(ctx context.Context, id int64) error {
+	if err := st.qRW.DeleteCharacterSkillPlan(ctx, id); err != nil {
+		return fmt.Errorf("DeleteCharacterSkillPlan: %d: %w", id, err)
+	}
+	return nil
+}
+
+// GetCharacterSkillPlan returns a skill plan incl. its items.
+func (st *Storage) GetCharacterSkillPlan(ctx context.Context, id int64) (*app.CharacterSkillPlan, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("GetCharacterSkillPlan: %d: %w", id, err)
+	}
+	r, err := st.qRO.GetCharacterSkillPlan(ctx, id)
+	if err != nil {
+		return nil, wrapErr(convertGetError(err))
+	}
+	o := characterSkillPlanFromDBModel(r)
+	items, err := listCharacterSkillPlanItems(ctx, st.qRO, o.ID)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	o.Items = items
+	return o, nil
+}
+
+// ListCharacterSkillPlans returns the skill plans of a character incl. their items.
+func (st *Storage) ListCharacterSkillPlans(ctx context.Context, characterID int64) ([]*app.CharacterSkillPlan, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ListCharacterSkillPlans: %d: %w", characterID, err)
+	}
+	if characterID == 0 {
+		return nil, wrapErr(app.ErrInvalid)
+	}
+	rows, err := st.qRO.ListCharacterSkillPlans(ctx, characterID)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	var oo []*app.CharacterSkillPlan
+	for _, r := range rows {
+		o := characterSkillPlanFromDBModel(r)
+		items, err := listCharacterSkillPlanItems(ctx, st.qRO, o.ID)
+		if err != nil {
+			return nil, wrapErr(err)
+		}
+		o.Items = items
+		oo = append(oo, o)
+	}
+	return oo, nil
+}
+
+// ReplaceCharacterSkillPlanItems replaces all items of a skill plan.
+// Items are stored in the given order.
+func (st *Storage) ReplaceCharacterSkillPlanItems(ctx context.Context, id int64, items []CreateCharacterSkillPlanItemParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ReplaceCharacterSkillPlanItems for ID %d: %w", id, err)
+	}
+	if id == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	tx, err := st.dbRW.Begin()
+	if err != nil {
+		return wrapErr(err)
+	}
+	defer tx.Rollback()
+	qtx := st.qRW.WithTx(tx)
+	if err := qtx.DeleteCharacterSkillPlanItems(ctx, id); err != nil {
+		return wrapErr(err)
+	}
+	for i, it := range items {
+		if it.TypeID == 0 || it.Level < 1 || it.Level > 5 {
+			return wrapErr(app.ErrInvalid)
+		}
+		err := qtx.CreateCharacterSkillPlanItem(ctx, queries.CreateCharacterSkillPlanItemParams{
+			CharacterSkillPlanID: id,
+			Level:                int64(it.Level),
+			Position:             int64(i),
+			TypeID:               it.TypeID,
+		})
+		if err != nil {
+			return wrapErr(convertUniqueError(err))
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+// UpdateCharacterSkillPlanName renames a skill plan.
+// It returns [app.ErrAlreadyExists] when the character already has a plan with the same name.
+func (st *Storage) UpdateCharacterSkillPlanName(ctx context.Context, id int64, name string) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("UpdateCharacterSkillPlanName: %d %s: %w", id, name, err)
+	}
+	if id == 0 || name == "" {
+		return wrapErr(app.ErrInvalid)
+	}
+	err := st.qRW.UpdateCharacterSkillPlanName(ctx, queries.UpdateCharacterSkillPlanNameParams{
+		ID:   id,
+		Name: name,
+	})
+	if err != nil {
+		return wrapErr(convertUniqueError(err))
+	}
+	return nil
+}
+
+func listCharacterSkillPlanItems(ctx context.Context, q *queries.Queries, id int64) ([]*app.CharacterSkillPlanItem, error) {
+	rows, err := q.ListCharacterSkillPlanItems(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("list character skill plan items for ID %d: %w", id, err)
+	}
+	var oo []*app.CharacterSkillPlanItem
+	for _, r := range rows {
+		oo = append(oo, &app.CharacterSkillPlanItem{
+			Level: int(r.CharacterSkillPlanItem.Level),
+			Type:  eveTypeFromDBModel(r.EveType, r.EveGroup, r.EveCategory),
+		})
+	}
+	return oo, nil
+}
+
+// convertUniqueError returns [app.ErrAlreadyExists] for violations of unique constraints
+// and the original error otherwise.
+func convertUniqueError(err error) error {
+	if sqliteErr, ok := err.(sqlite3.Error); ok {
+		if sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return app.ErrAlreadyExists
+		}
+	}
+	return err
+}
+
+func characterSkillPlanFromDBModel(r queries.CharacterSkillPlan) *app.CharacterSkillPlan {
+	return &app.CharacterSkillPlan{
+		CharacterID: r.CharacterID,
+		ID:          r.ID,
+		Name:        r.Name,
+	}
+}
@@ -0,0 +1,126 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func TestCharacterSkillPlan(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+
+	t.Run("can create new plan", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		// when
+		o1, err := st.CreateCharacterSkillPlan(t.Context(), storage.CreateCharacterSkillPlanParams{
+			CharacterID: c.ID,
+			Name:        "Alpha",
+		})
+		// then
+		require.NoError(t, err)
+		o2, err := st.GetCharacterSkillPlan(t.Context(), o1.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, c.ID, o2.CharacterID)
+		xassert.Equal(t, "Alpha", o2.Name)
+		assert.Empty(t, o2.Items)
+	})
+	t.Run("should return error when name already exists for character", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		p := factory.CreateCharacterSkillPlan()
+		// when
+		_, err := st.CreateCharacterSkillPlan(t.Context(), storage.CreateCharacterSkillPlanParams{
+			CharacterID: p.CharacterID,
+			Name:        p.Name,
+		})
+		// then
+		require.ErrorIs(t, err, app.ErrAlreadyExists)
+	})
+	t.Run("can replace items and keep their order", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		p := factory.CreateCharacterSkillPlan()
+		skill1 := factory.CreateEveType()
+		skill2 := factory.CreateEveType()
+		err := st.ReplaceCharacterSkillPlanItems(t.Context(), p.ID, []storage.CreateCharacterSkillPlanItemParams{
+			{Level: 1, TypeID: skill1.ID},
+		})
+		require.NoError(t, err)
+		// when
+		err = st.ReplaceCharacterSkillPlanItems(t.Context(), p.ID, []storage.CreateCharacterSkillPlanItemParams{
+			{Level: 1, TypeID: skill2.ID},
+			{Level: 1, TypeID: skill1.ID},
+			{Level: 2, TypeID: skill1.ID},
+		})
+		// then
+		require.NoError(t, err)
+		o, err := st.GetCharacterSkillPlan(t.Context(), p.ID)
+		require.NoError(t, err)
+		got := xslices.Map(o.Items, func(x *app.CharacterSkillPlanItem) [2]int64 {
+			return [2]int64{x.Type.ID, int64(x.Level)}
+		})
+		want := [][2]int64{{skill2.ID, 1}, {skill1.ID, 1}, {skill1.ID, 2}}
+		xassert.Equal(t, want, got)
+	})
+	t.Run("should return error when items contain an invalid level", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		p := factory.CreateCharacterSkillPlan()
+		skill := factory.CreateEveType()
+		// when
+		err := st.ReplaceCharacterSkillPlanItems(t.Context(), p.ID, []storage.CreateCharacterSkillPlanItemParams{
+			{Level: 6, TypeID: skill.ID},
+		})
+		// then
+		require.ErrorIs(t, err, app.ErrInvalid)
+	})
+	t.Run("can list plans of a character", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		p1 := factory.CreateCharacterSkillPlan(storage.CreateCharacterSkillPlanParams{CharacterID: c.ID, Name: "Bravo"})
+		p2 := factory.CreateCharacterSkillPlan(storage.CreateCharacterSkillPlanParams{CharacterID: c.ID, Name: "alpha"})
+		factory.CreateCharacterSkillPlan()
+		// when
+		oo, err := st.ListCharacterSkillPlans(t.Context(), c.ID)
+		// then
+		require.NoError(t, err)
+		got := xslices.Map(oo, func(x *app.CharacterSkillPlan) int64 {
+			return x.ID
+		})
+		xassert.Equal(t, []int64{p2.ID, p1.ID}, got)
+	})
+	t.Run("can rename plan", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		p := factory.CreateCharacterSkillPlan()
+		// when
+		err := st.UpdateCharacterSkillPlanName(t.Context(), p.ID, "Charlie")
+		// then
+		require.NoError(t, err)
+		o, err := st.GetCharacterSkillPlan(t.Context(), p.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, "Charlie", o.Name)
+	})
+	t.Run("can delete plan", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		p := factory.CreateCharacterSkillPlan()
+		// when
+		err := st.DeleteCharacterSkillPlan(t.Context(), p.ID)
+		// then
+		require.NoError(t, err)
+		_, err = st.GetCharacterSkillPlan(t.Context(), p.ID)
+		require.ErrorIs(t, err, app.ErrNotFound)
+	})
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+)
+
+func (st *Storage) DeleteCharacterStandings(ctx context.Context, characterID int64, fromIDs set.Set[int64]) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("DeleteCharacterStandings for character %d and from IDs: %v: %w", characterID, fromIDs, err)
+	}
+	if characterID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	if fromIDs.Size() == 0 {
+		return nil
+	}
+	err := st.qRW.DeleteCharacterStandings(ctx, queries.DeleteCharacterStandingsParams{
+		CharacterID: characterID,
+		FromIds:     slices.Collect(fromIDs.All()),
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	slog.Info("Standings deleted", "characterID", characterID, "fromIDs", fromIDs)
+	return nil
+}
+
+func (st *Storage) GetCharacterStanding(ctx context.Context, characterID int64, fromID int64) (*app.CharacterStanding, error) {
+	r, err := st.qRO.GetCharacterStanding(ctx, queries.GetCharacterStandingParams{
+		CharacterID: characterID,
+		FromID:      fromID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetCharacterStanding for character %d: %w", characterID, convertGetError(err))
+	}
+	o := characterStandingFromDBModel(
+		r.CharacterStanding,
+		r.EveEntity,
+		nullEveEntity{
+			id:       r.FactionID,
+			category: r.FactionCategory,
+			name:     r.FactionName,
+		},
+	)
+	return o, nil
+}
+
+func (st *Storage) ListAllCharacterStandings(ctx context.Context) ([]*app.CharacterStanding, error) {
+	rows, err := st.qRO.ListAllCharacterStandings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ListAllCharacterStandings: %w", err)
+	}
+	var oo []*app.CharacterStanding
+	for _, r := range rows {
+		oo = append(oo, characterStandingFromDBModel(
+			r.CharacterStanding,
+			r.EveEntity,
+			nullEveEntity{
+				id:       r.FactionID,
+				category: r.FactionCategory,
+				name:     r.FactionName,
+			},
+		))
+	}
+	return oo, nil
+}
+
+func (st *Storage) ListCharacterStandingFromIDs(ctx context.Context, characterID int64) (set.Set[int64], error) {
+	ids, err := st.qRO.ListCharacterStandingFromIDs(ctx, characterID)
+	if err != nil {
+		return set.Set[int64]{}, fmt.Errorf("ListCharacterStandingFromIDs for character %d: %w", characterID, err)
+	}
+	return set.Collect(slices.Values(ids)), nil
+}
+
+func (st *Storage) ListCharacterStandings(ctx context.Context, characterID int64) ([]*app.CharacterStanding, error) {
+	rows, err := st.qRO.ListCharacterStandings(ctx, characterID)
+	if err != nil {
+		return nil, fmt.Errorf("ListCharacterStandings for character %d: %w", characterID, err)
+	}
+	var oo []*app.CharacterStanding
+	for _, r := range rows {
+		oo = append(oo, characterStandingFromDBModel(
+			r.CharacterStanding,
+			r.EveEntity,
+			nullEveEntity{
+				id:       r.FactionID,
+				category: r.FactionCategory,
+				name:     r.FactionName,
+			},
+		))
+	}
+	return oo, nil
+}
+
+func characterStandingFromDBModel(
+	standing queries.CharacterStanding,
+	from queries.EveEntity,
+	faction nullEveEntity,
+) *app.CharacterStanding {
+	o2 := &app.CharacterStanding{
+		CharacterID: standing.CharacterID,
+		Faction:     eveEntityFromNullableDBModel(faction),
+		From:        eveEntityFromDBModel(from),
+		FromType:    app.StandingFromType(standing.FromType),
+		Standing:    standing.Standing,
+	}
+	return o2
+}
+
+type UpdateOrCreateCharacterStandingParams struct {
+	CharacterID int64
+	FromID      int64
+	FromType    app.StandingFromType
+	Standing    float64
+}
+
+func (st *Storage) UpdateOrCreateCharacterStanding(ctx context.Context, arg UpdateOrCreateCharacterStandingParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("UpdateOrCreateCharacterStanding: %+v: %w", arg, err)
+	}
+	if arg.CharacterID == 0 || arg.FromID == 0 || arg.FromType == "" {
+		return wrapErr(app.ErrInvalid)
+	}
+	err := st.qRW.UpdateOrCreateCharacterStanding(ctx, queries.UpdateOrCreateCharacterStandingParams{
+		CharacterID: arg.CharacterID,
+		FromID:      arg.FromID,
+		FromType:    string(arg.FromType),
+		Standing:    arg.Standing,
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package storage_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+	"github.com/ErikKalkoken/evebuddy/internal/xiter"
+)
+
+func TestCharacterStanding(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	ctx := context.Background()
+	t.Run("can create new for faction", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		faction := factory.CreateEveEntityFaction()
+		arg := storage.UpdateOrCreateCharacterStandingParams{
+			CharacterID: c.ID,
+			FromID:      faction.ID,
+			FromType:    app.StandingFromFaction,
+			Standing:    4.5,
+		}
+		// when
+		err := st.UpdateOrCreateCharacterStanding(ctx, arg)
+		// then
+		require.NoError(t, err)
+		o, err := st.GetCharacterStanding(ctx, c.ID, faction.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, c.ID, o.CharacterID)
+		xassert.Equal(t, faction, o.From)
+		xassert.Equal(t, app.StandingFromFaction, o.FromType)
+		xassert.Equal(t, 4.5, o.Standing)
+		assert.True(t, o.Faction.IsEmpty())
+	})
+	t.Run("can create new for NPC corporation with faction", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		faction := factory.CreateEveEntityFaction()
+		corporation := factory.CreateEveEntityCorporation()
+		factory.CreateEveCorporation(storage.UpdateOrCreateEveCorporationParams{
+			ID:        corporation.ID,
+			Name:      corporation.Name,
+			FactionID: optional.New(faction.ID),
+		})
+		arg := storage.UpdateOrCreateCharacterStandingParams{
+			CharacterID: c.ID,
+			FromID:      corporation.ID,
+			FromType:    app.StandingFromNPCCorp,
+			Standing:    -1.5,
+		}
+		// when
+		err := st.UpdateOrCreateCharacterStanding(ctx, arg)
+		// then
+		require.NoError(t, err)
+		o, err := st.GetCharacterStanding(ctx, c.ID, corporation.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, corporation, o.From)
+		xassert.Equal(t, app.StandingFromNPCCorp, o.FromType)
+		xassert.Equal(t, -1.5, o.Standing)
+		xassert.EqualOptional(t, faction, o.Faction)
+	})
+	t.Run("can update existing", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		o1 := factory.CreateCharacterStanding()
+		arg := storage.UpdateOrCreateCharacterStandingParams{
+			CharacterID: o1.CharacterID,
+			FromID:      o1.From.ID,
+			FromType:    o1.FromType,
+			Standing:    9.99,
+		}
+		// when
+		err := st.UpdateOrCreateCharacterStanding(ctx, arg)
+		// then
+		require.NoError(t, err)
+		o2, err := st.GetCharacterStanding(ctx, o1.CharacterID, o1.From.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, 9.99, o2.Standing)
+	})
+	t.Run("should return error when params are invalid", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		// when
+		err := st.UpdateOrCreateCharacterStanding(ctx, storage.UpdateOrCreateCharacterStandingParams{
+			CharacterID: c.ID,
+		})
+		// then
+		assert.ErrorIs(t, err, app.ErrInvalid)
+	})
+	t.Run("can list standings for a character", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		o1 := factory.CreateCharacterStanding(storage.UpdateOrCreateCharacterStandingParams{
+			CharacterID: c.ID,
+		})
+		o2 := factory.CreateCharacterStanding(storage.UpdateOrCreateCharacterStandingParams{
+			CharacterID: c.ID,
+			FromType:    app.StandingFromAgent,
+		})
+		factory.CreateCharacterStanding()
+		// when
+		oo, err := st.ListCharacterStandings(ctx, c.ID)
+		// then
+		require.NoError(t, err)
+		got := set.Collect(xiter.Map(slices.Values(oo), func(x *app.CharacterStanding) int64 {
+			return x.From.ID
+		}))
+		xassert.Equal(t, set.Of(o1.From.ID, o2.From.ID), got)
+	})
+	t.Run("can list standings for all characters", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		o1 := factory.CreateCharacterStanding()
+		o2 := factory.CreateCharacterStanding(storage.UpdateOrCreateCharacterStandingParams{
+			FromType: app.StandingFromNPCCorp,
+		})
+		// when
+		oo, err := st.ListAllCharacterStandings(ctx)
+		// then
+		require.NoError(t, err)
+		got := set.Collect(xiter.Map(slices.Values(oo), func(x *app.CharacterStanding) int64 {
+			return x.CharacterID
+		}))
+		xassert.Equal(t, set.Of(o1.CharacterID, o2.CharacterID), got)
+	})
+	t.Run("can list from IDs and delete standings", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		o1 := factory.CreateCharacterStanding(storage.UpdateOrCreateCharacterStandingParams{
+			CharacterID: c.ID,
+		})
+		o2 := factory.CreateCharacterStanding(storage.UpdateOrCreateCharacterStandingParams{
+			CharacterID: c.ID,
+		})
+		// when
+		err := st.DeleteCharacterStandings(ctx, c.ID, set.Of(o2.From.ID))
+		// then
+		require.NoError(t, err)
+		got, err := st.ListCharacterStandingFromIDs(ctx, c.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, set.Of(o1.From.ID), got)
+	})
+}
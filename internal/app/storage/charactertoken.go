@@ -27,7 +27,10 @@ func (st *Storage) GetCharacterToken(ctx context.Context, characterID int64) (*a
 	scopes := set.Of(xslices.Map(rows, func(x queries.Scope) string {
 		return x.Name
 	})...)
-	t2 := characterTokenFromDBModel(r, scopes)
+	t2, err := st.characterTokenFromDBModel(r, scopes)
+	if err != nil {
+		return nil, fmt.Errorf("get token for character %d: %w", characterID, err)
+	}
 	return t2, nil
 }
 
@@ -53,13 +56,21 @@ func UpdateOrCreateCharacterTokenParamsFromToken(o *app.CharacterToken) UpdateOr
 
 func (st *Storage) UpdateOrCreateCharacterToken(ctx context.Context, arg UpdateOrCreateCharacterTokenParams) error {
 	wrapErr := func(err error) error {
-		return fmt.Errorf("updateOrCreateCharacterToken: %+v: %w", arg, err)
+		return fmt.Errorf("updateOrCreateCharacterToken: character %d: %w", arg.CharacterID, err)
+	}
+	accessToken, err := st.encryptToken(ctx, arg.AccessToken)
+	if err != nil {
+		return wrapErr(err)
+	}
+	refreshToken, err := st.encryptToken(ctx, arg.RefreshToken)
+	if err != nil {
+		return wrapErr(err)
 	}
 	token, err := st.qRW.UpdateOrCreateCharacterToken(ctx, queries.UpdateOrCreateCharacterTokenParams{
-		AccessToken:  arg.AccessToken,
+		AccessToken:  accessToken,
 		CharacterID:  arg.CharacterID,
 		ExpiresAt:    arg.ExpiresAt,
-		RefreshToken: arg.RefreshToken,
+		RefreshToken: refreshToken,
 		TokenType:    arg.TokenType,
 	})
 	if err != nil {
@@ -156,22 +167,36 @@ func (st *Storage) ListCharacterTokenForCorporation(ctx context.Context, corpora
 		if !scopes2.ContainsAll(scopes.All()) {
 			continue
 		}
-		tokens = append(tokens, characterTokenFromDBModel(r, scopes2))
+		t, err := st.characterTokenFromDBModel(r, scopes2)
+		if err != nil {
+			return nil, wrapErr(err)
+		}
+		tokens = append(tokens, t)
 	}
 	return tokens, nil
 }
 
-func characterTokenFromDBModel(o queries.CharacterToken, scopes set.Set[string]) *app.CharacterToken {
+// characterTokenFromDBModel returns a token from a DB model and decrypts it's secrets when needed.
+func (st *Storage) characterTokenFromDBModel(o queries.CharacterToken, scopes set.Set[string]) (*app.CharacterToken, error) {
 	if o.CharacterID == 0 {
 		panic("missing character ID")
 	}
-	return &app.CharacterToken{
-		AccessToken:  o.AccessToken,
+	accessToken, err := st.decryptToken(o.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := st.decryptToken(o.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	t := &app.CharacterToken{
+		AccessToken:  accessToken,
 		CharacterID:  o.CharacterID,
 		ExpiresAt:    o.ExpiresAt,
 		ID:           o.ID,
-		RefreshToken: o.RefreshToken,
+		RefreshToken: refreshToken,
 		Scopes:       scopes,
 		TokenType:    o.TokenType,
 	}
+	return t, nil
 }
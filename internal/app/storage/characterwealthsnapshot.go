@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+)
+
+func (st *Storage) GetCharacterWealthSnapshot(ctx context.Context, characterID int64, day time.Time) (*app.CharacterWealthSnapshot, error) {
+	r, err := st.qRO.GetCharacterWealthSnapshot(ctx, queries.GetCharacterWealthSnapshotParams{
+		CharacterID: characterID,
+		Day:         day.UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetCharacterWealthSnapshot for character %d and day %s: %w", characterID, day, convertGetError(err))
+	}
+	return characterWealthSnapshotFromDBModel(r), nil
+}
+
+// ListCharacterWealthSnapshots returns all snapshots for all characters since a given day.
+func (st *Storage) ListCharacterWealthSnapshots(ctx context.Context, since time.Time) ([]*app.CharacterWealthSnapshot, error) {
+	rows, err := st.qRO.ListCharacterWealthSnapshots(ctx, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("ListCharacterWealthSnapshots since %s: %w", since, err)
+	}
+	oo := make([]*app.CharacterWealthSnapshot, len(rows))
+	for i, r := range rows {
+		oo[i] = characterWealthSnapshotFromDBModel(r)
+	}
+	return oo, nil
+}
+
+func characterWealthSnapshotFromDBModel(r queries.CharacterWealthSnapshot) *app.CharacterWealthSnapshot {
+	return &app.CharacterWealthSnapshot{
+		AssetsValue:     r.AssetsValue,
+		CharacterID:     r.CharacterID,
+		ContractsEscrow: r.ContractsEscrow,
+		Day:             r.Day.UTC(),
+		OrdersEscrow:    r.OrdersEscrow,
+		WalletBalance:   r.WalletBalance,
+	}
+}
+
+type UpdateOrCreateCharacterWealthSnapshotParams struct {
+	AssetsValue     float64
+	CharacterID     int64
+	ContractsEscrow float64
+	Day             time.Time
+	OrdersEscrow    float64
+	WalletBalance   float64
+}
+
+func (st *Storage) UpdateOrCreateCharacterWealthSnapshot(ctx context.Context, arg UpdateOrCreateCharacterWealthSnapshotParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("UpdateOrCreateCharacterWealthSnapshot: %+v: %w", arg, err)
+	}
+	if arg.CharacterID == 0 || arg.Day.IsZero() {
+		return wrapErr(app.ErrInvalid)
+	}
+	err := st.qRW.UpdateOrCreateCharacterWealthSnapshot(ctx, queries.UpdateOrCreateCharacterWealthSnapshotParams{
+		AssetsValue:     arg.AssetsValue,
+		CharacterID:     arg.CharacterID,
+		ContractsEscrow: arg.ContractsEscrow,
+		Day:             arg.Day.UTC(),
+		OrdersEscrow:    arg.OrdersEscrow,
+		WalletBalance:   arg.WalletBalance,
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestCharacterWealthSnapshot(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	ctx := context.Background()
+	t.Run("can create new", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		day := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+		arg := storage.UpdateOrCreateCharacterWealthSnapshotParams{
+			AssetsValue:     1,
+			CharacterID:     c.ID,
+			ContractsEscrow: 2,
+			Day:             day,
+			OrdersEscrow:    3,
+			WalletBalance:   4,
+		}
+		// when
+		err := st.UpdateOrCreateCharacterWealthSnapshot(ctx, arg)
+		// then
+		require.NoError(t, err)
+		o, err := st.GetCharacterWealthSnapshot(ctx, c.ID, day)
+		require.NoError(t, err)
+		xassert.Equal(t, c.ID, o.CharacterID)
+		xassert.Equal(t, day, o.Day)
+		xassert.Equal(t, 1.0, o.AssetsValue)
+		xassert.Equal(t, 2.0, o.ContractsEscrow)
+		xassert.Equal(t, 3.0, o.OrdersEscrow)
+		xassert.Equal(t, 4.0, o.WalletBalance)
+	})
+	t.Run("can update existing", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		o1 := factory.CreateCharacterWealthSnapshot()
+		arg := storage.UpdateOrCreateCharacterWealthSnapshotParams{
+			AssetsValue:     10,
+			CharacterID:     o1.CharacterID,
+			ContractsEscrow: 20,
+			Day:             o1.Day,
+			OrdersEscrow:    30,
+			WalletBalance:   40,
+		}
+		// when
+		err := st.UpdateOrCreateCharacterWealthSnapshot(ctx, arg)
+		// then
+		require.NoError(t, err)
+		o2, err := st.GetCharacterWealthSnapshot(ctx, o1.CharacterID, o1.Day)
+		require.NoError(t, err)
+		xassert.Equal(t, 100.0, o2.Total())
+	})
+	t.Run("should return not found error", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		// when
+		_, err := st.GetCharacterWealthSnapshot(ctx, c.ID, app.SnapshotDay(time.Now()))
+		// then
+		require.ErrorIs(t, err, app.ErrNotFound)
+	})
+	t.Run("can list snapshots since a day", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c1 := factory.CreateCharacter()
+		c2 := factory.CreateCharacter()
+		today := app.SnapshotDay(time.Now())
+		o1 := factory.CreateCharacterWealthSnapshot(storage.UpdateOrCreateCharacterWealthSnapshotParams{
+			CharacterID: c1.ID,
+			Day:         today.AddDate(0, 0, -1),
+		})
+		o2 := factory.CreateCharacterWealthSnapshot(storage.UpdateOrCreateCharacterWealthSnapshotParams{
+			CharacterID: c2.ID,
+			Day:         today,
+		})
+		factory.CreateCharacterWealthSnapshot(storage.UpdateOrCreateCharacterWealthSnapshotParams{
+			CharacterID: c1.ID,
+			Day:         today.AddDate(0, 0, -10),
+		})
+		// when
+		oo, err := st.ListCharacterWealthSnapshots(ctx, today.AddDate(0, 0, -2))
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, []*app.CharacterWealthSnapshot{o1, o2}, oo)
+	})
+	t.Run("should not allow zero day", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		// when
+		err := st.UpdateOrCreateCharacterWealthSnapshot(ctx, storage.UpdateOrCreateCharacterWealthSnapshotParams{
+			CharacterID: c.ID,
+		})
+		// then
+		require.ErrorIs(t, err, app.ErrInvalid)
+	})
+}
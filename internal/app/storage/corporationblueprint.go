@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+)
+
+type CreateCorporationBlueprintParams struct {
+	CorporationID      int64
+	ItemID             int64
+	LocationFlag       app.LocationFlag
+	LocationID         int64
+	MaterialEfficiency int
+	Quantity           int
+	Runs               int
+	TimeEfficiency     int
+	TypeID             int64
+}
+
+func (st *Storage) CreateCorporationBlueprint(ctx context.Context, arg CreateCorporationBlueprintParams) error {
+	return createCorporationBlueprint(ctx, st.qRW, arg)
+}
+
+func (st *Storage) DeleteCorporationBlueprints(ctx context.Context, corporationID int64) error {
+	if err := st.qRW.DeleteCorporationBlueprints(ctx, corporationID); err != nil {
+		return fmt.Errorf("DeleteCorporationBlueprints: %d: %w", corporationID, err)
+	}
+	return nil
+}
+
+// ListAllCorporationBlueprints returns the blueprints of all corporations ordered by name.
+func (st *Storage) ListAllCorporationBlueprints(ctx context.Context) ([]*app.CorporationBlueprint, error) {
+	rows, err := st.qRO.ListAllCorporationBlueprints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ListAllCorporationBlueprints: %w", err)
+	}
+	oo := make([]*app.CorporationBlueprint, len(rows))
+	for i, r := range rows {
+		oo[i] = corporationBlueprintFromDBModel(r.CorporationBlueprint, r.EveType, r.EveGroup, r.EveCategory)
+	}
+	return oo, nil
+}
+
+// ListCorporationBlueprints returns the blueprints of a corporation ordered by name.
+func (st *Storage) ListCorporationBlueprints(ctx context.Context, corporationID int64) ([]*app.CorporationBlueprint, error) {
+	rows, err := st.qRO.ListCorporationBlueprints(ctx, corporationID)
+	if err != nil {
+		return nil, fmt.Errorf("ListCorporationBlueprints: %d: %w", corporationID, err)
+	}
+	oo := make([]*app.CorporationBlueprint, len(rows))
+	for i, r := range rows {
+		oo[i] = corporationBlueprintFromDBModel(r.CorporationBlueprint, r.EveType, r.EveGroup, r.EveCategory)
+	}
+	return oo, nil
+}
+
+// ReplaceCorporationBlueprints replaces all blueprints of a corporation.
+func (st *Storage) ReplaceCorporationBlueprints(ctx context.Context, corporationID int64, blueprints []CreateCorporationBlueprintParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ReplaceCorporationBlueprints for ID %d: %w", corporationID, err)
+	}
+	if corporationID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	tx, err := st.dbRW.Begin()
+	if err != nil {
+		return wrapErr(err)
+	}
+	defer tx.Rollback()
+	qtx := st.qRW.WithTx(tx)
+	if err := qtx.DeleteCorporationBlueprints(ctx, corporationID); err != nil {
+		return wrapErr(err)
+	}
+	for _, arg := range blueprints {
+		if arg.CorporationID != corporationID {
+			return wrapErr(app.ErrInvalid)
+		}
+		if err := createCorporationBlueprint(ctx, qtx, arg); err != nil {
+			return wrapErr(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+func createCorporationBlueprint(ctx context.Context, q *queries.Queries, arg CreateCorporationBlueprintParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("createCorporationBlueprint: %+v: %w", arg, err)
+	}
+	if arg.CorporationID == 0 || arg.ItemID == 0 || arg.TypeID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	err := q.CreateCorporationBlueprint(ctx, queries.CreateCorporationBlueprintParams{
+		CorporationID:      arg.CorporationID,
+		EveTypeID:          arg.TypeID,
+		ItemID:             arg.ItemID,
+		LocationFlag:       locationFlagToDBValue2[arg.LocationFlag],
+		LocationID:         arg.LocationID,
+		MaterialEfficiency: int64(arg.MaterialEfficiency),
+		Quantity:           int64(arg.Quantity),
+		Runs:               int64(arg.Runs),
+		TimeEfficiency:     int64(arg.TimeEfficiency),
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+func corporationBlueprintFromDBModel(r queries.CorporationBlueprint, t queries.EveType, g queries.EveGroup, c queries.EveCategory) *app.CorporationBlueprint {
+	locationFlag, found := locationFlagFromDBValue2[r.LocationFlag]
+	if !found {
+		locationFlag = app.FlagUnknown
+	}
+	return &app.CorporationBlueprint{
+		CorporationID: r.CorporationID,
+		Blueprint: app.Blueprint{
+			ItemID:             r.ItemID,
+			LocationFlag:       locationFlag,
+			LocationID:         r.LocationID,
+			MaterialEfficiency: int(r.MaterialEfficiency),
+			Quantity:           int(r.Quantity),
+			Runs:               int(r.Runs),
+			TimeEfficiency:     int(r.TimeEfficiency),
+			Type:               eveTypeFromDBModel(t, g, c),
+		},
+	}
+}
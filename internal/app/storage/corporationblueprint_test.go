@@ -0,0 +1,107 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func TestCorporationBlueprint(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	t.Run("can create new blueprint", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		owner := factory.CreateCorporation()
+		et := factory.CreateEveType()
+		location := factory.CreateEveLocationStructure()
+		// when
+		err := st.CreateCorporationBlueprint(t.Context(), storage.CreateCorporationBlueprintParams{
+			CorporationID:      owner.ID,
+			ItemID:             42,
+			LocationFlag:       app.FlagHangar,
+			LocationID:         location.ID,
+			MaterialEfficiency: 10,
+			Quantity:           -2,
+			Runs:               5,
+			TimeEfficiency:     20,
+			TypeID:             et.ID,
+		})
+		// then
+		require.NoError(t, err)
+		oo, err := st.ListCorporationBlueprints(t.Context(), owner.ID)
+		require.NoError(t, err)
+		if assert.Len(t, oo, 1) {
+			o := oo[0]
+			xassert.Equal(t, owner.ID, o.CorporationID)
+			xassert.Equal(t, 42, o.ItemID)
+			xassert.Equal(t, app.FlagHangar, o.LocationFlag)
+			xassert.Equal(t, location.ID, o.LocationID)
+			xassert.Equal(t, 10, o.MaterialEfficiency)
+			xassert.Equal(t, -2, o.Quantity)
+			xassert.Equal(t, 5, o.Runs)
+			xassert.Equal(t, 20, o.TimeEfficiency)
+			xassert.Equal(t, et.ID, o.Type.ID)
+			assert.True(t, o.IsCopy())
+		}
+	})
+	t.Run("can replace blueprints", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		owner := factory.CreateCorporation()
+		factory.CreateCorporationBlueprint(storage.CreateCorporationBlueprintParams{CorporationID: owner.ID})
+		et := factory.CreateEveType()
+		location := factory.CreateEveLocationStructure()
+		// when
+		err := st.ReplaceCorporationBlueprints(t.Context(), owner.ID, []storage.CreateCorporationBlueprintParams{{
+			CorporationID: owner.ID,
+			ItemID:        7,
+			LocationFlag:  app.FlagHangar,
+			LocationID:    location.ID,
+			Quantity:      -1,
+			Runs:          -1,
+			TypeID:        et.ID,
+		}})
+		// then
+		require.NoError(t, err)
+		oo, err := st.ListCorporationBlueprints(t.Context(), owner.ID)
+		require.NoError(t, err)
+		got := xslices.Map(oo, func(x *app.CorporationBlueprint) int64 {
+			return x.ItemID
+		})
+		xassert.Equal(t, []int64{7}, got)
+	})
+	t.Run("can list blueprints of all owners", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		o1 := factory.CreateCorporationBlueprint()
+		o2 := factory.CreateCorporationBlueprint()
+		// when
+		oo, err := st.ListAllCorporationBlueprints(t.Context())
+		// then
+		require.NoError(t, err)
+		got := xslices.Map(oo, func(x *app.CorporationBlueprint) int64 {
+			return x.ItemID
+		})
+		assert.ElementsMatch(t, []int64{o1.ItemID, o2.ItemID}, got)
+	})
+	t.Run("can delete blueprints", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		o := factory.CreateCorporationBlueprint()
+		// when
+		err := st.DeleteCorporationBlueprints(t.Context(), o.CorporationID)
+		// then
+		require.NoError(t, err)
+		oo, err := st.ListCorporationBlueprints(t.Context(), o.CorporationID)
+		require.NoError(t, err)
+		assert.Empty(t, oo)
+	})
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+)
+
+// CreateCorporationKillmail links an existing killmail to a corporation.
+// Does nothing when the link already exists.
+func (st *Storage) CreateCorporationKillmail(ctx context.Context, corporationID, killmailID int64) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("CreateCorporationKillmail: corporation %d, killmail %d: %w", corporationID, killmailID, err)
+	}
+	if corporationID == 0 || killmailID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	err := st.qRW.CreateCorporationKillmail(ctx, queries.CreateCorporationKillmailParams{
+		CorporationID: corporationID,
+		KillmailID:    killmailID,
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+func (st *Storage) DeleteCorporationKillmails(ctx context.Context, corporationID int64) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("DeleteCorporationKillmails: corporation %d: %w", corporationID, err)
+	}
+	if corporationID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	if err := st.qRW.DeleteCorporationKillmails(ctx, corporationID); err != nil {
+		return wrapErr(err)
+	}
+	slog.Info("Killmails deleted for corporation", "corporationID", corporationID)
+	return nil
+}
+
+func (st *Storage) ListCorporationKillmailIDs(ctx context.Context, corporationID int64) (set.Set[int64], error) {
+	ids, err := st.qRO.ListCorporationKillmailIDs(ctx, corporationID)
+	if err != nil {
+		return set.Set[int64]{}, fmt.Errorf("ListCorporationKillmailIDs for corporation %d: %w", corporationID, err)
+	}
+	return set.Collect(slices.Values(ids)), nil
+}
+
+// ListCorporationKillmails returns the killmails of a corporation ordered by time with the most recent first.
+// Attackers and items are not populated.
+func (st *Storage) ListCorporationKillmails(ctx context.Context, corporationID int64) ([]*app.EveKillmail, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ListCorporationKillmails for corporation %d: %w", corporationID, err)
+	}
+	ids, err := st.qRO.ListCorporationKillmailIDs(ctx, corporationID)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	oo, err := st.listEveKillmails(ctx, ids)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	return oo, nil
+}
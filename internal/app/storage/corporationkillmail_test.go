@@ -0,0 +1,36 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestCorporationKillmail(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	ctx := context.Background()
+	t.Run("can link, list and delete killmails", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCorporation()
+		k := factory.CreateEveKillmail()
+		// when
+		err := st.CreateCorporationKillmail(ctx, c.ID, k.ID)
+		// then
+		require.NoError(t, err)
+		oo, err := st.ListCorporationKillmails(ctx, c.ID)
+		require.NoError(t, err)
+		require.Len(t, oo, 1)
+		xassert.Equal(t, k.ID, oo[0].ID)
+		err = st.DeleteCorporationKillmails(ctx, c.ID)
+		require.NoError(t, err)
+		ids, err := st.ListCorporationKillmailIDs(ctx, c.ID)
+		require.NoError(t, err)
+		xassert.Equal(t, 0, ids.Size())
+	})
+}
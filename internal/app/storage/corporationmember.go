@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/ErikKalkoken/go-set"
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
 	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
 )
 
 type CorporationMemberParams struct {
@@ -51,7 +53,7 @@ func (st *Storage) GetCorporationMember(ctx context.Context, arg CorporationMemb
 	if err != nil {
 		return nil, wrapErr(convertGetError(err))
 	}
-	return corporationMemberFromDBModel(r.CorporationMember, r.EveEntity), nil
+	return corporationMemberFromDBModel(r), nil
 }
 
 func (st *Storage) DeleteCorporationMembers(ctx context.Context, corporationID int64, characterIDs set.Set[int64]) error {
@@ -87,7 +89,7 @@ func (st *Storage) ListCorporationMembers(ctx context.Context, corporationID int
 	}
 	oo := make([]*app.CorporationMember, len(rows))
 	for i, r := range rows {
-		oo[i] = corporationMemberFromDBModel(r.CorporationMember, r.EveEntity)
+		oo[i] = corporationMemberFromDBModel(queries.GetCorporationMembersRow(r))
 	}
 	return oo, nil
 }
@@ -106,10 +108,81 @@ func (st *Storage) ListCorporationMemberIDs(ctx context.Context, corporationID i
 	return set.Collect(slices.Values(ids)), nil
 }
 
-func corporationMemberFromDBModel(o queries.CorporationMember, ee queries.EveEntity) *app.CorporationMember {
+// ResetCorporationMemberTracking removes the tracking data from all members of a corporation.
+func (st *Storage) ResetCorporationMemberTracking(ctx context.Context, corporationID int64) error {
+	if corporationID == 0 {
+		return fmt.Errorf("ResetCorporationMemberTracking: %w", app.ErrInvalid)
+	}
+	if err := st.qRW.ResetCorporationMemberTracking(ctx, corporationID); err != nil {
+		return fmt.Errorf("ResetCorporationMemberTracking: %d: %w", corporationID, err)
+	}
+	return nil
+}
+
+type UpdateOrCreateCorporationMemberTrackingParams struct {
+	BaseID        optional.Optional[int64]
+	CharacterID   int64
+	CorporationID int64
+	LocationID    optional.Optional[int64]
+	LogoffDate    optional.Optional[time.Time]
+	LogonDate     optional.Optional[time.Time]
+	ShipTypeID    optional.Optional[int64]
+	StartDate     optional.Optional[time.Time]
+}
+
+// UpdateOrCreateCorporationMemberTracking updates the tracking data of a member
+// and creates the member if it does not exist.
+func (st *Storage) UpdateOrCreateCorporationMemberTracking(ctx context.Context, arg UpdateOrCreateCorporationMemberTrackingParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("UpdateOrCreateCorporationMemberTracking %+v: %w", arg, err)
+	}
+	if arg.CharacterID == 0 || arg.CorporationID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	err := st.qRW.UpdateOrCreateCorporationMemberTracking(ctx, queries.UpdateOrCreateCorporationMemberTrackingParams{
+		BaseID:        optional.ToNullInt64(arg.BaseID),
+		CharacterID:   arg.CharacterID,
+		CorporationID: arg.CorporationID,
+		LocationID:    optional.ToNullInt64(arg.LocationID),
+		LogoffDate:    optional.ToNullTime(arg.LogoffDate),
+		LogonDate:     optional.ToNullTime(arg.LogonDate),
+		ShipTypeID:    optional.ToNullInt64(arg.ShipTypeID),
+		StartDate:     optional.ToNullTime(arg.StartDate),
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+func corporationMemberFromDBModel(r queries.GetCorporationMembersRow) *app.CorporationMember {
+	o := r.CorporationMember
 	o2 := &app.CorporationMember{
 		CorporationID: o.CorporationID,
-		Character:     eveEntityFromDBModel(ee),
+		Character:     eveEntityFromDBModel(r.EveEntity),
+		LogoffDate:    optional.FromNullTime(o.LogoffDate),
+		LogonDate:     optional.FromNullTime(o.LogonDate),
+		StartDate:     optional.FromNullTime(o.StartDate),
+	}
+	if o.BaseID.Valid {
+		o2.Base = optional.New(&app.EveLocationShort{
+			ID:             o.BaseID.Int64,
+			Name:           optional.FromNullString(r.BaseName),
+			SecurityStatus: optional.FromNullFloat64ToFloat32(r.BaseSecurityStatus),
+		})
+	}
+	if o.LocationID.Valid {
+		o2.Location = optional.New(&app.EveLocationShort{
+			ID:             o.LocationID.Int64,
+			Name:           optional.FromNullString(r.LocationName),
+			SecurityStatus: optional.FromNullFloat64ToFloat32(r.LocationSecurityStatus),
+		})
+	}
+	if o.ShipTypeID.Valid && r.ShipTypeName.Valid {
+		o2.ShipType = optional.New(&app.EntityShort{
+			ID:   o.ShipTypeID.Int64,
+			Name: r.ShipTypeName.String,
+		})
 	}
 	return o2
 }
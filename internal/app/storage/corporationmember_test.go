@@ -3,13 +3,16 @@ package storage_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/ErikKalkoken/go-set"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
 	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
 	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
 	"github.com/ErikKalkoken/evebuddy/internal/xassert"
 	"github.com/ErikKalkoken/evebuddy/internal/xslices"
 )
@@ -102,4 +105,83 @@ func TestCorporationMember(t *testing.T) {
 			xassert.Equal(t, want, got)
 		}
 	})
+	t.Run("can update tracking of existing member", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		m := factory.CreateCorporationMember()
+		base := factory.CreateEveLocationStation()
+		location := factory.CreateEveLocationStructure()
+		ship := factory.CreateEveType()
+		logoff := time.Now().UTC().Add(-3 * time.Hour).Truncate(time.Second)
+		logon := logoff.Add(-2 * time.Hour)
+		start := logoff.Add(-100 * 24 * time.Hour)
+		// when
+		err := st.UpdateOrCreateCorporationMemberTracking(ctx, storage.UpdateOrCreateCorporationMemberTrackingParams{
+			BaseID:        optional.New(base.ID),
+			CharacterID:   m.Character.ID,
+			CorporationID: m.CorporationID,
+			LocationID:    optional.New(location.ID),
+			LogoffDate:    optional.New(logoff),
+			LogonDate:     optional.New(logon),
+			ShipTypeID:    optional.New(ship.ID),
+			StartDate:     optional.New(start),
+		})
+		// then
+		if assert.NoError(t, err) {
+			x, err := st.GetCorporationMember(ctx, storage.CorporationMemberParams{
+				CorporationID: m.CorporationID,
+				CharacterID:   m.Character.ID,
+			})
+			if assert.NoError(t, err) {
+				xassert.Equal(t, base.ID, x.Base.MustValue().ID)
+				xassert.Equal(t, base.Name, x.Base.MustValue().Name.ValueOrZero())
+				xassert.Equal(t, location.ID, x.Location.MustValue().ID)
+				xassert.Equal(t, ship.Name, x.ShipType.MustValue().Name)
+				xassert.EqualOptional(t, logoff, x.LogoffDate)
+				xassert.EqualOptional(t, logon, x.LogonDate)
+				xassert.EqualOptional(t, start, x.StartDate)
+			}
+		}
+	})
+	t.Run("can create member with tracking", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCorporation()
+		character := factory.CreateEveEntityCharacter()
+		// when
+		err := st.UpdateOrCreateCorporationMemberTracking(ctx, storage.UpdateOrCreateCorporationMemberTrackingParams{
+			CharacterID:   character.ID,
+			CorporationID: c.ID,
+		})
+		// then
+		if assert.NoError(t, err) {
+			got, err := st.ListCorporationMemberIDs(ctx, c.ID)
+			if assert.NoError(t, err) {
+				xassert.Equal(t, set.Of(character.ID), got)
+			}
+		}
+	})
+	t.Run("can reset tracking", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		m := factory.CreateCorporationMember()
+		err := st.UpdateOrCreateCorporationMemberTracking(ctx, storage.UpdateOrCreateCorporationMemberTrackingParams{
+			CharacterID:   m.Character.ID,
+			CorporationID: m.CorporationID,
+			LogonDate:     optional.New(time.Now().UTC()),
+		})
+		require.NoError(t, err)
+		// when
+		err = st.ResetCorporationMemberTracking(ctx, m.CorporationID)
+		// then
+		if assert.NoError(t, err) {
+			x, err := st.GetCorporationMember(ctx, storage.CorporationMemberParams{
+				CorporationID: m.CorporationID,
+				CharacterID:   m.Character.ID,
+			})
+			if assert.NoError(t, err) {
+				assert.False(t, x.HasTracking())
+			}
+		}
+	})
 }
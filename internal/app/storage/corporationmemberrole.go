@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+)
+
+var roleLocation2String = map[app.RoleLocation]string{
+	app.RoleLocationBase:      "base",
+	app.RoleLocationGeneral:   "general",
+	app.RoleLocationGrantable: "grantable",
+	app.RoleLocationHQ:        "hq",
+	app.RoleLocationOther:     "other",
+}
+
+var string2RoleLocation = map[string]app.RoleLocation{}
+
+func init() {
+	for k, v := range roleLocation2String {
+		string2RoleLocation[v] = k
+	}
+}
+
+type CorporationMemberRoleParams struct {
+	CharacterID   int64
+	CorporationID int64
+	Location      app.RoleLocation
+	Role          app.Role
+}
+
+func (arg CorporationMemberRoleParams) isValid(corporationID int64) bool {
+	return arg.CorporationID == corporationID &&
+		arg.CharacterID != 0 &&
+		arg.Location != app.RoleLocationUndefined &&
+		arg.Role != app.RoleUndefined
+}
+
+type CreateCorporationRoleChangeParams struct {
+	ChangedAt     time.Time
+	CharacterID   int64
+	CorporationID int64
+	IsGranted     bool
+	Location      app.RoleLocation
+	Role          app.Role
+}
+
+func (arg CreateCorporationRoleChangeParams) isValid(corporationID int64) bool {
+	return arg.CorporationID == corporationID &&
+		arg.CharacterID != 0 &&
+		arg.Location != app.RoleLocationUndefined &&
+		arg.Role != app.RoleUndefined
+}
+
+// DeleteCorporationMemberRoles deletes all member roles and role changes of a corporation.
+func (st *Storage) DeleteCorporationMemberRoles(ctx context.Context, corporationID int64) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("DeleteCorporationMemberRoles: %d: %w", corporationID, err)
+	}
+	if corporationID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	tx, err := st.dbRW.Begin()
+	if err != nil {
+		return wrapErr(err)
+	}
+	defer tx.Rollback()
+	qtx := st.qRW.WithTx(tx)
+	if err := qtx.DeleteCorporationMemberRoles(ctx, corporationID); err != nil {
+		return wrapErr(err)
+	}
+	if err := qtx.DeleteCorporationRoleChanges(ctx, corporationID); err != nil {
+		return wrapErr(err)
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+func (st *Storage) ListCorporationMemberRoles(ctx context.Context, corporationID int64) ([]*app.CorporationMemberRole, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ListCorporationMemberRoles: %d: %w", corporationID, err)
+	}
+	if corporationID == 0 {
+		return nil, wrapErr(app.ErrInvalid)
+	}
+	rows, err := st.qRO.ListCorporationMemberRoles(ctx, corporationID)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	oo := make([]*app.CorporationMemberRole, len(rows))
+	for i, r := range rows {
+		oo[i] = &app.CorporationMemberRole{
+			CorporationID: r.CorporationMemberRole.CorporationID,
+			Character:     eveEntityFromDBModel(r.EveEntity),
+			Location:      string2RoleLocation[r.CorporationMemberRole.Location],
+			Role:          string2Role[r.CorporationMemberRole.Name],
+		}
+	}
+	return oo, nil
+}
+
+func (st *Storage) ListCorporationRoleChanges(ctx context.Context, corporationID int64) ([]*app.CorporationRoleChange, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ListCorporationRoleChanges: %d: %w", corporationID, err)
+	}
+	if corporationID == 0 {
+		return nil, wrapErr(app.ErrInvalid)
+	}
+	rows, err := st.qRO.ListCorporationRoleChanges(ctx, corporationID)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	oo := make([]*app.CorporationRoleChange, len(rows))
+	for i, r := range rows {
+		oo[i] = &app.CorporationRoleChange{
+			ID:            r.CorporationRoleChange.ID,
+			ChangedAt:     r.CorporationRoleChange.ChangedAt,
+			Character:     eveEntityFromDBModel(r.EveEntity),
+			CorporationID: r.CorporationRoleChange.CorporationID,
+			IsGranted:     r.CorporationRoleChange.IsGranted,
+			Location:      string2RoleLocation[r.CorporationRoleChange.Location],
+			Role:          string2Role[r.CorporationRoleChange.Name],
+		}
+	}
+	return oo, nil
+}
+
+// ReplaceCorporationMemberRoles replaces all member roles of a corporation
+// and records the changes in the same transaction.
+func (st *Storage) ReplaceCorporationMemberRoles(ctx context.Context, corporationID int64, roles []CorporationMemberRoleParams, changes []CreateCorporationRoleChangeParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ReplaceCorporationMemberRoles: %d: %w", corporationID, err)
+	}
+	if corporationID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	tx, err := st.dbRW.Begin()
+	if err != nil {
+		return wrapErr(err)
+	}
+	defer tx.Rollback()
+	qtx := st.qRW.WithTx(tx)
+	if err := qtx.DeleteCorporationMemberRoles(ctx, corporationID); err != nil {
+		return wrapErr(err)
+	}
+	for _, arg := range roles {
+		if !arg.isValid(corporationID) {
+			return wrapErr(app.ErrInvalid)
+		}
+		err := qtx.CreateCorporationMemberRole(ctx, queries.CreateCorporationMemberRoleParams{
+			CharacterID:   arg.CharacterID,
+			CorporationID: arg.CorporationID,
+			Location:      roleLocation2String[arg.Location],
+			Name:          role2String[arg.Role],
+		})
+		if err != nil {
+			return wrapErr(err)
+		}
+	}
+	for _, arg := range changes {
+		if !arg.isValid(corporationID) {
+			return wrapErr(app.ErrInvalid)
+		}
+		err := qtx.CreateCorporationRoleChange(ctx, queries.CreateCorporationRoleChangeParams{
+			ChangedAt:     arg.ChangedAt,
+			CharacterID:   arg.CharacterID,
+			CorporationID: arg.CorporationID,
+			IsGranted:     arg.IsGranted,
+			Location:      roleLocation2String[arg.Location],
+			Name:          role2String[arg.Role],
+		})
+		if err != nil {
+			return wrapErr(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func TestCorporationMemberRole(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	ctx := context.Background()
+	t.Run("can replace member roles and record changes", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCorporation()
+		m1 := factory.CreateEveEntityCharacter()
+		m2 := factory.CreateEveEntityCharacter()
+		err := st.ReplaceCorporationMemberRoles(ctx, c.ID, []storage.CorporationMemberRoleParams{
+			{CorporationID: c.ID, CharacterID: m1.ID, Location: app.RoleLocationGeneral, Role: app.RoleAccountant},
+		}, nil)
+		require.NoError(t, err)
+		changedAt := time.Now().UTC().Truncate(time.Second)
+		// when
+		err = st.ReplaceCorporationMemberRoles(ctx, c.ID, []storage.CorporationMemberRoleParams{
+			{CorporationID: c.ID, CharacterID: m1.ID, Location: app.RoleLocationGeneral, Role: app.RoleDirector},
+			{CorporationID: c.ID, CharacterID: m2.ID, Location: app.RoleLocationGeneral, Role: app.RoleStationManager},
+		}, []storage.CreateCorporationRoleChangeParams{
+			{ChangedAt: changedAt, CorporationID: c.ID, CharacterID: m1.ID, IsGranted: true, Location: app.RoleLocationGeneral, Role: app.RoleDirector},
+			{ChangedAt: changedAt, CorporationID: c.ID, CharacterID: m1.ID, IsGranted: false, Location: app.RoleLocationGeneral, Role: app.RoleAccountant},
+		})
+		// then
+		if assert.NoError(t, err) {
+			oo, err := st.ListCorporationMemberRoles(ctx, c.ID)
+			require.NoError(t, err)
+			got := set.Of(xslices.Map(oo, func(x *app.CorporationMemberRole) app.Role {
+				return x.Role
+			})...)
+			want := set.Of(app.RoleDirector, app.RoleStationManager)
+			xassert.Equal(t, want, got)
+			changes, err := st.ListCorporationRoleChanges(ctx, c.ID)
+			require.NoError(t, err)
+			if assert.Len(t, changes, 2) {
+				xassert.Equal(t, m1, changes[0].Character)
+				xassert.Equal(t, changedAt, changes[0].ChangedAt.UTC())
+				xassert.Equal(t, app.RoleAccountant, changes[0].Role)
+				assert.False(t, changes[0].IsGranted)
+				xassert.Equal(t, app.RoleDirector, changes[1].Role)
+				assert.True(t, changes[1].IsGranted)
+			}
+		}
+	})
+	t.Run("can store the same role at different locations", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCorporation()
+		m := factory.CreateEveEntityCharacter()
+		changedAt := time.Now().UTC().Truncate(time.Second)
+		// when
+		err := st.ReplaceCorporationMemberRoles(ctx, c.ID, []storage.CorporationMemberRoleParams{
+			{CorporationID: c.ID, CharacterID: m.ID, Location: app.RoleLocationGeneral, Role: app.RoleStationManager},
+			{CorporationID: c.ID, CharacterID: m.ID, Location: app.RoleLocationHQ, Role: app.RoleStationManager},
+		}, []storage.CreateCorporationRoleChangeParams{
+			{ChangedAt: changedAt, CorporationID: c.ID, CharacterID: m.ID, IsGranted: true, Location: app.RoleLocationHQ, Role: app.RoleStationManager},
+		})
+		// then
+		if assert.NoError(t, err) {
+			oo, err := st.ListCorporationMemberRoles(ctx, c.ID)
+			require.NoError(t, err)
+			got := set.Of(xslices.Map(oo, func(x *app.CorporationMemberRole) app.RoleLocation {
+				return x.Location
+			})...)
+			want := set.Of(app.RoleLocationGeneral, app.RoleLocationHQ)
+			xassert.Equal(t, want, got)
+			changes, err := st.ListCorporationRoleChanges(ctx, c.ID)
+			require.NoError(t, err)
+			if assert.Len(t, changes, 1) {
+				xassert.Equal(t, app.RoleLocationHQ, changes[0].Location)
+			}
+		}
+	})
+	t.Run("should reject roles without location", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCorporation()
+		m := factory.CreateEveEntityCharacter()
+		// when
+		err := st.ReplaceCorporationMemberRoles(ctx, c.ID, []storage.CorporationMemberRoleParams{
+			{CorporationID: c.ID, CharacterID: m.ID, Role: app.RoleStationManager},
+		}, nil)
+		// then
+		assert.ErrorIs(t, err, app.ErrInvalid)
+	})
+	t.Run("can delete member roles and changes", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCorporation()
+		m := factory.CreateEveEntityCharacter()
+		err := st.ReplaceCorporationMemberRoles(ctx, c.ID, []storage.CorporationMemberRoleParams{
+			{CorporationID: c.ID, CharacterID: m.ID, Location: app.RoleLocationGeneral, Role: app.RoleDirector},
+		}, []storage.CreateCorporationRoleChangeParams{
+			{ChangedAt: time.Now(), CorporationID: c.ID, CharacterID: m.ID, IsGranted: true, Location: app.RoleLocationGeneral, Role: app.RoleDirector},
+		})
+		require.NoError(t, err)
+		// when
+		err = st.DeleteCorporationMemberRoles(ctx, c.ID)
+		// then
+		if assert.NoError(t, err) {
+			oo, err := st.ListCorporationMemberRoles(ctx, c.ID)
+			require.NoError(t, err)
+			assert.Len(t, oo, 0)
+			changes, err := st.ListCorporationRoleChanges(ctx, c.ID)
+			require.NoError(t, err)
+			assert.Len(t, changes, 0)
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+type UpdateOrCreateCorporationMiningObserverParams struct {
+	CorporationID int64
+	LastUpdated   time.Time
+	ObserverID    int64
+	ObserverType  string
+}
+
+func (st *Storage) UpdateOrCreateCorporationMiningObserver(ctx context.Context, arg UpdateOrCreateCorporationMiningObserverParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("UpdateOrCreateCorporationMiningObserver: %+v: %w", arg, err)
+	}
+	if arg.CorporationID == 0 || arg.ObserverID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	err := st.qRW.UpdateOrCreateCorporationMiningObserver(ctx, queries.UpdateOrCreateCorporationMiningObserverParams{
+		CorporationID: arg.CorporationID,
+		LastUpdated:   arg.LastUpdated.UTC(),
+		ObserverID:    arg.ObserverID,
+		ObserverType:  arg.ObserverType,
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+// DeleteCorporationMiningObservers deletes all mining observers of a corporation incl. their entries.
+func (st *Storage) DeleteCorporationMiningObservers(ctx context.Context, corporationID int64) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("DeleteCorporationMiningObservers: %d: %w", corporationID, err)
+	}
+	tx, err := st.dbRW.Begin()
+	if err != nil {
+		return wrapErr(err)
+	}
+	defer tx.Rollback()
+	qtx := st.qRW.WithTx(tx)
+	if err := qtx.DeleteCorporationMiningObserverEntries(ctx, corporationID); err != nil {
+		return wrapErr(err)
+	}
+	if err := qtx.DeleteCorporationMiningObservers(ctx, corporationID); err != nil {
+		return wrapErr(err)
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+func (st *Storage) ListCorporationMiningObservers(ctx context.Context, corporationID int64) ([]*app.CorporationMiningObserver, error) {
+	rows, err := st.qRO.ListCorporationMiningObservers(ctx, corporationID)
+	if err != nil {
+		return nil, fmt.Errorf("ListCorporationMiningObservers: %d: %w", corporationID, err)
+	}
+	oo := make([]*app.CorporationMiningObserver, len(rows))
+	for i, r := range rows {
+		oo[i] = &app.CorporationMiningObserver{
+			CorporationID: r.CorporationMiningObserver.CorporationID,
+			LastUpdated:   r.CorporationMiningObserver.LastUpdated,
+			ObserverID:    r.CorporationMiningObserver.ObserverID,
+			ObserverType:  r.CorporationMiningObserver.ObserverType,
+			StructureName: optional.FromZeroValue(r.StructureName.String),
+		}
+	}
+	return oo, nil
+}
+
+type UpdateOrCreateCorporationMiningObserverEntryParams struct {
+	CharacterID           int64
+	CorporationID         int64
+	LastUpdated           time.Time
+	ObserverID            int64
+	Quantity              int64
+	RecordedCorporationID int64
+	TypeID                int64
+}
+
+// UpdateOrCreateCorporationMiningObserverEntry updates or creates an entry for a mining observer.
+// Entries are identified by observer, character, type and day.
+func (st *Storage) UpdateOrCreateCorporationMiningObserverEntry(ctx context.Context, arg UpdateOrCreateCorporationMiningObserverEntryParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("UpdateOrCreateCorporationMiningObserverEntry: %+v: %w", arg, err)
+	}
+	if arg.CharacterID == 0 || arg.CorporationID == 0 || arg.ObserverID == 0 || arg.RecordedCorporationID == 0 || arg.TypeID == 0 || arg.LastUpdated.IsZero() {
+		return wrapErr(app.ErrInvalid)
+	}
+	err := st.qRW.UpdateOrCreateCorporationMiningObserverEntry(ctx, queries.UpdateOrCreateCorporationMiningObserverEntryParams{
+		CharacterID:           arg.CharacterID,
+		CorporationID:         arg.CorporationID,
+		EveTypeID:             arg.TypeID,
+		LastUpdated:           arg.LastUpdated.UTC(),
+		ObserverID:            arg.ObserverID,
+		Quantity:              arg.Quantity,
+		RecordedCorporationID: arg.RecordedCorporationID,
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+// ListAllCorporationMiningObserverEntries returns the mining observer entries of all corporations.
+// Newest entries come first.
+func (st *Storage) ListAllCorporationMiningObserverEntries(ctx context.Context) ([]*app.CorporationMiningObserverEntry, error) {
+	rows, err := st.qRO.ListAllCorporationMiningObserverEntries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ListAllCorporationMiningObserverEntries: %w", err)
+	}
+	oo := make([]*app.CorporationMiningObserverEntry, len(rows))
+	for i, r := range rows {
+		oo[i] = corporationMiningObserverEntryFromDBModel(
+			r.CorporationMiningObserverEntry,
+			r.EveEntity,
+			r.EveType,
+			r.EveGroup,
+			r.EveCategory,
+			r.Price,
+			r.StructureName,
+		)
+	}
+	return oo, nil
+}
+
+// ListCorporationMiningObserverEntries returns the mining observer entries of a corporation.
+// Newest entries come first.
+func (st *Storage) ListCorporationMiningObserverEntries(ctx context.Context, corporationID int64) ([]*app.CorporationMiningObserverEntry, error) {
+	rows, err := st.qRO.ListCorporationMiningObserverEntries(ctx, corporationID)
+	if err != nil {
+		return nil, fmt.Errorf("ListCorporationMiningObserverEntries: %d: %w", corporationID, err)
+	}
+	oo := make([]*app.CorporationMiningObserverEntry, len(rows))
+	for i, r := range rows {
+		oo[i] = corporationMiningObserverEntryFromDBModel(
+			r.CorporationMiningObserverEntry,
+			r.EveEntity,
+			r.EveType,
+			r.EveGroup,
+			r.EveCategory,
+			r.Price,
+			r.StructureName,
+		)
+	}
+	return oo, nil
+}
+
+func corporationMiningObserverEntryFromDBModel(
+	e queries.CorporationMiningObserverEntry,
+	character queries.EveEntity,
+	t queries.EveType,
+	g queries.EveGroup,
+	c queries.EveCategory,
+	price sql.NullFloat64,
+	structureName sql.NullString,
+) *app.CorporationMiningObserverEntry {
+	if e.CorporationID == 0 {
+		panic("missing corporation ID")
+	}
+	return &app.CorporationMiningObserverEntry{
+		Character:             eveEntityFromDBModel(character),
+		CorporationID:         e.CorporationID,
+		LastUpdated:           e.LastUpdated,
+		ObserverID:            e.ObserverID,
+		Price:                 optional.FromNullFloat64(price),
+		Quantity:              e.Quantity,
+		RecordedCorporationID: e.RecordedCorporationID,
+		StructureName:         optional.FromZeroValue(structureName.String),
+		Type:                  eveTypeFromDBModel(t, g, c),
+	}
+}
@@ -0,0 +1,155 @@
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func TestCorporationMiningObserver(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	t.Run("can create new observer", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCorporation()
+		lastUpdated := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+		// when
+		err := st.UpdateOrCreateCorporationMiningObserver(t.Context(), storage.UpdateOrCreateCorporationMiningObserverParams{
+			CorporationID: c.ID,
+			LastUpdated:   lastUpdated,
+			ObserverID:    1_000_000_000_001,
+			ObserverType:  "structure",
+		})
+		// then
+		require.NoError(t, err)
+		oo, err := st.ListCorporationMiningObservers(t.Context(), c.ID)
+		require.NoError(t, err)
+		if assert.Len(t, oo, 1) {
+			o := oo[0]
+			xassert.Equal(t, c.ID, o.CorporationID)
+			assert.True(t, lastUpdated.Equal(o.LastUpdated))
+			xassert.Equal(t, 1_000_000_000_001, o.ObserverID)
+			xassert.Equal(t, "structure", o.ObserverType)
+			assert.True(t, o.StructureName.IsEmpty())
+		}
+	})
+	t.Run("should return name of known structures", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		s := factory.CreateCorporationStructure(storage.UpdateOrCreateCorporationStructureParams{
+			Name: optional.New("Alpha - Refinery"),
+		})
+		factory.CreateCorporationMiningObserver(storage.UpdateOrCreateCorporationMiningObserverParams{
+			CorporationID: s.CorporationID,
+			ObserverID:    s.StructureID,
+		})
+		// when
+		oo, err := st.ListCorporationMiningObservers(t.Context(), s.CorporationID)
+		// then
+		require.NoError(t, err)
+		if assert.Len(t, oo, 1) {
+			xassert.Equal(t, optional.New("Alpha - Refinery"), oo[0].StructureName)
+		}
+	})
+	t.Run("can delete observers with their entries", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		e := factory.CreateCorporationMiningObserverEntry()
+		// when
+		err := st.DeleteCorporationMiningObservers(t.Context(), e.CorporationID)
+		// then
+		require.NoError(t, err)
+		oo, err := st.ListCorporationMiningObservers(t.Context(), e.CorporationID)
+		require.NoError(t, err)
+		assert.Len(t, oo, 0)
+		ee, err := st.ListCorporationMiningObserverEntries(t.Context(), e.CorporationID)
+		require.NoError(t, err)
+		assert.Len(t, ee, 0)
+	})
+}
+
+func TestCorporationMiningObserverEntry(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	t.Run("can create new entry", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		observer := factory.CreateCorporationMiningObserver()
+		character := factory.CreateEveEntityCharacter()
+		corporation := factory.CreateEveEntityCorporation()
+		et := factory.CreateEveType()
+		factory.CreateEveMarketPrice(storage.UpdateOrCreateEveMarketPriceParams{TypeID: et.ID, AveragePrice: optional.New(100.0)})
+		lastUpdated := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+		// when
+		err := st.UpdateOrCreateCorporationMiningObserverEntry(t.Context(), storage.UpdateOrCreateCorporationMiningObserverEntryParams{
+			CharacterID:           character.ID,
+			CorporationID:         observer.CorporationID,
+			LastUpdated:           lastUpdated,
+			ObserverID:            observer.ObserverID,
+			Quantity:              42,
+			RecordedCorporationID: corporation.ID,
+			TypeID:                et.ID,
+		})
+		// then
+		require.NoError(t, err)
+		oo, err := st.ListCorporationMiningObserverEntries(t.Context(), observer.CorporationID)
+		require.NoError(t, err)
+		if assert.Len(t, oo, 1) {
+			o := oo[0]
+			xassert.Equal(t, character, o.Character)
+			xassert.Equal(t, observer.CorporationID, o.CorporationID)
+			assert.True(t, lastUpdated.Equal(o.LastUpdated))
+			xassert.Equal(t, observer.ObserverID, o.ObserverID)
+			xassert.Equal(t, 42, o.Quantity)
+			xassert.Equal(t, corporation.ID, o.RecordedCorporationID)
+			xassert.Equal(t, et.ID, o.Type.ID)
+			xassert.Equal(t, optional.New(4200.0), o.Value())
+		}
+	})
+	t.Run("can update existing entry", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		x := factory.CreateCorporationMiningObserverEntry()
+		// when
+		err := st.UpdateOrCreateCorporationMiningObserverEntry(t.Context(), storage.UpdateOrCreateCorporationMiningObserverEntryParams{
+			CharacterID:           x.Character.ID,
+			CorporationID:         x.CorporationID,
+			LastUpdated:           x.LastUpdated,
+			ObserverID:            x.ObserverID,
+			Quantity:              7,
+			RecordedCorporationID: x.RecordedCorporationID,
+			TypeID:                x.Type.ID,
+		})
+		// then
+		require.NoError(t, err)
+		oo, err := st.ListCorporationMiningObserverEntries(t.Context(), x.CorporationID)
+		require.NoError(t, err)
+		if assert.Len(t, oo, 1) {
+			xassert.Equal(t, 7, oo[0].Quantity)
+		}
+	})
+	t.Run("can list entries of all corporations", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		e1 := factory.CreateCorporationMiningObserverEntry()
+		e2 := factory.CreateCorporationMiningObserverEntry()
+		// when
+		oo, err := st.ListAllCorporationMiningObserverEntries(t.Context())
+		// then
+		require.NoError(t, err)
+		got := xslices.Map(oo, func(x *app.CorporationMiningObserverEntry) int64 {
+			return x.CorporationID
+		})
+		assert.ElementsMatch(t, []int64{e1.CorporationID, e2.CorporationID}, got)
+	})
+}
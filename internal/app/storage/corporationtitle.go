@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+)
+
+type CorporationTitleParams struct {
+	CorporationID int64
+	Name          string
+	TitleID       int64
+}
+
+type CorporationMemberTitleParams struct {
+	CharacterID   int64
+	CorporationID int64
+	TitleID       int64
+}
+
+// DeleteCorporationTitles deletes all titles and member titles of a corporation.
+func (st *Storage) DeleteCorporationTitles(ctx context.Context, corporationID int64) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("DeleteCorporationTitles: %d: %w", corporationID, err)
+	}
+	if corporationID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	tx, err := st.dbRW.Begin()
+	if err != nil {
+		return wrapErr(err)
+	}
+	defer tx.Rollback()
+	qtx := st.qRW.WithTx(tx)
+	if err := qtx.DeleteCorporationMemberTitles(ctx, corporationID); err != nil {
+		return wrapErr(err)
+	}
+	if err := qtx.DeleteCorporationTitles(ctx, corporationID); err != nil {
+		return wrapErr(err)
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+func (st *Storage) ListCorporationTitles(ctx context.Context, corporationID int64) ([]*app.CorporationTitle, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ListCorporationTitles: %d: %w", corporationID, err)
+	}
+	if corporationID == 0 {
+		return nil, wrapErr(app.ErrInvalid)
+	}
+	rows, err := st.qRO.ListCorporationTitles(ctx, corporationID)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	oo := make([]*app.CorporationTitle, len(rows))
+	for i, r := range rows {
+		oo[i] = corporationTitleFromDBModel(r)
+	}
+	return oo, nil
+}
+
+func (st *Storage) ListCorporationMemberTitles(ctx context.Context, corporationID int64) ([]*app.CorporationMemberTitle, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ListCorporationMemberTitles: %d: %w", corporationID, err)
+	}
+	if corporationID == 0 {
+		return nil, wrapErr(app.ErrInvalid)
+	}
+	rows, err := st.qRO.ListCorporationMemberTitles(ctx, corporationID)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	oo := make([]*app.CorporationMemberTitle, len(rows))
+	for i, r := range rows {
+		oo[i] = &app.CorporationMemberTitle{
+			CorporationID: r.CorporationMemberTitle.CorporationID,
+			Character:     eveEntityFromDBModel(r.EveEntity),
+			Title:         corporationTitleFromDBModel(r.CorporationTitle),
+		}
+	}
+	return oo, nil
+}
+
+// ReplaceCorporationTitles replaces all titles and member titles of a corporation.
+func (st *Storage) ReplaceCorporationTitles(ctx context.Context, corporationID int64, titles []CorporationTitleParams, memberTitles []CorporationMemberTitleParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ReplaceCorporationTitles: %d: %w", corporationID, err)
+	}
+	if corporationID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	tx, err := st.dbRW.Begin()
+	if err != nil {
+		return wrapErr(err)
+	}
+	defer tx.Rollback()
+	qtx := st.qRW.WithTx(tx)
+	if err := qtx.DeleteCorporationMemberTitles(ctx, corporationID); err != nil {
+		return wrapErr(err)
+	}
+	if err := qtx.DeleteCorporationTitles(ctx, corporationID); err != nil {
+		return wrapErr(err)
+	}
+	for _, arg := range titles {
+		if arg.CorporationID != corporationID || arg.TitleID == 0 {
+			return wrapErr(app.ErrInvalid)
+		}
+		err := qtx.CreateCorporationTitle(ctx, queries.CreateCorporationTitleParams{
+			CorporationID: arg.CorporationID,
+			Name:          arg.Name,
+			TitleID:       arg.TitleID,
+		})
+		if err != nil {
+			return wrapErr(err)
+		}
+	}
+	for _, arg := range memberTitles {
+		if arg.CorporationID != corporationID || arg.CharacterID == 0 || arg.TitleID == 0 {
+			return wrapErr(app.ErrInvalid)
+		}
+		err := qtx.CreateCorporationMemberTitle(ctx, queries.CreateCorporationMemberTitleParams{
+			CharacterID:   arg.CharacterID,
+			CorporationID: arg.CorporationID,
+			TitleID:       arg.TitleID,
+		})
+		if err != nil {
+			return wrapErr(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+func corporationTitleFromDBModel(o queries.CorporationTitle) *app.CorporationTitle {
+	return &app.CorporationTitle{
+		CorporationID: o.CorporationID,
+		Name:          o.Name,
+		TitleID:       o.TitleID,
+	}
+}
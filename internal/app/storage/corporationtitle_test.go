@@ -0,0 +1,75 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestCorporationTitle(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	ctx := context.Background()
+	t.Run("can replace titles", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCorporation()
+		m := factory.CreateEveEntityCharacter()
+		err := st.ReplaceCorporationTitles(ctx, c.ID, []storage.CorporationTitleParams{
+			{CorporationID: c.ID, TitleID: 1, Name: "Old"},
+		}, nil)
+		require.NoError(t, err)
+		// when
+		err = st.ReplaceCorporationTitles(ctx, c.ID, []storage.CorporationTitleParams{
+			{CorporationID: c.ID, TitleID: 2, Name: "Beta"},
+			{CorporationID: c.ID, TitleID: 4, Name: "Alpha"},
+		}, []storage.CorporationMemberTitleParams{
+			{CorporationID: c.ID, CharacterID: m.ID, TitleID: 4},
+		})
+		// then
+		if assert.NoError(t, err) {
+			titles, err := st.ListCorporationTitles(ctx, c.ID)
+			require.NoError(t, err)
+			if assert.Len(t, titles, 2) {
+				xassert.Equal(t, "Alpha", titles[0].Name)
+				xassert.Equal(t, "Beta", titles[1].Name)
+			}
+			memberTitles, err := st.ListCorporationMemberTitles(ctx, c.ID)
+			require.NoError(t, err)
+			if assert.Len(t, memberTitles, 1) {
+				xassert.Equal(t, m, memberTitles[0].Character)
+				xassert.Equal(t, int64(4), memberTitles[0].Title.TitleID)
+				xassert.Equal(t, "Alpha", memberTitles[0].Title.Name)
+			}
+		}
+	})
+	t.Run("can delete titles", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCorporation()
+		m := factory.CreateEveEntityCharacter()
+		err := st.ReplaceCorporationTitles(ctx, c.ID, []storage.CorporationTitleParams{
+			{CorporationID: c.ID, TitleID: 1, Name: "Alpha"},
+		}, []storage.CorporationMemberTitleParams{
+			{CorporationID: c.ID, CharacterID: m.ID, TitleID: 1},
+		})
+		require.NoError(t, err)
+		// when
+		err = st.DeleteCorporationTitles(ctx, c.ID)
+		// then
+		if assert.NoError(t, err) {
+			titles, err := st.ListCorporationTitles(ctx, c.ID)
+			require.NoError(t, err)
+			assert.Len(t, titles, 0)
+			memberTitles, err := st.ListCorporationMemberTitles(ctx, c.ID)
+			require.NoError(t, err)
+			assert.Len(t, memberTitles, 0)
+		}
+	})
+}
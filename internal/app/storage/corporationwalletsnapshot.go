@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+)
+
+// ListCorporationWalletSnapshots returns all wallet snapshots for a corporation since a given day.
+func (st *Storage) ListCorporationWalletSnapshots(ctx context.Context, corporationID int64, since time.Time) ([]*app.CorporationWalletSnapshot, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ListCorporationWalletSnapshots for corporation %d since %s: %w", corporationID, since, err)
+	}
+	if corporationID == 0 {
+		return nil, wrapErr(app.ErrInvalid)
+	}
+	rows, err := st.qRO.ListCorporationWalletSnapshots(ctx, queries.ListCorporationWalletSnapshotsParams{
+		CorporationID: corporationID,
+		Day:           since.UTC(),
+	})
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	oo := make([]*app.CorporationWalletSnapshot, len(rows))
+	for i, r := range rows {
+		oo[i] = &app.CorporationWalletSnapshot{
+			Balance:       r.Balance,
+			CorporationID: r.CorporationID,
+			Day:           r.Day.UTC(),
+			DivisionID:    r.DivisionID,
+		}
+	}
+	return oo, nil
+}
+
+type UpdateOrCreateCorporationWalletSnapshotParams struct {
+	Balance       float64
+	CorporationID int64
+	Day           time.Time
+	DivisionID    int64
+}
+
+func (st *Storage) UpdateOrCreateCorporationWalletSnapshot(ctx context.Context, arg UpdateOrCreateCorporationWalletSnapshotParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("UpdateOrCreateCorporationWalletSnapshot: %+v: %w", arg, err)
+	}
+	if arg.CorporationID == 0 || arg.DivisionID == 0 || arg.Day.IsZero() {
+		return wrapErr(app.ErrInvalid)
+	}
+	err := st.qRW.UpdateOrCreateCorporationWalletSnapshot(ctx, queries.UpdateOrCreateCorporationWalletSnapshotParams{
+		Balance:       arg.Balance,
+		CorporationID: arg.CorporationID,
+		Day:           arg.Day.UTC(),
+		DivisionID:    arg.DivisionID,
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
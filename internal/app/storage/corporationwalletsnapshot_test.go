@@ -0,0 +1,75 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestCorporationWalletSnapshot(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	ctx := context.Background()
+	t.Run("can create and update", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCorporation()
+		day := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+		arg := storage.UpdateOrCreateCorporationWalletSnapshotParams{
+			Balance:       1,
+			CorporationID: c.ID,
+			Day:           day,
+			DivisionID:    2,
+		}
+		// when
+		err1 := st.UpdateOrCreateCorporationWalletSnapshot(ctx, arg)
+		arg.Balance = 5
+		err2 := st.UpdateOrCreateCorporationWalletSnapshot(ctx, arg)
+		// then
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		oo, err := st.ListCorporationWalletSnapshots(ctx, c.ID, day)
+		require.NoError(t, err)
+		xassert.Equal(t, []*app.CorporationWalletSnapshot{{
+			Balance:       5,
+			CorporationID: c.ID,
+			Day:           day,
+			DivisionID:    2,
+		}}, oo)
+	})
+	t.Run("can list snapshots since a day", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCorporation()
+		today := app.SnapshotDay(time.Now())
+		o1 := factory.CreateCorporationWalletSnapshot(storage.UpdateOrCreateCorporationWalletSnapshotParams{
+			CorporationID: c.ID,
+			Day:           today,
+			DivisionID:    1,
+		})
+		o2 := factory.CreateCorporationWalletSnapshot(storage.UpdateOrCreateCorporationWalletSnapshotParams{
+			CorporationID: c.ID,
+			Day:           today,
+			DivisionID:    2,
+		})
+		factory.CreateCorporationWalletSnapshot(storage.UpdateOrCreateCorporationWalletSnapshotParams{
+			CorporationID: c.ID,
+			Day:           today.AddDate(0, 0, -5),
+		})
+		factory.CreateCorporationWalletSnapshot(storage.UpdateOrCreateCorporationWalletSnapshotParams{
+			Day: today,
+		})
+		// when
+		oo, err := st.ListCorporationWalletSnapshots(ctx, c.ID, today.AddDate(0, 0, -1))
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, []*app.CorporationWalletSnapshot{o1, o2}, oo)
+	})
+}
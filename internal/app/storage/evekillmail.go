@@ -0,0 +1,244 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+type CreateEveKillmailParams struct {
+	Attackers           []CreateEveKillmailAttackerParams
+	Hash                string
+	ID                  int64
+	Items               []CreateEveKillmailItemParams
+	MoonID              optional.Optional[int64]
+	SolarSystemID       int64
+	Time                time.Time
+	VictimAllianceID    optional.Optional[int64]
+	VictimCharacterID   optional.Optional[int64]
+	VictimCorporationID optional.Optional[int64]
+	VictimDamageTaken   int64
+	VictimFactionID     optional.Optional[int64]
+	VictimShipTypeID    int64
+	WarID               optional.Optional[int64]
+}
+
+type CreateEveKillmailAttackerParams struct {
+	AllianceID     optional.Optional[int64]
+	CharacterID    optional.Optional[int64]
+	CorporationID  optional.Optional[int64]
+	DamageDone     int64
+	FactionID      optional.Optional[int64]
+	IsFinalBlow    bool
+	SecurityStatus float64
+	ShipTypeID     optional.Optional[int64]
+	WeaponTypeID   optional.Optional[int64]
+}
+
+type CreateEveKillmailItemParams struct {
+	Flag              int64
+	IsSingleton       bool
+	QuantityDestroyed int64
+	QuantityDropped   int64
+	TypeID            int64
+}
+
+// CreateEveKillmail creates a new killmail with all its attackers and items.
+func (st *Storage) CreateEveKillmail(ctx context.Context, arg CreateEveKillmailParams) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("CreateEveKillmail: %d: %w", arg.ID, err)
+	}
+	if arg.ID == 0 || arg.Hash == "" || arg.SolarSystemID == 0 || arg.VictimShipTypeID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	tx, err := st.dbRW.Begin()
+	if err != nil {
+		return wrapErr(err)
+	}
+	defer tx.Rollback()
+	qtx := st.qRW.WithTx(tx)
+	err = qtx.CreateEveKillmail(ctx, queries.CreateEveKillmailParams{
+		ID:                  arg.ID,
+		Hash:                arg.Hash,
+		MoonID:              optional.ToNullInt64(arg.MoonID),
+		SolarSystemID:       arg.SolarSystemID,
+		Time:                arg.Time,
+		VictimAllianceID:    optional.ToNullInt64(arg.VictimAllianceID),
+		VictimCharacterID:   optional.ToNullInt64(arg.VictimCharacterID),
+		VictimCorporationID: optional.ToNullInt64(arg.VictimCorporationID),
+		VictimDamageTaken:   arg.VictimDamageTaken,
+		VictimFactionID:     optional.ToNullInt64(arg.VictimFactionID),
+		VictimShipTypeID:    arg.VictimShipTypeID,
+		WarID:               optional.ToNullInt64(arg.WarID),
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	for _, a := range arg.Attackers {
+		err := qtx.CreateEveKillmailAttacker(ctx, queries.CreateEveKillmailAttackerParams{
+			KillmailID:     arg.ID,
+			AllianceID:     optional.ToNullInt64(a.AllianceID),
+			CharacterID:    optional.ToNullInt64(a.CharacterID),
+			CorporationID:  optional.ToNullInt64(a.CorporationID),
+			DamageDone:     a.DamageDone,
+			FactionID:      optional.ToNullInt64(a.FactionID),
+			IsFinalBlow:    a.IsFinalBlow,
+			SecurityStatus: a.SecurityStatus,
+			ShipTypeID:     optional.ToNullInt64(a.ShipTypeID),
+			WeaponTypeID:   optional.ToNullInt64(a.WeaponTypeID),
+		})
+		if err != nil {
+			return wrapErr(err)
+		}
+	}
+	for _, it := range arg.Items {
+		err := qtx.CreateEveKillmailItem(ctx, queries.CreateEveKillmailItemParams{
+			KillmailID:        arg.ID,
+			Flag:              it.Flag,
+			IsSingleton:       it.IsSingleton,
+			QuantityDestroyed: it.QuantityDestroyed,
+			QuantityDropped:   it.QuantityDropped,
+			TypeID:            it.TypeID,
+		})
+		if err != nil {
+			return wrapErr(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+// GetEveKillmail returns a killmail with all its attackers and items.
+func (st *Storage) GetEveKillmail(ctx context.Context, id int64) (*app.EveKillmail, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("GetEveKillmail: %d: %w", id, err)
+	}
+	oo, err := st.listEveKillmails(ctx, []int64{id})
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	if len(oo) == 0 {
+		return nil, wrapErr(app.ErrNotFound)
+	}
+	o := oo[0]
+	attackers, err := st.qRO.ListEveKillmailAttackers(ctx, id)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	for _, r := range attackers {
+		a := r.EveKillmailAttacker
+		o.Attackers = append(o.Attackers, &app.EveKillmailAttacker{
+			Alliance:       killmailEntityFromDBModel(a.AllianceID, r.AllianceName, app.EveEntityAlliance),
+			Character:      killmailEntityFromDBModel(a.CharacterID, r.CharacterName, app.EveEntityCharacter),
+			Corporation:    killmailEntityFromDBModel(a.CorporationID, r.CorporationName, app.EveEntityCorporation),
+			DamageDone:     a.DamageDone,
+			Faction:        killmailEntityFromDBModel(a.FactionID, r.FactionName, app.EveEntityFaction),
+			IsFinalBlow:    a.IsFinalBlow,
+			SecurityStatus: a.SecurityStatus,
+			ShipType:       killmailTypeFromDBModel(a.ShipTypeID, r.ShipTypeName),
+			WeaponType:     killmailTypeFromDBModel(a.WeaponTypeID, r.WeaponTypeName),
+		})
+	}
+	items, err := st.qRO.ListEveKillmailItems(ctx, id)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	for _, r := range items {
+		it := r.EveKillmailItem
+		o.Items = append(o.Items, &app.EveKillmailItem{
+			Flag:              it.Flag,
+			IsSingleton:       it.IsSingleton,
+			Price:             optional.FromNullFloat64(r.Price),
+			QuantityDestroyed: it.QuantityDestroyed,
+			QuantityDropped:   it.QuantityDropped,
+			Type:              &app.EntityShort{ID: it.TypeID, Name: r.TypeName},
+		})
+	}
+	return o, nil
+}
+
+// MissingEveKillmails returns the IDs of killmails, which do not yet exist.
+func (st *Storage) MissingEveKillmails(ctx context.Context, ids set.Set[int64]) (set.Set[int64], error) {
+	currentIDs, err := st.qRO.ListEveKillmailIDs(ctx, slices.Collect(ids.All()))
+	if err != nil {
+		return set.Set[int64]{}, fmt.Errorf("MissingEveKillmails: %w", err)
+	}
+	current := set.Collect(slices.Values(currentIDs))
+	missing := set.Difference(ids, current)
+	return missing, nil
+}
+
+// listEveKillmails returns the killmails for the given IDs without attackers and items
+// ordered by time with the most recent first.
+func (st *Storage) listEveKillmails(ctx context.Context, ids []int64) ([]*app.EveKillmail, error) {
+	if len(ids) == 0 {
+		return []*app.EveKillmail{}, nil
+	}
+	rows, err := st.qRO.ListEveKillmails(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	values, err := st.qRO.ListEveKillmailItemValues(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	itemValues := make(map[int64]sql.NullFloat64)
+	for _, v := range values {
+		itemValues[v.KillmailID] = v.Value
+	}
+	oo := make([]*app.EveKillmail, len(rows))
+	for i, r := range rows {
+		k := r.EveKillmail
+		oo[i] = &app.EveKillmail{
+			Hash:                k.Hash,
+			ID:                  k.ID,
+			ItemsValue:          optional.FromNullFloat64(itemValues[k.ID]),
+			MoonID:              optional.FromNullInt64(k.MoonID),
+			ShipValue:           optional.FromNullFloat64(r.ShipPrice),
+			SolarSystem:         &app.EntityShort{ID: k.SolarSystemID, Name: r.SolarSystemName},
+			SolarSystemSecurity: float32(r.SolarSystemSecurity),
+			Time:                k.Time,
+			Victim: app.EveKillmailVictim{
+				Alliance:    killmailEntityFromDBModel(k.VictimAllianceID, r.VictimAllianceName, app.EveEntityAlliance),
+				Character:   killmailEntityFromDBModel(k.VictimCharacterID, r.VictimCharacterName, app.EveEntityCharacter),
+				Corporation: killmailEntityFromDBModel(k.VictimCorporationID, r.VictimCorporationName, app.EveEntityCorporation),
+				DamageTaken: k.VictimDamageTaken,
+				Faction:     killmailEntityFromDBModel(k.VictimFactionID, r.VictimFactionName, app.EveEntityFaction),
+				ShipType:    &app.EntityShort{ID: k.VictimShipTypeID, Name: r.ShipTypeName},
+			},
+			WarID: optional.FromNullInt64(k.WarID),
+		}
+	}
+	return oo, nil
+}
+
+func killmailEntityFromDBModel(id sql.NullInt64, name sql.NullString, category app.EveEntityCategory) optional.Optional[*app.EveEntity] {
+	if !id.Valid {
+		return optional.Optional[*app.EveEntity]{}
+	}
+	return optional.New(&app.EveEntity{
+		Category: category,
+		ID:       id.Int64,
+		Name:     name.String,
+	})
+}
+
+func killmailTypeFromDBModel(id sql.NullInt64, name sql.NullString) optional.Optional[*app.EntityShort] {
+	if !id.Valid {
+		return optional.Optional[*app.EntityShort]{}
+	}
+	return optional.New(&app.EntityShort{
+		ID:   id.Int64,
+		Name: name.String,
+	})
+}
@@ -0,0 +1,110 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/go-set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestEveKillmail(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	ctx := context.Background()
+	t.Run("can create new with attackers and items", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		system := factory.CreateEveSolarSystem()
+		ship := factory.CreateEveType()
+		victim := factory.CreateEveEntityCharacter()
+		victimCorporation := factory.CreateEveEntityCorporation()
+		attacker := factory.CreateEveEntityCharacter()
+		weapon := factory.CreateEveType()
+		item := factory.CreateEveType()
+		factory.CreateEveMarketPrice(storage.UpdateOrCreateEveMarketPriceParams{
+			TypeID:       ship.ID,
+			AveragePrice: optional.New(100.0),
+		})
+		factory.CreateEveMarketPrice(storage.UpdateOrCreateEveMarketPriceParams{
+			TypeID:       item.ID,
+			AveragePrice: optional.New(5.0),
+		})
+		killTime := time.Now().UTC().Truncate(time.Second)
+		arg := storage.CreateEveKillmailParams{
+			Attackers: []storage.CreateEveKillmailAttackerParams{{
+				CharacterID:    optional.New(attacker.ID),
+				DamageDone:     500,
+				IsFinalBlow:    true,
+				SecurityStatus: 1.5,
+				WeaponTypeID:   optional.New(weapon.ID),
+			}},
+			Hash: "abc",
+			ID:   42,
+			Items: []storage.CreateEveKillmailItemParams{{
+				Flag:              27,
+				QuantityDestroyed: 2,
+				QuantityDropped:   1,
+				TypeID:            item.ID,
+			}},
+			SolarSystemID:       system.ID,
+			Time:                killTime,
+			VictimCharacterID:   optional.New(victim.ID),
+			VictimCorporationID: optional.New(victimCorporation.ID),
+			VictimDamageTaken:   500,
+			VictimShipTypeID:    ship.ID,
+		}
+		// when
+		err := st.CreateEveKillmail(ctx, arg)
+		// then
+		require.NoError(t, err)
+		o, err := st.GetEveKillmail(ctx, 42)
+		require.NoError(t, err)
+		xassert.Equal(t, "abc", o.Hash)
+		xassert.Equal(t, killTime, o.Time.UTC())
+		xassert.Equal(t, system.ID, o.SolarSystem.ID)
+		xassert.Equal(t, system.Name, o.SolarSystem.Name)
+		xassert.Equal(t, ship.ID, o.Victim.ShipType.ID)
+		xassert.EqualOptional(t, victim, o.Victim.Character)
+		xassert.EqualOptional(t, victimCorporation, o.Victim.Corporation)
+		assert.True(t, o.Victim.Alliance.IsEmpty())
+		xassert.Equal(t, 500, o.Victim.DamageTaken)
+		xassert.EqualOptional(t, 100, o.ShipValue)
+		xassert.EqualOptional(t, 15, o.ItemsValue)
+		xassert.EqualOptional(t, 115, o.Value())
+		require.Len(t, o.Attackers, 1)
+		xassert.EqualOptional(t, attacker, o.Attackers[0].Character)
+		xassert.Equal(t, true, o.Attackers[0].IsFinalBlow)
+		xassert.Equal(t, weapon.ID, o.Attackers[0].WeaponType.MustValue().ID)
+		assert.True(t, o.Attackers[0].ShipType.IsEmpty())
+		require.Len(t, o.Items, 1)
+		xassert.Equal(t, item.ID, o.Items[0].Type.ID)
+		xassert.EqualOptional(t, 15, o.Items[0].Value())
+	})
+	t.Run("should return not found error", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		// when
+		_, err := st.GetEveKillmail(ctx, 42)
+		// then
+		require.ErrorIs(t, err, app.ErrNotFound)
+	})
+	t.Run("can report missing killmails", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		o := factory.CreateEveKillmail()
+		// when
+		got, err := st.MissingEveKillmails(ctx, set.Of(o.ID, 42))
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, set.Of[int64](42), got)
+	})
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+)
+
+// ListEveMarketHistory returns the market history of a type in a region ordered by date.
+func (st *Storage) ListEveMarketHistory(ctx context.Context, regionID, typeID int64) ([]*app.EveMarketHistory, error) {
+	rows, err := st.qRO.ListEveMarketHistory(ctx, queries.ListEveMarketHistoryParams{
+		RegionID: regionID,
+		TypeID:   typeID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ListEveMarketHistory for region %d and type %d: %w", regionID, typeID, err)
+	}
+	oo := make([]*app.EveMarketHistory, len(rows))
+	for i, r := range rows {
+		oo[i] = eveMarketHistoryFromDBModel(r)
+	}
+	return oo, nil
+}
+
+// ReplaceEveMarketHistory replaces the market history of a type in a region.
+func (st *Storage) ReplaceEveMarketHistory(ctx context.Context, regionID, typeID int64, items []app.EveMarketHistory) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("ReplaceEveMarketHistory for region %d and type %d: %w", regionID, typeID, err)
+	}
+	if regionID == 0 || typeID == 0 {
+		return wrapErr(app.ErrInvalid)
+	}
+	tx, err := st.dbRW.Begin()
+	if err != nil {
+		return wrapErr(err)
+	}
+	defer tx.Rollback()
+	qtx := st.qRW.WithTx(tx)
+	err = qtx.DeleteEveMarketHistory(ctx, queries.DeleteEveMarketHistoryParams{
+		RegionID: regionID,
+		TypeID:   typeID,
+	})
+	if err != nil {
+		return wrapErr(err)
+	}
+	for _, x := range items {
+		if x.RegionID != regionID || x.TypeID != typeID || x.Date.IsZero() {
+			return wrapErr(app.ErrInvalid)
+		}
+		err := qtx.CreateEveMarketHistory(ctx, queries.CreateEveMarketHistoryParams{
+			Average:    x.Average,
+			Date:       x.Date.UTC(),
+			Highest:    x.Highest,
+			Lowest:     x.Lowest,
+			OrderCount: x.OrderCount,
+			RegionID:   x.RegionID,
+			TypeID:     x.TypeID,
+			Volume:     x.Volume,
+		})
+		if err != nil {
+			return wrapErr(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+func eveMarketHistoryFromDBModel(r queries.EveMarketHistory) *app.EveMarketHistory {
+	return &app.EveMarketHistory{
+		Average:    r.Average,
+		Date:       r.Date,
+		Highest:    r.Highest,
+		Lowest:     r.Lowest,
+		OrderCount: r.OrderCount,
+		RegionID:   r.RegionID,
+		TypeID:     r.TypeID,
+		Volume:     r.Volume,
+	}
+}
@@ -0,0 +1,75 @@
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func TestStorage_EveMarketHistory(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	t.Run("can replace and list history", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		region := factory.CreateEveRegion()
+		et := factory.CreateEveType()
+		day1 := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		day2 := day1.AddDate(0, 0, 1)
+		err := st.ReplaceEveMarketHistory(t.Context(), region.ID, et.ID, []app.EveMarketHistory{{
+			Date:     day1,
+			RegionID: region.ID,
+			TypeID:   et.ID,
+		}})
+		require.NoError(t, err)
+		// when
+		err = st.ReplaceEveMarketHistory(t.Context(), region.ID, et.ID, []app.EveMarketHistory{
+			{
+				Average:    2.5,
+				Date:       day2,
+				Highest:    3,
+				Lowest:     2,
+				OrderCount: 7,
+				RegionID:   region.ID,
+				TypeID:     et.ID,
+				Volume:     42,
+			},
+			{
+				Date:     day1,
+				RegionID: region.ID,
+				TypeID:   et.ID,
+			},
+		})
+		// then
+		require.NoError(t, err)
+		oo, err := st.ListEveMarketHistory(t.Context(), region.ID, et.ID)
+		require.NoError(t, err)
+		got := xslices.Map(oo, func(x *app.EveMarketHistory) time.Time {
+			return x.Date.UTC()
+		})
+		xassert.Equal(t, []time.Time{day1, day2}, got)
+		xassert.Equal(t, 2.5, oo[1].Average)
+		xassert.Equal(t, 42, oo[1].Volume)
+	})
+	t.Run("should reject items for other types", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		region := factory.CreateEveRegion()
+		et := factory.CreateEveType()
+		// when
+		err := st.ReplaceEveMarketHistory(t.Context(), region.ID, et.ID, []app.EveMarketHistory{{
+			Date:     time.Now(),
+			RegionID: region.ID,
+			TypeID:   et.ID + 1,
+		}})
+		// then
+		assert.ErrorIs(t, err, app.ErrInvalid)
+	})
+}
@@ -100,6 +100,33 @@ func (st *Storage) ListEveSkills(ctx context.Context) ([]*app.EveType, error) {
 	return oo, nil
 }
 
+// ListEveTypesForCategory returns the published types of a category.
+func (st *Storage) ListEveTypesForCategory(ctx context.Context, categoryID int64) ([]*app.EveType, error) {
+	rows, err := st.qRO.ListEveTypesForCategory(ctx, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("ListEveTypesForCategory: %d: %w", categoryID, err)
+	}
+	var oo []*app.EveType
+	for _, r := range rows {
+		oo = append(oo, eveTypeFromDBModel(r.EveType, r.EveGroup, r.EveCategory))
+	}
+	return oo, nil
+}
+
+// ListEveTypesForNames returns the types matching the given names.
+// Names without a matching type are ignored.
+func (st *Storage) ListEveTypesForNames(ctx context.Context, names []string) ([]*app.EveType, error) {
+	rows, err := st.qRO.ListEveTypesForNames(ctx, names)
+	if err != nil {
+		return nil, fmt.Errorf("ListEveTypesForNames: %w", err)
+	}
+	var oo []*app.EveType
+	for _, r := range rows {
+		oo = append(oo, eveTypeFromDBModel(r.EveType, r.EveGroup, r.EveCategory))
+	}
+	return oo, nil
+}
+
 func eveTypeFromDBModel(t queries.EveType, g queries.EveGroup, c queries.EveCategory) *app.EveType {
 	return &app.EveType{
 		ID:             t.ID,
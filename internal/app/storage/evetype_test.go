@@ -134,6 +134,18 @@ func TestEveType(t *testing.T) {
 		require.NoError(t, err)
 		assert.ElementsMatch(t, []*app.EveType{x1, x2}, got)
 	})
+	t.Run("can list types for names", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		x1 := factory.CreateEveType(storage.CreateEveTypeParams{Name: "Alpha"})
+		x2 := factory.CreateEveType(storage.CreateEveTypeParams{Name: "Bravo"})
+		factory.CreateEveType(storage.CreateEveTypeParams{Name: "Charlie"})
+		// when
+		got, err := st.ListEveTypesForNames(ctx, []string{"Alpha", "Bravo", "Delta"})
+		// then
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []*app.EveType{x1, x2}, got)
+	})
 	t.Run("can identify missing", func(t *testing.T) {
 		// given
 		testutil.MustTruncateTables(db)
@@ -162,4 +174,22 @@ func TestEveType(t *testing.T) {
 		}))
 		xassert.Equal(t, want, got)
 	})
+	t.Run("can list published types for category", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		category := factory.CreateEveCategory(storage.CreateEveCategoryParams{ID: app.EveCategoryBlueprint})
+		group := factory.CreateEveGroup(storage.CreateEveGroupParams{CategoryID: category.ID, IsPublished: true})
+		o1 := factory.CreateEveType(storage.CreateEveTypeParams{GroupID: group.ID, IsPublished: true})
+		factory.CreateEveType(storage.CreateEveTypeParams{GroupID: group.ID, IsPublished: false})
+		factory.CreateEveType()
+		// when
+		oo, err := st.ListEveTypesForCategory(ctx, app.EveCategoryBlueprint)
+		// then
+		require.NoError(t, err)
+		want := set.Of(o1.ID)
+		got := set.Collect(xiter.MapSlice(oo, func(x *app.EveType) int64 {
+			return x.ID
+		}))
+		xassert.Equal(t, want, got)
+	})
 }
@@ -0,0 +1,11 @@
+//go:build !sqlite_fts5
+
+package storage
+
+// The search index requires SQLite with the FTS5 extension,
+// which go-sqlite3 only includes when built with the tag sqlite_fts5.
+// The undefined identifier below makes builds without that tag fail early
+// instead of failing at runtime when the migrations are applied.
+//
+// Build, run and test with: -tags sqlite_fts5
+var _ = buildTagSqliteFTS5IsRequired
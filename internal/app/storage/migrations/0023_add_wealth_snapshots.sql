@@ -0,0 +1,29 @@
+CREATE TABLE character_wealth_snapshots (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    character_id INTEGER NOT NULL,
+    day DATETIME NOT NULL,
+    assets_value REAL NOT NULL,
+    contracts_escrow REAL NOT NULL,
+    orders_escrow REAL NOT NULL,
+    wallet_balance REAL NOT NULL,
+    FOREIGN KEY (character_id) REFERENCES characters (id) ON DELETE CASCADE,
+    UNIQUE (character_id, day)
+);
+
+CREATE INDEX character_wealth_snapshots_idx1 ON character_wealth_snapshots (character_id);
+
+CREATE INDEX character_wealth_snapshots_idx2 ON character_wealth_snapshots (day);
+
+CREATE TABLE corporation_wallet_snapshots (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    corporation_id INTEGER NOT NULL,
+    division_id INTEGER NOT NULL,
+    day DATETIME NOT NULL,
+    balance REAL NOT NULL,
+    FOREIGN KEY (corporation_id) REFERENCES corporations (id) ON DELETE CASCADE,
+    UNIQUE (corporation_id, division_id, day)
+);
+
+CREATE INDEX corporation_wallet_snapshots_idx1 ON corporation_wallet_snapshots (corporation_id);
+
+CREATE INDEX corporation_wallet_snapshots_idx2 ON corporation_wallet_snapshots (day);
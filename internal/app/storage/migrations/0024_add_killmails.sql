@@ -0,0 +1,91 @@
+CREATE TABLE eve_killmails (
+    id INTEGER PRIMARY KEY NOT NULL,
+    hash TEXT NOT NULL,
+    moon_id INTEGER,
+    solar_system_id INTEGER NOT NULL,
+    time DATETIME NOT NULL,
+    victim_alliance_id INTEGER,
+    victim_character_id INTEGER,
+    victim_corporation_id INTEGER,
+    victim_damage_taken INTEGER NOT NULL,
+    victim_faction_id INTEGER,
+    victim_ship_type_id INTEGER NOT NULL,
+    war_id INTEGER,
+    FOREIGN KEY (solar_system_id) REFERENCES eve_solar_systems (id) ON DELETE CASCADE,
+    FOREIGN KEY (victim_alliance_id) REFERENCES eve_entities (id) ON DELETE SET NULL,
+    FOREIGN KEY (victim_character_id) REFERENCES eve_entities (id) ON DELETE SET NULL,
+    FOREIGN KEY (victim_corporation_id) REFERENCES eve_entities (id) ON DELETE SET NULL,
+    FOREIGN KEY (victim_faction_id) REFERENCES eve_entities (id) ON DELETE SET NULL,
+    FOREIGN KEY (victim_ship_type_id) REFERENCES eve_types (id) ON DELETE CASCADE
+);
+
+CREATE INDEX eve_killmails_idx1 ON eve_killmails (solar_system_id);
+
+CREATE INDEX eve_killmails_idx2 ON eve_killmails (time);
+
+CREATE INDEX eve_killmails_idx3 ON eve_killmails (victim_character_id);
+
+CREATE INDEX eve_killmails_idx4 ON eve_killmails (victim_corporation_id);
+
+CREATE TABLE eve_killmail_attackers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    killmail_id INTEGER NOT NULL,
+    alliance_id INTEGER,
+    character_id INTEGER,
+    corporation_id INTEGER,
+    damage_done INTEGER NOT NULL,
+    faction_id INTEGER,
+    is_final_blow BOOL NOT NULL,
+    security_status REAL NOT NULL,
+    ship_type_id INTEGER,
+    weapon_type_id INTEGER,
+    FOREIGN KEY (killmail_id) REFERENCES eve_killmails (id) ON DELETE CASCADE,
+    FOREIGN KEY (alliance_id) REFERENCES eve_entities (id) ON DELETE SET NULL,
+    FOREIGN KEY (character_id) REFERENCES eve_entities (id) ON DELETE SET NULL,
+    FOREIGN KEY (corporation_id) REFERENCES eve_entities (id) ON DELETE SET NULL,
+    FOREIGN KEY (faction_id) REFERENCES eve_entities (id) ON DELETE SET NULL,
+    FOREIGN KEY (ship_type_id) REFERENCES eve_types (id) ON DELETE SET NULL,
+    FOREIGN KEY (weapon_type_id) REFERENCES eve_types (id) ON DELETE SET NULL
+);
+
+CREATE INDEX eve_killmail_attackers_idx1 ON eve_killmail_attackers (killmail_id);
+
+CREATE TABLE eve_killmail_items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    killmail_id INTEGER NOT NULL,
+    flag INTEGER NOT NULL,
+    is_singleton BOOL NOT NULL,
+    quantity_destroyed INTEGER NOT NULL,
+    quantity_dropped INTEGER NOT NULL,
+    type_id INTEGER NOT NULL,
+    FOREIGN KEY (killmail_id) REFERENCES eve_killmails (id) ON DELETE CASCADE,
+    FOREIGN KEY (type_id) REFERENCES eve_types (id) ON DELETE CASCADE
+);
+
+CREATE INDEX eve_killmail_items_idx1 ON eve_killmail_items (killmail_id);
+
+CREATE TABLE character_killmails (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    character_id INTEGER NOT NULL,
+    killmail_id INTEGER NOT NULL,
+    FOREIGN KEY (character_id) REFERENCES characters (id) ON DELETE CASCADE,
+    FOREIGN KEY (killmail_id) REFERENCES eve_killmails (id) ON DELETE CASCADE,
+    UNIQUE (character_id, killmail_id)
+);
+
+CREATE INDEX character_killmails_idx1 ON character_killmails (character_id);
+
+CREATE INDEX character_killmails_idx2 ON character_killmails (killmail_id);
+
+CREATE TABLE corporation_killmails (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    corporation_id INTEGER NOT NULL,
+    killmail_id INTEGER NOT NULL,
+    FOREIGN KEY (corporation_id) REFERENCES corporations (id) ON DELETE CASCADE,
+    FOREIGN KEY (killmail_id) REFERENCES eve_killmails (id) ON DELETE CASCADE,
+    UNIQUE (corporation_id, killmail_id)
+);
+
+CREATE INDEX corporation_killmails_idx1 ON corporation_killmails (corporation_id);
+
+CREATE INDEX corporation_killmails_idx2 ON corporation_killmails (killmail_id);
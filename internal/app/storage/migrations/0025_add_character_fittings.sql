@@ -0,0 +1,29 @@
+CREATE TABLE character_fittings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    character_id INTEGER NOT NULL,
+    description TEXT NOT NULL,
+    fitting_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    ship_type_id INTEGER NOT NULL,
+    FOREIGN KEY (character_id) REFERENCES characters (id) ON DELETE CASCADE,
+    FOREIGN KEY (ship_type_id) REFERENCES eve_types (id) ON DELETE CASCADE,
+    UNIQUE (character_id, fitting_id)
+);
+
+CREATE INDEX character_fittings_idx1 ON character_fittings (character_id);
+
+CREATE INDEX character_fittings_idx2 ON character_fittings (ship_type_id);
+
+CREATE TABLE character_fitting_items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    character_fitting_id INTEGER NOT NULL,
+    flag TEXT NOT NULL,
+    quantity INTEGER NOT NULL,
+    type_id INTEGER NOT NULL,
+    FOREIGN KEY (character_fitting_id) REFERENCES character_fittings (id) ON DELETE CASCADE,
+    FOREIGN KEY (type_id) REFERENCES eve_types (id) ON DELETE CASCADE
+);
+
+CREATE INDEX character_fitting_items_idx1 ON character_fitting_items (character_fitting_id);
+
+CREATE INDEX character_fitting_items_idx2 ON character_fitting_items (type_id);
@@ -0,0 +1,24 @@
+CREATE TABLE character_skill_plans (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    character_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    FOREIGN KEY (character_id) REFERENCES characters (id) ON DELETE CASCADE,
+    UNIQUE (character_id, name)
+);
+
+CREATE INDEX character_skill_plans_idx1 ON character_skill_plans (character_id);
+
+CREATE TABLE character_skill_plan_items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    character_skill_plan_id INTEGER NOT NULL,
+    level INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    type_id INTEGER NOT NULL,
+    FOREIGN KEY (character_skill_plan_id) REFERENCES character_skill_plans (id) ON DELETE CASCADE,
+    FOREIGN KEY (type_id) REFERENCES eve_types (id) ON DELETE CASCADE,
+    UNIQUE (character_skill_plan_id, type_id, level)
+);
+
+CREATE INDEX character_skill_plan_items_idx1 ON character_skill_plan_items (character_skill_plan_id);
+
+CREATE INDEX character_skill_plan_items_idx2 ON character_skill_plan_items (type_id);
@@ -0,0 +1,39 @@
+CREATE TABLE character_blueprints (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    character_id INTEGER NOT NULL,
+    eve_type_id INTEGER NOT NULL,
+    item_id INTEGER NOT NULL,
+    location_flag TEXT NOT NULL,
+    location_id INTEGER NOT NULL,
+    material_efficiency INTEGER NOT NULL,
+    quantity INTEGER NOT NULL,
+    runs INTEGER NOT NULL,
+    time_efficiency INTEGER NOT NULL,
+    FOREIGN KEY (character_id) REFERENCES characters (id) ON DELETE CASCADE,
+    FOREIGN KEY (eve_type_id) REFERENCES eve_types (id) ON DELETE CASCADE,
+    UNIQUE (character_id, item_id)
+);
+
+CREATE INDEX character_blueprints_idx1 ON character_blueprints (character_id);
+
+CREATE INDEX character_blueprints_idx2 ON character_blueprints (eve_type_id);
+
+CREATE TABLE corporation_blueprints (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    corporation_id INTEGER NOT NULL,
+    eve_type_id INTEGER NOT NULL,
+    item_id INTEGER NOT NULL,
+    location_flag TEXT NOT NULL,
+    location_id INTEGER NOT NULL,
+    material_efficiency INTEGER NOT NULL,
+    quantity INTEGER NOT NULL,
+    runs INTEGER NOT NULL,
+    time_efficiency INTEGER NOT NULL,
+    FOREIGN KEY (corporation_id) REFERENCES corporations (id) ON DELETE CASCADE,
+    FOREIGN KEY (eve_type_id) REFERENCES eve_types (id) ON DELETE CASCADE,
+    UNIQUE (corporation_id, item_id)
+);
+
+CREATE INDEX corporation_blueprints_idx1 ON corporation_blueprints (corporation_id);
+
+CREATE INDEX corporation_blueprints_idx2 ON corporation_blueprints (eve_type_id);
@@ -0,0 +1,50 @@
+CREATE TABLE character_mining_ledger_entries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    character_id INTEGER NOT NULL,
+    date DATETIME NOT NULL,
+    eve_solar_system_id INTEGER NOT NULL,
+    eve_type_id INTEGER NOT NULL,
+    quantity INTEGER NOT NULL,
+    FOREIGN KEY (character_id) REFERENCES characters (id) ON DELETE CASCADE,
+    FOREIGN KEY (eve_solar_system_id) REFERENCES eve_solar_systems (id) ON DELETE CASCADE,
+    FOREIGN KEY (eve_type_id) REFERENCES eve_types (id) ON DELETE CASCADE,
+    UNIQUE (character_id, date, eve_solar_system_id, eve_type_id)
+);
+
+CREATE INDEX character_mining_ledger_entries_idx1 ON character_mining_ledger_entries (character_id);
+
+CREATE INDEX character_mining_ledger_entries_idx2 ON character_mining_ledger_entries (date);
+
+CREATE TABLE corporation_mining_observers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    corporation_id INTEGER NOT NULL,
+    last_updated DATETIME NOT NULL,
+    observer_id INTEGER NOT NULL,
+    observer_type TEXT NOT NULL,
+    FOREIGN KEY (corporation_id) REFERENCES corporations (id) ON DELETE CASCADE,
+    UNIQUE (corporation_id, observer_id)
+);
+
+CREATE INDEX corporation_mining_observers_idx1 ON corporation_mining_observers (corporation_id);
+
+CREATE TABLE corporation_mining_observer_entries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    character_id INTEGER NOT NULL,
+    corporation_id INTEGER NOT NULL,
+    eve_type_id INTEGER NOT NULL,
+    last_updated DATETIME NOT NULL,
+    observer_id INTEGER NOT NULL,
+    quantity INTEGER NOT NULL,
+    recorded_corporation_id INTEGER NOT NULL,
+    FOREIGN KEY (character_id) REFERENCES eve_entities (id) ON DELETE CASCADE,
+    FOREIGN KEY (corporation_id) REFERENCES corporations (id) ON DELETE CASCADE,
+    FOREIGN KEY (eve_type_id) REFERENCES eve_types (id) ON DELETE CASCADE,
+    FOREIGN KEY (recorded_corporation_id) REFERENCES eve_entities (id) ON DELETE CASCADE,
+    UNIQUE (corporation_id, observer_id, character_id, eve_type_id, last_updated)
+);
+
+CREATE INDEX corporation_mining_observer_entries_idx1 ON corporation_mining_observer_entries (corporation_id);
+
+CREATE INDEX corporation_mining_observer_entries_idx2 ON corporation_mining_observer_entries (corporation_id, observer_id);
+
+CREATE INDEX corporation_mining_observer_entries_idx3 ON corporation_mining_observer_entries (last_updated);
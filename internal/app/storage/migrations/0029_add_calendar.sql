@@ -0,0 +1,33 @@
+CREATE TABLE character_calendar_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    character_id INTEGER NOT NULL,
+    date DATETIME NOT NULL,
+    duration INTEGER NOT NULL,
+    event_id INTEGER NOT NULL,
+    importance INTEGER NOT NULL,
+    last_notified DATETIME,
+    owner_id INTEGER NOT NULL,
+    owner_name TEXT NOT NULL,
+    owner_type TEXT NOT NULL,
+    response TEXT NOT NULL,
+    text TEXT NOT NULL,
+    title TEXT NOT NULL,
+    FOREIGN KEY (character_id) REFERENCES characters (id) ON DELETE CASCADE,
+    UNIQUE (character_id, event_id)
+);
+
+CREATE INDEX character_calendar_events_idx1 ON character_calendar_events (character_id);
+
+CREATE INDEX character_calendar_events_idx2 ON character_calendar_events (date);
+
+CREATE TABLE character_calendar_event_attendees (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    calendar_event_id INTEGER NOT NULL,
+    eve_entity_id INTEGER NOT NULL,
+    response TEXT NOT NULL,
+    FOREIGN KEY (calendar_event_id) REFERENCES character_calendar_events (id) ON DELETE CASCADE,
+    FOREIGN KEY (eve_entity_id) REFERENCES eve_entities (id) ON DELETE CASCADE,
+    UNIQUE (calendar_event_id, eve_entity_id)
+);
+
+CREATE INDEX character_calendar_event_attendees_idx1 ON character_calendar_event_attendees (calendar_event_id);
@@ -0,0 +1,8 @@
+ALTER TABLE characters
+ADD COLUMN jump_fatigue_expires_at DATETIME;
+
+ALTER TABLE characters
+ADD COLUMN last_jump_at DATETIME;
+
+ALTER TABLE characters
+ADD COLUMN is_jump_fatigue_watched BOOL DEFAULT FALSE NOT NULL;
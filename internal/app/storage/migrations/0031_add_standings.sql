@@ -0,0 +1,14 @@
+CREATE TABLE character_standings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    character_id INTEGER NOT NULL,
+    from_id INTEGER NOT NULL,
+    from_type TEXT NOT NULL,
+    standing REAL NOT NULL,
+    FOREIGN KEY (character_id) REFERENCES characters (id) ON DELETE CASCADE,
+    FOREIGN KEY (from_id) REFERENCES eve_entities (id) ON DELETE CASCADE,
+    UNIQUE (character_id, from_id)
+);
+
+CREATE INDEX character_standings_idx1 ON character_standings (character_id);
+
+CREATE INDEX character_standings_idx2 ON character_standings (from_id);
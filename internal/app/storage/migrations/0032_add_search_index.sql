@@ -0,0 +1,39 @@
+CREATE VIRTUAL TABLE character_mails_fts USING fts5 (
+    subject,
+    body,
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER character_mails_fts_delete AFTER DELETE ON character_mails
+BEGIN
+    DELETE FROM character_mails_fts
+    WHERE
+        rowid = old.id;
+END;
+
+CREATE TRIGGER character_mails_fts_update AFTER UPDATE OF subject, body ON character_mails
+BEGIN
+    DELETE FROM character_mails_fts
+    WHERE
+        rowid = old.id;
+END;
+
+CREATE VIRTUAL TABLE character_notifications_fts USING fts5 (
+    title,
+    body,
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER character_notifications_fts_delete AFTER DELETE ON character_notifications
+BEGIN
+    DELETE FROM character_notifications_fts
+    WHERE
+        rowid = old.id;
+END;
+
+CREATE TRIGGER character_notifications_fts_update AFTER UPDATE OF title, body ON character_notifications
+BEGIN
+    DELETE FROM character_notifications_fts
+    WHERE
+        rowid = old.id;
+END;
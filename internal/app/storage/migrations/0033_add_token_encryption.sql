@@ -0,0 +1,8 @@
+-- Existing tokens are encrypted by the app once a key has been set up,
+-- because the key is kept outside of the database.
+CREATE TABLE token_encryption (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    key_check TEXT NOT NULL,
+    key_salt BLOB NOT NULL,
+    key_source TEXT NOT NULL
+);
@@ -0,0 +1,16 @@
+CREATE TABLE eve_market_histories (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    region_id INTEGER NOT NULL,
+    type_id INTEGER NOT NULL,
+    date DATETIME NOT NULL,
+    average REAL NOT NULL,
+    highest REAL NOT NULL,
+    lowest REAL NOT NULL,
+    order_count INTEGER NOT NULL,
+    volume INTEGER NOT NULL,
+    FOREIGN KEY (region_id) REFERENCES eve_regions (id) ON DELETE CASCADE,
+    FOREIGN KEY (type_id) REFERENCES eve_types (id) ON DELETE CASCADE,
+    UNIQUE (region_id, type_id, date)
+);
+
+CREATE INDEX eve_market_histories_idx1 ON eve_market_histories (type_id);
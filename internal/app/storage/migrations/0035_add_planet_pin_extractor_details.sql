@@ -0,0 +1,5 @@
+ALTER TABLE planet_pins
+ADD COLUMN extractor_cycle_time INTEGER;
+
+ALTER TABLE planet_pins
+ADD COLUMN extractor_qty_per_cycle INTEGER;
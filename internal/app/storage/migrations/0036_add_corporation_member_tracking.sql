@@ -0,0 +1,17 @@
+ALTER TABLE corporation_members
+ADD COLUMN base_id INTEGER REFERENCES eve_locations (id) ON DELETE SET NULL;
+
+ALTER TABLE corporation_members
+ADD COLUMN location_id INTEGER REFERENCES eve_locations (id) ON DELETE SET NULL;
+
+ALTER TABLE corporation_members
+ADD COLUMN logoff_date DATETIME;
+
+ALTER TABLE corporation_members
+ADD COLUMN logon_date DATETIME;
+
+ALTER TABLE corporation_members
+ADD COLUMN ship_type_id INTEGER REFERENCES eve_types (id) ON DELETE SET NULL;
+
+ALTER TABLE corporation_members
+ADD COLUMN start_date DATETIME;
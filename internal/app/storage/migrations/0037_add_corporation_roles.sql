@@ -0,0 +1,55 @@
+CREATE TABLE corporation_member_roles (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    corporation_id INTEGER NOT NULL,
+    character_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    FOREIGN KEY (corporation_id) REFERENCES corporations (id) ON DELETE CASCADE,
+    FOREIGN KEY (character_id) REFERENCES eve_entities (id) ON DELETE CASCADE,
+    UNIQUE (corporation_id, character_id, name)
+);
+
+CREATE INDEX corporation_member_roles_idx1 ON corporation_member_roles (corporation_id);
+
+CREATE INDEX corporation_member_roles_idx2 ON corporation_member_roles (character_id);
+
+CREATE TABLE corporation_role_changes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    corporation_id INTEGER NOT NULL,
+    character_id INTEGER NOT NULL,
+    changed_at DATETIME NOT NULL,
+    is_granted BOOL NOT NULL,
+    name TEXT NOT NULL,
+    FOREIGN KEY (corporation_id) REFERENCES corporations (id) ON DELETE CASCADE,
+    FOREIGN KEY (character_id) REFERENCES eve_entities (id) ON DELETE CASCADE
+);
+
+CREATE INDEX corporation_role_changes_idx1 ON corporation_role_changes (corporation_id);
+
+CREATE INDEX corporation_role_changes_idx2 ON corporation_role_changes (character_id);
+
+CREATE INDEX corporation_role_changes_idx3 ON corporation_role_changes (changed_at DESC);
+
+CREATE TABLE corporation_titles (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    corporation_id INTEGER NOT NULL,
+    title_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    FOREIGN KEY (corporation_id) REFERENCES corporations (id) ON DELETE CASCADE,
+    UNIQUE (corporation_id, title_id)
+);
+
+CREATE INDEX corporation_titles_idx1 ON corporation_titles (corporation_id);
+
+CREATE TABLE corporation_member_titles (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    corporation_id INTEGER NOT NULL,
+    character_id INTEGER NOT NULL,
+    title_id INTEGER NOT NULL,
+    FOREIGN KEY (corporation_id) REFERENCES corporations (id) ON DELETE CASCADE,
+    FOREIGN KEY (character_id) REFERENCES eve_entities (id) ON DELETE CASCADE,
+    UNIQUE (corporation_id, character_id, title_id)
+);
+
+CREATE INDEX corporation_member_titles_idx1 ON corporation_member_titles (corporation_id);
+
+CREATE INDEX corporation_member_titles_idx2 ON corporation_member_titles (character_id);
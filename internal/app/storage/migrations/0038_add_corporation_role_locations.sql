@@ -0,0 +1,33 @@
+CREATE TABLE corporation_member_roles_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    corporation_id INTEGER NOT NULL,
+    character_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    location TEXT NOT NULL,
+    FOREIGN KEY (corporation_id) REFERENCES corporations (id) ON DELETE CASCADE,
+    FOREIGN KEY (character_id) REFERENCES eve_entities (id) ON DELETE CASCADE,
+    UNIQUE (corporation_id, character_id, name, location)
+);
+
+INSERT INTO
+    corporation_member_roles_new (id, corporation_id, character_id, name, location)
+SELECT
+    id,
+    corporation_id,
+    character_id,
+    name,
+    'general'
+FROM
+    corporation_member_roles;
+
+DROP TABLE corporation_member_roles;
+
+ALTER TABLE corporation_member_roles_new
+RENAME TO corporation_member_roles;
+
+CREATE INDEX corporation_member_roles_idx1 ON corporation_member_roles (corporation_id);
+
+CREATE INDEX corporation_member_roles_idx2 ON corporation_member_roles (character_id);
+
+ALTER TABLE corporation_role_changes
+ADD COLUMN location TEXT NOT NULL DEFAULT 'general';
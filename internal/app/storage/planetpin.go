@@ -11,15 +11,17 @@ import (
 )
 
 type CreatePlanetPinParams struct {
-	CharacterPlanetID      int64
-	ExpiryTime             optional.Optional[time.Time]
-	ExtractorProductTypeID optional.Optional[int64]
-	FactorySchematicID     optional.Optional[int64]
-	InstallTime            optional.Optional[time.Time]
-	LastCycleStart         optional.Optional[time.Time]
-	PinID                  int64
-	SchematicID            optional.Optional[int64]
-	TypeID                 int64
+	CharacterPlanetID         int64
+	ExpiryTime                optional.Optional[time.Time]
+	ExtractorCycleTime        optional.Optional[time.Duration]
+	ExtractorProductTypeID    optional.Optional[int64]
+	ExtractorQuantityPerCycle optional.Optional[int64]
+	FactorySchematicID        optional.Optional[int64]
+	InstallTime               optional.Optional[time.Time]
+	LastCycleStart            optional.Optional[time.Time]
+	PinID                     int64
+	SchematicID               optional.Optional[int64]
+	TypeID                    int64
 }
 
 func (st *Storage) CreatePlanetPin(ctx context.Context, arg CreatePlanetPinParams) error {
@@ -29,10 +31,16 @@ func (st *Storage) CreatePlanetPin(ctx context.Context, arg CreatePlanetPinParam
 	if arg.CharacterPlanetID == 0 || arg.PinID == 0 || arg.TypeID == 0 {
 		return wrapErr(app.ErrInvalid)
 	}
+	var cycleTime optional.Optional[int64]
+	if v, ok := arg.ExtractorCycleTime.Value(); ok {
+		cycleTime.Set(int64(v.Seconds()))
+	}
 	err := st.qRW.CreatePlanetPin(ctx, queries.CreatePlanetPinParams{
 		CharacterPlanetID:      arg.CharacterPlanetID,
 		ExpiryTime:             optional.ToNullTime(arg.ExpiryTime),
+		ExtractorCycleTime:     optional.ToNullInt64(cycleTime),
 		ExtractorProductTypeID: optional.ToNullInt64(arg.ExtractorProductTypeID),
+		ExtractorQtyPerCycle:   optional.ToNullInt64(arg.ExtractorQuantityPerCycle),
 		FactorySchemaID:        optional.ToNullInt64(arg.FactorySchematicID),
 		InstallTime:            optional.ToNullTime(arg.InstallTime),
 		LastCycleStart:         optional.ToNullTime(arg.LastCycleStart),
@@ -94,11 +102,15 @@ func (st *Storage) ListPlanetPins(ctx context.Context, characterPlanetID int64)
 
 func (st *Storage) planetPinFromDBModel(ctx context.Context, r queries.GetPlanetPinRow) (*app.PlanetPin, error) {
 	o := &app.PlanetPin{
-		ID:             r.PlanetPin.PinID,
-		ExpiryTime:     optional.FromNullTime(r.PlanetPin.ExpiryTime),
-		InstallTime:    optional.FromNullTime(r.PlanetPin.InstallTime),
-		LastCycleStart: optional.FromNullTime(r.PlanetPin.LastCycleStart),
-		Type:           eveTypeFromDBModel(r.EveType, r.EveGroup, r.EveCategory),
+		ID:                        r.PlanetPin.PinID,
+		ExpiryTime:                optional.FromNullTime(r.PlanetPin.ExpiryTime),
+		ExtractorQuantityPerCycle: optional.FromNullInt64(r.PlanetPin.ExtractorQtyPerCycle),
+		InstallTime:               optional.FromNullTime(r.PlanetPin.InstallTime),
+		LastCycleStart:            optional.FromNullTime(r.PlanetPin.LastCycleStart),
+		Type:                      eveTypeFromDBModel(r.EveType, r.EveGroup, r.EveCategory),
+	}
+	if r.PlanetPin.ExtractorCycleTime.Valid {
+		o.ExtractorCycleTime.Set(time.Duration(r.PlanetPin.ExtractorCycleTime.Int64) * time.Second)
 	}
 	if r.SchematicName.Valid {
 		o.Schematic.Set(eveSchematicFromDBModel(queries.EveSchematic{
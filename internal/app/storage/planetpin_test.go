@@ -51,15 +51,17 @@ func TestPlanetPin(t *testing.T) {
 		factorySchematic := factory.CreateEveSchematic()
 		// when
 		err := st.CreatePlanetPin(ctx, storage.CreatePlanetPinParams{
-			CharacterPlanetID:      planet.ID,
-			ExpiryTime:             optional.New(expiryTime),
-			ExtractorProductTypeID: optional.New(productType.ID),
-			FactorySchematicID:     optional.New(factorySchematic.ID),
-			InstallTime:            optional.New(installTime),
-			LastCycleStart:         optional.New(lastCycleStart),
-			PinID:                  42,
-			SchematicID:            optional.New(schematic.ID),
-			TypeID:                 pinType.ID,
+			CharacterPlanetID:         planet.ID,
+			ExpiryTime:                optional.New(expiryTime),
+			ExtractorCycleTime:        optional.New(30 * time.Minute),
+			ExtractorProductTypeID:    optional.New(productType.ID),
+			ExtractorQuantityPerCycle: optional.New[int64](1081),
+			FactorySchematicID:        optional.New(factorySchematic.ID),
+			InstallTime:               optional.New(installTime),
+			LastCycleStart:            optional.New(lastCycleStart),
+			PinID:                     42,
+			SchematicID:               optional.New(schematic.ID),
+			TypeID:                    pinType.ID,
 		})
 		// then
 		if assert.NoError(t, err) {
@@ -67,6 +69,8 @@ func TestPlanetPin(t *testing.T) {
 			if assert.NoError(t, err) {
 				xassert.Equal(t, pinType, c2.Type)
 				xassert.EqualOptional(t, productType, c2.ExtractorProductType)
+				xassert.EqualOptional(t, 30*time.Minute, c2.ExtractorCycleTime)
+				xassert.EqualOptional(t, 1081, c2.ExtractorQuantityPerCycle)
 				xassert.EqualOptional(t, expiryTime, c2.ExpiryTime)
 				xassert.EqualOptional(t, installTime, c2.InstallTime)
 				xassert.EqualOptional(t, lastCycleStart, c2.LastCycleStart)
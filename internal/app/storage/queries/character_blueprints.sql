@@ -0,0 +1,52 @@
+-- name: CreateCharacterBlueprint :exec
+INSERT INTO
+    character_blueprints (
+        character_id,
+        eve_type_id,
+        item_id,
+        location_flag,
+        location_id,
+        material_efficiency,
+        quantity,
+        runs,
+        time_efficiency
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: DeleteCharacterBlueprints :exec
+DELETE FROM character_blueprints
+WHERE
+    character_id = ?;
+
+-- name: ListAllCharacterBlueprints :many
+SELECT
+    sqlc.embed(cb),
+    sqlc.embed(et),
+    sqlc.embed(eg),
+    sqlc.embed(ec)
+FROM
+    character_blueprints cb
+    JOIN eve_types et ON et.id = cb.eve_type_id
+    JOIN eve_groups eg ON eg.id = et.eve_group_id
+    JOIN eve_categories ec ON ec.id = eg.eve_category_id
+ORDER BY
+    et.name,
+    cb.item_id;
+
+-- name: ListCharacterBlueprints :many
+SELECT
+    sqlc.embed(cb),
+    sqlc.embed(et),
+    sqlc.embed(eg),
+    sqlc.embed(ec)
+FROM
+    character_blueprints cb
+    JOIN eve_types et ON et.id = cb.eve_type_id
+    JOIN eve_groups eg ON eg.id = et.eve_group_id
+    JOIN eve_categories ec ON ec.id = eg.eve_category_id
+WHERE
+    cb.character_id = ?
+ORDER BY
+    et.name,
+    cb.item_id;
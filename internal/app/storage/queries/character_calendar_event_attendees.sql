@@ -0,0 +1,22 @@
+-- name: CreateCharacterCalendarEventAttendee :exec
+INSERT INTO
+    character_calendar_event_attendees (calendar_event_id, eve_entity_id, response)
+VALUES
+    (?, ?, ?);
+
+-- name: DeleteCharacterCalendarEventAttendees :exec
+DELETE FROM character_calendar_event_attendees
+WHERE
+    calendar_event_id = ?;
+
+-- name: ListCharacterCalendarEventAttendees :many
+SELECT
+    sqlc.embed(ee),
+    ccea.response
+FROM
+    character_calendar_event_attendees ccea
+    JOIN eve_entities ee ON ee.id = ccea.eve_entity_id
+WHERE
+    ccea.calendar_event_id = ?
+ORDER BY
+    ee.name;
@@ -0,0 +1,80 @@
+-- name: DeleteCharacterCalendarEvents :exec
+DELETE FROM character_calendar_events
+WHERE
+    character_id = ?
+    AND event_id IN (sqlc.slice ('event_ids'));
+
+-- name: GetCharacterCalendarEvent :one
+SELECT
+    *
+FROM
+    character_calendar_events
+WHERE
+    character_id = ?
+    AND event_id = ?;
+
+-- name: ListAllCharacterCalendarEventsFrom :many
+SELECT
+    *
+FROM
+    character_calendar_events
+WHERE
+    date >= ?
+ORDER BY
+    date,
+    character_id;
+
+-- name: ListCharacterCalendarEventIDs :many
+SELECT
+    event_id
+FROM
+    character_calendar_events
+WHERE
+    character_id = ?;
+
+-- name: ListCharacterCalendarEvents :many
+SELECT
+    *
+FROM
+    character_calendar_events
+WHERE
+    character_id = ?
+ORDER BY
+    date;
+
+-- name: UpdateCharacterCalendarEventLastNotified :exec
+UPDATE character_calendar_events
+SET
+    last_notified = ?
+WHERE
+    character_id = ?
+    AND event_id = ?;
+
+-- name: UpdateOrCreateCharacterCalendarEvent :one
+INSERT INTO
+    character_calendar_events (
+        character_id,
+        date,
+        duration,
+        event_id,
+        importance,
+        owner_id,
+        owner_name,
+        owner_type,
+        response,
+        text,
+        title
+    )
+VALUES
+    (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11)
+ON CONFLICT (character_id, event_id) DO UPDATE
+SET
+    date = ?2,
+    duration = ?3,
+    importance = ?5,
+    owner_id = ?6,
+    owner_name = ?7,
+    owner_type = ?8,
+    response = ?9,
+    text = ?10,
+    title = ?11 RETURNING id;
@@ -0,0 +1,69 @@
+-- name: CreateCharacterFitting :one
+INSERT INTO
+    character_fittings (
+        character_id,
+        description,
+        fitting_id,
+        name,
+        ship_type_id
+    )
+VALUES
+    (?, ?, ?, ?, ?) RETURNING id;
+
+-- name: CreateCharacterFittingItem :exec
+INSERT INTO
+    character_fitting_items (character_fitting_id, flag, quantity, type_id)
+VALUES
+    (?, ?, ?, ?);
+
+-- name: DeleteCharacterFittings :exec
+DELETE FROM character_fittings
+WHERE
+    character_id = ?;
+
+-- name: GetCharacterFitting :one
+SELECT
+    sqlc.embed(cf),
+    sqlc.embed(et),
+    sqlc.embed(eg),
+    sqlc.embed(ec)
+FROM
+    character_fittings cf
+    JOIN eve_types et ON et.id = cf.ship_type_id
+    JOIN eve_groups eg ON eg.id = et.eve_group_id
+    JOIN eve_categories ec ON ec.id = eg.eve_category_id
+WHERE
+    cf.character_id = ?
+    AND cf.fitting_id = ?;
+
+-- name: ListCharacterFittings :many
+SELECT
+    sqlc.embed(cf),
+    sqlc.embed(et),
+    sqlc.embed(eg),
+    sqlc.embed(ec)
+FROM
+    character_fittings cf
+    JOIN eve_types et ON et.id = cf.ship_type_id
+    JOIN eve_groups eg ON eg.id = et.eve_group_id
+    JOIN eve_categories ec ON ec.id = eg.eve_category_id
+WHERE
+    cf.character_id = ?
+ORDER BY
+    cf.name COLLATE NOCASE;
+
+-- name: ListCharacterFittingItems :many
+SELECT
+    sqlc.embed(cfi),
+    sqlc.embed(et),
+    sqlc.embed(eg),
+    sqlc.embed(ec)
+FROM
+    character_fitting_items cfi
+    JOIN eve_types et ON et.id = cfi.type_id
+    JOIN eve_groups eg ON eg.id = et.eve_group_id
+    JOIN eve_categories ec ON ec.id = eg.eve_category_id
+WHERE
+    cfi.character_fitting_id = ?
+ORDER BY
+    cfi.id;
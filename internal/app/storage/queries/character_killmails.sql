@@ -0,0 +1,13 @@
+-- name: CreateCharacterKillmail :exec
+INSERT OR IGNORE INTO
+    character_killmails (character_id, killmail_id)
+VALUES
+    (?, ?);
+
+-- name: ListCharacterKillmailIDs :many
+SELECT
+    killmail_id
+FROM
+    character_killmails
+WHERE
+    character_id = ?;
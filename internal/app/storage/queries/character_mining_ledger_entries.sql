@@ -0,0 +1,62 @@
+-- name: ListAllCharacterMiningLedgerEntries :many
+SELECT
+    sqlc.embed(cml),
+    sqlc.embed(et),
+    sqlc.embed(eg),
+    sqlc.embed(ec),
+    sqlc.embed(ess),
+    sqlc.embed(ecs),
+    sqlc.embed(er),
+    emp.average_price AS price
+FROM
+    character_mining_ledger_entries cml
+    JOIN eve_types et ON et.id = cml.eve_type_id
+    JOIN eve_groups eg ON eg.id = et.eve_group_id
+    JOIN eve_categories ec ON ec.id = eg.eve_category_id
+    JOIN eve_solar_systems ess ON ess.id = cml.eve_solar_system_id
+    JOIN eve_constellations ecs ON ecs.id = ess.eve_constellation_id
+    JOIN eve_regions er ON er.id = ecs.eve_region_id
+    LEFT JOIN eve_market_prices emp ON emp.type_id = cml.eve_type_id
+ORDER BY
+    cml.date DESC,
+    et.name;
+
+-- name: ListCharacterMiningLedgerEntries :many
+SELECT
+    sqlc.embed(cml),
+    sqlc.embed(et),
+    sqlc.embed(eg),
+    sqlc.embed(ec),
+    sqlc.embed(ess),
+    sqlc.embed(ecs),
+    sqlc.embed(er),
+    emp.average_price AS price
+FROM
+    character_mining_ledger_entries cml
+    JOIN eve_types et ON et.id = cml.eve_type_id
+    JOIN eve_groups eg ON eg.id = et.eve_group_id
+    JOIN eve_categories ec ON ec.id = eg.eve_category_id
+    JOIN eve_solar_systems ess ON ess.id = cml.eve_solar_system_id
+    JOIN eve_constellations ecs ON ecs.id = ess.eve_constellation_id
+    JOIN eve_regions er ON er.id = ecs.eve_region_id
+    LEFT JOIN eve_market_prices emp ON emp.type_id = cml.eve_type_id
+WHERE
+    cml.character_id = ?
+ORDER BY
+    cml.date DESC,
+    et.name;
+
+-- name: UpdateOrCreateCharacterMiningLedgerEntry :exec
+INSERT INTO
+    character_mining_ledger_entries (
+        character_id,
+        date,
+        eve_solar_system_id,
+        eve_type_id,
+        quantity
+    )
+VALUES
+    (?1, ?2, ?3, ?4, ?5)
+ON CONFLICT (character_id, date, eve_solar_system_id, eve_type_id) DO UPDATE
+SET
+    quantity = ?5;
@@ -0,0 +1,64 @@
+-- name: CreateCharacterSkillPlan :one
+INSERT INTO
+    character_skill_plans (character_id, name)
+VALUES
+    (?, ?)
+RETURNING
+    *;
+
+-- name: CreateCharacterSkillPlanItem :exec
+INSERT INTO
+    character_skill_plan_items (character_skill_plan_id, level, position, type_id)
+VALUES
+    (?, ?, ?, ?);
+
+-- name: DeleteCharacterSkillPlan :exec
+DELETE FROM character_skill_plans
+WHERE
+    id = ?;
+
+-- name: DeleteCharacterSkillPlanItems :exec
+DELETE FROM character_skill_plan_items
+WHERE
+    character_skill_plan_id = ?;
+
+-- name: GetCharacterSkillPlan :one
+SELECT
+    *
+FROM
+    character_skill_plans
+WHERE
+    id = ?;
+
+-- name: ListCharacterSkillPlans :many
+SELECT
+    *
+FROM
+    character_skill_plans
+WHERE
+    character_id = ?
+ORDER BY
+    name COLLATE NOCASE;
+
+-- name: ListCharacterSkillPlanItems :many
+SELECT
+    sqlc.embed(cspi),
+    sqlc.embed(et),
+    sqlc.embed(eg),
+    sqlc.embed(ec)
+FROM
+    character_skill_plan_items cspi
+    JOIN eve_types et ON et.id = cspi.type_id
+    JOIN eve_groups eg ON eg.id = et.eve_group_id
+    JOIN eve_categories ec ON ec.id = eg.eve_category_id
+WHERE
+    cspi.character_skill_plan_id = ?
+ORDER BY
+    cspi.position;
+
+-- name: UpdateCharacterSkillPlanName :exec
+UPDATE character_skill_plans
+SET
+    name = ?
+WHERE
+    id = ?;
@@ -0,0 +1,72 @@
+-- name: DeleteCharacterStandings :exec
+DELETE FROM character_standings
+WHERE
+    character_id = ?
+    AND from_id IN (sqlc.slice ('from_ids'));
+
+-- name: GetCharacterStanding :one
+SELECT
+    sqlc.embed(cs),
+    sqlc.embed(ee),
+    ec.faction_id as faction_id,
+    eef.name as faction_name,
+    eef.category as faction_category
+FROM
+    character_standings cs
+    JOIN eve_entities ee ON ee.id = cs.from_id
+    LEFT JOIN eve_corporations ec ON ec.id = cs.from_id
+    LEFT JOIN eve_entities as eef ON eef.id = ec.faction_id
+WHERE
+    cs.character_id = ?
+    AND cs.from_id = ?;
+
+-- name: ListAllCharacterStandings :many
+SELECT
+    sqlc.embed(cs),
+    sqlc.embed(ee),
+    ec.faction_id as faction_id,
+    eef.name as faction_name,
+    eef.category as faction_category
+FROM
+    character_standings cs
+    JOIN eve_entities ee ON ee.id = cs.from_id
+    LEFT JOIN eve_corporations ec ON ec.id = cs.from_id
+    LEFT JOIN eve_entities as eef ON eef.id = ec.faction_id
+ORDER BY
+    cs.character_id,
+    ee.name;
+
+-- name: ListCharacterStandingFromIDs :many
+SELECT
+    from_id
+FROM
+    character_standings
+WHERE
+    character_id = ?;
+
+-- name: ListCharacterStandings :many
+SELECT
+    sqlc.embed(cs),
+    sqlc.embed(ee),
+    ec.faction_id as faction_id,
+    eef.name as faction_name,
+    eef.category as faction_category
+FROM
+    character_standings cs
+    JOIN eve_entities ee ON ee.id = cs.from_id
+    LEFT JOIN eve_corporations ec ON ec.id = cs.from_id
+    LEFT JOIN eve_entities as eef ON eef.id = ec.faction_id
+WHERE
+    cs.character_id = ?
+ORDER BY
+    ee.name;
+
+-- name: UpdateOrCreateCharacterStanding :exec
+INSERT INTO
+    character_standings (character_id, from_id, from_type, standing)
+VALUES
+    (?1, ?2, ?3, ?4)
+ON CONFLICT (character_id, from_id) DO UPDATE
+SET
+    from_type = ?3,
+    standing = ?4;
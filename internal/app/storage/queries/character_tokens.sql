@@ -16,6 +16,9 @@ WHERE
             character_id = ?
     );
 
+-- name: DeleteCharacterTokens :exec
+DELETE FROM character_tokens;
+
 -- name: GetCharacterToken :one
 SELECT
     *
@@ -44,6 +47,14 @@ FROM
 WHERE
     ec.corporation_id = ?;
 
+-- name: ListCharacterTokens :many
+SELECT
+    *
+FROM
+    character_tokens
+ORDER BY
+    id;
+
 -- name: ListCharacterTokenScopes :many
 SELECT
     scopes.*
@@ -56,6 +67,14 @@ WHERE
 ORDER BY
     scopes.name;
 
+-- name: UpdateCharacterTokenSecrets :exec
+UPDATE character_tokens
+SET
+    access_token = ?,
+    refresh_token = ?
+WHERE
+    id = ?;
+
 -- name: UpdateOrCreateCharacterToken :one
 INSERT INTO
     character_tokens (
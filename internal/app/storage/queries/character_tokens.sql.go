@@ -46,6 +46,15 @@ func (q *Queries) ClearCharacterTokenScopes(ctx context.Context, characterID int
 	return err
 }
 
+const deleteCharacterTokens = `-- name: DeleteCharacterTokens :exec
+DELETE FROM character_tokens
+`
+
+func (q *Queries) DeleteCharacterTokens(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteCharacterTokens)
+	return err
+}
+
 const getCharacterToken = `-- name: GetCharacterToken :one
 SELECT
     id, access_token, character_id, expires_at, refresh_token, token_type
@@ -167,6 +176,45 @@ func (q *Queries) ListCharacterTokenForCorporationWithRoles(ctx context.Context,
 	return items, nil
 }
 
+const listCharacterTokens = `-- name: ListCharacterTokens :many
+SELECT
+    id, access_token, character_id, expires_at, refresh_token, token_type
+FROM
+    character_tokens
+ORDER BY
+    id
+`
+
+func (q *Queries) ListCharacterTokens(ctx context.Context) ([]CharacterToken, error) {
+	rows, err := q.db.QueryContext(ctx, listCharacterTokens)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CharacterToken
+	for rows.Next() {
+		var i CharacterToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccessToken,
+			&i.CharacterID,
+			&i.ExpiresAt,
+			&i.RefreshToken,
+			&i.TokenType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCharacterTokenScopes = `-- name: ListCharacterTokenScopes :many
 SELECT
     scopes.id, scopes.name
@@ -203,6 +251,26 @@ func (q *Queries) ListCharacterTokenScopes(ctx context.Context, characterID int6
 	return items, nil
 }
 
+const updateCharacterTokenSecrets = `-- name: UpdateCharacterTokenSecrets :exec
+UPDATE character_tokens
+SET
+    access_token = ?,
+    refresh_token = ?
+WHERE
+    id = ?
+`
+
+type UpdateCharacterTokenSecretsParams struct {
+	AccessToken  string
+	RefreshToken string
+	ID           int64
+}
+
+func (q *Queries) UpdateCharacterTokenSecrets(ctx context.Context, arg UpdateCharacterTokenSecretsParams) error {
+	_, err := q.db.ExecContext(ctx, updateCharacterTokenSecrets, arg.AccessToken, arg.RefreshToken, arg.ID)
+	return err
+}
+
 const updateOrCreateCharacterToken = `-- name: UpdateOrCreateCharacterToken :one
 INSERT INTO
     character_tokens (
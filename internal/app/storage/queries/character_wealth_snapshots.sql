@@ -0,0 +1,38 @@
+-- name: GetCharacterWealthSnapshot :one
+SELECT
+    *
+FROM
+    character_wealth_snapshots
+WHERE
+    character_id = ?
+    AND day = ?;
+
+-- name: ListCharacterWealthSnapshots :many
+SELECT
+    *
+FROM
+    character_wealth_snapshots
+WHERE
+    day >= ?
+ORDER BY
+    day,
+    character_id;
+
+-- name: UpdateOrCreateCharacterWealthSnapshot :exec
+INSERT INTO
+    character_wealth_snapshots (
+        character_id,
+        day,
+        assets_value,
+        contracts_escrow,
+        orders_escrow,
+        wallet_balance
+    )
+VALUES
+    (?1, ?2, ?3, ?4, ?5, ?6)
+ON CONFLICT (character_id, day) DO UPDATE
+SET
+    assets_value = ?3,
+    contracts_escrow = ?4,
+    orders_escrow = ?5,
+    wallet_balance = ?6;
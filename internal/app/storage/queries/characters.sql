@@ -6,8 +6,11 @@ INSERT INTO
         contract_items_value,
         contracts_escrow,
         home_id,
+        is_jump_fatigue_watched,
         is_training_watched,
+        jump_fatigue_expires_at,
         last_clone_jump_at,
+        last_jump_at,
         last_login_at,
         location_id,
         order_items_value,
@@ -19,7 +22,7 @@ INSERT INTO
         wallet_balance
     )
 VALUES
-    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: DeleteCharacter :exec
 DELETE FROM characters
@@ -111,6 +114,15 @@ SELECT
 FROM
     characters;
 
+-- name: ListCharacterIDsForCorporation :many
+SELECT
+    ch.id
+FROM
+    characters ch
+    JOIN eve_characters ec ON ec.id = ch.id
+WHERE
+    ec.corporation_id = ?;
+
 -- name: ListCharacterEveCharacters :many
 SELECT
     sqlc.embed(ec),
@@ -180,6 +192,21 @@ SET
 WHERE
     id = ?;
 
+-- name: UpdateCharacterIsJumpFatigueWatched :exec
+UPDATE characters
+SET
+    is_jump_fatigue_watched = ?
+WHERE
+    id = ?;
+
+-- name: UpdateCharacterJumpFatigue :exec
+UPDATE characters
+SET
+    jump_fatigue_expires_at = ?,
+    last_jump_at = ?
+WHERE
+    id = ?;
+
 -- name: UpdateCharacterLastCloneJump :exec
 UPDATE characters
 SET
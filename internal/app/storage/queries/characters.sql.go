@@ -18,8 +18,11 @@ INSERT INTO
         contract_items_value,
         contracts_escrow,
         home_id,
+        is_jump_fatigue_watched,
         is_training_watched,
+        jump_fatigue_expires_at,
         last_clone_jump_at,
+        last_jump_at,
         last_login_at,
         location_id,
         order_items_value,
@@ -31,26 +34,29 @@ INSERT INTO
         wallet_balance
     )
 VALUES
-    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateCharacterParams struct {
-	ID                 int64
-	AssetValue         sql.NullFloat64
-	ContractItemsValue sql.NullFloat64
-	ContractsEscrow    sql.NullFloat64
-	HomeID             sql.NullInt64
-	IsTrainingWatched  bool
-	LastCloneJumpAt    sql.NullTime
-	LastLoginAt        sql.NullTime
-	LocationID         sql.NullInt64
-	OrderItemsValue    sql.NullFloat64
-	OrdersEscrow       sql.NullFloat64
-	ShipID             sql.NullInt64
-	SkillPointsValue   sql.NullFloat64
-	TotalSp            sql.NullInt64
-	UnallocatedSp      sql.NullInt64
-	WalletBalance      sql.NullFloat64
+	ID                   int64
+	AssetValue           sql.NullFloat64
+	ContractItemsValue   sql.NullFloat64
+	ContractsEscrow      sql.NullFloat64
+	HomeID               sql.NullInt64
+	IsJumpFatigueWatched bool
+	IsTrainingWatched    bool
+	JumpFatigueExpiresAt sql.NullTime
+	LastCloneJumpAt      sql.NullTime
+	LastJumpAt           sql.NullTime
+	LastLoginAt          sql.NullTime
+	LocationID           sql.NullInt64
+	OrderItemsValue      sql.NullFloat64
+	OrdersEscrow         sql.NullFloat64
+	ShipID               sql.NullInt64
+	SkillPointsValue     sql.NullFloat64
+	TotalSp              sql.NullInt64
+	UnallocatedSp        sql.NullInt64
+	WalletBalance        sql.NullFloat64
 }
 
 func (q *Queries) CreateCharacter(ctx context.Context, arg CreateCharacterParams) error {
@@ -60,8 +66,11 @@ func (q *Queries) CreateCharacter(ctx context.Context, arg CreateCharacterParams
 		arg.ContractItemsValue,
 		arg.ContractsEscrow,
 		arg.HomeID,
+		arg.IsJumpFatigueWatched,
 		arg.IsTrainingWatched,
+		arg.JumpFatigueExpiresAt,
 		arg.LastCloneJumpAt,
+		arg.LastJumpAt,
 		arg.LastLoginAt,
 		arg.LocationID,
 		arg.OrderItemsValue,
@@ -99,7 +108,7 @@ func (q *Queries) DisableAllTrainingWatchers(ctx context.Context) error {
 
 const getCharacter = `-- name: GetCharacter :one
 SELECT
-    cc.id, cc.asset_value, cc.home_id, cc.last_login_at, cc.location_id, cc.ship_id, cc.total_sp, cc.unallocated_sp, cc.wallet_balance, cc.is_training_watched, cc.last_clone_jump_at, cc.contracts_escrow, cc.contract_items_value, cc.orders_escrow, cc.order_items_value, cc.skill_points_value,
+    cc.id, cc.asset_value, cc.home_id, cc.last_login_at, cc.location_id, cc.ship_id, cc.total_sp, cc.unallocated_sp, cc.wallet_balance, cc.is_training_watched, cc.last_clone_jump_at, cc.contracts_escrow, cc.contract_items_value, cc.orders_escrow, cc.order_items_value, cc.skill_points_value, cc.jump_fatigue_expires_at, cc.last_jump_at, cc.is_jump_fatigue_watched,
     ec.alliance_id, ec.birthday, ec.corporation_id, ec.description, ec.gender, ec.faction_id, ec.id, ec.name, ec.race_id, ec.security_status, ec.title, ec.bloodline_id,
     eec.id, eec.category, eec.name,
     er.id, er.description, er.name, er.faction_id,
@@ -163,6 +172,9 @@ func (q *Queries) GetCharacter(ctx context.Context, id int64) (GetCharacterRow,
 		&i.Character.OrdersEscrow,
 		&i.Character.OrderItemsValue,
 		&i.Character.SkillPointsValue,
+		&i.Character.JumpFatigueExpiresAt,
+		&i.Character.LastJumpAt,
+		&i.Character.IsJumpFatigueWatched,
 		&i.EveCharacter.AllianceID,
 		&i.EveCharacter.Birthday,
 		&i.EveCharacter.CorporationID,
@@ -399,6 +411,39 @@ func (q *Queries) ListCharacterIDs(ctx context.Context) ([]int64, error) {
 	return items, nil
 }
 
+const listCharacterIDsForCorporation = `-- name: ListCharacterIDsForCorporation :many
+SELECT
+    ch.id
+FROM
+    characters ch
+    JOIN eve_characters ec ON ec.id = ch.id
+WHERE
+    ec.corporation_id = ?
+`
+
+func (q *Queries) ListCharacterIDsForCorporation(ctx context.Context, corporationID int64) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listCharacterIDsForCorporation, corporationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCharacterWealthValues = `-- name: ListCharacterWealthValues :many
 SELECT
     id,
@@ -439,7 +484,7 @@ func (q *Queries) ListCharacterWealthValues(ctx context.Context) ([]ListCharacte
 
 const listCharacters = `-- name: ListCharacters :many
 SELECT DISTINCT
-    cc.id, cc.asset_value, cc.home_id, cc.last_login_at, cc.location_id, cc.ship_id, cc.total_sp, cc.unallocated_sp, cc.wallet_balance, cc.is_training_watched, cc.last_clone_jump_at, cc.contracts_escrow, cc.contract_items_value, cc.orders_escrow, cc.order_items_value, cc.skill_points_value,
+    cc.id, cc.asset_value, cc.home_id, cc.last_login_at, cc.location_id, cc.ship_id, cc.total_sp, cc.unallocated_sp, cc.wallet_balance, cc.is_training_watched, cc.last_clone_jump_at, cc.contracts_escrow, cc.contract_items_value, cc.orders_escrow, cc.order_items_value, cc.skill_points_value, cc.jump_fatigue_expires_at, cc.last_jump_at, cc.is_jump_fatigue_watched,
     ec.alliance_id, ec.birthday, ec.corporation_id, ec.description, ec.gender, ec.faction_id, ec.id, ec.name, ec.race_id, ec.security_status, ec.title, ec.bloodline_id,
     eec.id, eec.category, eec.name,
     er.id, er.description, er.name, er.faction_id,
@@ -509,6 +554,9 @@ func (q *Queries) ListCharacters(ctx context.Context) ([]ListCharactersRow, erro
 			&i.Character.OrdersEscrow,
 			&i.Character.OrderItemsValue,
 			&i.Character.SkillPointsValue,
+			&i.Character.JumpFatigueExpiresAt,
+			&i.Character.LastJumpAt,
+			&i.Character.IsJumpFatigueWatched,
 			&i.EveCharacter.AllianceID,
 			&i.EveCharacter.Birthday,
 			&i.EveCharacter.CorporationID,
@@ -681,6 +729,44 @@ func (q *Queries) UpdateCharacterIsTrainingWatched(ctx context.Context, arg Upda
 	return err
 }
 
+const updateCharacterIsJumpFatigueWatched = `-- name: UpdateCharacterIsJumpFatigueWatched :exec
+UPDATE characters
+SET
+    is_jump_fatigue_watched = ?
+WHERE
+    id = ?
+`
+
+type UpdateCharacterIsJumpFatigueWatchedParams struct {
+	IsJumpFatigueWatched bool
+	ID                   int64
+}
+
+func (q *Queries) UpdateCharacterIsJumpFatigueWatched(ctx context.Context, arg UpdateCharacterIsJumpFatigueWatchedParams) error {
+	_, err := q.db.ExecContext(ctx, updateCharacterIsJumpFatigueWatched, arg.IsJumpFatigueWatched, arg.ID)
+	return err
+}
+
+const updateCharacterJumpFatigue = `-- name: UpdateCharacterJumpFatigue :exec
+UPDATE characters
+SET
+    jump_fatigue_expires_at = ?,
+    last_jump_at = ?
+WHERE
+    id = ?
+`
+
+type UpdateCharacterJumpFatigueParams struct {
+	JumpFatigueExpiresAt sql.NullTime
+	LastJumpAt           sql.NullTime
+	ID                   int64
+}
+
+func (q *Queries) UpdateCharacterJumpFatigue(ctx context.Context, arg UpdateCharacterJumpFatigueParams) error {
+	_, err := q.db.ExecContext(ctx, updateCharacterJumpFatigue, arg.JumpFatigueExpiresAt, arg.LastJumpAt, arg.ID)
+	return err
+}
+
 const updateCharacterLastCloneJump = `-- name: UpdateCharacterLastCloneJump :exec
 UPDATE characters
 SET
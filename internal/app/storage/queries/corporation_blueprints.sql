@@ -0,0 +1,52 @@
+-- name: CreateCorporationBlueprint :exec
+INSERT INTO
+    corporation_blueprints (
+        corporation_id,
+        eve_type_id,
+        item_id,
+        location_flag,
+        location_id,
+        material_efficiency,
+        quantity,
+        runs,
+        time_efficiency
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: DeleteCorporationBlueprints :exec
+DELETE FROM corporation_blueprints
+WHERE
+    corporation_id = ?;
+
+-- name: ListAllCorporationBlueprints :many
+SELECT
+    sqlc.embed(cb),
+    sqlc.embed(et),
+    sqlc.embed(eg),
+    sqlc.embed(ec)
+FROM
+    corporation_blueprints cb
+    JOIN eve_types et ON et.id = cb.eve_type_id
+    JOIN eve_groups eg ON eg.id = et.eve_group_id
+    JOIN eve_categories ec ON ec.id = eg.eve_category_id
+ORDER BY
+    et.name,
+    cb.item_id;
+
+-- name: ListCorporationBlueprints :many
+SELECT
+    sqlc.embed(cb),
+    sqlc.embed(et),
+    sqlc.embed(eg),
+    sqlc.embed(ec)
+FROM
+    corporation_blueprints cb
+    JOIN eve_types et ON et.id = cb.eve_type_id
+    JOIN eve_groups eg ON eg.id = et.eve_group_id
+    JOIN eve_categories ec ON ec.id = eg.eve_category_id
+WHERE
+    cb.corporation_id = ?
+ORDER BY
+    et.name,
+    cb.item_id;
@@ -0,0 +1,18 @@
+-- name: CreateCorporationKillmail :exec
+INSERT OR IGNORE INTO
+    corporation_killmails (corporation_id, killmail_id)
+VALUES
+    (?, ?);
+
+-- name: DeleteCorporationKillmails :exec
+DELETE FROM corporation_killmails
+WHERE
+    corporation_id = ?;
+
+-- name: ListCorporationKillmailIDs :many
+SELECT
+    killmail_id
+FROM
+    corporation_killmails
+WHERE
+    corporation_id = ?;
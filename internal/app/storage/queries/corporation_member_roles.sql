@@ -0,0 +1,20 @@
+-- name: CreateCorporationMemberRole :exec
+INSERT INTO
+    corporation_member_roles (corporation_id, character_id, name, location)
+VALUES
+    (?, ?, ?, ?);
+
+-- name: DeleteCorporationMemberRoles :exec
+DELETE FROM corporation_member_roles
+WHERE
+    corporation_id = ?;
+
+-- name: ListCorporationMemberRoles :many
+SELECT
+    sqlc.embed(cmr),
+    sqlc.embed(ee)
+FROM
+    corporation_member_roles cmr
+    JOIN eve_entities ee ON ee.id = cmr.character_id
+WHERE
+    cmr.corporation_id = ?;
@@ -13,10 +13,20 @@ WHERE
 -- name: GetCorporationMembers :one
 SELECT
     sqlc.embed(cm),
-    sqlc.embed(ee)
+    sqlc.embed(ee),
+    bases.name AS base_name,
+    base_systems.security_status AS base_security_status,
+    locations.name AS location_name,
+    location_systems.security_status AS location_security_status,
+    ship_types.name AS ship_type_name
 FROM
     corporation_members cm
     JOIN eve_entities ee ON ee.id = cm.character_id
+    LEFT JOIN eve_locations bases ON bases.id = cm.base_id
+    LEFT JOIN eve_solar_systems base_systems ON base_systems.id = bases.eve_solar_system_id
+    LEFT JOIN eve_locations locations ON locations.id = cm.location_id
+    LEFT JOIN eve_solar_systems location_systems ON location_systems.id = locations.eve_solar_system_id
+    LEFT JOIN eve_types ship_types ON ship_types.id = cm.ship_type_id
 WHERE
     cm.corporation_id = ?
     AND cm.character_id = ?;
@@ -24,10 +34,20 @@ WHERE
 -- name: ListCorporationMembers :many
 SELECT
     sqlc.embed(cm),
-    sqlc.embed(ee)
+    sqlc.embed(ee),
+    bases.name AS base_name,
+    base_systems.security_status AS base_security_status,
+    locations.name AS location_name,
+    location_systems.security_status AS location_security_status,
+    ship_types.name AS ship_type_name
 FROM
     corporation_members cm
     JOIN eve_entities ee ON ee.id = cm.character_id
+    LEFT JOIN eve_locations bases ON bases.id = cm.base_id
+    LEFT JOIN eve_solar_systems base_systems ON base_systems.id = bases.eve_solar_system_id
+    LEFT JOIN eve_locations locations ON locations.id = cm.location_id
+    LEFT JOIN eve_solar_systems location_systems ON location_systems.id = locations.eve_solar_system_id
+    LEFT JOIN eve_types ship_types ON ship_types.id = cm.ship_type_id
 WHERE
     cm.corporation_id = ?;
 
@@ -38,3 +58,38 @@ FROM
     corporation_members
 WHERE
     corporation_id = ?;
+
+-- name: ResetCorporationMemberTracking :exec
+UPDATE corporation_members
+SET
+    base_id = NULL,
+    location_id = NULL,
+    logoff_date = NULL,
+    logon_date = NULL,
+    ship_type_id = NULL,
+    start_date = NULL
+WHERE
+    corporation_id = ?;
+
+-- name: UpdateOrCreateCorporationMemberTracking :exec
+INSERT INTO
+    corporation_members (
+        corporation_id,
+        character_id,
+        base_id,
+        location_id,
+        logoff_date,
+        logon_date,
+        ship_type_id,
+        start_date
+    )
+VALUES
+    (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8)
+ON CONFLICT (corporation_id, character_id) DO UPDATE
+SET
+    base_id = ?3,
+    location_id = ?4,
+    logoff_date = ?5,
+    logon_date = ?6,
+    ship_type_id = ?7,
+    start_date = ?8;
@@ -7,6 +7,7 @@ package queries
 
 import (
 	"context"
+	"database/sql"
 	"strings"
 )
 
@@ -57,11 +58,21 @@ func (q *Queries) DeleteCorporationMembers(ctx context.Context, arg DeleteCorpor
 
 const getCorporationMembers = `-- name: GetCorporationMembers :one
 SELECT
-    cm.id, cm.corporation_id, cm.character_id,
-    ee.id, ee.category, ee.name
+    cm.id, cm.corporation_id, cm.character_id, cm.base_id, cm.location_id, cm.logoff_date, cm.logon_date, cm.ship_type_id, cm.start_date,
+    ee.id, ee.category, ee.name,
+    bases.name AS base_name,
+    base_systems.security_status AS base_security_status,
+    locations.name AS location_name,
+    location_systems.security_status AS location_security_status,
+    ship_types.name AS ship_type_name
 FROM
     corporation_members cm
     JOIN eve_entities ee ON ee.id = cm.character_id
+    LEFT JOIN eve_locations bases ON bases.id = cm.base_id
+    LEFT JOIN eve_solar_systems base_systems ON base_systems.id = bases.eve_solar_system_id
+    LEFT JOIN eve_locations locations ON locations.id = cm.location_id
+    LEFT JOIN eve_solar_systems location_systems ON location_systems.id = locations.eve_solar_system_id
+    LEFT JOIN eve_types ship_types ON ship_types.id = cm.ship_type_id
 WHERE
     cm.corporation_id = ?
     AND cm.character_id = ?
@@ -73,8 +84,13 @@ type GetCorporationMembersParams struct {
 }
 
 type GetCorporationMembersRow struct {
-	CorporationMember CorporationMember
-	EveEntity         EveEntity
+	CorporationMember      CorporationMember
+	EveEntity              EveEntity
+	BaseName               sql.NullString
+	BaseSecurityStatus     sql.NullFloat64
+	LocationName           sql.NullString
+	LocationSecurityStatus sql.NullFloat64
+	ShipTypeName           sql.NullString
 }
 
 func (q *Queries) GetCorporationMembers(ctx context.Context, arg GetCorporationMembersParams) (GetCorporationMembersRow, error) {
@@ -84,9 +100,20 @@ func (q *Queries) GetCorporationMembers(ctx context.Context, arg GetCorporationM
 		&i.CorporationMember.ID,
 		&i.CorporationMember.CorporationID,
 		&i.CorporationMember.CharacterID,
+		&i.CorporationMember.BaseID,
+		&i.CorporationMember.LocationID,
+		&i.CorporationMember.LogoffDate,
+		&i.CorporationMember.LogonDate,
+		&i.CorporationMember.ShipTypeID,
+		&i.CorporationMember.StartDate,
 		&i.EveEntity.ID,
 		&i.EveEntity.Category,
 		&i.EveEntity.Name,
+		&i.BaseName,
+		&i.BaseSecurityStatus,
+		&i.LocationName,
+		&i.LocationSecurityStatus,
+		&i.ShipTypeName,
 	)
 	return i, err
 }
@@ -125,18 +152,33 @@ func (q *Queries) ListCorporationMemberIDs(ctx context.Context, corporationID in
 
 const listCorporationMembers = `-- name: ListCorporationMembers :many
 SELECT
-    cm.id, cm.corporation_id, cm.character_id,
-    ee.id, ee.category, ee.name
+    cm.id, cm.corporation_id, cm.character_id, cm.base_id, cm.location_id, cm.logoff_date, cm.logon_date, cm.ship_type_id, cm.start_date,
+    ee.id, ee.category, ee.name,
+    bases.name AS base_name,
+    base_systems.security_status AS base_security_status,
+    locations.name AS location_name,
+    location_systems.security_status AS location_security_status,
+    ship_types.name AS ship_type_name
 FROM
     corporation_members cm
     JOIN eve_entities ee ON ee.id = cm.character_id
+    LEFT JOIN eve_locations bases ON bases.id = cm.base_id
+    LEFT JOIN eve_solar_systems base_systems ON base_systems.id = bases.eve_solar_system_id
+    LEFT JOIN eve_locations locations ON locations.id = cm.location_id
+    LEFT JOIN eve_solar_systems location_systems ON location_systems.id = locations.eve_solar_system_id
+    LEFT JOIN eve_types ship_types ON ship_types.id = cm.ship_type_id
 WHERE
     cm.corporation_id = ?
 `
 
 type ListCorporationMembersRow struct {
-	CorporationMember CorporationMember
-	EveEntity         EveEntity
+	CorporationMember      CorporationMember
+	EveEntity              EveEntity
+	BaseName               sql.NullString
+	BaseSecurityStatus     sql.NullFloat64
+	LocationName           sql.NullString
+	LocationSecurityStatus sql.NullFloat64
+	ShipTypeName           sql.NullString
 }
 
 func (q *Queries) ListCorporationMembers(ctx context.Context, corporationID int64) ([]ListCorporationMembersRow, error) {
@@ -152,9 +194,20 @@ func (q *Queries) ListCorporationMembers(ctx context.Context, corporationID int6
 			&i.CorporationMember.ID,
 			&i.CorporationMember.CorporationID,
 			&i.CorporationMember.CharacterID,
+			&i.CorporationMember.BaseID,
+			&i.CorporationMember.LocationID,
+			&i.CorporationMember.LogoffDate,
+			&i.CorporationMember.LogonDate,
+			&i.CorporationMember.ShipTypeID,
+			&i.CorporationMember.StartDate,
 			&i.EveEntity.ID,
 			&i.EveEntity.Category,
 			&i.EveEntity.Name,
+			&i.BaseName,
+			&i.BaseSecurityStatus,
+			&i.LocationName,
+			&i.LocationSecurityStatus,
+			&i.ShipTypeName,
 		); err != nil {
 			return nil, err
 		}
@@ -168,3 +221,70 @@ func (q *Queries) ListCorporationMembers(ctx context.Context, corporationID int6
 	}
 	return items, nil
 }
+
+const resetCorporationMemberTracking = `-- name: ResetCorporationMemberTracking :exec
+UPDATE corporation_members
+SET
+    base_id = NULL,
+    location_id = NULL,
+    logoff_date = NULL,
+    logon_date = NULL,
+    ship_type_id = NULL,
+    start_date = NULL
+WHERE
+    corporation_id = ?
+`
+
+func (q *Queries) ResetCorporationMemberTracking(ctx context.Context, corporationID int64) error {
+	_, err := q.db.ExecContext(ctx, resetCorporationMemberTracking, corporationID)
+	return err
+}
+
+const updateOrCreateCorporationMemberTracking = `-- name: UpdateOrCreateCorporationMemberTracking :exec
+INSERT INTO
+    corporation_members (
+        corporation_id,
+        character_id,
+        base_id,
+        location_id,
+        logoff_date,
+        logon_date,
+        ship_type_id,
+        start_date
+    )
+VALUES
+    (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8)
+ON CONFLICT (corporation_id, character_id) DO UPDATE
+SET
+    base_id = ?3,
+    location_id = ?4,
+    logoff_date = ?5,
+    logon_date = ?6,
+    ship_type_id = ?7,
+    start_date = ?8
+`
+
+type UpdateOrCreateCorporationMemberTrackingParams struct {
+	CorporationID int64
+	CharacterID   int64
+	BaseID        sql.NullInt64
+	LocationID    sql.NullInt64
+	LogoffDate    sql.NullTime
+	LogonDate     sql.NullTime
+	ShipTypeID    sql.NullInt64
+	StartDate     sql.NullTime
+}
+
+func (q *Queries) UpdateOrCreateCorporationMemberTracking(ctx context.Context, arg UpdateOrCreateCorporationMemberTrackingParams) error {
+	_, err := q.db.ExecContext(ctx, updateOrCreateCorporationMemberTracking,
+		arg.CorporationID,
+		arg.CharacterID,
+		arg.BaseID,
+		arg.LocationID,
+		arg.LogoffDate,
+		arg.LogonDate,
+		arg.ShipTypeID,
+		arg.StartDate,
+	)
+	return err
+}
@@ -0,0 +1,68 @@
+-- name: DeleteCorporationMiningObserverEntries :exec
+DELETE FROM corporation_mining_observer_entries
+WHERE
+    corporation_id = ?;
+
+-- name: ListAllCorporationMiningObserverEntries :many
+SELECT
+    sqlc.embed(cmoe),
+    sqlc.embed(ee),
+    sqlc.embed(et),
+    sqlc.embed(eg),
+    sqlc.embed(ec),
+    emp.average_price AS price,
+    cs.name AS structure_name
+FROM
+    corporation_mining_observer_entries cmoe
+    JOIN eve_entities ee ON ee.id = cmoe.character_id
+    JOIN eve_types et ON et.id = cmoe.eve_type_id
+    JOIN eve_groups eg ON eg.id = et.eve_group_id
+    JOIN eve_categories ec ON ec.id = eg.eve_category_id
+    LEFT JOIN eve_market_prices emp ON emp.type_id = cmoe.eve_type_id
+    LEFT JOIN corporation_structures cs ON cs.corporation_id = cmoe.corporation_id
+    AND cs.structure_id = cmoe.observer_id
+ORDER BY
+    cmoe.last_updated DESC,
+    et.name;
+
+-- name: ListCorporationMiningObserverEntries :many
+SELECT
+    sqlc.embed(cmoe),
+    sqlc.embed(ee),
+    sqlc.embed(et),
+    sqlc.embed(eg),
+    sqlc.embed(ec),
+    emp.average_price AS price,
+    cs.name AS structure_name
+FROM
+    corporation_mining_observer_entries cmoe
+    JOIN eve_entities ee ON ee.id = cmoe.character_id
+    JOIN eve_types et ON et.id = cmoe.eve_type_id
+    JOIN eve_groups eg ON eg.id = et.eve_group_id
+    JOIN eve_categories ec ON ec.id = eg.eve_category_id
+    LEFT JOIN eve_market_prices emp ON emp.type_id = cmoe.eve_type_id
+    LEFT JOIN corporation_structures cs ON cs.corporation_id = cmoe.corporation_id
+    AND cs.structure_id = cmoe.observer_id
+WHERE
+    cmoe.corporation_id = ?
+ORDER BY
+    cmoe.last_updated DESC,
+    et.name;
+
+-- name: UpdateOrCreateCorporationMiningObserverEntry :exec
+INSERT INTO
+    corporation_mining_observer_entries (
+        character_id,
+        corporation_id,
+        eve_type_id,
+        last_updated,
+        observer_id,
+        quantity,
+        recorded_corporation_id
+    )
+VALUES
+    (?1, ?2, ?3, ?4, ?5, ?6, ?7)
+ON CONFLICT (corporation_id, observer_id, character_id, eve_type_id, last_updated) DO UPDATE
+SET
+    quantity = ?6,
+    recorded_corporation_id = ?7;
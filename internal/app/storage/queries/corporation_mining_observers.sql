@@ -0,0 +1,32 @@
+-- name: DeleteCorporationMiningObservers :exec
+DELETE FROM corporation_mining_observers
+WHERE
+    corporation_id = ?;
+
+-- name: ListCorporationMiningObservers :many
+SELECT
+    sqlc.embed(cmo),
+    cs.name AS structure_name
+FROM
+    corporation_mining_observers cmo
+    LEFT JOIN corporation_structures cs ON cs.corporation_id = cmo.corporation_id
+    AND cs.structure_id = cmo.observer_id
+WHERE
+    cmo.corporation_id = ?
+ORDER BY
+    cmo.observer_id;
+
+-- name: UpdateOrCreateCorporationMiningObserver :exec
+INSERT INTO
+    corporation_mining_observers (
+        corporation_id,
+        last_updated,
+        observer_id,
+        observer_type
+    )
+VALUES
+    (?1, ?2, ?3, ?4)
+ON CONFLICT (corporation_id, observer_id) DO UPDATE
+SET
+    last_updated = ?2,
+    observer_type = ?4;
@@ -0,0 +1,30 @@
+-- name: CreateCorporationRoleChange :exec
+INSERT INTO
+    corporation_role_changes (
+        corporation_id,
+        character_id,
+        changed_at,
+        is_granted,
+        name,
+        location
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?);
+
+-- name: DeleteCorporationRoleChanges :exec
+DELETE FROM corporation_role_changes
+WHERE
+    corporation_id = ?;
+
+-- name: ListCorporationRoleChanges :many
+SELECT
+    sqlc.embed(crc),
+    sqlc.embed(ee)
+FROM
+    corporation_role_changes crc
+    JOIN eve_entities ee ON ee.id = crc.character_id
+WHERE
+    crc.corporation_id = ?
+ORDER BY
+    crc.changed_at DESC,
+    crc.id DESC;
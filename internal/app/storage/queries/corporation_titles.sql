@@ -0,0 +1,44 @@
+-- name: CreateCorporationMemberTitle :exec
+INSERT INTO
+    corporation_member_titles (corporation_id, character_id, title_id)
+VALUES
+    (?, ?, ?);
+
+-- name: CreateCorporationTitle :exec
+INSERT INTO
+    corporation_titles (corporation_id, title_id, name)
+VALUES
+    (?, ?, ?);
+
+-- name: DeleteCorporationMemberTitles :exec
+DELETE FROM corporation_member_titles
+WHERE
+    corporation_id = ?;
+
+-- name: DeleteCorporationTitles :exec
+DELETE FROM corporation_titles
+WHERE
+    corporation_id = ?;
+
+-- name: ListCorporationMemberTitles :many
+SELECT
+    sqlc.embed(cmt),
+    sqlc.embed(ee),
+    sqlc.embed(ct)
+FROM
+    corporation_member_titles cmt
+    JOIN eve_entities ee ON ee.id = cmt.character_id
+    JOIN corporation_titles ct ON ct.corporation_id = cmt.corporation_id
+    AND ct.title_id = cmt.title_id
+WHERE
+    cmt.corporation_id = ?;
+
+-- name: ListCorporationTitles :many
+SELECT
+    *
+FROM
+    corporation_titles
+WHERE
+    corporation_id = ?
+ORDER BY
+    name;
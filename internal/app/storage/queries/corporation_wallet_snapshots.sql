@@ -0,0 +1,20 @@
+-- name: ListCorporationWalletSnapshots :many
+SELECT
+    *
+FROM
+    corporation_wallet_snapshots
+WHERE
+    corporation_id = ?
+    AND day >= ?
+ORDER BY
+    day,
+    division_id;
+
+-- name: UpdateOrCreateCorporationWalletSnapshot :exec
+INSERT INTO
+    corporation_wallet_snapshots (corporation_id, division_id, day, balance)
+VALUES
+    (?1, ?2, ?3, ?4)
+ON CONFLICT (corporation_id, division_id, day) DO UPDATE
+SET
+    balance = ?4;
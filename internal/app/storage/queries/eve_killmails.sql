@@ -0,0 +1,132 @@
+-- name: CreateEveKillmail :exec
+INSERT INTO
+    eve_killmails (
+        id,
+        hash,
+        moon_id,
+        solar_system_id,
+        time,
+        victim_alliance_id,
+        victim_character_id,
+        victim_corporation_id,
+        victim_damage_taken,
+        victim_faction_id,
+        victim_ship_type_id,
+        war_id
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: CreateEveKillmailAttacker :exec
+INSERT INTO
+    eve_killmail_attackers (
+        killmail_id,
+        alliance_id,
+        character_id,
+        corporation_id,
+        damage_done,
+        faction_id,
+        is_final_blow,
+        security_status,
+        ship_type_id,
+        weapon_type_id
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: CreateEveKillmailItem :exec
+INSERT INTO
+    eve_killmail_items (
+        killmail_id,
+        flag,
+        is_singleton,
+        quantity_destroyed,
+        quantity_dropped,
+        type_id
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?);
+
+-- name: ListEveKillmailAttackers :many
+SELECT
+    sqlc.embed(ka),
+    ea.name AS alliance_name,
+    ec.name AS character_name,
+    eco.name AS corporation_name,
+    ef.name AS faction_name,
+    est.name AS ship_type_name,
+    ewt.name AS weapon_type_name
+FROM
+    eve_killmail_attackers ka
+    LEFT JOIN eve_entities ea ON ea.id = ka.alliance_id
+    LEFT JOIN eve_entities ec ON ec.id = ka.character_id
+    LEFT JOIN eve_entities eco ON eco.id = ka.corporation_id
+    LEFT JOIN eve_entities ef ON ef.id = ka.faction_id
+    LEFT JOIN eve_types est ON est.id = ka.ship_type_id
+    LEFT JOIN eve_types ewt ON ewt.id = ka.weapon_type_id
+WHERE
+    ka.killmail_id = ?
+ORDER BY
+    ka.damage_done DESC;
+
+-- name: ListEveKillmailIDs :many
+SELECT
+    id
+FROM
+    eve_killmails
+WHERE
+    id IN (sqlc.slice('ids'));
+
+-- name: ListEveKillmailItems :many
+SELECT
+    sqlc.embed(ki),
+    et.name AS type_name,
+    emp.average_price AS price
+FROM
+    eve_killmail_items ki
+    JOIN eve_types et ON et.id = ki.type_id
+    LEFT JOIN eve_market_prices emp ON emp.type_id = ki.type_id
+WHERE
+    ki.killmail_id = ?
+ORDER BY
+    ki.flag,
+    et.name;
+
+-- name: ListEveKillmailItemValues :many
+SELECT
+    ki.killmail_id,
+    SUM(
+        (ki.quantity_destroyed + ki.quantity_dropped) * IFNULL(emp.average_price, 0)
+    ) AS value
+FROM
+    eve_killmail_items ki
+    LEFT JOIN eve_market_prices emp ON emp.type_id = ki.type_id
+WHERE
+    ki.killmail_id IN (sqlc.slice('ids'))
+GROUP BY
+    ki.killmail_id;
+
+-- name: ListEveKillmails :many
+SELECT
+    sqlc.embed(ek),
+    ess.name AS solar_system_name,
+    ess.security_status AS solar_system_security,
+    est.name AS ship_type_name,
+    va.name AS victim_alliance_name,
+    vc.name AS victim_character_name,
+    vco.name AS victim_corporation_name,
+    vf.name AS victim_faction_name,
+    emp.average_price AS ship_price
+FROM
+    eve_killmails ek
+    JOIN eve_solar_systems ess ON ess.id = ek.solar_system_id
+    JOIN eve_types est ON est.id = ek.victim_ship_type_id
+    LEFT JOIN eve_entities va ON va.id = ek.victim_alliance_id
+    LEFT JOIN eve_entities vc ON vc.id = ek.victim_character_id
+    LEFT JOIN eve_entities vco ON vco.id = ek.victim_corporation_id
+    LEFT JOIN eve_entities vf ON vf.id = ek.victim_faction_id
+    LEFT JOIN eve_market_prices emp ON emp.type_id = ek.victim_ship_type_id
+WHERE
+    ek.id IN (sqlc.slice('ids'))
+ORDER BY
+    ek.time DESC;
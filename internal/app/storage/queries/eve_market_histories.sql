@@ -0,0 +1,31 @@
+-- name: CreateEveMarketHistory :exec
+INSERT INTO
+    eve_market_histories (
+        region_id,
+        type_id,
+        date,
+        average,
+        highest,
+        lowest,
+        order_count,
+        volume
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: DeleteEveMarketHistory :exec
+DELETE FROM eve_market_histories
+WHERE
+    region_id = ?
+    AND type_id = ?;
+
+-- name: ListEveMarketHistory :many
+SELECT
+    *
+FROM
+    eve_market_histories
+WHERE
+    region_id = ?
+    AND type_id = ?
+ORDER BY
+    date;
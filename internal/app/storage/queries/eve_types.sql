@@ -41,6 +41,18 @@ FROM
     JOIN eve_groups eg ON eg.id = et.eve_group_id
     JOIN eve_categories ec ON ec.id = eg.eve_category_id;
 
+-- name: ListEveTypesForNames :many
+SELECT
+    sqlc.embed(et),
+    sqlc.embed(eg),
+    sqlc.embed(ec)
+FROM
+    eve_types et
+    JOIN eve_groups eg ON eg.id = et.eve_group_id
+    JOIN eve_categories ec ON ec.id = eg.eve_category_id
+WHERE
+    et.name IN (sqlc.slice('names'));
+
 -- name: ListEveSkills :many
 SELECT
     sqlc.embed(et),
@@ -54,6 +66,19 @@ WHERE
     ec.id = 16
     AND et.is_published = true;
 
+-- name: ListEveTypesForCategory :many
+SELECT
+    sqlc.embed(et),
+    sqlc.embed(eg),
+    sqlc.embed(ec)
+FROM
+    eve_types et
+    JOIN eve_groups eg ON eg.id = et.eve_group_id
+    JOIN eve_categories ec ON ec.id = eg.eve_category_id
+WHERE
+    ec.id = ?
+    AND et.is_published = true;
+
 -- name: ListEveTypeIDs :many
 SELECT
     id
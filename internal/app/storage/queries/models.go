@@ -18,22 +18,25 @@ type Cache struct {
 }
 
 type Character struct {
-	ID                 int64
-	AssetValue         sql.NullFloat64
-	HomeID             sql.NullInt64
-	LastLoginAt        sql.NullTime
-	LocationID         sql.NullInt64
-	ShipID             sql.NullInt64
-	TotalSp            sql.NullInt64
-	UnallocatedSp      sql.NullInt64
-	WalletBalance      sql.NullFloat64
-	IsTrainingWatched  bool
-	LastCloneJumpAt    sql.NullTime
-	ContractsEscrow    sql.NullFloat64
-	ContractItemsValue sql.NullFloat64
-	OrdersEscrow       sql.NullFloat64
-	OrderItemsValue    sql.NullFloat64
-	SkillPointsValue   sql.NullFloat64
+	ID                   int64
+	AssetValue           sql.NullFloat64
+	HomeID               sql.NullInt64
+	LastLoginAt          sql.NullTime
+	LocationID           sql.NullInt64
+	ShipID               sql.NullInt64
+	TotalSp              sql.NullInt64
+	UnallocatedSp        sql.NullInt64
+	WalletBalance        sql.NullFloat64
+	IsTrainingWatched    bool
+	LastCloneJumpAt      sql.NullTime
+	ContractsEscrow      sql.NullFloat64
+	ContractItemsValue   sql.NullFloat64
+	OrdersEscrow         sql.NullFloat64
+	OrderItemsValue      sql.NullFloat64
+	SkillPointsValue     sql.NullFloat64
+	JumpFatigueExpiresAt sql.NullTime
+	LastJumpAt           sql.NullTime
+	IsJumpFatigueWatched bool
 }
 
 type CharacterAsset struct {
@@ -62,6 +65,42 @@ type CharacterAttribute struct {
 	Willpower     int64
 }
 
+type CharacterBlueprint struct {
+	ID                 int64
+	CharacterID        int64
+	EveTypeID          int64
+	ItemID             int64
+	LocationFlag       string
+	LocationID         int64
+	MaterialEfficiency int64
+	Quantity           int64
+	Runs               int64
+	TimeEfficiency     int64
+}
+
+type CharacterCalendarEventAttendee struct {
+	ID              int64
+	CalendarEventID int64
+	EveEntityID     int64
+	Response        string
+}
+
+type CharacterCalendarEvent struct {
+	ID           int64
+	CharacterID  int64
+	Date         time.Time
+	Duration     int64
+	EventID      int64
+	Importance   int64
+	LastNotified sql.NullTime
+	OwnerID      int64
+	OwnerName    string
+	OwnerType    string
+	Response     string
+	Text         string
+	Title        string
+}
+
 type CharacterContact struct {
 	ID          int64
 	CharacterID int64
@@ -133,6 +172,23 @@ type CharacterContractItem struct {
 	TypeID      int64
 }
 
+type CharacterFitting struct {
+	ID          int64
+	CharacterID int64
+	Description string
+	FittingID   int64
+	Name        string
+	ShipTypeID  int64
+}
+
+type CharacterFittingItem struct {
+	ID                 int64
+	CharacterFittingID int64
+	Flag               string
+	Quantity           int64
+	TypeID             int64
+}
+
 type CharacterImplant struct {
 	ID          int64
 	CharacterID int64
@@ -180,6 +236,12 @@ type CharacterJumpCloneImplant struct {
 	EveTypeID int64
 }
 
+type CharacterKillmail struct {
+	ID          int64
+	CharacterID int64
+	KillmailID  int64
+}
+
 type CharacterLoyaltyPointEntry struct {
 	ID            int64
 	CharacterID   int64
@@ -248,6 +310,15 @@ type CharacterMarketOrder struct {
 	VolumeTotal   int64
 }
 
+type CharacterMiningLedgerEntry struct {
+	ID               int64
+	CharacterID      int64
+	Date             time.Time
+	EveSolarSystemID int64
+	EveTypeID        int64
+	Quantity         int64
+}
+
 type CharacterNotification struct {
 	ID             int64
 	Body           sql.NullString
@@ -299,6 +370,20 @@ type CharacterSkill struct {
 	TrainedSkillLevel  int64
 }
 
+type CharacterSkillPlan struct {
+	ID          int64
+	CharacterID int64
+	Name        string
+}
+
+type CharacterSkillPlanItem struct {
+	ID                   int64
+	CharacterSkillPlanID int64
+	Level                int64
+	Position             int64
+	TypeID               int64
+}
+
 type CharacterSkillqueueItem struct {
 	ID              int64
 	CharacterID     int64
@@ -312,6 +397,14 @@ type CharacterSkillqueueItem struct {
 	TrainingStartSp sql.NullInt64
 }
 
+type CharacterStanding struct {
+	ID          int64
+	CharacterID int64
+	FromID      int64
+	FromType    string
+	Standing    float64
+}
+
 type CharacterTag struct {
 	ID   int64
 	Name string
@@ -365,6 +458,16 @@ type CharacterWalletTransaction struct {
 	UnitPrice     float64
 }
 
+type CharacterWealthSnapshot struct {
+	ID              int64
+	CharacterID     int64
+	Day             time.Time
+	AssetsValue     float64
+	ContractsEscrow float64
+	OrdersEscrow    float64
+	WalletBalance   float64
+}
+
 type CharactersCharacterTag struct {
 	CharacterID int64
 	TagID       int64
@@ -389,6 +492,19 @@ type CorporationAsset struct {
 	Quantity        int64
 }
 
+type CorporationBlueprint struct {
+	ID                 int64
+	CorporationID      int64
+	EveTypeID          int64
+	ItemID             int64
+	LocationFlag       string
+	LocationID         int64
+	MaterialEfficiency int64
+	Quantity           int64
+	Runs               int64
+	TimeEfficiency     int64
+}
+
 type CorporationContract struct {
 	ID                  int64
 	AcceptorID          sql.NullInt64
@@ -472,10 +588,66 @@ type CorporationIndustryJob struct {
 	SuccessfulRuns       sql.NullInt64
 }
 
+type CorporationKillmail struct {
+	ID            int64
+	CorporationID int64
+	KillmailID    int64
+}
+
 type CorporationMember struct {
 	ID            int64
 	CorporationID int64
 	CharacterID   int64
+	BaseID        sql.NullInt64
+	LocationID    sql.NullInt64
+	LogoffDate    sql.NullTime
+	LogonDate     sql.NullTime
+	ShipTypeID    sql.NullInt64
+	StartDate     sql.NullTime
+}
+
+type CorporationMemberRole struct {
+	ID            int64
+	CorporationID int64
+	CharacterID   int64
+	Name          string
+	Location      string
+}
+
+type CorporationMemberTitle struct {
+	ID            int64
+	CorporationID int64
+	CharacterID   int64
+	TitleID       int64
+}
+
+type CorporationMiningObserver struct {
+	ID            int64
+	CorporationID int64
+	LastUpdated   time.Time
+	ObserverID    int64
+	ObserverType  string
+}
+
+type CorporationMiningObserverEntry struct {
+	ID                    int64
+	CharacterID           int64
+	CorporationID         int64
+	EveTypeID             int64
+	LastUpdated           time.Time
+	ObserverID            int64
+	Quantity              int64
+	RecordedCorporationID int64
+}
+
+type CorporationRoleChange struct {
+	ID            int64
+	CorporationID int64
+	CharacterID   int64
+	ChangedAt     time.Time
+	IsGranted     bool
+	Name          string
+	Location      string
 }
 
 type CorporationSectionStatus struct {
@@ -516,6 +688,13 @@ type CorporationStructureService struct {
 	State                  string
 }
 
+type CorporationTitle struct {
+	ID            int64
+	CorporationID int64
+	TitleID       int64
+	Name          string
+}
+
 type CorporationWalletBalance struct {
 	ID            int64
 	CorporationID int64
@@ -549,6 +728,14 @@ type CorporationWalletName struct {
 	Name          string
 }
 
+type CorporationWalletSnapshot struct {
+	ID            int64
+	CorporationID int64
+	DivisionID    int64
+	Day           time.Time
+	Balance       float64
+}
+
 type CorporationWalletTransaction struct {
 	ID            int64
 	CorporationID int64
@@ -662,6 +849,45 @@ type EveGroup struct {
 	IsPublished   bool
 }
 
+type EveKillmail struct {
+	ID                  int64
+	Hash                string
+	MoonID              sql.NullInt64
+	SolarSystemID       int64
+	Time                time.Time
+	VictimAllianceID    sql.NullInt64
+	VictimCharacterID   sql.NullInt64
+	VictimCorporationID sql.NullInt64
+	VictimDamageTaken   int64
+	VictimFactionID     sql.NullInt64
+	VictimShipTypeID    int64
+	WarID               sql.NullInt64
+}
+
+type EveKillmailAttacker struct {
+	ID             int64
+	KillmailID     int64
+	AllianceID     sql.NullInt64
+	CharacterID    sql.NullInt64
+	CorporationID  sql.NullInt64
+	DamageDone     int64
+	FactionID      sql.NullInt64
+	IsFinalBlow    bool
+	SecurityStatus float64
+	ShipTypeID     sql.NullInt64
+	WeaponTypeID   sql.NullInt64
+}
+
+type EveKillmailItem struct {
+	ID                int64
+	KillmailID        int64
+	Flag              int64
+	IsSingleton       bool
+	QuantityDestroyed int64
+	QuantityDropped   int64
+	TypeID            int64
+}
+
 type EveLocation struct {
 	ID               int64
 	EveSolarSystemID sql.NullInt64
@@ -671,6 +897,18 @@ type EveLocation struct {
 	UpdatedAt        time.Time
 }
 
+type EveMarketHistory struct {
+	ID         int64
+	RegionID   int64
+	TypeID     int64
+	Date       time.Time
+	Average    float64
+	Highest    float64
+	Lowest     float64
+	OrderCount int64
+	Volume     int64
+}
+
 type EveMarketPrice struct {
 	TypeID        int64
 	AdjustedPrice float64
@@ -782,9 +1020,18 @@ type PlanetPin struct {
 	PinID                  int64
 	SchematicID            sql.NullInt64
 	TypeID                 int64
+	ExtractorCycleTime     sql.NullInt64
+	ExtractorQtyPerCycle   sql.NullInt64
 }
 
 type Scope struct {
 	ID   int64
 	Name string
 }
+
+type TokenEncryption struct {
+	ID        int64
+	KeyCheck  string
+	KeySalt   []byte
+	KeySource string
+}
@@ -2,7 +2,9 @@
 INSERT INTO
     planet_pins (
         character_planet_id,
+        extractor_cycle_time,
         extractor_product_type_id,
+        extractor_qty_per_cycle,
         factory_schema_id,
         schematic_id,
         type_id,
@@ -12,7 +14,7 @@ INSERT INTO
         pin_id
     )
 VALUES
-    (?, ?, ?, ?, ?, ?, ?, ?, ?);
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: DeletePlanetPins :exec
 DELETE FROM
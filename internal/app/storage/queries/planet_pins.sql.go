@@ -14,7 +14,9 @@ const createPlanetPin = `-- name: CreatePlanetPin :exec
 INSERT INTO
     planet_pins (
         character_planet_id,
+        extractor_cycle_time,
         extractor_product_type_id,
+        extractor_qty_per_cycle,
         factory_schema_id,
         schematic_id,
         type_id,
@@ -24,12 +26,14 @@ INSERT INTO
         pin_id
     )
 VALUES
-    (?, ?, ?, ?, ?, ?, ?, ?, ?)
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreatePlanetPinParams struct {
 	CharacterPlanetID      int64
+	ExtractorCycleTime     sql.NullInt64
 	ExtractorProductTypeID sql.NullInt64
+	ExtractorQtyPerCycle   sql.NullInt64
 	FactorySchemaID        sql.NullInt64
 	SchematicID            sql.NullInt64
 	TypeID                 int64
@@ -42,7 +46,9 @@ type CreatePlanetPinParams struct {
 func (q *Queries) CreatePlanetPin(ctx context.Context, arg CreatePlanetPinParams) error {
 	_, err := q.db.ExecContext(ctx, createPlanetPin,
 		arg.CharacterPlanetID,
+		arg.ExtractorCycleTime,
 		arg.ExtractorProductTypeID,
+		arg.ExtractorQtyPerCycle,
 		arg.FactorySchemaID,
 		arg.SchematicID,
 		arg.TypeID,
@@ -68,7 +74,7 @@ func (q *Queries) DeletePlanetPins(ctx context.Context, characterPlanetID int64)
 
 const getPlanetPin = `-- name: GetPlanetPin :one
 SELECT
-    pp.id, pp.character_planet_id, pp.expiry_time, pp.extractor_product_type_id, pp.factory_schema_id, pp.install_time, pp.last_cycle_start, pp.pin_id, pp.schematic_id, pp.type_id,
+    pp.id, pp.character_planet_id, pp.expiry_time, pp.extractor_product_type_id, pp.factory_schema_id, pp.install_time, pp.last_cycle_start, pp.pin_id, pp.schematic_id, pp.type_id, pp.extractor_cycle_time, pp.extractor_qty_per_cycle,
     et.id, et.eve_group_id, et.capacity, et.description, et.graphic_id, et.icon_id, et.is_published, et.market_group_id, et.mass, et.name, et.packaged_volume, et.portion_size, et.radius, et.volume,
     eg.id, eg.eve_category_id, eg.name, eg.is_published,
     ec.id, ec.name, ec.is_published,
@@ -118,6 +124,8 @@ func (q *Queries) GetPlanetPin(ctx context.Context, arg GetPlanetPinParams) (Get
 		&i.PlanetPin.PinID,
 		&i.PlanetPin.SchematicID,
 		&i.PlanetPin.TypeID,
+		&i.PlanetPin.ExtractorCycleTime,
+		&i.PlanetPin.ExtractorQtyPerCycle,
 		&i.EveType.ID,
 		&i.EveType.EveGroupID,
 		&i.EveType.Capacity,
@@ -149,7 +157,7 @@ func (q *Queries) GetPlanetPin(ctx context.Context, arg GetPlanetPinParams) (Get
 
 const listPlanetPins = `-- name: ListPlanetPins :many
 SELECT
-    pp.id, pp.character_planet_id, pp.expiry_time, pp.extractor_product_type_id, pp.factory_schema_id, pp.install_time, pp.last_cycle_start, pp.pin_id, pp.schematic_id, pp.type_id,
+    pp.id, pp.character_planet_id, pp.expiry_time, pp.extractor_product_type_id, pp.factory_schema_id, pp.install_time, pp.last_cycle_start, pp.pin_id, pp.schematic_id, pp.type_id, pp.extractor_cycle_time, pp.extractor_qty_per_cycle,
     et.id, et.eve_group_id, et.capacity, et.description, et.graphic_id, et.icon_id, et.is_published, et.market_group_id, et.mass, et.name, et.packaged_volume, et.portion_size, et.radius, et.volume,
     eg.id, eg.eve_category_id, eg.name, eg.is_published,
     ec.id, ec.name, ec.is_published,
@@ -199,6 +207,8 @@ func (q *Queries) ListPlanetPins(ctx context.Context, characterPlanetID int64) (
 			&i.PlanetPin.PinID,
 			&i.PlanetPin.SchematicID,
 			&i.PlanetPin.TypeID,
+			&i.PlanetPin.ExtractorCycleTime,
+			&i.PlanetPin.ExtractorQtyPerCycle,
 			&i.EveType.ID,
 			&i.EveType.EveGroupID,
 			&i.EveType.Capacity,
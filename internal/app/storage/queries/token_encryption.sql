@@ -0,0 +1,21 @@
+-- name: DeleteTokenEncryption :exec
+DELETE FROM token_encryption;
+
+-- name: GetTokenEncryption :one
+SELECT
+    *
+FROM
+    token_encryption
+WHERE
+    id = 1;
+
+-- name: UpdateOrCreateTokenEncryption :exec
+INSERT INTO
+    token_encryption (id, key_check, key_salt, key_source)
+VALUES
+    (1, ?1, ?2, ?3)
+ON CONFLICT (id) DO UPDATE
+SET
+    key_check = ?1,
+    key_salt = ?2,
+    key_source = ?3;
@@ -0,0 +1,246 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+)
+
+// The full-text search index is implemented with FTS5 virtual tables,
+// which are not supported by sqlc. Therefore all queries are written by hand.
+//
+// The rowid of an index entry is always the ID of the indexed mail or notification.
+// Entries are removed by triggers when the source object is deleted or changed
+// and need to be re-added with the Update methods.
+
+// SearchIndexItem represents an object to be added to the full-text search index.
+type SearchIndexItem struct {
+	ID    int64 // ID of the source object
+	Title string
+	Body  string
+}
+
+const listCharacterMailsNotIndexedSQL = `
+SELECT cm.id, cm.subject, cm.body
+FROM character_mails cm
+LEFT JOIN character_mails_fts fts ON fts.rowid = cm.id
+WHERE cm.character_id = ?
+AND fts.rowid IS NULL
+ORDER BY cm.id
+`
+
+// ListCharacterMailsNotIndexed returns the mails of a character,
+// which are not yet in the full-text search index.
+func (st *Storage) ListCharacterMailsNotIndexed(ctx context.Context, characterID int64) ([]SearchIndexItem, error) {
+	items, err := st.listSearchIndexItems(ctx, listCharacterMailsNotIndexedSQL, characterID)
+	if err != nil {
+		return nil, fmt.Errorf("ListCharacterMailsNotIndexed: %d: %w", characterID, err)
+	}
+	return items, nil
+}
+
+const listCharacterNotificationsNotIndexedSQL = `
+SELECT cn.id, cn.title, IFNULL(cn.body, '')
+FROM character_notifications cn
+LEFT JOIN character_notifications_fts fts ON fts.rowid = cn.id
+WHERE cn.character_id = ?
+AND cn.title IS NOT NULL
+AND fts.rowid IS NULL
+ORDER BY cn.id
+`
+
+// ListCharacterNotificationsNotIndexed returns the rendered notifications of a character,
+// which are not yet in the full-text search index.
+func (st *Storage) ListCharacterNotificationsNotIndexed(ctx context.Context, characterID int64) ([]SearchIndexItem, error) {
+	items, err := st.listSearchIndexItems(ctx, listCharacterNotificationsNotIndexedSQL, characterID)
+	if err != nil {
+		return nil, fmt.Errorf("ListCharacterNotificationsNotIndexed: %d: %w", characterID, err)
+	}
+	return items, nil
+}
+
+func (st *Storage) listSearchIndexItems(ctx context.Context, query string, characterID int64) ([]SearchIndexItem, error) {
+	if characterID == 0 {
+		return nil, app.ErrInvalid
+	}
+	rows, err := st.dbRO.QueryContext(ctx, query, characterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchIndexItem
+	for rows.Next() {
+		var i SearchIndexItem
+		if err := rows.Scan(&i.ID, &i.Title, &i.Body); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// UpdateMailSearchIndex adds or replaces mails in the full-text search index.
+func (st *Storage) UpdateMailSearchIndex(ctx context.Context, items []SearchIndexItem) error {
+	if err := st.updateSearchIndex(ctx, "character_mails_fts", "subject", items); err != nil {
+		return fmt.Errorf("UpdateMailSearchIndex: %w", err)
+	}
+	return nil
+}
+
+// UpdateNotificationSearchIndex adds or replaces notifications in the full-text search index.
+func (st *Storage) UpdateNotificationSearchIndex(ctx context.Context, items []SearchIndexItem) error {
+	if err := st.updateSearchIndex(ctx, "character_notifications_fts", "title", items); err != nil {
+		return fmt.Errorf("UpdateNotificationSearchIndex: %w", err)
+	}
+	return nil
+}
+
+func (st *Storage) updateSearchIndex(ctx context.Context, table, titleColumn string, items []SearchIndexItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	tx, err := st.dbRW.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	deleteSQL := fmt.Sprintf("DELETE FROM %s WHERE rowid = ?", table)
+	insertSQL := fmt.Sprintf("INSERT INTO %s (rowid, %s, body) VALUES (?, ?, ?)", table, titleColumn)
+	for _, it := range items {
+		if it.ID == 0 {
+			return app.ErrInvalid
+		}
+		if _, err := tx.ExecContext(ctx, deleteSQL, it.ID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, insertSQL, it.ID, it.Title, it.Body); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+const searchCharacterMailsSQL = `
+SELECT
+	cm.character_id,
+	cm.mail_id,
+	cm.subject,
+	cm.timestamp,
+	ee.id,
+	ee.category,
+	ee.name,
+	snippet(character_mails_fts, -1, '', '', '…', 16)
+FROM character_mails_fts fts
+JOIN character_mails cm ON cm.id = fts.rowid
+JOIN eve_entities ee ON ee.id = cm.from_id
+WHERE character_mails_fts MATCH ?
+ORDER BY cm.timestamp DESC, cm.id DESC
+LIMIT ?
+`
+
+// SearchCharacterMails returns the mails of all characters matching a search query.
+// The newest matches are returned first.
+func (st *Storage) SearchCharacterMails(ctx context.Context, query string, limit int) ([]*app.TextSearchHit, error) {
+	hits, err := st.searchIndex(ctx, searchCharacterMailsSQL, app.TextSearchHitMail, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("SearchCharacterMails: %q: %w", query, err)
+	}
+	return hits, nil
+}
+
+const searchCharacterNotificationsSQL = `
+SELECT
+	cn.character_id,
+	cn.notification_id,
+	cn.title,
+	cn.timestamp,
+	ee.id,
+	ee.category,
+	ee.name,
+	snippet(character_notifications_fts, -1, '', '', '…', 16)
+FROM character_notifications_fts fts
+JOIN character_notifications cn ON cn.id = fts.rowid
+JOIN eve_entities ee ON ee.id = cn.sender_id
+WHERE character_notifications_fts MATCH ?
+ORDER BY cn.timestamp DESC, cn.id DESC
+LIMIT ?
+`
+
+// SearchCharacterNotifications returns the notifications of all characters matching a search query.
+// The newest matches are returned first.
+func (st *Storage) SearchCharacterNotifications(ctx context.Context, query string, limit int) ([]*app.TextSearchHit, error) {
+	hits, err := st.searchIndex(ctx, searchCharacterNotificationsSQL, app.TextSearchHitNotification, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("SearchCharacterNotifications: %q: %w", query, err)
+	}
+	return hits, nil
+}
+
+func (st *Storage) searchIndex(ctx context.Context, sqlQuery string, kind app.TextSearchHitKind, query string, limit int) ([]*app.TextSearchHit, error) {
+	match := makeFTSMatchExpression(query)
+	if match == "" {
+		return []*app.TextSearchHit{}, nil
+	}
+	rows, err := st.dbRO.QueryContext(ctx, sqlQuery, match, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	hits := make([]*app.TextSearchHit, 0)
+	for rows.Next() {
+		var (
+			characterID, id, senderID int64
+			title, category, name     string
+			snippet                   sql.NullString
+			timestamp                 time.Time
+		)
+		if err := rows.Scan(&characterID, &id, &title, &timestamp, &senderID, &category, &name, &snippet); err != nil {
+			return nil, err
+		}
+		hits = append(hits, &app.TextSearchHit{
+			CharacterID: characterID,
+			ID:          id,
+			Kind:        kind,
+			Sender: &app.EveEntity{
+				Category: eveEntityCategoryFromDBModel(category),
+				ID:       senderID,
+				Name:     name,
+			},
+			Snippet:   strings.Join(strings.Fields(snippet.String), " "),
+			Timestamp: timestamp,
+			Title:     title,
+		})
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+// makeFTSMatchExpression returns a FTS5 match expression for a user query.
+// Each word of the query is matched as prefix and all words must match.
+// Special characters are removed, so that users can not create invalid expressions.
+func makeFTSMatchExpression(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	parts := make([]string, len(words))
+	for i, w := range words {
+		parts[i] = fmt.Sprintf(`"%s"*`, w)
+	}
+	return strings.Join(parts, " ")
+}
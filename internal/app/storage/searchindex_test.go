@@ -0,0 +1,184 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func TestSearchIndexMails(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	ctx := context.Background()
+	t.Run("can list mails not yet indexed", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		m1 := factory.CreateCharacterMail(storage.CreateCharacterMailParams{CharacterID: c.ID})
+		m2 := factory.CreateCharacterMail(storage.CreateCharacterMailParams{CharacterID: c.ID})
+		err := st.UpdateMailSearchIndex(ctx, []storage.SearchIndexItem{{ID: m1.ID, Title: "alpha"}})
+		require.NoError(t, err)
+		// when
+		got, err := st.ListCharacterMailsNotIndexed(ctx, c.ID)
+		// then
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []int64{m2.ID}, xslices.Map(got, func(x storage.SearchIndexItem) int64 {
+			return x.ID
+		}))
+	})
+	t.Run("can search mails of all characters", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		m1 := factory.CreateCharacterMail()
+		m2 := factory.CreateCharacterMail()
+		factory.CreateCharacterMail()
+		err := st.UpdateMailSearchIndex(ctx, []storage.SearchIndexItem{
+			{ID: m1.ID, Title: "Fleet tonight", Body: "We form up in Jita"},
+			{ID: m2.ID, Title: "Contract", Body: "Please deliver the fleet hangar"},
+		})
+		require.NoError(t, err)
+		// when
+		got, err := st.SearchCharacterMails(ctx, "flee", 10)
+		// then
+		require.NoError(t, err)
+		if assert.Len(t, got, 2) {
+			for _, h := range got {
+				assert.Equal(t, app.TextSearchHitMail, h.Kind)
+				assert.NotEmpty(t, h.Snippet)
+				assert.NotNil(t, h.Sender)
+			}
+			assert.ElementsMatch(t, []int64{m1.MailID, m2.MailID}, xslices.Map(got, func(x *app.TextSearchHit) int64 {
+				return x.ID
+			}))
+		}
+	})
+	t.Run("should return newest matches first when limited", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		now := time.Now().UTC()
+		m1 := factory.CreateCharacterMail(storage.CreateCharacterMailParams{Timestamp: now.Add(-2 * time.Hour)})
+		m2 := factory.CreateCharacterMail(storage.CreateCharacterMailParams{Timestamp: now.Add(-1 * time.Hour)})
+		m3 := factory.CreateCharacterMail(storage.CreateCharacterMailParams{Timestamp: now.Add(-3 * time.Hour)})
+		err := st.UpdateMailSearchIndex(ctx, []storage.SearchIndexItem{
+			{ID: m1.ID, Title: "Fleet fleet fleet"},
+			{ID: m2.ID, Title: "Contract", Body: "Please deliver the fleet hangar and some other stuff"},
+			{ID: m3.ID, Title: "Fleet"},
+		})
+		require.NoError(t, err)
+		// when
+		got, err := st.SearchCharacterMails(ctx, "fleet", 2)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []int64{m2.MailID, m1.MailID}, xslices.Map(got, func(x *app.TextSearchHit) int64 {
+			return x.ID
+		}))
+	})
+	t.Run("should require all words to match", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		m1 := factory.CreateCharacterMail()
+		m2 := factory.CreateCharacterMail()
+		err := st.UpdateMailSearchIndex(ctx, []storage.SearchIndexItem{
+			{ID: m1.ID, Title: "Fleet tonight", Body: "We form up in Jita"},
+			{ID: m2.ID, Title: "Contract", Body: "Please deliver the fleet hangar"},
+		})
+		require.NoError(t, err)
+		// when
+		got, err := st.SearchCharacterMails(ctx, "fleet jita", 10)
+		// then
+		require.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, m1.MailID, got[0].ID)
+			assert.Equal(t, m1.CharacterID, got[0].CharacterID)
+		}
+	})
+	t.Run("should remove index entry when mail body is updated", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		m := factory.CreateCharacterMail()
+		err := st.UpdateMailSearchIndex(ctx, []storage.SearchIndexItem{{ID: m.ID, Title: "alpha"}})
+		require.NoError(t, err)
+		// when
+		err = st.UpdateCharacterMailSetBody(ctx, m.CharacterID, m.MailID, optional.New("bravo"))
+		// then
+		require.NoError(t, err)
+		got, err := st.ListCharacterMailsNotIndexed(ctx, m.CharacterID)
+		require.NoError(t, err)
+		assert.Len(t, got, 1)
+	})
+	t.Run("should ignore special characters in query", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		m := factory.CreateCharacterMail()
+		err := st.UpdateMailSearchIndex(ctx, []storage.SearchIndexItem{{ID: m.ID, Title: "alpha"}})
+		require.NoError(t, err)
+		// when
+		got, err := st.SearchCharacterMails(ctx, `"alpha" OR (NEAR`, 10)
+		// then
+		require.NoError(t, err)
+		assert.Len(t, got, 0)
+	})
+	t.Run("should return empty result for empty query", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		// when
+		got, err := st.SearchCharacterMails(ctx, "  ", 10)
+		// then
+		require.NoError(t, err)
+		assert.Len(t, got, 0)
+	})
+}
+
+func TestSearchIndexNotifications(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	ctx := context.Background()
+	t.Run("can list rendered notifications not yet indexed", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacter()
+		n1 := factory.CreateCharacterNotification(storage.CreateCharacterNotificationParams{
+			CharacterID: c.ID,
+			Title:       optional.New("title"),
+			Body:        optional.New("body"),
+		})
+		factory.CreateCharacterNotification(storage.CreateCharacterNotificationParams{
+			CharacterID: c.ID,
+		})
+		// when
+		got, err := st.ListCharacterNotificationsNotIndexed(ctx, c.ID)
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []storage.SearchIndexItem{{ID: n1.ID, Title: "title", Body: "body"}}, got)
+	})
+	t.Run("can search notifications", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		n1 := factory.CreateCharacterNotification()
+		n2 := factory.CreateCharacterNotification()
+		err := st.UpdateNotificationSearchIndex(ctx, []storage.SearchIndexItem{
+			{ID: n1.ID, Title: "Structure under attack", Body: "Your Astrahus is under attack"},
+			{ID: n2.ID, Title: "Bounty claimed", Body: "A bounty has been paid"},
+		})
+		require.NoError(t, err)
+		// when
+		got, err := st.SearchCharacterNotifications(ctx, "astrahus", 10)
+		// then
+		require.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, app.TextSearchHitNotification, got[0].Kind)
+			assert.Equal(t, n1.NotificationID, got[0].ID)
+			assert.Equal(t, "Structure under attack", got[0].Title)
+			assert.Equal(t, n1.Sender.ID, got[0].Sender.ID)
+		}
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"net/url"
 	"slices"
+	"sync/atomic"
 
 	_ "github.com/mattn/go-sqlite3" // required for this package to work in tests
 
@@ -25,10 +26,11 @@ var embedMigrations embed.FS
 type Storage struct {
 	MaxListEveEntitiesForIDs int // Max IDs per SQL query
 
-	dbRO *sql.DB
-	dbRW *sql.DB
-	qRO  *queries.Queries
-	qRW  *queries.Queries
+	dbRO        *sql.DB
+	dbRW        *sql.DB
+	qRO         *queries.Queries
+	qRW         *queries.Queries
+	tokenCipher atomic.Pointer[TokenCipher] // encrypts secrets of character tokens when set
 }
 
 // New returns a new storage object.
@@ -139,6 +141,20 @@ func ApplyMigrations(db *sql.DB) error {
 	return migrate.Run(db, embedMigrations)
 }
 
+// CheckMigrations returns an error when the database has migrations applied
+// which are unknown to this version of the app,
+// e.g. because it was created by a newer version.
+func CheckMigrations(db *sql.DB) error {
+	unknown, err := migrate.Unknown(db, embedMigrations)
+	if err != nil {
+		return err
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("database was created by a newer version of the app: unknown migrations %v: %w", unknown, app.ErrInvalid)
+	}
+	return nil
+}
+
 func sqliteDSN(dsn string, isReadonly bool) string {
 	v := url.Values{}
 	v.Add("_fk", "on")
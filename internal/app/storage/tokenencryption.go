@@ -0,0 +1,246 @@
+package storage
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage/queries"
+)
+
+// TokenKeySize is the size of keys for a [TokenCipher] in bytes.
+const TokenKeySize = 32
+
+// Encrypted values are stored with a prefix,
+// so they can be distinguished from values stored before encryption was introduced.
+const tokenCipherPrefix = "enc:v1:"
+
+// TokenCipher encrypts and decrypts the secrets of character tokens with AES-GCM.
+type TokenCipher struct {
+	aead cipher.AEAD
+}
+
+// NewTokenCipher returns a new cipher for a key of [TokenKeySize] bytes.
+func NewTokenCipher(key []byte) (*TokenCipher, error) {
+	if len(key) != TokenKeySize {
+		return nil, fmt.Errorf("token cipher: invalid key size %d: %w", len(key), app.ErrInvalid)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenCipher{aead: aead}, nil
+}
+
+// Encrypt returns an encrypted value for s.
+func (c *TokenCipher) Encrypt(s string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	b := c.aead.Seal(nonce, nonce, []byte(s), nil)
+	return tokenCipherPrefix + base64.RawStdEncoding.EncodeToString(b), nil
+}
+
+// Decrypt returns the decrypted value for s.
+// It returns [app.ErrTokenKeyUnavailable] when s was encrypted with a different key.
+func (c *TokenCipher) Decrypt(s string) (string, error) {
+	if !isEncryptedToken(s) {
+		return "", fmt.Errorf("token cipher: value not encrypted: %w", app.ErrInvalid)
+	}
+	b, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(s, tokenCipherPrefix))
+	if err != nil {
+		return "", fmt.Errorf("token cipher: %w: %w", err, app.ErrInvalid)
+	}
+	n := c.aead.NonceSize()
+	if len(b) < n {
+		return "", fmt.Errorf("token cipher: value too short: %w", app.ErrInvalid)
+	}
+	plaintext, err := c.aead.Open(nil, b[:n], b[n:], nil)
+	if err != nil {
+		return "", fmt.Errorf("token cipher: wrong key: %w", app.ErrTokenKeyUnavailable)
+	}
+	return string(plaintext), nil
+}
+
+func isEncryptedToken(s string) bool {
+	return strings.HasPrefix(s, tokenCipherPrefix)
+}
+
+// SetTokenCipher sets the cipher for the secrets of character tokens.
+// Without a cipher new tokens are stored unencrypted and encrypted tokens can not be read.
+func (st *Storage) SetTokenCipher(c *TokenCipher) {
+	st.tokenCipher.Store(c)
+}
+
+// HasTokenCipher reports whether a cipher for character tokens is set.
+func (st *Storage) HasTokenCipher() bool {
+	return st.tokenCipher.Load() != nil
+}
+
+// encryptToken returns s encrypted with the current cipher.
+// Without a cipher s is returned unchanged, unless encryption has been configured,
+// which means the key is currently not available.
+func (st *Storage) encryptToken(ctx context.Context, s string) (string, error) {
+	c := st.tokenCipher.Load()
+	if c != nil {
+		return c.Encrypt(s)
+	}
+	_, err := st.qRO.GetTokenEncryption(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return s, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return "", app.ErrTokenKeyUnavailable
+}
+
+func (st *Storage) decryptToken(s string) (string, error) {
+	if !isEncryptedToken(s) {
+		return s, nil
+	}
+	c := st.tokenCipher.Load()
+	if c == nil {
+		return "", app.ErrTokenKeyUnavailable
+	}
+	return c.Decrypt(s)
+}
+
+// CountUnencryptedCharacterTokens returns the number of character tokens,
+// which have secrets stored unencrypted.
+func (st *Storage) CountUnencryptedCharacterTokens(ctx context.Context) (int, error) {
+	rows, err := st.qRO.ListCharacterTokens(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("count unencrypted character tokens: %w", err)
+	}
+	var n int
+	for _, r := range rows {
+		if !isEncryptedToken(r.AccessToken) || !isEncryptedToken(r.RefreshToken) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// TokenEncryption is the configuration for encrypting character tokens.
+type TokenEncryption struct {
+	KeyCheck  string // a known value encrypted with the key, for verifying a key
+	KeySalt   []byte // salt for deriving a key from a passphrase
+	KeySource string // where the key comes from
+}
+
+func (st *Storage) GetTokenEncryption(ctx context.Context) (*TokenEncryption, error) {
+	r, err := st.qRO.GetTokenEncryption(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get token encryption: %w", convertGetError(err))
+	}
+	o := &TokenEncryption{
+		KeyCheck:  r.KeyCheck,
+		KeySalt:   r.KeySalt,
+		KeySource: r.KeySource,
+	}
+	return o, nil
+}
+
+// UpdateTokenEncryption encrypts the secrets of all character tokens with c,
+// stores the configuration and sets c as current cipher.
+// Tokens which were encrypted before are first decrypted with the current cipher.
+// Tokens are migrated in one transaction, so either all tokens are migrated or none.
+// Returns the number of migrated tokens.
+func (st *Storage) UpdateTokenEncryption(ctx context.Context, c *TokenCipher, arg TokenEncryption) (int, error) {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("update token encryption: %w", err)
+	}
+	if c == nil || arg.KeySource == "" {
+		return 0, wrapErr(app.ErrInvalid)
+	}
+	tx, err := st.dbRW.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, wrapErr(err)
+	}
+	defer tx.Rollback()
+	qtx := st.qRW.WithTx(tx)
+	rows, err := qtx.ListCharacterTokens(ctx)
+	if err != nil {
+		return 0, wrapErr(err)
+	}
+	for _, r := range rows {
+		secrets := [2]string{r.AccessToken, r.RefreshToken}
+		for i, s := range secrets {
+			s, err := st.decryptToken(s)
+			if err != nil {
+				return 0, wrapErr(fmt.Errorf("character %d: %w", r.CharacterID, err))
+			}
+			s, err = c.Encrypt(s)
+			if err != nil {
+				return 0, wrapErr(err)
+			}
+			secrets[i] = s
+		}
+		err := qtx.UpdateCharacterTokenSecrets(ctx, queries.UpdateCharacterTokenSecretsParams{
+			AccessToken:  secrets[0],
+			ID:           r.ID,
+			RefreshToken: secrets[1],
+		})
+		if err != nil {
+			return 0, wrapErr(err)
+		}
+	}
+	salt := arg.KeySalt
+	if salt == nil {
+		salt = []byte{}
+	}
+	err = qtx.UpdateOrCreateTokenEncryption(ctx, queries.UpdateOrCreateTokenEncryptionParams{
+		KeyCheck:  arg.KeyCheck,
+		KeySalt:   salt,
+		KeySource: arg.KeySource,
+	})
+	if err != nil {
+		return 0, wrapErr(err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, wrapErr(err)
+	}
+	st.tokenCipher.Store(c)
+	return len(rows), nil
+}
+
+// ResetTokenEncryption deletes all character tokens and the encryption configuration
+// and removes the current cipher.
+//
+// This allows users to start over when the key has been lost.
+// Characters need to be authorized again afterwards.
+func (st *Storage) ResetTokenEncryption(ctx context.Context) error {
+	wrapErr := func(err error) error {
+		return fmt.Errorf("reset token encryption: %w", err)
+	}
+	tx, err := st.dbRW.BeginTx(ctx, nil)
+	if err != nil {
+		return wrapErr(err)
+	}
+	defer tx.Rollback()
+	qtx := st.qRW.WithTx(tx)
+	if err := qtx.DeleteCharacterTokens(ctx); err != nil {
+		return wrapErr(err)
+	}
+	if err := qtx.DeleteTokenEncryption(ctx); err != nil {
+		return wrapErr(err)
+	}
+	if err := tx.Commit(); err != nil {
+		return wrapErr(err)
+	}
+	st.tokenCipher.Store(nil)
+	return nil
+}
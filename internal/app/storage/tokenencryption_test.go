@@ -0,0 +1,180 @@
+package storage_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestTokenCipher(t *testing.T) {
+	t.Run("can encrypt and decrypt", func(t *testing.T) {
+		c := newTokenCipher(t, 1)
+		s, err := c.Encrypt("alpha")
+		require.NoError(t, err)
+		assert.NotContains(t, s, "alpha")
+		got, err := c.Decrypt(s)
+		require.NoError(t, err)
+		xassert.Equal(t, "alpha", got)
+	})
+	t.Run("should report error when decrypting with wrong key", func(t *testing.T) {
+		s, err := newTokenCipher(t, 1).Encrypt("alpha")
+		require.NoError(t, err)
+		_, err = newTokenCipher(t, 2).Decrypt(s)
+		assert.ErrorIs(t, err, app.ErrTokenKeyUnavailable)
+	})
+	t.Run("should reject keys with invalid size", func(t *testing.T) {
+		_, err := storage.NewTokenCipher([]byte("short"))
+		assert.ErrorIs(t, err, app.ErrInvalid)
+	})
+}
+
+func TestTokenEncryption(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	ctx := context.Background()
+	t.Run("can encrypt existing tokens", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(nil)
+		o := factory.CreateCharacterToken()
+		c := newTokenCipher(t, 1)
+		// when
+		n, err := st.UpdateTokenEncryption(ctx, c, storage.TokenEncryption{KeyCheck: "check", KeySource: "test"})
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, 1, n)
+		assertTokenSecretsEncrypted(t, db, o.CharacterID)
+		o2, err := st.GetCharacterToken(ctx, o.CharacterID)
+		require.NoError(t, err)
+		xassert.Equal(t, o.AccessToken, o2.AccessToken)
+		xassert.Equal(t, o.RefreshToken, o2.RefreshToken)
+		x, err := st.GetTokenEncryption(ctx)
+		require.NoError(t, err)
+		xassert.Equal(t, "check", x.KeyCheck)
+		xassert.Equal(t, "test", x.KeySource)
+	})
+	t.Run("can count unencrypted tokens", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(nil)
+		factory.CreateCharacterToken()
+		// when
+		n1, err := st.CountUnencryptedCharacterTokens(ctx)
+		require.NoError(t, err)
+		_, err = st.UpdateTokenEncryption(ctx, newTokenCipher(t, 1), storage.TokenEncryption{KeyCheck: "check", KeySource: "test"})
+		require.NoError(t, err)
+		n2, err := st.CountUnencryptedCharacterTokens(ctx)
+		require.NoError(t, err)
+		// then
+		xassert.Equal(t, 1, n1)
+		xassert.Equal(t, 0, n2)
+	})
+	t.Run("can re-encrypt tokens with a new key", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(newTokenCipher(t, 1))
+		o := factory.CreateCharacterToken()
+		c := newTokenCipher(t, 2)
+		// when
+		_, err := st.UpdateTokenEncryption(ctx, c, storage.TokenEncryption{KeyCheck: "check", KeySource: "test"})
+		// then
+		require.NoError(t, err)
+		st.SetTokenCipher(newTokenCipher(t, 1))
+		_, err = st.GetCharacterToken(ctx, o.CharacterID)
+		assert.ErrorIs(t, err, app.ErrTokenKeyUnavailable)
+		st.SetTokenCipher(c)
+		o2, err := st.GetCharacterToken(ctx, o.CharacterID)
+		require.NoError(t, err)
+		xassert.Equal(t, o.RefreshToken, o2.RefreshToken)
+	})
+	t.Run("should report error when reading encrypted tokens without key", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(newTokenCipher(t, 1))
+		o := factory.CreateCharacterToken()
+		st.SetTokenCipher(nil)
+		// when
+		_, err := st.GetCharacterToken(ctx, o.CharacterID)
+		// then
+		assert.ErrorIs(t, err, app.ErrTokenKeyUnavailable)
+	})
+	t.Run("should not store unencrypted tokens when key is unavailable", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(nil)
+		c := factory.CreateCharacterFull()
+		_, err := st.UpdateTokenEncryption(ctx, newTokenCipher(t, 1), storage.TokenEncryption{KeyCheck: "check", KeySource: "test"})
+		require.NoError(t, err)
+		st.SetTokenCipher(nil)
+		// when
+		err = st.UpdateOrCreateCharacterToken(ctx, storage.UpdateOrCreateCharacterTokenParams{
+			AccessToken:  "access",
+			CharacterID:  c.ID,
+			RefreshToken: "refresh",
+		})
+		// then
+		assert.ErrorIs(t, err, app.ErrTokenKeyUnavailable)
+	})
+	t.Run("can read unencrypted tokens with key", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(nil)
+		o := factory.CreateCharacterToken()
+		st.SetTokenCipher(newTokenCipher(t, 1))
+		// when
+		o2, err := st.GetCharacterToken(ctx, o.CharacterID)
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, o.RefreshToken, o2.RefreshToken)
+	})
+	t.Run("can reset encryption", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(nil)
+		o := factory.CreateCharacterToken()
+		_, err := st.UpdateTokenEncryption(ctx, newTokenCipher(t, 1), storage.TokenEncryption{KeyCheck: "check", KeySource: "test"})
+		require.NoError(t, err)
+		// when
+		err = st.ResetTokenEncryption(ctx)
+		// then
+		require.NoError(t, err)
+		assert.False(t, st.HasTokenCipher())
+		_, err = st.GetCharacterToken(ctx, o.CharacterID)
+		assert.ErrorIs(t, err, app.ErrNotFound)
+		_, err = st.GetTokenEncryption(ctx)
+		assert.ErrorIs(t, err, app.ErrNotFound)
+	})
+	st.SetTokenCipher(nil)
+}
+
+func newTokenCipher(t *testing.T, b byte) *storage.TokenCipher {
+	t.Helper()
+	key := make([]byte, storage.TokenKeySize)
+	for i := range key {
+		key[i] = b
+	}
+	c, err := storage.NewTokenCipher(key)
+	require.NoError(t, err)
+	return c
+}
+
+// assertTokenSecretsEncrypted asserts that the secrets of a token are stored encrypted.
+func assertTokenSecretsEncrypted(t *testing.T, db *sql.DB, characterID int64) {
+	t.Helper()
+	var accessToken, refreshToken string
+	err := db.QueryRow(
+		"SELECT access_token, refresh_token FROM character_tokens WHERE character_id = ?", characterID,
+	).Scan(&accessToken, &refreshToken)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(accessToken, "enc:"), "access token not encrypted")
+	assert.True(t, strings.HasPrefix(refreshToken, "enc:"), "refresh token not encrypted")
+}
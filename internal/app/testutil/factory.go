@@ -239,6 +239,99 @@ func (f Factory) CreateCharacterAsset(args ...storage.CreateCharacterAssetParams
 	return o
 }
 
+func (f Factory) CreateCharacterBlueprint(args ...storage.CreateCharacterBlueprintParams) *app.CharacterBlueprint {
+	ctx := context.Background()
+	var arg storage.CreateCharacterBlueprintParams
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	if arg.CharacterID == 0 {
+		x := f.CreateCharacterFull()
+		arg.CharacterID = x.ID
+	}
+	if arg.TypeID == 0 {
+		x := f.CreateEveType()
+		arg.TypeID = x.ID
+	}
+	if arg.ItemID == 0 {
+		arg.ItemID = f.calcNewIDWithCharacter("character_blueprints", "item_id", arg.CharacterID)
+	}
+	if arg.LocationFlag == app.FlagUndefined {
+		arg.LocationFlag = app.FlagHangar
+	}
+	if arg.LocationID == 0 {
+		x := f.CreateEveLocationStructure()
+		arg.LocationID = x.ID
+	}
+	if arg.Quantity == 0 {
+		arg.Quantity = -1
+	}
+	if arg.Runs == 0 {
+		if arg.Quantity == -2 {
+			arg.Runs = rand.IntN(100) + 1
+		} else {
+			arg.Runs = -1
+		}
+	}
+	if err := f.st.CreateCharacterBlueprint(ctx, arg); err != nil {
+		panic(err)
+	}
+	oo, err := f.st.ListCharacterBlueprints(ctx, arg.CharacterID)
+	if err != nil {
+		panic(err)
+	}
+	for _, o := range oo {
+		if o.ItemID == arg.ItemID {
+			return o
+		}
+	}
+	panic("blueprint not found")
+}
+
+func (f Factory) CreateCharacterCalendarEvent(args ...storage.UpdateOrCreateCharacterCalendarEventParams) *app.CharacterCalendarEvent {
+	ctx := context.Background()
+	var arg storage.UpdateOrCreateCharacterCalendarEventParams
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	if arg.CharacterID == 0 {
+		x := f.CreateCharacterFull()
+		arg.CharacterID = x.ID
+	}
+	if arg.EventID == 0 {
+		arg.EventID = f.calcNewID("character_calendar_events", "event_id", 1)
+	}
+	if arg.Date.IsZero() {
+		arg.Date = time.Now().UTC().Add(time.Duration(rand.IntN(7*24)+1) * time.Hour).Truncate(time.Minute)
+	}
+	if arg.Duration == 0 {
+		arg.Duration = time.Duration(rand.IntN(4)+1) * time.Hour
+	}
+	if arg.OwnerID == 0 {
+		x := f.CreateEveEntityCorporation()
+		arg.OwnerID = x.ID
+		arg.OwnerName = x.Name
+		arg.OwnerType = "corporation"
+	}
+	if arg.Response == "" {
+		arg.Response = app.CalendarEventResponseNotResponded
+	}
+	if arg.Title == "" {
+		arg.Title = fake.Sentence()
+	}
+	if arg.Text == "" {
+		arg.Text = fake.Paragraph()
+	}
+	if err := f.st.UpdateOrCreateCharacterCalendarEvent(ctx, arg); err != nil {
+		panic(err)
+	}
+	o, err := f.st.GetCharacterCalendarEvent(ctx, arg.CharacterID, arg.EventID)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
 func (f Factory) CreateCharacterContact(args ...storage.UpdateOrCreateCharacterContactParams) *app.CharacterContact {
 	ctx := context.Background()
 	var arg storage.UpdateOrCreateCharacterContactParams
@@ -453,6 +546,49 @@ func (f Factory) CreateCharacterContractItem(args ...storage.CreateCharacterCont
 	return o
 }
 
+func (f Factory) CreateCharacterFitting(args ...storage.CreateCharacterFittingParams) *app.CharacterFitting {
+	ctx := context.Background()
+	var arg storage.CreateCharacterFittingParams
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	if arg.CharacterID == 0 {
+		x := f.CreateCharacter()
+		arg.CharacterID = x.ID
+	}
+	if arg.FittingID == 0 {
+		arg.FittingID = f.calcNewIDWithCharacter(
+			"character_fittings",
+			"fitting_id",
+			arg.CharacterID,
+		)
+	}
+	if arg.Name == "" {
+		arg.Name = fmt.Sprintf("Fitting #%d", arg.FittingID)
+	}
+	if arg.ShipTypeID == 0 {
+		x := f.CreateEveType()
+		arg.ShipTypeID = x.ID
+	}
+	if len(arg.Items) == 0 {
+		x := f.CreateEveType()
+		arg.Items = append(arg.Items, storage.CreateCharacterFittingItemParams{
+			Flag:     app.FlagHiSlot0,
+			Quantity: 1,
+			TypeID:   x.ID,
+		})
+	}
+	err := f.st.CreateCharacterFitting(ctx, arg)
+	if err != nil {
+		panic(err)
+	}
+	o, err := f.st.GetCharacterFitting(ctx, arg.CharacterID, arg.FittingID)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
 func (f Factory) CreateCharacterImplant(args ...storage.CreateCharacterImplantParams) *app.CharacterImplant {
 	ctx := context.Background()
 	var arg storage.CreateCharacterImplantParams
@@ -862,6 +998,26 @@ func (f Factory) CreateCharacterSkill(args ...storage.UpdateOrCreateCharacterSki
 	return o
 }
 
+func (f Factory) CreateCharacterSkillPlan(args ...storage.CreateCharacterSkillPlanParams) *app.CharacterSkillPlan {
+	ctx := context.Background()
+	var arg storage.CreateCharacterSkillPlanParams
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	if arg.CharacterID == 0 {
+		x := f.CreateCharacter()
+		arg.CharacterID = x.ID
+	}
+	if arg.Name == "" {
+		arg.Name = fmt.Sprintf("Plan #%d", f.calcNewID("character_skill_plans", "id", 1))
+	}
+	o, err := f.st.CreateCharacterSkillPlan(ctx, arg)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
 func (f Factory) CreateCharacterSkillqueueItem(args ...storage.SkillqueueItemParams) *app.CharacterSkillqueueItem {
 	ctx := context.Background()
 	var arg storage.SkillqueueItemParams
@@ -925,6 +1081,56 @@ func (f Factory) CreateCharacterSkillqueueItem(args ...storage.SkillqueueItemPar
 	return i
 }
 
+func (f Factory) CreateCharacterStanding(args ...storage.UpdateOrCreateCharacterStandingParams) *app.CharacterStanding {
+	ctx := context.Background()
+	var arg storage.UpdateOrCreateCharacterStandingParams
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	if arg.CharacterID == 0 {
+		x := f.CreateCharacter()
+		arg.CharacterID = x.ID
+	}
+	if arg.FromType == "" {
+		arg.FromType = app.StandingFromFaction
+	}
+	if arg.FromID == 0 {
+		switch arg.FromType {
+		case app.StandingFromNPCCorp:
+			x1 := f.CreateEveEntity(app.EveEntity{
+				Category: app.EveEntityFaction,
+			})
+			x2 := f.CreateEveEntityCorporation()
+			f.CreateEveCorporation(storage.UpdateOrCreateEveCorporationParams{
+				ID:        x2.ID,
+				Name:      x2.Name,
+				FactionID: optional.New(x1.ID),
+			})
+			arg.FromID = x2.ID
+		case app.StandingFromAgent:
+			x := f.CreateEveEntityCharacter()
+			arg.FromID = x.ID
+		default:
+			x := f.CreateEveEntity(app.EveEntity{
+				Category: app.EveEntityFaction,
+			})
+			arg.FromID = x.ID
+		}
+	}
+	if arg.Standing == 0 {
+		arg.Standing = rand.Float64()*20 - 10
+	}
+	err := f.st.UpdateOrCreateCharacterStanding(ctx, arg)
+	if err != nil {
+		panic(err)
+	}
+	o, err := f.st.GetCharacterStanding(ctx, arg.CharacterID, arg.FromID)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
 func (f Factory) CreateCharacterTag(names ...string) *app.CharacterTag {
 	var name string
 	if len(names) > 0 {
@@ -1159,6 +1365,42 @@ func (f Factory) CreateCharacterWalletTransaction(args ...storage.CreateCharacte
 	return x
 }
 
+func (f Factory) CreateCharacterWealthSnapshot(args ...storage.UpdateOrCreateCharacterWealthSnapshotParams) *app.CharacterWealthSnapshot {
+	ctx := context.Background()
+	var arg storage.UpdateOrCreateCharacterWealthSnapshotParams
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	if arg.CharacterID == 0 {
+		x := f.CreateCharacter()
+		arg.CharacterID = x.ID
+	}
+	if arg.Day.IsZero() {
+		arg.Day = app.SnapshotDay(time.Now().Add(-time.Duration(rand.IntN(365)) * 24 * time.Hour))
+	}
+	if arg.AssetsValue == 0 {
+		arg.AssetsValue = rand.Float64() * 100_000_000_000
+	}
+	if arg.ContractsEscrow == 0 {
+		arg.ContractsEscrow = rand.Float64() * 1_000_000_000
+	}
+	if arg.OrdersEscrow == 0 {
+		arg.OrdersEscrow = rand.Float64() * 1_000_000_000
+	}
+	if arg.WalletBalance == 0 {
+		arg.WalletBalance = rand.Float64() * 10_000_000_000
+	}
+	err := f.st.UpdateOrCreateCharacterWealthSnapshot(ctx, arg)
+	if err != nil {
+		panic(err)
+	}
+	o, err := f.st.GetCharacterWealthSnapshot(ctx, arg.CharacterID, arg.Day)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
 func (f Factory) CreateCharacterMarketOrder(args ...storage.UpdateOrCreateCharacterMarketOrderParams) *app.CharacterMarketOrder {
 	ctx := context.Background()
 	var arg storage.UpdateOrCreateCharacterMarketOrderParams
@@ -1239,6 +1481,46 @@ func (f Factory) CreateCharacterMarketOrder(args ...storage.UpdateOrCreateCharac
 	return x
 }
 
+func (f Factory) CreateCharacterMiningLedgerEntry(args ...storage.UpdateOrCreateCharacterMiningLedgerEntryParams) *app.CharacterMiningLedgerEntry {
+	ctx := context.Background()
+	var arg storage.UpdateOrCreateCharacterMiningLedgerEntryParams
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	if arg.CharacterID == 0 {
+		x := f.CreateCharacterFull()
+		arg.CharacterID = x.ID
+	}
+	if arg.Date.IsZero() {
+		d := time.Now().UTC().Add(-time.Duration(rand.IntN(30)) * 24 * time.Hour)
+		arg.Date = time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	if arg.Quantity == 0 {
+		arg.Quantity = rand.Int64N(10_000) + 1
+	}
+	if arg.SolarSystemID == 0 {
+		x := f.CreateEveSolarSystem()
+		arg.SolarSystemID = x.ID
+	}
+	if arg.TypeID == 0 {
+		x := f.CreateEveType()
+		arg.TypeID = x.ID
+	}
+	if err := f.st.UpdateOrCreateCharacterMiningLedgerEntry(ctx, arg); err != nil {
+		panic(err)
+	}
+	oo, err := f.st.ListCharacterMiningLedgerEntries(ctx, arg.CharacterID)
+	if err != nil {
+		panic(err)
+	}
+	for _, o := range oo {
+		if o.Date.Equal(arg.Date) && o.SolarSystem.ID == arg.SolarSystemID && o.Type.ID == arg.TypeID {
+			return o
+		}
+	}
+	panic("created mining ledger entry not found")
+}
+
 func (f Factory) CreateCharacterNotification(args ...storage.CreateCharacterNotificationParams) *app.CharacterNotification {
 	var arg storage.CreateCharacterNotificationParams
 	if len(args) > 0 {
@@ -1355,6 +1637,55 @@ func (f Factory) CreateCorporationAsset(args ...storage.CreateCorporationAssetPa
 	return o
 }
 
+func (f Factory) CreateCorporationBlueprint(args ...storage.CreateCorporationBlueprintParams) *app.CorporationBlueprint {
+	ctx := context.Background()
+	var arg storage.CreateCorporationBlueprintParams
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	if arg.CorporationID == 0 {
+		x := f.CreateCorporation()
+		arg.CorporationID = x.ID
+	}
+	if arg.TypeID == 0 {
+		x := f.CreateEveType()
+		arg.TypeID = x.ID
+	}
+	if arg.ItemID == 0 {
+		arg.ItemID = f.calcNewIDWithCorporation("corporation_blueprints", "item_id", arg.CorporationID)
+	}
+	if arg.LocationFlag == app.FlagUndefined {
+		arg.LocationFlag = app.FlagHangar
+	}
+	if arg.LocationID == 0 {
+		x := f.CreateEveLocationStructure()
+		arg.LocationID = x.ID
+	}
+	if arg.Quantity == 0 {
+		arg.Quantity = -1
+	}
+	if arg.Runs == 0 {
+		if arg.Quantity == -2 {
+			arg.Runs = rand.IntN(100) + 1
+		} else {
+			arg.Runs = -1
+		}
+	}
+	if err := f.st.CreateCorporationBlueprint(ctx, arg); err != nil {
+		panic(err)
+	}
+	oo, err := f.st.ListCorporationBlueprints(ctx, arg.CorporationID)
+	if err != nil {
+		panic(err)
+	}
+	for _, o := range oo {
+		if o.ItemID == arg.ItemID {
+			return o
+		}
+	}
+	panic("blueprint not found")
+}
+
 func (f Factory) CreateCorporationTokenForSection(corporationID int64, section app.CorporationSection) *app.CharacterToken {
 	return f.CreateCorporationToken(corporationID, section.Roles(), section.Scopes())
 }
@@ -1587,6 +1918,91 @@ func (f Factory) CreateCorporationMember(args ...storage.CorporationMemberParams
 	return x
 }
 
+func (f Factory) CreateCorporationMiningObserver(args ...storage.UpdateOrCreateCorporationMiningObserverParams) *app.CorporationMiningObserver {
+	ctx := context.Background()
+	var arg storage.UpdateOrCreateCorporationMiningObserverParams
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	if arg.CorporationID == 0 {
+		x := f.CreateCorporation()
+		arg.CorporationID = x.ID
+	}
+	if arg.LastUpdated.IsZero() {
+		arg.LastUpdated = time.Now().UTC()
+	}
+	if arg.ObserverID == 0 {
+		arg.ObserverID = f.calcNewID("corporation_mining_observers", "observer_id", 1_000_000_000_000)
+	}
+	if arg.ObserverType == "" {
+		arg.ObserverType = "structure"
+	}
+	if err := f.st.UpdateOrCreateCorporationMiningObserver(ctx, arg); err != nil {
+		panic(err)
+	}
+	oo, err := f.st.ListCorporationMiningObservers(ctx, arg.CorporationID)
+	if err != nil {
+		panic(err)
+	}
+	for _, o := range oo {
+		if o.ObserverID == arg.ObserverID {
+			return o
+		}
+	}
+	panic("created mining observer not found")
+}
+
+func (f Factory) CreateCorporationMiningObserverEntry(args ...storage.UpdateOrCreateCorporationMiningObserverEntryParams) *app.CorporationMiningObserverEntry {
+	ctx := context.Background()
+	var arg storage.UpdateOrCreateCorporationMiningObserverEntryParams
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	if arg.ObserverID == 0 {
+		x := f.CreateCorporationMiningObserver(storage.UpdateOrCreateCorporationMiningObserverParams{
+			CorporationID: arg.CorporationID,
+		})
+		arg.CorporationID = x.CorporationID
+		arg.ObserverID = x.ObserverID
+	}
+	if arg.CorporationID == 0 {
+		x := f.CreateCorporation()
+		arg.CorporationID = x.ID
+	}
+	if arg.CharacterID == 0 {
+		x := f.CreateEveEntityCharacter()
+		arg.CharacterID = x.ID
+	}
+	if arg.LastUpdated.IsZero() {
+		d := time.Now().UTC().Add(-time.Duration(rand.IntN(30)) * 24 * time.Hour)
+		arg.LastUpdated = time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	if arg.Quantity == 0 {
+		arg.Quantity = rand.Int64N(10_000) + 1
+	}
+	if arg.RecordedCorporationID == 0 {
+		x := f.CreateEveEntityCorporation()
+		arg.RecordedCorporationID = x.ID
+	}
+	if arg.TypeID == 0 {
+		x := f.CreateEveType()
+		arg.TypeID = x.ID
+	}
+	if err := f.st.UpdateOrCreateCorporationMiningObserverEntry(ctx, arg); err != nil {
+		panic(err)
+	}
+	oo, err := f.st.ListCorporationMiningObserverEntries(ctx, arg.CorporationID)
+	if err != nil {
+		panic(err)
+	}
+	for _, o := range oo {
+		if o.ObserverID == arg.ObserverID && o.Character.ID == arg.CharacterID && o.Type.ID == arg.TypeID && o.LastUpdated.Equal(arg.LastUpdated) {
+			return o
+		}
+	}
+	panic("created mining observer entry not found")
+}
+
 func (f Factory) CreateCorporationIndustryJob(args ...storage.UpdateOrCreateCorporationIndustryJobParams) *app.CorporationIndustryJob {
 	ctx := context.Background()
 	var arg storage.UpdateOrCreateCorporationIndustryJobParams
@@ -1805,6 +2221,37 @@ func (f Factory) CreateCorporationWalletBalance(args ...storage.UpdateOrCreateCo
 	return x
 }
 
+func (f Factory) CreateCorporationWalletSnapshot(args ...storage.UpdateOrCreateCorporationWalletSnapshotParams) *app.CorporationWalletSnapshot {
+	ctx := context.Background()
+	var arg storage.UpdateOrCreateCorporationWalletSnapshotParams
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	if arg.CorporationID == 0 {
+		x := f.CreateCorporation()
+		arg.CorporationID = x.ID
+	}
+	if arg.DivisionID == 0 {
+		arg.DivisionID = 1
+	}
+	if arg.Day.IsZero() {
+		arg.Day = app.SnapshotDay(time.Now().Add(-time.Duration(rand.IntN(365)) * 24 * time.Hour))
+	}
+	if arg.Balance == 0 {
+		arg.Balance = rand.Float64()*100_000_000_000 + rand.Float64()
+	}
+	err := f.st.UpdateOrCreateCorporationWalletSnapshot(ctx, arg)
+	if err != nil {
+		panic(err)
+	}
+	return &app.CorporationWalletSnapshot{
+		Balance:       arg.Balance,
+		CorporationID: arg.CorporationID,
+		Day:           arg.Day.UTC(),
+		DivisionID:    arg.DivisionID,
+	}
+}
+
 func (f Factory) CreateCorporationWalletName(args ...storage.UpdateOrCreateCorporationWalletNameParams) *app.CorporationWalletName {
 	ctx := context.Background()
 	var arg storage.UpdateOrCreateCorporationWalletNameParams
@@ -2736,6 +3183,64 @@ func (f Factory) createEveLocationStructure(startID int64, categoryID int64, isE
 	return x
 }
 
+func (f Factory) CreateEveKillmail(args ...storage.CreateEveKillmailParams) *app.EveKillmail {
+	ctx := context.Background()
+	var arg storage.CreateEveKillmailParams
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	if arg.ID == 0 {
+		arg.ID = f.calcNewID("eve_killmails", "id", 100_000_001)
+	}
+	if arg.Hash == "" {
+		arg.Hash = fmt.Sprintf("%040x", rand.Uint64())
+	}
+	if arg.SolarSystemID == 0 {
+		x := f.CreateEveSolarSystem()
+		arg.SolarSystemID = x.ID
+	}
+	if arg.Time.IsZero() {
+		arg.Time = f.RandomTimeRounded()
+	}
+	if arg.VictimCharacterID.IsEmpty() && arg.VictimCorporationID.IsEmpty() {
+		arg.VictimCharacterID = optional.New(f.CreateEveEntityCharacter().ID)
+		arg.VictimCorporationID = optional.New(f.CreateEveEntityCorporation().ID)
+	}
+	if arg.VictimDamageTaken == 0 {
+		arg.VictimDamageTaken = rand.Int64N(100_000) + 1
+	}
+	if arg.VictimShipTypeID == 0 {
+		x := f.CreateEveType()
+		arg.VictimShipTypeID = x.ID
+	}
+	if arg.Attackers == nil {
+		arg.Attackers = []storage.CreateEveKillmailAttackerParams{{
+			CharacterID:    optional.New(f.CreateEveEntityCharacter().ID),
+			CorporationID:  optional.New(f.CreateEveEntityCorporation().ID),
+			DamageDone:     arg.VictimDamageTaken,
+			IsFinalBlow:    true,
+			SecurityStatus: rand.Float64()*10 - 5,
+			ShipTypeID:     optional.New(f.CreateEveType().ID),
+		}}
+	}
+	if arg.Items == nil {
+		arg.Items = []storage.CreateEveKillmailItemParams{{
+			Flag:              27,
+			QuantityDestroyed: 1,
+			TypeID:            f.CreateEveType().ID,
+		}}
+	}
+	err := f.st.CreateEveKillmail(ctx, arg)
+	if err != nil {
+		panic(err)
+	}
+	o, err := f.st.GetEveKillmail(ctx, arg.ID)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
 func (f Factory) CreateEveMarketPrice(args ...storage.UpdateOrCreateEveMarketPriceParams) *app.EveMarketPrice {
 	var arg storage.UpdateOrCreateEveMarketPriceParams
 	ctx := context.Background()
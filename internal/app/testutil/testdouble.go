@@ -346,6 +346,14 @@ func (s *SettingsStub) NotificationTypesEnabled() set.Set[string] {
 	return set.Set[string]{}
 }
 
+func (s *SettingsStub) NotifyCalendarEnabled() bool {
+	return true
+}
+
+func (s *SettingsStub) NotifyCalendarLeadMinutes() int {
+	return 15
+}
+
 func (s *SettingsStub) NotifyCommunicationsEarliest() time.Time {
 	return time.Now()
 }
@@ -362,6 +370,18 @@ func (s *SettingsStub) NotifyContractsEnabled() bool {
 	return true
 }
 
+func (s *SettingsStub) NotifyIndustryJobsEarliest() time.Time {
+	return time.Now()
+}
+
+func (s *SettingsStub) NotifyIndustryJobsEnabled() bool {
+	return true
+}
+
+func (s *SettingsStub) NotifyJumpFatigueEnabled() bool {
+	return true
+}
+
 func (s *SettingsStub) NotifyMailsEarliest() time.Time {
 	return time.Now()
 }
@@ -369,6 +389,18 @@ func (s *SettingsStub) NotifyMailsEnabled() bool {
 	return true
 }
 
+func (s *SettingsStub) NotifyMarketOrdersEarliest() time.Time {
+	return time.Now()
+}
+
+func (s *SettingsStub) NotifyMarketOrdersEnabled() bool {
+	return true
+}
+
+func (s *SettingsStub) NotifyMarketUndercutEnabled() bool {
+	return true
+}
+
 func (s *SettingsStub) NotifyPIEnabled() bool {
 	return true
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui/infoviewer"
+	"github.com/ErikKalkoken/evebuddy/internal/everefservice"
 	"github.com/ErikKalkoken/evebuddy/internal/janiceservice"
 	"github.com/ErikKalkoken/evebuddy/internal/optional"
 )
@@ -131,6 +132,30 @@ func (s *SettingsFake) MaxWalletTransactions() int {
 	return s.MaxTransactions
 }
 
+func (s *SettingsFake) NotifyIndustryJobsEarliest() time.Time {
+	return time.Time{}
+}
+
+func (s *SettingsFake) NotifyIndustryJobsEnabled() bool {
+	return false
+}
+
+func (s *SettingsFake) NotifyStructureFuelHours() []int {
+	return []int{}
+}
+
+func (s *SettingsFake) NotifyStructuresEarliest() time.Time {
+	return time.Time{}
+}
+
+func (s *SettingsFake) NotifyStructuresEnabled() bool {
+	return false
+}
+
+func (s *SettingsFake) NotifyStructureTimerHours() int {
+	return 1
+}
+
 type CharacterServiceFake struct {
 	Token          *app.CharacterToken
 	CorporationIDs set.Set[int64]
@@ -313,6 +338,10 @@ func (u *UIFake) EVEUniverse() *eveuniverseservice.EVEUniverseService {
 	return u.eus
 }
 
+func (u *UIFake) EveRef() *everefservice.EveRefService {
+	panic("NOT IMPLEMENTED")
+}
+
 func (u *UIFake) GetOrCreateWindow(id string, titles ...string) (window fyne.Window, created bool) {
 	return u.app.NewWindow("Dummy"), true
 }
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/ErikKalkoken/go-set"
@@ -60,20 +62,30 @@ func TruncateTables(dbRW *sql.DB) error {
 	if err != nil {
 		return err
 	}
-	sql := `SELECT name FROM sqlite_master WHERE type = "table" and name != "migrations"`
+	sql := `SELECT name, sql FROM sqlite_master WHERE type = "table" and name != "migrations"`
 	rows, err := dbRW.Query(sql)
 	if err != nil {
 		return err
 	}
 	defer rows.Close()
 	var tables set.Set[string]
+	var virtualTables []string
 	for rows.Next() {
-		var name string
-		if err := rows.Scan(&name); err != nil {
+		var name, definition string
+		if err := rows.Scan(&name, &definition); err != nil {
 			return err
 		}
 		tables.Add(name)
+		if strings.HasPrefix(definition, "CREATE VIRTUAL TABLE") {
+			virtualTables = append(virtualTables, name)
+		}
 	}
+	// shadow tables of virtual tables (e.g. for FTS5) must not be purged directly
+	tables.DeleteFunc(func(n string) bool {
+		return slices.ContainsFunc(virtualTables, func(vt string) bool {
+			return strings.HasPrefix(n, vt+"_")
+		})
+	})
 	for n := range tables.All() {
 		sql := fmt.Sprintf("DELETE FROM %s;", n)
 		_, err := dbRW.Exec(sql)
@@ -0,0 +1,357 @@
+// Package tokenkey manages the key for encrypting the stored tokens of characters.
+//
+// By default the key is created randomly and kept in the keyring of the operating system.
+// Alternatively users can set a passphrase from which the key is derived.
+// The passphrase is never stored and tokens are locked until the user unlocks them
+// by entering the passphrase again.
+// When no keyring is available and no passphrase has been set, tokens are stored unencrypted.
+package tokenkey
+
+import (
+	"context"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/keyring"
+)
+
+// Sources of the encryption key.
+const (
+	SourceKeyring    = "keyring"
+	SourcePassphrase = "passphrase"
+)
+
+// PassphraseMinSize is the minimum number of characters for a passphrase.
+const PassphraseMinSize = 8
+
+const (
+	keyCheckValue    = "evebuddy-token-key"
+	keyringService   = "evebuddy"
+	keyringUser      = "token-encryption-key"
+	pbkdf2Iterations = 600_000
+	saltSize         = 16
+)
+
+var ErrWrongPassphrase = errors.New("wrong passphrase")
+
+// State is the state of the token encryption.
+type State uint
+
+const (
+	Unencrypted State = iota // tokens are stored unencrypted
+	Unlocked                 // tokens are encrypted and the key is available
+	Locked                   // tokens are encrypted and the key is not available
+)
+
+func (s State) String() string {
+	switch s {
+	case Unencrypted:
+		return "unencrypted"
+	case Unlocked:
+		return "unlocked"
+	case Locked:
+		return "locked"
+	}
+	return "?"
+}
+
+// Keyring is a secret store.
+type Keyring interface {
+	Get(service, user string) (string, error)
+	Set(service, user, secret string) error
+}
+
+// systemKeyring is the keyring of the operating system.
+type systemKeyring struct{}
+
+func (systemKeyring) Get(service, user string) (string, error) {
+	return keyring.Get(service, user)
+}
+
+func (systemKeyring) Set(service, user, secret string) error {
+	return keyring.Set(service, user, secret)
+}
+
+type Params struct {
+	Keyring Keyring // optional, defaults to the keyring of the operating system
+	Storage *storage.Storage
+}
+
+// Manager manages the key for encrypting tokens.
+type Manager struct {
+	keyring Keyring
+	st      *storage.Storage
+
+	mu     sync.Mutex
+	source string
+	state  State
+}
+
+// New returns a new manager.
+func New(arg Params) *Manager {
+	if arg.Storage == nil {
+		panic("tokenkey: missing storage")
+	}
+	m := &Manager{
+		keyring: arg.Keyring,
+		st:      arg.Storage,
+	}
+	if m.keyring == nil {
+		m.keyring = systemKeyring{}
+	}
+	return m
+}
+
+// State returns the current state of the token encryption.
+func (m *Manager) State() State {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state
+}
+
+// Source returns the source of the current key or an empty string when there is none.
+func (m *Manager) Source() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.source
+}
+
+// Init loads the key and should be called once during startup.
+//
+// When tokens are not yet encrypted and a keyring is available,
+// a new key is created and all existing tokens are encrypted with it.
+// When the key comes from a passphrase, tokens remain locked until [Manager.Unlock] is called.
+func (m *Manager) Init(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.init(ctx)
+}
+
+func (m *Manager) init(ctx context.Context) error {
+	cfg, err := m.st.GetTokenEncryption(ctx)
+	if errors.Is(err, app.ErrNotFound) {
+		err := m.useKeyring(ctx)
+		if err != nil {
+			slog.Warn("Token encryption not available. Tokens are stored unencrypted", "error", err)
+			m.state = Unencrypted
+			m.source = ""
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("token key init: %w", err)
+	}
+	m.source = cfg.KeySource
+	switch cfg.KeySource {
+	case SourceKeyring:
+		s, err := m.keyring.Get(keyringService, keyringUser)
+		if err != nil {
+			m.state = Locked
+			return fmt.Errorf("token key init: load key from keyring: %w: %w", err, app.ErrTokenKeyUnavailable)
+		}
+		c, err := cipherFromKeyring(s)
+		if err != nil {
+			m.state = Locked
+			return fmt.Errorf("token key init: %w: %w", err, app.ErrTokenKeyUnavailable)
+		}
+		if !verifyCipher(c, cfg.KeyCheck) {
+			m.state = Locked
+			return fmt.Errorf("token key init: key in keyring does not match: %w", app.ErrTokenKeyUnavailable)
+		}
+		m.st.SetTokenCipher(c)
+		m.state = Unlocked
+		if err := m.encryptRemaining(ctx, c, cfg); err != nil {
+			return fmt.Errorf("token key init: %w", err)
+		}
+	case SourcePassphrase:
+		m.state = Locked
+	default:
+		m.state = Locked
+		return fmt.Errorf("token key init: unknown key source %q: %w", cfg.KeySource, app.ErrInvalid)
+	}
+	return nil
+}
+
+// Unlock unlocks the tokens with a passphrase.
+// Returns [ErrWrongPassphrase] when the passphrase does not match.
+func (m *Manager) Unlock(ctx context.Context, passphrase string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cfg, err := m.st.GetTokenEncryption(ctx)
+	if err != nil {
+		return fmt.Errorf("token key unlock: %w", err)
+	}
+	if cfg.KeySource != SourcePassphrase {
+		return fmt.Errorf("token key unlock: key source is %s: %w", cfg.KeySource, app.ErrInvalid)
+	}
+	c, err := cipherFromPassphrase(passphrase, cfg.KeySalt)
+	if err != nil {
+		return fmt.Errorf("token key unlock: %w", err)
+	}
+	if !verifyCipher(c, cfg.KeyCheck) {
+		return ErrWrongPassphrase
+	}
+	m.st.SetTokenCipher(c)
+	m.state = Unlocked
+	slog.Info("Token encryption unlocked")
+	if err := m.encryptRemaining(ctx, c, cfg); err != nil {
+		return fmt.Errorf("token key unlock: %w", err)
+	}
+	return nil
+}
+
+// UnencryptedTokens returns the number of character tokens, which are stored unencrypted.
+func (m *Manager) UnencryptedTokens(ctx context.Context) (int, error) {
+	return m.st.CountUnencryptedCharacterTokens(ctx)
+}
+
+// encryptRemaining encrypts tokens which are still stored unencrypted,
+// e.g. because they were stored before encryption was set up.
+func (m *Manager) encryptRemaining(ctx context.Context, c *storage.TokenCipher, cfg *storage.TokenEncryption) error {
+	n, err := m.st.CountUnencryptedCharacterTokens(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return nil
+	}
+	if _, err := m.st.UpdateTokenEncryption(ctx, c, *cfg); err != nil {
+		return err
+	}
+	slog.Info("Encrypted remaining unencrypted tokens", "tokens", n)
+	return nil
+}
+
+// SetPassphrase changes the key to one derived from a passphrase
+// and re-encrypts all tokens with it.
+// Tokens must not be locked.
+func (m *Manager) SetPassphrase(ctx context.Context, passphrase string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.state == Locked {
+		return fmt.Errorf("token key set passphrase: %w", app.ErrTokenKeyUnavailable)
+	}
+	if len(passphrase) < PassphraseMinSize {
+		return fmt.Errorf("token key set passphrase: passphrase must have at least %d characters: %w", PassphraseMinSize, app.ErrInvalid)
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("token key set passphrase: %w", err)
+	}
+	c, err := cipherFromPassphrase(passphrase, salt)
+	if err != nil {
+		return fmt.Errorf("token key set passphrase: %w", err)
+	}
+	if err := m.updateEncryption(ctx, c, SourcePassphrase, salt); err != nil {
+		return fmt.Errorf("token key set passphrase: %w", err)
+	}
+	return nil
+}
+
+// UseKeyring changes the key to one kept in the keyring of the operating system
+// and re-encrypts all tokens with it.
+// Tokens must not be locked.
+func (m *Manager) UseKeyring(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.state == Locked {
+		return fmt.Errorf("token key use keyring: %w", app.ErrTokenKeyUnavailable)
+	}
+	if err := m.useKeyring(ctx); err != nil {
+		return fmt.Errorf("token key use keyring: %w", err)
+	}
+	return nil
+}
+
+// useKeyring encrypts all tokens with a key from the keyring.
+// An existing key in the keyring is re-used, else a new key is created.
+func (m *Manager) useKeyring(ctx context.Context) error {
+	var c *storage.TokenCipher
+	s, err := m.keyring.Get(keyringService, keyringUser)
+	switch {
+	case errors.Is(err, keyring.ErrNotFound):
+		c, err = m.createKeyringCipher()
+	case err == nil:
+		c, err = cipherFromKeyring(s)
+	}
+	if err != nil {
+		return err
+	}
+	return m.updateEncryption(ctx, c, SourceKeyring, nil)
+}
+
+// createKeyringCipher creates a new random key, stores it in the keyring and returns a cipher for it.
+func (m *Manager) createKeyringCipher() (*storage.TokenCipher, error) {
+	key := make([]byte, storage.TokenKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := m.keyring.Set(keyringService, keyringUser, base64.StdEncoding.EncodeToString(key)); err != nil {
+		return nil, err
+	}
+	return storage.NewTokenCipher(key)
+}
+
+func (m *Manager) updateEncryption(ctx context.Context, c *storage.TokenCipher, source string, salt []byte) error {
+	check, err := c.Encrypt(keyCheckValue)
+	if err != nil {
+		return err
+	}
+	n, err := m.st.UpdateTokenEncryption(ctx, c, storage.TokenEncryption{
+		KeyCheck:  check,
+		KeySalt:   salt,
+		KeySource: source,
+	})
+	if err != nil {
+		return err
+	}
+	m.source = source
+	m.state = Unlocked
+	slog.Info("Token encryption updated", "source", source, "tokens", n)
+	return nil
+}
+
+// Reset deletes all tokens and the encryption configuration
+// and then sets up encryption again from scratch.
+//
+// This allows users to start over when the key has been lost, e.g. after a forgotten passphrase.
+// All characters need to be authorized again afterwards.
+func (m *Manager) Reset(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.st.ResetTokenEncryption(ctx); err != nil {
+		return fmt.Errorf("token key reset: %w", err)
+	}
+	slog.Info("Token encryption reset")
+	return m.init(ctx)
+}
+
+func cipherFromKeyring(s string) (*storage.TokenCipher, error) {
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key in keyring: %w", err)
+	}
+	return storage.NewTokenCipher(key)
+}
+
+func cipherFromPassphrase(passphrase string, salt []byte) (*storage.TokenCipher, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, pbkdf2Iterations, storage.TokenKeySize)
+	if err != nil {
+		return nil, err
+	}
+	return storage.NewTokenCipher(key)
+}
+
+// verifyCipher reports whether c is able to decrypt the check value.
+func verifyCipher(c *storage.TokenCipher, check string) bool {
+	s, err := c.Decrypt(check)
+	return err == nil && s == keyCheckValue
+}
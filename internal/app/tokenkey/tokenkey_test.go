@@ -0,0 +1,231 @@
+package tokenkey_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/app/tokenkey"
+	"github.com/ErikKalkoken/evebuddy/internal/keyring"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+type keyringFake struct {
+	err     error
+	secrets map[string]string
+}
+
+func newKeyringFake() *keyringFake {
+	return &keyringFake{secrets: make(map[string]string)}
+}
+
+func (k *keyringFake) Get(service, user string) (string, error) {
+	if k.err != nil {
+		return "", k.err
+	}
+	s, ok := k.secrets[service+":"+user]
+	if !ok {
+		return "", keyring.ErrNotFound
+	}
+	return s, nil
+}
+
+func (k *keyringFake) Set(service, user, secret string) error {
+	if k.err != nil {
+		return k.err
+	}
+	k.secrets[service+":"+user] = secret
+	return nil
+}
+
+func TestManager(t *testing.T) {
+	db, st, factory := testutil.NewDBInMemory()
+	defer db.Close()
+	ctx := context.Background()
+	t.Run("should encrypt existing tokens with new key from keyring", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(nil)
+		o := factory.CreateCharacterToken()
+		m := tokenkey.New(tokenkey.Params{Keyring: newKeyringFake(), Storage: st})
+		// when
+		err := m.Init(ctx)
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, tokenkey.Unlocked, m.State())
+		xassert.Equal(t, tokenkey.SourceKeyring, m.Source())
+		o2, err := st.GetCharacterToken(ctx, o.CharacterID)
+		require.NoError(t, err)
+		xassert.Equal(t, o.RefreshToken, o2.RefreshToken)
+		n, err := m.UnencryptedTokens(ctx)
+		require.NoError(t, err)
+		xassert.Equal(t, 0, n)
+	})
+	t.Run("should encrypt existing tokens when keyring becomes available", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(nil)
+		o := factory.CreateCharacterToken()
+		kr := newKeyringFake()
+		kr.err = keyring.ErrUnsupported
+		m := tokenkey.New(tokenkey.Params{Keyring: kr, Storage: st})
+		err := m.Init(ctx)
+		require.NoError(t, err)
+		n, err := m.UnencryptedTokens(ctx)
+		require.NoError(t, err)
+		xassert.Equal(t, 1, n)
+		m2 := tokenkey.New(tokenkey.Params{Keyring: newKeyringFake(), Storage: st})
+		// when
+		err = m2.Init(ctx)
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, tokenkey.Unlocked, m2.State())
+		n, err = m2.UnencryptedTokens(ctx)
+		require.NoError(t, err)
+		xassert.Equal(t, 0, n)
+		o2, err := st.GetCharacterToken(ctx, o.CharacterID)
+		require.NoError(t, err)
+		xassert.Equal(t, o.RefreshToken, o2.RefreshToken)
+	})
+	t.Run("should encrypt remaining unencrypted tokens when loading key", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(nil)
+		kr := newKeyringFake()
+		o := factory.CreateCharacterToken()
+		err := tokenkey.New(tokenkey.Params{Keyring: kr, Storage: st}).Init(ctx)
+		require.NoError(t, err)
+		_, err = db.Exec("UPDATE character_tokens SET refresh_token = ? WHERE character_id = ?", o.RefreshToken, o.CharacterID)
+		require.NoError(t, err)
+		st.SetTokenCipher(nil)
+		m := tokenkey.New(tokenkey.Params{Keyring: kr, Storage: st})
+		// when
+		err = m.Init(ctx)
+		// then
+		require.NoError(t, err)
+		n, err := m.UnencryptedTokens(ctx)
+		require.NoError(t, err)
+		xassert.Equal(t, 0, n)
+		o2, err := st.GetCharacterToken(ctx, o.CharacterID)
+		require.NoError(t, err)
+		xassert.Equal(t, o.RefreshToken, o2.RefreshToken)
+	})
+	t.Run("should load existing key from keyring", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(nil)
+		kr := newKeyringFake()
+		o := factory.CreateCharacterToken()
+		err := tokenkey.New(tokenkey.Params{Keyring: kr, Storage: st}).Init(ctx)
+		require.NoError(t, err)
+		st.SetTokenCipher(nil)
+		m := tokenkey.New(tokenkey.Params{Keyring: kr, Storage: st})
+		// when
+		err = m.Init(ctx)
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, tokenkey.Unlocked, m.State())
+		o2, err := st.GetCharacterToken(ctx, o.CharacterID)
+		require.NoError(t, err)
+		xassert.Equal(t, o.RefreshToken, o2.RefreshToken)
+	})
+	t.Run("should keep tokens unencrypted when no keyring is available", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(nil)
+		kr := newKeyringFake()
+		kr.err = keyring.ErrUnsupported
+		m := tokenkey.New(tokenkey.Params{Keyring: kr, Storage: st})
+		// when
+		err := m.Init(ctx)
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, tokenkey.Unencrypted, m.State())
+		_, err = st.GetTokenEncryption(ctx)
+		assert.ErrorIs(t, err, app.ErrNotFound)
+	})
+	t.Run("should report when key in keyring is missing", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(nil)
+		factory.CreateCharacterToken()
+		err := tokenkey.New(tokenkey.Params{Keyring: newKeyringFake(), Storage: st}).Init(ctx)
+		require.NoError(t, err)
+		st.SetTokenCipher(nil)
+		m := tokenkey.New(tokenkey.Params{Keyring: newKeyringFake(), Storage: st})
+		// when
+		err = m.Init(ctx)
+		// then
+		assert.ErrorIs(t, err, app.ErrTokenKeyUnavailable)
+		xassert.Equal(t, tokenkey.Locked, m.State())
+	})
+	t.Run("can set passphrase and unlock", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(nil)
+		kr := newKeyringFake()
+		o := factory.CreateCharacterToken()
+		m := tokenkey.New(tokenkey.Params{Keyring: kr, Storage: st})
+		err := m.Init(ctx)
+		require.NoError(t, err)
+		// when
+		err = m.SetPassphrase(ctx, "my secret passphrase")
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, tokenkey.SourcePassphrase, m.Source())
+		st.SetTokenCipher(nil)
+		m2 := tokenkey.New(tokenkey.Params{Keyring: kr, Storage: st})
+		err = m2.Init(ctx)
+		require.NoError(t, err)
+		xassert.Equal(t, tokenkey.Locked, m2.State())
+		err = m2.Unlock(ctx, "wrong passphrase")
+		assert.ErrorIs(t, err, tokenkey.ErrWrongPassphrase)
+		xassert.Equal(t, tokenkey.Locked, m2.State())
+		err = m2.Unlock(ctx, "my secret passphrase")
+		require.NoError(t, err)
+		xassert.Equal(t, tokenkey.Unlocked, m2.State())
+		o2, err := st.GetCharacterToken(ctx, o.CharacterID)
+		require.NoError(t, err)
+		xassert.Equal(t, o.RefreshToken, o2.RefreshToken)
+	})
+	t.Run("should reject short passphrases", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(nil)
+		m := tokenkey.New(tokenkey.Params{Keyring: newKeyringFake(), Storage: st})
+		err := m.Init(ctx)
+		require.NoError(t, err)
+		// when
+		err = m.SetPassphrase(ctx, "short")
+		// then
+		assert.ErrorIs(t, err, app.ErrInvalid)
+	})
+	t.Run("can reset when key is lost", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		st.SetTokenCipher(nil)
+		o := factory.CreateCharacterToken()
+		m := tokenkey.New(tokenkey.Params{Keyring: newKeyringFake(), Storage: st})
+		err := m.Init(ctx)
+		require.NoError(t, err)
+		err = m.SetPassphrase(ctx, "my secret passphrase")
+		require.NoError(t, err)
+		st.SetTokenCipher(nil)
+		m2 := tokenkey.New(tokenkey.Params{Keyring: newKeyringFake(), Storage: st})
+		err = m2.Init(ctx)
+		require.NoError(t, err)
+		// when
+		err = m2.Reset(ctx)
+		// then
+		require.NoError(t, err)
+		xassert.Equal(t, tokenkey.Unlocked, m2.State())
+		xassert.Equal(t, tokenkey.SourceKeyring, m2.Source())
+		_, err = st.GetCharacterToken(ctx, o.CharacterID)
+		assert.ErrorIs(t, err, app.ErrNotFound)
+	})
+	st.SetTokenCipher(nil)
+}
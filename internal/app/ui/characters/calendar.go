@@ -0,0 +1,443 @@
+package characters
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	ihumanize "github.com/ErikKalkoken/evebuddy/internal/humanize"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+	"github.com/ErikKalkoken/evebuddy/internal/xstrings"
+	"github.com/ErikKalkoken/evebuddy/internal/xwidget"
+)
+
+type calendarCharacter struct {
+	id       int64
+	name     string
+	response app.CalendarEventResponse
+}
+
+// calendarRow represents a calendar event,
+// which can be in the calendar of several characters.
+type calendarRow struct {
+	characters  []calendarCharacter
+	date        time.Time
+	duration    time.Duration
+	eventID     int64
+	isImportant bool
+	ownerID     int64
+	ownerName   string
+	ownerType   string
+	text        string
+	title       string
+}
+
+func (r calendarRow) charactersDisplay() string {
+	s := xslices.Map(r.characters, func(c calendarCharacter) string {
+		return fmt.Sprintf("%s (%s)", c.name, c.response.Display())
+	})
+	return strings.Join(s, ", ")
+}
+
+func (r calendarRow) dateDisplay() string {
+	return r.date.UTC().Format(app.DateTimeFormat)
+}
+
+func (r calendarRow) durationDisplay() string {
+	return ihumanize.Duration(r.duration)
+}
+
+func (r calendarRow) hasCharacter(name string) bool {
+	return slices.ContainsFunc(r.characters, func(c calendarCharacter) bool {
+		return c.name == name
+	})
+}
+
+// mergeCalendarEvents returns the calendar events of characters merged into one row per event.
+// Rows are ordered by start date.
+func mergeCalendarEvents(events []*app.CharacterCalendarEvent, characterNames map[int64]string) []calendarRow {
+	m := make(map[int64]calendarRow)
+	for _, e := range events {
+		r, ok := m[e.EventID]
+		if !ok {
+			r = calendarRow{
+				date:        e.Date,
+				duration:    e.Duration,
+				eventID:     e.EventID,
+				isImportant: e.IsImportant(),
+				ownerID:     e.OwnerID,
+				ownerName:   e.OwnerName,
+				ownerType:   e.OwnerType,
+				text:        e.TextPlain(),
+				title:       e.Title,
+			}
+		}
+		r.characters = append(r.characters, calendarCharacter{
+			id:       e.CharacterID,
+			name:     characterNames[e.CharacterID],
+			response: e.Response,
+		})
+		m[e.EventID] = r
+	}
+	var rows []calendarRow
+	for _, r := range m {
+		slices.SortFunc(r.characters, func(a, b calendarCharacter) int {
+			return xstrings.CompareIgnoreCase(a.name, b.name)
+		})
+		rows = append(rows, r)
+	}
+	slices.SortFunc(rows, func(a, b calendarRow) int {
+		return cmp.Or(
+			a.date.Compare(b.date),
+			cmp.Compare(a.eventID, b.eventID),
+		)
+	})
+	return rows
+}
+
+// Calendar is a widget which shows the upcoming calendar events of all characters.
+type Calendar struct {
+	widget.BaseWidget
+
+	columnSorter    *xwidget.ColumnSorter[calendarRow]
+	footer          *widget.Label
+	main            fyne.CanvasObject
+	rows            []calendarRow
+	rowsFiltered    []calendarRow
+	selectCharacter *kxwidget.FilterChipSelect
+	selectOwner     *kxwidget.FilterChipSelect
+	sortButton      *xwidget.SortButton[calendarRow]
+	u               baseUI
+}
+
+const (
+	calendarColDate = iota + 1
+	calendarColTitle
+	calendarColDuration
+	calendarColOwner
+	calendarColCharacters
+)
+
+// NewCalendar returns a new widget for showing upcoming calendar events.
+func NewCalendar(u baseUI) *Calendar {
+	columns := xwidget.NewDataColumns([]xwidget.DataColumn[calendarRow]{{
+		ID:    calendarColDate,
+		Label: "Start",
+		Width: 150,
+		Sort: func(a, b calendarRow) int {
+			return a.date.Compare(b.date)
+		},
+		Update: func(r calendarRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(r.dateDisplay())
+		},
+	}, {
+		ID:    calendarColTitle,
+		Label: "Title",
+		Width: 300,
+		Sort: func(a, b calendarRow) int {
+			return xstrings.CompareIgnoreCase(a.title, b.title)
+		},
+		Update: func(r calendarRow, co fyne.CanvasObject) {
+			var c fyne.ThemeColorName
+			if r.isImportant {
+				c = theme.ColorNameError
+			} else {
+				c = theme.ColorNameForeground
+			}
+			co.(*xwidget.RichText).SetWithText(r.title, widget.RichTextStyle{
+				ColorName: c,
+			})
+		},
+	}, {
+		ID:    calendarColDuration,
+		Label: "Duration",
+		Width: 100,
+		Sort: func(a, b calendarRow) int {
+			return cmp.Compare(a.duration, b.duration)
+		},
+		Update: func(r calendarRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(r.durationDisplay())
+		},
+	}, {
+		ID:    calendarColOwner,
+		Label: "Owner",
+		Width: ui.ColumnWidthEntity,
+		Sort: func(a, b calendarRow) int {
+			return xstrings.CompareIgnoreCase(a.ownerName, b.ownerName)
+		},
+		Update: func(r calendarRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(r.ownerName)
+		},
+	}, {
+		ID:    calendarColCharacters,
+		Label: "Characters",
+		Width: 300,
+		Update: func(r calendarRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(r.charactersDisplay())
+		},
+	}})
+	a := &Calendar{
+		columnSorter: xwidget.NewColumnSorter(columns, calendarColDate, xwidget.SortAsc),
+		footer:       ui.NewLabelWithTruncation(""),
+		u:            u,
+	}
+	a.ExtendBaseWidget(a)
+
+	if !a.u.IsMobile() {
+		a.main = xwidget.MakeDataTable(
+			columns,
+			&a.rowsFiltered,
+			func() fyne.CanvasObject {
+				x := xwidget.NewRichText()
+				x.Truncation = fyne.TextTruncateClip
+				return x
+			},
+			a.columnSorter,
+			a.filterRowsAsync, func(_ int, r calendarRow) {
+				showCalendarEventWindow(a.u, r)
+			})
+	} else {
+		a.main = a.makeDataList()
+	}
+
+	a.selectCharacter = kxwidget.NewFilterChipSelect("Character", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.selectOwner = kxwidget.NewFilterChipSelect("Owner", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.sortButton = a.columnSorter.NewSortButton(func() {
+		a.filterRowsAsync(-1)
+	})
+
+	// Signals
+	a.u.Signals().AppInit.AddListener(func(ctx context.Context, _ struct{}) {
+		a.update(ctx)
+	})
+	a.u.Signals().CharacterSectionChanged.AddListener(func(ctx context.Context, arg app.CharacterSectionUpdated) {
+		switch arg.Section {
+		case app.SectionCharacterCalendar:
+			a.update(ctx)
+		}
+	})
+	a.u.Signals().CharacterAdded.AddListener(func(ctx context.Context, _ *app.Character) {
+		a.update(ctx)
+	})
+	a.u.Signals().CharacterRemoved.AddListener(func(ctx context.Context, _ *app.EntityShort) {
+		a.update(ctx)
+	})
+	return a
+}
+
+func (a *Calendar) CreateRenderer() fyne.WidgetRenderer {
+	filter := container.NewHBox(a.selectCharacter, a.selectOwner)
+	if a.u.IsMobile() {
+		filter.Add(a.sortButton)
+	}
+	p := theme.Padding()
+	c := container.NewBorder(
+		container.NewHScroll(filter),
+		container.New(layout.NewCustomPaddedLayout(p, p, 0, 0), a.footer),
+		nil,
+		nil,
+		a.main,
+	)
+	return widget.NewSimpleRenderer(c)
+}
+
+func (a *Calendar) makeDataList() *xwidget.StripedList {
+	p := theme.Padding()
+	l := xwidget.NewStripedList(
+		func() int {
+			return len(a.rowsFiltered)
+		},
+		func() fyne.CanvasObject {
+			title := widget.NewLabel("Template")
+			title.Truncation = fyne.TextTruncateClip
+			title.TextStyle.Bold = true
+			date := widget.NewLabel("Template")
+			duration := widget.NewLabel("Template")
+			duration.Alignment = fyne.TextAlignTrailing
+			owner := widget.NewLabel("Template")
+			owner.Truncation = fyne.TextTruncateClip
+			characters := widget.NewLabel("Template")
+			characters.Truncation = fyne.TextTruncateClip
+			return container.New(layout.NewCustomPaddedVBoxLayout(-p),
+				title,
+				container.NewBorder(nil, nil, nil, duration, date),
+				owner,
+				characters,
+			)
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id < 0 || id >= len(a.rowsFiltered) {
+				return
+			}
+			r := a.rowsFiltered[id]
+			c := co.(*fyne.Container).Objects
+
+			title := c[0].(*widget.Label)
+			title.Text = r.title
+			if r.isImportant {
+				title.Importance = widget.DangerImportance
+			} else {
+				title.Importance = widget.MediumImportance
+			}
+			title.Refresh()
+
+			b1 := c[1].(*fyne.Container).Objects
+			b1[0].(*widget.Label).SetText(r.dateDisplay())
+			b1[1].(*widget.Label).SetText(r.durationDisplay())
+
+			c[2].(*widget.Label).SetText(r.ownerName)
+			c[3].(*widget.Label).SetText(r.charactersDisplay())
+		},
+	)
+	l.OnSelected = func(id widget.ListItemID) {
+		defer l.UnselectAll()
+		if id < 0 || id >= len(a.rowsFiltered) {
+			return
+		}
+		showCalendarEventWindow(a.u, a.rowsFiltered[id])
+	}
+	l.HideSeparators = true
+	return l
+}
+
+func (a *Calendar) filterRowsAsync(sortCol int) {
+	totalRows := len(a.rows)
+	rows := slices.Clone(a.rows)
+	character := a.selectCharacter.Selected
+	owner := a.selectOwner.Selected
+	sortCol, dir, doSort := a.columnSorter.CalcSort(sortCol)
+
+	go func() {
+		// filter
+		if character != "" {
+			rows = slices.DeleteFunc(rows, func(r calendarRow) bool {
+				return !r.hasCharacter(character)
+			})
+		}
+		if owner != "" {
+			rows = slices.DeleteFunc(rows, func(r calendarRow) bool {
+				return r.ownerName != owner
+			})
+		}
+		// set filter options
+		var characterOptions []string
+		for _, r := range rows {
+			for _, c := range r.characters {
+				characterOptions = append(characterOptions, c.name)
+			}
+		}
+		ownerOptions := xslices.Map(rows, func(r calendarRow) string {
+			return r.ownerName
+		})
+		footer := fmt.Sprintf("Showing %d / %d upcoming events", len(rows), totalRows)
+		a.columnSorter.SortRows(rows, sortCol, dir, doSort)
+
+		fyne.Do(func() {
+			a.footer.Text = footer
+			a.footer.Importance = widget.MediumImportance
+			a.footer.Refresh()
+			a.selectCharacter.SetOptions(characterOptions)
+			a.selectOwner.SetOptions(ownerOptions)
+			a.rowsFiltered = rows
+			a.main.Refresh()
+		})
+	}()
+}
+
+func (a *Calendar) update(ctx context.Context) {
+	rows, err := a.fetchRows(ctx)
+	if err != nil {
+		slog.Error("Failed to refresh calendar UI", "err", err)
+		fyne.Do(func() {
+			a.footer.Text = "ERROR: " + a.u.ErrorDisplay(err)
+			a.footer.Importance = widget.DangerImportance
+			a.footer.Refresh()
+		})
+		return
+	}
+	fyne.Do(func() {
+		a.rows = rows
+		a.filterRowsAsync(-1)
+	})
+}
+
+func (a *Calendar) fetchRows(ctx context.Context) ([]calendarRow, error) {
+	events, err := a.u.Character().ListAllUpcomingCalendarEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	characterNames, err := a.u.Character().CharacterNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mergeCalendarEvents(events, characterNames), nil
+}
+
+// showCalendarEventWindow shows the details of a calendar event in a new window.
+func showCalendarEventWindow(u baseUI, r calendarRow) {
+	w, created := u.GetOrCreateWindow(fmt.Sprintf("calendar-event-%d", r.eventID), "Calendar Event", r.title)
+	if !created {
+		w.Show()
+		return
+	}
+	importance := widget.NewLabel("Normal")
+	if r.isImportant {
+		importance.Text = "Important"
+		importance.Importance = widget.DangerImportance
+	}
+	text := widget.NewLabel(r.text)
+	text.Wrapping = fyne.TextWrapWord
+	items := []*widget.FormItem{
+		widget.NewFormItem("Title", widget.NewLabel(r.title)),
+		widget.NewFormItem("Start", widget.NewLabel(r.dateDisplay())),
+		widget.NewFormItem("End", widget.NewLabel(r.date.Add(r.duration).UTC().Format(app.DateTimeFormat))),
+		widget.NewFormItem("Duration", widget.NewLabel(r.durationDisplay())),
+		widget.NewFormItem("Importance", importance),
+		widget.NewFormItem("Owner", widget.NewLabel(fmt.Sprintf("%s (%s)", r.ownerName, xstrings.Title(r.ownerType)))),
+		widget.NewFormItem("Description", text),
+	}
+	for _, c := range r.characters {
+		items = append(items, widget.NewFormItem(c.name, widget.NewLabel(c.response.Display())))
+	}
+	if len(r.characters) > 0 {
+		attendees, err := u.Character().ListCalendarEventAttendees(context.Background(), r.characters[0].id, r.eventID)
+		if err != nil {
+			slog.Error("Failed to fetch calendar event attendees", "eventID", r.eventID, "error", err)
+		} else if len(attendees) > 0 {
+			s := xslices.Map(attendees, func(x *app.CharacterCalendarEventAttendee) string {
+				return fmt.Sprintf("%s (%s)", x.Character.Name, x.Response.Display())
+			})
+			l := widget.NewLabel(strings.Join(s, "\n"))
+			l.Wrapping = fyne.TextWrapWord
+			items = append(items, widget.NewFormItem("Attendees", l))
+		}
+	}
+	if u.IsDeveloperMode() {
+		items = append(items, widget.NewFormItem("Event ID", xwidget.NewTappableLabelWithClipboardCopy(fmt.Sprint(r.eventID))))
+	}
+	f := widget.NewForm(items...)
+	f.Orientation = widget.Adaptive
+	ui.MakeDetailWindow(ui.MakeDetailWindowParams{
+		Content: f,
+		Title:   r.title,
+		Window:  w,
+	})
+	w.Show()
+}
@@ -0,0 +1,32 @@
+package characters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func TestMergeCalendarEvents(t *testing.T) {
+	now := time.Date(2025, 10, 1, 18, 0, 0, 0, time.UTC)
+	events := []*app.CharacterCalendarEvent{
+		{CharacterID: 1, EventID: 100, Date: now.Add(time.Hour), Title: "Moon pop", Response: app.CalendarEventResponseAccepted},
+		{CharacterID: 2, EventID: 100, Date: now.Add(time.Hour), Title: "Moon pop", Response: app.CalendarEventResponseDeclined},
+		{CharacterID: 2, EventID: 101, Date: now, Title: "Fleet op", Importance: 1, Response: app.CalendarEventResponseNotResponded},
+	}
+	names := map[int64]string{1: "Bravo", 2: "Alpha"}
+	got := mergeCalendarEvents(events, names)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, []int64{101, 100}, xslices.Map(got, func(r calendarRow) int64 {
+			return r.eventID
+		}))
+		assert.True(t, got[0].isImportant)
+		assert.Equal(t, "Alpha (Not Responded)", got[0].charactersDisplay())
+		assert.Equal(t, "Alpha (Declined), Bravo (Accepted)", got[1].charactersDisplay())
+		assert.True(t, got[1].hasCharacter("Bravo"))
+		assert.False(t, got[0].hasCharacter("Bravo"))
+	}
+}
@@ -0,0 +1,412 @@
+package characters
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	"github.com/ErikKalkoken/evebuddy/internal/xstrings"
+	"github.com/ErikKalkoken/evebuddy/internal/xwidget"
+)
+
+// messageSearchLimit is the maximum number of hits per kind returned by a search.
+const messageSearchLimit = 500
+
+// messageSearchRow represents a mail or notification found by a search.
+type messageSearchRow struct {
+	characterID   int64
+	characterName string
+	id            int64 // mail ID or notification ID
+	kind          app.TextSearchHitKind
+	sender        *app.EveEntity
+	snippet       string
+	timestamp     time.Time
+	title         string
+}
+
+func (r messageSearchRow) kindDisplay() string {
+	return xstrings.Title(r.kind.String())
+}
+
+func (r messageSearchRow) senderName() string {
+	if r.sender == nil {
+		return ""
+	}
+	return r.sender.Name
+}
+
+func (r messageSearchRow) timestampDisplay() string {
+	return r.timestamp.UTC().Format(app.DateTimeFormat)
+}
+
+// MessageSearch is a widget for searching the mails and notifications of all characters.
+type MessageSearch struct {
+	widget.BaseWidget
+
+	columnSorter    *xwidget.ColumnSorter[messageSearchRow]
+	entry           *widget.Entry
+	footer          *widget.Label
+	main            fyne.CanvasObject
+	rows            []messageSearchRow
+	rowsFiltered    []messageSearchRow
+	selectCharacter *kxwidget.FilterChipSelect
+	selectKind      *kxwidget.FilterChipSelect
+	sortButton      *xwidget.SortButton[messageSearchRow]
+	u               baseUI
+}
+
+const (
+	messageSearchColDate = iota + 1
+	messageSearchColKind
+	messageSearchColTitle
+	messageSearchColFrom
+	messageSearchColCharacter
+	messageSearchColSnippet
+)
+
+// NewMessageSearch returns a new widget for searching mails and notifications.
+func NewMessageSearch(u baseUI) *MessageSearch {
+	columns := xwidget.NewDataColumns([]xwidget.DataColumn[messageSearchRow]{{
+		ID:    messageSearchColDate,
+		Label: "Date",
+		Width: ui.ColumnWidthDateTime,
+		Sort: func(a, b messageSearchRow) int {
+			return a.timestamp.Compare(b.timestamp)
+		},
+		Update: func(r messageSearchRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(r.timestampDisplay())
+		},
+	}, {
+		ID:    messageSearchColKind,
+		Label: "Type",
+		Width: 100,
+		Sort: func(a, b messageSearchRow) int {
+			return strings.Compare(a.kind.String(), b.kind.String())
+		},
+		Update: func(r messageSearchRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(r.kindDisplay())
+		},
+	}, {
+		ID:    messageSearchColTitle,
+		Label: "Subject",
+		Width: 250,
+		Sort: func(a, b messageSearchRow) int {
+			return xstrings.CompareIgnoreCase(a.title, b.title)
+		},
+		Update: func(r messageSearchRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(r.title)
+		},
+	}, {
+		ID:    messageSearchColFrom,
+		Label: "From",
+		Width: ui.ColumnWidthEntity,
+		Sort: func(a, b messageSearchRow) int {
+			return xstrings.CompareIgnoreCase(a.senderName(), b.senderName())
+		},
+		Update: func(r messageSearchRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(r.senderName())
+		},
+	}, {
+		ID:    messageSearchColCharacter,
+		Label: "Character",
+		Width: ui.ColumnWidthEntity,
+		Sort: func(a, b messageSearchRow) int {
+			return xstrings.CompareIgnoreCase(a.characterName, b.characterName)
+		},
+		Update: func(r messageSearchRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(r.characterName)
+		},
+	}, {
+		ID:    messageSearchColSnippet,
+		Label: "Match",
+		Width: 400,
+		Update: func(r messageSearchRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(r.snippet)
+		},
+	}})
+	a := &MessageSearch{
+		columnSorter: xwidget.NewColumnSorter(columns, messageSearchColDate, xwidget.SortDesc),
+		entry:        widget.NewEntry(),
+		footer:       ui.NewLabelWithTruncation(""),
+		u:            u,
+	}
+	a.ExtendBaseWidget(a)
+
+	if !a.u.IsMobile() {
+		a.main = xwidget.MakeDataTable(
+			columns,
+			&a.rowsFiltered,
+			func() fyne.CanvasObject {
+				x := xwidget.NewRichText()
+				x.Truncation = fyne.TextTruncateClip
+				return x
+			},
+			a.columnSorter,
+			a.filterRowsAsync, func(_ int, r messageSearchRow) {
+				showMessageSearchHitWindow(a.u, r)
+			})
+	} else {
+		a.main = a.makeDataList()
+	}
+
+	a.entry.PlaceHolder = "Search mails and notifications"
+	a.entry.ActionItem = kxwidget.NewIconButton(theme.CancelIcon(), func() {
+		a.Reset()
+	})
+	a.entry.OnSubmitted = func(s string) {
+		go a.search(context.Background(), s)
+	}
+
+	a.selectCharacter = kxwidget.NewFilterChipSelect("Character", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.selectKind = kxwidget.NewFilterChipSelect("Type", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.sortButton = a.columnSorter.NewSortButton(func() {
+		a.filterRowsAsync(-1)
+	})
+
+	// Signals
+	a.u.Signals().CharacterRemoved.AddListener(func(ctx context.Context, _ *app.EntityShort) {
+		a.search(ctx, a.currentQuery())
+	})
+	return a
+}
+
+func (a *MessageSearch) CreateRenderer() fyne.WidgetRenderer {
+	filter := container.NewHBox(a.selectCharacter, a.selectKind)
+	if a.u.IsMobile() {
+		filter.Add(a.sortButton)
+	}
+	p := theme.Padding()
+	c := container.NewBorder(
+		container.NewVBox(a.entry, container.NewHScroll(filter)),
+		container.New(layout.NewCustomPaddedLayout(p, p, 0, 0), a.footer),
+		nil,
+		nil,
+		a.main,
+	)
+	return widget.NewSimpleRenderer(c)
+}
+
+// Reset clears the search query and all results.
+func (a *MessageSearch) Reset() {
+	a.entry.SetText("")
+	a.selectCharacter.ClearSelected()
+	a.selectKind.ClearSelected()
+	a.rows = []messageSearchRow{}
+	a.filterRowsAsync(-1)
+}
+
+func (a *MessageSearch) currentQuery() string {
+	var s string
+	fyne.DoAndWait(func() {
+		s = a.entry.Text
+	})
+	return s
+}
+
+func (a *MessageSearch) makeDataList() *xwidget.StripedList {
+	p := theme.Padding()
+	l := xwidget.NewStripedList(
+		func() int {
+			return len(a.rowsFiltered)
+		},
+		func() fyne.CanvasObject {
+			title := widget.NewLabel("Template")
+			title.Truncation = fyne.TextTruncateClip
+			title.TextStyle.Bold = true
+			date := widget.NewLabel("Template")
+			kind := widget.NewLabel("Template")
+			kind.Alignment = fyne.TextAlignTrailing
+			from := widget.NewLabel("Template")
+			from.Truncation = fyne.TextTruncateClip
+			snippet := widget.NewLabel("Template")
+			snippet.Truncation = fyne.TextTruncateClip
+			return container.New(layout.NewCustomPaddedVBoxLayout(-p),
+				title,
+				container.NewBorder(nil, nil, nil, kind, date),
+				from,
+				snippet,
+			)
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id < 0 || id >= len(a.rowsFiltered) {
+				return
+			}
+			r := a.rowsFiltered[id]
+			c := co.(*fyne.Container).Objects
+			c[0].(*widget.Label).SetText(r.title)
+			b1 := c[1].(*fyne.Container).Objects
+			b1[0].(*widget.Label).SetText(r.timestampDisplay())
+			b1[1].(*widget.Label).SetText(r.kindDisplay())
+			c[2].(*widget.Label).SetText(fmt.Sprintf("%s ► %s", r.senderName(), r.characterName))
+			c[3].(*widget.Label).SetText(r.snippet)
+		},
+	)
+	l.OnSelected = func(id widget.ListItemID) {
+		defer l.UnselectAll()
+		if id < 0 || id >= len(a.rowsFiltered) {
+			return
+		}
+		showMessageSearchHitWindow(a.u, a.rowsFiltered[id])
+	}
+	l.HideSeparators = true
+	return l
+}
+
+func (a *MessageSearch) filterRowsAsync(sortCol int) {
+	totalRows := len(a.rows)
+	rows := slices.Clone(a.rows)
+	character := a.selectCharacter.Selected
+	kind := a.selectKind.Selected
+	sortCol, dir, doSort := a.columnSorter.CalcSort(sortCol)
+
+	go func() {
+		// filter
+		if character != "" {
+			rows = slices.DeleteFunc(rows, func(r messageSearchRow) bool {
+				return r.characterName != character
+			})
+		}
+		if kind != "" {
+			rows = slices.DeleteFunc(rows, func(r messageSearchRow) bool {
+				return r.kindDisplay() != kind
+			})
+		}
+		// set filter options
+		var characterOptions, kindOptions []string
+		for _, r := range rows {
+			characterOptions = append(characterOptions, r.characterName)
+			kindOptions = append(kindOptions, r.kindDisplay())
+		}
+		footer := fmt.Sprintf("Showing %d / %d hits", len(rows), totalRows)
+		a.columnSorter.SortRows(rows, sortCol, dir, doSort)
+
+		fyne.Do(func() {
+			a.footer.Text = footer
+			a.footer.Importance = widget.MediumImportance
+			a.footer.Refresh()
+			a.selectCharacter.SetOptions(characterOptions)
+			a.selectKind.SetOptions(kindOptions)
+			a.rowsFiltered = rows
+			a.main.Refresh()
+		})
+	}()
+}
+
+func (a *MessageSearch) search(ctx context.Context, query string) {
+	rows, err := a.fetchRows(ctx, query)
+	if err != nil {
+		slog.Error("Failed to search messages", "query", query, "err", err)
+		fyne.Do(func() {
+			a.footer.Text = "ERROR: " + a.u.ErrorDisplay(err)
+			a.footer.Importance = widget.DangerImportance
+			a.footer.Refresh()
+		})
+		return
+	}
+	fyne.Do(func() {
+		a.rows = rows
+		a.filterRowsAsync(-1)
+	})
+}
+
+func (a *MessageSearch) fetchRows(ctx context.Context, query string) ([]messageSearchRow, error) {
+	if strings.TrimSpace(query) == "" {
+		return []messageSearchRow{}, nil
+	}
+	hits, err := a.u.Character().SearchMailsAndNotifications(ctx, query, messageSearchLimit)
+	if err != nil {
+		return nil, err
+	}
+	characterNames, err := a.u.Character().CharacterNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]messageSearchRow, 0, len(hits))
+	for _, h := range hits {
+		name, ok := characterNames[h.CharacterID]
+		if !ok {
+			continue // character was removed
+		}
+		rows = append(rows, messageSearchRow{
+			characterID:   h.CharacterID,
+			characterName: name,
+			id:            h.ID,
+			kind:          h.Kind,
+			sender:        h.Sender,
+			snippet:       h.Snippet,
+			timestamp:     h.Timestamp,
+			title:         h.Title,
+		})
+	}
+	return rows, nil
+}
+
+// showMessageSearchHitWindow shows the mail or notification of a search hit in a new window.
+func showMessageSearchHitWindow(u baseUI, r messageSearchRow) {
+	w, created := u.GetOrCreateWindow(
+		fmt.Sprintf("message-search-%s-%d-%d", r.kind, r.characterID, r.id),
+		r.kindDisplay(),
+		r.characterName,
+	)
+	if !created {
+		w.Show()
+		return
+	}
+	subject := widget.NewLabel(r.title)
+	subject.SizeName = theme.SizeNameSubHeadingText
+	subject.Wrapping = fyne.TextWrapWord
+	header := NewMailHeaderWidget(u.EVEImage().EveEntityLogoAsync, u.InfoViewer().Show)
+	body := xwidget.NewRichText()
+	body.Wrapping = fyne.TextWrapWord
+	ctx := context.Background()
+	switch r.kind {
+	case app.TextSearchHitMail:
+		m, err := u.Character().GetMail(ctx, r.characterID, r.id)
+		if err != nil {
+			slog.Error("Failed to load mail", "characterID", r.characterID, "mailID", r.id, "error", err)
+			body.SetWithText("ERROR: Failed to load mail: "+u.ErrorDisplay(err), widget.RichTextStyle{
+				ColorName: theme.ColorNameError,
+			})
+			break
+		}
+		header.Set(m.From, m.Timestamp, m.Recipients...)
+		body.SetWithText(m.BodyPlain())
+	case app.TextSearchHitNotification:
+		n, err := u.Character().GetNotification(ctx, r.characterID, r.id)
+		if err != nil {
+			slog.Error("Failed to load notification", "characterID", r.characterID, "notificationID", r.id, "error", err)
+			body.SetWithText("ERROR: Failed to load notification: "+u.ErrorDisplay(err), widget.RichTextStyle{
+				ColorName: theme.ColorNameError,
+			})
+			break
+		}
+		var recipients []*app.EveEntity
+		if v, ok := n.Recipient.Value(); ok {
+			recipients = append(recipients, v)
+		}
+		header.Set(n.Sender, n.Timestamp, recipients...)
+		body.ParseMarkdown(n.Body.ValueOrZero())
+	}
+	ui.MakeDetailWindow(ui.MakeDetailWindowParams{
+		Content: container.NewVBox(subject, header, body),
+		Title:   r.title,
+		Window:  w,
+	})
+	w.Show()
+}
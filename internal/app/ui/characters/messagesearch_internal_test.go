@@ -0,0 +1,64 @@
+package characters
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil/testdouble"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+func TestMessageSearch_FetchRows(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+
+	t.Run("should return hits with character names", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c := factory.CreateCharacterFull()
+		m := factory.CreateCharacterMail(storage.CreateCharacterMailParams{
+			CharacterID: c.ID,
+			Body:        optional.New("Moon extraction is ready"),
+		})
+		a := NewMessageSearch(testdouble.NewUIFake(testdouble.UIParams{
+			App:     test.NewTempApp(t),
+			Storage: st,
+		}))
+		err := a.u.Character().UpdateSearchIndex(t.Context(), c.ID)
+		require.NoError(t, err)
+
+		// when
+		rows, err := a.fetchRows(t.Context(), "moon")
+
+		// then
+		require.NoError(t, err)
+		if assert.Len(t, rows, 1) {
+			assert.Equal(t, m.MailID, rows[0].id)
+			assert.Equal(t, app.TextSearchHitMail, rows[0].kind)
+			assert.Equal(t, c.EveCharacter.Name, rows[0].characterName)
+			assert.Equal(t, m.From.ID, rows[0].sender.ID)
+		}
+	})
+
+	t.Run("should return no hits for empty query", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		a := NewMessageSearch(testdouble.NewUIFake(testdouble.UIParams{
+			App:     test.NewTempApp(t),
+			Storage: st,
+		}))
+
+		// when
+		rows, err := a.fetchRows(t.Context(), " ")
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, rows, 0)
+	})
+}
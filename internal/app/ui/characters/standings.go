@@ -0,0 +1,445 @@
+package characters
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+	"github.com/ErikKalkoken/evebuddy/internal/xstrings"
+	"github.com/ErikKalkoken/evebuddy/internal/xwidget"
+)
+
+// standingsRow represents the standing of an NPC entity towards a character.
+type standingsRow struct {
+	agentLevel    int
+	characterID   int64
+	characterName string
+	effective     float64
+	factionName   string
+	from          *app.EveEntity
+	fromType      app.StandingFromType
+	standing      float64
+}
+
+func (r standingsRow) agentLevelDisplay() string {
+	if r.agentLevel == 0 {
+		return "None"
+	}
+	return fmt.Sprintf("L%d", r.agentLevel)
+}
+
+func (r standingsRow) agentLevelColor() fyne.ThemeColorName {
+	switch r.agentLevel {
+	case 0:
+		return theme.ColorNameError
+	case 5:
+		return theme.ColorNameSuccess
+	}
+	return theme.ColorNameForeground
+}
+
+func standingDisplay(v float64) string {
+	return fmt.Sprintf("%.2f", v)
+}
+
+func standingColor(v float64) fyne.ThemeColorName {
+	switch {
+	case v <= -2:
+		return theme.ColorNameError
+	case v >= 5:
+		return theme.ColorNameSuccess
+	}
+	return theme.ColorNameForeground
+}
+
+// makeStandingsRows returns the standings of characters as rows
+// with effective standings and the highest accessible agent level.
+//
+// The agent level of a corporation also takes the standing of its faction into account.
+// For agents only their own standing is used, because their corporation is not known.
+func makeStandingsRows(standings []*app.CharacterStanding, skills map[int64]app.StandingSkills, characterNames map[int64]string) []standingsRow {
+	type key struct {
+		characterID int64
+		factionID   int64
+	}
+	factionEffective := make(map[key]float64)
+	for _, s := range standings {
+		if s.FromType != app.StandingFromFaction {
+			continue
+		}
+		factionEffective[key{s.CharacterID, s.From.ID}] = s.EffectiveStanding(skills[s.CharacterID])
+	}
+	var rows []standingsRow
+	for _, s := range standings {
+		effective := s.EffectiveStanding(skills[s.CharacterID])
+		r := standingsRow{
+			characterID:   s.CharacterID,
+			characterName: characterNames[s.CharacterID],
+			effective:     effective,
+			from:          s.From,
+			fromType:      s.FromType,
+			standing:      s.Standing,
+		}
+		relevant := []float64{effective}
+		if f, ok := s.Faction.Value(); ok && f != nil {
+			r.factionName = f.Name
+			if v, ok := factionEffective[key{s.CharacterID, f.ID}]; ok {
+				relevant = append(relevant, v)
+			}
+		} else if s.FromType == app.StandingFromFaction {
+			r.factionName = s.From.Name
+		}
+		r.agentLevel = app.MaxAgentLevel(relevant...)
+		rows = append(rows, r)
+	}
+	slices.SortFunc(rows, func(a, b standingsRow) int {
+		return cmp.Or(
+			xstrings.CompareIgnoreCase(a.characterName, b.characterName),
+			xstrings.CompareIgnoreCase(a.from.Name, b.from.Name),
+		)
+	})
+	return rows
+}
+
+// Standings is a widget which shows the NPC standings of all characters.
+type Standings struct {
+	widget.BaseWidget
+
+	columnSorter    *xwidget.ColumnSorter[standingsRow]
+	footer          *widget.Label
+	main            fyne.CanvasObject
+	rows            []standingsRow
+	rowsFiltered    []standingsRow
+	selectCharacter *kxwidget.FilterChipSelect
+	selectFaction   *kxwidget.FilterChipSelect
+	selectType      *kxwidget.FilterChipSelect
+	sortButton      *xwidget.SortButton[standingsRow]
+	u               baseUI
+}
+
+const (
+	standingsColCharacter = iota + 1
+	standingsColFrom
+	standingsColType
+	standingsColFaction
+	standingsColStanding
+	standingsColEffective
+	standingsColAgentLevel
+)
+
+// NewStandings returns a new widget for showing NPC standings.
+func NewStandings(u baseUI) *Standings {
+	columns := xwidget.NewDataColumns([]xwidget.DataColumn[standingsRow]{
+		ui.MakeEveEntityColumn(ui.MakeEveEntityColumnParams[standingsRow]{
+			ColumnID: standingsColCharacter,
+			EIS:      u.EVEImage(),
+			GetEntity: func(r standingsRow) *app.EveEntity {
+				return &app.EveEntity{
+					ID:       r.characterID,
+					Name:     r.characterName,
+					Category: app.EveEntityCharacter,
+				}
+			},
+			IsAvatar: true,
+			Label:    "Character",
+		}),
+		ui.MakeEveEntityColumn(ui.MakeEveEntityColumnParams[standingsRow]{
+			ColumnID: standingsColFrom,
+			EIS:      u.EVEImage(),
+			GetEntity: func(r standingsRow) *app.EveEntity {
+				return r.from
+			},
+			Label: "From",
+			Width: 250,
+		}), {
+			ID:    standingsColType,
+			Label: "Type",
+			Width: 100,
+			Sort: func(a, b standingsRow) int {
+				return cmp.Compare(a.fromType.Display(), b.fromType.Display())
+			},
+			Update: func(r standingsRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.fromType.Display())
+			},
+		}, {
+			ID:    standingsColFaction,
+			Label: "Faction",
+			Width: 200,
+			Sort: func(a, b standingsRow) int {
+				return xstrings.CompareIgnoreCase(a.factionName, b.factionName)
+			},
+			Update: func(r standingsRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.factionName)
+			},
+		}, {
+			ID:    standingsColStanding,
+			Label: "Standing",
+			Width: 90,
+			Sort: func(a, b standingsRow) int {
+				return cmp.Compare(a.standing, b.standing)
+			},
+			Update: func(r standingsRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(standingDisplay(r.standing), widget.RichTextStyle{
+					Alignment: fyne.TextAlignTrailing,
+				})
+			},
+		}, {
+			ID:    standingsColEffective,
+			Label: "Effective",
+			Width: 90,
+			Sort: func(a, b standingsRow) int {
+				return cmp.Compare(a.effective, b.effective)
+			},
+			Update: func(r standingsRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(standingDisplay(r.effective), widget.RichTextStyle{
+					Alignment: fyne.TextAlignTrailing,
+					ColorName: standingColor(r.effective),
+				})
+			},
+		}, {
+			ID:    standingsColAgentLevel,
+			Label: "Agents",
+			Width: 80,
+			Sort: func(a, b standingsRow) int {
+				return cmp.Compare(a.agentLevel, b.agentLevel)
+			},
+			Update: func(r standingsRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.agentLevelDisplay(), widget.RichTextStyle{
+					ColorName: r.agentLevelColor(),
+				})
+			},
+		}})
+	a := &Standings{
+		columnSorter: xwidget.NewColumnSorter(columns, standingsColCharacter, xwidget.SortAsc),
+		footer:       ui.NewLabelWithTruncation(""),
+		u:            u,
+	}
+	a.ExtendBaseWidget(a)
+
+	if !a.u.IsMobile() {
+		a.main = xwidget.MakeDataTable(
+			columns,
+			&a.rowsFiltered,
+			func() fyne.CanvasObject {
+				x := xwidget.NewRichText()
+				x.Truncation = fyne.TextTruncateClip
+				return x
+			},
+			a.columnSorter,
+			a.filterRowsAsync, func(_ int, r standingsRow) {
+				a.u.InfoViewer().Show(r.from)
+			})
+	} else {
+		a.main = a.makeDataList()
+	}
+
+	a.selectCharacter = kxwidget.NewFilterChipSelect("Character", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.selectFaction = kxwidget.NewFilterChipSelect("Faction", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.selectType = kxwidget.NewFilterChipSelect("Type", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.sortButton = a.columnSorter.NewSortButton(func() {
+		a.filterRowsAsync(-1)
+	})
+
+	// Signals
+	a.u.Signals().AppInit.AddListener(func(ctx context.Context, _ struct{}) {
+		a.update(ctx)
+	})
+	a.u.Signals().CharacterSectionChanged.AddListener(func(ctx context.Context, arg app.CharacterSectionUpdated) {
+		switch arg.Section {
+		case app.SectionCharacterStandings, app.SectionCharacterSkills:
+			a.update(ctx)
+		}
+	})
+	a.u.Signals().CharacterAdded.AddListener(func(ctx context.Context, _ *app.Character) {
+		a.update(ctx)
+	})
+	a.u.Signals().CharacterRemoved.AddListener(func(ctx context.Context, _ *app.EntityShort) {
+		a.update(ctx)
+	})
+	return a
+}
+
+func (a *Standings) CreateRenderer() fyne.WidgetRenderer {
+	filter := container.NewHBox(a.selectCharacter, a.selectType, a.selectFaction)
+	if a.u.IsMobile() {
+		filter.Add(a.sortButton)
+	}
+	p := theme.Padding()
+	c := container.NewBorder(
+		container.NewHScroll(filter),
+		container.New(layout.NewCustomPaddedLayout(p, p, 0, 0), a.footer),
+		nil,
+		nil,
+		a.main,
+	)
+	return widget.NewSimpleRenderer(c)
+}
+
+func (a *Standings) makeDataList() *xwidget.StripedList {
+	p := theme.Padding()
+	l := xwidget.NewStripedList(
+		func() int {
+			return len(a.rowsFiltered)
+		},
+		func() fyne.CanvasObject {
+			from := widget.NewLabel("Template")
+			from.Truncation = fyne.TextTruncateClip
+			from.TextStyle.Bold = true
+			agents := widget.NewLabel("Template")
+			character := widget.NewLabel("Template")
+			character.Truncation = fyne.TextTruncateClip
+			effective := widget.NewLabel("Template")
+			effective.Alignment = fyne.TextAlignTrailing
+			faction := widget.NewLabel("Template")
+			faction.Truncation = fyne.TextTruncateClip
+			return container.New(layout.NewCustomPaddedVBoxLayout(-p),
+				container.NewBorder(nil, nil, nil, agents, from),
+				container.NewBorder(nil, nil, nil, effective, character),
+				faction,
+			)
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id < 0 || id >= len(a.rowsFiltered) {
+				return
+			}
+			r := a.rowsFiltered[id]
+			c := co.(*fyne.Container).Objects
+
+			b0 := c[0].(*fyne.Container).Objects
+			b0[0].(*widget.Label).SetText(r.from.Name)
+			agents := b0[1].(*widget.Label)
+			agents.Text = "Agents: " + r.agentLevelDisplay()
+			if r.agentLevel == 0 {
+				agents.Importance = widget.DangerImportance
+			} else {
+				agents.Importance = widget.MediumImportance
+			}
+			agents.Refresh()
+
+			b1 := c[1].(*fyne.Container).Objects
+			b1[0].(*widget.Label).SetText(r.characterName)
+			b1[1].(*widget.Label).SetText(fmt.Sprintf(
+				"%s (%s)", standingDisplay(r.effective), standingDisplay(r.standing),
+			))
+
+			s := r.fromType.Display()
+			if r.factionName != "" && r.fromType != app.StandingFromFaction {
+				s += " • " + r.factionName
+			}
+			c[2].(*widget.Label).SetText(s)
+		},
+	)
+	l.OnSelected = func(id widget.ListItemID) {
+		defer l.UnselectAll()
+		if id < 0 || id >= len(a.rowsFiltered) {
+			return
+		}
+		a.u.InfoViewer().Show(a.rowsFiltered[id].from)
+	}
+	l.HideSeparators = true
+	return l
+}
+
+func (a *Standings) filterRowsAsync(sortCol int) {
+	totalRows := len(a.rows)
+	rows := slices.Clone(a.rows)
+	character := a.selectCharacter.Selected
+	faction := a.selectFaction.Selected
+	fromType := a.selectType.Selected
+	sortCol, dir, doSort := a.columnSorter.CalcSort(sortCol)
+
+	go func() {
+		// filter
+		if character != "" {
+			rows = slices.DeleteFunc(rows, func(r standingsRow) bool {
+				return r.characterName != character
+			})
+		}
+		if faction != "" {
+			rows = slices.DeleteFunc(rows, func(r standingsRow) bool {
+				return r.factionName != faction
+			})
+		}
+		if fromType != "" {
+			rows = slices.DeleteFunc(rows, func(r standingsRow) bool {
+				return r.fromType.Display() != fromType
+			})
+		}
+		// set filter options
+		characterOptions := xslices.Map(rows, func(r standingsRow) string {
+			return r.characterName
+		})
+		factionOptions := xslices.Map(slices.DeleteFunc(slices.Clone(rows), func(r standingsRow) bool {
+			return r.factionName == ""
+		}), func(r standingsRow) string {
+			return r.factionName
+		})
+		typeOptions := xslices.Map(rows, func(r standingsRow) string {
+			return r.fromType.Display()
+		})
+		footer := fmt.Sprintf("Showing %d / %d standings", len(rows), totalRows)
+		a.columnSorter.SortRows(rows, sortCol, dir, doSort)
+
+		fyne.Do(func() {
+			a.footer.Text = footer
+			a.footer.Importance = widget.MediumImportance
+			a.footer.Refresh()
+			a.selectCharacter.SetOptions(characterOptions)
+			a.selectFaction.SetOptions(factionOptions)
+			a.selectType.SetOptions(typeOptions)
+			a.rowsFiltered = rows
+			a.main.Refresh()
+		})
+	}()
+}
+
+func (a *Standings) update(ctx context.Context) {
+	rows, err := a.fetchRows(ctx)
+	if err != nil {
+		slog.Error("Failed to refresh standings UI", "err", err)
+		fyne.Do(func() {
+			a.footer.Text = "ERROR: " + a.u.ErrorDisplay(err)
+			a.footer.Importance = widget.DangerImportance
+			a.footer.Refresh()
+		})
+		return
+	}
+	fyne.Do(func() {
+		a.rows = rows
+		a.filterRowsAsync(-1)
+	})
+}
+
+func (a *Standings) fetchRows(ctx context.Context) ([]standingsRow, error) {
+	standings, err := a.u.Character().ListAllStandings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	skills, err := a.u.Character().ListAllStandingSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+	characterNames, err := a.u.Character().CharacterNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return makeStandingsRows(standings, skills, characterNames), nil
+}
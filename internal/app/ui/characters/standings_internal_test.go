@@ -0,0 +1,100 @@
+package characters
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil/testdouble"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func TestMakeStandingsRows(t *testing.T) {
+	caldari := &app.EveEntity{ID: 500001, Name: "Caldari State", Category: app.EveEntityFaction}
+	standings := []*app.CharacterStanding{
+		{CharacterID: 1, From: caldari, FromType: app.StandingFromFaction, Standing: 6.5},
+		{
+			CharacterID: 1,
+			From:        &app.EveEntity{ID: 1000035, Name: "Caldari Navy", Category: app.EveEntityCorporation},
+			FromType:    app.StandingFromNPCCorp,
+			Faction:     optional.New(caldari),
+			Standing:    2.0,
+		},
+		{
+			CharacterID: 2,
+			From:        &app.EveEntity{ID: 1000035, Name: "Caldari Navy", Category: app.EveEntityCorporation},
+			FromType:    app.StandingFromNPCCorp,
+			Faction:     optional.New(caldari),
+			Standing:    -3.0,
+		},
+		{
+			CharacterID: 2,
+			From:        &app.EveEntity{ID: 3008416, Name: "Aakari Kurvinen", Category: app.EveEntityCharacter},
+			FromType:    app.StandingFromAgent,
+			Standing:    0.5,
+		},
+	}
+	skills := map[int64]app.StandingSkills{
+		1: {Connections: 5},
+		2: {Connections: 5, Diplomacy: 5},
+	}
+	names := map[int64]string{1: "Alpha", 2: "Bravo"}
+	got := makeStandingsRows(standings, skills, names)
+	if assert.Len(t, got, 4) {
+		// Alpha: Caldari Navy uses faction standing
+		assert.Equal(t, "Caldari Navy", got[0].from.Name)
+		assert.Equal(t, "Caldari State", got[0].factionName)
+		assert.InDelta(t, 3.6, got[0].effective, 0.0001)
+		assert.Equal(t, 5, got[0].agentLevel)
+		// Alpha: Caldari State
+		assert.Equal(t, "Caldari State", got[1].factionName)
+		assert.InDelta(t, 7.2, got[1].effective, 0.0001)
+		assert.Equal(t, 5, got[1].agentLevel)
+		// Bravo: agent
+		assert.InDelta(t, 2.4, got[2].effective, 0.0001)
+		assert.Equal(t, 2, got[2].agentLevel)
+		assert.Equal(t, "L2", got[2].agentLevelDisplay())
+		// Bravo: Caldari Navy with bad standing
+		assert.InDelta(t, -0.4, got[3].effective, 0.0001)
+		assert.Equal(t, 1, got[3].agentLevel)
+	}
+}
+
+func TestStandings_Update(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+
+	t.Run("should show standings of all characters", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c1 := factory.CreateCharacterFull()
+		c2 := factory.CreateCharacterFull()
+		o1 := factory.CreateCharacterStanding(storage.UpdateOrCreateCharacterStandingParams{
+			CharacterID: c1.ID,
+		})
+		o2 := factory.CreateCharacterStanding(storage.UpdateOrCreateCharacterStandingParams{
+			CharacterID: c2.ID,
+			FromType:    app.StandingFromNPCCorp,
+		})
+		a := NewStandings(testdouble.NewUIFake(testdouble.UIParams{
+			App:     test.NewTempApp(t),
+			Storage: st,
+		}))
+
+		// when
+		rows, err := a.fetchRows(t.Context())
+
+		// then
+		if assert.NoError(t, err) {
+			got := xslices.Map(rows, func(r standingsRow) int64 {
+				return r.from.ID
+			})
+			assert.ElementsMatch(t, []int64{o1.From.ID, o2.From.ID}, got)
+		}
+	})
+}
@@ -0,0 +1,474 @@
+package clones
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	ihumanize "github.com/ErikKalkoken/evebuddy/internal/humanize"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+	"github.com/ErikKalkoken/evebuddy/internal/xwidget"
+)
+
+type capitalReadinessRow struct {
+	character *app.Character
+	tags      set.Set[string]
+}
+
+func (r capitalReadinessRow) characterName() string {
+	return r.character.NameOrZero()
+}
+
+func (r capitalReadinessRow) shipName() string {
+	ship, ok := r.character.Ship.Value()
+	if !ok {
+		return "?"
+	}
+	return ship.Name
+}
+
+func (r capitalReadinessRow) locationName() string {
+	location, ok := r.character.Location.Value()
+	if !ok {
+		return "?"
+	}
+	return location.DisplayName()
+}
+
+func (r capitalReadinessRow) fatigue(now time.Time) time.Duration {
+	return r.character.JumpFatigueRemaining(now)
+}
+
+func (r capitalReadinessRow) fatigueDisplay(now time.Time) (string, fyne.ThemeColorName) {
+	d := r.fatigue(now)
+	if d == 0 {
+		return "None", theme.ColorNameSuccess
+	}
+	return ihumanize.Duration(d), theme.ColorNameWarning
+}
+
+func (r capitalReadinessRow) blueTimer(now time.Time) time.Duration {
+	v, ok := r.character.JumpActivationExpiresAt().Value()
+	if !ok || !v.After(now) {
+		return 0
+	}
+	return v.Sub(now)
+}
+
+func (r capitalReadinessRow) blueTimerDisplay(now time.Time) (string, fyne.ThemeColorName) {
+	d := r.blueTimer(now)
+	if d == 0 {
+		return "Ready", theme.ColorNameSuccess
+	}
+	return ihumanize.Duration(d), theme.ColorNameError
+}
+
+// nextCloneJump returns the time until the next clone jump is available
+// and reports whether it is known.
+func (r capitalReadinessRow) nextCloneJump(now time.Time) (time.Duration, bool) {
+	v, ok := r.character.NextCloneJump.Value()
+	if !ok {
+		return 0, false
+	}
+	if v.IsZero() || !v.After(now) {
+		return 0, true
+	}
+	return v.Sub(now), true
+}
+
+func (r capitalReadinessRow) nextCloneJumpDisplay(now time.Time) (string, fyne.ThemeColorName) {
+	d, ok := r.nextCloneJump(now)
+	if !ok {
+		return "?", theme.ColorNameForeground
+	}
+	if d == 0 {
+		return "NOW", theme.ColorNameSuccess
+	}
+	return ihumanize.Duration(d), theme.ColorNameError
+}
+
+func (r capitalReadinessRow) watchedDisplay() string {
+	if r.character.IsJumpFatigueWatched {
+		return "Yes"
+	}
+	return "No"
+}
+
+// CapitalReadiness is a widget for showing the jump readiness of all characters,
+// e.g. their jump fatigue and when the next clone jump is available.
+type CapitalReadiness struct {
+	widget.BaseWidget
+
+	body            fyne.CanvasObject
+	columnSorter    *xwidget.ColumnSorter[capitalReadinessRow]
+	footer          *widget.Label
+	rows            []capitalReadinessRow
+	rowsFiltered    []capitalReadinessRow
+	selectCharacter *kxwidget.FilterChipSelect
+	selectFatigue   *kxwidget.FilterChipSelect
+	selectTag       *kxwidget.FilterChipSelect
+	sortButton      *xwidget.SortButton[capitalReadinessRow]
+	u               baseUI
+}
+
+const (
+	capitalReadinessColCharacter = iota + 1
+	capitalReadinessColShip
+	capitalReadinessColLocation
+	capitalReadinessColFatigue
+	capitalReadinessColBlueTimer
+	capitalReadinessColCloneJump
+	capitalReadinessColWatched
+)
+
+const (
+	capitalReadinessFatigueNone = "No fatigue"
+	capitalReadinessFatigueSome = "Has fatigue"
+)
+
+func NewCapitalReadiness(u baseUI) *CapitalReadiness {
+	columns := xwidget.NewDataColumns([]xwidget.DataColumn[capitalReadinessRow]{
+		ui.MakeEveEntityColumn(ui.MakeEveEntityColumnParams[capitalReadinessRow]{
+			ColumnID: capitalReadinessColCharacter,
+			EIS:      u.EVEImage(),
+			GetEntity: func(r capitalReadinessRow) *app.EveEntity {
+				return &app.EveEntity{
+					ID:       r.character.ID,
+					Name:     r.characterName(),
+					Category: app.EveEntityCharacter,
+				}
+			},
+			IsAvatar: true,
+			Label:    "Character",
+		}), {
+			ID:    capitalReadinessColShip,
+			Label: "Ship",
+			Width: 150,
+			Sort: func(a, b capitalReadinessRow) int {
+				return cmp.Compare(a.shipName(), b.shipName())
+			},
+			Update: func(r capitalReadinessRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.shipName())
+			},
+		}, {
+			ID:    capitalReadinessColLocation,
+			Label: "Location",
+			Width: ui.ColumnWidthLocation,
+			Sort: func(a, b capitalReadinessRow) int {
+				return cmp.Compare(a.locationName(), b.locationName())
+			},
+			Update: func(r capitalReadinessRow, co fyne.CanvasObject) {
+				if location, ok := r.character.Location.Value(); ok {
+					co.(*xwidget.RichText).Set(location.DisplayRichText())
+				} else {
+					co.(*xwidget.RichText).SetWithText("?")
+				}
+			},
+		}, {
+			ID:    capitalReadinessColFatigue,
+			Label: "Jump Fatigue",
+			Width: 120,
+			Sort: func(a, b capitalReadinessRow) int {
+				now := time.Now()
+				return cmp.Compare(a.fatigue(now), b.fatigue(now))
+			},
+			Update: func(r capitalReadinessRow, co fyne.CanvasObject) {
+				s, color := r.fatigueDisplay(time.Now())
+				co.(*xwidget.RichText).SetWithText(s, widget.RichTextStyle{
+					ColorName: color,
+				})
+			},
+		}, {
+			ID:    capitalReadinessColBlueTimer,
+			Label: "Blue Timer",
+			Width: 100,
+			Sort: func(a, b capitalReadinessRow) int {
+				now := time.Now()
+				return cmp.Compare(a.blueTimer(now), b.blueTimer(now))
+			},
+			Update: func(r capitalReadinessRow, co fyne.CanvasObject) {
+				s, color := r.blueTimerDisplay(time.Now())
+				co.(*xwidget.RichText).SetWithText(s, widget.RichTextStyle{
+					ColorName: color,
+				})
+			},
+		}, {
+			ID:    capitalReadinessColCloneJump,
+			Label: "Clone Jump",
+			Width: 100,
+			Sort: func(a, b capitalReadinessRow) int {
+				now := time.Now()
+				x, _ := a.nextCloneJump(now)
+				y, _ := b.nextCloneJump(now)
+				return cmp.Compare(x, y)
+			},
+			Update: func(r capitalReadinessRow, co fyne.CanvasObject) {
+				s, color := r.nextCloneJumpDisplay(time.Now())
+				co.(*xwidget.RichText).SetWithText(s, widget.RichTextStyle{
+					ColorName: color,
+				})
+			},
+		}, {
+			ID:    capitalReadinessColWatched,
+			Label: "Watched",
+			Width: 80,
+			Update: func(r capitalReadinessRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.watchedDisplay())
+			},
+		}})
+	a := &CapitalReadiness{
+		columnSorter: xwidget.NewColumnSorter(columns, capitalReadinessColCharacter, xwidget.SortAsc),
+		footer:       ui.NewLabelWithTruncation(""),
+		u:            u,
+	}
+	a.ExtendBaseWidget(a)
+	if !a.u.IsMobile() {
+		a.body = xwidget.MakeDataTable(
+			columns,
+			&a.rowsFiltered,
+			func() fyne.CanvasObject {
+				x := xwidget.NewRichText()
+				x.Truncation = fyne.TextTruncateClip
+				return x
+			},
+			a.columnSorter,
+			a.filterRowsAsync,
+			func(_ int, r capitalReadinessRow) {
+				a.toggleWatched(r)
+			},
+		)
+	} else {
+		a.body = xwidget.MakeDataList(
+			columns,
+			&a.rowsFiltered,
+			func(col int, r capitalReadinessRow) []widget.RichTextSegment {
+				now := time.Now()
+				var s []widget.RichTextSegment
+				switch col {
+				case capitalReadinessColCharacter:
+					s = xwidget.RichTextSegmentsFromText(r.characterName())
+				case capitalReadinessColShip:
+					s = xwidget.RichTextSegmentsFromText(r.shipName())
+				case capitalReadinessColLocation:
+					s = xwidget.RichTextSegmentsFromText(r.locationName())
+				case capitalReadinessColFatigue:
+					text, color := r.fatigueDisplay(now)
+					s = xwidget.RichTextSegmentsFromText(text, widget.RichTextStyle{ColorName: color})
+				case capitalReadinessColBlueTimer:
+					text, color := r.blueTimerDisplay(now)
+					s = xwidget.RichTextSegmentsFromText(text, widget.RichTextStyle{ColorName: color})
+				case capitalReadinessColCloneJump:
+					text, color := r.nextCloneJumpDisplay(now)
+					s = xwidget.RichTextSegmentsFromText(text, widget.RichTextStyle{ColorName: color})
+				case capitalReadinessColWatched:
+					s = xwidget.RichTextSegmentsFromText(r.watchedDisplay())
+				}
+				return s
+			},
+			func(r capitalReadinessRow) {
+				a.toggleWatched(r)
+			},
+		)
+	}
+
+	a.selectCharacter = kxwidget.NewFilterChipSelect("Character", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.selectFatigue = kxwidget.NewFilterChipSelect("Jump Fatigue", []string{
+		capitalReadinessFatigueNone,
+		capitalReadinessFatigueSome,
+	}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.selectTag = kxwidget.NewFilterChipSelect("Tag", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.sortButton = a.columnSorter.NewSortButton(func() {
+		a.filterRowsAsync(-1)
+	})
+
+	// signals
+	a.u.Signals().AppInit.AddListener(func(ctx context.Context, _ struct{}) {
+		a.update(ctx)
+	})
+	a.u.Signals().CharacterSectionChanged.AddListener(func(ctx context.Context, arg app.CharacterSectionUpdated) {
+		switch arg.Section {
+		case app.SectionCharacterJumpClones,
+			app.SectionCharacterJumpFatigue,
+			app.SectionCharacterLocation,
+			app.SectionCharacterShip:
+			a.update(ctx)
+		}
+	})
+	a.u.Signals().CharacterAdded.AddListener(func(ctx context.Context, _ *app.Character) {
+		a.update(ctx)
+	})
+	a.u.Signals().CharacterChanged.AddListener(func(ctx context.Context, _ int64) {
+		a.update(ctx)
+	})
+	a.u.Signals().CharacterRemoved.AddListener(func(ctx context.Context, _ *app.EntityShort) {
+		a.update(ctx)
+	})
+	a.u.Signals().TagsChanged.AddListener(func(ctx context.Context, _ struct{}) {
+		a.update(ctx)
+	})
+	a.u.Signals().RefreshTickerExpired.AddListener(func(_ context.Context, _ struct{}) {
+		fyne.Do(func() {
+			a.body.Refresh()
+		})
+	})
+	return a
+}
+
+func (a *CapitalReadiness) CreateRenderer() fyne.WidgetRenderer {
+	filters := container.NewHBox(
+		a.selectCharacter,
+		a.selectFatigue,
+		a.selectTag,
+	)
+	if a.u.IsMobile() {
+		filters.Add(a.sortButton)
+	}
+	c := container.NewBorder(
+		container.NewHScroll(filters),
+		a.footer,
+		nil,
+		nil,
+		a.body,
+	)
+	return widget.NewSimpleRenderer(c)
+}
+
+func (a *CapitalReadiness) filterRowsAsync(sortCol int) {
+	totalRows := len(a.rows)
+	rows := slices.Clone(a.rows)
+	character := a.selectCharacter.Selected
+	fatigue := a.selectFatigue.Selected
+	tag := a.selectTag.Selected
+	sortCol, dir, doSort := a.columnSorter.CalcSort(sortCol)
+
+	go func() {
+		now := time.Now()
+		// filter
+		if character != "" {
+			rows = slices.DeleteFunc(rows, func(r capitalReadinessRow) bool {
+				return r.characterName() != character
+			})
+		}
+		switch fatigue {
+		case capitalReadinessFatigueNone:
+			rows = slices.DeleteFunc(rows, func(r capitalReadinessRow) bool {
+				return r.fatigue(now) > 0
+			})
+		case capitalReadinessFatigueSome:
+			rows = slices.DeleteFunc(rows, func(r capitalReadinessRow) bool {
+				return r.fatigue(now) == 0
+			})
+		}
+		if tag != "" {
+			rows = slices.DeleteFunc(rows, func(r capitalReadinessRow) bool {
+				return !r.tags.Contains(tag)
+			})
+		}
+		a.columnSorter.SortRows(rows, sortCol, dir, doSort)
+		// set data & refresh
+		tagOptions := slices.Sorted(set.Union(xslices.Map(rows, func(r capitalReadinessRow) set.Set[string] {
+			return r.tags
+		})...).All())
+		characterOptions := xslices.Map(rows, func(r capitalReadinessRow) string {
+			return r.characterName()
+		})
+		var fatigued int
+		for _, r := range rows {
+			if r.fatigue(now) > 0 {
+				fatigued++
+			}
+		}
+		footer := fmt.Sprintf("Showing %d / %d characters • %d with jump fatigue", len(rows), totalRows, fatigued)
+
+		fyne.Do(func() {
+			a.footer.Text = footer
+			a.footer.Importance = widget.MediumImportance
+			a.footer.Refresh()
+			a.selectCharacter.SetOptions(characterOptions)
+			a.selectTag.SetOptions(tagOptions)
+			a.rowsFiltered = rows
+			a.body.Refresh()
+		})
+	}()
+}
+
+func (a *CapitalReadiness) update(ctx context.Context) {
+	rows, err := a.fetchRows(ctx)
+	if err != nil {
+		slog.Error("Failed to refresh capital readiness UI", "err", err)
+		fyne.Do(func() {
+			a.footer.Text = "ERROR: " + a.u.ErrorDisplay(err)
+			a.footer.Importance = widget.DangerImportance
+			a.footer.Refresh()
+		})
+		return
+	}
+	fyne.Do(func() {
+		a.rows = rows
+		a.filterRowsAsync(-1)
+	})
+}
+
+func (a *CapitalReadiness) fetchRows(ctx context.Context) ([]capitalReadinessRow, error) {
+	characters, err := a.u.Character().ListCharactersWithJumpTimers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rows []capitalReadinessRow
+	for _, c := range characters {
+		tags, err := a.u.Character().ListTagsForCharacter(ctx, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, capitalReadinessRow{character: c, tags: tags})
+	}
+	return rows, nil
+}
+
+// toggleWatched asks the user to toggle the jump fatigue watcher of a character.
+func (a *CapitalReadiness) toggleWatched(r capitalReadinessRow) {
+	c := r.character
+	on := !c.IsJumpFatigueWatched
+	var title, message string
+	if on {
+		title = "Watch Jump Fatigue"
+		message = fmt.Sprintf("Get notified when the jump fatigue of %s expires?", r.characterName())
+	} else {
+		title = "Stop Watching Jump Fatigue"
+		message = fmt.Sprintf("Stop getting notified when the jump fatigue of %s expires?", r.characterName())
+	}
+	dialog.ShowConfirm(title, message, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		go func() {
+			ctx := context.Background()
+			err := a.u.Character().UpdateIsJumpFatigueWatched(ctx, c.ID, on)
+			if err != nil {
+				slog.Error("Failed to update jump fatigue watcher", "characterID", c.ID, "error", err)
+				a.u.ShowSnackbar("Failed to update jump fatigue watcher: " + a.u.ErrorDisplay(err))
+				return
+			}
+			a.u.Signals().CharacterChanged.Emit(ctx, c.ID)
+		}()
+	}, a.u.MainWindow())
+}
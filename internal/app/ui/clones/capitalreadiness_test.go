@@ -0,0 +1,85 @@
+package clones
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil"
+	"github.com/ErikKalkoken/evebuddy/internal/app/testutil/testdouble"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func TestCapitalReadinessRow(t *testing.T) {
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	t.Run("should report no fatigue and ready timers", func(t *testing.T) {
+		r := capitalReadinessRow{character: &app.Character{
+			JumpFatigueExpiresAt: optional.New(now.Add(-time.Hour)),
+			LastJumpAt:           optional.New(now.Add(-3 * time.Hour)),
+			NextCloneJump:        optional.New(time.Time{}),
+		}}
+		s, color := r.fatigueDisplay(now)
+		xassert.Equal(t, "None", s)
+		xassert.Equal(t, theme.ColorNameSuccess, color)
+		s, _ = r.blueTimerDisplay(now)
+		xassert.Equal(t, "Ready", s)
+		s, _ = r.nextCloneJumpDisplay(now)
+		xassert.Equal(t, "NOW", s)
+	})
+	t.Run("should report remaining fatigue and timers", func(t *testing.T) {
+		r := capitalReadinessRow{character: &app.Character{
+			JumpFatigueExpiresAt: optional.New(now.Add(95 * time.Minute)),
+			LastJumpAt:           optional.New(now.Add(-5 * time.Minute)),
+			NextCloneJump:        optional.New(now.Add(2 * time.Hour)),
+		}}
+		xassert.Equal(t, 95*time.Minute, r.fatigue(now))
+		xassert.Equal(t, 5*time.Minute, r.blueTimer(now))
+		d, ok := r.nextCloneJump(now)
+		assert.True(t, ok)
+		xassert.Equal(t, 2*time.Hour, d)
+		_, color := r.fatigueDisplay(now)
+		xassert.Equal(t, theme.ColorNameWarning, color)
+	})
+	t.Run("should report unknown clone jump", func(t *testing.T) {
+		r := capitalReadinessRow{character: &app.Character{}}
+		s, _ := r.nextCloneJumpDisplay(now)
+		xassert.Equal(t, "?", s)
+		xassert.Equal(t, "?", r.shipName())
+		xassert.Equal(t, "?", r.locationName())
+	})
+}
+
+func TestCapitalReadiness_Update(t *testing.T) {
+	db, st, factory := testutil.NewDBOnDisk(t)
+	defer db.Close()
+
+	t.Run("should show all characters", func(t *testing.T) {
+		// given
+		testutil.MustTruncateTables(db)
+		c1 := factory.CreateCharacterFull(storage.CreateCharacterParams{
+			JumpFatigueExpiresAt: optional.New(time.Now().Add(time.Hour)),
+			LastJumpAt:           optional.New(time.Now().Add(-time.Minute)),
+		})
+		c2 := factory.CreateCharacterFull()
+		a := NewCapitalReadiness(testdouble.NewUIFake(testdouble.UIParams{
+			App:     test.NewTempApp(t),
+			Storage: st,
+		}))
+
+		// when
+		a.update(t.Context())
+
+		// then
+		got := xslices.Map(a.rows, func(r capitalReadinessRow) int64 {
+			return r.character.ID
+		})
+		assert.ElementsMatch(t, []int64{c1.ID, c2.ID}, got)
+	})
+}
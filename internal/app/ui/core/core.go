@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -22,23 +23,30 @@ import (
 	"github.com/ErikKalkoken/go-set"
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/backup"
 	"github.com/ErikKalkoken/evebuddy/internal/app/characterservice"
 	"github.com/ErikKalkoken/evebuddy/internal/app/corporationservice"
 	"github.com/ErikKalkoken/evebuddy/internal/app/esistatusservice"
 	"github.com/ErikKalkoken/evebuddy/internal/app/eveuniverseservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationsink"
 	"github.com/ErikKalkoken/evebuddy/internal/app/settings"
 	"github.com/ErikKalkoken/evebuddy/internal/app/statuscache"
+	"github.com/ErikKalkoken/evebuddy/internal/app/tokenkey"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui/assets"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui/characters"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui/clones"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui/contracts"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui/corporations"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui/fittings"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui/gamesearch"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui/industry"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui/infoviewer"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui/killmails"
+	uisettings "github.com/ErikKalkoken/evebuddy/internal/app/ui/settings"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui/skills"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui/wallets"
+	"github.com/ErikKalkoken/evebuddy/internal/everefservice"
 	"github.com/ErikKalkoken/evebuddy/internal/fynetools"
 	"github.com/ErikKalkoken/evebuddy/internal/github"
 	"github.com/ErikKalkoken/evebuddy/internal/icons"
@@ -58,6 +66,7 @@ const (
 
 // ticker
 const (
+	backupTick              = 15 * time.Minute
 	refreshUITick           = 30 * time.Second
 	characterUpdateTick     = 60 * time.Second
 	corporationUpdateTick   = 60 * time.Second
@@ -80,13 +89,17 @@ type UIParams struct {
 	Signals     *app.Signals
 	Settings    *settings.Settings
 	// optional
+	Backup           *backup.Backup
 	ClearCacheFunc   func()
 	ConcurrencyLimit int
 	DataPaths        map[string]string
+	EveRef           *everefservice.EveRefService
 	IsFakeMobile     bool
 	IsMobile         bool
 	IsOfflineMode    bool
 	IsUpdateDisabled bool
+	TokenKey         *tokenkey.Manager
+	Webhook          *notificationsink.Webhook
 }
 
 // baseUI represents the core UI logic and is used by both the desktop and mobile UI.
@@ -117,16 +130,22 @@ type baseUI struct {
 	characterCommunications  *characters.Communications
 	characterContacts        *characters.Contacts
 	characterCorporation     *corporations.CorporationSheet
+	characterFittings        *fittings.Fittings
 	characterJumpClones      *clones.CharacterClones
+	characterKillmails       *killmails.Killmails
 	characterMails           *characters.Mails
 	characterOverview        *characters.Overview
 	characterSheet           *characters.CharacterSheet
 	characterShips           *skills.FlyableShips
 	characterSkillCatalogue  *skills.Catalogue
+	characterSkillPlans      *skills.SkillPlans
 	characterSkillQueue      *skills.Queue
 	characterWallet          *wallets.CharacterWallet
+	calendar                 *characters.Calendar
+	capitalReadiness         *clones.CapitalReadiness
 	clones                   *clones.Clones
 	colonies                 *industry.Colonies
+	colonyProduction         *industry.ColonyProduction
 	contractList             *contracts.Contracts
 	contractSlotsPersonal    *contracts.Slots
 	contractSlotsCorporation *contracts.Slots
@@ -134,35 +153,48 @@ type baseUI struct {
 	corporationAssetSearch   *assets.Search
 	corporationContracts     *contracts.Contracts
 	corporationIndyJobs      *industry.Jobs
+	corporationKillmails     *killmails.Killmails
 	corporationMember        *corporations.Members
+	corporationRoleChanges   *corporations.RoleChanges
+	corporationRoles         *corporations.MemberRoles
 	corporationSheet         *corporations.CorporationSheet
 	corporationStructures    *corporations.Structures
 	corporationWallets       map[app.Division]*wallets.CorporationWallet
 	gameSearch               *gamesearch.GameSearch
+	industryBlueprints       *industry.Blueprints
+	industryCalculator       *industry.Calculator
 	industryJobs             *industry.Jobs
+	industryMiningLedger     *industry.Mining
+	industryMiningObservers  *industry.Mining
 	iw                       *infoviewer.InfoViewer
 	loyaltyPoints            *wallets.LoyaltyPoints
 	marketOrdersBuy          *industry.MarketOrders
 	marketOrdersSell         *industry.MarketOrders
+	messageSearch            *characters.MessageSearch
 	skillSearch              *skills.Search
 	industrySlotsManufacturing       *industry.Slots
 	industrySlotsReactions           *industry.Slots
 	industrySlotsResearch            *industry.Slots
 	snackbar                 *xwidget.Snackbar
+	standings                *characters.Standings
 	statusText               *statusText
 	training                 *skills.Training
 	unifiedCommunications    *characters.Communications
 	wealth                   *wallets.Wealth
 
 	// Services
+	bs       *backup.Backup
 	cs       *characterservice.CharacterService
 	eis      ui.EVEImageService
 	ess      *esistatusservice.ESIStatusService
+	ers      *everefservice.EveRefService
 	eus      *eveuniverseservice.EVEUniverseService
 	js       *janiceservice.JaniceService
 	rs       *corporationservice.CorporationService
 	scs      *statuscache.StatusCache
 	settings *settings.Settings
+	tk       *tokenkey.Manager
+	webhook  *notificationsink.Webhook
 
 	// UI state & configuration
 	app                            fyne.App
@@ -223,6 +255,7 @@ func newBaseUI(arg UIParams) *baseUI {
 	corporationAvatarPlaceholder64, _ := fynetools.MakeAvatar(icons.Corporationplaceholder64Png)
 	u := &baseUI{
 		app:                            arg.App,
+		bs:                             arg.Backup,
 		concurrencyLimit:               -1, // Default is no limit
 		corporationWallets:             make(map[app.Division]*wallets.CorporationWallet),
 		cs:                             arg.Character,
@@ -238,6 +271,7 @@ func newBaseUI(arg UIParams) *baseUI {
 		settings:                       arg.Settings,
 		signals:                        arg.Signals,
 		statusText:                     newStatusText(),
+		tk:                             arg.TokenKey,
 		windows:                        make(map[string]fyne.Window),
 		characterAvatarPlaceholder64:   characterAvatarPlaceholder64,
 		corporationAvatarPlaceholder64: corporationAvatarPlaceholder64,
@@ -259,6 +293,16 @@ func newBaseUI(arg UIParams) *baseUI {
 	} else {
 		u.dataPaths = make(xmaps.OrderedMap[string, string])
 	}
+	if arg.EveRef != nil {
+		u.ers = arg.EveRef
+	} else {
+		u.ers = everefservice.New(http.DefaultClient)
+	}
+	if arg.Webhook != nil {
+		u.webhook = arg.Webhook
+	} else {
+		u.webhook = notificationsink.NewWebhook(notificationsink.WebhookParams{Settings: arg.Settings})
+	}
 
 	if !u.isMobile {
 		xwidget.DefaultImageScaleMode = canvas.ImageScaleFastest
@@ -420,16 +464,22 @@ func newBaseUI(arg UIParams) *baseUI {
 	u.characterContacts = characters.NewContacts(u)
 	u.characterCommunications = characters.NewCommunicationsForCharacter(u)
 	u.characterCorporation = corporations.NewCorporationSheet(u, false)
+	u.characterFittings = fittings.NewFittings(u)
 	u.characterJumpClones = clones.NewCharacterClones(u)
+	u.characterKillmails = killmails.NewCharacterKillmails(u)
 	u.characterMails = characters.NewMails(u)
 	u.characterOverview = characters.NewOverview(u)
 	u.characterSheet = characters.NewCharacterSheet(u)
 	u.characterShips = skills.NewFlyableShips(u)
 	u.characterSkillCatalogue = skills.NewCatalogue(u)
+	u.characterSkillPlans = skills.NewSkillPlans(u)
 	u.characterSkillQueue = skills.NewQueue(u)
 	u.characterWallet = wallets.NewCharacterWallet(u)
+	u.calendar = characters.NewCalendar(u)
+	u.capitalReadiness = clones.NewCapitalReadiness(u)
 	u.clones = clones.NewClones(u)
 	u.colonies = industry.NewColonies(u)
+	u.colonyProduction = industry.NewColonyProduction(u)
 	u.contractList = contracts.NewContractsForCharacters(u)
 	u.contractSlotsPersonal = contracts.NewSlots(u, false)
 	u.contractSlotsCorporation = contracts.NewSlots(u, true)
@@ -437,23 +487,32 @@ func newBaseUI(arg UIParams) *baseUI {
 	u.corporationAssetSearch = assets.NewSearchForCorporation(u)
 	u.corporationContracts = contracts.NewContractsForCorporation(u)
 	u.corporationIndyJobs = industry.NewJobsForCorporation(u)
+	u.corporationKillmails = killmails.NewCorporationKillmails(u)
 
 	u.corporationMember = corporations.NewMembers(u)
+	u.corporationRoleChanges = corporations.NewRoleChanges(u)
+	u.corporationRoles = corporations.NewMemberRoles(u)
 	u.corporationStructures = corporations.NewStructures(u)
 	u.corporationSheet = corporations.NewCorporationSheet(u, true)
 	for _, d := range app.Divisions {
 		u.corporationWallets[d] = wallets.NewCorporationWallet(u, d)
 	}
 	u.gameSearch = gamesearch.NewGameSearch(u)
+	u.industryBlueprints = industry.NewBlueprints(u)
+	u.industryCalculator = industry.NewCalculator(u)
 	u.industryJobs = industry.NewJobsForOverview(u)
+	u.industryMiningLedger = industry.NewMining(u, false)
+	u.industryMiningObservers = industry.NewMining(u, true)
 	u.loyaltyPoints = wallets.NewLoyaltyPoints(u)
 	u.marketOrdersBuy = industry.NewMarketOrders(u, true)
 	u.marketOrdersSell = industry.NewMarketOrders(u, false)
+	u.messageSearch = characters.NewMessageSearch(u)
 	u.industrySlotsManufacturing = industry.NewSlots(u, app.ManufacturingJob)
 	u.industrySlotsReactions = industry.NewSlots(u, app.ReactionJob)
 	u.industrySlotsResearch = industry.NewSlots(u, app.ScienceJob)
 	u.snackbar = xwidget.NewSnackbar(u.window.Canvas())
 	u.skillSearch = skills.NewSearch(u)
+	u.standings = characters.NewStandings(u)
 	u.training = skills.NewTraining(u)
 	u.wealth = wallets.NewWealth(u)
 
@@ -557,6 +616,22 @@ func (u *baseUI) Start() bool {
 		if u.onAppFirstStarted != nil {
 			u.onAppFirstStarted()
 		}
+		if u.tk != nil && u.tk.State() == tokenkey.Locked && u.tk.Source() == tokenkey.SourcePassphrase {
+			fyne.Do(func() {
+				uisettings.ShowUnlockTokensDialog(u)
+			})
+		}
+		if u.tk != nil && u.tk.State() == tokenkey.Unencrypted {
+			n, err := u.tk.UnencryptedTokens(ctx)
+			if err != nil {
+				slog.Error("Failed to count unencrypted tokens", "error", err)
+			} else if n > 0 {
+				u.ShowSnackbarWithTimeout(
+					"WARNING: Character tokens are stored unencrypted. Set a passphrase in the settings to encrypt them",
+					15*time.Second,
+				)
+			}
+		}
 		if !u.isOfflineMode && !u.isUpdateDisabled.Load() {
 			time.Sleep(delayBeforeUpdateStatus) // allow app to fully load before updating
 			slog.Info("Starting update ticker")
@@ -566,6 +641,9 @@ func (u *baseUI) Start() bool {
 		} else {
 			slog.Info("Update ticker disabled")
 		}
+		if u.bs != nil {
+			u.bs.StartTicker(ctx, backupTick)
+		}
 	}()
 	return true
 }
@@ -590,6 +668,16 @@ func (u *baseUI) ClearAllCaches() {
 	slog.Info("Cleared all caches")
 }
 
+// Backup returns the backup service or nil if not available.
+func (u *baseUI) Backup() *backup.Backup {
+	return u.bs
+}
+
+// TokenKey returns the manager of the token encryption key or nil if not available.
+func (u *baseUI) TokenKey() *tokenkey.Manager {
+	return u.tk
+}
+
 func (u *baseUI) Character() *characterservice.CharacterService {
 	return u.cs
 }
@@ -658,6 +746,10 @@ func (u *baseUI) IsUpdateDisabled() bool {
 	return u.isUpdateDisabled.Load()
 }
 
+func (u *baseUI) EveRef() *everefservice.EveRefService {
+	return u.ers
+}
+
 func (u *baseUI) Janice() *janiceservice.JaniceService {
 	return u.js
 }
@@ -666,6 +758,10 @@ func (u *baseUI) Settings() *settings.Settings {
 	return u.settings
 }
 
+func (u *baseUI) Webhook() *notificationsink.Webhook {
+	return u.webhook
+}
+
 func (u *baseUI) ShowCharacter(ctx context.Context, characterID int64) {
 	character := u.character.Load()
 	if u.onShowCharacter != nil {
@@ -109,7 +109,10 @@ func NewDesktopUI(params UIParams) *DesktopUI {
 	unifiedCommunications := xwidget.NewNavPage(
 		"Communications",
 		theme.NewThemedResource(icons.MessageSvg),
-		newContentPage("Communications", u.unifiedCommunications),
+		newContentPage("Communications", container.NewAppTabs(
+			container.NewTabItem("Messages", u.unifiedCommunications),
+			container.NewTabItem("Search", u.messageSearch),
+		)),
 	)
 	u.unifiedCommunications.OnUpdate = func(count optional.Optional[int]) {
 		var s string
@@ -143,7 +146,10 @@ func NewDesktopUI(params UIParams) *DesktopUI {
 	overviewColonies := xwidget.NewNavPage(
 		"Colonies",
 		theme.NewThemedResource(icons.EarthSvg),
-		newContentPage("Colonies", u.colonies),
+		newContentPage("Colonies", container.NewAppTabs(
+			container.NewTabItem("Colonies", u.colonies),
+			container.NewTabItem("Production", u.colonyProduction),
+		)),
 	)
 	u.colonies.OnUpdate = func(_, expired int) {
 		var s string
@@ -162,8 +168,14 @@ func NewDesktopUI(params UIParams) *DesktopUI {
 				container.NewTabItem("Manufacturing", u.industrySlotsManufacturing),
 				container.NewTabItem("Science", u.industrySlotsResearch),
 				container.NewTabItem("Reactions", u.industrySlotsReactions),
-			))),
-		),
+			)),
+			container.NewTabItem("Blueprints", u.industryBlueprints),
+			container.NewTabItem("Calculator", u.industryCalculator),
+			container.NewTabItem("Mining", container.NewAppTabs(
+				container.NewTabItem("Ledger", u.industryMiningLedger),
+				container.NewTabItem("Moon Mining", u.industryMiningObservers),
+			)),
+		)),
 	)
 	u.industryJobs.OnUpdate = func(count int) {
 		var badge string
@@ -206,12 +218,18 @@ func NewDesktopUI(params UIParams) *DesktopUI {
 	homeNav = xwidget.NewNavDrawer(
 		overview,
 		allAssets,
+		xwidget.NewNavPage(
+			"Calendar",
+			theme.NewThemedResource(icons.AccesstimeSvg),
+			newContentPage("Calendar", u.calendar),
+		),
 		xwidget.NewNavPage(
 			"Clones",
 			theme.NewThemedResource(icons.HeadSnowflakeSvg),
 			newContentPage("Clones", container.NewAppTabs(
 				container.NewTabItem("Augmentations", u.augmentations),
 				container.NewTabItem("Jump Clones", u.clones),
+				container.NewTabItem("Capital Readiness", u.capitalReadiness),
 			)),
 		),
 		unifiedCommunications,
@@ -225,6 +243,11 @@ func NewDesktopUI(params UIParams) *DesktopUI {
 		),
 		marketOrders,
 		skills,
+		xwidget.NewNavPage(
+			"Standings",
+			theme.NewThemedResource(icons.CheckDecagramSvg),
+			newContentPage("Standings", u.standings),
+		),
 		wealth,
 	)
 	homeNav.OnSelectItem = func(it *xwidget.NavItem) {
@@ -273,6 +296,7 @@ func NewDesktopUI(params UIParams) *DesktopUI {
 				container.NewTabItem("Catalogue", u.characterSkillCatalogue),
 				container.NewTabItem("Training", u.characterSkillQueue),
 				container.NewTabItem("Ships", u.characterShips),
+				container.NewTabItem("Plans", u.characterSkillPlans),
 			),
 		),
 	)
@@ -310,6 +334,16 @@ func NewDesktopUI(params UIParams) *DesktopUI {
 			newContentPage("Contacts", u.characterContacts),
 		),
 		characterCommunicationsNav,
+		xwidget.NewNavPage(
+			"Fittings",
+			theme.NewThemedResource(icons.ShipWheelSvg),
+			newContentPage("Fittings", u.characterFittings),
+		),
+		xwidget.NewNavPage(
+			"Killmails",
+			icons.ZkillboardPng,
+			newContentPage("Killmails", u.characterKillmails),
+		),
 		characterMailNav,
 		characterSkillsNav,
 		characterWalletNav,
@@ -371,6 +405,12 @@ func NewDesktopUI(params UIParams) *DesktopUI {
 		corporationNav.SetItemBadge(corpIndustryItem, badge)
 	}
 
+	corpKillmailsItem := xwidget.NewNavPage(
+		"Killmails",
+		icons.ZkillboardPng,
+		newContentPage("Killmails", u.corporationKillmails),
+	)
+
 	corpStructuresItem := xwidget.NewNavPage(
 		"Structures",
 		theme.NewThemedResource(icons.OfficeBuildingSvg),
@@ -390,6 +430,8 @@ func NewDesktopUI(params UIParams) *DesktopUI {
 		newContentPage("Corporation Sheet", container.NewAppTabs(
 			container.NewTabItem("Corporation", u.corporationSheet),
 			container.NewTabItem("Members", u.corporationMember),
+			container.NewTabItem("Roles", u.corporationRoles),
+			container.NewTabItem("Role Changes", u.corporationRoleChanges),
 		)),
 	)
 
@@ -399,6 +441,7 @@ func NewDesktopUI(params UIParams) *DesktopUI {
 			corpAssetsItem,
 			corpContractsItem,
 			corpIndustryItem,
+			corpKillmailsItem,
 			corpStructuresItem,
 		},
 		corpWalletItems,
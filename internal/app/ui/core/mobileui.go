@@ -134,6 +134,7 @@ func NewMobileUI(params UIParams) *MobileUI {
 						container.NewTabItem("Catalogue", u.characterSkillCatalogue),
 						container.NewTabItem("Training", u.characterSkillQueue),
 						container.NewTabItem("Ships", u.characterShips),
+						container.NewTabItem("Plans", u.characterSkillPlans),
 					),
 				))
 		},
@@ -177,6 +178,22 @@ func NewMobileUI(params UIParams) *MobileUI {
 			},
 		),
 		navItemCommunications,
+		xwidget.NewNavListItem(
+			"Fittings",
+			theme.NewThemedResource(icons.ShipWheelSvg),
+			func() {
+				characterNav.Push(
+					newCharacterAppBar("Fittings", u.characterFittings))
+			},
+		),
+		xwidget.NewNavListItem(
+			"Killmails",
+			icons.ZkillboardPng,
+			func() {
+				characterNav.Push(
+					newCharacterAppBar("Killmails", u.characterKillmails))
+			},
+		),
 		navItemMail,
 		navItemSkills,
 		navItemWallet,
@@ -319,6 +336,14 @@ func NewMobileUI(params UIParams) *MobileUI {
 		},
 	)
 
+	corpKillmailsNav := xwidget.NewNavListItem(
+		"Killmails",
+		icons.ZkillboardPng,
+		func() {
+			corpNav.Push(newCorpAppBar("Killmails", u.corporationKillmails))
+		},
+	)
+
 	corpStructuresNav := xwidget.NewNavListItem(
 		"Structures",
 		theme.NewThemedResource(icons.OfficeBuildingSvg),
@@ -337,6 +362,8 @@ func NewMobileUI(params UIParams) *MobileUI {
 					container.NewAppTabs(
 						container.NewTabItem("Corporation", u.corporationSheet),
 						container.NewTabItem("Members", u.corporationMember),
+						container.NewTabItem("Roles", u.corporationRoles),
+						container.NewTabItem("Role Changes", u.corporationRoleChanges),
 					),
 				))
 		},
@@ -349,6 +376,7 @@ func NewMobileUI(params UIParams) *MobileUI {
 			corpAssetSearchNav,
 			corpContractsNav,
 			corpIndustryNav,
+			corpKillmailsNav,
 			corpStructuresNav,
 			corpWalletNav,
 		})...,
@@ -781,7 +809,10 @@ func makeHomeNav(u *MobileUI) (*xwidget.Navigator, *StatusBarItem) {
 		"Colonies",
 		theme.NewThemedResource(icons.EarthSvg),
 		func() {
-			homeNav.PushAndHideNavBar(xwidget.NewAppBar("Colonies", u.colonies))
+			homeNav.PushAndHideNavBar(xwidget.NewAppBar("Colonies", container.NewAppTabs(
+				container.NewTabItem("Colonies", u.colonies),
+				container.NewTabItem("Production", u.colonyProduction),
+			)))
 		},
 	)
 	u.colonies.OnUpdate = func(_, expired int) {
@@ -801,6 +832,12 @@ func makeHomeNav(u *MobileUI) (*xwidget.Navigator, *StatusBarItem) {
 						container.NewTabItem("Science", u.industrySlotsResearch),
 						container.NewTabItem("Reactions", u.industrySlotsReactions),
 					)),
+					container.NewTabItem("Blueprints", u.industryBlueprints),
+					container.NewTabItem("Calculator", u.industryCalculator),
+					container.NewTabItem("Mining", container.NewAppTabs(
+						container.NewTabItem("Ledger", u.industryMiningLedger),
+						container.NewTabItem("Moon Mining", u.industryMiningObservers),
+					)),
 				),
 			))
 		},
@@ -885,6 +922,9 @@ func makeHomeNav(u *MobileUI) (*xwidget.Navigator, *StatusBarItem) {
 				xwidget.NewAppBar(
 					"Communications",
 					u.unifiedCommunications.MessagePane,
+					kxwidget.NewIconButton(theme.SearchIcon(), func() {
+						homeNav.Push(xwidget.NewAppBar("Search", u.messageSearch))
+					}),
 					kxwidget.NewIconButtonWithMenu(theme.FolderIcon(), unifiedCommunicationsMenu),
 				),
 			)
@@ -925,6 +965,13 @@ func makeHomeNav(u *MobileUI) (*xwidget.Navigator, *StatusBarItem) {
 	homeList = xwidget.NewNavList(
 		navItemCharacters,
 		navItemAssets,
+		xwidget.NewNavListItem(
+			"Calendar",
+			theme.NewThemedResource(icons.AccesstimeSvg),
+			func() {
+				homeNav.Push(xwidget.NewAppBar("Calendar", u.calendar))
+			},
+		),
 		xwidget.NewNavListItem(
 			"Clones",
 			theme.NewThemedResource(icons.HeadSnowflakeSvg),
@@ -932,6 +979,7 @@ func makeHomeNav(u *MobileUI) (*xwidget.Navigator, *StatusBarItem) {
 				homeNav.Push(xwidget.NewAppBar("Clones", container.NewAppTabs(
 					container.NewTabItem("Augmentations", u.augmentations),
 					container.NewTabItem("Jump Clones", u.clones),
+					container.NewTabItem("Capital Readiness", u.capitalReadiness),
 				)))
 			},
 		),
@@ -959,6 +1007,13 @@ func makeHomeNav(u *MobileUI) (*xwidget.Navigator, *StatusBarItem) {
 			},
 		),
 		navItemSkills,
+		xwidget.NewNavListItem(
+			"Standings",
+			theme.NewThemedResource(icons.CheckDecagramSvg),
+			func() {
+				homeNav.Push(xwidget.NewAppBar("Standings", u.standings))
+			},
+		),
 		navItemWealth,
 	)
 	status := NewStatusBarItem(theme.NewThemedResource(icons.UpdateSvg), "?", func() {
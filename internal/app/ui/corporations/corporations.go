@@ -8,6 +8,7 @@ import (
 	"github.com/ErikKalkoken/evebuddy/internal/app/characterservice"
 	"github.com/ErikKalkoken/evebuddy/internal/app/corporationservice"
 	"github.com/ErikKalkoken/evebuddy/internal/app/eveuniverseservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/settings"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
 )
 
@@ -23,5 +24,7 @@ type baseUI interface {
 	IsDeveloperMode() bool
 	IsMobile() bool
 	MainWindow() fyne.Window
+	Settings() *settings.Settings
+	ShowSnackbar(text string)
 	Signals() *app.Signals
 }
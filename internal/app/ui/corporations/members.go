@@ -1,17 +1,26 @@
 package corporations
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"fmt"
+	"log/slog"
 	"slices"
+	"strconv"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
 	"github.com/ErikKalkoken/go-set"
+	"github.com/dustin/go-humanize"
 	ttwidget "github.com/dweymouth/fyne-tooltip/widget"
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
@@ -23,24 +32,62 @@ import (
 	"github.com/ErikKalkoken/evebuddy/internal/xwidget"
 )
 
+const (
+	memberActivityOnline   = "Online"
+	memberActivityActive   = "Active"
+	memberActivityInactive = "Inactive"
+)
+
 type memberRow struct {
+	base         optional.Optional[*app.EveLocationShort]
+	hasTracking  bool
 	id           int64
-	name         string
+	inactiveFor  optional.Optional[time.Duration]
 	isCEO        bool
+	isInactive   bool
+	isOnline     bool
 	isOwned      bool
+	location     optional.Optional[*app.EveLocationShort]
+	logoffDate   optional.Optional[time.Time]
+	logonDate    optional.Optional[time.Time]
+	name         string
 	searchTarget string
+	shipType     optional.Optional[*app.EntityShort]
+	startDate    optional.Optional[time.Time]
+}
+
+// trackingDisplay returns a short summary of the tracking data for a member.
+func (r memberRow) trackingDisplay() string {
+	if !r.hasTracking {
+		return ""
+	}
+	var parts []string
+	if r.isOnline {
+		parts = append(parts, "Online")
+	} else if v, ok := r.logoffDate.Value(); ok {
+		parts = append(parts, "Last online "+humanize.Time(v))
+	}
+	if v, ok := r.location.Value(); ok {
+		parts = append(parts, v.DisplayName())
+	}
+	if v, ok := r.shipType.Value(); ok {
+		parts = append(parts, v.Name)
+	}
+	return strings.Join(parts, " • ")
 }
 
 type Members struct {
 	widget.BaseWidget
 
-	corporation  atomic.Pointer[app.Corporation]
-	footer       *widget.Label
-	list         *widget.List
-	rows         []memberRow
-	rowsFiltered []memberRow
-	searchEntry  *xwidget.SearchEntry
-	u            baseUI
+	corporation    atomic.Pointer[app.Corporation]
+	exportButton   *xwidget.ContextMenuButton
+	footer         *widget.Label
+	list           *widget.List
+	rows           []memberRow
+	rowsFiltered   []memberRow
+	searchEntry    *xwidget.SearchEntry
+	selectActivity *kxwidget.FilterChipSelect
+	u              baseUI
 }
 
 func NewMembers(s baseUI) *Members {
@@ -58,11 +105,24 @@ func NewMembers(s baseUI) *Members {
 		a.filterRowsAsync()
 		a.list.ScrollToTop()
 	})
+	a.selectActivity = kxwidget.NewFilterChipSelect("Activity", []string{
+		memberActivityOnline,
+		memberActivityActive,
+		memberActivityInactive,
+	}, func(string) {
+		a.filterRowsAsync()
+		a.list.ScrollToTop()
+	})
+	a.exportButton = xwidget.NewContextMenuButtonWithIcon("Export", icons.ExportVariantSvg, fyne.NewMenu("",
+		fyne.NewMenuItem("Copy members to clipboard", a.copyMembersToClipboard),
+		fyne.NewMenuItem("Save members as file", a.saveMembersAsCSV),
+	))
 
 	a.u.Signals().CurrentCorporationExchanged.AddListener(func(ctx context.Context, c *app.Corporation) {
 		a.corporation.Store(c)
 		fyne.Do(func() {
 			a.searchEntry.ClearSilent()
+			a.selectActivity.SetSelected("")
 		})
 		a.update(ctx)
 	})
@@ -70,17 +130,24 @@ func NewMembers(s baseUI) *Members {
 		if a.corporation.Load().IDOrZero() != arg.CorporationID {
 			return
 		}
-		if arg.Section != app.SectionCorporationMembers {
-			return
+		switch arg.Section {
+		case app.SectionCorporationMembers, app.SectionCorporationMemberTracking:
+			a.update(ctx)
 		}
-		a.update(ctx)
 	})
 	return a
 }
 
 func (a *Members) CreateRenderer() fyne.WidgetRenderer {
+	filter := container.NewHBox(a.selectActivity, a.exportButton)
+	var top fyne.CanvasObject
+	if a.u.IsMobile() {
+		top = container.NewVBox(a.searchEntry, container.NewHScroll(filter))
+	} else {
+		top = container.NewBorder(nil, nil, filter, nil, a.searchEntry)
+	}
 	c := container.NewBorder(
-		a.searchEntry,
+		top,
 		a.footer,
 		nil,
 		nil,
@@ -119,6 +186,7 @@ func (a *Members) filterRowsAsync() {
 	totalRows := len(a.rows)
 	rows := slices.Clone(a.rows)
 	search := strings.ToLower(a.searchEntry.Text)
+	activity := a.selectActivity.Selected
 
 	go func() {
 		if len(search) > 1 {
@@ -126,11 +194,33 @@ func (a *Members) filterRowsAsync() {
 				return !strings.Contains(r.searchTarget, search)
 			})
 		}
+		if activity != "" {
+			rows = slices.DeleteFunc(rows, func(r memberRow) bool {
+				switch activity {
+				case memberActivityOnline:
+					return !r.isOnline
+				case memberActivityActive:
+					return !r.hasTracking || r.isInactive
+				case memberActivityInactive:
+					return !r.isInactive
+				}
+				return false
+			})
+		}
 		slices.SortFunc(rows, func(a, b memberRow) int {
 			return strings.Compare(a.name, b.name)
 		})
 
+		var inactive int
+		for _, r := range rows {
+			if r.isInactive {
+				inactive++
+			}
+		}
 		footer := fmt.Sprintf("Showing %s / %s members", ihumanize.Comma(len(rows)), ihumanize.Comma(totalRows))
+		if inactive > 0 {
+			footer += fmt.Sprintf(" • %s inactive", ihumanize.Comma(inactive))
+		}
 
 		fyne.Do(func() {
 			a.footer.Text = footer
@@ -203,36 +293,130 @@ func (a *Members) fetchRows(ctx context.Context, corporationID, ceoID int64) ([]
 	if err != nil {
 		return nil, err
 	}
+	now := time.Now()
+	threshold := time.Duration(a.u.Settings().CorporationMemberInactiveDays()) * 24 * time.Hour
 	var rows []memberRow
 	for _, o := range oo {
 		rows = append(rows, memberRow{
+			base:         o.Base,
+			hasTracking:  o.HasTracking(),
 			id:           o.Character.ID,
-			name:         o.Character.Name,
+			inactiveFor:  o.InactiveFor(now),
 			isCEO:        o.Character.ID == ceoID,
+			isInactive:   o.IsInactive(now, threshold),
+			isOnline:     o.IsOnline(),
 			isOwned:      owned.Contains(o.Character.ID),
+			location:     o.Location,
+			logoffDate:   o.LogoffDate,
+			logonDate:    o.LogonDate,
+			name:         o.Character.Name,
 			searchTarget: strings.ToLower(o.Character.Name),
+			shipType:     o.ShipType,
+			startDate:    o.StartDate,
 		})
 	}
 	return rows, nil
 }
 
+func (a *Members) makeMembersCSVString() string {
+	formatDate := func(o optional.Optional[time.Time]) string {
+		return optional.Map(o, "", func(x time.Time) string {
+			return x.UTC().Format(app.DateTimeFormat)
+		})
+	}
+	formatLocation := func(o optional.Optional[*app.EveLocationShort]) string {
+		return optional.Map(o, "", func(x *app.EveLocationShort) string {
+			return x.DisplayName()
+		})
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{
+		"Character", "Character ID", "Start Date", "Last Logon", "Last Logoff",
+		"Inactive Days", "Location", "Base", "Ship",
+	})
+	for _, r := range a.rowsFiltered {
+		inactiveDays := optional.Map(r.inactiveFor, "", func(x time.Duration) string {
+			return strconv.Itoa(int(x.Hours() / 24))
+		})
+		shipType := optional.Map(r.shipType, "", func(x *app.EntityShort) string {
+			return x.Name
+		})
+		_ = w.Write([]string{
+			r.name,
+			strconv.FormatInt(r.id, 10),
+			formatDate(r.startDate),
+			formatDate(r.logonDate),
+			formatDate(r.logoffDate),
+			inactiveDays,
+			formatLocation(r.location),
+			formatLocation(r.base),
+			shipType,
+		})
+	}
+	w.Flush()
+	return buf.String()
+}
+
+func (a *Members) copyMembersToClipboard() {
+	fyne.CurrentApp().Clipboard().SetContent(a.makeMembersCSVString())
+	a.u.ShowSnackbar("Members copied to clipboard")
+}
+
+func (a *Members) saveMembersAsCSV() {
+	d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+		if err != nil {
+			ui.ShowErrorAndLog("Failed to save file", err, a.u.IsDeveloperMode(), a.u.MainWindow())
+			return
+		}
+		_, err = writer.Write([]byte(a.makeMembersCSVString()))
+		if err != nil {
+			ui.ShowErrorAndLog("Failed to save file", err, a.u.IsDeveloperMode(), a.u.MainWindow())
+			return
+		}
+		slog.Info("Corporation members exported to file", "uri", writer.URI())
+		a.u.ShowSnackbar("Members saved to file")
+	}, a.u.MainWindow())
+
+	d.SetFileName("members.csv")
+	d.SetFilter(storage.NewExtensionFileFilter([]string{".csv"}))
+	d.SetTitleText("Save members")
+	d.Show()
+
+	_, s := a.u.MainWindow().Canvas().InteractiveArea()
+	winSize := fyne.NewSize(s.Width*0.8, s.Height*0.8)
+	d.Resize(winSize)
+}
+
 type corporationMemberItem struct {
 	widget.BaseWidget
 
-	ceo    *ttwidget.Icon
-	owned  *ttwidget.Icon
-	member *ui.EveEntityListItem
+	ceo      *ttwidget.Icon
+	inactive *ttwidget.Icon
+	member   *ui.EveEntityListItem
+	owned    *ttwidget.Icon
+	tracking *widget.Label
 }
 
 func newCorporationMemberItem(loadCharacterIcon ui.EveEntityIconLoader) *corporationMemberItem {
 	ceo := ttwidget.NewIcon(theme.NewWarningThemedResource(icons.CrownSvg))
 	ceo.SetToolTip("CEO of this corporation")
+	inactive := ttwidget.NewIcon(theme.NewErrorThemedResource(theme.WarningIcon()))
 	owned := ttwidget.NewIcon(theme.NewSuccessThemedResource(icons.CheckDecagramSvg))
 	owned.SetToolTip("You own this character")
+	tracking := widget.NewLabel("")
+	tracking.Truncation = fyne.TextTruncateEllipsis
+	tracking.SizeName = theme.SizeNameCaptionText
 	w := &corporationMemberItem{
-		ceo:    ceo,
-		owned:  owned,
-		member: ui.NewEveEntityListItem(loadCharacterIcon),
+		ceo:      ceo,
+		inactive: inactive,
+		member:   ui.NewEveEntityListItem(loadCharacterIcon),
+		owned:    owned,
+		tracking: tracking,
 	}
 	w.ExtendBaseWidget(w)
 	return w
@@ -243,14 +427,26 @@ func (w *corporationMemberItem) CreateRenderer() fyne.WidgetRenderer {
 		nil,
 		nil,
 		nil,
-		container.NewHBox(w.owned, w.ceo),
-		w.member,
+		container.NewHBox(w.inactive, w.owned, w.ceo),
+		container.NewVBox(w.member, w.tracking),
 	))
 	return widget.NewSimpleRenderer(c)
 }
 
 func (w *corporationMemberItem) set(r memberRow) {
 	w.member.Set2(r.id, r.name, app.EveEntityCharacter)
+	if s := r.trackingDisplay(); s != "" {
+		w.tracking.SetText(s)
+		w.tracking.Show()
+	} else {
+		w.tracking.Hide()
+	}
+	if r.isInactive {
+		w.inactive.SetToolTip(fmt.Sprintf("No login for %s", ihumanize.Duration(r.inactiveFor.ValueOrZero())))
+		w.inactive.Show()
+	} else {
+		w.inactive.Hide()
+	}
 	if r.isOwned {
 		w.owned.Show()
 	} else {
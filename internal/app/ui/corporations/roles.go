@@ -0,0 +1,462 @@
+package corporations
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	ihumanize "github.com/ErikKalkoken/evebuddy/internal/humanize"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+	"github.com/ErikKalkoken/evebuddy/internal/xwidget"
+)
+
+type memberRoleRow struct {
+	id           int64
+	isDirector   bool
+	name         string
+	roles        set.Set[string]
+	searchTarget string
+	titles       []string
+}
+
+// rolesDisplay returns a short summary of the roles and titles of a member.
+func (r memberRoleRow) rolesDisplay() string {
+	var parts []string
+	if r.isDirector {
+		parts = append(parts, "Director")
+	} else if r.roles.Size() > 0 {
+		parts = append(parts, strings.Join(slices.Sorted(r.roles.All()), ", "))
+	}
+	if len(r.titles) > 0 {
+		parts = append(parts, "Titles: "+strings.Join(r.titles, ", "))
+	}
+	return strings.Join(parts, " • ")
+}
+
+// MemberRoles is a widget for auditing which members hold which roles and titles.
+type MemberRoles struct {
+	widget.BaseWidget
+
+	corporation  atomic.Pointer[app.Corporation]
+	footer       *widget.Label
+	list         *widget.List
+	rows         []memberRoleRow
+	rowsFiltered []memberRoleRow
+	searchEntry  *xwidget.SearchEntry
+	selectRole   *kxwidget.FilterChipSelect
+	u            baseUI
+}
+
+func NewMemberRoles(s baseUI) *MemberRoles {
+	a := &MemberRoles{
+		footer: ui.NewLabelWithTruncation(""),
+		u:      s,
+	}
+	a.list = a.makeList()
+	a.ExtendBaseWidget(a)
+
+	a.searchEntry = xwidget.NewSearchEntry("Search members and titles", func(s string) {
+		if len(s) == 1 {
+			return
+		}
+		a.filterRowsAsync()
+		a.list.ScrollToTop()
+	})
+	a.selectRole = kxwidget.NewFilterChipSelect("Role", []string{}, func(string) {
+		a.filterRowsAsync()
+		a.list.ScrollToTop()
+	})
+
+	a.u.Signals().CurrentCorporationExchanged.AddListener(func(ctx context.Context, c *app.Corporation) {
+		a.corporation.Store(c)
+		fyne.Do(func() {
+			a.searchEntry.ClearSilent()
+			a.selectRole.SetSelected("")
+		})
+		a.update(ctx)
+	})
+	a.u.Signals().CorporationSectionChanged.AddListener(func(ctx context.Context, arg app.CorporationSectionUpdated) {
+		if a.corporation.Load().IDOrZero() != arg.CorporationID {
+			return
+		}
+		switch arg.Section {
+		case app.SectionCorporationMemberRoles, app.SectionCorporationTitles:
+			a.update(ctx)
+		}
+	})
+	return a
+}
+
+func (a *MemberRoles) CreateRenderer() fyne.WidgetRenderer {
+	var top fyne.CanvasObject
+	if a.u.IsMobile() {
+		top = container.NewVBox(a.searchEntry, container.NewHScroll(container.NewHBox(a.selectRole)))
+	} else {
+		top = container.NewBorder(nil, nil, a.selectRole, nil, a.searchEntry)
+	}
+	c := container.NewBorder(
+		top,
+		a.footer,
+		nil,
+		nil,
+		a.list,
+	)
+	return widget.NewSimpleRenderer(c)
+}
+
+func (a *MemberRoles) makeList() *widget.List {
+	l := widget.NewList(
+		func() int {
+			return len(a.rowsFiltered)
+		},
+		func() fyne.CanvasObject {
+			return newCorporationRoleItem(a.u.EVEImage().EveEntityLogoAsync)
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id >= len(a.rowsFiltered) {
+				return
+			}
+			r := a.rowsFiltered[id]
+			co.(*corporationRoleItem).set(r.id, r.name, r.rolesDisplay(), r.isDirector)
+		},
+	)
+	l.OnSelected = func(id widget.ListItemID) {
+		defer l.UnselectAll()
+		if id >= len(a.rowsFiltered) {
+			return
+		}
+		r := a.rowsFiltered[id]
+		a.u.InfoViewer().Show(&app.EveEntity{ID: r.id, Category: app.EveEntityCharacter})
+	}
+	return l
+}
+
+func (a *MemberRoles) filterRowsAsync() {
+	totalRows := len(a.rows)
+	rows := slices.Clone(a.rows)
+	search := strings.ToLower(a.searchEntry.Text)
+	role := a.selectRole.Selected
+
+	go func() {
+		if len(search) > 1 {
+			rows = slices.DeleteFunc(rows, func(r memberRoleRow) bool {
+				return !strings.Contains(r.searchTarget, search)
+			})
+		}
+		if role != "" {
+			rows = slices.DeleteFunc(rows, func(r memberRoleRow) bool {
+				return !r.roles.Contains(role)
+			})
+		}
+		roleOptions := slices.Sorted(set.Union(xslices.Map(rows, func(r memberRoleRow) set.Set[string] {
+			return r.roles
+		})...).All())
+
+		var directors int
+		for _, r := range rows {
+			if r.isDirector {
+				directors++
+			}
+		}
+		footer := fmt.Sprintf("Showing %s / %s members", ihumanize.Comma(len(rows)), ihumanize.Comma(totalRows))
+		if directors > 0 {
+			footer += fmt.Sprintf(" • %s directors", ihumanize.Comma(directors))
+		}
+
+		fyne.Do(func() {
+			a.footer.Text = footer
+			a.footer.Importance = widget.MediumImportance
+			a.footer.Refresh()
+			a.selectRole.SetOptions(roleOptions)
+			a.rowsFiltered = rows
+			a.list.Refresh()
+		})
+	}()
+}
+
+func (a *MemberRoles) update(ctx context.Context) {
+	reset := func() {
+		fyne.Do(func() {
+			xslices.Clear(&a.rows)
+			xslices.Clear(&a.rowsFiltered)
+			a.searchEntry.SetText("")
+			a.list.Refresh()
+		})
+	}
+	setTop := func(s string, i widget.Importance) {
+		fyne.Do(func() {
+			a.footer.Text, a.footer.Importance = s, i
+			a.footer.Refresh()
+		})
+	}
+	corporation := a.corporation.Load()
+	if corporation == nil {
+		reset()
+		return
+	}
+	var hasData bool
+	for _, s := range []app.CorporationSection{app.SectionCorporationMemberRoles, app.SectionCorporationTitles} {
+		ok, err := a.u.Corporation().HasSection(ctx, corporation.ID, s)
+		if err != nil {
+			reset()
+			setTop("ERROR: "+a.u.ErrorDisplay(err), widget.DangerImportance)
+			return
+		}
+		hasData = hasData || ok
+	}
+	if !hasData {
+		reset()
+		return
+	}
+	oo, err := a.u.Corporation().ListMemberAudits(ctx, corporation.ID)
+	if err != nil {
+		reset()
+		setTop("ERROR: "+a.u.ErrorDisplay(err), widget.DangerImportance)
+		return
+	}
+	var rows []memberRoleRow
+	for _, o := range oo {
+		r := memberRoleRow{
+			id:         o.Character.ID,
+			isDirector: o.IsDirector(),
+			name:       o.Character.Name,
+		}
+		for x := range o.Roles.All() {
+			r.roles.Add(x.Display())
+		}
+		for _, t := range o.Titles {
+			r.titles = append(r.titles, t.Name)
+		}
+		r.searchTarget = strings.ToLower(r.name + " " + strings.Join(r.titles, " "))
+		rows = append(rows, r)
+	}
+	fyne.Do(func() {
+		a.rows = rows
+		a.filterRowsAsync()
+	})
+}
+
+type roleChangeRow struct {
+	changedAt    time.Time
+	characterID  int64
+	display      string
+	isDirector   bool
+	name         string
+	searchTarget string
+}
+
+// RoleChanges is a widget for showing the log of role changes of a corporation.
+type RoleChanges struct {
+	widget.BaseWidget
+
+	corporation  atomic.Pointer[app.Corporation]
+	footer       *widget.Label
+	list         *widget.List
+	rows         []roleChangeRow
+	rowsFiltered []roleChangeRow
+	searchEntry  *xwidget.SearchEntry
+	u            baseUI
+}
+
+func NewRoleChanges(s baseUI) *RoleChanges {
+	a := &RoleChanges{
+		footer: ui.NewLabelWithTruncation(""),
+		u:      s,
+	}
+	a.list = a.makeList()
+	a.ExtendBaseWidget(a)
+
+	a.searchEntry = xwidget.NewSearchEntry("Search role changes", func(s string) {
+		if len(s) == 1 {
+			return
+		}
+		a.filterRowsAsync()
+		a.list.ScrollToTop()
+	})
+
+	a.u.Signals().CurrentCorporationExchanged.AddListener(func(ctx context.Context, c *app.Corporation) {
+		a.corporation.Store(c)
+		fyne.Do(func() {
+			a.searchEntry.ClearSilent()
+		})
+		a.update(ctx)
+	})
+	a.u.Signals().CorporationSectionChanged.AddListener(func(ctx context.Context, arg app.CorporationSectionUpdated) {
+		if a.corporation.Load().IDOrZero() != arg.CorporationID {
+			return
+		}
+		if arg.Section == app.SectionCorporationMemberRoles {
+			a.update(ctx)
+		}
+	})
+	return a
+}
+
+func (a *RoleChanges) CreateRenderer() fyne.WidgetRenderer {
+	c := container.NewBorder(
+		a.searchEntry,
+		a.footer,
+		nil,
+		nil,
+		a.list,
+	)
+	return widget.NewSimpleRenderer(c)
+}
+
+func (a *RoleChanges) makeList() *widget.List {
+	l := widget.NewList(
+		func() int {
+			return len(a.rowsFiltered)
+		},
+		func() fyne.CanvasObject {
+			return newCorporationRoleItem(a.u.EVEImage().EveEntityLogoAsync)
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id >= len(a.rowsFiltered) {
+				return
+			}
+			r := a.rowsFiltered[id]
+			s := r.changedAt.Format(app.DateTimeFormat) + " • " + r.display
+			co.(*corporationRoleItem).set(r.characterID, r.name, s, r.isDirector)
+		},
+	)
+	l.OnSelected = func(id widget.ListItemID) {
+		defer l.UnselectAll()
+		if id >= len(a.rowsFiltered) {
+			return
+		}
+		r := a.rowsFiltered[id]
+		a.u.InfoViewer().Show(&app.EveEntity{ID: r.characterID, Category: app.EveEntityCharacter})
+	}
+	return l
+}
+
+func (a *RoleChanges) filterRowsAsync() {
+	totalRows := len(a.rows)
+	rows := slices.Clone(a.rows)
+	search := strings.ToLower(a.searchEntry.Text)
+
+	go func() {
+		if len(search) > 1 {
+			rows = slices.DeleteFunc(rows, func(r roleChangeRow) bool {
+				return !strings.Contains(r.searchTarget, search)
+			})
+		}
+		footer := fmt.Sprintf("Showing %s / %s changes", ihumanize.Comma(len(rows)), ihumanize.Comma(totalRows))
+
+		fyne.Do(func() {
+			a.footer.Text = footer
+			a.footer.Importance = widget.MediumImportance
+			a.footer.Refresh()
+			a.rowsFiltered = rows
+			a.list.Refresh()
+		})
+	}()
+}
+
+func (a *RoleChanges) update(ctx context.Context) {
+	reset := func() {
+		fyne.Do(func() {
+			xslices.Clear(&a.rows)
+			xslices.Clear(&a.rowsFiltered)
+			a.searchEntry.SetText("")
+			a.list.Refresh()
+		})
+	}
+	setTop := func(s string, i widget.Importance) {
+		fyne.Do(func() {
+			a.footer.Text, a.footer.Importance = s, i
+			a.footer.Refresh()
+		})
+	}
+	corporation := a.corporation.Load()
+	if corporation == nil {
+		reset()
+		return
+	}
+	hasData, err := a.u.Corporation().HasSection(ctx, corporation.ID, app.SectionCorporationMemberRoles)
+	if err != nil {
+		reset()
+		setTop("ERROR: "+a.u.ErrorDisplay(err), widget.DangerImportance)
+		return
+	}
+	if !hasData {
+		reset()
+		return
+	}
+	oo, err := a.u.Corporation().ListRoleChanges(ctx, corporation.ID)
+	if err != nil {
+		reset()
+		setTop("ERROR: "+a.u.ErrorDisplay(err), widget.DangerImportance)
+		return
+	}
+	rows := xslices.Map(oo, func(o *app.CorporationRoleChange) roleChangeRow {
+		display := o.Display()
+		return roleChangeRow{
+			changedAt:    o.ChangedAt.Local(),
+			characterID:  o.Character.ID,
+			display:      display,
+			isDirector:   o.Role == app.RoleDirector && o.Location == app.RoleLocationGeneral,
+			name:         o.Character.Name,
+			searchTarget: strings.ToLower(o.Character.Name + " " + display),
+		}
+	})
+	fyne.Do(func() {
+		a.rows = rows
+		a.filterRowsAsync()
+	})
+}
+
+type corporationRoleItem struct {
+	widget.BaseWidget
+
+	detail   *widget.Label
+	director *widget.Icon
+	member   *ui.EveEntityListItem
+}
+
+func newCorporationRoleItem(loadCharacterIcon ui.EveEntityIconLoader) *corporationRoleItem {
+	detail := widget.NewLabel("")
+	detail.Truncation = fyne.TextTruncateEllipsis
+	detail.SizeName = theme.SizeNameCaptionText
+	w := &corporationRoleItem{
+		detail:   detail,
+		director: widget.NewIcon(theme.NewWarningThemedResource(theme.WarningIcon())),
+		member:   ui.NewEveEntityListItem(loadCharacterIcon),
+	}
+	w.ExtendBaseWidget(w)
+	return w
+}
+
+func (w *corporationRoleItem) CreateRenderer() fyne.WidgetRenderer {
+	c := container.NewPadded(container.NewBorder(
+		nil,
+		nil,
+		nil,
+		w.director,
+		container.NewVBox(w.member, w.detail),
+	))
+	return widget.NewSimpleRenderer(c)
+}
+
+func (w *corporationRoleItem) set(characterID int64, name, detail string, isDirector bool) {
+	w.member.Set2(characterID, name, app.EveEntityCharacter)
+	w.detail.SetText(detail)
+	if isDirector {
+		w.director.Show()
+	} else {
+		w.director.Hide()
+	}
+}
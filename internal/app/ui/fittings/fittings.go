@@ -0,0 +1,336 @@
+// Package fittings provides widgets for building the ship fittings UI.
+package fittings
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/characterservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	ihumanize "github.com/ErikKalkoken/evebuddy/internal/humanize"
+	"github.com/ErikKalkoken/evebuddy/internal/xdesktop"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+	"github.com/ErikKalkoken/evebuddy/internal/xstrings"
+	"github.com/ErikKalkoken/evebuddy/internal/xwidget"
+)
+
+type baseUI interface {
+	Character() *characterservice.CharacterService
+	ErrorDisplay(err error) string
+	EVEImage() ui.EVEImageService
+	GetOrCreateWindow(id string, titles ...string) (window fyne.Window, created bool)
+	InfoViewer() ui.InfoViewer
+	IsDeveloperMode() bool
+	IsMobile() bool
+	MainWindow() fyne.Window
+	ShowSnackbar(text string)
+	Signals() *app.Signals
+}
+
+type fittingRow struct {
+	fitting       *app.CharacterFitting
+	itemsCount    int
+	name          string
+	shipGroupName string
+	shipTypeID    int64
+	shipTypeName  string
+}
+
+// Fittings is a widget for showing the saved ship fittings of the current character.
+type Fittings struct {
+	widget.BaseWidget
+
+	character    atomic.Pointer[app.Character]
+	columnSorter *xwidget.ColumnSorter[fittingRow]
+	footer       *widget.Label
+	importButton *widget.Button
+	main         fyne.CanvasObject
+	rows         []fittingRow
+	rowsFiltered []fittingRow
+	selectGroup  *kxwidget.FilterChipSelect
+	sortButton   *xwidget.SortButton[fittingRow]
+	u            baseUI
+}
+
+const (
+	fittingsColShip = iota + 1
+	fittingsColName
+	fittingsColGroup
+	fittingsColItems
+)
+
+// NewFittings returns a new widget for showing the fittings of the current character.
+func NewFittings(u baseUI) *Fittings {
+	columns := xwidget.NewDataColumns([]xwidget.DataColumn[fittingRow]{
+		ui.MakeEveEntityColumn(ui.MakeEveEntityColumnParams[fittingRow]{
+			ColumnID: fittingsColShip,
+			EIS:      u.EVEImage(),
+			GetEntity: func(r fittingRow) *app.EveEntity {
+				return &app.EveEntity{
+					ID:       r.shipTypeID,
+					Name:     r.shipTypeName,
+					Category: app.EveEntityInventoryType,
+				}
+			},
+			IsAvatar: false,
+			Label:    "Ship",
+		}), {
+			ID:    fittingsColName,
+			Label: "Name",
+			Width: 250,
+			Sort: func(a, b fittingRow) int {
+				return xstrings.CompareIgnoreCase(a.name, b.name)
+			},
+			Update: func(r fittingRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.name)
+			},
+		}, {
+			ID:    fittingsColGroup,
+			Label: "Group",
+			Width: 150,
+			Sort: func(a, b fittingRow) int {
+				return strings.Compare(a.shipGroupName, b.shipGroupName)
+			},
+			Update: func(r fittingRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.shipGroupName)
+			},
+		}, {
+			ID:    fittingsColItems,
+			Label: "Items",
+			Width: 75,
+			Sort: func(a, b fittingRow) int {
+				return a.itemsCount - b.itemsCount
+			},
+			Update: func(r fittingRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(ihumanize.Comma(r.itemsCount), widget.RichTextStyle{
+					Alignment: fyne.TextAlignTrailing,
+				})
+			},
+		}})
+	a := &Fittings{
+		columnSorter: xwidget.NewColumnSorter(columns, fittingsColShip, xwidget.SortAsc),
+		footer:       ui.NewLabelWithTruncation(""),
+		u:            u,
+	}
+	a.ExtendBaseWidget(a)
+	if !a.u.IsMobile() {
+		a.main = xwidget.MakeDataTable(
+			columns,
+			&a.rowsFiltered,
+			func() fyne.CanvasObject {
+				x := xwidget.NewRichText()
+				x.Truncation = fyne.TextTruncateClip
+				return x
+			},
+			a.columnSorter,
+			a.filterRowsAsync, func(_ int, r fittingRow) {
+				showFittingWindow(a.u, r.fitting)
+			})
+	} else {
+		a.main = a.makeDataList()
+	}
+
+	a.selectGroup = kxwidget.NewFilterChipSelectWithSearch("Group", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	}, a.u.MainWindow())
+	a.sortButton = a.columnSorter.NewSortButton(func() {
+		a.filterRowsAsync(-1)
+	})
+	a.importButton = widget.NewButtonWithIcon("Import EFT", theme.ContentPasteIcon(), func() {
+		showImportDialog(a.u)
+	})
+
+	a.u.Signals().CurrentCharacterExchanged.AddListener(func(ctx context.Context, c *app.Character) {
+		a.character.Store(c)
+		fyne.Do(func() {
+			a.selectGroup.Selected = ""
+		})
+		a.Update(ctx)
+	})
+	a.u.Signals().CharacterSectionChanged.AddListener(func(ctx context.Context, arg app.CharacterSectionUpdated) {
+		if a.character.Load().IDOrZero() != arg.CharacterID {
+			return
+		}
+		if arg.Section != app.SectionCharacterFittings {
+			return
+		}
+		a.Update(ctx)
+	})
+	return a
+}
+
+func (a *Fittings) CreateRenderer() fyne.WidgetRenderer {
+	filter := container.NewHBox(a.selectGroup)
+	if a.u.IsMobile() {
+		filter.Add(a.sortButton)
+	}
+	p := theme.Padding()
+	c := container.NewBorder(
+		container.NewBorder(nil, nil, nil, a.importButton, container.NewHScroll(filter)),
+		container.New(layout.NewCustomPaddedLayout(p, p, 0, 0), a.footer),
+		nil,
+		nil,
+		a.main,
+	)
+	return widget.NewSimpleRenderer(c)
+}
+
+func (a *Fittings) makeDataList() *xwidget.StripedList {
+	p := theme.Padding()
+	l := xwidget.NewStripedList(
+		func() int {
+			return len(a.rowsFiltered)
+		},
+		func() fyne.CanvasObject {
+			name := widget.NewLabel("Template")
+			name.Truncation = fyne.TextTruncateClip
+			name.TextStyle.Bold = true
+			ship := widget.NewLabel("Template")
+			ship.Truncation = fyne.TextTruncateClip
+			items := widget.NewLabel("Template")
+			items.Alignment = fyne.TextAlignTrailing
+			return container.New(layout.NewCustomPaddedVBoxLayout(-p),
+				name,
+				container.NewBorder(nil, nil, nil, items, ship),
+			)
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id < 0 || id >= len(a.rowsFiltered) {
+				return
+			}
+			r := a.rowsFiltered[id]
+			c := co.(*fyne.Container).Objects
+			c[0].(*widget.Label).SetText(r.name)
+			b1 := c[1].(*fyne.Container).Objects
+			b1[0].(*widget.Label).SetText(fmt.Sprintf("%s (%s)", r.shipTypeName, r.shipGroupName))
+			b1[1].(*widget.Label).SetText(fmt.Sprintf("%s items", ihumanize.Comma(r.itemsCount)))
+		},
+	)
+	l.OnSelected = func(id widget.ListItemID) {
+		defer l.UnselectAll()
+		if id < 0 || id >= len(a.rowsFiltered) {
+			return
+		}
+		showFittingWindow(a.u, a.rowsFiltered[id].fitting)
+	}
+	l.HideSeparators = true
+	return l
+}
+
+func (a *Fittings) filterRowsAsync(sortCol int) {
+	totalRows := len(a.rows)
+	rows := slices.Clone(a.rows)
+	group := a.selectGroup.Selected
+	sortCol, dir, doSort := a.columnSorter.CalcSort(sortCol)
+
+	go func() {
+		if group != "" {
+			rows = slices.DeleteFunc(rows, func(r fittingRow) bool {
+				return r.shipGroupName != group
+			})
+		}
+		a.columnSorter.SortRows(rows, sortCol, dir, doSort)
+		groupOptions := xslices.Map(rows, func(r fittingRow) string {
+			return r.shipGroupName
+		})
+		footer := fmt.Sprintf("Showing %s / %s fittings", ihumanize.Comma(len(rows)), ihumanize.Comma(totalRows))
+
+		fyne.Do(func() {
+			a.footer.Text = footer
+			a.footer.Importance = widget.MediumImportance
+			a.footer.Refresh()
+			a.selectGroup.SetOptions(groupOptions)
+			a.rowsFiltered = rows
+			a.main.Refresh()
+		})
+	}()
+}
+
+// Update refreshes the widget with the latest fittings.
+func (a *Fittings) Update(ctx context.Context) {
+	reset := func() {
+		fyne.Do(func() {
+			xslices.Clear(&a.rows)
+			a.filterRowsAsync(-1)
+		})
+	}
+	setFooter := func(s string, i widget.Importance) {
+		fyne.Do(func() {
+			a.footer.Text = s
+			a.footer.Importance = i
+			a.footer.Refresh()
+		})
+	}
+
+	characterID := a.character.Load().IDOrZero()
+	if characterID == 0 {
+		reset()
+		setFooter("No character", widget.LowImportance)
+		return
+	}
+	hasData, err := a.u.Character().HasSection(ctx, characterID, app.SectionCharacterFittings)
+	if err != nil {
+		reset()
+		setFooter("ERROR: "+a.u.ErrorDisplay(err), widget.DangerImportance)
+		return
+	}
+	if !hasData {
+		reset()
+		setFooter("Loading data...", widget.WarningImportance)
+		return
+	}
+	oo, err := a.u.Character().ListFittings(ctx, characterID)
+	if err != nil {
+		reset()
+		setFooter("ERROR: "+a.u.ErrorDisplay(err), widget.DangerImportance)
+		return
+	}
+	rows := xslices.Map(oo, func(o *app.CharacterFitting) fittingRow {
+		return fittingRow{
+			fitting:       o,
+			itemsCount:    len(o.Items),
+			name:          o.Name,
+			shipGroupName: o.ShipType.Group.Name,
+			shipTypeID:    o.ShipType.ID,
+			shipTypeName:  o.ShipType.Name,
+		}
+	})
+	fyne.Do(func() {
+		a.rows = rows
+		a.filterRowsAsync(-1)
+	})
+}
+
+// showImportDialog shows a dialog for importing a fitting from EFT text.
+func showImportDialog(u baseUI) {
+	entry := widget.NewMultiLineEntry()
+	entry.SetPlaceHolder("[Rifter, My fitting]\n\n200mm Steel Plates I\n...")
+	entry.SetMinRowsVisible(10)
+	entry.TextStyle.Monospace = true
+	d := dialog.NewCustomConfirm("Import fitting from EFT", "Import", "Cancel", entry, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		cf, err := u.Character().ImportFittingEFT(context.Background(), entry.Text)
+		if err != nil {
+			ui.ShowErrorAndLog("Failed to import fitting", err, u.IsDeveloperMode(), u.MainWindow())
+			return
+		}
+		showFittingWindow(u, cf)
+	}, u.MainWindow())
+	xdesktop.DisableShortcutsForDialog(d, u.MainWindow())
+	d.Resize(fyne.NewSize(500, 400))
+	d.Show()
+}
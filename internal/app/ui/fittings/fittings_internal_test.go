@@ -0,0 +1,78 @@
+package fittings
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+func TestMakeFittingSections(t *testing.T) {
+	t.Run("should group items by slot in display order", func(t *testing.T) {
+		gun := &app.EveType{ID: 1, Name: "Gun"}
+		plate := &app.EveType{ID: 2, Name: "Plate"}
+		drone := &app.EveType{ID: 3, Name: "Drone"}
+		cf := &app.CharacterFitting{
+			Items: []*app.CharacterFittingItem{
+				{Flag: app.FlagDroneBay, Quantity: 5, Type: drone},
+				{Flag: app.FlagLoSlot0, Quantity: 1, Type: plate},
+				{Flag: app.FlagHiSlot1, Quantity: 1, Type: gun},
+				{Flag: app.FlagHiSlot0, Quantity: 1, Type: gun},
+			},
+		}
+		got := makeFittingSections(cf)
+		labels := xslices.Map(got, func(x fittingSection) string {
+			return x.label
+		})
+		xassert.Equal(t, []string{"High Slots", "Low Slots", "Drone Bay"}, labels)
+		xassert.Equal(t, app.FlagHiSlot0, got[0].items[0].Flag)
+		xassert.Equal(t, app.FlagHiSlot1, got[0].items[1].Flag)
+	})
+	t.Run("should return no sections for empty fitting", func(t *testing.T) {
+		got := makeFittingSections(&app.CharacterFitting{})
+		xassert.Equal(t, 0, len(got))
+	})
+}
+
+func TestMakeSkillCheckItems(t *testing.T) {
+	t.Run("should show status for each character and its missing skills", func(t *testing.T) {
+		skill := &app.EveType{ID: 3300, Name: "Gunnery"}
+		checks := []*app.CharacterFittingSkillCheck{
+			{Character: &app.EntityShort{ID: 1, Name: "Alpha"}},
+			{
+				Character: &app.EntityShort{ID: 2, Name: "Bravo"},
+				MissingSkills: []*app.CharacterMissingSkill{{
+					ActiveLevel:   1,
+					RequiredLevel: 3,
+					TrainingTime:  optional.New(2 * time.Hour),
+					Type:          skill,
+				}},
+			},
+		}
+		got := makeSkillCheckItems(checks)
+		labels := xslices.Map(got, func(x skillCheckItem) string {
+			return x.label
+		})
+		xassert.Equal(t, []string{"Alpha", "Bravo", "Gunnery I → III"}, labels)
+		xassert.Equal(t, "Can fly", got[0].status)
+		xassert.Equal(t, widget.WarningImportance, got[1].importance)
+		xassert.Equal(t, skill.ID, got[2].typeID)
+	})
+	t.Run("should show unknown training time", func(t *testing.T) {
+		checks := []*app.CharacterFittingSkillCheck{{
+			Character: &app.EntityShort{ID: 1, Name: "Alpha"},
+			MissingSkills: []*app.CharacterMissingSkill{{
+				RequiredLevel: 1,
+				Type:          &app.EveType{ID: 3300, Name: "Gunnery"},
+			}},
+		}}
+		got := makeSkillCheckItems(checks)
+		xassert.Equal(t, "Skills missing: 1", got[0].status)
+		xassert.Equal(t, "?", got[1].status)
+	})
+}
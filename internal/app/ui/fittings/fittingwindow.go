@@ -0,0 +1,279 @@
+package fittings
+
+import (
+	"context"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	ihumanize "github.com/ErikKalkoken/evebuddy/internal/humanize"
+	"github.com/ErikKalkoken/evebuddy/internal/xwidget"
+)
+
+// fittingSection is a group of items shown in the fitting window.
+type fittingSection struct {
+	label string
+	items []*app.CharacterFittingItem
+}
+
+var fittingSectionLabels = []struct {
+	slot  app.FittingSlot
+	label string
+}{
+	{app.FittingSlotHigh, "High Slots"},
+	{app.FittingSlotMedium, "Medium Slots"},
+	{app.FittingSlotLow, "Low Slots"},
+	{app.FittingSlotRig, "Rig Slots"},
+	{app.FittingSlotSubsystem, "Subsystem Slots"},
+	{app.FittingSlotService, "Service Slots"},
+	{app.FittingSlotDrone, "Drone Bay"},
+	{app.FittingSlotFighter, "Fighter Bay"},
+	{app.FittingSlotCargo, "Cargo Hold"},
+}
+
+// makeFittingSections returns the non-empty sections of a fitting in display order.
+func makeFittingSections(cf *app.CharacterFitting) []fittingSection {
+	var sections []fittingSection
+	for _, x := range fittingSectionLabels {
+		items := cf.ItemsForSlot(x.slot)
+		if len(items) == 0 {
+			continue
+		}
+		sections = append(sections, fittingSection{label: x.label, items: items})
+	}
+	return sections
+}
+
+// skillCheckItem is an entry in the list of skill checks for a fitting.
+// It is either a header for a character or a skill that character is missing.
+type skillCheckItem struct {
+	isHeader   bool
+	importance widget.Importance
+	label      string
+	status     string
+	typeID     int64
+}
+
+// makeSkillCheckItems returns the list entries for showing the results of a skill check.
+func makeSkillCheckItems(checks []*app.CharacterFittingSkillCheck) []skillCheckItem {
+	var items []skillCheckItem
+	for _, c := range checks {
+		header := skillCheckItem{isHeader: true, label: c.Character.Name}
+		if c.CanFly() {
+			header.importance = widget.SuccessImportance
+			header.status = "Can fly"
+		} else {
+			header.importance = widget.WarningImportance
+			header.status = fmt.Sprintf("Skills missing: %d", len(c.MissingSkills))
+			if d, ok := c.TrainingTime().Value(); ok {
+				header.status += " • " + ihumanize.Duration(d)
+			}
+		}
+		items = append(items, header)
+		for _, m := range c.MissingSkills {
+			it := skillCheckItem{
+				importance: widget.MediumImportance,
+				label: fmt.Sprintf(
+					"%s %s → %s",
+					m.Type.Name,
+					ihumanize.RomanLetter(m.ActiveLevel),
+					ihumanize.RomanLetter(m.RequiredLevel),
+				),
+				typeID: m.Type.ID,
+			}
+			if d, ok := m.TrainingTime.Value(); ok {
+				it.status = ihumanize.Duration(d)
+			} else {
+				it.status = "?"
+			}
+			items = append(items, it)
+		}
+	}
+	return items
+}
+
+// makeSkillCheckList returns a list showing which skills each character is missing to fly a fitting.
+// The skill check is loaded in the background.
+func makeSkillCheckList(u baseUI, cf *app.CharacterFitting) fyne.CanvasObject {
+	var items []skillCheckItem
+	list := widget.NewList(
+		func() int {
+			return len(items)
+		},
+		func() fyne.CanvasObject {
+			status := widget.NewLabel("Template")
+			status.Alignment = fyne.TextAlignTrailing
+			return container.NewBorder(nil, nil, nil, status, ui.NewLabelWithTruncation("Template"))
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id < 0 || id >= len(items) {
+				return
+			}
+			r := items[id]
+			c := co.(*fyne.Container).Objects
+			name := c[0].(*widget.Label)
+			status := c[1].(*widget.Label)
+			name.TextStyle.Bold = r.isHeader
+			name.SetText(r.label)
+			status.Importance = r.importance
+			status.SetText(r.status)
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		defer list.UnselectAll()
+		if id < 0 || id >= len(items) {
+			return
+		}
+		r := items[id]
+		if r.isHeader || r.typeID == 0 {
+			return
+		}
+		u.InfoViewer().ShowType(r.typeID, 0)
+	}
+	hint := widget.NewLabel("Checking skills...")
+	hint.Importance = widget.LowImportance
+	go func() {
+		checks, err := u.Character().CheckFittingSkills(context.Background(), cf)
+		fyne.Do(func() {
+			if err != nil {
+				hint.Text = "ERROR: " + u.ErrorDisplay(err)
+				hint.Importance = widget.DangerImportance
+				hint.Refresh()
+				return
+			}
+			items = makeSkillCheckItems(checks)
+			if len(items) == 0 {
+				hint.SetText("No characters")
+				return
+			}
+			hint.Hide()
+			list.Refresh()
+		})
+	}()
+	return container.NewBorder(hint, nil, nil, nil, list)
+}
+
+// showFittingWindow shows the details of a fitting in a window.
+func showFittingWindow(u baseUI, cf *app.CharacterFitting) {
+	var windowID string
+	if cf.FittingID != 0 {
+		windowID = fmt.Sprintf("fitting-%d-%d", cf.CharacterID, cf.FittingID)
+	} else {
+		windowID = fmt.Sprintf("fitting-eft-%d-%s", cf.ShipType.ID, cf.Name)
+	}
+	title := fmt.Sprintf("Fitting: %s", cf.Name)
+	w, created := u.GetOrCreateWindow(windowID, title, cf.ShipType.Name)
+	if !created {
+		w.Show()
+		return
+	}
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Name", widget.NewLabel(cf.Name)),
+		widget.NewFormItem("Ship", ui.MakeLinkLabelWithWrap(cf.ShipType.Name, func() {
+			u.InfoViewer().ShowType(cf.ShipType.ID, 0)
+		})),
+		widget.NewFormItem("Group", widget.NewLabel(cf.ShipType.Group.Name)),
+	}
+	if cf.Description != "" {
+		description := widget.NewLabel(cf.Description)
+		description.Wrapping = fyne.TextWrapWord
+		items = append(items, widget.NewFormItem("Description", description))
+	}
+	items = append(items, widget.NewFormItem("Items", widget.NewLabel(ihumanize.Comma(len(cf.Items)))))
+	if u.IsDeveloperMode() && cf.FittingID != 0 {
+		items = append(items, widget.NewFormItem("Fitting ID", xwidget.NewTappableLabelWithClipboardCopy(fmt.Sprint(cf.FittingID))))
+	}
+	f := widget.NewForm(items...)
+	f.Orientation = widget.Adaptive
+
+	type listItem struct {
+		isHeader bool
+		label    string
+		item     *app.CharacterFittingItem
+	}
+	var listItems []listItem
+	for _, s := range makeFittingSections(cf) {
+		listItems = append(listItems, listItem{isHeader: true, label: s.label})
+		for _, it := range s.items {
+			listItems = append(listItems, listItem{item: it})
+		}
+	}
+	modules := widget.NewList(
+		func() int {
+			return len(listItems)
+		},
+		func() fyne.CanvasObject {
+			quantity := widget.NewLabel("Template")
+			quantity.Alignment = fyne.TextAlignTrailing
+			return container.NewBorder(nil, nil, nil, quantity, ui.NewLabelWithTruncation("Template"))
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id < 0 || id >= len(listItems) {
+				return
+			}
+			r := listItems[id]
+			c := co.(*fyne.Container).Objects
+			name := c[0].(*widget.Label)
+			quantity := c[1].(*widget.Label)
+			if r.isHeader {
+				name.TextStyle.Bold = true
+				name.SetText(r.label)
+				quantity.SetText("")
+				return
+			}
+			name.TextStyle.Bold = false
+			name.SetText(r.item.Type.Name)
+			if r.item.Quantity > 1 {
+				quantity.SetText("x " + ihumanize.Comma(r.item.Quantity))
+			} else {
+				quantity.SetText("")
+			}
+		},
+	)
+	modules.OnSelected = func(id widget.ListItemID) {
+		defer modules.UnselectAll()
+		if id < 0 || id >= len(listItems) {
+			return
+		}
+		r := listItems[id]
+		if r.isHeader {
+			return
+		}
+		u.InfoViewer().ShowType(r.item.Type.ID, 0)
+	}
+
+	copyButton := widget.NewButtonWithIcon("Copy EFT", theme.ContentCopyIcon(), func() {
+		fyne.CurrentApp().Clipboard().SetContent(cf.EFT())
+		u.ShowSnackbar("Fitting copied to clipboard")
+	})
+	content := container.NewBorder(
+		nil,
+		container.NewCenter(copyButton),
+		nil,
+		nil,
+		container.NewAppTabs(
+			container.NewTabItem("Overview", container.NewVScroll(f)),
+			container.NewTabItem(fmt.Sprintf("Items (%d)", len(cf.Items)), modules),
+			container.NewTabItem("Skills", makeSkillCheckList(u, cf)),
+		),
+	)
+	ui.MakeDetailWindow(ui.MakeDetailWindowParams{
+		Content: content,
+		ImageAction: func() {
+			u.InfoViewer().ShowType(cf.ShipType.ID, 0)
+		},
+		ImageLoader: func(setter func(r fyne.Resource)) {
+			u.EVEImage().InventoryTypeRenderAsync(cf.ShipType.ID, 256, setter)
+		},
+		MinSize: fyne.NewSize(500, 450),
+		Title:   title,
+		Window:  w,
+	})
+	w.Show()
+}
@@ -0,0 +1,545 @@
+package industry
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/asset"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+	"github.com/ErikKalkoken/evebuddy/internal/xstrings"
+	"github.com/ErikKalkoken/evebuddy/internal/xwidget"
+)
+
+const (
+	blueprintKindOriginal = "BPO"
+	blueprintKindCopy     = "BPC"
+)
+
+type blueprintRow struct {
+	characterID   int64
+	count         int
+	isCopy        bool
+	isCorporation bool
+	itemID        int64
+	kind          string
+	locationName  string
+	me            int
+	ownerID       int64
+	ownerName     string
+	runs          int
+	runsDisplay   string
+	tags          set.Set[string]
+	te            int
+	typeID        int64
+	typeName      string
+}
+
+func newBlueprintRow(bp app.Blueprint) blueprintRow {
+	r := blueprintRow{
+		count:       bp.Count(),
+		isCopy:      bp.IsCopy(),
+		itemID:      bp.ItemID,
+		kind:        bp.KindDisplay(),
+		me:          bp.MaterialEfficiency,
+		runs:        bp.Runs,
+		runsDisplay: bp.RunsDisplay(),
+		te:          bp.TimeEfficiency,
+		typeName:    bp.TypeName(),
+	}
+	if bp.Type != nil {
+		r.typeID = bp.Type.ID
+	}
+	return r
+}
+
+func (r blueprintRow) efficiencyDisplay() string {
+	return fmt.Sprintf("ME %d • TE %d", r.me, r.te)
+}
+
+func (r blueprintRow) kindDisplay() string {
+	if r.count > 1 {
+		return fmt.Sprintf("%s x%d", r.kind, r.count)
+	}
+	return r.kind
+}
+
+// Blueprints is a widget which shows the blueprints of all characters and corporations.
+type Blueprints struct {
+	widget.BaseWidget
+
+	columnSorter   *xwidget.ColumnSorter[blueprintRow]
+	footer         *widget.Label
+	main           fyne.CanvasObject
+	rows           []blueprintRow
+	rowsFiltered   []blueprintRow
+	selectKind     *kxwidget.FilterChipSelect
+	selectLocation *kxwidget.FilterChipSelect
+	selectOwner    *kxwidget.FilterChipSelect
+	selectTag      *kxwidget.FilterChipSelect
+	selectType     *kxwidget.FilterChipSelect
+	sortButton     *xwidget.SortButton[blueprintRow]
+	u              baseUI
+}
+
+const (
+	blueprintsColType = iota + 1
+	blueprintsColKind
+	blueprintsColME
+	blueprintsColTE
+	blueprintsColRuns
+	blueprintsColLocation
+	blueprintsColOwner
+)
+
+func NewBlueprints(u baseUI) *Blueprints {
+	columns := xwidget.NewDataColumns([]xwidget.DataColumn[blueprintRow]{
+		ui.MakeEveEntityColumn(ui.MakeEveEntityColumnParams[blueprintRow]{
+			ColumnID: blueprintsColType,
+			EIS:      u.EVEImage(),
+			GetEntity: func(r blueprintRow) *app.EveEntity {
+				return &app.EveEntity{
+					ID:       r.typeID,
+					Name:     r.typeName,
+					Category: app.EveEntityInventoryType,
+				}
+			},
+			IsAvatar: false,
+			Label:    "Type",
+			Width:    300,
+		}), {
+			ID:    blueprintsColKind,
+			Label: "Kind",
+			Width: 75,
+			Sort: func(a, b blueprintRow) int {
+				return strings.Compare(a.kind, b.kind)
+			},
+			Update: func(r blueprintRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.kindDisplay())
+			},
+		}, {
+			ID:    blueprintsColME,
+			Label: "ME",
+			Width: 50,
+			Sort: func(a, b blueprintRow) int {
+				return cmp.Compare(a.me, b.me)
+			},
+			Update: func(r blueprintRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(fmt.Sprint(r.me), widget.RichTextStyle{
+					Alignment: fyne.TextAlignTrailing,
+				})
+			},
+		}, {
+			ID:    blueprintsColTE,
+			Label: "TE",
+			Width: 50,
+			Sort: func(a, b blueprintRow) int {
+				return cmp.Compare(a.te, b.te)
+			},
+			Update: func(r blueprintRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(fmt.Sprint(r.te), widget.RichTextStyle{
+					Alignment: fyne.TextAlignTrailing,
+				})
+			},
+		}, {
+			ID:    blueprintsColRuns,
+			Label: "Runs",
+			Width: 75,
+			Sort: func(a, b blueprintRow) int {
+				return cmp.Compare(a.runs, b.runs)
+			},
+			Update: func(r blueprintRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.runsDisplay, widget.RichTextStyle{
+					Alignment: fyne.TextAlignTrailing,
+				})
+			},
+		}, {
+			ID:    blueprintsColLocation,
+			Label: "Location",
+			Width: ui.ColumnWidthLocation,
+			Sort: func(a, b blueprintRow) int {
+				return strings.Compare(a.locationName, b.locationName)
+			},
+			Update: func(r blueprintRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.locationName)
+			},
+		}, {
+			ID:    blueprintsColOwner,
+			Label: "Owner",
+			Width: ui.ColumnWidthEntity,
+			Sort: func(a, b blueprintRow) int {
+				return xstrings.CompareIgnoreCase(a.ownerName, b.ownerName)
+			},
+			Update: func(r blueprintRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.ownerName)
+			},
+		}})
+	a := &Blueprints{
+		columnSorter: xwidget.NewColumnSorter(columns, blueprintsColType, xwidget.SortAsc),
+		footer:       ui.NewLabelWithTruncation(""),
+		u:            u,
+	}
+	a.ExtendBaseWidget(a)
+
+	if !a.u.IsMobile() {
+		a.main = xwidget.MakeDataTable(
+			columns,
+			&a.rowsFiltered,
+			func() fyne.CanvasObject {
+				x := xwidget.NewRichText()
+				x.Truncation = fyne.TextTruncateClip
+				return x
+			},
+			a.columnSorter,
+			a.filterRowsAsync, func(_ int, r blueprintRow) {
+				a.u.InfoViewer().ShowType(r.typeID, r.characterID)
+			})
+	} else {
+		a.main = a.makeDataList()
+	}
+
+	a.selectKind = kxwidget.NewFilterChipSelect("Kind", []string{
+		blueprintKindOriginal,
+		blueprintKindCopy,
+	}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.selectKind.SortDisabled = true
+	a.selectLocation = kxwidget.NewFilterChipSelectWithSearch("Location", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	}, a.u.MainWindow())
+	a.selectOwner = kxwidget.NewFilterChipSelect("Owner", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.selectTag = kxwidget.NewFilterChipSelect("Tag", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.selectType = kxwidget.NewFilterChipSelectWithSearch("Type", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	}, a.u.MainWindow())
+	a.sortButton = a.columnSorter.NewSortButton(func() {
+		a.filterRowsAsync(-1)
+	})
+
+	// Signals
+	a.u.Signals().AppInit.AddListener(func(ctx context.Context, _ struct{}) {
+		a.update(ctx)
+	})
+	a.u.Signals().CharacterSectionChanged.AddListener(func(ctx context.Context, arg app.CharacterSectionUpdated) {
+		switch arg.Section {
+		case app.SectionCharacterAssets, app.SectionCharacterBlueprints:
+			a.update(ctx)
+		}
+	})
+	a.u.Signals().CorporationSectionChanged.AddListener(func(ctx context.Context, arg app.CorporationSectionUpdated) {
+		switch arg.Section {
+		case app.SectionCorporationAssets, app.SectionCorporationBlueprints:
+			a.update(ctx)
+		}
+	})
+	a.u.Signals().CharacterAdded.AddListener(func(ctx context.Context, _ *app.Character) {
+		a.update(ctx)
+	})
+	a.u.Signals().CharacterRemoved.AddListener(func(ctx context.Context, _ *app.EntityShort) {
+		a.update(ctx)
+	})
+	a.u.Signals().TagsChanged.AddListener(func(ctx context.Context, _ struct{}) {
+		a.update(ctx)
+	})
+	return a
+}
+
+func (a *Blueprints) CreateRenderer() fyne.WidgetRenderer {
+	filter := container.NewHBox(a.selectType, a.selectKind, a.selectLocation, a.selectOwner, a.selectTag)
+	if a.u.IsMobile() {
+		filter.Add(a.sortButton)
+	}
+	p := theme.Padding()
+	c := container.NewBorder(
+		container.NewHScroll(filter),
+		container.New(layout.NewCustomPaddedLayout(p, p, 0, 0), a.footer),
+		nil,
+		nil,
+		a.main,
+	)
+	return widget.NewSimpleRenderer(c)
+}
+
+func (a *Blueprints) makeDataList() *xwidget.StripedList {
+	p := theme.Padding()
+	l := xwidget.NewStripedList(
+		func() int {
+			return len(a.rowsFiltered)
+		},
+		func() fyne.CanvasObject {
+			item := widget.NewLabel("Template")
+			item.Truncation = fyne.TextTruncateClip
+			item.TextStyle.Bold = true
+			kind := widget.NewLabel("Template")
+			kind.Alignment = fyne.TextAlignTrailing
+			efficiency := widget.NewLabel("Template")
+			runs := widget.NewLabel("Template")
+			runs.Alignment = fyne.TextAlignTrailing
+			location := widget.NewLabel("Template")
+			location.Truncation = fyne.TextTruncateClip
+			owner := widget.NewLabel("Template")
+			owner.Truncation = fyne.TextTruncateClip
+			return container.New(layout.NewCustomPaddedVBoxLayout(-p),
+				container.NewBorder(nil, nil, nil, kind, item),
+				container.NewBorder(nil, nil, nil, runs, efficiency),
+				location,
+				owner,
+			)
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id < 0 || id >= len(a.rowsFiltered) {
+				return
+			}
+			r := a.rowsFiltered[id]
+			c := co.(*fyne.Container).Objects
+
+			b0 := c[0].(*fyne.Container).Objects
+			b0[0].(*widget.Label).SetText(r.typeName)
+			b0[1].(*widget.Label).SetText(r.kindDisplay())
+
+			b1 := c[1].(*fyne.Container).Objects
+			b1[0].(*widget.Label).SetText(r.efficiencyDisplay())
+			b1[1].(*widget.Label).SetText("Runs: " + r.runsDisplay)
+
+			c[2].(*widget.Label).SetText(r.locationName)
+			c[3].(*widget.Label).SetText(r.ownerName)
+		},
+	)
+	l.OnSelected = func(id widget.ListItemID) {
+		defer l.UnselectAll()
+		if id < 0 || id >= len(a.rowsFiltered) {
+			return
+		}
+		r := a.rowsFiltered[id]
+		a.u.InfoViewer().ShowType(r.typeID, r.characterID)
+	}
+	l.HideSeparators = true
+	return l
+}
+
+func (a *Blueprints) filterRowsAsync(sortCol int) {
+	totalRows := len(a.rows)
+	rows := slices.Clone(a.rows)
+	kind := a.selectKind.Selected
+	location := a.selectLocation.Selected
+	owner := a.selectOwner.Selected
+	tag := a.selectTag.Selected
+	et := a.selectType.Selected
+	sortCol, dir, doSort := a.columnSorter.CalcSort(sortCol)
+
+	go func() {
+		// filter
+		if kind != "" {
+			rows = slices.DeleteFunc(rows, func(r blueprintRow) bool {
+				return r.kind != kind
+			})
+		}
+		if location != "" {
+			rows = slices.DeleteFunc(rows, func(r blueprintRow) bool {
+				return r.locationName != location
+			})
+		}
+		if owner != "" {
+			rows = slices.DeleteFunc(rows, func(r blueprintRow) bool {
+				return r.ownerName != owner
+			})
+		}
+		if tag != "" {
+			rows = slices.DeleteFunc(rows, func(r blueprintRow) bool {
+				return !r.tags.Contains(tag)
+			})
+		}
+		if et != "" {
+			rows = slices.DeleteFunc(rows, func(r blueprintRow) bool {
+				return r.typeName != et
+			})
+		}
+		a.columnSorter.SortRows(rows, sortCol, dir, doSort)
+		// set data & refresh
+		locationOptions := xslices.Map(rows, func(r blueprintRow) string {
+			return r.locationName
+		})
+		ownerOptions := xslices.Map(rows, func(r blueprintRow) string {
+			return r.ownerName
+		})
+		tagOptions := slices.Sorted(set.Union(xslices.Map(rows, func(r blueprintRow) set.Set[string] {
+			return r.tags
+		})...).All())
+		typeOptions := xslices.Map(rows, func(r blueprintRow) string {
+			return r.typeName
+		})
+
+		var originals, copies int
+		for _, r := range rows {
+			if r.isCopy {
+				copies += r.count
+			} else {
+				originals += r.count
+			}
+		}
+		footer := fmt.Sprintf(
+			"Showing %d / %d blueprints • %d originals • %d copies",
+			len(rows),
+			totalRows,
+			originals,
+			copies,
+		)
+
+		fyne.Do(func() {
+			a.footer.Text = footer
+			a.footer.Importance = widget.MediumImportance
+			a.footer.Refresh()
+			a.selectLocation.SetOptions(locationOptions)
+			a.selectOwner.SetOptions(ownerOptions)
+			a.selectTag.SetOptions(tagOptions)
+			a.selectType.SetOptions(typeOptions)
+			a.rowsFiltered = rows
+			a.main.Refresh()
+		})
+	}()
+}
+
+func (a *Blueprints) update(ctx context.Context) {
+	rows, err := a.fetchRows(ctx)
+	if err != nil {
+		slog.Error("Failed to refresh blueprints UI", "err", err)
+		fyne.Do(func() {
+			a.footer.Text = "ERROR: " + a.u.ErrorDisplay(err)
+			a.footer.Importance = widget.DangerImportance
+			a.footer.Refresh()
+		})
+		return
+	}
+	fyne.Do(func() {
+		a.rows = rows
+		a.filterRowsAsync(-1)
+	})
+}
+
+func (a *Blueprints) fetchRows(ctx context.Context) ([]blueprintRow, error) {
+	locations, err := a.u.EVEUniverse().ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locationLookup := make(map[int64]*app.EveLocation)
+	for _, el := range locations {
+		locationLookup[el.ID] = el
+	}
+	rows1, err := a.fetchCharacterRows(ctx, locations, locationLookup)
+	if err != nil {
+		return nil, err
+	}
+	rows2, err := a.fetchCorporationRows(ctx, locations, locationLookup)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Concat(rows1, rows2), nil
+}
+
+func (a *Blueprints) fetchCharacterRows(ctx context.Context, locations []*app.EveLocation, locationLookup map[int64]*app.EveLocation) ([]blueprintRow, error) {
+	blueprints, err := a.u.Character().ListAllBlueprints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(blueprints) == 0 {
+		return nil, nil
+	}
+	characters, err := a.u.Character().CharacterNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tagsPerCharacter := make(map[int64]set.Set[string])
+	for id := range characters {
+		tags, err := a.u.Character().ListTagsForCharacter(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		tagsPerCharacter[id] = tags
+	}
+	assets, err := a.u.Character().ListAllAssets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tree := asset.NewFromCharacterAssets(assets, locations)
+	var rows []blueprintRow
+	for _, bp := range blueprints {
+		r := newBlueprintRow(bp.Blueprint)
+		r.characterID = bp.CharacterID
+		r.locationName = blueprintLocationName(bp.Blueprint, tree, locationLookup)
+		r.ownerID = bp.CharacterID
+		r.ownerName = characters[bp.CharacterID]
+		r.tags = tagsPerCharacter[bp.CharacterID]
+		rows = append(rows, r)
+	}
+	return rows, nil
+}
+
+func (a *Blueprints) fetchCorporationRows(ctx context.Context, locations []*app.EveLocation, locationLookup map[int64]*app.EveLocation) ([]blueprintRow, error) {
+	blueprints, err := a.u.Corporation().ListAllBlueprints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(blueprints) == 0 {
+		return nil, nil
+	}
+	cc, err := a.u.Corporation().ListCorporationsShort(ctx)
+	if err != nil {
+		return nil, err
+	}
+	corporationNames := make(map[int64]string)
+	for _, o := range cc {
+		corporationNames[o.ID] = o.Name
+	}
+	assets, err := a.u.Corporation().ListAllAssets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tree := asset.NewFromCorporationAssets(assets, locations)
+	var rows []blueprintRow
+	for _, bp := range blueprints {
+		r := newBlueprintRow(bp.Blueprint)
+		r.isCorporation = true
+		r.locationName = blueprintLocationName(bp.Blueprint, tree, locationLookup)
+		r.ownerID = bp.CorporationID
+		r.ownerName = corporationNames[bp.CorporationID]
+		r.tags = set.Of[string]()
+		rows = append(rows, r)
+	}
+	return rows, nil
+}
+
+// blueprintLocationName returns the name of the location where a blueprint is stored.
+// Blueprints inside containers, ships or corporation hangars are resolved with the asset tree.
+func blueprintLocationName(bp app.Blueprint, tree asset.Tree, locations map[int64]*app.EveLocation) string {
+	for _, id := range []int64{bp.ItemID, bp.LocationID} {
+		n, ok := tree.LocationForItem(id)
+		if !ok {
+			continue
+		}
+		if el, ok := n.Location(); ok {
+			return el.DisplayName()
+		}
+	}
+	if el, ok := locations[bp.LocationID]; ok {
+		return el.DisplayName()
+	}
+	return "?"
+}
@@ -0,0 +1,93 @@
+package industry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/asset"
+)
+
+func TestBlueprintLocationName(t *testing.T) {
+	const (
+		stationID   = 60000001
+		structureID = 1000000000001
+		shipID      = 10001
+		blueprintID = 10002
+	)
+	shipType := &app.EveType{
+		ID:    603,
+		Name:  "Merlin",
+		Group: &app.EveGroup{ID: 25, Category: &app.EveCategory{ID: app.EveCategoryShip}},
+	}
+	blueprintType := &app.EveType{
+		ID:    954,
+		Name:  "Merlin Blueprint",
+		Group: &app.EveGroup{ID: 105, Category: &app.EveCategory{ID: app.EveCategoryBlueprint}},
+	}
+	station := &app.EveLocation{ID: stationID, Name: "Jita IV - Moon 4"}
+	structure := &app.EveLocation{ID: structureID, Name: "Perimeter - Keepstar"}
+	locations := []*app.EveLocation{station, structure}
+	locationLookup := map[int64]*app.EveLocation{
+		stationID:   station,
+		structureID: structure,
+	}
+	assets := []*app.CharacterAsset{
+		{
+			CharacterID: 1001,
+			Asset: app.Asset{
+				IsSingleton:  true,
+				ItemID:       shipID,
+				LocationFlag: app.FlagHangar,
+				LocationID:   stationID,
+				LocationType: app.TypeStation,
+				Quantity:     1,
+				Type:         shipType,
+			},
+		},
+		{
+			CharacterID: 1001,
+			Asset: app.Asset{
+				IsSingleton:  true,
+				ItemID:       blueprintID,
+				LocationFlag: app.FlagCargo,
+				LocationID:   shipID,
+				LocationType: app.TypeItem,
+				Quantity:     1,
+				Type:         blueprintType,
+			},
+		},
+	}
+	tree := asset.NewFromCharacterAssets(assets, locations)
+	cases := []struct {
+		name string
+		bp   app.Blueprint
+		want string
+	}{
+		{"blueprint found in assets", app.Blueprint{ItemID: blueprintID, LocationID: shipID}, "Jita IV - Moon 4"},
+		{"container found in assets", app.Blueprint{ItemID: 99, LocationID: shipID}, "Jita IV - Moon 4"},
+		{"known location", app.Blueprint{ItemID: 99, LocationID: structureID}, "Perimeter - Keepstar"},
+		{"unknown location", app.Blueprint{ItemID: 99, LocationID: 42}, "?"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := blueprintLocationName(tc.bp, tree, locationLookup)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBlueprintRow(t *testing.T) {
+	t.Run("should show count for stacks of originals", func(t *testing.T) {
+		r := newBlueprintRow(app.Blueprint{Quantity: 3, Runs: -1})
+		assert.Equal(t, "BPO x3", r.kindDisplay())
+		assert.Equal(t, "∞", r.runsDisplay)
+	})
+	t.Run("should show copies", func(t *testing.T) {
+		r := newBlueprintRow(app.Blueprint{Quantity: -2, Runs: 10, MaterialEfficiency: 10, TimeEfficiency: 20})
+		assert.Equal(t, "BPC", r.kindDisplay())
+		assert.Equal(t, "ME 10 • TE 20", r.efficiencyDisplay())
+		assert.Equal(t, "10", r.runsDisplay)
+	})
+}
@@ -0,0 +1,602 @@
+package industry
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/asset"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	"github.com/ErikKalkoken/evebuddy/internal/everefservice"
+	ihumanize "github.com/ErikKalkoken/evebuddy/internal/humanize"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+)
+
+type calculatorBlueprint struct {
+	me     int
+	name   string
+	te     int
+	typeID int64
+}
+
+type calculatorSystem struct {
+	id       int64
+	name     string
+	security app.SolarSystemSecurityType
+}
+
+type calculatorParams struct {
+	blueprint   calculatorBlueprint
+	facilityTax float64
+	market      app.MarketHub
+	me          int
+	rig         app.ManufacturingRig
+	runs        int
+	structure   app.ManufacturingStructure
+	system      calculatorSystem
+	te          int
+}
+
+type calculatorMaterial struct {
+	name     string
+	quantity int64
+	total    float64
+	typeID   int64
+}
+
+type calculatorResult struct {
+	cost            app.ManufacturingCost
+	costIndex       float64
+	materials       []calculatorMaterial
+	missingPrices   []string
+	priceSource     string
+	productName     string
+	productQuantity int64
+}
+
+// Calculator is a UI component for calculating the cost and profit of manufacturing jobs.
+type Calculator struct {
+	widget.BaseWidget
+
+	blueprints      map[string]calculatorBlueprint
+	entryME         *widget.Entry
+	entryRuns       *widget.Entry
+	entryTax        *widget.Entry
+	entryTE         *widget.Entry
+	materials       []calculatorMaterial
+	materialList    *widget.List
+	selectBlueprint *kxwidget.FilterChipSelect
+	selectMarket    *widget.Select
+	selectRig       *widget.Select
+	selectStructure *widget.Select
+	selectSystem    *kxwidget.FilterChipSelect
+	status          *widget.Label
+	summary         *widget.Form
+	systems         map[string]calculatorSystem
+	u               baseUI
+	values          map[string]*widget.Label
+}
+
+const (
+	calculatorCostIndex    = "Cost index"
+	calculatorDuration     = "Job duration"
+	calculatorEIV          = "Estimated item value"
+	calculatorInstallCost  = "Job install cost"
+	calculatorMargin       = "Profit margin"
+	calculatorMaterialCost = "Material cost"
+	calculatorPrices       = "Market prices"
+	calculatorProduct      = "Product"
+	calculatorProfit       = "Profit"
+	calculatorRevenue      = "Sell value"
+)
+
+func NewCalculator(u baseUI) *Calculator {
+	a := &Calculator{
+		blueprints: make(map[string]calculatorBlueprint),
+		entryME:    widget.NewEntry(),
+		entryRuns:  widget.NewEntry(),
+		entryTax:   widget.NewEntry(),
+		entryTE:    widget.NewEntry(),
+		status:     widget.NewLabel(""),
+		summary:    widget.NewForm(),
+		systems:    make(map[string]calculatorSystem),
+		u:          u,
+		values:     make(map[string]*widget.Label),
+	}
+	a.ExtendBaseWidget(a)
+	a.status.Wrapping = fyne.TextWrapWord
+
+	for _, k := range []string{
+		calculatorProduct,
+		calculatorDuration,
+		calculatorPrices,
+		calculatorCostIndex,
+		calculatorEIV,
+		calculatorMaterialCost,
+		calculatorInstallCost,
+		calculatorRevenue,
+		calculatorProfit,
+		calculatorMargin,
+	} {
+		l := widget.NewLabel("")
+		l.Alignment = fyne.TextAlignTrailing
+		a.values[k] = l
+		a.summary.Append(k, l)
+	}
+
+	a.selectBlueprint = kxwidget.NewFilterChipSelectWithSearch("Blueprint", []string{}, func(s string) {
+		if bp, ok := a.blueprints[s]; ok {
+			a.entryME.SetText(strconv.Itoa(bp.me))
+			a.entryTE.SetText(strconv.Itoa(bp.te))
+		}
+		a.calculateAsync()
+	}, a.u.MainWindow())
+	a.selectSystem = kxwidget.NewFilterChipSelectWithSearch("System", []string{}, func(string) {
+		a.calculateAsync()
+	}, a.u.MainWindow())
+
+	a.selectStructure = widget.NewSelect(xslices.Map(app.ManufacturingStructures(), func(x app.ManufacturingStructure) string {
+		return x.String()
+	}), func(s string) {
+		if s == app.StructureNPCStation.String() {
+			a.selectRig.SetSelectedIndex(0)
+			a.selectRig.Disable()
+		} else {
+			a.selectRig.Enable()
+		}
+		a.calculateAsync()
+	})
+	a.selectRig = widget.NewSelect(xslices.Map(app.ManufacturingRigs(), func(x app.ManufacturingRig) string {
+		return x.String()
+	}), func(string) {
+		a.calculateAsync()
+	})
+	a.selectRig.SetSelectedIndex(0)
+	a.selectStructure.SetSelectedIndex(0)
+	a.selectMarket = widget.NewSelect(xslices.Map(app.MarketHubs(), func(x app.MarketHub) string {
+		return x.Name
+	}), func(string) {
+		a.calculateAsync()
+	})
+	a.selectMarket.SetSelectedIndex(0)
+
+	for _, e := range []*widget.Entry{a.entryME, a.entryRuns, a.entryTax, a.entryTE} {
+		e.OnSubmitted = func(string) {
+			a.calculateAsync()
+		}
+	}
+	a.entryME.SetPlaceHolder("0 - 10")
+	a.entryTE.SetPlaceHolder("0 - 20")
+	a.entryRuns.SetText("1")
+	a.entryTE.SetText("0")
+	a.entryTax.SetText("0")
+
+	a.materialList = a.makeMaterialList()
+
+	// Signals
+	a.u.Signals().AppInit.AddListener(func(ctx context.Context, _ struct{}) {
+		a.update(ctx)
+	})
+	a.u.Signals().CharacterSectionChanged.AddListener(func(ctx context.Context, arg app.CharacterSectionUpdated) {
+		switch arg.Section {
+		case app.SectionCharacterAssets, app.SectionCharacterBlueprints:
+			a.update(ctx)
+		}
+	})
+	a.u.Signals().CorporationSectionChanged.AddListener(func(ctx context.Context, arg app.CorporationSectionUpdated) {
+		if arg.Section == app.SectionCorporationBlueprints {
+			a.update(ctx)
+		}
+	})
+	a.u.Signals().EveUniverseSectionChanged.AddListener(func(ctx context.Context, arg app.EveUniverseSectionUpdated) {
+		if arg.Section == app.SectionEveTypes {
+			a.update(ctx)
+		}
+	})
+	a.u.Signals().CharacterRemoved.AddListener(func(ctx context.Context, _ *app.EntityShort) {
+		a.update(ctx)
+	})
+	return a
+}
+
+func (a *Calculator) CreateRenderer() fyne.WidgetRenderer {
+	calculate := widget.NewButtonWithIcon("Calculate", theme.MediaPlayIcon(), func() {
+		a.calculateAsync()
+	})
+	calculate.Importance = widget.HighImportance
+	form := widget.NewForm(
+		widget.NewFormItem("ME", a.entryME),
+		widget.NewFormItem("TE", a.entryTE),
+		widget.NewFormItem("Runs", a.entryRuns),
+		widget.NewFormItem("Structure", a.selectStructure),
+		widget.NewFormItem("Rig", a.selectRig),
+		widget.NewFormItem("Facility tax %", a.entryTax),
+		widget.NewFormItem("Market", a.selectMarket),
+	)
+	top := container.NewVBox(
+		container.NewHScroll(container.NewHBox(a.selectBlueprint, a.selectSystem)),
+		form,
+		container.NewHBox(calculate),
+		a.status,
+	)
+	bottom := container.NewVBox(widget.NewSeparator(), a.summary)
+	var c fyne.CanvasObject
+	if a.u.IsMobile() {
+		c = container.NewVScroll(container.NewVBox(top, a.summary, widget.NewLabel("Materials")))
+		c = container.NewVSplit(c, a.materialList)
+	} else {
+		c = container.NewBorder(top, bottom, nil, nil, a.materialList)
+	}
+	return widget.NewSimpleRenderer(c)
+}
+
+func (a *Calculator) makeMaterialList() *widget.List {
+	l := widget.NewList(
+		func() int {
+			return len(a.materials)
+		},
+		func() fyne.CanvasObject {
+			name := widget.NewLabel("Template")
+			name.Truncation = fyne.TextTruncateClip
+			total := widget.NewLabel("Template")
+			total.Alignment = fyne.TextAlignTrailing
+			return container.NewBorder(nil, nil, nil, total, name)
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id < 0 || id >= len(a.materials) {
+				return
+			}
+			m := a.materials[id]
+			c := co.(*fyne.Container).Objects
+			c[0].(*widget.Label).SetText(fmt.Sprintf("%s x %s", ihumanize.Comma(m.quantity), m.name))
+			c[1].(*widget.Label).SetText(ihumanize.NumberF(m.total, 2) + " ISK")
+		},
+	)
+	l.OnSelected = func(id widget.ListItemID) {
+		defer l.UnselectAll()
+		if id < 0 || id >= len(a.materials) {
+			return
+		}
+		a.u.InfoViewer().ShowType(a.materials[id].typeID, 0)
+	}
+	return l
+}
+
+// update refreshes the available blueprints and systems.
+func (a *Calculator) update(ctx context.Context) {
+	blueprints, systems, err := a.fetchOptions(ctx)
+	if err != nil {
+		slog.Error("Failed to refresh calculator UI", "err", err)
+		fyne.Do(func() {
+			a.setStatus("ERROR: "+a.u.ErrorDisplay(err), widget.DangerImportance)
+		})
+		return
+	}
+	fyne.Do(func() {
+		a.blueprints = blueprints
+		a.systems = systems
+		a.selectBlueprint.SetOptions(slices.Collect(maps.Keys(blueprints)))
+		a.selectSystem.SetOptions(slices.Collect(maps.Keys(systems)))
+		if _, ok := blueprints[a.selectBlueprint.Selected]; !ok {
+			a.selectBlueprint.ClearSelected()
+		}
+		if _, ok := systems[a.selectSystem.Selected]; !ok {
+			a.selectSystem.ClearSelected()
+		}
+	})
+}
+
+// fetchOptions returns all published blueprints and the solar systems where characters have assets.
+// Blueprints owned by characters and corporations are preset with their best ME and TE.
+func (a *Calculator) fetchOptions(ctx context.Context) (map[string]calculatorBlueprint, map[string]calculatorSystem, error) {
+	blueprints := make(map[string]calculatorBlueprint)
+	types, err := a.u.EVEUniverse().ListTypesForCategory(ctx, app.EveCategoryBlueprint)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, et := range types {
+		blueprints[et.Name] = calculatorBlueprint{
+			name:   et.Name,
+			typeID: et.ID,
+		}
+	}
+	addBlueprint := func(bp app.Blueprint) {
+		if bp.Type == nil {
+			return
+		}
+		x, ok := blueprints[bp.Type.Name]
+		if ok && (x.me > bp.MaterialEfficiency || x.me == bp.MaterialEfficiency && x.te >= bp.TimeEfficiency) {
+			return
+		}
+		blueprints[bp.Type.Name] = calculatorBlueprint{
+			me:     bp.MaterialEfficiency,
+			name:   bp.Type.Name,
+			te:     bp.TimeEfficiency,
+			typeID: bp.Type.ID,
+		}
+	}
+	cbs, err := a.u.Character().ListAllBlueprints(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, bp := range cbs {
+		addBlueprint(bp.Blueprint)
+	}
+	obs, err := a.u.Corporation().ListAllBlueprints(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, bp := range obs {
+		addBlueprint(bp.Blueprint)
+	}
+
+	locations, err := a.u.EVEUniverse().ListLocations(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	assets, err := a.u.Character().ListAllAssets(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	tree := asset.NewFromCharacterAssets(assets, locations)
+	systems := make(map[string]calculatorSystem)
+	for _, n := range tree.Locations() {
+		el, ok := n.Location()
+		if !ok {
+			continue
+		}
+		es, ok := el.SolarSystem.Value()
+		if !ok {
+			continue
+		}
+		systems[es.Name] = calculatorSystem{
+			id:       es.ID,
+			name:     es.Name,
+			security: es.SecurityType(),
+		}
+	}
+	return blueprints, systems, nil
+}
+
+func (a *Calculator) calculateAsync() {
+	arg, hint := a.makeParams()
+	if hint != "" {
+		a.setStatus(hint, widget.WarningImportance)
+		return
+	}
+	if a.u.IsOffline() {
+		a.setStatus("Can not calculate when offline", widget.WarningImportance)
+		return
+	}
+	a.setStatus("Calculating...", widget.MediumImportance)
+	go func() {
+		ctx := context.Background()
+		r, err := a.calculate(ctx, arg)
+		if err != nil {
+			slog.Error("Failed to calculate manufacturing cost", "blueprint", arg.blueprint.typeID, "err", err)
+			fyne.Do(func() {
+				a.setStatus("ERROR: "+a.u.ErrorDisplay(err), widget.DangerImportance)
+			})
+			return
+		}
+		fyne.Do(func() {
+			a.setResult(r)
+		})
+	}()
+}
+
+// makeParams returns the parameters for a calculation from the current input
+// or a hint for the user when the input is incomplete or invalid.
+func (a *Calculator) makeParams() (calculatorParams, string) {
+	var arg calculatorParams
+	bp, ok := a.blueprints[a.selectBlueprint.Selected]
+	if !ok {
+		return arg, "Select a blueprint"
+	}
+	arg.blueprint = bp
+	system, ok := a.systems[a.selectSystem.Selected]
+	if !ok {
+		return arg, "Select a system"
+	}
+	arg.system = system
+	me, err := strconv.Atoi(strings.TrimSpace(a.entryME.Text))
+	if err != nil || me < 0 || me > 10 {
+		return arg, "ME must be a number between 0 and 10"
+	}
+	arg.me = me
+	te, err := strconv.Atoi(strings.TrimSpace(a.entryTE.Text))
+	if err != nil || te < 0 || te > 20 {
+		return arg, "TE must be a number between 0 and 20"
+	}
+	arg.te = te
+	runs, err := strconv.Atoi(strings.TrimSpace(a.entryRuns.Text))
+	if err != nil || runs < 1 {
+		return arg, "Runs must be a positive number"
+	}
+	arg.runs = runs
+	tax, err := strconv.ParseFloat(strings.TrimSpace(a.entryTax.Text), 64)
+	if err != nil || tax < 0 || tax > 100 {
+		return arg, "Facility tax must be a percentage between 0 and 100"
+	}
+	arg.facilityTax = tax / 100
+	arg.structure = app.ManufacturingStructures()[max(a.selectStructure.SelectedIndex(), 0)]
+	arg.rig = app.ManufacturingRigs()[max(a.selectRig.SelectedIndex(), 0)]
+	arg.market = app.MarketHubs()[max(a.selectMarket.SelectedIndex(), 0)]
+	return arg, ""
+}
+
+func (a *Calculator) calculate(ctx context.Context, arg calculatorParams) (calculatorResult, error) {
+	var r calculatorResult
+	bp, err := a.u.EveRef().FetchBlueprint(ctx, arg.blueprint.typeID)
+	if err != nil {
+		return r, err
+	}
+	activity, ok := bp.Activities[everefservice.ActivityManufacturing]
+	if !ok || len(activity.Products) == 0 {
+		return r, fmt.Errorf("%s can not be used for manufacturing", arg.blueprint.name)
+	}
+	var product everefservice.TypeQuantity
+	for _, p := range activity.Products {
+		product = p
+		break
+	}
+	var materials []app.ManufacturingMaterial
+	typeIDs := []int64{product.TypeID}
+	for _, m := range activity.Materials {
+		materials = append(materials, app.ManufacturingMaterial{
+			Quantity: m.Quantity,
+			TypeID:   m.TypeID,
+		})
+		typeIDs = append(typeIDs, m.TypeID)
+	}
+	costIndex, err := a.u.EVEUniverse().ManufacturingCostIndexESI(ctx, arg.system.id)
+	if err != nil {
+		return r, err
+	}
+	mp, err := a.u.EVEUniverse().ListMarketPrices(ctx)
+	if err != nil {
+		return r, err
+	}
+	adjustedPrices := make(map[int64]float64)
+	for id, o := range mp {
+		if v, ok := o.AdjustedPrice.Value(); ok {
+			adjustedPrices[id] = v
+		}
+	}
+	prices, err := a.fetchRegionalPrices(ctx, arg.market.RegionID, typeIDs)
+	if err != nil {
+		return r, err
+	}
+	region, err := a.u.EVEUniverse().GetOrCreateRegionESI(ctx, arg.market.RegionID)
+	if err != nil {
+		return r, err
+	}
+	r.priceSource = "Daily average in " + region.Name
+	r.cost = app.CalculateManufacturingCost(app.ManufacturingCostParams{
+		AdjustedPrices:  adjustedPrices,
+		CostIndex:       costIndex,
+		FacilityTax:     arg.facilityTax,
+		Materials:       materials,
+		ME:              arg.me,
+		Prices:          prices,
+		ProductQuantity: product.Quantity,
+		ProductTypeID:   product.TypeID,
+		Rig:             arg.rig,
+		Runs:            arg.runs,
+		Security:        arg.system.security,
+		Structure:       arg.structure,
+		TE:              arg.te,
+		Time:            time.Duration(activity.Time) * time.Second,
+	})
+	r.costIndex = costIndex
+	r.productQuantity = product.Quantity * int64(arg.runs)
+	et, err := a.u.EVEUniverse().GetOrCreateTypeESI(ctx, product.TypeID)
+	if err != nil {
+		return r, err
+	}
+	r.productName = et.Name
+	if r.cost.MissingPrices.Contains(product.TypeID) {
+		r.missingPrices = append(r.missingPrices, et.Name)
+	}
+	for _, m := range r.cost.Materials {
+		et, err := a.u.EVEUniverse().GetOrCreateTypeESI(ctx, m.TypeID)
+		if err != nil {
+			return r, err
+		}
+		r.materials = append(r.materials, calculatorMaterial{
+			name:     et.Name,
+			quantity: m.Quantity,
+			total:    m.Total,
+			typeID:   m.TypeID,
+		})
+		if m.Price.IsEmpty() {
+			r.missingPrices = append(r.missingPrices, et.Name)
+		}
+	}
+	slices.SortFunc(r.materials, func(x, y calculatorMaterial) int {
+		return cmp.Compare(x.name, y.name)
+	})
+	slices.Sort(r.missingPrices)
+	return r, nil
+}
+
+// fetchRegionalPrices returns the latest daily average price of types in a region.
+// Types without market history have no price.
+func (a *Calculator) fetchRegionalPrices(ctx context.Context, regionID int64, typeIDs []int64) (map[int64]float64, error) {
+	var mu sync.Mutex
+	prices := make(map[int64]float64)
+	g := new(errgroup.Group)
+	g.SetLimit(5)
+	for _, id := range typeIDs {
+		g.Go(func() error {
+			oo, err := a.u.EVEUniverse().MarketHistory(ctx, regionID, id)
+			if err != nil {
+				slog.Warn("Failed to fetch market history for calculator", "regionID", regionID, "typeID", id, "err", err)
+				return nil
+			}
+			if len(oo) == 0 {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			prices[id] = oo[len(oo)-1].Average
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+func (a *Calculator) setResult(r calculatorResult) {
+	a.materials = r.materials
+	a.materialList.Refresh()
+	a.values[calculatorProduct].SetText(fmt.Sprintf("%s x %s", ihumanize.Comma(r.productQuantity), r.productName))
+	a.values[calculatorDuration].SetText(ihumanize.Duration(r.cost.Duration))
+	a.values[calculatorPrices].SetText(r.priceSource)
+	a.values[calculatorCostIndex].SetText(fmt.Sprintf("%.2f %%", r.costIndex*100))
+	a.values[calculatorEIV].SetText(ui.FormatISKAmount(r.cost.EstimatedItemValue))
+	a.values[calculatorMaterialCost].SetText(ui.FormatISKAmount(r.cost.MaterialCost))
+	a.values[calculatorInstallCost].SetText(ui.FormatISKAmount(r.cost.InstallCost))
+	a.values[calculatorRevenue].SetText(ui.FormatISKAmount(r.cost.Revenue))
+	profit := a.values[calculatorProfit]
+	profit.SetText(ui.FormatISKAmount(r.cost.Profit))
+	if r.cost.Profit < 0 {
+		profit.Importance = widget.DangerImportance
+	} else {
+		profit.Importance = widget.SuccessImportance
+	}
+	profit.Refresh()
+	if v, ok := r.cost.Margin().Value(); ok {
+		a.values[calculatorMargin].SetText(fmt.Sprintf("%.1f %%", v*100))
+	} else {
+		a.values[calculatorMargin].SetText("?")
+	}
+	if len(r.missingPrices) > 0 {
+		a.setStatus("No price for: "+strings.Join(r.missingPrices, ", "), widget.WarningImportance)
+		return
+	}
+	a.setStatus("", widget.MediumImportance)
+}
+
+func (a *Calculator) setStatus(text string, importance widget.Importance) {
+	a.status.Text = text
+	a.status.Importance = importance
+	a.status.Refresh()
+}
@@ -0,0 +1,555 @@
+package industry
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	ihumanize "github.com/ErikKalkoken/evebuddy/internal/humanize"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xiter"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+	"github.com/ErikKalkoken/evebuddy/internal/xstrings"
+	"github.com/ErikKalkoken/evebuddy/internal/xwidget"
+)
+
+type colonyProductionRow struct {
+	characterID  int64
+	decay        optional.Optional[float64]
+	nameDisplay  []widget.RichTextSegment
+	outputText   string
+	ownerName    string
+	planetID     int64
+	planetName   string
+	production   app.ColonyProduction
+	shortText    string
+	starvedCount int
+	valuePerDay  float64
+}
+
+func (r colonyProductionRow) decayDisplay() string {
+	return r.decay.StringFunc("-", func(v float64) string {
+		return fmt.Sprintf("%+.0f%%", v*100)
+	})
+}
+
+func (r colonyProductionRow) starvedDisplay() []widget.RichTextSegment {
+	if r.starvedCount == 0 {
+		return xwidget.RichTextSegmentsFromText("-")
+	}
+	return xwidget.RichTextSegmentsFromText(
+		fmt.Sprintf("%d short of %s", r.starvedCount, r.shortText),
+		widget.RichTextStyle{ColorName: theme.ColorNameWarning},
+	)
+}
+
+// colonyProductionTypeRow is the combined production of a type over several colonies.
+type colonyProductionTypeRow struct {
+	consumed    float64
+	name        string
+	produced    float64
+	typeID      int64
+	valuePerDay float64
+}
+
+func (r colonyProductionTypeRow) net() float64 {
+	return r.produced - r.consumed
+}
+
+// ColonyProduction is a UI component which shows the estimated production of colonies.
+type ColonyProduction struct {
+	widget.BaseWidget
+
+	body         fyne.CanvasObject
+	columnSorter *xwidget.ColumnSorter[colonyProductionRow]
+	footer       *widget.Label
+	prices       map[int64]float64
+	rows         []colonyProductionRow
+	rowsFiltered []colonyProductionRow
+	selectOwner  *kxwidget.FilterChipSelect
+	sortButton   *xwidget.SortButton[colonyProductionRow]
+	typeList     *widget.List
+	typeNames    map[int64]string
+	types        []colonyProductionTypeRow
+	u            baseUI
+}
+
+const (
+	colonyProductionColPlanet = iota + 1
+	colonyProductionColOutput
+	colonyProductionColValue
+	colonyProductionColStarved
+	colonyProductionColDecay
+	colonyProductionColCharacter
+)
+
+func NewColonyProduction(u baseUI) *ColonyProduction {
+	columns := xwidget.NewDataColumns([]xwidget.DataColumn[colonyProductionRow]{{
+		ID:    colonyProductionColPlanet,
+		Label: "Planet",
+		Width: 200,
+		Sort: func(a, b colonyProductionRow) int {
+			return strings.Compare(a.planetName, b.planetName)
+		},
+		Update: func(r colonyProductionRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).Set(r.nameDisplay)
+		},
+	}, {
+		ID:    colonyProductionColOutput,
+		Label: "Output / hour",
+		Width: 250,
+		Update: func(r colonyProductionRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(r.outputText)
+		},
+	}, {
+		ID:    colonyProductionColValue,
+		Label: "ISK / day",
+		Width: 120,
+		Sort: func(a, b colonyProductionRow) int {
+			return cmp.Compare(a.valuePerDay, b.valuePerDay)
+		},
+		Update: func(r colonyProductionRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(ihumanize.NumberF(r.valuePerDay, 1))
+		},
+	}, {
+		ID:    colonyProductionColStarved,
+		Label: "Starved factories",
+		Width: 200,
+		Sort: func(a, b colonyProductionRow) int {
+			return cmp.Compare(a.starvedCount, b.starvedCount)
+		},
+		Update: func(r colonyProductionRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).Set(r.starvedDisplay())
+		},
+	}, {
+		ID:    colonyProductionColDecay,
+		Label: "Extractor decay",
+		Width: 120,
+		Sort: func(a, b colonyProductionRow) int {
+			return optional.Compare(a.decay, b.decay)
+		},
+		Update: func(r colonyProductionRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(r.decayDisplay())
+		},
+	}, {
+		ID:    colonyProductionColCharacter,
+		Label: "Character",
+		Width: ui.ColumnWidthEntity,
+		Sort: func(a, b colonyProductionRow) int {
+			return xstrings.CompareIgnoreCase(a.ownerName, b.ownerName)
+		},
+		Update: func(r colonyProductionRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(r.ownerName)
+		},
+	}})
+	a := &ColonyProduction{
+		columnSorter: xwidget.NewColumnSorter(columns, colonyProductionColValue, xwidget.SortDesc),
+		footer:       ui.NewLabelWithTruncation(""),
+		u:            u,
+	}
+	a.ExtendBaseWidget(a)
+
+	if a.u.IsMobile() {
+		a.body = a.makeDataList()
+	} else {
+		a.body = xwidget.MakeDataTable(
+			columns,
+			&a.rowsFiltered,
+			func() fyne.CanvasObject {
+				x := xwidget.NewRichText()
+				x.Truncation = fyne.TextTruncateClip
+				return x
+			},
+			a.columnSorter,
+			a.filterRowsAsync, func(_ int, r colonyProductionRow) {
+				showColonyDetailsWindow(a.u, colonyRow{
+					characterID: r.characterID,
+					ownerName:   r.ownerName,
+					planetID:    r.planetID,
+					planetName:  r.planetName,
+				})
+			})
+	}
+	a.typeList = a.makeTypeList()
+
+	a.selectOwner = kxwidget.NewFilterChipSelect("Owner", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.sortButton = a.columnSorter.NewSortButton(func() {
+		a.filterRowsAsync(-1)
+	})
+
+	// Signals
+	a.u.Signals().AppInit.AddListener(func(ctx context.Context, _ struct{}) {
+		a.update(ctx)
+	})
+	a.u.Signals().CharacterSectionChanged.AddListener(func(ctx context.Context, arg app.CharacterSectionUpdated) {
+		if arg.Section == app.SectionCharacterPlanets {
+			a.update(ctx)
+		}
+	})
+	a.u.Signals().CharacterRemoved.AddListener(func(ctx context.Context, _ *app.EntityShort) {
+		a.update(ctx)
+	})
+	return a
+}
+
+func (a *ColonyProduction) CreateRenderer() fyne.WidgetRenderer {
+	filter := container.NewHBox(a.selectOwner)
+	if a.u.IsMobile() {
+		filter.Add(a.sortButton)
+	}
+	types := container.NewBorder(
+		widget.NewLabelWithStyle("Combined output", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		nil,
+		nil,
+		nil,
+		a.typeList,
+	)
+	var main fyne.CanvasObject
+	if a.u.IsMobile() {
+		main = container.NewVSplit(a.body, types)
+	} else {
+		split := container.NewHSplit(a.body, types)
+		split.Offset = 0.7
+		main = split
+	}
+	c := container.NewBorder(
+		container.NewHScroll(filter),
+		a.footer,
+		nil,
+		nil,
+		main,
+	)
+	return widget.NewSimpleRenderer(c)
+}
+
+func (a *ColonyProduction) makeDataList() *xwidget.StripedList {
+	p := theme.Padding()
+	l := xwidget.NewStripedList(
+		func() int {
+			return len(a.rowsFiltered)
+		},
+		func() fyne.CanvasObject {
+			title := xwidget.NewRichText()
+			title.Truncation = fyne.TextTruncateClip
+			value := widget.NewLabel("Template")
+			value.Alignment = fyne.TextAlignTrailing
+			output := widget.NewLabel("Template")
+			output.Truncation = fyne.TextTruncateClip
+			starved := xwidget.NewRichText()
+			starved.Truncation = fyne.TextTruncateClip
+			decay := widget.NewLabel("Template")
+			decay.Alignment = fyne.TextAlignTrailing
+			owner := widget.NewLabel("Template")
+			owner.Truncation = fyne.TextTruncateClip
+			return container.New(layout.NewCustomPaddedVBoxLayout(-p),
+				container.NewBorder(nil, nil, nil, value, title),
+				output,
+				container.NewBorder(nil, nil, nil, decay, starved),
+				owner,
+			)
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id < 0 || id >= len(a.rowsFiltered) {
+				return
+			}
+			r := a.rowsFiltered[id]
+			c := co.(*fyne.Container).Objects
+
+			b0 := c[0].(*fyne.Container).Objects
+			b0[0].(*xwidget.RichText).Set(r.nameDisplay)
+			b0[1].(*widget.Label).SetText(ihumanize.NumberF(r.valuePerDay, 1) + " ISK / day")
+
+			c[1].(*widget.Label).SetText(r.outputText)
+
+			b2 := c[2].(*fyne.Container).Objects
+			b2[0].(*xwidget.RichText).Set(r.starvedDisplay())
+			b2[1].(*widget.Label).SetText("Decay: " + r.decayDisplay())
+
+			c[3].(*widget.Label).SetText(r.ownerName)
+		},
+	)
+	l.OnSelected = func(id widget.ListItemID) {
+		defer l.UnselectAll()
+		if id < 0 || id >= len(a.rowsFiltered) {
+			return
+		}
+		r := a.rowsFiltered[id]
+		showColonyDetailsWindow(a.u, colonyRow{
+			characterID: r.characterID,
+			ownerName:   r.ownerName,
+			planetID:    r.planetID,
+			planetName:  r.planetName,
+		})
+	}
+	return l
+}
+
+func (a *ColonyProduction) makeTypeList() *widget.List {
+	l := widget.NewList(
+		func() int {
+			return len(a.types)
+		},
+		func() fyne.CanvasObject {
+			name := widget.NewLabel("Template")
+			name.Truncation = fyne.TextTruncateClip
+			net := widget.NewLabel("Template")
+			net.Alignment = fyne.TextAlignTrailing
+			return container.NewBorder(nil, nil, nil, net, name)
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id < 0 || id >= len(a.types) {
+				return
+			}
+			r := a.types[id]
+			c := co.(*fyne.Container).Objects
+			c[0].(*widget.Label).SetText(r.name)
+			net := c[1].(*widget.Label)
+			s := fmt.Sprintf("%s / h", ihumanize.NumberF(r.net(), 0))
+			if r.valuePerDay > 0 {
+				s += fmt.Sprintf(" • %s ISK / day", ihumanize.NumberF(r.valuePerDay, 1))
+			}
+			net.Text = s
+			if r.net() < 0 {
+				net.Importance = widget.WarningImportance
+			} else {
+				net.Importance = widget.MediumImportance
+			}
+			net.Refresh()
+		},
+	)
+	l.OnSelected = func(id widget.ListItemID) {
+		defer l.UnselectAll()
+		if id < 0 || id >= len(a.types) {
+			return
+		}
+		a.u.InfoViewer().ShowType(a.types[id].typeID, 0)
+	}
+	return l
+}
+
+func (a *ColonyProduction) filterRowsAsync(sortCol int) {
+	totalRows := len(a.rows)
+	rows := slices.Clone(a.rows)
+	owner := a.selectOwner.Selected
+	prices := a.prices
+	typeNames := a.typeNames
+	sortCol, dir, doSort := a.columnSorter.CalcSort(sortCol)
+
+	go func() {
+		if owner != "" {
+			rows = slices.DeleteFunc(rows, func(r colonyProductionRow) bool {
+				return r.ownerName != owner
+			})
+		}
+		a.columnSorter.SortRows(rows, sortCol, dir, doSort)
+
+		// combined production of all shown colonies
+		total := app.MergeColonyProductions(xslices.Map(rows, func(r colonyProductionRow) app.ColonyProduction {
+			return r.production
+		})...)
+		var types []colonyProductionTypeRow
+		for id := range total.Produced {
+			r := colonyProductionTypeRow{
+				consumed: total.Consumed[id],
+				name:     typeNames[id],
+				produced: total.Produced[id],
+				typeID:   id,
+			}
+			if n := r.net(); n > 0 {
+				r.valuePerDay = n * 24 * prices[id]
+			}
+			types = append(types, r)
+		}
+		for id := range total.Consumed {
+			if _, ok := total.Produced[id]; !ok {
+				types = append(types, colonyProductionTypeRow{
+					consumed: total.Consumed[id],
+					name:     typeNames[id],
+					typeID:   id,
+				})
+			}
+		}
+		slices.SortFunc(types, func(x, y colonyProductionTypeRow) int {
+			return cmp.Compare(x.name, y.name)
+		})
+
+		ownerOptions := xslices.Map(rows, func(r colonyProductionRow) string {
+			return r.ownerName
+		})
+		footer := fmt.Sprintf(
+			"Showing %d / %d colonies • %s ISK / day",
+			len(rows),
+			totalRows,
+			ihumanize.NumberF(total.ValuePerDay(prices), 1),
+		)
+		if n := len(total.StarvedFactories); n > 0 {
+			footer += fmt.Sprintf(" • %d starved factories", n)
+		}
+
+		fyne.Do(func() {
+			a.footer.Text = footer
+			a.footer.Importance = widget.MediumImportance
+			a.footer.Refresh()
+			a.selectOwner.SetOptions(ownerOptions)
+			a.rowsFiltered = rows
+			a.body.Refresh()
+			a.types = types
+			a.typeList.Refresh()
+		})
+	}()
+}
+
+func (a *ColonyProduction) update(ctx context.Context) {
+	rows, typeNames, prices, err := a.fetchRows(ctx)
+	if err != nil {
+		slog.Error("Failed to refresh colony production UI", "err", err)
+		fyne.Do(func() {
+			a.footer.Text = "ERROR: " + a.u.ErrorDisplay(err)
+			a.footer.Importance = widget.DangerImportance
+			a.footer.Refresh()
+		})
+		return
+	}
+	fyne.Do(func() {
+		a.rows = rows
+		a.typeNames = typeNames
+		a.prices = prices
+		a.filterRowsAsync(-1)
+	})
+}
+
+func (a *ColonyProduction) fetchRows(ctx context.Context) ([]colonyProductionRow, map[int64]string, map[int64]float64, error) {
+	planets, err := a.u.Character().ListAllPlanets(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	characters, err := a.u.Character().CharacterNames(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	schematics, err := a.fetchSchematics(ctx, planets)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	mp, err := a.u.EVEUniverse().ListMarketPrices(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	prices := make(map[int64]float64)
+	for id, o := range mp {
+		if v, ok := o.AveragePrice.Value(); ok {
+			prices[id] = v
+		}
+	}
+	typeNames := make(map[int64]string)
+	now := time.Now()
+	var rows []colonyProductionRow
+	for _, p := range planets {
+		production := p.Production(schematics, now)
+		for id := range set.Union(set.Collect(maps.Keys(production.Produced)), set.Collect(maps.Keys(production.Consumed))).All() {
+			if _, ok := typeNames[id]; ok {
+				continue
+			}
+			et, err := a.u.EVEUniverse().GetOrCreateTypeESI(ctx, id)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			typeNames[id] = et.Name
+		}
+		r := colonyProductionRow{
+			characterID:  p.CharacterID,
+			decay:        extractorDecay(p, now),
+			nameDisplay:  p.NameRichText(),
+			ownerName:    characters[p.CharacterID],
+			planetID:     p.EvePlanet.ID,
+			planetName:   p.EvePlanet.Name,
+			production:   production,
+			starvedCount: len(production.StarvedFactories),
+			valuePerDay:  production.ValuePerDay(prices),
+		}
+		var output []string
+		for id, v := range production.Surplus() {
+			output = append(output, fmt.Sprintf("%s %s", typeNames[id], ihumanize.NumberF(v, 0)))
+		}
+		if len(output) == 0 {
+			r.outputText = "-"
+		} else {
+			slices.Sort(output)
+			r.outputText = strings.Join(output, ", ")
+		}
+		short := slices.Sorted(xiter.Map(production.ShortTypes.All(), func(id int64) string {
+			return typeNames[id]
+		}))
+		r.shortText = strings.Join(short, ", ")
+		rows = append(rows, r)
+	}
+	return rows, typeNames, prices, nil
+}
+
+// fetchSchematics returns the schematics of all factories of the given planets.
+// Returns no schematics when offline.
+func (a *ColonyProduction) fetchSchematics(ctx context.Context, planets []*app.CharacterPlanet) (map[int64]app.PlanetSchematic, error) {
+	schematics := make(map[int64]app.PlanetSchematic)
+	if a.u.IsOffline() {
+		return schematics, nil
+	}
+	for _, p := range planets {
+		for _, es := range p.ProducedSchematics() {
+			if _, ok := schematics[es.ID]; ok {
+				continue
+			}
+			o, err := a.u.EveRef().FetchSchematic(ctx, es.ID)
+			if err != nil {
+				return nil, err
+			}
+			s := app.PlanetSchematic{
+				CycleTime: time.Duration(o.CycleTime) * time.Second,
+				Inputs:    make(map[int64]int64),
+				Outputs:   make(map[int64]int64),
+			}
+			for _, m := range o.Materials {
+				s.Inputs[m.TypeID] = m.Quantity
+			}
+			for _, m := range o.Products {
+				s.Outputs[m.TypeID] = m.Quantity
+			}
+			schematics[es.ID] = s
+		}
+	}
+	return schematics, nil
+}
+
+// extractorDecay returns the projected change of the output of all extractors of a colony
+// from the current cycle to the last cycle before expiry as fraction.
+func extractorDecay(p *app.CharacterPlanet, now time.Time) optional.Optional[float64] {
+	var first, last int64
+	for pp := range p.ActiveExtractors() {
+		cycles := pp.ExtractorCycles(now)
+		if len(cycles) == 0 {
+			continue
+		}
+		first += cycles[0].Quantity
+		last += cycles[len(cycles)-1].Quantity
+	}
+	if first == 0 {
+		return optional.Optional[float64]{}
+	}
+	return optional.New(float64(last)/float64(first) - 1)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ErikKalkoken/evebuddy/internal/app/corporationservice"
 	"github.com/ErikKalkoken/evebuddy/internal/app/eveuniverseservice"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	"github.com/ErikKalkoken/evebuddy/internal/everefservice"
 )
 
 type baseUI interface {
@@ -18,11 +19,13 @@ type baseUI interface {
 	ErrorDisplay(err error) string
 	EVEImage() ui.EVEImageService
 	EVEUniverse() *eveuniverseservice.EVEUniverseService
+	EveRef() *everefservice.EveRefService
 	GetOrCreateWindow(id string, titles ...string) (window fyne.Window, created bool)
 	GetOrCreateWindowWithOnClosed(id string, titles ...string) (window fyne.Window, created bool, onClosed func())
 	InfoViewer() ui.InfoViewer
 	IsDeveloperMode() bool
 	IsMobile() bool
+	IsOffline() bool
 	MainWindow() fyne.Window
 	Signals() *app.Signals
 }
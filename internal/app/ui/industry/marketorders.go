@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
 	"slices"
 	"strings"
 	"time"
@@ -57,6 +58,8 @@ type marketOrderRow struct {
 	tags          set.Set[string]
 	typeID        int64
 	typeName      string
+	undercut      optional.Optional[float64] // best competing price when undercut
+	undercutKnown bool                       // whether undercut has been checked
 	volumeRemain  int64
 	volumeTotal   int64
 }
@@ -115,6 +118,28 @@ func (r marketOrderRow) stateDisplay() string {
 
 }
 
+// undercutGap returns the difference of the best competing price to the price of an undercut order.
+func (r marketOrderRow) undercutGap() float64 {
+	v, ok := r.undercut.Value()
+	if !ok {
+		return 0
+	}
+	return v - r.price
+}
+
+func (r marketOrderRow) undercutDisplay() (string, fyne.ThemeColorName) {
+	if r.stateCorrected() != app.OrderOpen {
+		return "", theme.ColorNameForeground
+	}
+	if !r.undercutKnown {
+		return "?", theme.ColorNameForeground
+	}
+	if r.undercut.IsEmpty() {
+		return "Best price", theme.ColorNameSuccess
+	}
+	return humanize.FormatFloat("+"+ui.FloatFormatISK, r.undercutGap()), theme.ColorNameError
+}
+
 func (r marketOrderRow) volumeDisplay() string {
 	return fmt.Sprintf("%s / %s", ihumanize.Comma(r.volumeRemain), ihumanize.Comma(r.volumeTotal))
 }
@@ -145,6 +170,7 @@ const (
 	marketOrdersColLocation
 	marketOrdersColRegion
 	marketOrdersColOwner
+	marketOrdersColUndercut
 )
 
 func NewMarketOrders(u baseUI, isBuyOrders bool) *MarketOrders {
@@ -185,6 +211,20 @@ func NewMarketOrders(u baseUI, isBuyOrders bool) *MarketOrders {
 					Alignment: fyne.TextAlignTrailing,
 				})
 			},
+		}, {
+			ID:    marketOrdersColUndercut,
+			Label: "Undercut",
+			Width: 100,
+			Sort: func(a, b marketOrderRow) int {
+				return cmp.Compare(math.Abs(a.undercutGap()), math.Abs(b.undercutGap()))
+			},
+			Update: func(r marketOrderRow, co fyne.CanvasObject) {
+				text, color := r.undercutDisplay()
+				co.(*xwidget.RichText).SetWithText(text, widget.RichTextStyle{
+					Alignment: fyne.TextAlignTrailing,
+					ColorName: color,
+				})
+			},
 		}, {
 			ID:    marketOrdersColState,
 			Label: "State",
@@ -362,7 +402,11 @@ func (a *MarketOrders) makeDataList() *xwidget.StripedList {
 			state.Refresh()
 
 			b1 := c[1].(*fyne.Container).Objects
-			b1[0].(*widget.Label).SetText(ihumanize.NumberF(r.price, 2) + " ISK")
+			price := ihumanize.NumberF(r.price, 2) + " ISK"
+			if v, ok := r.undercut.Value(); ok && r.stateCorrected() == app.OrderOpen {
+				price += fmt.Sprintf(" • undercut at %s", ihumanize.NumberF(v, 2))
+			}
+			b1[0].(*widget.Label).SetText(price)
 			b1[1].(*widget.Label).SetText(r.volumeDisplay())
 
 			c[2].(*xwidget.RichText).Set(r.location.DisplayRichText())
@@ -475,6 +519,48 @@ func (a *MarketOrders) update(ctx context.Context) {
 		a.rows = rows
 		a.filterRowsAsync(-1)
 	})
+	if a.u.IsOffline() {
+		return
+	}
+	undercuts, err := a.fetchUndercuts(ctx, rows)
+	if err != nil {
+		slog.Error("Failed to check market orders for undercuts", "err", err)
+		return
+	}
+	fyne.Do(func() {
+		for i, r := range a.rows {
+			v, ok := undercuts[r.orderID]
+			if ok {
+				r.undercut = optional.New(v)
+			}
+			r.undercutKnown = true
+			a.rows[i] = r
+		}
+		a.filterRowsAsync(-1)
+	})
+}
+
+// fetchUndercuts returns the best competing prices for undercut orders by order ID.
+func (a *MarketOrders) fetchUndercuts(ctx context.Context, rows []marketOrderRow) (map[int64]float64, error) {
+	var orders []*app.CharacterMarketOrder
+	for _, r := range rows {
+		if r.stateCorrected() != app.OrderOpen {
+			continue
+		}
+		orders = append(orders, &app.CharacterMarketOrder{
+			IsBuyOrder: r.IsBuyOrder,
+			Location:   r.location,
+			OrderID:    r.orderID,
+			Price:      r.price,
+			Region:     &app.EntityShort{ID: r.regionID},
+			State:      r.state,
+			Type:       &app.EntityShort{ID: r.typeID},
+		})
+	}
+	if len(orders) == 0 {
+		return nil, nil
+	}
+	return a.u.Character().MarketOrderUndercuts(ctx, orders)
 }
 
 func (a *MarketOrders) fetchRows(ctx context.Context, isBuyOrders bool) ([]marketOrderRow, error) {
@@ -492,7 +578,7 @@ func (a *MarketOrders) fetchRows(ctx context.Context, isBuyOrders bool) ([]marke
 			characterID:   o.CharacterID,
 			characterName: characters[o.CharacterID],
 			escrow:        o.Escrow,
-			expires:       o.Issued.Add(time.Duration(o.Duration) * time.Hour * 24),
+			expires:       o.Expires(),
 			IsBuyOrder:    o.IsBuyOrder,
 			isCorporation: o.IsCorporation,
 			issued:        o.Issued,
@@ -0,0 +1,535 @@
+package industry
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
+	"github.com/ErikKalkoken/go-set"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	ihumanize "github.com/ErikKalkoken/evebuddy/internal/humanize"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+	"github.com/ErikKalkoken/evebuddy/internal/xstrings"
+	"github.com/ErikKalkoken/evebuddy/internal/xwidget"
+)
+
+const (
+	miningViewEntries = "Entries"
+	miningViewSummary = "Summary per day & ore"
+)
+
+type miningRow struct {
+	characterID   int64
+	characterName string
+	date          time.Time
+	locationName  string
+	ownerName     string
+	price         optional.Optional[float64]
+	quantity      int64
+	tags          set.Set[string]
+	typeID        int64
+	typeName      string
+}
+
+func (r miningRow) dateDisplay() string {
+	return r.date.Format(time.DateOnly)
+}
+
+func (r miningRow) value() optional.Optional[float64] {
+	return app.MiningSummaryItem{Price: r.price, Quantity: r.quantity}.Value()
+}
+
+func (r miningRow) valueDisplay() string {
+	return ihumanize.OptionalWithDecimals(r.value(), 0, "?")
+}
+
+// Mining is a widget which shows what has been mined,
+// either by all characters or at the mining observers of all corporations.
+type Mining struct {
+	widget.BaseWidget
+
+	columnSorter    *xwidget.ColumnSorter[miningRow]
+	footer          *widget.Label
+	isCorporation   bool
+	main            fyne.CanvasObject
+	rows            []miningRow
+	rowsFiltered    []miningRow
+	selectOwner     *kxwidget.FilterChipSelect
+	selectCharacter *kxwidget.FilterChipSelect
+	selectLocation  *kxwidget.FilterChipSelect
+	selectTag       *kxwidget.FilterChipSelect
+	selectType      *kxwidget.FilterChipSelect
+	selectView      *kxwidget.FilterChipSelect
+	sortButton      *xwidget.SortButton[miningRow]
+	u               baseUI
+}
+
+const (
+	miningColDate = iota + 1
+	miningColType
+	miningColQuantity
+	miningColValue
+	miningColLocation
+	miningColCharacter
+)
+
+// NewMining returns a new widget for mining.
+// It shows the mining ledger of all characters or, when isCorporation is true,
+// the ore mined at the mining observers of all corporations.
+func NewMining(u baseUI, isCorporation bool) *Mining {
+	var locationLabel string
+	if isCorporation {
+		locationLabel = "Structure"
+	} else {
+		locationLabel = "Solar System"
+	}
+	columns := xwidget.NewDataColumns([]xwidget.DataColumn[miningRow]{{
+		ID:    miningColDate,
+		Label: "Date",
+		Width: 100,
+		Sort: func(a, b miningRow) int {
+			return a.date.Compare(b.date)
+		},
+		Update: func(r miningRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(r.dateDisplay())
+		},
+	},
+		ui.MakeEveEntityColumn(ui.MakeEveEntityColumnParams[miningRow]{
+			ColumnID: miningColType,
+			EIS:      u.EVEImage(),
+			GetEntity: func(r miningRow) *app.EveEntity {
+				return &app.EveEntity{
+					ID:       r.typeID,
+					Name:     r.typeName,
+					Category: app.EveEntityInventoryType,
+				}
+			},
+			IsAvatar: false,
+			Label:    "Type",
+		}), {
+			ID:    miningColQuantity,
+			Label: "Quantity",
+			Width: 100,
+			Sort: func(a, b miningRow) int {
+				return cmp.Compare(a.quantity, b.quantity)
+			},
+			Update: func(r miningRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(ihumanize.Comma(r.quantity), widget.RichTextStyle{
+					Alignment: fyne.TextAlignTrailing,
+				})
+			},
+		}, {
+			ID:    miningColValue,
+			Label: "Est. Value",
+			Width: 125,
+			Sort: func(a, b miningRow) int {
+				return optional.Compare(a.value(), b.value())
+			},
+			Update: func(r miningRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.valueDisplay(), widget.RichTextStyle{
+					Alignment: fyne.TextAlignTrailing,
+				})
+			},
+		}, {
+			ID:    miningColLocation,
+			Label: locationLabel,
+			Width: ui.ColumnWidthLocation,
+			Sort: func(a, b miningRow) int {
+				return strings.Compare(a.locationName, b.locationName)
+			},
+			Update: func(r miningRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.locationName)
+			},
+		}, {
+			ID:    miningColCharacter,
+			Label: "Character",
+			Width: ui.ColumnWidthEntity,
+			Sort: func(a, b miningRow) int {
+				return xstrings.CompareIgnoreCase(a.characterName, b.characterName)
+			},
+			Update: func(r miningRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.characterName)
+			},
+		}})
+	a := &Mining{
+		columnSorter:  xwidget.NewColumnSorter(columns, miningColDate, xwidget.SortDesc),
+		footer:        ui.NewLabelWithTruncation(""),
+		isCorporation: isCorporation,
+		u:             u,
+	}
+	a.ExtendBaseWidget(a)
+
+	if !a.u.IsMobile() {
+		a.main = xwidget.MakeDataTable(
+			columns,
+			&a.rowsFiltered,
+			func() fyne.CanvasObject {
+				x := xwidget.NewRichText()
+				x.Truncation = fyne.TextTruncateClip
+				return x
+			},
+			a.columnSorter,
+			a.filterRowsAsync, func(_ int, r miningRow) {
+				a.u.InfoViewer().ShowType(r.typeID, r.characterID)
+			})
+	} else {
+		a.main = a.makeDataList()
+	}
+
+	a.selectCharacter = kxwidget.NewFilterChipSelectWithSearch("Character", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	}, a.u.MainWindow())
+	a.selectLocation = kxwidget.NewFilterChipSelectWithSearch(locationLabel, []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	}, a.u.MainWindow())
+	a.selectOwner = kxwidget.NewFilterChipSelect("Corporation", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.selectTag = kxwidget.NewFilterChipSelect("Tag", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.selectType = kxwidget.NewFilterChipSelectWithSearch("Type", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	}, a.u.MainWindow())
+	a.selectView = kxwidget.NewFilterChipSelect("", []string{
+		miningViewEntries,
+		miningViewSummary,
+	}, func(string) {
+		a.filterRowsAsync(-1)
+	})
+	a.selectView.Selected = miningViewEntries
+	a.selectView.SortDisabled = true
+	a.sortButton = a.columnSorter.NewSortButton(func() {
+		a.filterRowsAsync(-1)
+	})
+
+	// Signals
+	a.u.Signals().AppInit.AddListener(func(ctx context.Context, _ struct{}) {
+		a.update(ctx)
+	})
+	if a.isCorporation {
+		a.u.Signals().CorporationSectionChanged.AddListener(func(ctx context.Context, arg app.CorporationSectionUpdated) {
+			switch arg.Section {
+			case app.SectionCorporationMiningObservers, app.SectionCorporationStructures:
+				a.update(ctx)
+			}
+		})
+		a.u.Signals().CorporationsChanged.AddListener(func(ctx context.Context, _ struct{}) {
+			a.update(ctx)
+		})
+	} else {
+		a.u.Signals().CharacterSectionChanged.AddListener(func(ctx context.Context, arg app.CharacterSectionUpdated) {
+			switch arg.Section {
+			case app.SectionCharacterMiningLedger:
+				a.update(ctx)
+			}
+		})
+		a.u.Signals().CharacterAdded.AddListener(func(ctx context.Context, _ *app.Character) {
+			a.update(ctx)
+		})
+		a.u.Signals().CharacterRemoved.AddListener(func(ctx context.Context, _ *app.EntityShort) {
+			a.update(ctx)
+		})
+		a.u.Signals().TagsChanged.AddListener(func(ctx context.Context, _ struct{}) {
+			a.update(ctx)
+		})
+	}
+	return a
+}
+
+func (a *Mining) CreateRenderer() fyne.WidgetRenderer {
+	filter := container.NewHBox(a.selectView, a.selectType, a.selectLocation, a.selectCharacter)
+	if a.isCorporation {
+		filter.Add(a.selectOwner)
+	} else {
+		filter.Add(a.selectTag)
+	}
+	if a.u.IsMobile() {
+		filter.Add(a.sortButton)
+	}
+	p := theme.Padding()
+	c := container.NewBorder(
+		container.NewHScroll(filter),
+		container.New(layout.NewCustomPaddedLayout(p, p, 0, 0), a.footer),
+		nil,
+		nil,
+		a.main,
+	)
+	return widget.NewSimpleRenderer(c)
+}
+
+func (a *Mining) makeDataList() *xwidget.StripedList {
+	p := theme.Padding()
+	l := xwidget.NewStripedList(
+		func() int {
+			return len(a.rowsFiltered)
+		},
+		func() fyne.CanvasObject {
+			item := widget.NewLabel("Template")
+			item.Truncation = fyne.TextTruncateClip
+			item.TextStyle.Bold = true
+			date := widget.NewLabel("Template")
+			date.Alignment = fyne.TextAlignTrailing
+			quantity := widget.NewLabel("Template")
+			value := widget.NewLabel("Template")
+			value.Alignment = fyne.TextAlignTrailing
+			location := widget.NewLabel("Template")
+			location.Truncation = fyne.TextTruncateClip
+			character := widget.NewLabel("Template")
+			character.Truncation = fyne.TextTruncateClip
+			return container.New(layout.NewCustomPaddedVBoxLayout(-p),
+				container.NewBorder(nil, nil, nil, date, item),
+				container.NewBorder(nil, nil, nil, value, quantity),
+				location,
+				character,
+			)
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id < 0 || id >= len(a.rowsFiltered) {
+				return
+			}
+			r := a.rowsFiltered[id]
+			c := co.(*fyne.Container).Objects
+
+			b0 := c[0].(*fyne.Container).Objects
+			b0[0].(*widget.Label).SetText(r.typeName)
+			b0[1].(*widget.Label).SetText(r.dateDisplay())
+
+			b1 := c[1].(*fyne.Container).Objects
+			b1[0].(*widget.Label).SetText(ihumanize.Comma(r.quantity) + " units")
+			b1[1].(*widget.Label).SetText(r.valueDisplay() + " ISK")
+
+			location := c[2].(*widget.Label)
+			if r.locationName != "" {
+				location.SetText(r.locationName)
+				location.Show()
+			} else {
+				location.Hide()
+			}
+			character := c[3].(*widget.Label)
+			if r.characterName != "" {
+				character.SetText(r.characterName)
+				character.Show()
+			} else {
+				character.Hide()
+			}
+		},
+	)
+	l.OnSelected = func(id widget.ListItemID) {
+		defer l.UnselectAll()
+		if id < 0 || id >= len(a.rowsFiltered) {
+			return
+		}
+		r := a.rowsFiltered[id]
+		a.u.InfoViewer().ShowType(r.typeID, r.characterID)
+	}
+	l.HideSeparators = true
+	return l
+}
+
+func (a *Mining) filterRowsAsync(sortCol int) {
+	totalRows := len(a.rows)
+	rows := slices.Clone(a.rows)
+	character := a.selectCharacter.Selected
+	location := a.selectLocation.Selected
+	owner := a.selectOwner.Selected
+	tag := a.selectTag.Selected
+	et := a.selectType.Selected
+	isSummary := a.selectView.Selected == miningViewSummary
+	sortCol, dir, doSort := a.columnSorter.CalcSort(sortCol)
+
+	go func() {
+		// filter
+		if character != "" {
+			rows = slices.DeleteFunc(rows, func(r miningRow) bool {
+				return r.characterName != character
+			})
+		}
+		if location != "" {
+			rows = slices.DeleteFunc(rows, func(r miningRow) bool {
+				return r.locationName != location
+			})
+		}
+		if owner != "" {
+			rows = slices.DeleteFunc(rows, func(r miningRow) bool {
+				return r.ownerName != owner
+			})
+		}
+		if tag != "" {
+			rows = slices.DeleteFunc(rows, func(r miningRow) bool {
+				return !r.tags.Contains(tag)
+			})
+		}
+		if et != "" {
+			rows = slices.DeleteFunc(rows, func(r miningRow) bool {
+				return r.typeName != et
+			})
+		}
+		// set filter options
+		characterOptions := xslices.Map(rows, func(r miningRow) string {
+			return r.characterName
+		})
+		locationOptions := xslices.Map(rows, func(r miningRow) string {
+			return r.locationName
+		})
+		ownerOptions := xslices.Map(rows, func(r miningRow) string {
+			return r.ownerName
+		})
+		tagOptions := slices.Sorted(set.Union(xslices.Map(rows, func(r miningRow) set.Set[string] {
+			return r.tags
+		})...).All())
+		typeOptions := xslices.Map(rows, func(r miningRow) string {
+			return r.typeName
+		})
+		// summarize
+		var quantity int64
+		var value float64
+		for _, r := range rows {
+			quantity += r.quantity
+			value += r.value().ValueOrZero()
+		}
+		var footer string
+		if isSummary {
+			rows = summarizeMiningRows(rows)
+			footer = fmt.Sprintf("Showing %d days & ores", len(rows))
+		} else {
+			footer = fmt.Sprintf("Showing %d / %d entries", len(rows), totalRows)
+		}
+		footer += fmt.Sprintf(" • %s units • %s ISK est. value", ihumanize.Comma(quantity), ihumanize.Comma(int64(value)))
+		a.columnSorter.SortRows(rows, sortCol, dir, doSort)
+
+		fyne.Do(func() {
+			a.footer.Text = footer
+			a.footer.Importance = widget.MediumImportance
+			a.footer.Refresh()
+			a.selectCharacter.SetOptions(characterOptions)
+			a.selectLocation.SetOptions(locationOptions)
+			a.selectOwner.SetOptions(ownerOptions)
+			a.selectTag.SetOptions(tagOptions)
+			a.selectType.SetOptions(typeOptions)
+			a.rowsFiltered = rows
+			a.main.Refresh()
+		})
+	}()
+}
+
+// summarizeMiningRows returns new rows with the total quantity mined per day and ore type.
+func summarizeMiningRows(rows []miningRow) []miningRow {
+	items := xslices.Map(rows, func(r miningRow) app.MiningSummaryItem {
+		return app.MiningSummaryItem{
+			Date:     r.date,
+			Price:    r.price,
+			Quantity: r.quantity,
+			Type:     &app.EveType{ID: r.typeID, Name: r.typeName},
+		}
+	})
+	return xslices.Map(app.SummarizeMining(items), func(x app.MiningSummaryItem) miningRow {
+		return miningRow{
+			date:     x.Date,
+			price:    x.Price,
+			quantity: x.Quantity,
+			typeID:   x.Type.ID,
+			typeName: x.Type.Name,
+		}
+	})
+}
+
+func (a *Mining) update(ctx context.Context) {
+	var rows []miningRow
+	var err error
+	if a.isCorporation {
+		rows, err = a.fetchCorporationRows(ctx)
+	} else {
+		rows, err = a.fetchCharacterRows(ctx)
+	}
+	if err != nil {
+		slog.Error("Failed to refresh mining UI", "err", err)
+		fyne.Do(func() {
+			a.footer.Text = "ERROR: " + a.u.ErrorDisplay(err)
+			a.footer.Importance = widget.DangerImportance
+			a.footer.Refresh()
+		})
+		return
+	}
+	fyne.Do(func() {
+		a.rows = rows
+		a.filterRowsAsync(-1)
+	})
+}
+
+func (a *Mining) fetchCharacterRows(ctx context.Context) ([]miningRow, error) {
+	entries, err := a.u.Character().ListAllMiningLedgerEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	characters, err := a.u.Character().CharacterNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tagsPerCharacter := make(map[int64]set.Set[string])
+	for id := range characters {
+		tags, err := a.u.Character().ListTagsForCharacter(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		tagsPerCharacter[id] = tags
+	}
+	var rows []miningRow
+	for _, e := range entries {
+		rows = append(rows, miningRow{
+			characterID:   e.CharacterID,
+			characterName: characters[e.CharacterID],
+			date:          e.Date,
+			locationName:  e.SolarSystem.Name,
+			ownerName:     characters[e.CharacterID],
+			price:         e.Price,
+			quantity:      e.Quantity,
+			tags:          tagsPerCharacter[e.CharacterID],
+			typeID:        e.Type.ID,
+			typeName:      e.Type.Name,
+		})
+	}
+	return rows, nil
+}
+
+func (a *Mining) fetchCorporationRows(ctx context.Context) ([]miningRow, error) {
+	entries, err := a.u.Corporation().ListAllMiningObserverEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := a.u.Corporation().ListCorporationsShort(ctx)
+	if err != nil {
+		return nil, err
+	}
+	corporationNames := make(map[int64]string)
+	for _, o := range cc {
+		corporationNames[o.ID] = o.Name
+	}
+	var rows []miningRow
+	for _, e := range entries {
+		rows = append(rows, miningRow{
+			characterName: e.Character.Name,
+			date:          e.LastUpdated,
+			locationName:  e.StructureName.ValueOrFallback(fmt.Sprintf("Structure #%d", e.ObserverID)),
+			ownerName:     corporationNames[e.CorporationID],
+			price:         e.Price,
+			quantity:      e.Quantity,
+			typeID:        e.Type.ID,
+			typeName:      e.Type.Name,
+		})
+	}
+	return rows, nil
+}
@@ -0,0 +1,27 @@
+package industry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+func TestSummarizeMiningRows(t *testing.T) {
+	day := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	rows := []miningRow{
+		{date: day, typeID: 1230, typeName: "Veldspar", quantity: 100, price: optional.New(10.0), characterName: "Alpha", locationName: "Amarr"},
+		{date: day, typeID: 1230, typeName: "Veldspar", quantity: 50, price: optional.New(10.0), characterName: "Bravo", locationName: "Jita"},
+		{date: day, typeID: 1228, typeName: "Scordite", quantity: 20, characterName: "Alpha", locationName: "Amarr"},
+	}
+	got := summarizeMiningRows(rows)
+	want := []miningRow{
+		{date: day, typeID: 1228, typeName: "Scordite", quantity: 20},
+		{date: day, typeID: 1230, typeName: "Veldspar", quantity: 150, price: optional.New(10.0)},
+	}
+	assert.Equal(t, want, got)
+	assert.Equal(t, optional.New(1500.0), got[1].value())
+	assert.Equal(t, "?", got[0].valueDisplay())
+}
@@ -230,6 +230,12 @@ func (a *inventoryTypeInfo) update(ctx context.Context) error {
 			a.tabs.Append(marketTab)
 		})
 	}
+	priceHistoryTab := a.makePriceHistoryTab(ctx, et)
+	if priceHistoryTab != nil {
+		fyne.Do(func() {
+			a.tabs.Append(priceHistoryTab)
+		})
+	}
 
 	// Set initial tab
 	fyne.Do(func() {
@@ -705,6 +711,17 @@ func (a *inventoryTypeInfo) makeMarketTab(ctx context.Context, et *app.EveType)
 	return marketTab
 }
 
+func (a *inventoryTypeInfo) makePriceHistoryTab(ctx context.Context, et *app.EveType) *container.TabItem {
+	if !et.IsTradeable() || a.iw.u.IsOffline() {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	a.iw.onClosedFuncs = append(a.iw.onClosedFuncs, cancel)
+	mh := newMarketHistory(ctx, a.iw, et.ID)
+	go mh.update()
+	return container.NewTabItem("Price History", mh)
+}
+
 func (a *inventoryTypeInfo) addJanicePriceItems(ctx context.Context, typeID int64) ([]attributeItem, error) {
 	j, err := a.iw.u.Janice().FetchPrices(ctx, typeID)
 	if err != nil {
@@ -0,0 +1,197 @@
+package infoviewer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/dustin/go-humanize"
+	"github.com/s-daehling/fyne-charts/pkg/coord"
+	"github.com/s-daehling/fyne-charts/pkg/data"
+	"github.com/s-daehling/fyne-charts/pkg/style"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	ihumanize "github.com/ErikKalkoken/evebuddy/internal/humanize"
+)
+
+const (
+	marketHistoryPeriod30Days  = "30 days"
+	marketHistoryPeriod90Days  = "90 days"
+	marketHistoryPeriod365Days = "1 year"
+)
+
+var marketHistoryPeriods = map[string]int{
+	marketHistoryPeriod30Days:  30,
+	marketHistoryPeriod90Days:  90,
+	marketHistoryPeriod365Days: 365,
+}
+
+// marketHistory shows the daily market history of a type for a selectable trade hub region.
+type marketHistory struct {
+	widget.BaseWidget
+
+	ctx          context.Context
+	iw           *InfoViewer
+	prices       *coord.CartesianTemporalChart
+	selectHub    *widget.Select
+	selectPeriod *widget.Select
+	summary      *widget.Label
+	typeID       int64
+	volumes      *coord.CartesianTemporalChart
+}
+
+func newMarketHistory(ctx context.Context, iw *InfoViewer, typeID int64) *marketHistory {
+	a := &marketHistory{
+		ctx:     ctx,
+		iw:      iw,
+		prices:  coord.NewCartesianTemporalChart(""),
+		summary: widget.NewLabel(""),
+		typeID:  typeID,
+		volumes: coord.NewCartesianTemporalChart(""),
+	}
+	a.ExtendBaseWidget(a)
+	a.summary.Wrapping = fyne.TextWrapWord
+
+	var hubs []string
+	for _, h := range app.MarketHubs() {
+		hubs = append(hubs, h.Name)
+	}
+	a.selectHub = widget.NewSelect(hubs, func(string) {
+		go a.update()
+	})
+	a.selectHub.Selected = hubs[0]
+	a.selectPeriod = widget.NewSelect([]string{
+		marketHistoryPeriod30Days,
+		marketHistoryPeriod90Days,
+		marketHistoryPeriod365Days,
+	}, func(string) {
+		go a.update()
+	})
+	a.selectPeriod.Selected = marketHistoryPeriod90Days
+
+	ts := style.DefaultTitleStyle()
+	ts.SizeName = theme.SizeNameText
+	ts.TextStyle.Bold = true
+	yls := style.DefaultAxisLabelStyle()
+	yls.SizeName = theme.SizeNameText
+	a.prices.SetTitleStyle(ts)
+	a.prices.SetYAxisStyle(yls, style.DefaultAxisStyle())
+	a.prices.SetYAxisLabel("ISK")
+	a.volumes.SetTitleStyle(ts)
+	a.volumes.SetYAxisStyle(yls, style.DefaultAxisStyle())
+	a.volumes.SetYAxisLabel("Units")
+	a.volumes.HideLegend()
+	return a
+}
+
+func (a *marketHistory) CreateRenderer() fyne.WidgetRenderer {
+	c := container.NewBorder(
+		container.NewHBox(a.selectHub, a.selectPeriod),
+		a.summary,
+		nil,
+		nil,
+		container.NewGridWithRows(2, a.prices, a.volumes),
+	)
+	return widget.NewSimpleRenderer(c)
+}
+
+func (a *marketHistory) regionID() int64 {
+	for _, h := range app.MarketHubs() {
+		if h.Name == a.selectHub.Selected {
+			return h.RegionID
+		}
+	}
+	return 0
+}
+
+func (a *marketHistory) update() {
+	var hub, period string
+	var regionID int64
+	fyne.DoAndWait(func() {
+		hub = a.selectHub.Selected
+		period = a.selectPeriod.Selected
+		regionID = a.regionID()
+	})
+	if regionID == 0 {
+		return
+	}
+	fyne.Do(func() {
+		a.summary.Importance = widget.MediumImportance
+		a.summary.SetText("Fetching market history...")
+	})
+	oo, err := a.iw.u.EVEUniverse().MarketHistory(a.ctx, regionID, a.typeID)
+	if err != nil {
+		slog.Error("market history", "regionID", regionID, "typeID", a.typeID, "error", err)
+		fyne.Do(func() {
+			a.summary.Importance = widget.DangerImportance
+			a.summary.SetText("ERROR: Failed to fetch market history: " + a.iw.u.ErrorDisplay(err))
+		})
+		return
+	}
+	since := time.Now().UTC().AddDate(0, 0, -marketHistoryPeriods[period])
+	oo = slices.DeleteFunc(oo, func(x *app.EveMarketHistory) bool {
+		return x.Date.Before(since)
+	})
+	var average, highest, lowest, volume []data.TemporalPoint
+	for _, o := range oo {
+		average = append(average, data.TemporalPoint{T: o.Date, Val: o.Average})
+		highest = append(highest, data.TemporalPoint{T: o.Date, Val: o.Highest})
+		lowest = append(lowest, data.TemporalPoint{T: o.Date, Val: o.Lowest})
+		volume = append(volume, data.TemporalPoint{T: o.Date, Val: float64(o.Volume)})
+	}
+	fyne.Do(func() {
+		for _, x := range []struct {
+			chart  *coord.CartesianTemporalChart
+			name   string
+			color  fyne.ThemeColorName
+			points []data.TemporalPoint
+		}{
+			{a.prices, "Average", theme.ColorNamePrimary, average},
+			{a.prices, "Highest", theme.ColorNameSuccess, highest},
+			{a.prices, "Lowest", theme.ColorNameWarning, lowest},
+			{a.volumes, "Volume", ui.ColorNameInfo, volume},
+		} {
+			x.chart.RemoveSeries(x.name)
+			if len(x.points) == 0 {
+				continue
+			}
+			s, err := coord.NewTemporalPointSeries(x.name, x.color, x.points)
+			if err != nil {
+				slog.Error("market history", "error", err)
+				return
+			}
+			if err := x.chart.AddLineSeries(s); err != nil {
+				slog.Error("market history", "error", err)
+				return
+			}
+		}
+		a.prices.SetTitle(fmt.Sprintf("Daily Prices in %s - Last %s", hub, period))
+		a.volumes.SetTitle(fmt.Sprintf("Daily Volume in %s - Last %s", hub, period))
+		a.summary.Importance = widget.MediumImportance
+		a.summary.SetText(marketHistorySummary(oo))
+	})
+}
+
+// marketHistorySummary returns a summary of the most recent day in a market history.
+func marketHistorySummary(oo []*app.EveMarketHistory) string {
+	if len(oo) == 0 {
+		return "No trades in this period"
+	}
+	o := oo[len(oo)-1]
+	return fmt.Sprintf(
+		"%s: Average %s ISK • Range %s - %s ISK • Volume %s • Orders %s",
+		o.Date.Format(time.DateOnly),
+		humanize.FormatFloat(priceFormat, o.Average),
+		humanize.FormatFloat(priceFormat, o.Lowest),
+		humanize.FormatFloat(priceFormat, o.Highest),
+		ihumanize.Comma(o.Volume),
+		ihumanize.Comma(o.OrderCount),
+	)
+}
@@ -0,0 +1,519 @@
+// Package killmails provides widgets for building killmail UIs.
+package killmails
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/characterservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/corporationservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/eveuniverseservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	ihumanize "github.com/ErikKalkoken/evebuddy/internal/humanize"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+	"github.com/ErikKalkoken/evebuddy/internal/xstrings"
+	"github.com/ErikKalkoken/evebuddy/internal/xwidget"
+)
+
+type baseUI interface {
+	Character() *characterservice.CharacterService
+	Corporation() *corporationservice.CorporationService
+	ErrorDisplay(err error) string
+	EVEImage() ui.EVEImageService
+	EVEUniverse() *eveuniverseservice.EVEUniverseService
+	GetOrCreateWindow(id string, titles ...string) (window fyne.Window, created bool)
+	InfoViewer() ui.InfoViewer
+	IsDeveloperMode() bool
+	IsMobile() bool
+	MainWindow() fyne.Window
+	Signals() *app.Signals
+}
+
+const (
+	killmailResultKill = "Kills"
+	killmailResultLoss = "Losses"
+)
+
+type killmailRow struct {
+	isLoss          bool
+	killmailID      int64
+	shipTypeID      int64
+	shipTypeName    string
+	solarSystemID   int64
+	solarSystemName string
+	security        float32
+	time            time.Time
+	value           optional.Optional[float64]
+	victimName      string
+}
+
+func (r killmailRow) resultDisplay() string {
+	if r.isLoss {
+		return "Loss"
+	}
+	return "Kill"
+}
+
+func (r killmailRow) resultColor() fyne.ThemeColorName {
+	if r.isLoss {
+		return theme.ColorNameError
+	}
+	return theme.ColorNameSuccess
+}
+
+func (r killmailRow) resultImportance() widget.Importance {
+	if r.isLoss {
+		return widget.DangerImportance
+	}
+	return widget.SuccessImportance
+}
+
+func (r killmailRow) locationDisplay() string {
+	return fmt.Sprintf("%s %.1f", r.solarSystemName, r.security)
+}
+
+func (r killmailRow) valueDisplay() string {
+	return r.value.StringFunc("?", func(v float64) string {
+		return ihumanize.NumberF(v, 1)
+	})
+}
+
+// killmailSummary summarizes the ISK destroyed and lost for a set of killmails.
+type killmailSummary struct {
+	destroyed float64
+	kills     int
+	losses    int
+	lost      float64
+}
+
+func summarizeKillmails(rows []killmailRow) killmailSummary {
+	var s killmailSummary
+	for _, r := range rows {
+		if r.isLoss {
+			s.losses++
+			s.lost += r.value.ValueOrZero()
+		} else {
+			s.kills++
+			s.destroyed += r.value.ValueOrZero()
+		}
+	}
+	return s
+}
+
+// Killmails is a widget for showing the kills and losses of the current character or corporation.
+type Killmails struct {
+	widget.BaseWidget
+
+	character      atomic.Pointer[app.Character]
+	columnSorter   *xwidget.ColumnSorter[killmailRow]
+	corporation    atomic.Pointer[app.Corporation]
+	footer         *widget.Label
+	forCorporation bool
+	main           fyne.CanvasObject
+	rows           []killmailRow
+	rowsFiltered   []killmailRow
+	selectResult   *kxwidget.FilterChipSelect
+	selectSystem   *kxwidget.FilterChipSelect
+	sortButton     *xwidget.SortButton[killmailRow]
+	summary        *widget.Label
+	u              baseUI
+}
+
+const (
+	killmailsColTime = iota + 1
+	killmailsColShip
+	killmailsColVictim
+	killmailsColLocation
+	killmailsColValue
+	killmailsColResult
+)
+
+// NewCharacterKillmails returns a new widget for showing the killmails of the current character.
+func NewCharacterKillmails(u baseUI) *Killmails {
+	a := newKillmails(u, false)
+	a.u.Signals().CurrentCharacterExchanged.AddListener(func(ctx context.Context, c *app.Character) {
+		a.character.Store(c)
+		fyne.Do(func() {
+			a.selectResult.Selected = ""
+			a.selectSystem.Selected = ""
+		})
+		a.Update(ctx)
+	})
+	a.u.Signals().CharacterSectionChanged.AddListener(func(ctx context.Context, arg app.CharacterSectionUpdated) {
+		if a.character.Load().IDOrZero() != arg.CharacterID {
+			return
+		}
+		if arg.Section != app.SectionCharacterKillmails {
+			return
+		}
+		a.Update(ctx)
+	})
+	return a
+}
+
+// NewCorporationKillmails returns a new widget for showing the killmails of the current corporation.
+func NewCorporationKillmails(u baseUI) *Killmails {
+	a := newKillmails(u, true)
+	a.u.Signals().CurrentCorporationExchanged.AddListener(func(ctx context.Context, c *app.Corporation) {
+		a.corporation.Store(c)
+		fyne.Do(func() {
+			a.selectResult.Selected = ""
+			a.selectSystem.Selected = ""
+		})
+		a.Update(ctx)
+	})
+	a.u.Signals().CorporationSectionChanged.AddListener(func(ctx context.Context, arg app.CorporationSectionUpdated) {
+		if a.corporation.Load().IDOrZero() != arg.CorporationID {
+			return
+		}
+		if arg.Section != app.SectionCorporationKillmails {
+			return
+		}
+		a.Update(ctx)
+	})
+	return a
+}
+
+func newKillmails(u baseUI, forCorporation bool) *Killmails {
+	columns := xwidget.NewDataColumns([]xwidget.DataColumn[killmailRow]{{
+		ID:    killmailsColTime,
+		Label: "Time",
+		Width: ui.ColumnWidthDateTime,
+		Sort: func(a, b killmailRow) int {
+			return a.time.Compare(b.time)
+		},
+		Update: func(r killmailRow, co fyne.CanvasObject) {
+			co.(*xwidget.RichText).SetWithText(r.time.Format(app.DateTimeFormat))
+		},
+	},
+		ui.MakeEveEntityColumn(ui.MakeEveEntityColumnParams[killmailRow]{
+			ColumnID: killmailsColShip,
+			EIS:      u.EVEImage(),
+			GetEntity: func(r killmailRow) *app.EveEntity {
+				return &app.EveEntity{
+					ID:       r.shipTypeID,
+					Name:     r.shipTypeName,
+					Category: app.EveEntityInventoryType,
+				}
+			},
+			IsAvatar: false,
+			Label:    "Ship",
+		}), {
+			ID:    killmailsColVictim,
+			Label: "Victim",
+			Width: ui.ColumnWidthEntity,
+			Sort: func(a, b killmailRow) int {
+				return xstrings.CompareIgnoreCase(a.victimName, b.victimName)
+			},
+			Update: func(r killmailRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.victimName)
+			},
+		}, {
+			ID:    killmailsColLocation,
+			Label: "Location",
+			Width: ui.ColumnWidthRegion,
+			Sort: func(a, b killmailRow) int {
+				return strings.Compare(a.solarSystemName, b.solarSystemName)
+			},
+			Update: func(r killmailRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.locationDisplay())
+			},
+		}, {
+			ID:    killmailsColValue,
+			Label: "Value",
+			Width: 100,
+			Sort: func(a, b killmailRow) int {
+				return optional.Compare(a.value, b.value)
+			},
+			Update: func(r killmailRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.valueDisplay(), widget.RichTextStyle{
+					Alignment: fyne.TextAlignTrailing,
+				})
+			},
+		}, {
+			ID:    killmailsColResult,
+			Label: "Result",
+			Width: 75,
+			Sort: func(a, b killmailRow) int {
+				return strings.Compare(a.resultDisplay(), b.resultDisplay())
+			},
+			Update: func(r killmailRow, co fyne.CanvasObject) {
+				co.(*xwidget.RichText).SetWithText(r.resultDisplay(), widget.RichTextStyle{
+					ColorName: r.resultColor(),
+				})
+			},
+		}})
+	a := &Killmails{
+		columnSorter:   xwidget.NewColumnSorter(columns, killmailsColTime, xwidget.SortDesc),
+		footer:         ui.NewLabelWithTruncation(""),
+		forCorporation: forCorporation,
+		summary:        widget.NewLabel(""),
+		u:              u,
+	}
+	a.ExtendBaseWidget(a)
+	if !a.u.IsMobile() {
+		a.main = xwidget.MakeDataTable(
+			columns,
+			&a.rowsFiltered,
+			func() fyne.CanvasObject {
+				x := xwidget.NewRichText()
+				x.Truncation = fyne.TextTruncateClip
+				return x
+			},
+			a.columnSorter,
+			a.filterRowsAsync, func(_ int, r killmailRow) {
+				showKillmailWindow(a.u, r.killmailID)
+			})
+	} else {
+		a.main = a.makeDataList()
+	}
+
+	a.selectResult = kxwidget.NewFilterChipSelect("Result", []string{
+		killmailResultKill,
+		killmailResultLoss,
+	}, func(_ string) {
+		a.filterRowsAsync(-1)
+	})
+	a.selectResult.SortDisabled = true
+	a.selectSystem = kxwidget.NewFilterChipSelectWithSearch("System", []string{}, func(string) {
+		a.filterRowsAsync(-1)
+	}, a.u.MainWindow())
+	a.sortButton = a.columnSorter.NewSortButton(func() {
+		a.filterRowsAsync(-1)
+	})
+	return a
+}
+
+func (a *Killmails) CreateRenderer() fyne.WidgetRenderer {
+	filter := container.NewHBox(a.selectResult, a.selectSystem)
+	if a.u.IsMobile() {
+		filter.Add(a.sortButton)
+	}
+	p := theme.Padding()
+	c := container.NewBorder(
+		container.NewVBox(a.summary, container.NewHScroll(filter)),
+		container.New(layout.NewCustomPaddedLayout(p, p, 0, 0), a.footer),
+		nil,
+		nil,
+		a.main,
+	)
+	return widget.NewSimpleRenderer(c)
+}
+
+func (a *Killmails) makeDataList() *xwidget.StripedList {
+	p := theme.Padding()
+	l := xwidget.NewStripedList(
+		func() int {
+			return len(a.rowsFiltered)
+		},
+		func() fyne.CanvasObject {
+			ship := widget.NewLabel("Template")
+			ship.Truncation = fyne.TextTruncateClip
+			ship.TextStyle.Bold = true
+			result := widget.NewLabel("Template")
+			result.Alignment = fyne.TextAlignTrailing
+			victim := widget.NewLabel("Template")
+			victim.Truncation = fyne.TextTruncateClip
+			value := widget.NewLabel("Template")
+			value.Alignment = fyne.TextAlignTrailing
+			location := widget.NewLabel("Template")
+			location.Truncation = fyne.TextTruncateClip
+			when := widget.NewLabel("Template")
+			when.Alignment = fyne.TextAlignTrailing
+			return container.New(layout.NewCustomPaddedVBoxLayout(-p),
+				container.NewBorder(nil, nil, nil, result, ship),
+				container.NewBorder(nil, nil, nil, value, victim),
+				container.NewBorder(nil, nil, nil, when, location),
+			)
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id < 0 || id >= len(a.rowsFiltered) {
+				return
+			}
+			r := a.rowsFiltered[id]
+			c := co.(*fyne.Container).Objects
+
+			b0 := c[0].(*fyne.Container).Objects
+			b0[0].(*widget.Label).SetText(r.shipTypeName)
+			result := b0[1].(*widget.Label)
+			result.Text = r.resultDisplay()
+			result.Importance = r.resultImportance()
+			result.Refresh()
+
+			b1 := c[1].(*fyne.Container).Objects
+			b1[0].(*widget.Label).SetText(r.victimName)
+			b1[1].(*widget.Label).SetText(r.valueDisplay() + " ISK")
+
+			b2 := c[2].(*fyne.Container).Objects
+			b2[0].(*widget.Label).SetText(r.locationDisplay())
+			b2[1].(*widget.Label).SetText(r.time.Format(app.DateTimeFormat))
+		},
+	)
+	l.OnSelected = func(id widget.ListItemID) {
+		defer l.UnselectAll()
+		if id < 0 || id >= len(a.rowsFiltered) {
+			return
+		}
+		showKillmailWindow(a.u, a.rowsFiltered[id].killmailID)
+	}
+	l.HideSeparators = true
+	return l
+}
+
+func (a *Killmails) filterRowsAsync(sortCol int) {
+	totalRows := len(a.rows)
+	rows := slices.Clone(a.rows)
+	result := a.selectResult.Selected
+	system := a.selectSystem.Selected
+	sortCol, dir, doSort := a.columnSorter.CalcSort(sortCol)
+
+	go func() {
+		switch result {
+		case killmailResultKill:
+			rows = slices.DeleteFunc(rows, func(r killmailRow) bool {
+				return r.isLoss
+			})
+		case killmailResultLoss:
+			rows = slices.DeleteFunc(rows, func(r killmailRow) bool {
+				return !r.isLoss
+			})
+		}
+		if system != "" {
+			rows = slices.DeleteFunc(rows, func(r killmailRow) bool {
+				return r.solarSystemName != system
+			})
+		}
+		a.columnSorter.SortRows(rows, sortCol, dir, doSort)
+		systemOptions := xslices.Map(rows, func(r killmailRow) string {
+			return r.solarSystemName
+		})
+		s := summarizeKillmails(rows)
+		summary := fmt.Sprintf(
+			"%s ISK destroyed in %d kills • %s ISK lost in %d losses",
+			ihumanize.NumberF(s.destroyed, 1),
+			s.kills,
+			ihumanize.NumberF(s.lost, 1),
+			s.losses,
+		)
+		footer := fmt.Sprintf("Showing %s / %s killmails", ihumanize.Comma(len(rows)), ihumanize.Comma(totalRows))
+
+		fyne.Do(func() {
+			a.summary.SetText(summary)
+			a.footer.Text = footer
+			a.footer.Importance = widget.MediumImportance
+			a.footer.Refresh()
+			a.selectSystem.SetOptions(systemOptions)
+			a.rowsFiltered = rows
+			a.main.Refresh()
+		})
+	}()
+}
+
+// Update refreshes the widget with the latest killmails.
+func (a *Killmails) Update(ctx context.Context) {
+	reset := func() {
+		fyne.Do(func() {
+			xslices.Clear(&a.rows)
+			a.filterRowsAsync(-1)
+		})
+	}
+	setFooter := func(s string, i widget.Importance) {
+		fyne.Do(func() {
+			a.footer.Text = s
+			a.footer.Importance = i
+			a.footer.Refresh()
+		})
+	}
+
+	var ownerID int64
+	var hasData bool
+	var err error
+	if a.forCorporation {
+		ownerID = a.corporation.Load().IDOrZero()
+		if ownerID != 0 {
+			hasData, err = a.u.Corporation().HasSection(ctx, ownerID, app.SectionCorporationKillmails)
+		}
+	} else {
+		ownerID = a.character.Load().IDOrZero()
+		if ownerID != 0 {
+			hasData, err = a.u.Character().HasSection(ctx, ownerID, app.SectionCharacterKillmails)
+		}
+	}
+	if ownerID == 0 {
+		reset()
+		if a.forCorporation {
+			setFooter("No corporation", widget.LowImportance)
+		} else {
+			setFooter("No character", widget.LowImportance)
+		}
+		return
+	}
+	if err != nil {
+		reset()
+		setFooter("ERROR: "+a.u.ErrorDisplay(err), widget.DangerImportance)
+		return
+	}
+	if !hasData {
+		reset()
+		setFooter("Loading data...", widget.WarningImportance)
+		return
+	}
+
+	rows, err := a.fetchRows(ctx, ownerID)
+	if err != nil {
+		reset()
+		setFooter("ERROR: "+a.u.ErrorDisplay(err), widget.DangerImportance)
+		return
+	}
+	fyne.Do(func() {
+		a.rows = rows
+		a.filterRowsAsync(-1)
+	})
+}
+
+func (a *Killmails) fetchRows(ctx context.Context, ownerID int64) ([]killmailRow, error) {
+	var oo []*app.EveKillmail
+	var err error
+	if a.forCorporation {
+		oo, err = a.u.Corporation().ListKillmails(ctx, ownerID)
+	} else {
+		oo, err = a.u.Character().ListKillmails(ctx, ownerID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rows []killmailRow
+	for _, o := range oo {
+		r := killmailRow{
+			killmailID:      o.ID,
+			shipTypeID:      o.Victim.ShipType.ID,
+			shipTypeName:    o.Victim.ShipType.Name,
+			solarSystemID:   o.SolarSystem.ID,
+			solarSystemName: o.SolarSystem.Name,
+			security:        o.SolarSystemSecurity,
+			time:            o.Time,
+			value:           o.Value(),
+			victimName:      o.VictimName(),
+		}
+		if a.forCorporation {
+			r.isLoss = o.IsLossForCorporation(ownerID)
+		} else {
+			r.isLoss = o.IsLossForCharacter(ownerID)
+		}
+		rows = append(rows, r)
+	}
+	return rows, nil
+}
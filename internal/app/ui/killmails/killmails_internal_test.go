@@ -0,0 +1,33 @@
+package killmails
+
+import (
+	"testing"
+
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestSummarizeKillmails(t *testing.T) {
+	t.Run("should sum kills and losses separately", func(t *testing.T) {
+		rows := []killmailRow{
+			{value: optional.New(100.0)},
+			{value: optional.New(50.0)},
+			{isLoss: true, value: optional.New(30.0)},
+		}
+		got := summarizeKillmails(rows)
+		xassert.Equal(t, killmailSummary{destroyed: 150, kills: 2, losses: 1, lost: 30}, got)
+	})
+	t.Run("should count killmails without value", func(t *testing.T) {
+		rows := []killmailRow{
+			{value: optional.New(100.0)},
+			{},
+			{isLoss: true},
+		}
+		got := summarizeKillmails(rows)
+		xassert.Equal(t, killmailSummary{destroyed: 100, kills: 2, losses: 1}, got)
+	})
+	t.Run("should return zero summary for no rows", func(t *testing.T) {
+		got := summarizeKillmails(nil)
+		xassert.Equal(t, killmailSummary{}, got)
+	})
+}
@@ -0,0 +1,178 @@
+package killmails
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	ihumanize "github.com/ErikKalkoken/evebuddy/internal/humanize"
+	"github.com/ErikKalkoken/evebuddy/internal/xwidget"
+)
+
+// showKillmailWindow shows the details of a killmail in a window.
+func showKillmailWindow(u baseUI, killmailID int64) {
+	km, err := u.EVEUniverse().GetKillmail(context.Background(), killmailID)
+	if err != nil {
+		ui.ShowErrorAndLog("Failed to show killmail", err, u.IsDeveloperMode(), u.MainWindow())
+		return
+	}
+	title := fmt.Sprintf("Killmail #%d", km.ID)
+	w, created := u.GetOrCreateWindow(fmt.Sprintf("killmail-%d", km.ID), title, km.VictimName())
+	if !created {
+		w.Show()
+		return
+	}
+
+	formatISK := func(v float64) string {
+		return ui.FormatISKAmount(v)
+	}
+	items := []*widget.FormItem{
+		widget.NewFormItem("Time", widget.NewLabel(km.Time.Format(app.DateTimeFormat))),
+		widget.NewFormItem("Ship", ui.MakeLinkLabelWithWrap(km.Victim.ShipType.Name, func() {
+			u.InfoViewer().ShowType(km.Victim.ShipType.ID, 0)
+		})),
+	}
+	for _, x := range []struct {
+		label string
+		o     *app.EveEntity
+	}{
+		{"Victim", km.Victim.Character.ValueOrZero()},
+		{"Corporation", km.Victim.Corporation.ValueOrZero()},
+		{"Alliance", km.Victim.Alliance.ValueOrZero()},
+		{"Faction", km.Victim.Faction.ValueOrZero()},
+	} {
+		if x.o == nil {
+			continue
+		}
+		items = append(items, widget.NewFormItem(x.label, ui.MakeEveEntityActionLabel(x.o, u.InfoViewer().Show)))
+	}
+	items = slices.Concat(items, []*widget.FormItem{
+		widget.NewFormItem("Location", ui.MakeLinkLabel(
+			fmt.Sprintf("%s %.1f", km.SolarSystem.Name, km.SolarSystemSecurity),
+			func() {
+				u.InfoViewer().Show(&app.EveEntity{
+					Category: app.EveEntitySolarSystem,
+					ID:       km.SolarSystem.ID,
+					Name:     km.SolarSystem.Name,
+				})
+			},
+		)),
+		widget.NewFormItem("Damage Taken", widget.NewLabel(ihumanize.Comma(km.Victim.DamageTaken))),
+		widget.NewFormItem("Ship Value", widget.NewLabel(km.ShipValue.StringFunc("?", formatISK))),
+		widget.NewFormItem("Items Value", widget.NewLabel(km.ItemsValue.StringFunc("?", formatISK))),
+		widget.NewFormItem("Total Value", widget.NewLabel(km.Value().StringFunc("?", formatISK))),
+	})
+	if v, ok := km.FinalBlow().Value(); ok {
+		items = append(items, widget.NewFormItem("Final Blow", widget.NewLabel(attackerName(v))))
+	}
+	items = append(items, widget.NewFormItem("Attackers", widget.NewLabel(ihumanize.Comma(len(km.Attackers)))))
+	if u.IsDeveloperMode() {
+		items = append(items, widget.NewFormItem("Killmail ID", xwidget.NewTappableLabelWithClipboardCopy(fmt.Sprint(km.ID))))
+	}
+	f := widget.NewForm(items...)
+	f.Orientation = widget.Adaptive
+
+	attackers := widget.NewList(
+		func() int {
+			return len(km.Attackers)
+		},
+		func() fyne.CanvasObject {
+			damage := widget.NewLabel("Template")
+			damage.Alignment = fyne.TextAlignTrailing
+			return container.NewBorder(nil, nil, nil, damage, ui.NewLabelWithTruncation("Template"))
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id < 0 || id >= len(km.Attackers) {
+				return
+			}
+			a := km.Attackers[id]
+			c := co.(*fyne.Container).Objects
+			name := attackerName(a)
+			if v, ok := a.ShipType.Value(); ok {
+				name += " • " + v.Name
+			}
+			c[0].(*widget.Label).SetText(name)
+			c[1].(*widget.Label).SetText(ihumanize.Comma(a.DamageDone))
+		},
+	)
+	attackers.OnSelected = func(_ widget.ListItemID) {
+		attackers.UnselectAll()
+	}
+	fitting := widget.NewList(
+		func() int {
+			return len(km.Items)
+		},
+		func() fyne.CanvasObject {
+			value := widget.NewLabel("Template")
+			value.Alignment = fyne.TextAlignTrailing
+			return container.NewBorder(nil, nil, nil, value, ui.NewLabelWithTruncation("Template"))
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id < 0 || id >= len(km.Items) {
+				return
+			}
+			it := km.Items[id]
+			c := co.(*fyne.Container).Objects
+			var status string
+			if it.QuantityDropped > 0 {
+				status = "dropped"
+			} else {
+				status = "destroyed"
+			}
+			c[0].(*widget.Label).SetText(fmt.Sprintf(
+				"%s x %s (%s)",
+				it.Type.Name,
+				ihumanize.Comma(it.QuantityDestroyed+it.QuantityDropped),
+				status,
+			))
+			c[1].(*widget.Label).SetText(it.Value().StringFunc("?", func(v float64) string {
+				return ihumanize.NumberF(v, 1)
+			}))
+		},
+	)
+	fitting.OnSelected = func(id widget.ListItemID) {
+		defer fitting.UnselectAll()
+		if id < 0 || id >= len(km.Items) {
+			return
+		}
+		u.InfoViewer().ShowType(km.Items[id].Type.ID, 0)
+	}
+
+	content := container.NewAppTabs(
+		container.NewTabItem("Overview", container.NewVScroll(f)),
+		container.NewTabItem(fmt.Sprintf("Attackers (%d)", len(km.Attackers)), attackers),
+		container.NewTabItem(fmt.Sprintf("Items (%d)", len(km.Items)), fitting),
+	)
+	ui.MakeDetailWindow(ui.MakeDetailWindowParams{
+		Content: content,
+		ImageAction: func() {
+			u.InfoViewer().ShowType(km.Victim.ShipType.ID, 0)
+		},
+		ImageLoader: func(setter func(r fyne.Resource)) {
+			u.EVEImage().InventoryTypeRenderAsync(km.Victim.ShipType.ID, 256, setter)
+		},
+		MinSize: fyne.NewSize(500, 450),
+		Title:   title,
+		Window:  w,
+	})
+	w.Show()
+}
+
+func attackerName(a *app.EveKillmailAttacker) string {
+	for _, x := range []*app.EveEntity{
+		a.Character.ValueOrZero(),
+		a.Corporation.ValueOrZero(),
+		a.Faction.ValueOrZero(),
+	} {
+		if x != nil {
+			return x.Name
+		}
+	}
+	return "?"
+}
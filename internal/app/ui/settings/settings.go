@@ -2,14 +2,19 @@
 package settings
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"image/color"
+	"io"
 	"log/slog"
+	"net/url"
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -17,13 +22,20 @@ import (
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
+	"github.com/dustin/go-humanize"
 	fynetooltip "github.com/dweymouth/fyne-tooltip"
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/backup"
+	"github.com/ErikKalkoken/evebuddy/internal/app/characterservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationrule"
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationsink"
 	asettings "github.com/ErikKalkoken/evebuddy/internal/app/settings"
+	"github.com/ErikKalkoken/evebuddy/internal/app/tokenkey"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
 	"github.com/ErikKalkoken/evebuddy/internal/xdesktop"
 	"github.com/ErikKalkoken/evebuddy/internal/xmaps"
@@ -32,6 +44,8 @@ import (
 )
 
 type baseUI interface {
+	Backup() *backup.Backup
+	Character() *characterservice.CharacterService
 	ClearAllCaches()
 	DataPaths() xmaps.OrderedMap[string, string]
 	ErrorDisplay(err error) string
@@ -45,6 +59,8 @@ type baseUI interface {
 	SetDeveloperMode(b bool)
 	Settings() *asettings.Settings
 	Signals() *app.Signals
+	TokenKey() *tokenkey.Manager
+	Webhook() *notificationsink.Webhook
 }
 
 func Show(s baseUI) {
@@ -182,10 +198,28 @@ func (a *settings) makeGeneralPage() (fyne.CanvasObject, *kxwidget.IconButton) {
 		isMobile: a.u.IsMobile(),
 		window:   a.w,
 	})
+	iMin, iMax, iDef := a.u.Settings().CorporationMemberInactiveDaysPresets()
+	memberInactiveDays := NewSettingItemSlider(SettingItemSliderParams{
+		label:        "Inactive corporation members",
+		hint:         "Number of days without login after which corporation members are shown as inactive.",
+		minValue:     float64(iMin),
+		maxValue:     float64(iMax),
+		defaultValue: float64(iDef),
+		step:         1,
+		getter: func() float64 {
+			return float64(a.u.Settings().CorporationMemberInactiveDays())
+		},
+		setter: func(v float64) {
+			a.u.Settings().SetCorporationMemberInactiveDays(int(v))
+		},
+		isMobile: a.u.IsMobile(),
+		window:   a.w,
+	})
 	items = slices.Concat(items, []SettingItem{
 		NewSettingItemHeading("UI"),
 		preferMarketTab,
 		hideLimitedCorporations,
+		memberInactiveDays,
 		colorTheme,
 	})
 
@@ -303,6 +337,160 @@ func (a *settings) makeGeneralPage() (fyne.CanvasObject, *kxwidget.IconButton) {
 		marketOrdersRetention,
 	})
 
+	localAPIEnabled := NewSettingItemSwitch(SettingItemSwitchParams{
+		defaultValue: a.u.Settings().LocalAPIEnabledDefault(),
+		label:        "Enable local API",
+		hint:         "Provide character data as JSON to other apps on this computer (requires restart)",
+		getter:       a.u.Settings().LocalAPIEnabled,
+		onChanged:    a.u.Settings().SetLocalAPIEnabled,
+	})
+
+	vMin, vMax, vDef = a.u.Settings().LocalAPIPortPresets()
+	localAPIPort := NewSettingItemCustom(SettingItemCustomParams{
+		label: "Local API port",
+		hint:  fmt.Sprintf("Port of the local API on 127.0.0.1 between %d and %d. Requires restart.", vMin, vMax),
+		getter: func() any {
+			return a.u.Settings().LocalAPIPort()
+		},
+		onSelected: func(it SettingItem, refresh func()) {
+			port := widget.NewEntry()
+			port.SetText(strconv.Itoa(a.u.Settings().LocalAPIPort()))
+			port.Validator = func(s string) error {
+				v, err := strconv.Atoi(s)
+				if err != nil || v < vMin || v > vMax {
+					return errors.New("invalid port")
+				}
+				return nil
+			}
+			port.OnChanged = func(s string) {
+				if port.Validate() != nil {
+					return
+				}
+				v, _ := strconv.Atoi(s)
+				a.u.Settings().SetLocalAPIPort(v)
+			}
+			d := makeSettingDialog(makeSettingDialogParams{
+				setting:  port,
+				label:    it.Label,
+				hint:     it.Hint,
+				isMobile: a.u.IsMobile(),
+				reset: func() {
+					port.SetText(strconv.Itoa(vDef))
+				},
+				refresh: refresh,
+				window:  a.w,
+			})
+			d.Show()
+		},
+	})
+
+	localAPIToken := NewSettingItemCustom(SettingItemCustomParams{
+		label: "Local API token",
+		hint:  "Apps must send this token as bearer token. Reset creates a new token and requires a restart.",
+		getter: func() any {
+			return xstrings.Obfuscate(a.u.Settings().LocalAPIToken(), 4, 'X')
+		},
+		onSelected: func(it SettingItem, refresh func()) {
+			token := widget.NewLabel(a.u.Settings().LocalAPIToken())
+			token.Selectable = true
+			copyToken := widget.NewButtonWithIcon("", theme.ContentCopyIcon(), func() {
+				fyne.CurrentApp().Clipboard().SetContent(token.Text)
+				a.sb.Show("Token copied to clipboard")
+			})
+			d := makeSettingDialog(makeSettingDialogParams{
+				setting:  container.NewBorder(nil, nil, nil, copyToken, token),
+				label:    it.Label,
+				hint:     it.Hint,
+				isMobile: a.u.IsMobile(),
+				reset: func() {
+					token.SetText(a.u.Settings().ResetLocalAPIToken())
+				},
+				refresh: refresh,
+				window:  a.w,
+			})
+			d.Show()
+		},
+	})
+
+	if !a.u.IsMobile() {
+		items = slices.Concat(items, []SettingItem{
+			NewSettingItemHeading("Local API"),
+			localAPIEnabled,
+			localAPIPort,
+			localAPIToken,
+		})
+	}
+
+	backupAutoEnabled := NewSettingItemSwitch(SettingItemSwitchParams{
+		label:        "Automatic backups",
+		hint:         "Regularly create backups of all user data and settings",
+		defaultValue: a.u.Settings().BackupAutoEnabledDefault(),
+		getter:       a.u.Settings().BackupAutoEnabled,
+		onChanged: func(on bool) {
+			a.u.Settings().SetBackupAutoEnabled(on)
+		},
+	})
+	vMin, vMax, vDef = a.u.Settings().BackupIntervalHoursPresets()
+	backupInterval := NewSettingItemSlider(SettingItemSliderParams{
+		label:        "Backup interval",
+		hint:         "Hours between automatic backups",
+		minValue:     float64(vMin),
+		maxValue:     float64(vMax),
+		defaultValue: float64(vDef),
+		step:         1,
+		getter: func() float64 {
+			return float64(a.u.Settings().BackupIntervalHours())
+		},
+		setter: func(v float64) {
+			a.u.Settings().SetBackupIntervalHours(int(v))
+		},
+		isMobile: a.u.IsMobile(),
+		window:   a.w,
+	})
+	vMin, vMax, vDef = a.u.Settings().BackupRetentionPresets()
+	backupRetention := NewSettingItemSlider(SettingItemSliderParams{
+		label:        "Backups to keep",
+		hint:         "Older backups are deleted after an automatic backup",
+		minValue:     float64(vMin),
+		maxValue:     float64(vMax),
+		defaultValue: float64(vDef),
+		step:         1,
+		getter: func() float64 {
+			return float64(a.u.Settings().BackupRetention())
+		},
+		setter: func(v float64) {
+			a.u.Settings().SetBackupRetention(int(v))
+		},
+		isMobile: a.u.IsMobile(),
+		window:   a.w,
+	})
+	backupExcludeCache := NewSettingItemSwitch(SettingItemSwitchParams{
+		label:        "Exclude cache",
+		hint:         "Makes backups much smaller. The cache is rebuilt automatically.",
+		defaultValue: a.u.Settings().BackupExcludeCacheDefault(),
+		getter:       a.u.Settings().BackupExcludeCache,
+		onChanged: func(on bool) {
+			a.u.Settings().SetBackupExcludeCache(on)
+		},
+	})
+
+	if a.u.Backup() != nil {
+		items = slices.Concat(items, []SettingItem{
+			NewSettingItemHeading("Backup"),
+			backupAutoEnabled,
+			backupInterval,
+			backupRetention,
+			backupExcludeCache,
+		})
+	}
+
+	if a.u.TokenKey() != nil {
+		items = slices.Concat(items, []SettingItem{
+			NewSettingItemHeading("Security"),
+			a.makeTokenEncryptionItem(),
+		})
+	}
+
 	developerMode := NewSettingItemSwitch(SettingItemSwitchParams{
 		label:  "Developer mode",
 		hint:   "Show debug information, e.g. EVE IDs and technical error messages",
@@ -348,6 +536,14 @@ func (a *settings) makeGeneralPage() (fyne.CanvasObject, *kxwidget.IconButton) {
 			hideLimitedCorporations.Reset()
 			maxMail.Reset()
 			maxWallet.Reset()
+			localAPIEnabled.Reset()
+			_, _, port := a.u.Settings().LocalAPIPortPresets()
+			a.u.Settings().SetLocalAPIPort(port)
+			backupAutoEnabled.Reset()
+			backupInterval.Reset()
+			backupRetention.Reset()
+			backupExcludeCache.Reset()
+			list.Refresh()
 		},
 	}
 	exportAppLog := settingAction{
@@ -375,6 +571,20 @@ func (a *settings) makeGeneralPage() (fyne.CanvasObject, *kxwidget.IconButton) {
 		},
 	}
 	actions := []settingAction{reset, clearCache, exportAppLog, exportCrashLog, deleteAppLog, deleteCrashLog}
+	if a.u.Backup() != nil {
+		actions = append(actions, settingAction{
+			Label: "Create backup now",
+			Action: func() {
+				a.showCreateBackupDialog()
+			},
+		})
+		actions = append(actions, settingAction{
+			Label: "Restore from backup",
+			Action: func() {
+				a.showRestoreBackupDialog()
+			},
+		})
+	}
 	if !a.u.IsMobile() {
 		actions = append(actions, settingAction{
 			Label: "Resets main window size to defaults",
@@ -470,6 +680,146 @@ func (a *settings) showExportFileDialog(path string) {
 	d.Show()
 }
 
+func (a *settings) showCreateBackupDialog() {
+	ui.ShowProgressConfirm(
+		"Create Backup?",
+		"This will create a backup of all user data and settings in: "+a.u.Backup().Dir(),
+		"Create",
+		widget.HighImportance,
+		func() {
+			p, err := a.u.Backup().Create(context.Background(), a.u.Settings().BackupExcludeCache())
+			if err != nil {
+				slog.Error("create backup", "error", err)
+				a.sb.Show("ERROR: Failed to create backup: " + a.u.ErrorDisplay(err))
+				return
+			}
+			a.sb.Show("Backup created: " + filepath.Base(p))
+		}, a.w,
+	)
+}
+
+// showRestoreBackupDialog shows a dialog for choosing a backup to restore.
+func (a *settings) showRestoreBackupDialog() {
+	items, err := a.u.Backup().List()
+	if err != nil {
+		ui.ShowErrorAndLog("Failed to list backups", err, a.u.IsDeveloperMode(), a.w)
+		return
+	}
+	options := make([]string, 0, len(items))
+	paths := make(map[string]string)
+	for _, x := range items {
+		o := fmt.Sprintf("%s (%s)", x.CreatedAt.Local().Format(app.DateTimeFormatWithSeconds), humanize.Bytes(uint64(x.Size)))
+		options = append(options, o)
+		paths[o] = x.Path
+	}
+	sel := widget.NewSelect(options, nil)
+	sel.PlaceHolder = "Choose a backup"
+	var d dialog.Dialog
+	restore := widget.NewButtonWithIcon("Restore", theme.ConfirmIcon(), func() {
+		p, ok := paths[sel.Selected]
+		if !ok {
+			return
+		}
+		d.Hide()
+		a.restoreBackup(func() (io.ReadCloser, error) {
+			return os.Open(p)
+		})
+	})
+	restore.Importance = widget.DangerImportance
+	restore.Disable()
+	sel.OnChanged = func(string) {
+		restore.Enable()
+	}
+	buttons := []fyne.CanvasObject{
+		widget.NewButtonWithIcon("Cancel", theme.CancelIcon(), func() {
+			d.Hide()
+		}),
+	}
+	if !a.u.IsMobile() {
+		buttons = append(buttons, widget.NewButtonWithIcon("From file...", theme.FolderOpenIcon(), func() {
+			d.Hide()
+			fd := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+				if err != nil {
+					ui.ShowErrorAndLog("Failed to open backup", err, a.u.IsDeveloperMode(), a.w)
+					return
+				}
+				if reader == nil {
+					return
+				}
+				a.restoreBackup(func() (io.ReadCloser, error) {
+					return reader, nil
+				})
+			}, a.w)
+			fd.SetFilter(storage.NewExtensionFileFilter([]string{".zip"}))
+			xdesktop.DisableShortcutsForDialog(fd, a.w)
+			fd.Show()
+		}))
+	}
+	buttons = append(buttons, restore)
+	hint := widget.NewLabel(
+		"Restoring a backup replaces all current user data and settings. " +
+			"The restore is completed when the app is started the next time.",
+	)
+	hint.Wrapping = fyne.TextWrapWord
+	var content fyne.CanvasObject
+	if len(options) == 0 {
+		content = container.NewVBox(hint, widget.NewLabel("No backups found in: "+a.u.Backup().Dir()))
+	} else {
+		content = container.NewVBox(hint, sel)
+	}
+	d = dialog.NewCustomWithoutButtons("Restore From Backup", container.NewBorder(
+		nil,
+		container.NewHBox(layout.NewSpacer(), container.NewHBox(buttons...), layout.NewSpacer()),
+		nil,
+		nil,
+		content,
+	), a.w)
+	xdesktop.DisableShortcutsForDialog(d, a.w)
+	d.Resize(fyne.NewSize(500, 250))
+	d.Show()
+}
+
+// restoreBackup validates the backup from open and stages it for restore.
+func (a *settings) restoreBackup(open func() (io.ReadCloser, error)) {
+	ui.ShowProgressConfirm(
+		"Restore Backup?",
+		"This will replace all current user data and settings with the backup. Are you sure?",
+		"Restore",
+		widget.DangerImportance,
+		func() {
+			err := func() error {
+				r, err := open()
+				if err != nil {
+					return err
+				}
+				defer r.Close()
+				return a.u.Backup().PrepareRestore(context.Background(), r)
+			}()
+			if err != nil {
+				fyne.Do(func() {
+					ui.ShowErrorAndLog("Failed to restore backup", err, a.u.IsDeveloperMode(), a.w)
+				})
+				return
+			}
+			fyne.Do(func() {
+				d := dialog.NewConfirm(
+					"Restart Required",
+					"The backup will be restored when the app is started the next time. Quit the app now?",
+					func(ok bool) {
+						if ok {
+							fyne.CurrentApp().Quit()
+						}
+					}, a.w,
+				)
+				d.SetConfirmText("Quit")
+				d.SetDismissText("Later")
+				xdesktop.DisableShortcutsForDialog(d, a.w)
+				d.Show()
+			})
+		}, a.w,
+	)
+}
+
 func (a *settings) makeNotificationPage() (fyne.CanvasObject, *kxwidget.IconButton) {
 	groupsAndTypes := make(map[app.EveNotificationGroup][]app.EveNotificationType)
 	for n := range app.NotificationTypesSupported().All() {
@@ -542,6 +892,126 @@ func (a *settings) makeNotificationPage() (fyne.CanvasObject, *kxwidget.IconButt
 		},
 	})
 
+	notifyCalendar := NewSettingItemSwitch(SettingItemSwitchParams{
+		defaultValue: a.u.Settings().NotifyCalendarEnabledDefault(),
+		label:        "Notify Calendar",
+		hint:         "Whether to notify before calendar events start",
+		getter:       a.u.Settings().NotifyCalendarEnabled,
+		onChanged:    a.u.Settings().SetNotifyCalendarEnabled,
+	})
+
+	notifyJumpFatigue := NewSettingItemSwitch(SettingItemSwitchParams{
+		defaultValue: a.u.Settings().NotifyJumpFatigueEnabledDefault(),
+		label:        "Notify Jump Fatigue",
+		hint:         "Whether to notify when jump fatigue expires for watched characters",
+		getter:       a.u.Settings().NotifyJumpFatigueEnabled,
+		onChanged:    a.u.Settings().SetNotifyJumpFatigueEnabled,
+	})
+
+	notifyMarketUndercut := NewSettingItemSwitch(SettingItemSwitchParams{
+		defaultValue: a.u.Settings().NotifyMarketUndercutEnabledDefault(),
+		label:        "Notify Undercut Orders",
+		hint:         "Whether to notify when market orders are undercut or outbid",
+		getter:       a.u.Settings().NotifyMarketUndercutEnabled,
+		onChanged:    a.u.Settings().SetNotifyMarketUndercutEnabled,
+	})
+
+	notifyMarketOrders := NewSettingItemSwitch(SettingItemSwitchParams{
+		defaultValue: a.u.Settings().NotifyMarketOrdersEnabledDefault(),
+		label:        "Notify Market Orders",
+		hint:         "Whether to notify when market orders are filled or expire",
+		getter:       a.u.Settings().NotifyMarketOrdersEnabled,
+		onChanged: func(on bool) {
+			a.u.Settings().SetNotifyMarketOrdersEnabled(on)
+			if on {
+				a.u.Settings().SetNotifyMarketOrdersEarliest(time.Now())
+			}
+		},
+	})
+
+	notifyIndustryJobs := NewSettingItemSwitch(SettingItemSwitchParams{
+		defaultValue: a.u.Settings().NotifyIndustryJobsEnabledDefault(),
+		label:        "Notify Industry Jobs",
+		hint:         "Whether to notify when industry jobs are ready for delivery",
+		getter:       a.u.Settings().NotifyIndustryJobsEnabled,
+		onChanged: func(on bool) {
+			a.u.Settings().SetNotifyIndustryJobsEnabled(on)
+			if on {
+				a.u.Settings().SetNotifyIndustryJobsEarliest(time.Now())
+			}
+		},
+	})
+
+	notifyStructures := NewSettingItemSwitch(SettingItemSwitchParams{
+		defaultValue: a.u.Settings().NotifyStructuresEnabledDefault(),
+		label:        "Notify Structures",
+		hint:         "Whether to notify about low fuel, ending timers and offline services of corporation structures",
+		getter:       a.u.Settings().NotifyStructuresEnabled,
+		onChanged: func(on bool) {
+			a.u.Settings().SetNotifyStructuresEnabled(on)
+			if on {
+				a.u.Settings().SetNotifyStructuresEarliest(time.Now())
+			}
+		},
+	})
+
+	notifyStructureFuel := NewSettingItemCustom(SettingItemCustomParams{
+		label: "Structure Fuel Warnings",
+		hint:  "Warn when the remaining fuel of a structure falls below these thresholds",
+		getter: func() any {
+			hours := a.u.Settings().NotifyStructureFuelHours()
+			if len(hours) == 0 {
+				return "None"
+			}
+			slices.Sort(hours)
+			slices.Reverse(hours)
+			var parts []string
+			for _, h := range hours {
+				parts = append(parts, formatFuelHours(h))
+			}
+			return strings.Join(parts, ", ")
+		},
+		onSelected: func(it SettingItem, refresh func()) {
+			a.showStructureFuelDialog(it, refresh)
+		},
+	})
+
+	tMin, tMax, tDef := a.u.Settings().NotifyStructureTimerHoursPresets()
+	notifyStructureTimer := NewSettingItemSlider(SettingItemSliderParams{
+		label:        "Structure Timer Lead Time",
+		hint:         "Hours before a reinforcement or unanchoring timer ends to notify about it",
+		minValue:     float64(tMin),
+		maxValue:     float64(tMax),
+		defaultValue: float64(tDef),
+		step:         1.0,
+		getter: func() float64 {
+			return float64(a.u.Settings().NotifyStructureTimerHours())
+		},
+		setter: func(v float64) {
+			a.u.Settings().SetNotifyStructureTimerHours(int(v))
+		},
+		isMobile: a.u.IsMobile(),
+		window:   a.w,
+	})
+
+	lMin, lMax, lDef := a.u.Settings().NotifyCalendarLeadMinutesPresets()
+	notifyCalendarLead := NewSettingItemSlider(SettingItemSliderParams{
+		label:        "Calendar Lead Time",
+		hint:         "Minutes before an event starts to notify about it",
+		minValue:     float64(lMin),
+		maxValue:     float64(lMax),
+		defaultValue: float64(lDef),
+		step:         1.0,
+		getter: func() float64 {
+			return float64(a.u.Settings().NotifyCalendarLeadMinutes())
+		},
+		setter: func(v float64) {
+			a.u.Settings().SetNotifyCalendarLeadMinutes(int(v))
+		},
+		isMobile: a.u.IsMobile(),
+		window:   a.w,
+	})
+
 	vMin, vMax, vDef := a.u.Settings().NotifyTimeoutHoursPresets()
 	notifTimeout := NewSettingItemSlider(SettingItemSliderParams{
 		label:        "Notify Timeout",
@@ -567,6 +1037,15 @@ func (a *settings) makeNotificationPage() (fyne.CanvasObject, *kxwidget.IconButt
 		notifyPI,
 		notifyTraining,
 		notifyContracts,
+		notifyCalendar,
+		notifyCalendarLead,
+		notifyJumpFatigue,
+		notifyMarketUndercut,
+		notifyMarketOrders,
+		notifyIndustryJobs,
+		notifyStructures,
+		notifyStructureFuel,
+		notifyStructureTimer,
 		notifTimeout,
 	}
 	items = append(items, NewSettingItemHeading("Communication Groups"))
@@ -671,16 +1150,187 @@ func (a *settings) makeNotificationPage() (fyne.CanvasObject, *kxwidget.IconButt
 		items = append(items, it)
 	}
 
+	// add rules and quiet hours
+	notificationRules := NewSettingItemCustom(SettingItemCustomParams{
+		label: "Rules",
+		hint:  "Mute notifications for characters, tags or types and escalate important notifications",
+		getter: func() any {
+			rules, err := notificationrule.ParseRules(a.u.Settings().NotificationRules())
+			if err != nil {
+				return "Invalid"
+			}
+			var n int
+			for _, r := range rules {
+				if r.Enabled {
+					n++
+				}
+			}
+			return fmt.Sprintf("%d active", n)
+		},
+		onSelected: func(it SettingItem, refresh func()) {
+			a.showNotificationRulesDialog(it, refresh)
+		},
+	})
+	quietHoursEnabled := NewSettingItemSwitch(SettingItemSwitchParams{
+		defaultValue: a.u.Settings().NotifyQuietHoursEnabledDefault(),
+		label:        "Quiet Hours",
+		hint:         "Whether to hold back notifications during quiet hours and send a digest afterwards. Escalated notifications are always sent.",
+		getter:       a.u.Settings().NotifyQuietHoursEnabled,
+		onChanged:    a.u.Settings().SetNotifyQuietHoursEnabled,
+	})
+	qMin, qMax, qStartDef := a.u.Settings().NotifyQuietHoursStartPresets()
+	quietHoursStart := NewSettingItemSlider(SettingItemSliderParams{
+		label:        "Quiet Hours Start",
+		hint:         "Hour of the day when quiet hours start",
+		minValue:     float64(qMin),
+		maxValue:     float64(qMax),
+		defaultValue: float64(qStartDef),
+		step:         1.0,
+		formatter:    formatHour,
+		getter: func() float64 {
+			return float64(a.u.Settings().NotifyQuietHoursStart())
+		},
+		setter: func(v float64) {
+			a.u.Settings().SetNotifyQuietHoursStart(int(v))
+		},
+		isMobile: a.u.IsMobile(),
+		window:   a.w,
+	})
+	_, _, qEndDef := a.u.Settings().NotifyQuietHoursEndPresets()
+	quietHoursEnd := NewSettingItemSlider(SettingItemSliderParams{
+		label:        "Quiet Hours End",
+		hint:         "Hour of the day when quiet hours end",
+		minValue:     float64(qMin),
+		maxValue:     float64(qMax),
+		defaultValue: float64(qEndDef),
+		step:         1.0,
+		formatter:    formatHour,
+		getter: func() float64 {
+			return float64(a.u.Settings().NotifyQuietHoursEnd())
+		},
+		setter: func(v float64) {
+			a.u.Settings().SetNotifyQuietHoursEnd(int(v))
+		},
+		isMobile: a.u.IsMobile(),
+		window:   a.w,
+	})
+	items = slices.Concat(items, []SettingItem{
+		NewSettingItemHeading("Rules"),
+		notificationRules,
+		quietHoursEnabled,
+		quietHoursStart,
+		quietHoursEnd,
+	})
+
+	// add webhook
+	webhookEnabled := NewSettingItemSwitch(SettingItemSwitchParams{
+		defaultValue: a.u.Settings().WebhookEnabledDefault(),
+		label:        "Send to webhook",
+		hint:         "Whether to also send notifications to a webhook, e.g. a Discord channel",
+		getter:       a.u.Settings().WebhookEnabled,
+		onChanged:    a.u.Settings().SetWebhookEnabled,
+	})
+	webhookURL := NewSettingItemCustom(SettingItemCustomParams{
+		label: "Webhook URL",
+		hint:  "URL of the webhook. Notifications are sent as JSON via HTTP POST.",
+		getter: func() any {
+			u, err := url.Parse(a.u.Settings().WebhookURL())
+			if err != nil || u.Host == "" {
+				return "Not configured"
+			}
+			return u.Host
+		},
+		onSelected: func(it SettingItem, refresh func()) {
+			e := widget.NewEntry()
+			e.SetPlaceHolder("https://")
+			e.SetText(a.u.Settings().WebhookURL())
+			e.Validator = func(s string) error {
+				if s == "" {
+					return nil
+				}
+				u, err := url.Parse(s)
+				if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+					return errors.New("invalid URL")
+				}
+				return nil
+			}
+			e.OnChanged = func(s string) {
+				if e.Validate() != nil {
+					return
+				}
+				a.u.Settings().SetWebhookURL(s)
+			}
+			d := makeSettingDialog(makeSettingDialogParams{
+				setting:  e,
+				label:    it.Label,
+				hint:     it.Hint,
+				isMobile: a.u.IsMobile(),
+				reset: func() {
+					e.SetText("")
+				},
+				refresh: refresh,
+				window:  a.w,
+			})
+			d.Show()
+		},
+	})
+	webhookFormat := NewSettingItemOptions(SettingItemOptionsParams{
+		label:        "Webhook format",
+		hint:         "Format of the messages. 'Generic' sends plain JSON objects.",
+		options:      notificationsink.Formats(),
+		defaultValue: a.u.Settings().WebhookFormatDefault(),
+		getter:       a.u.Settings().WebhookFormat,
+		setter:       a.u.Settings().SetWebhookFormat,
+		isMobile:     a.u.IsMobile(),
+		window:       a.w,
+	})
+	webhookTypes := NewSettingItemCustom(SettingItemCustomParams{
+		label: "Webhook types",
+		hint:  "Choose which notifications to send to the webhook. None selected means all.",
+		getter: func() any {
+			n := a.u.Settings().WebhookTypes().Size()
+			if n == 0 {
+				return "All"
+			}
+			return fmt.Sprintf("%d selected", n)
+		},
+		onSelected: func(it SettingItem, refresh func()) {
+			a.showWebhookTypesDialog(it, refresh)
+		},
+	})
+	items = slices.Concat(items, []SettingItem{
+		NewSettingItemHeading("Webhook"),
+		webhookEnabled,
+		webhookURL,
+		webhookFormat,
+		webhookTypes,
+	})
+
 	list := newSettingList(items)
 	reset := settingAction{
 		Label: "Reset to defaults",
 		Action: func() {
+			webhookEnabled.Reset()
+			webhookFormat.Reset()
 			notifyCommunications.Reset()
 			notifyContracts.Reset()
 			notifyPI.Reset()
 			notifyTraining.Reset()
 			notifyMails.Reset()
+			notifyCalendar.Reset()
+			notifyCalendarLead.Reset()
+			notifyJumpFatigue.Reset()
+			notifyMarketUndercut.Reset()
+			notifyMarketOrders.Reset()
+			notifyIndustryJobs.Reset()
+			notifyStructures.Reset()
+			a.u.Settings().ResetNotifyStructureFuelHours()
+			notifyStructureTimer.Reset()
 			notifTimeout.Reset()
+			a.u.Settings().ResetNotificationRules()
+			quietHoursEnabled.Reset()
+			quietHoursStart.Reset()
+			quietHoursEnd.Reset()
 			typesEnabled.Clear()
 			a.u.Settings().ResetNotificationTypesEnabled()
 			list.Refresh()
@@ -715,7 +1365,368 @@ func (a *settings) makeNotificationPage() (fyne.CanvasObject, *kxwidget.IconButt
 			}()
 		},
 	}
-	return list, makeIconButtonFromActions([]settingAction{reset, all, none, send})
+	sendWebhook := settingAction{
+		Label: "Send test notification to webhook",
+		Action: func() {
+			go func() {
+				err := a.u.Webhook().SendTest(context.Background())
+				if err != nil {
+					slog.Warn("Failed to send test notification to webhook", "error", err)
+					a.sb.Show("Webhook test failed: " + a.u.ErrorDisplay(err))
+					return
+				}
+				a.sb.Show("Test notification sent to webhook")
+			}()
+		},
+	}
+	return list, makeIconButtonFromActions([]settingAction{reset, all, none, send, sendWebhook})
+}
+
+// showWebhookTypesDialog shows a dialog for choosing the notification types sent to the webhook.
+// It offers the notification types of the app and the enabled communication types.
+func (a *settings) showWebhookTypesDialog(it SettingItem, refresh func()) {
+	types := a.u.Settings().WebhookTypes()
+	makeItem := func(label, typ string) SettingItem {
+		return NewSettingItemSwitch(SettingItemSwitchParams{
+			label: label,
+			getter: func() bool {
+				return types.Contains(typ)
+			},
+			onChanged: func(on bool) {
+				if on {
+					types.Add(typ)
+				} else {
+					types.Delete(typ)
+				}
+				a.u.Settings().SetWebhookTypes(types)
+			},
+		})
+	}
+	items := []SettingItem{NewSettingItemHeading("Application")}
+	for _, typ := range notificationsink.AppTypes() {
+		items = append(items, makeItem(notificationsink.AppTypeDisplay(typ), typ))
+	}
+	var communications []app.EveNotificationType
+	for nt := range app.NotificationTypesSupported().All() {
+		if a.u.Settings().NotificationTypesEnabled().Contains(nt.String()) {
+			communications = append(communications, nt)
+		}
+	}
+	slices.Sort(communications)
+	if len(communications) > 0 {
+		items = append(items, NewSettingItemHeading("Communications"))
+		for _, nt := range communications {
+			items = append(items, makeItem(nt.Display(), nt.String()))
+		}
+	}
+	list := newSettingList(items)
+	hint := widget.NewLabel(it.Hint)
+	hint.SizeName = theme.SizeNameCaptionText
+	var d dialog.Dialog
+	buttons := container.NewHBox(
+		widget.NewButton("OK", func() {
+			d.Hide()
+		}),
+		layout.NewSpacer(),
+		widget.NewButton("Clear", func() {
+			types.Clear()
+			a.u.Settings().SetWebhookTypes(types)
+			list.Refresh()
+		}),
+	)
+	c := container.NewBorder(nil, container.NewVBox(hint, buttons), nil, nil, list)
+	d = dialog.NewCustomWithoutButtons(it.Label, c, a.w)
+	xdesktop.DisableShortcutsForDialog(d, a.w)
+	d.Show()
+	_, s := a.w.Canvas().InteractiveArea()
+	d.Resize(fyne.NewSize(s.Width*0.8, s.Height*0.8))
+	d.SetOnClosed(refresh)
+}
+
+// showStructureFuelDialog shows a dialog for choosing the fuel thresholds of structure notifications.
+func (a *settings) showStructureFuelDialog(it SettingItem, refresh func()) {
+	options := a.u.Settings().NotifyStructureFuelHoursOptions()
+	label2hours := make(map[string]int)
+	var labels []string
+	for _, h := range options {
+		l := formatFuelHours(h)
+		labels = append(labels, l)
+		label2hours[l] = h
+	}
+	hoursToLabels := func(hours []int) []string {
+		var s []string
+		for _, h := range hours {
+			s = append(s, formatFuelHours(h))
+		}
+		return s
+	}
+	checks := widget.NewCheckGroup(labels, func(selected []string) {
+		var hours []int
+		for _, l := range selected {
+			hours = append(hours, label2hours[l])
+		}
+		a.u.Settings().SetNotifyStructureFuelHours(hours)
+	})
+	checks.Selected = hoursToLabels(a.u.Settings().NotifyStructureFuelHours())
+	d := makeSettingDialog(makeSettingDialogParams{
+		setting:  checks,
+		label:    it.Label,
+		hint:     it.Hint,
+		isMobile: a.u.IsMobile(),
+		reset: func() {
+			checks.SetSelected(hoursToLabels(a.u.Settings().NotifyStructureFuelHoursDefault()))
+		},
+		refresh: refresh,
+		window:  a.w,
+	})
+	d.Show()
+}
+
+// formatFuelHours returns a fuel threshold in hours as user friendly text.
+func formatFuelHours(h int) string {
+	if h > 24 && h%24 == 0 {
+		return fmt.Sprintf("%d days", h/24)
+	}
+	return fmt.Sprintf("%d hours", h)
+}
+
+// formatHour returns an hour of the day as user friendly text.
+func formatHour(v any) string {
+	return fmt.Sprintf("%02d:00", int(v.(float64)))
+}
+
+// showNotificationRulesDialog shows a dialog for managing the notification rules.
+func (a *settings) showNotificationRulesDialog(it SettingItem, refresh func()) {
+	rules, err := notificationrule.ParseRules(a.u.Settings().NotificationRules())
+	if err != nil {
+		slog.Warn("Invalid notification rules. Using defaults", "error", err)
+		rules = notificationrule.DefaultRules()
+	}
+	save := func() {
+		s, err := notificationrule.FormatRules(rules)
+		if err != nil {
+			ui.ShowErrorAndLog("Failed to save notification rules", err, a.u.IsDeveloperMode(), a.w)
+			return
+		}
+		a.u.Settings().SetNotificationRules(s)
+	}
+	list := widget.NewList(
+		func() int {
+			return len(rules)
+		},
+		func() fyne.CanvasObject {
+			l := widget.NewLabel("Template")
+			l.Truncation = fyne.TextTruncateEllipsis
+			return l
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id >= len(rules) {
+				return
+			}
+			r := rules[id]
+			s := fmt.Sprintf("%s: %s", r.Action.Display(), r.Name)
+			if !r.Enabled {
+				s += " (disabled)"
+			}
+			co.(*widget.Label).SetText(s)
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		defer list.UnselectAll()
+		if id >= len(rules) {
+			return
+		}
+		a.showNotificationRuleDialog(rules[id], func(r notificationrule.Rule) {
+			rules[id] = r
+			save()
+			list.Refresh()
+		}, func() {
+			rules = slices.Delete(rules, id, id+1)
+			save()
+			list.Refresh()
+		})
+	}
+	hint := widget.NewLabel(it.Hint)
+	hint.SizeName = theme.SizeNameCaptionText
+	hint.Wrapping = fyne.TextWrapWord
+	var d dialog.Dialog
+	buttons := container.NewHBox(
+		widget.NewButton("OK", func() {
+			d.Hide()
+		}),
+		layout.NewSpacer(),
+		widget.NewButton("Add rule", func() {
+			r := notificationrule.Rule{Action: notificationrule.ActionMute, Enabled: true}
+			a.showNotificationRuleDialog(r, func(r notificationrule.Rule) {
+				rules = append(rules, r)
+				save()
+				list.Refresh()
+			}, nil)
+		}),
+		widget.NewButton("Reset", func() {
+			a.u.Settings().ResetNotificationRules()
+			rules = notificationrule.DefaultRules()
+			list.Refresh()
+		}),
+	)
+	c := container.NewBorder(nil, container.NewVBox(hint, buttons), nil, nil, list)
+	d = dialog.NewCustomWithoutButtons(it.Label, c, a.w)
+	xdesktop.DisableShortcutsForDialog(d, a.w)
+	d.Show()
+	_, s := a.w.Canvas().InteractiveArea()
+	d.Resize(fyne.NewSize(s.Width*0.8, s.Height*0.8))
+	d.SetOnClosed(refresh)
+}
+
+// showNotificationRuleDialog shows a dialog for editing a notification rule.
+// onDelete is optional and enables deleting the rule.
+func (a *settings) showNotificationRuleDialog(r notificationrule.Rule, onSave func(notificationrule.Rule), onDelete func()) {
+	ctx := context.Background()
+	characterNames, err := a.u.Character().CharacterNames(ctx)
+	if err != nil {
+		ui.ShowErrorAndLog("Failed to load characters", err, a.u.IsDeveloperMode(), a.w)
+		return
+	}
+	tags, err := a.u.Character().ListTagsByName(ctx)
+	if err != nil {
+		ui.ShowErrorAndLog("Failed to load tags", err, a.u.IsDeveloperMode(), a.w)
+		return
+	}
+
+	name := widget.NewEntry()
+	name.SetText(r.Name)
+	name.Validator = func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return errors.New("can not be empty")
+		}
+		return nil
+	}
+	action2label := make(map[notificationrule.Action]string)
+	label2action := make(map[string]notificationrule.Action)
+	var actionLabels []string
+	for _, x := range notificationrule.Actions() {
+		l := x.Display()
+		actionLabels = append(actionLabels, l)
+		action2label[x] = l
+		label2action[l] = x
+	}
+	action := widget.NewRadioGroup(actionLabels, nil)
+	action.Horizontal = true
+	action.Required = true
+	action.Selected = action2label[r.Action]
+	enabled := widget.NewCheck("", nil)
+	enabled.Checked = r.Enabled
+
+	var typeLabels []string
+	label2type := make(map[string]string)
+	for _, typ := range notificationsink.AppTypes() {
+		l := notificationsink.AppTypeDisplay(typ)
+		typeLabels = append(typeLabels, l)
+		label2type[l] = typ
+	}
+	var communications []app.EveNotificationType
+	for nt := range app.NotificationTypesSupported().All() {
+		communications = append(communications, nt)
+	}
+	slices.Sort(communications)
+	for _, nt := range communications {
+		l := nt.Display()
+		typeLabels = append(typeLabels, l)
+		label2type[l] = nt.String()
+	}
+	types, selectedTypes := newValueCheckGroup(typeLabels, label2type, r.Types)
+
+	var characterLabels []string
+	label2character := make(map[string]int64)
+	for id, n := range characterNames {
+		characterLabels = append(characterLabels, n)
+		label2character[n] = id
+	}
+	slices.Sort(characterLabels)
+	characters, selectedCharacters := newValueCheckGroup(characterLabels, label2character, r.CharacterIDs)
+
+	var tagLabels []string
+	label2tag := make(map[string]int64)
+	for _, t := range tags {
+		tagLabels = append(tagLabels, t.Name)
+		label2tag[t.Name] = t.ID
+	}
+	tagChecks, selectedTags := newValueCheckGroup(tagLabels, label2tag, r.TagIDs)
+
+	form := widget.NewForm(
+		widget.NewFormItem("Name", name),
+		widget.NewFormItem("Action", action),
+		widget.NewFormItem("Enabled", enabled),
+	)
+	filters := container.NewAppTabs(
+		container.NewTabItem("Types", container.NewVScroll(types)),
+		container.NewTabItem("Characters", container.NewVScroll(characters)),
+		container.NewTabItem("Tags", container.NewVScroll(tagChecks)),
+	)
+	hint := widget.NewLabel("A rule matches notifications of the selected types about the selected characters or characters with the selected tags. Nothing selected matches all.")
+	hint.SizeName = theme.SizeNameCaptionText
+	hint.Wrapping = fyne.TextWrapWord
+
+	var d dialog.Dialog
+	buttons := container.NewHBox(
+		widget.NewButton("Cancel", func() {
+			d.Hide()
+		}),
+		layout.NewSpacer(),
+	)
+	if onDelete != nil {
+		buttons.Add(widget.NewButton("Delete", func() {
+			dialog.ShowConfirm("Delete rule", fmt.Sprintf("Are you sure you want to delete the rule %s?", r.Name), func(confirmed bool) {
+				if !confirmed {
+					return
+				}
+				onDelete()
+				d.Hide()
+			}, a.w)
+		}))
+	}
+	save := widget.NewButton("Save", func() {
+		if err := name.Validate(); err != nil {
+			return
+		}
+		onSave(notificationrule.Rule{
+			Action:       label2action[action.Selected],
+			CharacterIDs: selectedCharacters(),
+			Enabled:      enabled.Checked,
+			Name:         strings.TrimSpace(name.Text),
+			TagIDs:       selectedTags(),
+			Types:        selectedTypes(),
+		})
+		d.Hide()
+	})
+	save.Importance = widget.HighImportance
+	buttons.Add(save)
+	c := container.NewBorder(form, container.NewVBox(hint, buttons), nil, nil, filters)
+	d = dialog.NewCustomWithoutButtons("Notification rule", c, a.w)
+	xdesktop.DisableShortcutsForDialog(d, a.w)
+	d.Show()
+	_, s := a.w.Canvas().InteractiveArea()
+	d.Resize(fyne.NewSize(s.Width*0.8, s.Height*0.8))
+}
+
+// newValueCheckGroup returns a check group for choosing values by their labels
+// and a function which returns the values currently selected.
+func newValueCheckGroup[T cmp.Ordered](labels []string, label2value map[string]T, selected []T) (*widget.CheckGroup, func() []T) {
+	cg := widget.NewCheckGroup(labels, nil)
+	for _, l := range labels {
+		if slices.Contains(selected, label2value[l]) {
+			cg.Selected = append(cg.Selected, l)
+		}
+	}
+	values := func() []T {
+		var s []T
+		for _, l := range cg.Selected {
+			s = append(s, label2value[l])
+		}
+		slices.Sort(s)
+		return s
+	}
+	return cg, values
 }
 
 // func (a *userSettings) reportError(text string, err error) {
@@ -0,0 +1,228 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app/tokenkey"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	"github.com/ErikKalkoken/evebuddy/internal/xdesktop"
+)
+
+// ShowUnlockTokensDialog shows a dialog for unlocking the tokens of characters with a passphrase.
+// This should be shown at startup when tokens are locked, because updates require valid tokens.
+func ShowUnlockTokensDialog(u baseUI) {
+	showUnlockTokensDialog(u, u.MainWindow(), func() {})
+}
+
+func showUnlockTokensDialog(u baseUI, w fyne.Window, onUnlocked func()) {
+	passphrase := widget.NewPasswordEntry()
+	items := []*widget.FormItem{
+		{
+			Text:     "Passphrase",
+			Widget:   passphrase,
+			HintText: "Required for updating characters from ESI",
+		},
+	}
+	d := dialog.NewForm("Unlock Tokens", "Unlock", "Later", items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		go func() {
+			err := u.TokenKey().Unlock(context.Background(), passphrase.Text)
+			fyne.Do(func() {
+				if errors.Is(err, tokenkey.ErrWrongPassphrase) {
+					d := dialog.NewInformation("Unlock Tokens", "Wrong passphrase. Please try again.", w)
+					d.SetOnClosed(func() {
+						showUnlockTokensDialog(u, w, onUnlocked)
+					})
+					xdesktop.DisableShortcutsForDialog(d, w)
+					d.Show()
+					return
+				}
+				if err != nil {
+					ui.ShowErrorAndLog("Failed to unlock tokens", err, u.IsDeveloperMode(), w)
+					return
+				}
+				onUnlocked()
+			})
+		}()
+	}, w)
+	xdesktop.DisableShortcutsForDialog(d, w)
+	d.Resize(fyne.NewSize(400, d.MinSize().Height))
+	d.Show()
+	w.Canvas().Focus(passphrase)
+}
+
+// makeTokenEncryptionItem returns a setting item for showing and changing the encryption of tokens.
+func (a *settings) makeTokenEncryptionItem() SettingItem {
+	tk := a.u.TokenKey()
+	return NewSettingItemCustom(SettingItemCustomParams{
+		label: "Token encryption",
+		hint:  "How the stored tokens of characters are protected",
+		getter: func() any {
+			switch tk.State() {
+			case tokenkey.Unencrypted:
+				return "Off - tokens stored unencrypted"
+			case tokenkey.Locked:
+				return "Locked"
+			}
+			switch tk.Source() {
+			case tokenkey.SourceKeyring:
+				return "Keyring"
+			case tokenkey.SourcePassphrase:
+				return "Passphrase"
+			}
+			return "?"
+		},
+		onSelected: func(it SettingItem, refresh func()) {
+			a.showTokenEncryptionDialog(refresh)
+		},
+	})
+}
+
+func (a *settings) showTokenEncryptionDialog(refresh func()) {
+	tk := a.u.TokenKey()
+	var d dialog.Dialog
+	var buttons []fyne.CanvasObject
+	addButton := func(label string, importance widget.Importance, action func()) {
+		b := widget.NewButton(label, func() {
+			d.Hide()
+			action()
+		})
+		b.Importance = importance
+		buttons = append(buttons, b)
+	}
+	var status string
+	switch tk.State() {
+	case tokenkey.Unencrypted:
+		status = "Tokens are stored unencrypted, because no keyring is available on this system. " +
+			"Set a passphrase to encrypt them."
+	case tokenkey.Locked:
+		status = "Tokens are encrypted, but the key is not available. " +
+			"Characters can not be updated until the tokens are unlocked. " +
+			"If the key is lost, reset the encryption and add all characters again."
+	case tokenkey.Unlocked:
+		if tk.Source() == tokenkey.SourcePassphrase {
+			status = "Tokens are encrypted with a key derived from your passphrase."
+		} else {
+			status = "Tokens are encrypted with a key stored in the keyring of your system."
+		}
+	}
+	if tk.State() == tokenkey.Locked {
+		if tk.Source() == tokenkey.SourcePassphrase {
+			addButton("Unlock", widget.HighImportance, func() {
+				showUnlockTokensDialog(a.u, a.w, func() {
+					refresh()
+					a.sb.Show("Tokens unlocked")
+				})
+			})
+		}
+	} else {
+		addButton("Set passphrase", widget.MediumImportance, func() {
+			a.showSetPassphraseDialog(refresh)
+		})
+		if tk.Source() == tokenkey.SourcePassphrase {
+			addButton("Use keyring", widget.MediumImportance, func() {
+				a.showUseKeyringDialog(refresh)
+			})
+		}
+	}
+	addButton("Reset", widget.DangerImportance, func() {
+		a.showResetTokenEncryptionDialog(refresh)
+	})
+	text := widget.NewLabel(status)
+	text.Wrapping = fyne.TextWrapWord
+	content := container.NewBorder(nil, container.NewHBox(buttons...), nil, nil, text)
+	d = dialog.NewCustom("Token Encryption", "Close", content, a.w)
+	xdesktop.DisableShortcutsForDialog(d, a.w)
+	d.Resize(fyne.NewSize(500, d.MinSize().Height))
+	d.Show()
+}
+
+func (a *settings) showSetPassphraseDialog(refresh func()) {
+	passphrase := widget.NewPasswordEntry()
+	passphrase.Validator = func(s string) error {
+		if len(s) < tokenkey.PassphraseMinSize {
+			return fmt.Errorf("must have at least %d characters", tokenkey.PassphraseMinSize)
+		}
+		return nil
+	}
+	confirm := widget.NewPasswordEntry()
+	confirm.Validator = func(s string) error {
+		if s != passphrase.Text {
+			return errors.New("does not match")
+		}
+		return nil
+	}
+	items := []*widget.FormItem{
+		{Text: "Passphrase", Widget: passphrase, HintText: "You need to enter it each time the app starts"},
+		{Text: "Confirm", Widget: confirm, HintText: "If you forget it, all characters must be added again"},
+	}
+	d := dialog.NewForm("Set Passphrase", "Set", "Cancel", items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		go func() {
+			err := a.u.TokenKey().SetPassphrase(context.Background(), passphrase.Text)
+			fyne.Do(func() {
+				if err != nil {
+					ui.ShowErrorAndLog("Failed to set passphrase", err, a.u.IsDeveloperMode(), a.w)
+					return
+				}
+				refresh()
+				a.sb.Show("Tokens encrypted with passphrase")
+			})
+		}()
+	}, a.w)
+	xdesktop.DisableShortcutsForDialog(d, a.w)
+	d.Resize(fyne.NewSize(400, d.MinSize().Height))
+	d.Show()
+}
+
+func (a *settings) showUseKeyringDialog(refresh func()) {
+	ui.ShowProgressConfirm(
+		"Use Keyring?",
+		"This will encrypt tokens with a key stored in the keyring of your system instead of your passphrase.",
+		"Use keyring",
+		widget.HighImportance,
+		func() {
+			err := a.u.TokenKey().UseKeyring(context.Background())
+			fyne.Do(func() {
+				if err != nil {
+					ui.ShowErrorAndLog("Failed to use keyring", err, a.u.IsDeveloperMode(), a.w)
+					return
+				}
+				refresh()
+				a.sb.Show("Tokens encrypted with key from keyring")
+			})
+		}, a.w,
+	)
+}
+
+func (a *settings) showResetTokenEncryptionDialog(refresh func()) {
+	ui.ShowProgressConfirm(
+		"Reset Token Encryption?",
+		"This will delete the tokens of all characters and set up encryption again. "+
+			"All characters need to be added again afterwards. Are you sure?",
+		"Reset",
+		widget.DangerImportance,
+		func() {
+			err := a.u.TokenKey().Reset(context.Background())
+			fyne.Do(func() {
+				if err != nil {
+					ui.ShowErrorAndLog("Failed to reset token encryption", err, a.u.IsDeveloperMode(), a.w)
+					return
+				}
+				refresh()
+				a.sb.Show("Token encryption reset")
+			})
+		}, a.w,
+	)
+}
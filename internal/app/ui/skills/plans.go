@@ -0,0 +1,538 @@
+package skills
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/ui"
+	ihumanize "github.com/ErikKalkoken/evebuddy/internal/humanize"
+	"github.com/ErikKalkoken/evebuddy/internal/xdesktop"
+	"github.com/ErikKalkoken/evebuddy/internal/xslices"
+	"github.com/ErikKalkoken/evebuddy/internal/xwidget"
+)
+
+// SkillPlans is a UI for managing the skill plans of a character.
+type SkillPlans struct {
+	widget.BaseWidget
+
+	addButton    *widget.Button
+	character    atomic.Pointer[app.Character]
+	copyButton   *widget.Button
+	deleteButton *widget.Button
+	entries      []app.SkillPlanEntry
+	list         *widget.List
+	newButton    *widget.Button
+	plans        []*app.CharacterSkillPlan
+	remap        *widget.Label
+	renameButton *widget.Button
+	selectPlan   *widget.Select
+	selectedID   atomic.Int64
+	summary      *widget.Label
+	top          *widget.Label
+	u            baseUI
+}
+
+func NewSkillPlans(u baseUI) *SkillPlans {
+	a := &SkillPlans{
+		remap:   ui.NewLabelWithWrapping(""),
+		summary: ui.NewLabelWithTruncation(""),
+		top:     ui.NewLabelWithWrapping(""),
+		u:       u,
+	}
+	a.ExtendBaseWidget(a)
+	a.list = a.makeList()
+
+	a.selectPlan = widget.NewSelect([]string{}, func(s string) {
+		for _, p := range a.plans {
+			if p.Name == s {
+				a.selectedID.Store(p.ID)
+				go a.update(context.Background())
+				return
+			}
+		}
+	})
+	a.selectPlan.PlaceHolder = "Select a plan"
+	a.newButton = widget.NewButtonWithIcon("New", theme.ContentAddIcon(), func() {
+		a.showPlanNameDialog("New Skill Plan", "Create", "", func(name string) error {
+			characterID := a.character.Load().IDOrZero()
+			if characterID == 0 {
+				return app.ErrInvalid
+			}
+			p, err := a.u.Character().CreateSkillPlan(context.Background(), characterID, name)
+			if err != nil {
+				return err
+			}
+			a.selectedID.Store(p.ID)
+			return nil
+		})
+	})
+	a.renameButton = widget.NewButtonWithIcon("Rename", theme.DocumentCreateIcon(), func() {
+		p := a.currentPlan()
+		if p == nil {
+			return
+		}
+		a.showPlanNameDialog("Rename Skill Plan", "Rename", p.Name, func(name string) error {
+			return a.u.Character().RenameSkillPlan(context.Background(), p.ID, name)
+		})
+	})
+	a.deleteButton = widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), func() {
+		p := a.currentPlan()
+		if p == nil {
+			return
+		}
+		ui.ShowConfirm(
+			"Delete Skill Plan",
+			fmt.Sprintf("Are you sure you want to delete the skill plan \"%s\"?", p.Name),
+			"Delete",
+			func(confirmed bool) {
+				if !confirmed {
+					return
+				}
+				if err := a.u.Character().DeleteSkillPlan(context.Background(), p.ID); err != nil {
+					ui.ShowErrorAndLog("Failed to delete skill plan", err, a.u.IsDeveloperMode(), a.u.MainWindow())
+					return
+				}
+				a.selectedID.Store(0)
+				go a.update(context.Background())
+			},
+			a.u.MainWindow(),
+		)
+	})
+	a.addButton = widget.NewButtonWithIcon("Add skill", theme.ContentAddIcon(), func() {
+		a.showAddSkillDialog()
+	})
+	a.copyButton = widget.NewButtonWithIcon("Copy", theme.ContentCopyIcon(), func() {
+		p := a.currentPlan()
+		if p == nil || len(p.Items) == 0 {
+			a.u.ShowSnackbar("No skills to copy")
+			return
+		}
+		fyne.CurrentApp().Clipboard().SetContent(p.Export())
+		a.u.ShowSnackbar("Skill plan copied to clipboard")
+	})
+	a.copyButton.Importance = widget.LowImportance
+
+	// Signals
+	a.u.Signals().CurrentCharacterExchanged.AddListener(func(ctx context.Context, c *app.Character) {
+		a.character.Store(c)
+		a.selectedID.Store(0)
+		a.update(ctx)
+	})
+	a.u.Signals().CharacterSectionChanged.AddListener(func(ctx context.Context, arg app.CharacterSectionUpdated) {
+		if a.character.Load().IDOrZero() != arg.CharacterID {
+			return
+		}
+		switch arg.Section {
+		case app.SectionCharacterAttributes, app.SectionCharacterImplants, app.SectionCharacterSkills:
+			a.update(ctx)
+		}
+	})
+	return a
+}
+
+func (a *SkillPlans) CreateRenderer() fyne.WidgetRenderer {
+	buttons := container.NewHBox(a.newButton, a.renameButton, a.deleteButton)
+	actions := container.NewHBox(a.addButton, layout.NewSpacer(), a.copyButton)
+	var topBox *fyne.Container
+	if a.u.IsMobile() {
+		topBox = container.NewVBox(
+			a.top,
+			a.selectPlan,
+			container.NewHScroll(buttons),
+			actions,
+			a.remap,
+		)
+	} else {
+		topBox = container.NewVBox(
+			a.top,
+			container.NewBorder(nil, nil, nil, buttons, a.selectPlan),
+			actions,
+			a.remap,
+		)
+	}
+	c := container.NewBorder(
+		topBox,
+		a.summary,
+		nil,
+		nil,
+		a.list,
+	)
+	return widget.NewSimpleRenderer(c)
+}
+
+func (a *SkillPlans) makeList() *widget.List {
+	l := widget.NewList(
+		func() int {
+			return len(a.entries)
+		},
+		func() fyne.CanvasObject {
+			name := widget.NewLabel("Template")
+			name.Truncation = fyne.TextTruncateEllipsis
+			return container.NewBorder(
+				nil,
+				nil,
+				nil,
+				container.NewHBox(
+					widget.NewLabel("Template"),
+					xwidget.NewIconButton(theme.DeleteIcon(), nil),
+				),
+				name,
+			)
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id >= len(a.entries) {
+				return
+			}
+			e := a.entries[id]
+			border := co.(*fyne.Container).Objects
+			name := border[0].(*widget.Label)
+			name.SetText(fmt.Sprintf("%s %s", e.Item.Type.Name, ihumanize.RomanLetter(e.Item.Level)))
+			box := border[1].(*fyne.Container).Objects
+			status := box[0].(*widget.Label)
+			status.Text, status.Importance = skillPlanEntryStatus(e)
+			status.Refresh()
+			remove := box[1].(*xwidget.IconButton)
+			remove.Icon.OnTapped = func() {
+				a.removeSkill(e.Item.Type.ID, e.Item.Level)
+			}
+			remove.SetToolTip("Remove from plan incl. dependent skills")
+		},
+	)
+	l.OnSelected = func(id widget.ListItemID) {
+		defer l.UnselectAll()
+		if id >= len(a.entries) {
+			return
+		}
+		e := a.entries[id]
+		a.u.InfoViewer().ShowType(e.Item.Type.ID, a.character.Load().IDOrZero())
+	}
+	return l
+}
+
+func (a *SkillPlans) currentPlan() *app.CharacterSkillPlan {
+	id := a.selectedID.Load()
+	for _, p := range a.plans {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+func (a *SkillPlans) removeSkill(typeID int64, level int) {
+	planID := a.selectedID.Load()
+	if planID == 0 {
+		return
+	}
+	go func() {
+		ctx := context.Background()
+		if err := a.u.Character().RemoveSkillFromPlan(ctx, planID, typeID, level); err != nil {
+			fyne.Do(func() {
+				ui.ShowErrorAndLog("Failed to remove skill from plan", err, a.u.IsDeveloperMode(), a.u.MainWindow())
+			})
+			return
+		}
+		a.update(ctx)
+	}()
+}
+
+func (a *SkillPlans) showPlanNameDialog(title, confirm, name string, execute func(name string) error) {
+	w := a.u.MainWindow()
+	names := make(map[string]bool)
+	for _, p := range a.plans {
+		names[strings.ToLower(p.Name)] = true
+	}
+	entry := widget.NewEntry()
+	entry.SetText(name)
+	entry.Validator = func(s string) error {
+		if len(s) == 0 {
+			return errors.New("can not be empty")
+		}
+		if s != name && names[strings.ToLower(s)] {
+			return errors.New("plan with same name already exists")
+		}
+		return nil
+	}
+	items := []*widget.FormItem{
+		widget.NewFormItem("Name", entry),
+	}
+	d := dialog.NewForm(
+		title, confirm, "Cancel", items, func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			if err := execute(entry.Text); err != nil {
+				ui.ShowErrorAndLog("Failed to save skill plan", err, a.u.IsDeveloperMode(), w)
+				return
+			}
+			go a.update(context.Background())
+		}, w,
+	)
+	xdesktop.DisableShortcutsForDialog(d, w)
+	d.Show()
+	d.Resize(fyne.NewSize(300, 200))
+	w.Canvas().Focus(entry)
+}
+
+func (a *SkillPlans) showAddSkillDialog() {
+	planID := a.selectedID.Load()
+	if planID == 0 {
+		return
+	}
+	w := a.u.MainWindow()
+	skills, err := a.u.EVEUniverse().ListSkills(context.Background())
+	if err != nil {
+		ui.ShowErrorAndLog("Failed to load skills", err, a.u.IsDeveloperMode(), w)
+		return
+	}
+	skills = slices.DeleteFunc(skills, func(x *app.EveSkill) bool {
+		return !x.Type.IsPublished
+	})
+	slices.SortFunc(skills, func(a, b *app.EveSkill) int {
+		return strings.Compare(a.Type.Name, b.Type.Name)
+	})
+	filtered := slices.Clone(skills)
+	var selected *app.EveSkill
+	selectedLabel := widget.NewLabel("No skill selected")
+	list := widget.NewList(
+		func() int {
+			return len(filtered)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Template")
+		},
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			if id >= len(filtered) {
+				return
+			}
+			co.(*widget.Label).SetText(filtered[id].Type.Name)
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		if id >= len(filtered) {
+			return
+		}
+		selected = filtered[id]
+		selectedLabel.SetText(selected.Type.Name)
+	}
+	search := xwidget.NewSearchEntry("Search skills", func(s string) {
+		s = strings.ToLower(s)
+		filtered = slices.DeleteFunc(slices.Clone(skills), func(x *app.EveSkill) bool {
+			return !strings.Contains(strings.ToLower(x.Type.Name), s)
+		})
+		list.UnselectAll()
+		list.Refresh()
+		list.ScrollToTop()
+	})
+	levels := make([]string, 5)
+	for i := range levels {
+		levels[i] = ihumanize.RomanLetter(i + 1)
+	}
+	selectLevel := widget.NewSelect(levels, nil)
+	selectLevel.SetSelectedIndex(len(levels) - 1)
+	c := container.NewBorder(
+		search,
+		container.NewBorder(nil, nil, nil, selectLevel, selectedLabel),
+		nil,
+		nil,
+		list,
+	)
+	d := dialog.NewCustomConfirm("Add Skill", "Add", "Cancel", c, func(confirmed bool) {
+		if !confirmed || selected == nil {
+			return
+		}
+		typeID := selected.Type.ID
+		level := selectLevel.SelectedIndex() + 1
+		go func() {
+			ctx := context.Background()
+			if err := a.u.Character().AddSkillToPlan(ctx, planID, typeID, level); err != nil {
+				fyne.Do(func() {
+					ui.ShowErrorAndLog("Failed to add skill to plan", err, a.u.IsDeveloperMode(), w)
+				})
+				return
+			}
+			a.update(ctx)
+		}()
+	}, w)
+	xdesktop.DisableShortcutsForDialog(d, w)
+	d.Show()
+	d.Resize(fyne.NewSize(400, 500))
+	w.Canvas().Focus(search)
+}
+
+func (a *SkillPlans) update(ctx context.Context) {
+	setTop := func(s string, i widget.Importance) {
+		fyne.Do(func() {
+			a.top.Text = s
+			a.top.Importance = i
+			a.top.Refresh()
+			a.top.Show()
+		})
+	}
+	reset := func() {
+		fyne.Do(func() {
+			a.plans = nil
+			xslices.Clear(&a.entries)
+			a.list.Refresh()
+			a.selectPlan.SetOptions([]string{})
+			a.selectPlan.ClearSelected()
+			a.remap.SetText("")
+			a.summary.SetText("")
+			a.setEnabled(false, false)
+		})
+	}
+	reportError := func(err error) {
+		slog.Error("Failed to update data for skill plans UI", "error", err)
+		setTop(a.u.ErrorDisplay(err), widget.DangerImportance)
+	}
+
+	characterID := a.character.Load().IDOrZero()
+	if characterID == 0 {
+		reset()
+		setTop("No character", widget.LowImportance)
+		return
+	}
+	plans, err := a.u.Character().ListSkillPlans(ctx, characterID)
+	if err != nil {
+		reset()
+		reportError(err)
+		return
+	}
+	var current *app.CharacterSkillPlan
+	selectedID := a.selectedID.Load()
+	for _, p := range plans {
+		if p.ID == selectedID {
+			current = p
+			break
+		}
+	}
+	if current == nil && len(plans) > 0 {
+		current = plans[0]
+		a.selectedID.Store(current.ID)
+	}
+	var estimate app.SkillPlanEstimate
+	if current != nil {
+		estimate, err = a.u.Character().EstimateSkillPlan(ctx, current)
+		if err != nil {
+			reset()
+			reportError(err)
+			return
+		}
+	}
+	summary, remap := makeSkillPlanSummary(estimate)
+	names := xslices.Map(plans, func(x *app.CharacterSkillPlan) string {
+		return x.Name
+	})
+	fyne.Do(func() {
+		a.plans = plans
+		a.entries = estimate.Entries
+		a.list.Refresh()
+		a.selectPlan.SetOptions(names)
+		if current != nil {
+			a.selectPlan.Selected = current.Name
+			a.selectPlan.Refresh()
+			a.summary.SetText(summary)
+			a.remap.SetText(remap)
+		} else {
+			a.selectPlan.ClearSelected()
+			a.summary.SetText("")
+			a.remap.SetText("")
+		}
+		a.setEnabled(true, current != nil)
+		if len(plans) == 0 {
+			a.top.Text = "No skill plans. Create a new plan to get started."
+			a.top.Importance = widget.LowImportance
+			a.top.Refresh()
+			a.top.Show()
+		} else {
+			a.top.Hide()
+		}
+	})
+}
+
+func (a *SkillPlans) setEnabled(hasCharacter, hasPlan bool) {
+	if hasCharacter {
+		a.newButton.Enable()
+		a.selectPlan.Enable()
+	} else {
+		a.newButton.Disable()
+		a.selectPlan.Disable()
+	}
+	for _, b := range []*widget.Button{a.renameButton, a.deleteButton, a.addButton, a.copyButton} {
+		if hasPlan {
+			b.Enable()
+		} else {
+			b.Disable()
+		}
+	}
+}
+
+// skillPlanEntryStatus returns the status text for an entry of a skill plan.
+func skillPlanEntryStatus(e app.SkillPlanEntry) (string, widget.Importance) {
+	if e.IsTrained {
+		return "Trained", widget.SuccessImportance
+	}
+	return e.TrainingTime.StringFunc("?", ihumanize.Duration), widget.MediumImportance
+}
+
+// formatTrainingAttributes returns a short description of training attributes.
+func formatTrainingAttributes(ta app.TrainingAttributes) string {
+	return fmt.Sprintf(
+		"Int %d • Mem %d • Per %d • Wil %d • Cha %d",
+		ta.Intelligence,
+		ta.Memory,
+		ta.Perception,
+		ta.Willpower,
+		ta.Charisma,
+	)
+}
+
+// makeSkillPlanSummary returns the texts for the summary and the remap suggestion of a skill plan.
+func makeSkillPlanSummary(e app.SkillPlanEstimate) (summary string, remap string) {
+	var count int
+	for _, x := range e.Entries {
+		if !x.IsTrained {
+			count++
+		}
+	}
+	if count == 0 {
+		return "Nothing to train", ""
+	}
+	total := e.TrainingTime()
+	summary = fmt.Sprintf(
+		"Skill levels to train: %d • %s",
+		count,
+		total.StringFunc("?", ihumanize.Duration),
+	)
+	v, ok := e.Remap.Value()
+	if !ok {
+		return summary, ""
+	}
+	remap = fmt.Sprintf("Optimal remap: %s", formatTrainingAttributes(v))
+	remapTime, ok := e.RemapTrainingTime.Value()
+	if !ok {
+		return summary, remap
+	}
+	remap += " • " + ihumanize.Duration(remapTime)
+	if d, ok := total.Value(); ok {
+		if saved := d - remapTime; saved > 0 {
+			remap += fmt.Sprintf(" (saves %s)", ihumanize.Duration(saved))
+		} else {
+			remap = "Current attributes are optimal for this plan"
+		}
+	}
+	return summary, remap
+}
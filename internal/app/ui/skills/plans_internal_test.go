@@ -0,0 +1,56 @@
+package skills
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/optional"
+)
+
+func TestMakeSkillPlanSummary(t *testing.T) {
+	remap := app.TrainingAttributes{
+		Charisma:     17,
+		Intelligence: 27,
+		Memory:       21,
+		Perception:   17,
+		Willpower:    17,
+	}
+	t.Run("should show total time and savings from remap", func(t *testing.T) {
+		e := app.SkillPlanEstimate{
+			Entries: []app.SkillPlanEntry{
+				{IsTrained: true},
+				{TrainingTime: optional.New(2 * time.Hour)},
+				{TrainingTime: optional.New(3 * time.Hour)},
+			},
+			Remap:             optional.New(remap),
+			RemapTrainingTime: optional.New(4 * time.Hour),
+		}
+		summary, remapText := makeSkillPlanSummary(e)
+		assert.Equal(t, "Skill levels to train: 2 • 5h 0m", summary)
+		assert.Equal(t, "Optimal remap: Int 27 • Mem 21 • Per 17 • Wil 17 • Cha 17 • 4h 0m (saves 1h 0m)", remapText)
+	})
+	t.Run("should report when attributes are already optimal", func(t *testing.T) {
+		e := app.SkillPlanEstimate{
+			Entries:           []app.SkillPlanEntry{{TrainingTime: optional.New(2 * time.Hour)}},
+			Remap:             optional.New(remap),
+			RemapTrainingTime: optional.New(2 * time.Hour),
+		}
+		_, remapText := makeSkillPlanSummary(e)
+		assert.Equal(t, "Current attributes are optimal for this plan", remapText)
+	})
+	t.Run("should show unknown training time", func(t *testing.T) {
+		e := app.SkillPlanEstimate{
+			Entries: []app.SkillPlanEntry{{}},
+		}
+		summary, remapText := makeSkillPlanSummary(e)
+		assert.Equal(t, "Skill levels to train: 1 • ?", summary)
+		assert.Equal(t, "", remapText)
+	})
+	t.Run("should report when nothing to train", func(t *testing.T) {
+		summary, _ := makeSkillPlanSummary(app.SkillPlanEstimate{})
+		assert.Equal(t, "Nothing to train", summary)
+	})
+}
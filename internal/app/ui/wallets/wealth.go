@@ -5,13 +5,16 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
 	"slices"
 	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	kxwidget "github.com/ErikKalkoken/fyne-kx/widget"
 	"github.com/s-daehling/fyne-charts/pkg/coord"
 	"github.com/s-daehling/fyne-charts/pkg/data"
 	"github.com/s-daehling/fyne-charts/pkg/prop"
@@ -30,6 +33,18 @@ const (
 	wealthNameTruncationSuffix = 2
 )
 
+const (
+	wealthPeriod30Days  = "30 days"
+	wealthPeriod90Days  = "90 days"
+	wealthPeriod365Days = "365 days"
+)
+
+var wealthPeriods = map[string]int{
+	wealthPeriod30Days:  30,
+	wealthPeriod90Days:  90,
+	wealthPeriod365Days: 365,
+}
+
 type wealthRow struct {
 	characterID     int64
 	characterName   string
@@ -47,6 +62,8 @@ type Wealth struct {
 
 	assetDetail          *coord.CartesianCategoricalChart
 	characterSplit       *prop.PieChart
+	history              *coord.CartesianTemporalChart
+	selectPeriod         *kxwidget.FilterChipSelect
 	top                  *widget.Label
 	totalSplit           *prop.PieChart
 	u                    baseUI
@@ -60,6 +77,7 @@ func NewWealth(u baseUI) *Wealth {
 	a := &Wealth{
 		assetDetail:    coord.NewCartesianCategoricalChart(""),
 		characterSplit: prop.NewPieChart(""),
+		history:        coord.NewCartesianTemporalChart(""),
 		top:            ui.NewLabelWithWrapping(""),
 		totalSplit:     prop.NewPieChart(""),
 		u:              u,
@@ -69,6 +87,16 @@ func NewWealth(u baseUI) *Wealth {
 	a.ExtendBaseWidget(a)
 	a.top.Hide()
 
+	a.selectPeriod = kxwidget.NewFilterChipSelect("", []string{
+		wealthPeriod30Days,
+		wealthPeriod90Days,
+		wealthPeriod365Days,
+	}, func(string) {
+		go a.updateHistory(context.Background())
+	})
+	a.selectPeriod.Selected = wealthPeriod30Days
+	a.selectPeriod.SortDisabled = true
+
 	ts := style.DefaultTitleStyle()
 	ts.SizeName = theme.SizeNameText
 	ts.TextStyle.Bold = true
@@ -83,6 +111,9 @@ func NewWealth(u baseUI) *Wealth {
 	a.walletDetail.HideLegend()
 	a.walletDetail.SetYAxisStyle(yls, style.DefaultAxisStyle())
 	a.walletDetail.SetYAxisLabel("B ISK")
+	a.history.SetTitleStyle(ts)
+	a.history.SetYAxisStyle(yls, style.DefaultAxisStyle())
+	a.history.SetYAxisLabel("B ISK")
 	a.totalSplit.SetTitleStyle(ts)
 	a.characterSplit.SetTitleStyle(ts)
 
@@ -131,6 +162,10 @@ func (a *Wealth) CreateRenderer() fyne.WidgetRenderer {
 		),
 		container.NewTabItem("Assets", a.assetDetail),
 		container.NewTabItem("Wallets", a.walletDetail),
+		container.NewTabItem(
+			"History",
+			container.NewBorder(container.NewHBox(a.selectPeriod), nil, nil, nil, a.history),
+		),
 	)
 	var c fyne.CanvasObject
 	if !a.u.IsMobile() {
@@ -177,6 +212,7 @@ func (a *Wealth) update(ctx context.Context) {
 	a.updateCharacterSplit(ctx, rows)
 	a.updateTotalSplit(ctx, rows)
 	a.updateWalletDetail(ctx, rows)
+	a.updateHistory(ctx)
 
 	fyne.Do(func() {
 		if a.OnUpdate != nil {
@@ -318,6 +354,91 @@ func (a *Wealth) updateWalletDetail(_ context.Context, rows []wealthRow) {
 	})
 }
 
+func (a *Wealth) updateHistory(ctx context.Context) {
+	var period string
+	fyne.DoAndWait(func() {
+		period = a.selectPeriod.Selected
+	})
+	days, ok := wealthPeriods[period]
+	if !ok {
+		days = wealthPeriods[wealthPeriod30Days]
+	}
+	since := time.Now().AddDate(0, 0, -days)
+	characters, err := a.u.Character().ListWealthSnapshots(ctx, since)
+	if err != nil {
+		slog.Error("wealth: history", "error", err)
+		return
+	}
+	var corporations []*app.CorporationWalletSnapshot
+	corporationIDs, err := a.u.Corporation().ListCorporationIDs(ctx)
+	if err != nil {
+		slog.Error("wealth: history", "error", err)
+		return
+	}
+	for id := range corporationIDs.All() {
+		oo, err := a.u.Corporation().ListWalletSnapshots(ctx, id, since)
+		if err != nil {
+			slog.Error("wealth: history", "corporationID", id, "error", err)
+			return
+		}
+		corporations = append(corporations, oo...)
+	}
+	characterPoints, corporationPoints := makeWealthHistory(characters, corporations)
+	fyne.Do(func() {
+		colors := newColorWheel()
+		for _, x := range []struct {
+			name   string
+			points []data.TemporalPoint
+		}{
+			{"Characters", characterPoints},
+			{"Corporations", corporationPoints},
+		} {
+			a.history.RemoveSeries(x.name)
+			c := colors.next()
+			if len(x.points) == 0 {
+				continue
+			}
+			s, err := coord.NewTemporalPointSeries(x.name, c, x.points)
+			if err != nil {
+				slog.Error("wealth: history", "error", err)
+				return
+			}
+			err = a.history.AddLineSeries(s)
+			if err != nil {
+				slog.Error("wealth: history", "error", err)
+				return
+			}
+		}
+		a.history.SetTitle(fmt.Sprintf("Net Worth History - Last %s", period))
+	})
+}
+
+// makeWealthHistory returns the daily totals in B ISK for characters and corporation wallets
+// as points ordered by day.
+func makeWealthHistory(characters []*app.CharacterWealthSnapshot, corporations []*app.CorporationWalletSnapshot) ([]data.TemporalPoint, []data.TemporalPoint) {
+	makePoints := func(m map[time.Time]float64) []data.TemporalPoint {
+		var points []data.TemporalPoint
+		for _, day := range slices.SortedFunc(maps.Keys(m), func(a, b time.Time) int {
+			return a.Compare(b)
+		}) {
+			points = append(points, data.TemporalPoint{
+				T:   day,
+				Val: m[day] / wealthMultiplier,
+			})
+		}
+		return points
+	}
+	m1 := make(map[time.Time]float64)
+	for _, x := range characters {
+		m1[x.Day] += x.Total()
+	}
+	m2 := make(map[time.Time]float64)
+	for _, x := range corporations {
+		m2[x.Day] += x.Balance
+	}
+	return makePoints(m1), makePoints(m2)
+}
+
 func reduceProportionalPoints(rows []data.ProportionalPoint, m int) []data.ProportionalPoint {
 	if len(rows) <= m {
 		return rows
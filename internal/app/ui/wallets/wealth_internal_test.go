@@ -2,11 +2,13 @@ package wallets
 
 import (
 	"testing"
+	"time"
 
 	chartData "github.com/s-daehling/fyne-charts/pkg/data"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ErikKalkoken/evebuddy/internal/app"
 	"github.com/ErikKalkoken/evebuddy/internal/xassert"
 )
 
@@ -124,3 +126,27 @@ func TestReduceCategoricalPoints(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeWealthHistory(t *testing.T) {
+	day1 := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)
+	t.Run("should sum up totals per day ordered by day", func(t *testing.T) {
+		characters := []*app.CharacterWealthSnapshot{
+			{CharacterID: 1, Day: day2, WalletBalance: 3 * wealthMultiplier},
+			{CharacterID: 1, Day: day1, WalletBalance: 1 * wealthMultiplier},
+			{CharacterID: 2, Day: day1, AssetsValue: 2 * wealthMultiplier},
+		}
+		corporations := []*app.CorporationWalletSnapshot{
+			{CorporationID: 1, Day: day1, DivisionID: 1, Balance: 4 * wealthMultiplier},
+			{CorporationID: 1, Day: day1, DivisionID: 2, Balance: 5 * wealthMultiplier},
+		}
+		got1, got2 := makeWealthHistory(characters, corporations)
+		xassert.Equal(t, []chartData.TemporalPoint{{T: day1, Val: 3}, {T: day2, Val: 3}}, got1)
+		xassert.Equal(t, []chartData.TemporalPoint{{T: day1, Val: 9}}, got2)
+	})
+	t.Run("should return empty when no snapshots", func(t *testing.T) {
+		got1, got2 := makeWealthHistory(nil, nil)
+		assert.Empty(t, got1)
+		assert.Empty(t, got2)
+	})
+}
@@ -0,0 +1,31 @@
+package app
+
+import "time"
+
+// CharacterWealthSnapshot represents the wealth of a character on a given day.
+type CharacterWealthSnapshot struct {
+	AssetsValue     float64
+	CharacterID     int64
+	ContractsEscrow float64
+	Day             time.Time
+	OrdersEscrow    float64
+	WalletBalance   float64
+}
+
+// Total returns the total wealth of a character.
+func (x CharacterWealthSnapshot) Total() float64 {
+	return x.AssetsValue + x.ContractsEscrow + x.OrdersEscrow + x.WalletBalance
+}
+
+// CorporationWalletSnapshot represents the balance of a corporation wallet division on a given day.
+type CorporationWalletSnapshot struct {
+	Balance       float64
+	CorporationID int64
+	Day           time.Time
+	DivisionID    int64
+}
+
+// SnapshotDay returns the day for a snapshot taken at t, i.e. midnight UTC.
+func SnapshotDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,43 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestCharacterWealthSnapshot_Total(t *testing.T) {
+	x := app.CharacterWealthSnapshot{
+		AssetsValue:     1,
+		ContractsEscrow: 2,
+		OrdersEscrow:    3,
+		WalletBalance:   4,
+	}
+	xassert.Equal(t, 10.0, x.Total())
+}
+
+func TestSnapshotDay(t *testing.T) {
+	cases := []struct {
+		name string
+		in   time.Time
+		want time.Time
+	}{
+		{
+			"UTC",
+			time.Date(2025, 3, 14, 17, 5, 0, 0, time.UTC),
+			time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			"other timezone",
+			time.Date(2025, 3, 15, 1, 5, 0, 0, time.FixedZone("X", 3*3600)),
+			time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			xassert.Equal(t, tc.want, app.SnapshotDay(tc.in))
+		})
+	}
+}
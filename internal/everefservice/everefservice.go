@@ -0,0 +1,136 @@
+// Package everefservice provides a service for accessing the reference data API of EVE Ref.
+//
+// The reference data is derived from the static data export (SDE) of EVE Online
+// and contains information not available from ESI, e.g. the materials of blueprints.
+package everefservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ErikKalkoken/evebuddy/internal/xsync"
+)
+
+const (
+	timeout = 10 * time.Second
+	baseURL = "https://ref-data.everef.net"
+)
+
+// Activities of blueprints.
+const (
+	ActivityManufacturing = "manufacturing"
+	ActivityReaction      = "reaction"
+)
+
+var (
+	ErrHTTPError = errors.New("HTTP error")
+	ErrNotFound  = errors.New("not found")
+)
+
+// EveRefService is a service for fetching reference data from EVE Ref.
+// Responses are cached in memory, because the reference data rarely changes.
+type EveRefService struct {
+	blueprints xsync.Map[int64, Blueprint]
+	httpClient *http.Client
+	schematics xsync.Map[int64, Schematic]
+}
+
+func New(httpClient *http.Client) *EveRefService {
+	if httpClient == nil {
+		panic("need HTTP client")
+	}
+	s := &EveRefService{
+		httpClient: httpClient,
+	}
+	return s
+}
+
+// Blueprint represents a blueprint from the reference data.
+type Blueprint struct {
+	Activities         map[string]BlueprintActivity `json:"activities"`
+	BlueprintTypeID    int64                        `json:"blueprint_type_id"`
+	MaxProductionLimit int64                        `json:"max_production_limit"`
+}
+
+// BlueprintActivity represents an activity of a blueprint, e.g. manufacturing.
+type BlueprintActivity struct {
+	Materials map[string]TypeQuantity `json:"materials"`
+	Products  map[string]TypeQuantity `json:"products"`
+	Time      int64                   `json:"time"` // in seconds
+}
+
+// TypeQuantity represents a quantity of a type used or produced by an activity or schematic.
+type TypeQuantity struct {
+	Quantity int64 `json:"quantity"`
+	TypeID   int64 `json:"type_id"`
+}
+
+// FetchBlueprint returns the blueprint for a blueprint type.
+// Returns [ErrNotFound] when the type is not a blueprint.
+func (s *EveRefService) FetchBlueprint(ctx context.Context, blueprintTypeID int64) (Blueprint, error) {
+	var bp Blueprint
+	if blueprintTypeID <= 0 {
+		return bp, errors.New("invalid blueprintTypeID")
+	}
+	if bp, ok := s.blueprints.Load(blueprintTypeID); ok {
+		return bp, nil
+	}
+	if err := s.fetch(ctx, fmt.Sprintf("%s/blueprints/%d", baseURL, blueprintTypeID), &bp); err != nil {
+		return bp, fmt.Errorf("fetch blueprint %d: %w", blueprintTypeID, err)
+	}
+	s.blueprints.Store(blueprintTypeID, bp)
+	return bp, nil
+}
+
+// Schematic represents a schematic for planetary industry from the reference data.
+type Schematic struct {
+	CycleTime   int64                   `json:"cycle_time"` // in seconds
+	Materials   map[string]TypeQuantity `json:"materials"`
+	Products    map[string]TypeQuantity `json:"products"`
+	SchematicID int64                   `json:"schematic_id"`
+}
+
+// FetchSchematic returns a schematic for planetary industry.
+// Returns [ErrNotFound] when the schematic does not exist.
+func (s *EveRefService) FetchSchematic(ctx context.Context, schematicID int64) (Schematic, error) {
+	var o Schematic
+	if schematicID <= 0 {
+		return o, errors.New("invalid schematicID")
+	}
+	if o, ok := s.schematics.Load(schematicID); ok {
+		return o, nil
+	}
+	if err := s.fetch(ctx, fmt.Sprintf("%s/schematics/%d", baseURL, schematicID), &o); err != nil {
+		return o, fmt.Errorf("fetch schematic %d: %w", schematicID, err)
+	}
+	s.schematics.Store(schematicID, o)
+	return o, nil
+}
+
+func (s *EveRefService) fetch(ctx context.Context, url string, v any) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("accept", "application/json")
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	if r.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if r.StatusCode >= 400 {
+		slog.Warn("Error response from EVE Ref", "url", url, "status", r.Status)
+		return fmt.Errorf("%s: %w", r.Status, ErrHTTPError)
+	}
+	return json.NewDecoder(r.Body).Decode(v)
+}
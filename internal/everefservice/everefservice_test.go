@@ -0,0 +1,136 @@
+package everefservice_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ErikKalkoken/evebuddy/internal/everefservice"
+	"github.com/ErikKalkoken/evebuddy/internal/xassert"
+)
+
+func TestService(t *testing.T) {
+	t.Run("should panic when trying to create without client", func(t *testing.T) {
+		assert.Panics(t, func() {
+			everefservice.New(nil)
+		})
+	})
+}
+
+func TestFetchBlueprint(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	t.Run("should return blueprint and cache it", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder(
+			"GET",
+			"https://ref-data.everef.net/blueprints/691",
+			httpmock.NewJsonResponderOrPanic(200, map[string]any{
+				"activities": map[string]any{
+					"manufacturing": map[string]any{
+						"materials": map[string]any{
+							"34": map[string]any{"quantity": 32000, "type_id": 34},
+							"35": map[string]any{"quantity": 6000, "type_id": 35},
+						},
+						"products": map[string]any{
+							"587": map[string]any{"quantity": 1, "type_id": 587},
+						},
+						"time": 6000,
+					},
+				},
+				"blueprint_type_id":    691,
+				"max_production_limit": 30,
+			}),
+		)
+		s := everefservice.New(http.DefaultClient)
+		// when
+		bp, err := s.FetchBlueprint(t.Context(), 691)
+		require.NoError(t, err)
+		_, err = s.FetchBlueprint(t.Context(), 691)
+		require.NoError(t, err)
+		// then
+		xassert.Equal(t, 691, bp.BlueprintTypeID)
+		xassert.Equal(t, 30, bp.MaxProductionLimit)
+		a := bp.Activities[everefservice.ActivityManufacturing]
+		xassert.Equal(t, 6000, a.Time)
+		xassert.Equal(t, everefservice.TypeQuantity{Quantity: 32000, TypeID: 34}, a.Materials["34"])
+		xassert.Equal(t, everefservice.TypeQuantity{Quantity: 1, TypeID: 587}, a.Products["587"])
+		xassert.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+	t.Run("should return not found error", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder(
+			"GET",
+			"https://ref-data.everef.net/blueprints/34",
+			httpmock.NewStringResponder(404, "not found"),
+		)
+		s := everefservice.New(http.DefaultClient)
+		// when
+		_, err := s.FetchBlueprint(t.Context(), 34)
+		// then
+		assert.ErrorIs(t, err, everefservice.ErrNotFound)
+	})
+	t.Run("should return error for other HTTP errors", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder(
+			"GET",
+			"https://ref-data.everef.net/blueprints/691",
+			httpmock.NewStringResponder(500, "internal error"),
+		)
+		s := everefservice.New(http.DefaultClient)
+		// when
+		_, err := s.FetchBlueprint(t.Context(), 691)
+		// then
+		assert.ErrorIs(t, err, everefservice.ErrHTTPError)
+	})
+}
+
+func TestFetchSchematic(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	t.Run("should return schematic and cache it", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder(
+			"GET",
+			"https://ref-data.everef.net/schematics/121",
+			httpmock.NewJsonResponderOrPanic(200, map[string]any{
+				"cycle_time": 1800,
+				"materials": map[string]any{
+					"2268": map[string]any{"quantity": 3000, "type_id": 2268},
+				},
+				"products": map[string]any{
+					"3645": map[string]any{"quantity": 20, "type_id": 3645},
+				},
+				"schematic_id": 121,
+			}),
+		)
+		s := everefservice.New(http.DefaultClient)
+		// when
+		o, err := s.FetchSchematic(t.Context(), 121)
+		require.NoError(t, err)
+		_, err = s.FetchSchematic(t.Context(), 121)
+		require.NoError(t, err)
+		// then
+		xassert.Equal(t, 121, o.SchematicID)
+		xassert.Equal(t, 1800, o.CycleTime)
+		xassert.Equal(t, everefservice.TypeQuantity{Quantity: 3000, TypeID: 2268}, o.Materials["2268"])
+		xassert.Equal(t, everefservice.TypeQuantity{Quantity: 20, TypeID: 3645}, o.Products["3645"])
+		xassert.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+	t.Run("should return not found error", func(t *testing.T) {
+		httpmock.Reset()
+		httpmock.RegisterResponder(
+			"GET",
+			"https://ref-data.everef.net/schematics/1",
+			httpmock.NewStringResponder(404, "not found"),
+		)
+		s := everefservice.New(http.DefaultClient)
+		// when
+		_, err := s.FetchSchematic(t.Context(), 1)
+		// then
+		assert.ErrorIs(t, err, everefservice.ErrNotFound)
+	})
+}
@@ -0,0 +1,31 @@
+// Package keyring provides access to the secret store of the operating system,
+// e.g. the Keychain on macOS, the Credential Manager on Windows
+// and the Secret Service on Linux.
+package keyring
+
+import "errors"
+
+var (
+	ErrNotFound    = errors.New("keyring: secret not found")
+	ErrUnsupported = errors.New("keyring: not supported on this system")
+)
+
+// Get returns the secret for a service and user.
+// It returns [ErrNotFound] when no such secret exists
+// and [ErrUnsupported] when there is no keyring on this system.
+func Get(service, user string) (string, error) {
+	return get(service, user)
+}
+
+// Set creates or updates the secret for a service and user.
+// It returns [ErrUnsupported] when there is no keyring on this system.
+func Set(service, user, secret string) error {
+	return set(service, user, secret)
+}
+
+// Delete deletes the secret for a service and user.
+// It returns [ErrNotFound] when no such secret exists
+// and [ErrUnsupported] when there is no keyring on this system.
+func Delete(service, user string) error {
+	return del(service, user)
+}
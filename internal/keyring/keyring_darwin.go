@@ -0,0 +1,63 @@
+//go:build darwin && !ios
+
+package keyring
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// The Keychain is accessed with the security command which ships with macOS.
+const (
+	securityCommand        = "/usr/bin/security"
+	securityExitNotFound   = 44
+	securityMaxInteractive = 4096 // max line length in interactive mode
+)
+
+func get(service, user string) (string, error) {
+	var stdout bytes.Buffer
+	cmd := exec.Command(securityCommand, "find-generic-password", "-s", service, "-a", user, "-w")
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", convertError(err)
+	}
+	return strings.TrimSuffix(stdout.String(), "\n"), nil
+}
+
+func set(service, user, secret string) error {
+	// Passing the secret through stdin in interactive mode ensures it does not show up in the process list.
+	// The secret is hex encoded to avoid any issues with quoting.
+	line := fmt.Sprintf("add-generic-password -U -s %q -a %q -X %s\n", service, user, hex.EncodeToString([]byte(secret)))
+	if len(line) > securityMaxInteractive {
+		return fmt.Errorf("keyring: secret too long")
+	}
+	cmd := exec.Command(securityCommand, "-i")
+	cmd.Stdin = strings.NewReader(line)
+	if err := cmd.Run(); err != nil {
+		return convertError(err)
+	}
+	return nil
+}
+
+func del(service, user string) error {
+	cmd := exec.Command(securityCommand, "delete-generic-password", "-s", service, "-a", user)
+	if err := cmd.Run(); err != nil {
+		return convertError(err)
+	}
+	return nil
+}
+
+func convertError(err error) error {
+	if errors.Is(err, exec.ErrNotFound) {
+		return ErrUnsupported
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == securityExitNotFound {
+		return ErrNotFound
+	}
+	return err
+}
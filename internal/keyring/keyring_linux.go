@@ -0,0 +1,69 @@
+//go:build linux && !android
+
+package keyring
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// The Secret Service is accessed with secret-tool from libsecret,
+// which is available on most Linux desktops.
+const secretTool = "secret-tool"
+
+func get(service, user string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(secretTool, "lookup", "service", service, "username", user)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", convertError(err, stderr.String())
+	}
+	s := stdout.String()
+	if s == "" {
+		return "", ErrNotFound
+	}
+	return s, nil
+}
+
+func set(service, user, secret string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(secretTool, "store", "--label="+service, "service", service, "username", user)
+	cmd.Stdin = strings.NewReader(secret)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return convertError(err, stderr.String())
+	}
+	return nil
+}
+
+func del(service, user string) error {
+	if _, err := get(service, user); err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command(secretTool, "clear", "service", service, "username", user)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return convertError(err, stderr.String())
+	}
+	return nil
+}
+
+func convertError(err error, stderr string) error {
+	if errors.Is(err, exec.ErrNotFound) {
+		return ErrUnsupported
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && strings.TrimSpace(stderr) == "" {
+		return ErrNotFound // secret-tool exits without message when a secret does not exist
+	}
+	if stderr != "" {
+		// e.g. when no Secret Service provider is running
+		return fmt.Errorf("%w: %s", ErrUnsupported, strings.TrimSpace(stderr))
+	}
+	return err
+}
@@ -0,0 +1,110 @@
+//go:build windows
+
+package keyring
+
+import (
+	"errors"
+	"syscall"
+	"unsafe"
+)
+
+// The Credential Manager is accessed through the Windows API.
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+var (
+	advapi32       = syscall.NewLazyDLL("advapi32.dll")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+	procCredRead   = advapi32.NewProc("CredReadW")
+	procCredWrite  = advapi32.NewProc("CredWriteW")
+)
+
+// credential is the CREDENTIALW structure of the Windows API.
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+func get(service, user string) (string, error) {
+	target, err := syscall.UTF16PtrFromString(targetName(service, user))
+	if err != nil {
+		return "", err
+	}
+	var c *credential
+	r, _, err := procCredRead.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&c)))
+	if r == 0 {
+		return "", convertError(err)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(c)))
+	if c.CredentialBlobSize == 0 {
+		return "", nil
+	}
+	return string(unsafe.Slice(c.CredentialBlob, c.CredentialBlobSize)), nil
+}
+
+func set(service, user, secret string) error {
+	target, err := syscall.UTF16PtrFromString(targetName(service, user))
+	if err != nil {
+		return err
+	}
+	userName, err := syscall.UTF16PtrFromString(user)
+	if err != nil {
+		return err
+	}
+	blob := []byte(secret)
+	c := credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+		UserName:           userName,
+	}
+	if len(blob) > 0 {
+		c.CredentialBlob = &blob[0]
+	}
+	r, _, err := procCredWrite.Call(uintptr(unsafe.Pointer(&c)), 0)
+	if r == 0 {
+		return convertError(err)
+	}
+	return nil
+}
+
+func del(service, user string) error {
+	target, err := syscall.UTF16PtrFromString(targetName(service, user))
+	if err != nil {
+		return err
+	}
+	r, _, err := procCredDelete.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+	if r == 0 {
+		return convertError(err)
+	}
+	return nil
+}
+
+func targetName(service, user string) string {
+	return service + ":" + user
+}
+
+func convertError(err error) error {
+	if errors.Is(err, errorNotFound) {
+		return ErrNotFound
+	}
+	if err := advapi32.Load(); err != nil {
+		return ErrUnsupported
+	}
+	return err
+}
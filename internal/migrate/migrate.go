@@ -38,6 +38,43 @@ func Run(db *sql.DB, migrations MigrateFS) error {
 	return nil
 }
 
+// Unknown returns the names of applied migrations which are not included in migrations.
+// This is the case when a database was migrated by a newer version of the app.
+func Unknown(db *sql.DB, migrations MigrateFS) ([]string, error) {
+	isEmpty, err := isEmpty(db)
+	if err != nil {
+		return nil, err
+	}
+	if isEmpty {
+		return nil, nil
+	}
+	applied, err := listMigrationNames(db)
+	if err != nil {
+		return nil, err
+	}
+	c, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+	var known set.Set[string]
+	for _, entry := range c {
+		fn := entry.Name()
+		ext := filepath.Ext(fn)
+		if ext != ".sql" {
+			continue
+		}
+		known.Add(strings.TrimSuffix(fn, ext))
+	}
+	var unknown []string
+	for _, name := range applied {
+		if !known.Contains(name) {
+			unknown = append(unknown, name)
+		}
+	}
+	slices.Sort(unknown)
+	return unknown, nil
+}
+
 var createMigrationTrackingSQL = `
 CREATE TABLE migrations(
     id INTEGER PRIMARY KEY NOT NULL,
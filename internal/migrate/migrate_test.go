@@ -35,3 +35,44 @@ func TestMigrate(t *testing.T) {
 		}
 	})
 }
+
+func TestUnknown(t *testing.T) {
+	migrations := fstest.MapFS{
+		"migrations/0001_alpha.sql": &fstest.MapFile{
+			Data: []byte("CREATE TABLE alpha(id INTEGER NOT NULL);"),
+		},
+	}
+	t.Run("should return nothing when all migrations are known", func(t *testing.T) {
+		db := migrate.CreateTestDB()
+		if err := migrate.Run(db, migrations); err != nil {
+			t.Fatal(err)
+		}
+		got, err := migrate.Unknown(db, migrations)
+		if assert.NoError(t, err) {
+			assert.Empty(t, got)
+		}
+	})
+	t.Run("should return migrations from a newer version", func(t *testing.T) {
+		db := migrate.CreateTestDB()
+		newer := fstest.MapFS{
+			"migrations/0001_alpha.sql": migrations["migrations/0001_alpha.sql"],
+			"migrations/0002_bravo.sql": &fstest.MapFile{
+				Data: []byte("CREATE TABLE bravo(id INTEGER NOT NULL);"),
+			},
+		}
+		if err := migrate.Run(db, newer); err != nil {
+			t.Fatal(err)
+		}
+		got, err := migrate.Unknown(db, migrations)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"0002_bravo"}, got)
+		}
+	})
+	t.Run("should return nothing for an empty database", func(t *testing.T) {
+		db := migrate.CreateTestDB()
+		got, err := migrate.Unknown(db, migrations)
+		if assert.NoError(t, err) {
+			assert.Empty(t, got)
+		}
+	})
+}
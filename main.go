@@ -35,18 +35,25 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/ErikKalkoken/evebuddy/internal/app"
+	"github.com/ErikKalkoken/evebuddy/internal/app/backup"
 	"github.com/ErikKalkoken/evebuddy/internal/app/characterservice"
 	"github.com/ErikKalkoken/evebuddy/internal/app/corporationservice"
 	"github.com/ErikKalkoken/evebuddy/internal/app/esistatusservice"
 	"github.com/ErikKalkoken/evebuddy/internal/app/eveimageservice"
 	"github.com/ErikKalkoken/evebuddy/internal/app/evenotification"
 	"github.com/ErikKalkoken/evebuddy/internal/app/eveuniverseservice"
+	"github.com/ErikKalkoken/evebuddy/internal/app/export"
+	"github.com/ErikKalkoken/evebuddy/internal/app/localapi"
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationrule"
+	"github.com/ErikKalkoken/evebuddy/internal/app/notificationsink"
 	"github.com/ErikKalkoken/evebuddy/internal/app/pcache"
 	"github.com/ErikKalkoken/evebuddy/internal/app/settings"
 	"github.com/ErikKalkoken/evebuddy/internal/app/statuscache"
 	"github.com/ErikKalkoken/evebuddy/internal/app/storage"
+	"github.com/ErikKalkoken/evebuddy/internal/app/tokenkey"
 	"github.com/ErikKalkoken/evebuddy/internal/app/ui/core"
 	"github.com/ErikKalkoken/evebuddy/internal/deleteapp"
+	"github.com/ErikKalkoken/evebuddy/internal/everefservice"
 	"github.com/ErikKalkoken/evebuddy/internal/janiceservice"
 	"github.com/ErikKalkoken/evebuddy/internal/remoteservice"
 	"github.com/ErikKalkoken/evebuddy/internal/xgoesi"
@@ -55,26 +62,27 @@ import (
 )
 
 const (
-	appID               = "io.github.erikkalkoken.evebuddy"
-	appName             = "evebuddy"
-	appNameVerbose      = "EVE Buddy"
-	authClientID        = "11ae857fe4d149b2be60d875649c05f1"
-	authPort            = 30123
-	remotePort          = 30125
-	cacheCleanUpTimeout = time.Minute * 30
-	concurrentLimit     = 10 // max concurrent Goroutines per group
-	crashFileName       = "crash.txt"
-	dbFileName          = appName + ".sqlite"
-	logFileName         = appName + ".log"
-	logFolderName       = "log"
-	logLevelDefault     = slog.LevelWarn // for startup only
-	logMaxBackups       = 3
-	logMaxSizeMB        = 50
-	maxCPUShare         = 0.5
-	mutexDelay          = 100 * time.Millisecond
-	mutexTimeout        = 250 * time.Millisecond
-	sourceURL           = "https://github.com/ErikKalkoken/evebuddy"
-	userAgentEmail      = "kalkoken87@gmail.com"
+	appID                 = "io.github.erikkalkoken.evebuddy"
+	appName               = "evebuddy"
+	appNameVerbose        = "EVE Buddy"
+	authClientID          = "11ae857fe4d149b2be60d875649c05f1"
+	authPort              = 30123
+	remotePort            = 30125
+	cacheCleanUpTimeout   = time.Minute * 30
+	concurrentLimit       = 10 // max concurrent Goroutines per group
+	crashFileName         = "crash.txt"
+	dbFileName            = appName + ".sqlite"
+	logFileName           = appName + ".log"
+	logFolderName         = "log"
+	logLevelDefault       = slog.LevelWarn // for startup only
+	logMaxBackups         = 3
+	logMaxSizeMB          = 50
+	maxCPUShare           = 0.5
+	mutexDelay            = 100 * time.Millisecond
+	mutexTimeout          = 250 * time.Millisecond
+	notificationQueueSize = 100 // max notifications waiting for delivery to sinks
+	sourceURL             = "https://github.com/ErikKalkoken/evebuddy"
+	userAgentEmail        = "kalkoken87@gmail.com"
 )
 
 // define flags
@@ -100,6 +108,7 @@ func main() {
 	slog.SetLogLoggerLevel(logLevelDefault)
 	log.SetFlags(log.LstdFlags | log.Lmicroseconds)
 	flag.Parse()
+	isExport := flag.Arg(0) == export.CommandName
 
 	// set manual log level for this session if requested
 	if v := *logLevelFlag; v != "" {
@@ -154,6 +163,7 @@ func main() {
 		"log":       logFilePath,
 		"crashfile": crashFilePath,
 		"settings":  path.Join(fyneApp.Storage().RootURI().Path(), "preferences.json"),
+		"backups":   backup.Dir(dataDir),
 	}
 
 	if *filesFlag {
@@ -210,7 +220,7 @@ func main() {
 		return
 	}
 
-	if isDesktop {
+	if isDesktop && !isExport {
 		// ensure single instance
 		mu, err := ensureSingleInstance()
 		if errors.Is(err, mutex.ErrTimeout) {
@@ -257,6 +267,18 @@ func main() {
 		}()
 	}
 
+	// restore backup if requested
+	// This must only happen while holding the single instance lock,
+	// because the database of a running instance would be replaced otherwise.
+	if !isExport {
+		if ok, err := backup.ApplyPendingRestore(dataDir, dbPath, fyneApp.Preferences(), settings.Keys()); err != nil {
+			slog.Error("Failed to restore backup", "error", err)
+			os.Exit(1)
+		} else if ok {
+			slog.Info("Backup restored")
+		}
+	}
+
 	// init database
 	dsn := "file:///" + filepath.ToSlash(dbPath)
 	dbRW, dbRO, err := storage.InitDB(dsn)
@@ -268,6 +290,12 @@ func main() {
 	defer dbRO.Close()
 	st := storage.New(dbRW, dbRO)
 
+	// Load key for encrypting tokens
+	tk := tokenkey.New(tokenkey.Params{Storage: st})
+	if err := tk.Init(context.Background()); err != nil {
+		slog.Error("Failed to load token encryption key", "error", err)
+	}
+
 	// Initialize persistent cache
 	pc := pcache.New(st, cacheCleanUpTimeout)
 	defer pc.Close()
@@ -310,6 +338,18 @@ func main() {
 	rhc2.Logger = slog.Default()
 	rhc2.ResponseLogHook = xgoesi.LogResponse
 
+	// HTTP client for EVE Ref with HTTP caching and response logging.
+	// It is separate from the ESI client, so that its requests do not count against the ESI rate limits.
+	rhc3 := retryablehttp.NewClient()
+	rhc3.RetryWaitMax = 30 * time.Second
+	rhc3.RetryMax = 3
+	rhc3.HTTPClient.Transport = &httpcache.Transport{
+		Cache:               pcache.NewHTTPCacheAdapter(pc, "everefcache-", 24*time.Hour),
+		MarkCachedResponses: true,
+	}
+	rhc3.Logger = slog.Default()
+	rhc3.ResponseLogHook = xgoesi.LogResponse
+
 	// init shared objects
 	signals := app.NewSignals()
 	settings := settings.New(fyneApp.Preferences())
@@ -320,6 +360,19 @@ func main() {
 		slog.Error("Failed to init cache", "error", err)
 		os.Exit(1)
 	}
+	// Init backup service
+	bs := backup.New(backup.Params{
+		DB:              dbRO,
+		DataDir:         dataDir,
+		PreferencesPath: dataPaths["settings"],
+		Settings:        settings,
+	})
+
+	// Init notification sinks
+	webhook := notificationsink.NewWebhook(notificationsink.WebhookParams{
+		Settings: settings,
+	})
+
 	// Init EveUniverse service
 	eus := eveuniverseservice.New(eveuniverseservice.Params{
 		ConcurrencyLimit:   concurrentLimit,
@@ -349,36 +402,50 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	notificationSink := notificationsink.NewQueue(notificationsink.NewDispatcher(webhook), notificationQueueSize)
+	defer notificationSink.Close()
+	notificationRules := notificationrule.New(notificationrule.Params{
+		Cache:    pcache.NewServiceCacheAdapter(pc, "notificationrule-"),
+		Settings: settings,
+		Storage:  st,
+	})
+	sendDesktopNotification := func(title, content string) {
+		fyneApp.SendNotification(fyne.NewNotification(title, content))
+		slog.Info("desktop notification sent", "title", title, "content", content)
+	}
 	cs := characterservice.New(characterservice.Params{
-		AuthClient:             authClient,
-		Cache:                  pcache.NewServiceCacheAdapter(pc, "characterservice-"),
-		ConcurrencyLimit:       concurrentLimit,
-		ESIClient:              esiClient,
-		EveNotificationService: evenotification.New(eus),
-		EveUniverseService:     eus,
-		HTTPClient:             rhc1.StandardClient(),
-		Settings:               settings,
-		StatusCacheService:     scs,
-		Storage:                st,
-		Signals:                signals,
-		SendDesktopNotification: func(title, content string) {
-			fyneApp.SendNotification(fyne.NewNotification(title, content))
-			slog.Info("desktop notification sent", "title", title, "content", content)
-		},
+		AuthClient:              authClient,
+		Cache:                   pcache.NewServiceCacheAdapter(pc, "characterservice-"),
+		ConcurrencyLimit:        concurrentLimit,
+		DisableNotifications:    isExport,
+		ESIClient:               esiClient,
+		EveNotificationService:  evenotification.New(eus),
+		EveUniverseService:      eus,
+		HTTPClient:              rhc1.StandardClient(),
+		Settings:                settings,
+		StatusCacheService:      scs,
+		Storage:                 st,
+		Signals:                 signals,
+		NotificationRules:       notificationRules,
+		NotificationSink:        notificationSink,
+		SendDesktopNotification: sendDesktopNotification,
 	})
 
 	// Init Corporation service
 	rs := corporationservice.New(corporationservice.Params{
-		Cache:              pcache.NewServiceCacheAdapter(pc, "corporationservice-"),
-		CharacterService:   cs,
-		ConcurrencyLimit:   concurrentLimit,
-		ESIClient:          esiClient,
-		EveUniverseService: eus,
-		HTTPClient:         rhc1.StandardClient(),
-		Settings:           settings,
-		Signals:            signals,
-		StatusCacheService: scs,
-		Storage:            st,
+		Cache:                   pcache.NewServiceCacheAdapter(pc, "corporationservice-"),
+		CharacterService:        cs,
+		ConcurrencyLimit:        concurrentLimit,
+		ESIClient:               esiClient,
+		EveUniverseService:      eus,
+		HTTPClient:              rhc1.StandardClient(),
+		NotificationRules:       notificationRules,
+		NotificationSink:        notificationSink,
+		SendDesktopNotification: sendDesktopNotification,
+		Settings:                settings,
+		Signals:                 signals,
+		StatusCacheService:      scs,
+		Storage:                 st,
 	})
 
 	if *deleteCharactersNoConfirmFlag {
@@ -397,6 +464,35 @@ func main() {
 		return
 	}
 
+	// run headless export without UI
+	if isExport {
+		var lockInstance func() (func(), error)
+		if isDesktop {
+			lockInstance = func() (func(), error) {
+				mu, err := ensureSingleInstance()
+				if err != nil {
+					return nil, err
+				}
+				return mu.Release, nil
+			}
+		}
+		x := export.New(export.Params{
+			CharacterService:   cs,
+			EveUniverseService: eus,
+			LockInstance:       lockInstance,
+			TokenKey:           tk,
+		})
+		err := x.RunCommand(context.Background(), flag.Args()[1:], os.Stdout, os.Stderr)
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "export failed: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Init UI
 	os.Setenv("FYNE_SCALE", fmt.Sprint(appSettings.FyneScale()))
 	os.Setenv("FYNE_DISABLE_DPI_DETECTION", fmt.Sprint(appSettings.DisableDPIDetection()))
@@ -407,6 +503,7 @@ func main() {
 	slog.Info("Janice API key", "value", xstrings.Obfuscate(key, 4, 'X'))
 	params := core.UIParams{
 		App:              fyneApp,
+		Backup:           bs,
 		Character:        cs,
 		ClearCacheFunc:   func() { pc.Clear() },
 		ConcurrencyLimit: concurrentLimit,
@@ -415,6 +512,7 @@ func main() {
 		ESIStatus:        esistatusservice.New(esiClient),
 		EVEImage:         eveimageservice.New(pc, rhc2.StandardClient(), *offlineFlag),
 		EVEUniverse:      eus,
+		EveRef:           everefservice.New(rhc3.StandardClient()),
 		IsFakeMobile:     *mobileFlag,
 		IsMobile:         *mobileFlag || fyne.CurrentDevice().IsMobile(),
 		IsOfflineMode:    *offlineFlag,
@@ -423,6 +521,8 @@ func main() {
 		Settings:         settings,
 		Signals:          signals,
 		StatusCache:      scs,
+		TokenKey:         tk,
+		Webhook:          webhook,
 	}
 	if isDesktop {
 		u := core.NewDesktopUI(params)
@@ -435,6 +535,18 @@ func main() {
 			log.Fatal(err)
 		}
 		defer stop()
+		if settings.LocalAPIEnabled() {
+			x := localapi.New(localapi.Params{
+				CharacterService: cs,
+				Token:            settings.LocalAPIToken(),
+			})
+			stop, err := x.Start(settings.LocalAPIPort())
+			if err != nil {
+				slog.Error("Failed to start local API", "error", err)
+			} else {
+				defer stop()
+			}
+		}
 		u.ShowAndRun()
 	} else {
 		u := core.NewMobileUI(params)
@@ -75,11 +75,13 @@ $repoRoot   = Split-Path -Parent $scriptDir
 Set-Location $repoRoot
 Write-Success "Working directory: $repoRoot"
 
+$tags = "migrated_fynedo,sqlite_fts5"
+
 # ── Tests ─────────────────────────────────────────────────────────────────────
 
 if (-not $SkipTests) {
     Write-Step "Running tests"
-    go test -short ./...
+    go test -short -tags $tags ./...
     if ($LASTEXITCODE -ne 0) {
         Write-Fail "Tests failed. Fix failures or use -SkipTests to skip."
         exit 1
@@ -89,8 +91,6 @@ if (-not $SkipTests) {
 
 # ── Build ─────────────────────────────────────────────────────────────────────
 
-$tags = "migrated_fynedo"
-
 if ($Release) {
     Write-Step "Building release package (fyne package)"
     go tool fyne package --os windows --release --tags $tags